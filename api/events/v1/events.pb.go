@@ -0,0 +1,1132 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/events/v1/events.proto
+
+package eventsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventChange_Kind int32
+
+const (
+	EventChange_KIND_UNSPECIFIED EventChange_Kind = 0
+	EventChange_ADDED            EventChange_Kind = 1
+	EventChange_MODIFIED         EventChange_Kind = 2
+	EventChange_REMOVED          EventChange_Kind = 3
+)
+
+// Enum value maps for EventChange_Kind.
+var (
+	EventChange_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "ADDED",
+		2: "MODIFIED",
+		3: "REMOVED",
+	}
+	EventChange_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"ADDED":            1,
+		"MODIFIED":         2,
+		"REMOVED":          3,
+	}
+)
+
+func (x EventChange_Kind) Enum() *EventChange_Kind {
+	p := new(EventChange_Kind)
+	*p = x
+	return p
+}
+
+func (x EventChange_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventChange_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_events_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (EventChange_Kind) Type() protoreflect.EnumType {
+	return &file_api_events_v1_events_proto_enumTypes[0]
+}
+
+func (x EventChange_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventChange_Kind.Descriptor instead.
+func (EventChange_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type Event struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug           string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	City           string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Country        string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Address        string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude       string                 `protobuf:"bytes,8,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude      string                 `protobuf:"bytes,9,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Timezone       string                 `protobuf:"bytes,12,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Url            string                 `protobuf:"bytes,13,opt,name=url,proto3" json:"url,omitempty"`
+	Provider       string                 `protobuf:"bytes,14,opt,name=provider,proto3" json:"provider,omitempty"`
+	Price          float64                `protobuf:"fixed64,15,opt,name=price,proto3" json:"price,omitempty"`
+	ImageUrl       string                 `protobuf:"bytes,16,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Capacity       int32                  `protobuf:"varint,17,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	AttendeeCount  int32                  `protobuf:"varint,18,opt,name=attendee_count,json=attendeeCount,proto3" json:"attendee_count,omitempty"`
+	FavoritesCount int32                  `protobuf:"varint,19,opt,name=favorites_count,json=favoritesCount,proto3" json:"favorites_count,omitempty"`
+	ViewCount      int64                  `protobuf:"varint,20,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	Featured       bool                   `protobuf:"varint,21,opt,name=featured,proto3" json:"featured,omitempty"`
+	FeaturedUntil  *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=featured_until,json=featuredUntil,proto3" json:"featured_until,omitempty"`
+	HasTickets     bool                   `protobuf:"varint,23,opt,name=has_tickets,json=hasTickets,proto3" json:"has_tickets,omitempty"`
+	OrganizerId    string                 `protobuf:"bytes,24,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	OrganizerName  string                 `protobuf:"bytes,25,opt,name=organizer_name,json=organizerName,proto3" json:"organizer_name,omitempty"`
+	CreateTime     *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime     *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_api_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Event) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Event) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Event) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Event) GetLatitude() string {
+	if x != nil {
+		return x.Latitude
+	}
+	return ""
+}
+
+func (x *Event) GetLongitude() string {
+	if x != nil {
+		return x.Longitude
+	}
+	return ""
+}
+
+func (x *Event) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Event) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Event) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Event) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Event) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Event) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Event) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Event) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Event) GetAttendeeCount() int32 {
+	if x != nil {
+		return x.AttendeeCount
+	}
+	return 0
+}
+
+func (x *Event) GetFavoritesCount() int32 {
+	if x != nil {
+		return x.FavoritesCount
+	}
+	return 0
+}
+
+func (x *Event) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *Event) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Event) GetFeaturedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FeaturedUntil
+	}
+	return nil
+}
+
+func (x *Event) GetHasTickets() bool {
+	if x != nil {
+		return x.HasTickets
+	}
+	return false
+}
+
+func (x *Event) GetOrganizerId() string {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return ""
+}
+
+func (x *Event) GetOrganizerName() string {
+	if x != nil {
+		return x.OrganizerName
+	}
+	return ""
+}
+
+func (x *Event) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Event) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type EventInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Capacity      int32                  `protobuf:"varint,7,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	OrganizerId   string                 `protobuf:"bytes,8,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	Url           string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,10,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventInput) Reset() {
+	*x = EventInput{}
+	mi := &file_api_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventInput) ProtoMessage() {}
+
+func (x *EventInput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventInput.ProtoReflect.Descriptor instead.
+func (*EventInput) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventInput) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *EventInput) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EventInput) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *EventInput) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *EventInput) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *EventInput) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *EventInput) GetOrganizerId() string {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return ""
+}
+
+func (x *EventInput) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EventInput) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type ListEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	OrganizerId   string                 `protobuf:"bytes,4,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	MinPrice      *float64               `protobuf:"fixed64,5,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice      *float64               `protobuf:"fixed64,6,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	SortKey       string                 `protobuf:"bytes,9,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
+	SortDir       string                 `protobuf:"bytes,10,opt,name=sort_dir,json=sortDir,proto3" json:"sort_dir,omitempty"`
+	PageSize      int32                  `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListEventsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetOrganizerId() string {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetMinPrice() float64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetMaxPrice() float64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetSortKey() string {
+	if x != nil {
+		return x.SortKey
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetSortDir() string {
+	if x != nil {
+		return x.SortDir
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	PrevPageToken string                 `protobuf:"bytes,3,opt,name=prev_page_token,json=prevPageToken,proto3" json:"prev_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_api_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListEventsResponse) GetPrevPageToken() string {
+	if x != nil {
+		return x.PrevPageToken
+	}
+	return ""
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *EventInput            `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEventRequest) Reset() {
+	*x = CreateEventRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEventRequest) ProtoMessage() {}
+
+func (x *CreateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEventRequest.ProtoReflect.Descriptor instead.
+func (*CreateEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateEventRequest) GetEvent() *EventInput {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type UpdateEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event         *EventInput            `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventRequest) Reset() {
+	*x = UpdateEventRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventRequest) ProtoMessage() {}
+
+func (x *UpdateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEventRequest) GetEvent() *EventInput {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventRequest) ProtoMessage() {}
+
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_api_events_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchEventsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type EventChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          EventChange_Kind       `protobuf:"varint,1,opt,name=kind,proto3,enum=bibently.events.v1.EventChange_Kind" json:"kind,omitempty"`
+	Event         *Event                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventChange) Reset() {
+	*x = EventChange{}
+	mi := &file_api_events_v1_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventChange) ProtoMessage() {}
+
+func (x *EventChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_events_v1_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventChange.ProtoReflect.Descriptor instead.
+func (*EventChange) Descriptor() ([]byte, []int) {
+	return file_api_events_v1_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EventChange) GetKind() EventChange_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return EventChange_KIND_UNSPECIFIED
+}
+
+func (x *EventChange) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_api_events_v1_events_proto protoreflect.FileDescriptor
+
+const file_api_events_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/events/v1/events.proto\x12\x12bibently.events.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\a\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12\x1a\n" +
+	"\blatitude\x18\b \x01(\tR\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\t \x01(\tR\tlongitude\x129\n" +
+	"\n" +
+	"start_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1a\n" +
+	"\btimezone\x18\f \x01(\tR\btimezone\x12\x10\n" +
+	"\x03url\x18\r \x01(\tR\x03url\x12\x1a\n" +
+	"\bprovider\x18\x0e \x01(\tR\bprovider\x12\x14\n" +
+	"\x05price\x18\x0f \x01(\x01R\x05price\x12\x1b\n" +
+	"\timage_url\x18\x10 \x01(\tR\bimageUrl\x12\x1a\n" +
+	"\bcapacity\x18\x11 \x01(\x05R\bcapacity\x12%\n" +
+	"\x0eattendee_count\x18\x12 \x01(\x05R\rattendeeCount\x12'\n" +
+	"\x0ffavorites_count\x18\x13 \x01(\x05R\x0efavoritesCount\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x14 \x01(\x03R\tviewCount\x12\x1a\n" +
+	"\bfeatured\x18\x15 \x01(\bR\bfeatured\x12A\n" +
+	"\x0efeatured_until\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\rfeaturedUntil\x12\x1f\n" +
+	"\vhas_tickets\x18\x17 \x01(\bR\n" +
+	"hasTickets\x12!\n" +
+	"\forganizer_id\x18\x18 \x01(\tR\vorganizerId\x12%\n" +
+	"\x0eorganizer_name\x18\x19 \x01(\tR\rorganizerName\x12;\n" +
+	"\vcreate_time\x18\x1a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x1b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\xbe\x02\n" +
+	"\n" +
+	"EventInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1a\n" +
+	"\bcapacity\x18\a \x01(\x05R\bcapacity\x12!\n" +
+	"\forganizer_id\x18\b \x01(\tR\vorganizerId\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x12\x1b\n" +
+	"\timage_url\x18\n" +
+	" \x01(\tR\bimageUrl\"\xb6\x03\n" +
+	"\x11ListEventsRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\forganizer_id\x18\x04 \x01(\tR\vorganizerId\x12 \n" +
+	"\tmin_price\x18\x05 \x01(\x01H\x00R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\x06 \x01(\x01H\x01R\bmaxPrice\x88\x01\x01\x129\n" +
+	"\n" +
+	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x19\n" +
+	"\bsort_key\x18\t \x01(\tR\asortKey\x12\x19\n" +
+	"\bsort_dir\x18\n" +
+	" \x01(\tR\asortDir\x12\x1b\n" +
+	"\tpage_size\x18\v \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tR\tpageTokenB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_price\"\x97\x01\n" +
+	"\x12ListEventsResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.bibently.events.v1.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12&\n" +
+	"\x0fprev_page_token\x18\x03 \x01(\tR\rprevPageToken\"!\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\x12CreateEventRequest\x124\n" +
+	"\x05event\x18\x01 \x01(\v2\x1e.bibently.events.v1.EventInputR\x05event\"\x97\x01\n" +
+	"\x12UpdateEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\x05event\x18\x02 \x01(\v2\x1e.bibently.events.v1.EventInputR\x05event\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"$\n" +
+	"\x12DeleteEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x12WatchEventsRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\xbc\x01\n" +
+	"\vEventChange\x128\n" +
+	"\x04kind\x18\x01 \x01(\x0e2$.bibently.events.v1.EventChange.KindR\x04kind\x12/\n" +
+	"\x05event\x18\x02 \x01(\v2\x19.bibently.events.v1.EventR\x05event\"B\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\f\n" +
+	"\bMODIFIED\x10\x02\x12\v\n" +
+	"\aREMOVED\x10\x032\x84\x04\n" +
+	"\fEventService\x12[\n" +
+	"\n" +
+	"ListEvents\x12%.bibently.events.v1.ListEventsRequest\x1a&.bibently.events.v1.ListEventsResponse\x12J\n" +
+	"\bGetEvent\x12#.bibently.events.v1.GetEventRequest\x1a\x19.bibently.events.v1.Event\x12P\n" +
+	"\vCreateEvent\x12&.bibently.events.v1.CreateEventRequest\x1a\x19.bibently.events.v1.Event\x12P\n" +
+	"\vUpdateEvent\x12&.bibently.events.v1.UpdateEventRequest\x1a\x19.bibently.events.v1.Event\x12M\n" +
+	"\vDeleteEvent\x12&.bibently.events.v1.DeleteEventRequest\x1a\x16.google.protobuf.Empty\x12X\n" +
+	"\vWatchEvents\x12&.bibently.events.v1.WatchEventsRequest\x1a\x1f.bibently.events.v1.EventChange0\x01B-Z+bibently.com/backend/api/events/v1;eventsv1b\x06proto3"
+
+var (
+	file_api_events_v1_events_proto_rawDescOnce sync.Once
+	file_api_events_v1_events_proto_rawDescData []byte
+)
+
+func file_api_events_v1_events_proto_rawDescGZIP() []byte {
+	file_api_events_v1_events_proto_rawDescOnce.Do(func() {
+		file_api_events_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_events_v1_events_proto_rawDesc), len(file_api_events_v1_events_proto_rawDesc)))
+	})
+	return file_api_events_v1_events_proto_rawDescData
+}
+
+var file_api_events_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_events_v1_events_proto_goTypes = []any{
+	(EventChange_Kind)(0),         // 0: bibently.events.v1.EventChange.Kind
+	(*Event)(nil),                 // 1: bibently.events.v1.Event
+	(*EventInput)(nil),            // 2: bibently.events.v1.EventInput
+	(*ListEventsRequest)(nil),     // 3: bibently.events.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),    // 4: bibently.events.v1.ListEventsResponse
+	(*GetEventRequest)(nil),       // 5: bibently.events.v1.GetEventRequest
+	(*CreateEventRequest)(nil),    // 6: bibently.events.v1.CreateEventRequest
+	(*UpdateEventRequest)(nil),    // 7: bibently.events.v1.UpdateEventRequest
+	(*DeleteEventRequest)(nil),    // 8: bibently.events.v1.DeleteEventRequest
+	(*WatchEventsRequest)(nil),    // 9: bibently.events.v1.WatchEventsRequest
+	(*EventChange)(nil),           // 10: bibently.events.v1.EventChange
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 13: google.protobuf.Empty
+}
+var file_api_events_v1_events_proto_depIdxs = []int32{
+	11, // 0: bibently.events.v1.Event.start_time:type_name -> google.protobuf.Timestamp
+	11, // 1: bibently.events.v1.Event.end_time:type_name -> google.protobuf.Timestamp
+	11, // 2: bibently.events.v1.Event.featured_until:type_name -> google.protobuf.Timestamp
+	11, // 3: bibently.events.v1.Event.create_time:type_name -> google.protobuf.Timestamp
+	11, // 4: bibently.events.v1.Event.update_time:type_name -> google.protobuf.Timestamp
+	11, // 5: bibently.events.v1.EventInput.start_time:type_name -> google.protobuf.Timestamp
+	11, // 6: bibently.events.v1.EventInput.end_time:type_name -> google.protobuf.Timestamp
+	11, // 7: bibently.events.v1.ListEventsRequest.start_time:type_name -> google.protobuf.Timestamp
+	11, // 8: bibently.events.v1.ListEventsRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 9: bibently.events.v1.ListEventsResponse.events:type_name -> bibently.events.v1.Event
+	2,  // 10: bibently.events.v1.CreateEventRequest.event:type_name -> bibently.events.v1.EventInput
+	2,  // 11: bibently.events.v1.UpdateEventRequest.event:type_name -> bibently.events.v1.EventInput
+	12, // 12: bibently.events.v1.UpdateEventRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 13: bibently.events.v1.EventChange.kind:type_name -> bibently.events.v1.EventChange.Kind
+	1,  // 14: bibently.events.v1.EventChange.event:type_name -> bibently.events.v1.Event
+	3,  // 15: bibently.events.v1.EventService.ListEvents:input_type -> bibently.events.v1.ListEventsRequest
+	5,  // 16: bibently.events.v1.EventService.GetEvent:input_type -> bibently.events.v1.GetEventRequest
+	6,  // 17: bibently.events.v1.EventService.CreateEvent:input_type -> bibently.events.v1.CreateEventRequest
+	7,  // 18: bibently.events.v1.EventService.UpdateEvent:input_type -> bibently.events.v1.UpdateEventRequest
+	8,  // 19: bibently.events.v1.EventService.DeleteEvent:input_type -> bibently.events.v1.DeleteEventRequest
+	9,  // 20: bibently.events.v1.EventService.WatchEvents:input_type -> bibently.events.v1.WatchEventsRequest
+	4,  // 21: bibently.events.v1.EventService.ListEvents:output_type -> bibently.events.v1.ListEventsResponse
+	1,  // 22: bibently.events.v1.EventService.GetEvent:output_type -> bibently.events.v1.Event
+	1,  // 23: bibently.events.v1.EventService.CreateEvent:output_type -> bibently.events.v1.Event
+	1,  // 24: bibently.events.v1.EventService.UpdateEvent:output_type -> bibently.events.v1.Event
+	13, // 25: bibently.events.v1.EventService.DeleteEvent:output_type -> google.protobuf.Empty
+	10, // 26: bibently.events.v1.EventService.WatchEvents:output_type -> bibently.events.v1.EventChange
+	21, // [21:27] is the sub-list for method output_type
+	15, // [15:21] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_events_v1_events_proto_init() }
+func file_api_events_v1_events_proto_init() {
+	if File_api_events_v1_events_proto != nil {
+		return
+	}
+	file_api_events_v1_events_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_events_v1_events_proto_rawDesc), len(file_api_events_v1_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_events_v1_events_proto_goTypes,
+		DependencyIndexes: file_api_events_v1_events_proto_depIdxs,
+		EnumInfos:         file_api_events_v1_events_proto_enumTypes,
+		MessageInfos:      file_api_events_v1_events_proto_msgTypes,
+	}.Build()
+	File_api_events_v1_events_proto = out.File
+	file_api_events_v1_events_proto_goTypes = nil
+	file_api_events_v1_events_proto_depIdxs = nil
+}
@@ -0,0 +1,1539 @@
+// Code generated by cmd/genclient from the OpenAPI document; DO NOT EDIT.
+
+package bibently
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type ArchiveResult struct {
+	Archived  int64     `json:"archived"`
+	Cutoff    time.Time `json:"cutoff"`
+	Remaining bool      `json:"remaining"`
+}
+
+type AuditEntry struct {
+	Actor      string                            `json:"actor"`
+	Changes    map[string]map[string]FieldChange `json:"changes,omitempty"`
+	CreatedAt  time.Time                         `json:"created_at"`
+	ID         string                            `json:"id"`
+	Method     string                            `json:"method"`
+	Path       string                            `json:"path"`
+	Resource   string                            `json:"resource"`
+	ResourceID *string                           `json:"resource_id,omitempty"`
+	Status     int64                             `json:"status"`
+	Truncated  *bool                             `json:"truncated,omitempty"`
+}
+
+type BackupOperation struct {
+	Collections    []string   `json:"collections,omitempty"`
+	Documents      int64      `json:"documents"`
+	Done           bool       `json:"done"`
+	EndTime        *time.Time `json:"end_time,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	EstimatedTotal int64      `json:"estimated_total"`
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	State          string     `json:"state"`
+	URI            string     `json:"uri"`
+}
+
+type BackupRecord struct {
+	Collection string         `json:"collection"`
+	Event      *Event         `json:"event,omitempty"`
+	ID         string         `json:"id"`
+	Tracking   *TrackingEvent `json:"tracking,omitempty"`
+}
+
+type BackupRequest struct {
+	Collections []string `json:"collections"`
+}
+
+type BatchCreateResult struct {
+	Created []BatchItemResult `json:"created"`
+	Failed  []BatchItemResult `json:"failed"`
+}
+
+type BatchEventRequest struct {
+	Events []EventDTO `json:"events"`
+}
+
+type BatchItemResult struct {
+	Error *string `json:"error,omitempty"`
+	ID    *string `json:"id,omitempty"`
+	Index int64   `json:"index"`
+}
+
+type BuildinfoInfo struct {
+	BuildTime string `json:"build_time"`
+	GitSha    string `json:"git_sha"`
+	GoVersion string `json:"go_version"`
+	Modified  *bool  `json:"modified,omitempty"`
+}
+
+type CurrentUser struct {
+	Claims        map[string]any `json:"claims"`
+	Email         *string        `json:"email,omitempty"`
+	EmailVerified bool           `json:"email_verified"`
+	Profile       map[string]any `json:"profile,omitempty"`
+	Roles         []string       `json:"roles"`
+	UID           string         `json:"uid"`
+}
+
+type DataDeletionReport struct {
+	Favorites      int64  `json:"favorites"`
+	Rsvps          int64  `json:"rsvps"`
+	TrackingEvents int64  `json:"tracking_events"`
+	UserID         string `json:"user_id"`
+}
+
+type DependencyHealth struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Status    string  `json:"status"`
+}
+
+type Error struct {
+	// Machine-readable code
+	Code *string `json:"code,omitempty"`
+	// Details, e.g. the Id of the existing event on duplicate_event
+	Data any `json:"data,omitempty"`
+	// Human-readable message
+	Error string `json:"error"`
+	// Id of the request in the logs
+	RequestID *string `json:"request_id,omitempty"`
+}
+
+type Event struct {
+	AttendeeCount   int64         `json:"attendee_count"`
+	Capacity        int64         `json:"capacity"`
+	City            string        `json:"city"`
+	CityLc          string        `json:"city_lc"`
+	Country         string        `json:"country"`
+	CreatedAt       time.Time     `json:"created_at"`
+	CreatedBy       string        `json:"created_by"`
+	DedupKey        string        `json:"dedup_key"`
+	Description     string        `json:"description"`
+	EndTime         time.Time     `json:"end_time"`
+	EventName       string        `json:"event_name"`
+	EventNameLc     string        `json:"event_name_lc"`
+	EventURL        string        `json:"event_url"`
+	FavoritesCount  int64         `json:"favorites_count"`
+	Featured        bool          `json:"featured"`
+	FeaturedUntil   time.Time     `json:"featured_until"`
+	FullAddress     string        `json:"full_address"`
+	HasTickets      bool          `json:"has_tickets"`
+	ID              string        `json:"id"`
+	ImageURL        string        `json:"image_url"`
+	Location        *LatlngLatLng `json:"location"`
+	OrganizerID     string        `json:"organizer_id"`
+	OrganizerName   string        `json:"organizer_name"`
+	PopularityScore float64       `json:"popularity_score"`
+	Price           float64       `json:"price"`
+	Provider        string        `json:"provider"`
+	Slug            string        `json:"slug"`
+	StartTime       time.Time     `json:"start_time"`
+	State           string        `json:"state"`
+	Street          string        `json:"street"`
+	Timezone        string        `json:"timezone"`
+	Type            EventType     `json:"type"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	UpdatedBy       string        `json:"updated_by"`
+	ViewCount       int64         `json:"view_count"`
+	Visibility      string        `json:"visibility"`
+}
+
+type EventDTO struct {
+	Capacity    *int64       `json:"capacity,omitempty"`
+	City        string       `json:"city"`
+	Description *string      `json:"description,omitempty"`
+	EndTime     *time.Time   `json:"end_time,omitempty"`
+	EventName   string       `json:"event_name"`
+	EventURL    *string      `json:"event_url,omitempty"`
+	ImageURL    *string      `json:"image_url,omitempty"`
+	Location    *GeoPointDTO `json:"location,omitempty"`
+	OrganizerID *string      `json:"organizer_id,omitempty"`
+	Price       *float64     `json:"price,omitempty"`
+	StartTime   time.Time    `json:"start_time"`
+	Type        EventType    `json:"type"`
+	Visibility  *string      `json:"visibility,omitempty"`
+}
+
+type EventResponse struct {
+	AttendeeCount   int64      `json:"attendee_count"`
+	Capacity        int64      `json:"capacity"`
+	City            string     `json:"city"`
+	Country         string     `json:"country"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Description     string     `json:"description"`
+	EndTime         *time.Time `json:"end_time,omitempty"`
+	EventName       string     `json:"event_name"`
+	EventURL        string     `json:"event_url"`
+	FavoritesCount  int64      `json:"favorites_count"`
+	Featured        bool       `json:"featured"`
+	FeaturedUntil   *time.Time `json:"featured_until,omitempty"`
+	FullAddress     string     `json:"full_address"`
+	HasTickets      bool       `json:"has_tickets"`
+	ID              string     `json:"id"`
+	ImageURL        string     `json:"image_url"`
+	IsFree          bool       `json:"is_free"`
+	IsPast          bool       `json:"is_past"`
+	Location        *GeoPoint  `json:"location"`
+	OrganizerID     string     `json:"organizer_id"`
+	OrganizerName   string     `json:"organizer_name"`
+	PopularityScore float64    `json:"popularity_score"`
+	Price           float64    `json:"price"`
+	Provider        string     `json:"provider"`
+	Slug            string     `json:"slug"`
+	StartTime       time.Time  `json:"start_time"`
+	State           string     `json:"state"`
+	Street          string     `json:"street"`
+	Timezone        string     `json:"timezone"`
+	Type            EventType  `json:"type"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ViewCount       int64      `json:"view_count"`
+	Visibility      string     `json:"visibility"`
+}
+
+type EventRevision struct {
+	Action    string                 `json:"action"`
+	Actor     string                 `json:"actor"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+	EventID   string                 `json:"event_id"`
+	ID        string                 `json:"id"`
+}
+
+type EventStats struct {
+	AvgPrice   float64 `json:"avg_price"`
+	Count      int64   `json:"count"`
+	Group      string  `json:"group"`
+	MaxPrice   float64 `json:"max_price"`
+	MinPrice   float64 `json:"min_price"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+type EventSummary struct {
+	AvgPrice   float64 `json:"avg_price"`
+	Count      int64   `json:"count"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+// EventType one of the event types configured for the deployment, as listed by GET /events/types
+type EventType = string
+
+type Favorite struct {
+	CreatedAt time.Time `json:"created_at"`
+	EventID   string    `json:"event_id"`
+	UserID    string    `json:"user_id"`
+}
+
+type FeatureEventDTO struct {
+	Featured      *bool      `json:"featured,omitempty"`
+	FeaturedUntil *time.Time `json:"featured_until,omitempty"`
+}
+
+type FieldChange struct {
+	New any `json:"new"`
+	Old any `json:"old"`
+}
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type GeoPointDTO struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+type GraphqlError struct {
+	Extensions map[string]any `json:"extensions,omitempty"`
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+}
+
+type GraphqlRequest struct {
+	OperationName *string        `json:"operationName,omitempty"`
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type GraphqlResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []GraphqlError `json:"errors,omitempty"`
+}
+
+type HealthReport struct {
+	Checks map[string]DependencyHealth `json:"checks,omitempty"`
+	Status string                      `json:"status"`
+}
+
+type ImportJob struct {
+	Created     int64             `json:"created"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Errors      []ImportRowResult `json:"errors"`
+	Failed      int64             `json:"failed"`
+	ID          string            `json:"id"`
+	Message     *string           `json:"message,omitempty"`
+	Status      string            `json:"status"`
+	Total       int64             `json:"total"`
+	TotalChunks int64             `json:"total_chunks"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type ImportReport struct {
+	Created int64             `json:"created"`
+	Failed  int64             `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+	Total   int64             `json:"total"`
+}
+
+type ImportRowResult struct {
+	Error   *string `json:"error,omitempty"`
+	EventID *string `json:"event_id,omitempty"`
+	Row     int64   `json:"row"`
+	Success bool    `json:"success"`
+}
+
+type LatlngLatLng struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+type Meta struct {
+	Fields        []string `json:"fields,omitempty"`
+	NextPageToken *string  `json:"next_page_token,omitempty"`
+	PrevPageToken *string  `json:"prev_page_token,omitempty"`
+}
+
+type Organizer struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	Email       string    `json:"email"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Website     string    `json:"website"`
+}
+
+type OrganizerDTO struct {
+	Description *string `json:"description,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Name        string  `json:"name"`
+	Website     *string `json:"website,omitempty"`
+}
+
+type PriceBucket struct {
+	Count int64    `json:"count"`
+	Max   *float64 `json:"max"`
+	Min   float64  `json:"min"`
+}
+
+type PurgeResult struct {
+	Cutoff    *time.Time `json:"cutoff,omitempty"`
+	Purged    int64      `json:"purged"`
+	Remaining bool       `json:"remaining"`
+}
+
+type PushSubscription struct {
+	City      string     `json:"city"`
+	CreatedAt time.Time  `json:"created_at"`
+	ID        string     `json:"id"`
+	Type      *EventType `json:"type,omitempty"`
+	UserID    string     `json:"user_id"`
+}
+
+type PushSubscriptionDTO struct {
+	City  string     `json:"city"`
+	Token string     `json:"token"`
+	Type  *EventType `json:"type,omitempty"`
+}
+
+type RSVP struct {
+	CreatedAt time.Time `json:"created_at"`
+	Email     string    `json:"email"`
+	EventID   string    `json:"event_id"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+}
+
+type RestoreFailure struct {
+	Error string  `json:"error"`
+	ID    *string `json:"id,omitempty"`
+	Line  int64   `json:"line"`
+}
+
+type RestoreRequest struct {
+	Collections []string `json:"collections,omitempty"`
+	Source      string   `json:"source"`
+}
+
+type RestoreResult struct {
+	Failed   []RestoreFailure `json:"failed"`
+	Restored int64            `json:"restored"`
+	Skipped  int64            `json:"skipped"`
+	Stopped  bool             `json:"stopped"`
+}
+
+type SeedRequest struct {
+	Cities           []string  `json:"cities"`
+	Events           int64     `json:"events"`
+	From             time.Time `json:"from"`
+	Seed             int64     `json:"seed"`
+	To               time.Time `json:"to"`
+	TrackingPerEvent int64     `json:"tracking_per_event"`
+}
+
+type SeedResult struct {
+	Events   int64 `json:"events"`
+	Failed   int64 `json:"failed"`
+	Tracking int64 `json:"tracking"`
+}
+
+type Subscription struct {
+	Cities     []string  `json:"cities"`
+	Email      *string   `json:"email,omitempty"`
+	EmailOptIn bool      `json:"email_opt_in"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UserID     string    `json:"user_id"`
+}
+
+type SubscriptionDTO struct {
+	Cities     []string `json:"cities,omitempty"`
+	EmailOptIn *bool    `json:"email_opt_in,omitempty"`
+}
+
+type TicketTier struct {
+	CreatedAt  time.Time `json:"created_at"`
+	EventID    string    `json:"event_id"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Price      float64   `json:"price"`
+	Quantity   int64     `json:"quantity"`
+	SalesEnd   time.Time `json:"sales_end"`
+	SalesStart time.Time `json:"sales_start"`
+}
+
+type TicketTierDTO struct {
+	Name       string     `json:"name"`
+	Price      *float64   `json:"price,omitempty"`
+	Quantity   *int64     `json:"quantity,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+}
+
+type TrackingEvent struct {
+	Action        string     `json:"action"`
+	ClientEventID *string    `json:"client_event_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EventID       *string    `json:"event_id,omitempty"`
+	ExpireAt      *time.Time `json:"expire_at,omitempty"`
+	ID            string     `json:"id"`
+	Payload       string     `json:"payload"`
+	SessionID     *string    `json:"session_id,omitempty"`
+	UserAgent     string     `json:"user_agent"`
+	UserID        *string    `json:"user_id,omitempty"`
+	UserName      string     `json:"user_name"`
+}
+
+type TrackingEventDTO struct {
+	Action        string  `json:"action"`
+	ClientEventID *string `json:"client_event_id,omitempty"`
+	EventID       *string `json:"event_id,omitempty"`
+	Payload       *string `json:"payload,omitempty"`
+	SessionID     *string `json:"session_id,omitempty"`
+	UserAgent     *string `json:"user_agent,omitempty"`
+	UserName      *string `json:"user_name,omitempty"`
+}
+
+type TrackingEventResponse struct {
+	Action        string    `json:"action"`
+	ClientEventID *string   `json:"client_event_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	EventID       *string   `json:"event_id,omitempty"`
+	ID            string    `json:"id"`
+	Payload       string    `json:"payload"`
+	SessionID     *string   `json:"session_id,omitempty"`
+	UserAgent     string    `json:"user_agent"`
+	UserID        *string   `json:"user_id,omitempty"`
+	UserName      string    `json:"user_name"`
+}
+
+type TrackingStats struct {
+	Counts map[string]int64 `json:"counts"`
+	Start  time.Time        `json:"start"`
+	Total  int64            `json:"total"`
+}
+
+type UpdateEventDTO struct {
+	Capacity    *int64       `json:"capacity,omitempty"`
+	City        *string      `json:"city,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	EndTime     *time.Time   `json:"end_time,omitempty"`
+	EventName   *string      `json:"event_name,omitempty"`
+	EventURL    *string      `json:"event_url,omitempty"`
+	ImageURL    *string      `json:"image_url,omitempty"`
+	Location    *GeoPointDTO `json:"location,omitempty"`
+	OrganizerID *string      `json:"organizer_id,omitempty"`
+	Price       *float64     `json:"price,omitempty"`
+	StartTime   *time.Time   `json:"start_time,omitempty"`
+	Type        *string      `json:"type,omitempty"`
+	Visibility  *string      `json:"visibility,omitempty"`
+}
+
+type UpdateOrganizerDTO struct {
+	Description *string `json:"description,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Website     *string `json:"website,omitempty"`
+}
+
+// ArchivePastEventsParams are the query and header parameters of ArchivePastEvents
+type ArchivePastEventsParams struct {
+	// Minimum age in days since the event ended
+	OlderThanDays *int64
+}
+
+func (p *ArchivePastEventsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.OlderThanDays != nil {
+		query.Set("older_than_days", fmt.Sprint(*p.OlderThanDays))
+	}
+	return query, header
+}
+
+// ArchivePastEvents is POST /v1/admin/archive-past-events (Archive Past Events).
+// Move events that ended more than older_than_days ago (default 30) to events_archive. Repeat
+// while "remaining" is true.
+func (c *Client) ArchivePastEvents(ctx context.Context, params *ArchivePastEventsParams) (*ArchiveResult, error) {
+	query, header := params.encode()
+	var out envelope[ArchiveResult]
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/archive-past-events", query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ListAuditLogsParams are the query and header parameters of ListAuditLogs
+type ListAuditLogsParams struct {
+	// Filter by actor UID
+	Actor *string
+	// Filter by resource (e.g. events, organizers)
+	Resource *string
+	// Filter by resource Id
+	ResourceID *string
+	// Maximum number of entries (default 50, max 500)
+	Limit *int64
+}
+
+func (p *ListAuditLogsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.Actor != nil {
+		query.Set("actor", fmt.Sprint(*p.Actor))
+	}
+	if p.Resource != nil {
+		query.Set("resource", fmt.Sprint(*p.Resource))
+	}
+	if p.ResourceID != nil {
+		query.Set("resource_id", fmt.Sprint(*p.ResourceID))
+	}
+	if p.Limit != nil {
+		query.Set("limit", fmt.Sprint(*p.Limit))
+	}
+	return query, header
+}
+
+// ListAuditLogs is GET /v1/admin/audit-logs (List Audit Logs).
+// Write operations with actor, target, outcome and (for events) old-vs-new field values.
+func (c *Client) ListAuditLogs(ctx context.Context, params *ListAuditLogsParams) ([]AuditEntry, error) {
+	query, header := params.encode()
+	var out envelope[[]AuditEntry]
+	err := c.do(ctx, http.MethodGet, "/v1/admin/audit-logs", query, header, nil, &out)
+	return out.Data, err
+}
+
+// StartBackup is POST /v1/admin/backups (Start Backup).
+// Starts a Firestore managed export to a new folder of BACKUP_BUCKET and returns the operation;
+// poll it until done. Only routed when BACKUP_BUCKET is set.
+func (c *Client) StartBackup(ctx context.Context, body *BackupRequest) (*BackupOperation, error) {
+	var payload any
+	if body != nil {
+		payload = body
+	}
+	var out envelope[BackupOperation]
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/backups", nil, nil, payload, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// DumpDatabaseParams are the query and header parameters of DumpDatabase
+type DumpDatabaseParams struct {
+	// Comma-separated collections (events, tracking); default both
+	Collections *string
+}
+
+func (p *DumpDatabaseParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.Collections != nil {
+		query.Set("collections", fmt.Sprint(*p.Collections))
+	}
+	return query, header
+}
+
+// DumpDatabase is GET /v1/admin/backups/dump (Dump Database).
+// Streams every event and tracking document as newline-delimited records, which POST
+// /admin/backups/dump restores. Meant for small databases and moving data between environments;
+// use POST /admin/backups for full backups. The caller reads and closes the application/x-ndjson
+// response body.
+func (c *Client) DumpDatabase(ctx context.Context, params *DumpDatabaseParams) (*http.Response, error) {
+	query, header := params.encode()
+	header.Set("Accept", "application/x-ndjson")
+	return c.send(ctx, http.MethodGet, "/v1/admin/backups/dump", query, header, nil)
+}
+
+// RestoreDumpParams are the query and header parameters of RestoreDump
+type RestoreDumpParams struct {
+	// What to do with records whose document exists
+	OnConflict *string
+}
+
+func (p *RestoreDumpParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.OnConflict != nil {
+		query.Set("on_conflict", fmt.Sprint(*p.OnConflict))
+	}
+	return query, header
+}
+
+// RestoreDump is POST /v1/admin/backups/dump (Restore Dump).
+// Restores a dump produced by GET /admin/backups/dump. on_conflict decides what happens to records
+// whose document exists: skip it (default), overwrite it, or fail, which stops after the current
+// batch of 500. Invalid lines are reported by line number; restored tracking is not added to the
+// stats rollups.
+func (c *Client) RestoreDump(ctx context.Context, body io.Reader, params *RestoreDumpParams) (*RestoreResult, error) {
+	query, header := params.encode()
+	header.Set("Content-Type", "application/x-ndjson")
+	var out envelope[RestoreResult]
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/backups/dump", query, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// GetBackupOperation is GET /v1/admin/backups/operations/{id} (Get Backup Operation).
+func (c *Client) GetBackupOperation(ctx context.Context, id string) (*BackupOperation, error) {
+	var out envelope[BackupOperation]
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/backups/operations/"+url.PathEscape(id), nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// RestoreBackup is POST /v1/admin/backups/restore (Restore Backup).
+// Starts a Firestore managed import of an export in BACKUP_BUCKET. Imported documents replace
+// existing documents with the same Id. Only routed when BACKUP_BUCKET is set.
+func (c *Client) RestoreBackup(ctx context.Context, body RestoreRequest) (*BackupOperation, error) {
+	var out envelope[BackupOperation]
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/backups/restore", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// GetLogLevel is GET /v1/admin/log-level (Get Log Level).
+// The minimum level of the logs this instance writes (LOG_LEVEL unless changed since the instance
+// started).
+func (c *Client) GetLogLevel(ctx context.Context) (*LogLevel, error) {
+	var out envelope[LogLevel]
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/log-level", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// SetLogLevel is PUT /v1/admin/log-level (Set Log Level).
+// Changes the minimum log level without a redeploy. It applies to the instance serving the request
+// until it stops; new instances start at LOG_LEVEL.
+func (c *Client) SetLogLevel(ctx context.Context, body LogLevel) (*LogLevel, error) {
+	var out envelope[LogLevel]
+	if err := c.do(ctx, http.MethodPut, "/v1/admin/log-level", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// TrackingFeed is GET /v1/admin/tracking/stream (Tracking Feed).
+// Server-sent events: one "tracking" event per tracking event stored after the connection opened,
+// across all instances. Idle streams get a comment line every 25s; EventSource reconnects when the
+// stream ends. The caller reads and closes the text/event-stream response body.
+func (c *Client) TrackingFeed(ctx context.Context) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Accept", "text/event-stream")
+	return c.send(ctx, http.MethodGet, "/v1/admin/tracking/stream", nil, header, nil)
+}
+
+// Seed is POST /v1/dev/seed (Seed Fake Data).
+// Development only. Generates events starting between from and to (default: the next 90 days) in
+// the given cities, with tracking from the last 7 days. The same seed generates the same data, so
+// repeating a request overwrites it. Large requests may outlast REQUEST_TIMEOUT; use cmd/seed for
+// those.
+func (c *Client) Seed(ctx context.Context, body *SeedRequest) (*SeedResult, error) {
+	var payload any
+	if body != nil {
+		payload = body
+	}
+	var out envelope[SeedResult]
+	if err := c.do(ctx, http.MethodPost, "/v1/dev/seed", nil, nil, payload, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ListEventsParams are the query and header parameters of ListEvents
+type ListEventsParams struct {
+	// Filter by event name (case-insensitive prefix)
+	EventName *string
+	// Filter by city (case-insensitive)
+	City *string
+	// Filter by type
+	Type *EventType
+	// Filter by organizer Id
+	OrganizerID *string
+	// Minimum price
+	MinPrice *float64
+	// Maximum price
+	MaxPrice *float64
+	// Start date (RFC3339)
+	StartDate *string
+	// End date (RFC3339)
+	EndDate *string
+	// Relative window; excludes start_date/end_date
+	When *string
+	// IANA timezone for 'when' (e.g. Europe/Warsaw), defaults to UTC
+	Tz *string
+	// Page size (1-100, default 20)
+	PageSize *int64
+	// Token of the page to read, from meta
+	PageToken *string
+	// Sort key
+	SortKey *string
+	// Sort direction
+	SortDir *string
+	// Comma-separated sparse fieldset (e.g. id,event_name,start_time,price); without it, deployments with EVENT_LIST_PROJECTION=card return the card fields listed in meta.fields
+	Fields *string
+	// ETag of a cached copy
+	IfNoneMatch *string
+}
+
+func (p *ListEventsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.EventName != nil {
+		query.Set("event_name", fmt.Sprint(*p.EventName))
+	}
+	if p.City != nil {
+		query.Set("city", fmt.Sprint(*p.City))
+	}
+	if p.Type != nil {
+		query.Set("type", fmt.Sprint(*p.Type))
+	}
+	if p.OrganizerID != nil {
+		query.Set("organizer_id", fmt.Sprint(*p.OrganizerID))
+	}
+	if p.MinPrice != nil {
+		query.Set("min_price", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		query.Set("max_price", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.StartDate != nil {
+		query.Set("start_date", fmt.Sprint(*p.StartDate))
+	}
+	if p.EndDate != nil {
+		query.Set("end_date", fmt.Sprint(*p.EndDate))
+	}
+	if p.When != nil {
+		query.Set("when", fmt.Sprint(*p.When))
+	}
+	if p.Tz != nil {
+		query.Set("tz", fmt.Sprint(*p.Tz))
+	}
+	if p.PageSize != nil {
+		query.Set("page_size", fmt.Sprint(*p.PageSize))
+	}
+	if p.PageToken != nil {
+		query.Set("page_token", fmt.Sprint(*p.PageToken))
+	}
+	if p.SortKey != nil {
+		query.Set("sort_key", fmt.Sprint(*p.SortKey))
+	}
+	if p.SortDir != nil {
+		query.Set("sort_dir", fmt.Sprint(*p.SortDir))
+	}
+	if p.Fields != nil {
+		query.Set("fields", fmt.Sprint(*p.Fields))
+	}
+	if p.IfNoneMatch != nil {
+		header.Set("If-None-Match", fmt.Sprint(*p.IfNoneMatch))
+	}
+	return query, header
+}
+
+// ListEvents is GET /v1/events (List Events).
+// Events matching the filters, one page at a time. Accept: application/x-ndjson streams every
+// match, one event per line, ignoring page_size and page_token; application/vnd.api+json returns a
+// JSON:API document with pagination links. Only public events are listed, except to admins, so a
+// page can hold fewer than page_size events; keep following next_page_token.
+func (c *Client) ListEvents(ctx context.Context, params *ListEventsParams) (*Page[EventResponse], error) {
+	query, header := params.encode()
+	var out Page[EventResponse]
+	if err := c.do(ctx, http.MethodGet, "/v1/events", query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEvent is POST /v1/events (Create Event).
+func (c *Client) CreateEvent(ctx context.Context, body EventDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/events", nil, nil, body, &out)
+	return out.Data, err
+}
+
+// BatchCreateEvents is POST /v1/events/batch (Batch Create Events).
+// Items are validated and stored independently: 201 when every item was created, 207 with per-item
+// results when some failed.
+func (c *Client) BatchCreateEvents(ctx context.Context, body BatchEventRequest) (*BatchCreateResult, error) {
+	var out envelope[BatchCreateResult]
+	if err := c.do(ctx, http.MethodPost, "/v1/events/batch", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// GetEventsParams are the query and header parameters of GetEvents
+type GetEventsParams struct {
+	// Comma-separated event Ids
+	IDS string
+}
+
+func (p *GetEventsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	query.Set("ids", fmt.Sprint(p.IDS))
+	return query, header
+}
+
+// GetEvents is GET /v1/events/batch-get (Get Events by Ids).
+// Events in the order requested; unknown Ids are left out. Up to 100 Ids, comma-separated or
+// repeated.
+func (c *Client) GetEvents(ctx context.Context, params *GetEventsParams) ([]EventResponse, error) {
+	query, header := params.encode()
+	var out envelope[[]EventResponse]
+	err := c.do(ctx, http.MethodGet, "/v1/events/batch-get", query, header, nil, &out)
+	return out.Data, err
+}
+
+// ListFeaturedEvents is GET /v1/events/featured (List Featured Events).
+// Events whose promotion has not expired, sorted by start_time.
+func (c *Client) ListFeaturedEvents(ctx context.Context) ([]EventResponse, error) {
+	var out envelope[[]EventResponse]
+	err := c.do(ctx, http.MethodGet, "/v1/events/featured", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// ImportEvents is POST /v1/events/import (Import Events from CSV).
+// Upload a CSV file (header: event_name,city,type,price,start_time,end_time; optional columns:
+// description,visibility,latitude,longitude,event_url,image_url) and get a per-row report.
+func (c *Client) ImportEvents(ctx context.Context, body io.Reader, contentType string) (*ImportReport, error) {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	var out envelope[ImportReport]
+	if err := c.do(ctx, http.MethodPost, "/v1/events/import", nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ImportEventsAsync is POST /v1/events/import-async (Import Events from CSV (async)).
+// Same format as /events/import. Rows are stored in chunks by a background worker; poll GET
+// /jobs/{id} for progress. Only routed when a task queue is configured.
+func (c *Client) ImportEventsAsync(ctx context.Context, body io.Reader, contentType string) (*ImportJob, error) {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	var out envelope[ImportJob]
+	if err := c.do(ctx, http.MethodPost, "/v1/events/import-async", nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// PriceBucketsParams are the query and header parameters of PriceBuckets
+type PriceBucketsParams struct {
+	// Filter by city
+	City *string
+	// Comma-separated ascending bucket edges (default 0,25,50,100,200)
+	Bounds *string
+}
+
+func (p *PriceBucketsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.City != nil {
+		query.Set("city", fmt.Sprint(*p.City))
+	}
+	if p.Bounds != nil {
+		query.Set("bounds", fmt.Sprint(*p.Bounds))
+	}
+	return query, header
+}
+
+// PriceBuckets is GET /v1/events/price-buckets (Price Histogram).
+// Count events in consecutive price ranges [bound_i, bound_i+1); the last range is open-ended.
+func (c *Client) PriceBuckets(ctx context.Context, params *PriceBucketsParams) ([]PriceBucket, error) {
+	query, header := params.encode()
+	var out envelope[[]PriceBucket]
+	err := c.do(ctx, http.MethodGet, "/v1/events/price-buckets", query, header, nil, &out)
+	return out.Data, err
+}
+
+// GetEventBySlug is GET /v1/events/slug/{slug} (Get Event by Slug).
+func (c *Client) GetEventBySlug(ctx context.Context, slug string) (*EventResponse, error) {
+	var out envelope[EventResponse]
+	if err := c.do(ctx, http.MethodGet, "/v1/events/slug/"+url.PathEscape(slug), nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// EventStatsParams are the query and header parameters of EventStats
+type EventStatsParams struct {
+	// Group by field
+	GroupBy string
+}
+
+func (p *EventStatsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	query.Set("group_by", fmt.Sprint(p.GroupBy))
+	return query, header
+}
+
+// EventStats is GET /v1/events/stats (Event Statistics).
+// Count, average, minimum and maximum price of events per city or type.
+func (c *Client) EventStats(ctx context.Context, params *EventStatsParams) ([]EventStats, error) {
+	query, header := params.encode()
+	var out envelope[[]EventStats]
+	err := c.do(ctx, http.MethodGet, "/v1/events/stats", query, header, nil, &out)
+	return out.Data, err
+}
+
+// EventSummaryParams are the query and header parameters of EventSummary
+type EventSummaryParams struct {
+	// Filter by event name (case-insensitive prefix)
+	EventName *string
+	// Filter by city (case-insensitive)
+	City *string
+	// Filter by type
+	Type *EventType
+	// Filter by organizer Id
+	OrganizerID *string
+	// Minimum price
+	MinPrice *float64
+	// Maximum price
+	MaxPrice *float64
+	// Start date (RFC3339)
+	StartDate *string
+	// End date (RFC3339)
+	EndDate *string
+	// Relative window; excludes start_date/end_date
+	When *string
+	// IANA timezone for 'when' (e.g. Europe/Warsaw), defaults to UTC
+	Tz *string
+}
+
+func (p *EventSummaryParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.EventName != nil {
+		query.Set("event_name", fmt.Sprint(*p.EventName))
+	}
+	if p.City != nil {
+		query.Set("city", fmt.Sprint(*p.City))
+	}
+	if p.Type != nil {
+		query.Set("type", fmt.Sprint(*p.Type))
+	}
+	if p.OrganizerID != nil {
+		query.Set("organizer_id", fmt.Sprint(*p.OrganizerID))
+	}
+	if p.MinPrice != nil {
+		query.Set("min_price", fmt.Sprint(*p.MinPrice))
+	}
+	if p.MaxPrice != nil {
+		query.Set("max_price", fmt.Sprint(*p.MaxPrice))
+	}
+	if p.StartDate != nil {
+		query.Set("start_date", fmt.Sprint(*p.StartDate))
+	}
+	if p.EndDate != nil {
+		query.Set("end_date", fmt.Sprint(*p.EndDate))
+	}
+	if p.When != nil {
+		query.Set("when", fmt.Sprint(*p.When))
+	}
+	if p.Tz != nil {
+		query.Set("tz", fmt.Sprint(*p.Tz))
+	}
+	return query, header
+}
+
+// EventSummary is GET /v1/events/stats/summary (Event Summary).
+// Count, total and average price of the events matching the list filters, computed by aggregation
+// queries.
+func (c *Client) EventSummary(ctx context.Context, params *EventSummaryParams) (*EventSummary, error) {
+	query, header := params.encode()
+	var out envelope[EventSummary]
+	if err := c.do(ctx, http.MethodGet, "/v1/events/stats/summary", query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ListEventTypes is GET /v1/events/types (List Event Types).
+// Event types this deployment accepts in type fields and filters. Operators can change them
+// without a release, so clients should not hard-code them.
+func (c *Client) ListEventTypes(ctx context.Context) ([]EventType, error) {
+	var out envelope[[]EventType]
+	err := c.do(ctx, http.MethodGet, "/v1/events/types", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// GetEventParams are the query and header parameters of GetEvent
+type GetEventParams struct {
+	// Comma-separated sparse fieldset (e.g. id,event_name,start_time,price)
+	Fields *string
+	// ETag of a cached copy
+	IfNoneMatch *string
+}
+
+func (p *GetEventParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.Fields != nil {
+		query.Set("fields", fmt.Sprint(*p.Fields))
+	}
+	if p.IfNoneMatch != nil {
+		header.Set("If-None-Match", fmt.Sprint(*p.IfNoneMatch))
+	}
+	return query, header
+}
+
+// GetEvent is GET /v1/events/{id} (Get Event).
+// Public and unlisted events are readable by anyone with the Id; private events only by their
+// creator and admins, and are not found for everyone else.
+func (c *Client) GetEvent(ctx context.Context, id string, params *GetEventParams) (*EventResponse, error) {
+	query, header := params.encode()
+	var out envelope[EventResponse]
+	if err := c.do(ctx, http.MethodGet, "/v1/events/"+url.PathEscape(id), query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// UpdateEvent is PUT /v1/events/{id} (Update Event).
+// Update specific fields of an event. Organizers may only update their own events.
+func (c *Client) UpdateEvent(ctx context.Context, id string, body UpdateEventDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPut, "/v1/events/"+url.PathEscape(id), nil, nil, body, &out)
+	return out.Data, err
+}
+
+// DeleteEvent is DELETE /v1/events/{id} (Delete Event).
+func (c *Client) DeleteEvent(ctx context.Context, id string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/events/"+url.PathEscape(id), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// ListAttendees is GET /v1/events/{id}/attendees (List Attendees).
+func (c *Client) ListAttendees(ctx context.Context, id string) ([]RSVP, error) {
+	var out envelope[[]RSVP]
+	err := c.do(ctx, http.MethodGet, "/v1/events/"+url.PathEscape(id)+"/attendees", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// SetEventFeatured is PUT /v1/events/{id}/featured (Toggle Featured).
+// Mark an event as featured until a given time, or remove the promotion.
+func (c *Client) SetEventFeatured(ctx context.Context, id string, body FeatureEventDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPut, "/v1/events/"+url.PathEscape(id)+"/featured", nil, nil, body, &out)
+	return out.Data, err
+}
+
+// EventHistory is GET /v1/events/{id}/history (Event History).
+// Create, update and delete revisions of an event, newest first, with the acting user and field
+// diff.
+func (c *Client) EventHistory(ctx context.Context, id string) ([]EventRevision, error) {
+	var out envelope[[]EventRevision]
+	err := c.do(ctx, http.MethodGet, "/v1/events/"+url.PathEscape(id)+"/history", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// Rsvp is POST /v1/events/{id}/rsvp (RSVP to Event).
+// Register the authenticated user for an event, capacity permitting. 409 when the event is full or
+// the user is already registered.
+func (c *Client) Rsvp(ctx context.Context, id string) (*RSVP, error) {
+	var out envelope[RSVP]
+	if err := c.do(ctx, http.MethodPost, "/v1/events/"+url.PathEscape(id)+"/rsvp", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ListTicketTiers is GET /v1/events/{id}/tiers (List Ticket Tiers).
+// Ticket tiers of an event, cheapest first.
+func (c *Client) ListTicketTiers(ctx context.Context, id string) ([]TicketTier, error) {
+	var out envelope[[]TicketTier]
+	err := c.do(ctx, http.MethodGet, "/v1/events/"+url.PathEscape(id)+"/tiers", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// CreateTicketTier is POST /v1/events/{id}/tiers (Create Ticket Tier).
+func (c *Client) CreateTicketTier(ctx context.Context, id string, body TicketTierDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/events/"+url.PathEscape(id)+"/tiers", nil, nil, body, &out)
+	return out.Data, err
+}
+
+// UpdateTicketTier is PUT /v1/events/{id}/tiers/{tierId} (Update Ticket Tier).
+func (c *Client) UpdateTicketTier(ctx context.Context, id string, tierID string, body TicketTierDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPut, "/v1/events/"+url.PathEscape(id)+"/tiers/"+url.PathEscape(tierID), nil, nil, body, &out)
+	return out.Data, err
+}
+
+// GraphqlQueryGetParams are the query and header parameters of GraphqlQueryGet
+type GraphqlQueryGetParams struct {
+	// GraphQL document
+	Query string
+	// Operation to run
+	OperationName *string
+	// JSON object of variables
+	Variables *string
+}
+
+func (p *GraphqlQueryGetParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	query.Set("query", fmt.Sprint(p.Query))
+	if p.OperationName != nil {
+		query.Set("operationName", fmt.Sprint(*p.OperationName))
+	}
+	if p.Variables != nil {
+		query.Set("variables", fmt.Sprint(*p.Variables))
+	}
+	return query, header
+}
+
+// GraphqlQueryGet is GET /v1/graphql (GraphQL Query (GET)).
+func (c *Client) GraphqlQueryGet(ctx context.Context, params *GraphqlQueryGetParams) (*GraphqlResponse, error) {
+	query, header := params.encode()
+	var out GraphqlResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/graphql", query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GraphqlQuery is POST /v1/graphql (GraphQL Query).
+// Executes a read-only GraphQL query. Partial results come back with 200 and an errors list;
+// requests that cannot run at all, including queries over the cost or alias limit, get 400.
+func (c *Client) GraphqlQuery(ctx context.Context, body GraphqlRequest) (*GraphqlResponse, error) {
+	var out GraphqlResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/graphql", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GraphqlSchema is GET /v1/graphql/schema.graphql (GraphQL Schema).
+// The GraphQL schema in schema definition language. The caller reads and closes the text/plain
+// response body.
+func (c *Client) GraphqlSchema(ctx context.Context) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Accept", "text/plain")
+	return c.send(ctx, http.MethodGet, "/v1/graphql/schema.graphql", nil, header, nil)
+}
+
+// Liveness is GET /healthz (Liveness).
+func (c *Client) Liveness(ctx context.Context) (*HealthReport, error) {
+	var out HealthReport
+	if err := c.do(ctx, http.MethodGet, "/healthz", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJob is GET /v1/jobs/{id} (Get Job Status).
+// Status, counters and row errors of an async import.
+func (c *Client) GetJob(ctx context.Context, id string) (*ImportJob, error) {
+	var out envelope[ImportJob]
+	if err := c.do(ctx, http.MethodGet, "/v1/jobs/"+url.PathEscape(id), nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// Openapi is GET /openapi.json (OpenAPI Document).
+// This document. Admin-only with API_DOCS=admin and not served with API_DOCS=off.
+func (c *Client) Openapi(ctx context.Context) (map[string]any, error) {
+	var out map[string]any
+	err := c.do(ctx, http.MethodGet, "/openapi.json", nil, nil, nil, &out)
+	return out, err
+}
+
+// ListOrganizers is GET /v1/organizers (List Organizers).
+// Every organizer, sorted by name.
+func (c *Client) ListOrganizers(ctx context.Context) ([]Organizer, error) {
+	var out envelope[[]Organizer]
+	err := c.do(ctx, http.MethodGet, "/v1/organizers", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// CreateOrganizer is POST /v1/organizers (Create Organizer).
+func (c *Client) CreateOrganizer(ctx context.Context, body OrganizerDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/organizers", nil, nil, body, &out)
+	return out.Data, err
+}
+
+// GetOrganizer is GET /v1/organizers/{id} (Get Organizer).
+func (c *Client) GetOrganizer(ctx context.Context, id string) (*Organizer, error) {
+	var out envelope[Organizer]
+	if err := c.do(ctx, http.MethodGet, "/v1/organizers/"+url.PathEscape(id), nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// UpdateOrganizer is PUT /v1/organizers/{id} (Update Organizer).
+func (c *Client) UpdateOrganizer(ctx context.Context, id string, body UpdateOrganizerDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPut, "/v1/organizers/"+url.PathEscape(id), nil, nil, body, &out)
+	return out.Data, err
+}
+
+// DeleteOrganizer is DELETE /v1/organizers/{id} (Delete Organizer).
+func (c *Client) DeleteOrganizer(ctx context.Context, id string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/organizers/"+url.PathEscape(id), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// Readiness is GET /readyz (Readiness).
+// Probes dependencies (Firestore) with a short deadline. 503 when any probe fails.
+func (c *Client) Readiness(ctx context.Context) (*HealthReport, error) {
+	var out HealthReport
+	if err := c.do(ctx, http.MethodGet, "/readyz", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListTracking is GET /v1/tracking (List Tracking Events).
+func (c *Client) ListTracking(ctx context.Context) ([]TrackingEventResponse, error) {
+	var out envelope[[]TrackingEventResponse]
+	err := c.do(ctx, http.MethodGet, "/v1/tracking", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// Track is POST /v1/tracking (Create Tracking Event).
+func (c *Client) Track(ctx context.Context, body TrackingEventDTO) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/tracking", nil, nil, body, &out)
+	return out.Data, err
+}
+
+// PurgeTrackingParams are the query and header parameters of PurgeTracking
+type PurgeTrackingParams struct {
+	// Cutoff: an RFC3339 timestamp or an age such as 720h
+	OlderThan *string
+	// Only events with this action
+	Action *string
+}
+
+func (p *PurgeTrackingParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.OlderThan != nil {
+		query.Set("older_than", fmt.Sprint(*p.OlderThan))
+	}
+	if p.Action != nil {
+		query.Set("action", fmt.Sprint(*p.Action))
+	}
+	return query, header
+}
+
+// PurgeTracking is DELETE /v1/tracking (Delete Tracking Events).
+// Remove tracking events older than a cutoff and/or of one action, and take them out of the stats.
+// At least one filter is required. One call deletes at most 5000 events; repeat it while remaining
+// is true.
+func (c *Client) PurgeTracking(ctx context.Context, params *PurgeTrackingParams) (*PurgeResult, error) {
+	query, header := params.encode()
+	var out envelope[PurgeResult]
+	if err := c.do(ctx, http.MethodDelete, "/v1/tracking", query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// TrackingSession is GET /v1/tracking/sessions/{id} (Tracking Session).
+// Tracking events of a session, oldest first.
+func (c *Client) TrackingSession(ctx context.Context, id string) ([]TrackingEventResponse, error) {
+	var out envelope[[]TrackingEventResponse]
+	err := c.do(ctx, http.MethodGet, "/v1/tracking/sessions/"+url.PathEscape(id), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// TrackingStatsParams are the query and header parameters of TrackingStats
+type TrackingStatsParams struct {
+	// Group by field (default action)
+	GroupBy *string
+	// Bucket size (default day)
+	Interval *string
+	// Window start (RFC3339), defaults to 7 days (hour: 24 hours) before to
+	From *string
+	// Window end (RFC3339), defaults to now
+	To *string
+}
+
+func (p *TrackingStatsParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	if p.GroupBy != nil {
+		query.Set("group_by", fmt.Sprint(*p.GroupBy))
+	}
+	if p.Interval != nil {
+		query.Set("interval", fmt.Sprint(*p.Interval))
+	}
+	if p.From != nil {
+		query.Set("from", fmt.Sprint(*p.From))
+	}
+	if p.To != nil {
+		query.Set("to", fmt.Sprint(*p.To))
+	}
+	return query, header
+}
+
+// TrackingStats is GET /v1/tracking/stats (Tracking Statistics).
+// Count tracking events per action in each UTC hour or day of a window, from rollups maintained on
+// write; empty buckets are included.
+func (c *Client) TrackingStats(ctx context.Context, params *TrackingStatsParams) ([]TrackingStats, error) {
+	query, header := params.encode()
+	var out envelope[[]TrackingStats]
+	err := c.do(ctx, http.MethodGet, "/v1/tracking/stats", query, header, nil, &out)
+	return out.Data, err
+}
+
+// DeleteTracking is DELETE /v1/tracking/{id} (Delete Tracking Event).
+// Remove a tracking event, e.g. test traffic, and take it out of the stats.
+func (c *Client) DeleteTracking(ctx context.Context, id string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/tracking/"+url.PathEscape(id), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// CurrentUser is GET /v1/users/me (Current User).
+// UID, email, roles and custom claims from the verified token, plus the users/{uid} profile
+// document if present.
+func (c *Client) CurrentUser(ctx context.Context) (*CurrentUser, error) {
+	var out envelope[CurrentUser]
+	if err := c.do(ctx, http.MethodGet, "/v1/users/me", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ListFavorites is GET /v1/users/me/favorites (List Favorites).
+// Events bookmarked by the caller, newest first.
+func (c *Client) ListFavorites(ctx context.Context) ([]Favorite, error) {
+	var out envelope[[]Favorite]
+	err := c.do(ctx, http.MethodGet, "/v1/users/me/favorites", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// AddFavorite is POST /v1/users/me/favorites/{eventId} (Add Favorite).
+// Bookmark an event (idempotent).
+func (c *Client) AddFavorite(ctx context.Context, eventID string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/users/me/favorites/"+url.PathEscape(eventID), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// RemoveFavorite is DELETE /v1/users/me/favorites/{eventId} (Remove Favorite).
+// Remove a bookmarked event (idempotent).
+func (c *Client) RemoveFavorite(ctx context.Context, eventID string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/users/me/favorites/"+url.PathEscape(eventID), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// GetSubscription is GET /v1/users/me/subscription (Get Subscription).
+// Cities the caller follows and whether new events are emailed. Only routed when email
+// notifications are configured.
+func (c *Client) GetSubscription(ctx context.Context) (*Subscription, error) {
+	var out envelope[Subscription]
+	if err := c.do(ctx, http.MethodGet, "/v1/users/me/subscription", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// UpdateSubscription is POST /v1/users/me/subscription (Update Subscription).
+// Follow cities and opt in or out of email about new events there. Opting in requires a verified
+// email on the account.
+func (c *Client) UpdateSubscription(ctx context.Context, body SubscriptionDTO) (*Subscription, error) {
+	var out envelope[Subscription]
+	if err := c.do(ctx, http.MethodPost, "/v1/users/me/subscription", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// DeleteSubscription is DELETE /v1/users/me/subscription (Delete Subscription).
+// Unfollow all cities and stop email notifications (idempotent).
+func (c *Client) DeleteSubscription(ctx context.Context) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/users/me/subscription", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// ListPushSubscriptions is GET /v1/users/me/subscriptions (List Push Subscriptions).
+// Cities (and event types) the caller's devices receive pushes for.
+func (c *Client) ListPushSubscriptions(ctx context.Context) ([]PushSubscription, error) {
+	var out envelope[[]PushSubscription]
+	err := c.do(ctx, http.MethodGet, "/v1/users/me/subscriptions", nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// SubscribePush is POST /v1/users/me/subscriptions (Subscribe to Push Notifications).
+// Send an FCM push to the device (token) when an event is created in city; type narrows it to one
+// event type. Idempotent.
+func (c *Client) SubscribePush(ctx context.Context, body PushSubscriptionDTO) (*PushSubscription, error) {
+	var out envelope[PushSubscription]
+	if err := c.do(ctx, http.MethodPost, "/v1/users/me/subscriptions", nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// UnsubscribePush is DELETE /v1/users/me/subscriptions/{id} (Unsubscribe from Push Notifications).
+func (c *Client) UnsubscribePush(ctx context.Context, id string) (string, error) {
+	var out envelope[string]
+	err := c.do(ctx, http.MethodDelete, "/v1/users/me/subscriptions/"+url.PathEscape(id), nil, nil, nil, &out)
+	return out.Data, err
+}
+
+// DeleteUserData is DELETE /v1/users/{uid}/data (Delete User Data).
+// Deletes the user's tracking events, RSVPs and favorites and reports how many of each were
+// removed. "me" stands for the caller; only admins may erase other users.
+func (c *Client) DeleteUserData(ctx context.Context, uid string) (*DataDeletionReport, error) {
+	var out envelope[DataDeletionReport]
+	if err := c.do(ctx, http.MethodDelete, "/v1/users/"+url.PathEscape(uid)+"/data", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// Version is GET /version (Version).
+// Git SHA, build time and Go version of the deployed binary.
+func (c *Client) Version(ctx context.Context) (*BuildinfoInfo, error) {
+	var out BuildinfoInfo
+	if err := c.do(ctx, http.MethodGet, "/version", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Warmup is GET /warmup (Warm-up).
+// Initializes the instance and primes dependency connections with trivial reads. 503 when any
+// probe fails.
+func (c *Client) Warmup(ctx context.Context) (*HealthReport, error) {
+	var out HealthReport
+	if err := c.do(ctx, http.MethodGet, "/warmup", nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PartnerEventWebhookParams are the query and header parameters of PartnerEventWebhook
+type PartnerEventWebhookParams struct {
+	// Unix time the request was signed at
+	XSignatureTimestamp int64
+	// sha256=<hex HMAC>
+	XSignature string
+}
+
+func (p *PartnerEventWebhookParams) encode() (url.Values, http.Header) {
+	query, header := url.Values{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	header.Set("X-Signature-Timestamp", fmt.Sprint(p.XSignatureTimestamp))
+	header.Set("X-Signature", fmt.Sprint(p.XSignature))
+	return query, header
+}
+
+// PartnerEventWebhook is POST /v1/webhooks/events (Partner Event Webhook).
+// Create an event on behalf of a partner system. The body must be signed: X-Signature-Timestamp
+// holds the Unix seconds it was signed at (within 5 minutes of the server clock) and X-Signature
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<raw body>" with the shared secret.
+func (c *Client) PartnerEventWebhook(ctx context.Context, body EventDTO, params *PartnerEventWebhookParams) (string, error) {
+	query, header := params.encode()
+	var out envelope[string]
+	err := c.do(ctx, http.MethodPost, "/v1/webhooks/events", query, header, body, &out)
+	return out.Data, err
+}
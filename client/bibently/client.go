@@ -0,0 +1,155 @@
+// Package bibently is the Go client of the Bibently API. The operations and types in api.gen.go
+// are generated from the OpenAPI document by cmd/genclient (make client); this file holds the
+// request plumbing they share.
+package bibently
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client calls the API at BaseURL, the origin the function is served at (e.g.
+// https://europe-west1-project.cloudfunctions.net/BibentlyFunctions); operations add the /v1 prefix.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Token returns the Firebase ID token sent as a bearer token; an empty token sends none
+	Token func(ctx context.Context) (string, error)
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends requests through hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.HTTPClient = hc }
+}
+
+// WithToken authenticates requests with the ID tokens token returns
+func WithToken(token func(ctx context.Context) (string, error)) Option {
+	return func(c *Client) { c.Token = token }
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is a response outside 2xx. Message, Code and RequestID come from the API's error model;
+// bodies that are not (e.g. the auth middleware's plain-text 403) end up in Message.
+type APIError struct {
+	Status    int
+	Message   string `json:"error"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id"`
+	Data      any    `json:"data"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("bibently: %d %s: %s", e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("bibently: %d: %s", e.Status, e.Message)
+}
+
+// envelope is the {"data": ...} wrapper of most responses
+type envelope[T any] struct {
+	Data T `json:"data"`
+}
+
+// Page is a page of a paginated list; pass Meta.NextPageToken as page_token to read the next one
+type Page[T any] struct {
+	Data []T  `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// do sends the request and decodes a JSON response into out (nil: the body is dropped)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body any, out any) error {
+	resp, err := c.send(ctx, method, path, query, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("bibently: decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// send sends the request and returns the response of a 2xx status, whose body the caller closes.
+// An io.Reader body is sent as is (header sets its Content-Type); any other non-nil body as JSON.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, header http.Header, body any) (*http.Response, error) {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("bibently: encode %s %s: %w", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Type", "application/json")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if c.Token != nil {
+		token, err := c.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("bibently: token: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+func decodeError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	apiErr := &APIError{Status: resp.StatusCode}
+	if json.Unmarshal(data, apiErr) != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
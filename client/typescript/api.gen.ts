@@ -0,0 +1,1198 @@
+// Code generated by cmd/genclient from the OpenAPI document; DO NOT EDIT.
+
+import { BaseClient } from "./runtime";
+
+/** A page of a paginated list; pass meta.next_page_token as page_token to read the next one */
+export interface Page<T> {
+  data: T[];
+  meta: Meta;
+}
+
+export interface ArchiveResult {
+  archived: number;
+  cutoff: string;
+  remaining: boolean;
+}
+
+export interface AuditEntry {
+  actor: string;
+  changes?: Record<string, Record<string, FieldChange>>;
+  created_at: string;
+  id: string;
+  method: string;
+  path: string;
+  resource: string;
+  resource_id?: string;
+  status: number;
+  truncated?: boolean;
+}
+
+export interface BackupOperation {
+  collections?: string[];
+  documents: number;
+  done: boolean;
+  end_time?: string;
+  error?: string;
+  estimated_total: number;
+  id: string;
+  kind: string;
+  start_time?: string;
+  state: string;
+  uri: string;
+}
+
+export interface BackupRecord {
+  collection: string;
+  event?: Event;
+  id: string;
+  tracking?: TrackingEvent;
+}
+
+export interface BackupRequest {
+  collections: string[] | null;
+}
+
+export interface BatchCreateResult {
+  created: BatchItemResult[] | null;
+  failed: BatchItemResult[] | null;
+}
+
+export interface BatchEventRequest {
+  events: EventDTO[] | null;
+}
+
+export interface BatchItemResult {
+  error?: string;
+  id?: string;
+  index: number;
+}
+
+export interface BuildinfoInfo {
+  build_time: string;
+  git_sha: string;
+  go_version: string;
+  modified?: boolean;
+}
+
+export interface CurrentUser {
+  claims: Record<string, unknown> | null;
+  email?: string;
+  email_verified: boolean;
+  profile?: Record<string, unknown>;
+  roles: string[] | null;
+  uid: string;
+}
+
+export interface DataDeletionReport {
+  favorites: number;
+  rsvps: number;
+  tracking_events: number;
+  user_id: string;
+}
+
+export interface DependencyHealth {
+  error?: string;
+  latency_ms: number;
+  status: string;
+}
+
+export interface Error {
+  /** Machine-readable code */
+  code?: "validation_failed" | "not_found" | "conflict" | "forbidden" | "duplicate_event" | "rate_limited" | "method_not_allowed" | "unsupported_media_type";
+  /** Details, e.g. the Id of the existing event on duplicate_event */
+  data?: unknown;
+  /** Human-readable message */
+  error: string;
+  /** Id of the request in the logs */
+  request_id?: string;
+}
+
+export interface Event {
+  attendee_count: number;
+  capacity: number;
+  city: string;
+  city_lc: string;
+  country: string;
+  created_at: string;
+  created_by: string;
+  dedup_key: string;
+  description: string;
+  end_time: string;
+  event_name: string;
+  event_name_lc: string;
+  event_url: string;
+  favorites_count: number;
+  featured: boolean;
+  featured_until: string;
+  full_address: string;
+  has_tickets: boolean;
+  id: string;
+  image_url: string;
+  location: LatlngLatLng | null;
+  organizer_id: string;
+  organizer_name: string;
+  popularity_score: number;
+  price: number;
+  provider: string;
+  slug: string;
+  start_time: string;
+  state: string;
+  street: string;
+  timezone: string;
+  type: EventType;
+  updated_at: string;
+  updated_by: string;
+  view_count: number;
+  visibility: string;
+}
+
+export interface EventDTO {
+  capacity?: number;
+  city: string;
+  description?: string;
+  end_time?: string;
+  event_name: string;
+  event_url?: string;
+  image_url?: string;
+  location?: GeoPointDTO | null;
+  organizer_id?: string;
+  price?: number;
+  start_time: string;
+  type: EventType;
+  visibility?: "public" | "unlisted" | "private";
+}
+
+export interface EventResponse {
+  attendee_count: number;
+  capacity: number;
+  city: string;
+  country: string;
+  created_at: string;
+  description: string;
+  end_time?: string;
+  event_name: string;
+  event_url: string;
+  favorites_count: number;
+  featured: boolean;
+  featured_until?: string;
+  full_address: string;
+  has_tickets: boolean;
+  id: string;
+  image_url: string;
+  is_free: boolean;
+  is_past: boolean;
+  location: GeoPoint | null;
+  organizer_id: string;
+  organizer_name: string;
+  popularity_score: number;
+  price: number;
+  provider: string;
+  slug: string;
+  start_time: string;
+  state: string;
+  street: string;
+  timezone: string;
+  type: EventType;
+  updated_at: string;
+  view_count: number;
+  visibility: string;
+}
+
+export interface EventRevision {
+  action: string;
+  actor: string;
+  changes: Record<string, FieldChange> | null;
+  created_at: string;
+  event_id: string;
+  id: string;
+}
+
+export interface EventStats {
+  avg_price: number;
+  count: number;
+  group: string;
+  max_price: number;
+  min_price: number;
+  total_price: number;
+}
+
+export interface EventSummary {
+  avg_price: number;
+  count: number;
+  total_price: number;
+}
+
+/** One of the event types configured for the deployment, as listed by GET /events/types */
+export type EventType = string;
+
+export interface Favorite {
+  created_at: string;
+  event_id: string;
+  user_id: string;
+}
+
+export interface FeatureEventDTO {
+  featured?: boolean;
+  featured_until?: string;
+}
+
+export interface FieldChange {
+  new: unknown;
+  old: unknown;
+}
+
+export interface GeoPoint {
+  latitude: number;
+  longitude: number;
+}
+
+export interface GeoPointDTO {
+  latitude: number | null;
+  longitude: number | null;
+}
+
+export interface GraphqlError {
+  extensions?: Record<string, unknown>;
+  message: string;
+  path?: unknown[];
+}
+
+export interface GraphqlRequest {
+  operationName?: string;
+  query: string;
+  variables?: Record<string, unknown>;
+}
+
+export interface GraphqlResponse {
+  data?: unknown;
+  errors?: GraphqlError[];
+}
+
+export interface HealthReport {
+  checks?: Record<string, DependencyHealth>;
+  status: string;
+}
+
+export interface ImportJob {
+  created: number;
+  created_at: string;
+  errors: ImportRowResult[] | null;
+  failed: number;
+  id: string;
+  message?: string;
+  status: string;
+  total: number;
+  total_chunks: number;
+  updated_at: string;
+}
+
+export interface ImportReport {
+  created: number;
+  failed: number;
+  rows: ImportRowResult[] | null;
+  total: number;
+}
+
+export interface ImportRowResult {
+  error?: string;
+  event_id?: string;
+  row: number;
+  success: boolean;
+}
+
+export interface LatlngLatLng {
+  latitude?: number;
+  longitude?: number;
+}
+
+export interface LogLevel {
+  level: string;
+}
+
+export interface Meta {
+  fields?: string[];
+  next_page_token?: string;
+  prev_page_token?: string;
+}
+
+export interface Organizer {
+  created_at: string;
+  description: string;
+  email: string;
+  id: string;
+  name: string;
+  website: string;
+}
+
+export interface OrganizerDTO {
+  description?: string;
+  email?: string;
+  name: string;
+  website?: string;
+}
+
+export interface PriceBucket {
+  count: number;
+  max: number | null;
+  min: number;
+}
+
+export interface PurgeResult {
+  cutoff?: string;
+  purged: number;
+  remaining: boolean;
+}
+
+export interface PushSubscription {
+  city: string;
+  created_at: string;
+  id: string;
+  type?: EventType;
+  user_id: string;
+}
+
+export interface PushSubscriptionDTO {
+  city: string;
+  token: string;
+  type?: EventType;
+}
+
+export interface RSVP {
+  created_at: string;
+  email: string;
+  event_id: string;
+  id: string;
+  user_id: string;
+}
+
+export interface RestoreFailure {
+  error: string;
+  id?: string;
+  line: number;
+}
+
+export interface RestoreRequest {
+  collections?: string[] | null;
+  source: string;
+}
+
+export interface RestoreResult {
+  failed: RestoreFailure[] | null;
+  restored: number;
+  skipped: number;
+  stopped: boolean;
+}
+
+export interface SeedRequest {
+  cities: string[] | null;
+  events: number;
+  from: string;
+  seed: number;
+  to: string;
+  tracking_per_event: number;
+}
+
+export interface SeedResult {
+  events: number;
+  failed: number;
+  tracking: number;
+}
+
+export interface Subscription {
+  cities: string[] | null;
+  email?: string;
+  email_opt_in: boolean;
+  updated_at: string;
+  user_id: string;
+}
+
+export interface SubscriptionDTO {
+  cities?: string[] | null;
+  email_opt_in?: boolean;
+}
+
+export interface TicketTier {
+  created_at: string;
+  event_id: string;
+  id: string;
+  name: string;
+  price: number;
+  quantity: number;
+  sales_end: string;
+  sales_start: string;
+}
+
+export interface TicketTierDTO {
+  name: string;
+  price?: number;
+  quantity?: number;
+  sales_end?: string;
+  sales_start?: string;
+}
+
+export interface TrackingEvent {
+  action: string;
+  client_event_id?: string;
+  created_at: string;
+  event_id?: string;
+  expire_at?: string;
+  id: string;
+  payload: string;
+  session_id?: string;
+  user_agent: string;
+  user_id?: string;
+  user_name: string;
+}
+
+export interface TrackingEventDTO {
+  action: string;
+  client_event_id?: string;
+  event_id?: string;
+  payload?: string;
+  session_id?: string;
+  user_agent?: string;
+  user_name?: string;
+}
+
+export interface TrackingEventResponse {
+  action: string;
+  client_event_id?: string;
+  created_at: string;
+  event_id?: string;
+  id: string;
+  payload: string;
+  session_id?: string;
+  user_agent: string;
+  user_id?: string;
+  user_name: string;
+}
+
+export interface TrackingStats {
+  counts: Record<string, number> | null;
+  start: string;
+  total: number;
+}
+
+export interface UpdateEventDTO {
+  capacity?: number | null;
+  city?: string | null;
+  description?: string | null;
+  end_time?: string | null;
+  event_name?: string | null;
+  event_url?: string | null;
+  image_url?: string | null;
+  location?: GeoPointDTO | null;
+  organizer_id?: string | null;
+  price?: number | null;
+  start_time?: string | null;
+  type?: string | null;
+  visibility?: "public" | "unlisted" | "private" | null;
+}
+
+export interface UpdateOrganizerDTO {
+  description?: string | null;
+  email?: string | null;
+  name?: string | null;
+  website?: string | null;
+}
+
+/** Query and header parameters of archivePastEvents */
+export interface ArchivePastEventsParams {
+  /** Minimum age in days since the event ended */
+  older_than_days?: number;
+}
+
+/** Query and header parameters of listAuditLogs */
+export interface ListAuditLogsParams {
+  /** Filter by actor UID */
+  actor?: string;
+  /** Filter by resource (e.g. events, organizers) */
+  resource?: string;
+  /** Filter by resource Id */
+  resource_id?: string;
+  /** Maximum number of entries (default 50, max 500) */
+  limit?: number;
+}
+
+/** Query and header parameters of dumpDatabase */
+export interface DumpDatabaseParams {
+  /** Comma-separated collections (events, tracking); default both */
+  collections?: string;
+}
+
+/** Query and header parameters of restoreDump */
+export interface RestoreDumpParams {
+  /** What to do with records whose document exists */
+  on_conflict?: "skip" | "overwrite" | "fail";
+}
+
+/** Query and header parameters of listEvents */
+export interface ListEventsParams {
+  /** Filter by event name (case-insensitive prefix) */
+  event_name?: string;
+  /** Filter by city (case-insensitive) */
+  city?: string;
+  /** Filter by type */
+  type?: EventType;
+  /** Filter by organizer Id */
+  organizer_id?: string;
+  /** Minimum price */
+  min_price?: number;
+  /** Maximum price */
+  max_price?: number;
+  /** Start date (RFC3339) */
+  start_date?: string;
+  /** End date (RFC3339) */
+  end_date?: string;
+  /** Relative window; excludes start_date/end_date */
+  when?: "upcoming" | "past" | "today" | "this_weekend";
+  /** IANA timezone for 'when' (e.g. Europe/Warsaw), defaults to UTC */
+  tz?: string;
+  /** Page size (1-100, default 20) */
+  page_size?: number;
+  /** Token of the page to read, from meta */
+  page_token?: string;
+  /** Sort key */
+  sort_key?: "event_name" | "city" | "price" | "start_time" | "created_at" | "popularity";
+  /** Sort direction */
+  sort_dir?: "asc" | "desc";
+  /** Comma-separated sparse fieldset (e.g. id,event_name,start_time,price); without it, deployments with EVENT_LIST_PROJECTION=card return the card fields listed in meta.fields */
+  fields?: string;
+  /** ETag of a cached copy */
+  "If-None-Match"?: string;
+}
+
+/** Query and header parameters of getEvents */
+export interface GetEventsParams {
+  /** Comma-separated event Ids */
+  ids: string;
+}
+
+/** Query and header parameters of priceBuckets */
+export interface PriceBucketsParams {
+  /** Filter by city */
+  city?: string;
+  /** Comma-separated ascending bucket edges (default 0,25,50,100,200) */
+  bounds?: string;
+}
+
+/** Query and header parameters of eventStats */
+export interface EventStatsParams {
+  /** Group by field */
+  group_by: "city" | "type";
+}
+
+/** Query and header parameters of eventSummary */
+export interface EventSummaryParams {
+  /** Filter by event name (case-insensitive prefix) */
+  event_name?: string;
+  /** Filter by city (case-insensitive) */
+  city?: string;
+  /** Filter by type */
+  type?: EventType;
+  /** Filter by organizer Id */
+  organizer_id?: string;
+  /** Minimum price */
+  min_price?: number;
+  /** Maximum price */
+  max_price?: number;
+  /** Start date (RFC3339) */
+  start_date?: string;
+  /** End date (RFC3339) */
+  end_date?: string;
+  /** Relative window; excludes start_date/end_date */
+  when?: "upcoming" | "past" | "today" | "this_weekend";
+  /** IANA timezone for 'when' (e.g. Europe/Warsaw), defaults to UTC */
+  tz?: string;
+}
+
+/** Query and header parameters of getEvent */
+export interface GetEventParams {
+  /** Comma-separated sparse fieldset (e.g. id,event_name,start_time,price) */
+  fields?: string;
+  /** ETag of a cached copy */
+  "If-None-Match"?: string;
+}
+
+/** Query and header parameters of graphqlQueryGet */
+export interface GraphqlQueryGetParams {
+  /** GraphQL document */
+  query: string;
+  /** Operation to run */
+  operationName?: string;
+  /** JSON object of variables */
+  variables?: string;
+}
+
+/** Query and header parameters of purgeTracking */
+export interface PurgeTrackingParams {
+  /** Cutoff: an RFC3339 timestamp or an age such as 720h */
+  older_than?: string;
+  /** Only events with this action */
+  action?: string;
+}
+
+/** Query and header parameters of trackingStats */
+export interface TrackingStatsParams {
+  /** Group by field (default action) */
+  group_by?: "action";
+  /** Bucket size (default day) */
+  interval?: "hour" | "day";
+  /** Window start (RFC3339), defaults to 7 days (hour: 24 hours) before to */
+  from?: string;
+  /** Window end (RFC3339), defaults to now */
+  to?: string;
+}
+
+/** Query and header parameters of partnerEventWebhook */
+export interface PartnerEventWebhookParams {
+  /** Unix time the request was signed at */
+  "X-Signature-Timestamp": number;
+  /** sha256=<hex HMAC> */
+  "X-Signature": string;
+}
+
+export class Client extends BaseClient {
+  /**
+   * Archive Past Events (POST /v1/admin/archive-past-events)
+   * Move events that ended more than older_than_days ago (default 30) to events_archive. Repeat
+   * while "remaining" is true.
+   */
+  async archivePastEvents(params: ArchivePastEventsParams = {}): Promise<ArchiveResult> {
+    return (await this.request<{ data: ArchiveResult }>("POST", "/v1/admin/archive-past-events", { query: { older_than_days: params.older_than_days } })).data;
+  }
+
+  /**
+   * List Audit Logs (GET /v1/admin/audit-logs)
+   * Write operations with actor, target, outcome and (for events) old-vs-new field values.
+   */
+  async listAuditLogs(params: ListAuditLogsParams = {}): Promise<AuditEntry[]> {
+    return (await this.request<{ data: AuditEntry[] }>("GET", "/v1/admin/audit-logs", { query: { actor: params.actor, resource: params.resource, resource_id: params.resource_id, limit: params.limit } })).data;
+  }
+
+  /**
+   * Start Backup (POST /v1/admin/backups)
+   * Starts a Firestore managed export to a new folder of BACKUP_BUCKET and returns the operation;
+   * poll it until done. Only routed when BACKUP_BUCKET is set.
+   */
+  async startBackup(body?: BackupRequest): Promise<BackupOperation> {
+    return (await this.request<{ data: BackupOperation }>("POST", "/v1/admin/backups", { body })).data;
+  }
+
+  /**
+   * Dump Database (GET /v1/admin/backups/dump)
+   * Streams every event and tracking document as newline-delimited records, which POST
+   * /admin/backups/dump restores. Meant for small databases and moving data between environments;
+   * use POST /admin/backups for full backups.
+   */
+  async dumpDatabase(params: DumpDatabaseParams = {}): Promise<Response> {
+    return this.send("GET", "/v1/admin/backups/dump", { query: { collections: params.collections }, headers: { Accept: "application/x-ndjson" } });
+  }
+
+  /**
+   * Restore Dump (POST /v1/admin/backups/dump)
+   * Restores a dump produced by GET /admin/backups/dump. on_conflict decides what happens to records
+   * whose document exists: skip it (default), overwrite it, or fail, which stops after the current
+   * batch of 500. Invalid lines are reported by line number; restored tracking is not added to the
+   * stats rollups.
+   */
+  async restoreDump(body: BodyInit, params: RestoreDumpParams = {}): Promise<RestoreResult> {
+    return (await this.request<{ data: RestoreResult }>("POST", "/v1/admin/backups/dump", { query: { on_conflict: params.on_conflict }, body, contentType: "application/x-ndjson" })).data;
+  }
+
+  /**
+   * Get Backup Operation (GET /v1/admin/backups/operations/{id})
+   */
+  async getBackupOperation(id: string): Promise<BackupOperation> {
+    return (await this.request<{ data: BackupOperation }>("GET", `/v1/admin/backups/operations/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Restore Backup (POST /v1/admin/backups/restore)
+   * Starts a Firestore managed import of an export in BACKUP_BUCKET. Imported documents replace
+   * existing documents with the same Id. Only routed when BACKUP_BUCKET is set.
+   */
+  async restoreBackup(body: RestoreRequest): Promise<BackupOperation> {
+    return (await this.request<{ data: BackupOperation }>("POST", "/v1/admin/backups/restore", { body })).data;
+  }
+
+  /**
+   * Get Log Level (GET /v1/admin/log-level)
+   * The minimum level of the logs this instance writes (LOG_LEVEL unless changed since the instance
+   * started).
+   */
+  async getLogLevel(): Promise<LogLevel> {
+    return (await this.request<{ data: LogLevel }>("GET", "/v1/admin/log-level")).data;
+  }
+
+  /**
+   * Set Log Level (PUT /v1/admin/log-level)
+   * Changes the minimum log level without a redeploy. It applies to the instance serving the request
+   * until it stops; new instances start at LOG_LEVEL.
+   */
+  async setLogLevel(body: LogLevel): Promise<LogLevel> {
+    return (await this.request<{ data: LogLevel }>("PUT", "/v1/admin/log-level", { body })).data;
+  }
+
+  /**
+   * Tracking Feed (GET /v1/admin/tracking/stream)
+   * Server-sent events: one "tracking" event per tracking event stored after the connection opened,
+   * across all instances. Idle streams get a comment line every 25s; EventSource reconnects when the
+   * stream ends.
+   */
+  async trackingFeed(): Promise<Response> {
+    return this.send("GET", "/v1/admin/tracking/stream", { headers: { Accept: "text/event-stream" } });
+  }
+
+  /**
+   * Seed Fake Data (POST /v1/dev/seed)
+   * Development only. Generates events starting between from and to (default: the next 90 days) in
+   * the given cities, with tracking from the last 7 days. The same seed generates the same data, so
+   * repeating a request overwrites it. Large requests may outlast REQUEST_TIMEOUT; use cmd/seed for
+   * those.
+   */
+  async seed(body?: SeedRequest): Promise<SeedResult> {
+    return (await this.request<{ data: SeedResult }>("POST", "/v1/dev/seed", { body })).data;
+  }
+
+  /**
+   * List Events (GET /v1/events)
+   * Events matching the filters, one page at a time. Accept: application/x-ndjson streams every
+   * match, one event per line, ignoring page_size and page_token; application/vnd.api+json returns a
+   * JSON:API document with pagination links. Only public events are listed, except to admins, so a
+   * page can hold fewer than page_size events; keep following next_page_token.
+   */
+  async listEvents(params: ListEventsParams = {}): Promise<Page<EventResponse>> {
+    return this.request<Page<EventResponse>>("GET", "/v1/events", { query: { event_name: params.event_name, city: params.city, type: params.type, organizer_id: params.organizer_id, min_price: params.min_price, max_price: params.max_price, start_date: params.start_date, end_date: params.end_date, when: params.when, tz: params.tz, page_size: params.page_size, page_token: params.page_token, sort_key: params.sort_key, sort_dir: params.sort_dir, fields: params.fields }, headers: { "If-None-Match": params["If-None-Match"] } });
+  }
+
+  /**
+   * Create Event (POST /v1/events)
+   */
+  async createEvent(body: EventDTO): Promise<string> {
+    return (await this.request<{ data: string }>("POST", "/v1/events", { body })).data;
+  }
+
+  /**
+   * Batch Create Events (POST /v1/events/batch)
+   * Items are validated and stored independently: 201 when every item was created, 207 with per-item
+   * results when some failed.
+   */
+  async batchCreateEvents(body: BatchEventRequest): Promise<BatchCreateResult> {
+    return (await this.request<{ data: BatchCreateResult }>("POST", "/v1/events/batch", { body })).data;
+  }
+
+  /**
+   * Get Events by Ids (GET /v1/events/batch-get)
+   * Events in the order requested; unknown Ids are left out. Up to 100 Ids, comma-separated or
+   * repeated.
+   */
+  async getEvents(params: GetEventsParams): Promise<EventResponse[]> {
+    return (await this.request<{ data: EventResponse[] }>("GET", "/v1/events/batch-get", { query: { ids: params.ids } })).data;
+  }
+
+  /**
+   * List Featured Events (GET /v1/events/featured)
+   * Events whose promotion has not expired, sorted by start_time.
+   */
+  async listFeaturedEvents(): Promise<EventResponse[]> {
+    return (await this.request<{ data: EventResponse[] }>("GET", "/v1/events/featured")).data;
+  }
+
+  /**
+   * Import Events from CSV (POST /v1/events/import)
+   * Upload a CSV file (header: event_name,city,type,price,start_time,end_time; optional columns:
+   * description,visibility,latitude,longitude,event_url,image_url) and get a per-row report.
+   */
+  async importEvents(body: FormData): Promise<ImportReport> {
+    return (await this.request<{ data: ImportReport }>("POST", "/v1/events/import", { body, contentType: "multipart/form-data" })).data;
+  }
+
+  /**
+   * Import Events from CSV (async) (POST /v1/events/import-async)
+   * Same format as /events/import. Rows are stored in chunks by a background worker; poll GET
+   * /jobs/{id} for progress. Only routed when a task queue is configured.
+   */
+  async importEventsAsync(body: FormData): Promise<ImportJob> {
+    return (await this.request<{ data: ImportJob }>("POST", "/v1/events/import-async", { body, contentType: "multipart/form-data" })).data;
+  }
+
+  /**
+   * Price Histogram (GET /v1/events/price-buckets)
+   * Count events in consecutive price ranges [bound_i, bound_i+1); the last range is open-ended.
+   */
+  async priceBuckets(params: PriceBucketsParams = {}): Promise<PriceBucket[]> {
+    return (await this.request<{ data: PriceBucket[] }>("GET", "/v1/events/price-buckets", { query: { city: params.city, bounds: params.bounds } })).data;
+  }
+
+  /**
+   * Get Event by Slug (GET /v1/events/slug/{slug})
+   */
+  async getEventBySlug(slug: string): Promise<EventResponse> {
+    return (await this.request<{ data: EventResponse }>("GET", `/v1/events/slug/${encodeURIComponent(slug)}`)).data;
+  }
+
+  /**
+   * Event Statistics (GET /v1/events/stats)
+   * Count, average, minimum and maximum price of events per city or type.
+   */
+  async eventStats(params: EventStatsParams): Promise<EventStats[]> {
+    return (await this.request<{ data: EventStats[] }>("GET", "/v1/events/stats", { query: { group_by: params.group_by } })).data;
+  }
+
+  /**
+   * Event Summary (GET /v1/events/stats/summary)
+   * Count, total and average price of the events matching the list filters, computed by aggregation
+   * queries.
+   */
+  async eventSummary(params: EventSummaryParams = {}): Promise<EventSummary> {
+    return (await this.request<{ data: EventSummary }>("GET", "/v1/events/stats/summary", { query: { event_name: params.event_name, city: params.city, type: params.type, organizer_id: params.organizer_id, min_price: params.min_price, max_price: params.max_price, start_date: params.start_date, end_date: params.end_date, when: params.when, tz: params.tz } })).data;
+  }
+
+  /**
+   * List Event Types (GET /v1/events/types)
+   * Event types this deployment accepts in type fields and filters. Operators can change them
+   * without a release, so clients should not hard-code them.
+   */
+  async listEventTypes(): Promise<EventType[]> {
+    return (await this.request<{ data: EventType[] }>("GET", "/v1/events/types")).data;
+  }
+
+  /**
+   * Get Event (GET /v1/events/{id})
+   * Public and unlisted events are readable by anyone with the Id; private events only by their
+   * creator and admins, and are not found for everyone else.
+   */
+  async getEvent(id: string, params: GetEventParams = {}): Promise<EventResponse> {
+    return (await this.request<{ data: EventResponse }>("GET", `/v1/events/${encodeURIComponent(id)}`, { query: { fields: params.fields }, headers: { "If-None-Match": params["If-None-Match"] } })).data;
+  }
+
+  /**
+   * Update Event (PUT /v1/events/{id})
+   * Update specific fields of an event. Organizers may only update their own events.
+   */
+  async updateEvent(id: string, body: UpdateEventDTO): Promise<string> {
+    return (await this.request<{ data: string }>("PUT", `/v1/events/${encodeURIComponent(id)}`, { body })).data;
+  }
+
+  /**
+   * Delete Event (DELETE /v1/events/{id})
+   */
+  async deleteEvent(id: string): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", `/v1/events/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * List Attendees (GET /v1/events/{id}/attendees)
+   */
+  async listAttendees(id: string): Promise<RSVP[]> {
+    return (await this.request<{ data: RSVP[] }>("GET", `/v1/events/${encodeURIComponent(id)}/attendees`)).data;
+  }
+
+  /**
+   * Toggle Featured (PUT /v1/events/{id}/featured)
+   * Mark an event as featured until a given time, or remove the promotion.
+   */
+  async setEventFeatured(id: string, body: FeatureEventDTO): Promise<string> {
+    return (await this.request<{ data: string }>("PUT", `/v1/events/${encodeURIComponent(id)}/featured`, { body })).data;
+  }
+
+  /**
+   * Event History (GET /v1/events/{id}/history)
+   * Create, update and delete revisions of an event, newest first, with the acting user and field
+   * diff.
+   */
+  async eventHistory(id: string): Promise<EventRevision[]> {
+    return (await this.request<{ data: EventRevision[] }>("GET", `/v1/events/${encodeURIComponent(id)}/history`)).data;
+  }
+
+  /**
+   * RSVP to Event (POST /v1/events/{id}/rsvp)
+   * Register the authenticated user for an event, capacity permitting. 409 when the event is full or
+   * the user is already registered.
+   */
+  async rsvp(id: string): Promise<RSVP> {
+    return (await this.request<{ data: RSVP }>("POST", `/v1/events/${encodeURIComponent(id)}/rsvp`)).data;
+  }
+
+  /**
+   * List Ticket Tiers (GET /v1/events/{id}/tiers)
+   * Ticket tiers of an event, cheapest first.
+   */
+  async listTicketTiers(id: string): Promise<TicketTier[]> {
+    return (await this.request<{ data: TicketTier[] }>("GET", `/v1/events/${encodeURIComponent(id)}/tiers`)).data;
+  }
+
+  /**
+   * Create Ticket Tier (POST /v1/events/{id}/tiers)
+   */
+  async createTicketTier(id: string, body: TicketTierDTO): Promise<string> {
+    return (await this.request<{ data: string }>("POST", `/v1/events/${encodeURIComponent(id)}/tiers`, { body })).data;
+  }
+
+  /**
+   * Update Ticket Tier (PUT /v1/events/{id}/tiers/{tierId})
+   */
+  async updateTicketTier(id: string, tierId: string, body: TicketTierDTO): Promise<string> {
+    return (await this.request<{ data: string }>("PUT", `/v1/events/${encodeURIComponent(id)}/tiers/${encodeURIComponent(tierId)}`, { body })).data;
+  }
+
+  /**
+   * GraphQL Query (GET) (GET /v1/graphql)
+   */
+  async graphqlQueryGet(params: GraphqlQueryGetParams): Promise<GraphqlResponse> {
+    return this.request<GraphqlResponse>("GET", "/v1/graphql", { query: { query: params.query, operationName: params.operationName, variables: params.variables } });
+  }
+
+  /**
+   * GraphQL Query (POST /v1/graphql)
+   * Executes a read-only GraphQL query. Partial results come back with 200 and an errors list;
+   * requests that cannot run at all, including queries over the cost or alias limit, get 400.
+   */
+  async graphqlQuery(body: GraphqlRequest): Promise<GraphqlResponse> {
+    return this.request<GraphqlResponse>("POST", "/v1/graphql", { body });
+  }
+
+  /**
+   * GraphQL Schema (GET /v1/graphql/schema.graphql)
+   * The GraphQL schema in schema definition language.
+   */
+  async graphqlSchema(): Promise<Response> {
+    return this.send("GET", "/v1/graphql/schema.graphql", { headers: { Accept: "text/plain" } });
+  }
+
+  /**
+   * Liveness (GET /healthz)
+   */
+  async liveness(): Promise<HealthReport> {
+    return this.request<HealthReport>("GET", "/healthz");
+  }
+
+  /**
+   * Get Job Status (GET /v1/jobs/{id})
+   * Status, counters and row errors of an async import.
+   */
+  async getJob(id: string): Promise<ImportJob> {
+    return (await this.request<{ data: ImportJob }>("GET", `/v1/jobs/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * OpenAPI Document (GET /openapi.json)
+   * This document. Admin-only with API_DOCS=admin and not served with API_DOCS=off.
+   */
+  async openapi(): Promise<Record<string, unknown>> {
+    return this.request<Record<string, unknown>>("GET", "/openapi.json");
+  }
+
+  /**
+   * List Organizers (GET /v1/organizers)
+   * Every organizer, sorted by name.
+   */
+  async listOrganizers(): Promise<Organizer[]> {
+    return (await this.request<{ data: Organizer[] }>("GET", "/v1/organizers")).data;
+  }
+
+  /**
+   * Create Organizer (POST /v1/organizers)
+   */
+  async createOrganizer(body: OrganizerDTO): Promise<string> {
+    return (await this.request<{ data: string }>("POST", "/v1/organizers", { body })).data;
+  }
+
+  /**
+   * Get Organizer (GET /v1/organizers/{id})
+   */
+  async getOrganizer(id: string): Promise<Organizer> {
+    return (await this.request<{ data: Organizer }>("GET", `/v1/organizers/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Update Organizer (PUT /v1/organizers/{id})
+   */
+  async updateOrganizer(id: string, body: UpdateOrganizerDTO): Promise<string> {
+    return (await this.request<{ data: string }>("PUT", `/v1/organizers/${encodeURIComponent(id)}`, { body })).data;
+  }
+
+  /**
+   * Delete Organizer (DELETE /v1/organizers/{id})
+   */
+  async deleteOrganizer(id: string): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", `/v1/organizers/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Readiness (GET /readyz)
+   * Probes dependencies (Firestore) with a short deadline. 503 when any probe fails.
+   */
+  async readiness(): Promise<HealthReport> {
+    return this.request<HealthReport>("GET", "/readyz");
+  }
+
+  /**
+   * List Tracking Events (GET /v1/tracking)
+   */
+  async listTracking(): Promise<TrackingEventResponse[]> {
+    return (await this.request<{ data: TrackingEventResponse[] }>("GET", "/v1/tracking")).data;
+  }
+
+  /**
+   * Create Tracking Event (POST /v1/tracking)
+   */
+  async track(body: TrackingEventDTO): Promise<string | undefined> {
+    return (await this.request<{ data: string }>("POST", "/v1/tracking", { body }))?.data;
+  }
+
+  /**
+   * Delete Tracking Events (DELETE /v1/tracking)
+   * Remove tracking events older than a cutoff and/or of one action, and take them out of the stats.
+   * At least one filter is required. One call deletes at most 5000 events; repeat it while remaining
+   * is true.
+   */
+  async purgeTracking(params: PurgeTrackingParams = {}): Promise<PurgeResult> {
+    return (await this.request<{ data: PurgeResult }>("DELETE", "/v1/tracking", { query: { older_than: params.older_than, action: params.action } })).data;
+  }
+
+  /**
+   * Tracking Session (GET /v1/tracking/sessions/{id})
+   * Tracking events of a session, oldest first.
+   */
+  async trackingSession(id: string): Promise<TrackingEventResponse[]> {
+    return (await this.request<{ data: TrackingEventResponse[] }>("GET", `/v1/tracking/sessions/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Tracking Statistics (GET /v1/tracking/stats)
+   * Count tracking events per action in each UTC hour or day of a window, from rollups maintained on
+   * write; empty buckets are included.
+   */
+  async trackingStats(params: TrackingStatsParams = {}): Promise<TrackingStats[]> {
+    return (await this.request<{ data: TrackingStats[] }>("GET", "/v1/tracking/stats", { query: { group_by: params.group_by, interval: params.interval, from: params.from, to: params.to } })).data;
+  }
+
+  /**
+   * Delete Tracking Event (DELETE /v1/tracking/{id})
+   * Remove a tracking event, e.g. test traffic, and take it out of the stats.
+   */
+  async deleteTracking(id: string): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", `/v1/tracking/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Current User (GET /v1/users/me)
+   * UID, email, roles and custom claims from the verified token, plus the users/{uid} profile
+   * document if present.
+   */
+  async currentUser(): Promise<CurrentUser> {
+    return (await this.request<{ data: CurrentUser }>("GET", "/v1/users/me")).data;
+  }
+
+  /**
+   * List Favorites (GET /v1/users/me/favorites)
+   * Events bookmarked by the caller, newest first.
+   */
+  async listFavorites(): Promise<Favorite[]> {
+    return (await this.request<{ data: Favorite[] }>("GET", "/v1/users/me/favorites")).data;
+  }
+
+  /**
+   * Add Favorite (POST /v1/users/me/favorites/{eventId})
+   * Bookmark an event (idempotent).
+   */
+  async addFavorite(eventId: string): Promise<string> {
+    return (await this.request<{ data: string }>("POST", `/v1/users/me/favorites/${encodeURIComponent(eventId)}`)).data;
+  }
+
+  /**
+   * Remove Favorite (DELETE /v1/users/me/favorites/{eventId})
+   * Remove a bookmarked event (idempotent).
+   */
+  async removeFavorite(eventId: string): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", `/v1/users/me/favorites/${encodeURIComponent(eventId)}`)).data;
+  }
+
+  /**
+   * Get Subscription (GET /v1/users/me/subscription)
+   * Cities the caller follows and whether new events are emailed. Only routed when email
+   * notifications are configured.
+   */
+  async getSubscription(): Promise<Subscription> {
+    return (await this.request<{ data: Subscription }>("GET", "/v1/users/me/subscription")).data;
+  }
+
+  /**
+   * Update Subscription (POST /v1/users/me/subscription)
+   * Follow cities and opt in or out of email about new events there. Opting in requires a verified
+   * email on the account.
+   */
+  async updateSubscription(body: SubscriptionDTO): Promise<Subscription> {
+    return (await this.request<{ data: Subscription }>("POST", "/v1/users/me/subscription", { body })).data;
+  }
+
+  /**
+   * Delete Subscription (DELETE /v1/users/me/subscription)
+   * Unfollow all cities and stop email notifications (idempotent).
+   */
+  async deleteSubscription(): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", "/v1/users/me/subscription")).data;
+  }
+
+  /**
+   * List Push Subscriptions (GET /v1/users/me/subscriptions)
+   * Cities (and event types) the caller's devices receive pushes for.
+   */
+  async listPushSubscriptions(): Promise<PushSubscription[]> {
+    return (await this.request<{ data: PushSubscription[] }>("GET", "/v1/users/me/subscriptions")).data;
+  }
+
+  /**
+   * Subscribe to Push Notifications (POST /v1/users/me/subscriptions)
+   * Send an FCM push to the device (token) when an event is created in city; type narrows it to one
+   * event type. Idempotent.
+   */
+  async subscribePush(body: PushSubscriptionDTO): Promise<PushSubscription> {
+    return (await this.request<{ data: PushSubscription }>("POST", "/v1/users/me/subscriptions", { body })).data;
+  }
+
+  /**
+   * Unsubscribe from Push Notifications (DELETE /v1/users/me/subscriptions/{id})
+   */
+  async unsubscribePush(id: string): Promise<string> {
+    return (await this.request<{ data: string }>("DELETE", `/v1/users/me/subscriptions/${encodeURIComponent(id)}`)).data;
+  }
+
+  /**
+   * Delete User Data (DELETE /v1/users/{uid}/data)
+   * Deletes the user's tracking events, RSVPs and favorites and reports how many of each were
+   * removed. "me" stands for the caller; only admins may erase other users.
+   */
+  async deleteUserData(uid: string): Promise<DataDeletionReport> {
+    return (await this.request<{ data: DataDeletionReport }>("DELETE", `/v1/users/${encodeURIComponent(uid)}/data`)).data;
+  }
+
+  /**
+   * Version (GET /version)
+   * Git SHA, build time and Go version of the deployed binary.
+   */
+  async version(): Promise<BuildinfoInfo> {
+    return this.request<BuildinfoInfo>("GET", "/version");
+  }
+
+  /**
+   * Warm-up (GET /warmup)
+   * Initializes the instance and primes dependency connections with trivial reads. 503 when any
+   * probe fails.
+   */
+  async warmup(): Promise<HealthReport> {
+    return this.request<HealthReport>("GET", "/warmup");
+  }
+
+  /**
+   * Partner Event Webhook (POST /v1/webhooks/events)
+   * Create an event on behalf of a partner system. The body must be signed: X-Signature-Timestamp
+   * holds the Unix seconds it was signed at (within 5 minutes of the server clock) and X-Signature
+   * "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<raw body>" with the shared secret.
+   */
+  async partnerEventWebhook(body: EventDTO, params: PartnerEventWebhookParams): Promise<string> {
+    return (await this.request<{ data: string }>("POST", "/v1/webhooks/events", { headers: { "X-Signature-Timestamp": params["X-Signature-Timestamp"], "X-Signature": params["X-Signature"] }, body })).data;
+  }
+}
@@ -0,0 +1,104 @@
+// Request plumbing shared by the generated Client in api.gen.ts (make client).
+
+export interface ClientOptions {
+  /** Origin the function is served at; operations add the /v1 prefix */
+  baseUrl: string;
+  /** Firebase ID token sent as a bearer token; undefined sends none */
+  token?: () => string | undefined | Promise<string | undefined>;
+  /** Defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  query?: Record<string, string | number | boolean | null | undefined>;
+  headers?: Record<string, string | number | boolean | null | undefined>;
+  body?: unknown;
+  /** Media type of a body that is not JSON; FormData bodies get theirs from fetch */
+  contentType?: string;
+}
+
+/** A response outside 2xx, with the fields of the API's error model */
+export class APIError extends Error {
+  constructor(
+    readonly status: number,
+    message: string,
+    readonly code?: string,
+    readonly requestId?: string,
+    readonly data?: unknown,
+  ) {
+    super(message);
+    this.name = "APIError";
+  }
+}
+
+export class BaseClient {
+  private readonly baseUrl: string;
+  private readonly token?: ClientOptions["token"];
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, "");
+    this.token = options.token;
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis);
+  }
+
+  /** Sends the request and returns the response of a 2xx status */
+  protected async send(method: string, path: string, options: RequestOptions = {}): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(options.query ?? {})) {
+      if (value !== undefined && value !== null && value !== "") {
+        url.searchParams.set(key, String(value));
+      }
+    }
+    const headers = new Headers({ Accept: "application/json" });
+    for (const [key, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(key, String(value));
+      }
+    }
+    let body: BodyInit | undefined;
+    if (options.body !== undefined) {
+      if (options.contentType === undefined) {
+        body = JSON.stringify(options.body);
+        headers.set("Content-Type", "application/json");
+      } else {
+        body = options.body as BodyInit;
+        if (!(body instanceof FormData)) {
+          headers.set("Content-Type", options.contentType);
+        }
+      }
+    }
+    const token = await this.token?.();
+    if (token) {
+      headers.set("Authorization", `Bearer ${token}`);
+    }
+
+    const response = await this.fetchFn(url, { method, headers, body });
+    if (!response.ok) {
+      throw await toAPIError(response);
+    }
+    return response;
+  }
+
+  /** Sends the request and parses the JSON response; 204 gives undefined */
+  protected async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const response = await this.send(method, path, options);
+    if (response.status === 204) {
+      return undefined as T;
+    }
+    return (await response.json()) as T;
+  }
+}
+
+async function toAPIError(response: Response): Promise<APIError> {
+  const text = await response.text();
+  try {
+    const body = JSON.parse(text);
+    if (typeof body?.error === "string") {
+      return new APIError(response.status, body.error, body.code, body.request_id, body.data);
+    }
+  } catch {
+    // Not the error model, e.g. the auth middleware's plain-text 403
+  }
+  return new APIError(response.status, text.trim() || response.statusText);
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	emulatorAuth "bibently.com/backend/internal/auth"
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+
+	firebase "firebase.google.com/go/v4"
+)
+
+// signInWithCustomTokenURL exchanges a custom token for the ID token the API verifies
+const signInWithCustomTokenURL = "https://identitytoolkit.googleapis.com/v1/accounts:signInWithCustomToken"
+
+// apiBackend calls the versioned HTTP API as an admin
+type apiBackend struct {
+	base   string
+	token  string
+	client *http.Client
+}
+
+func newAPIBackend(baseURL, token string) *apiBackend {
+	return &apiBackend{
+		base:   strings.TrimSuffix(baseURL, "/") + "/v1",
+		token:  token,
+		client: &http.Client{Timeout: 5 * time.Minute}, // exports stream for a while
+	}
+}
+
+// do sends the request and decodes the data of a successful response into out, when given.
+// Failures are reported with the API's error message.
+func (b *apiBackend) do(ctx context.Context, method, path string, body, out interface{}) error {
+	resp, err := b.send(ctx, method, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(&domain.APIResponse{Data: out})
+}
+
+func (b *apiBackend) send(ctx context.Context, method, path string, body interface{}, accept string) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.base+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	req.Header.Set("Accept", accept)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		var apiErr domain.APIResponse
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("%s %s: %s (%s)", method, path, apiErr.Error, resp.Status)
+		}
+		return nil, fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return resp, nil
+}
+
+func (b *apiBackend) CreateEvent(ctx context.Context, dto *domain.EventDTO) (string, error) {
+	var id string
+	err := b.do(ctx, http.MethodPost, "/events/", dto, &id)
+	return id, err
+}
+
+func (b *apiBackend) DeleteEvent(ctx context.Context, id string) error {
+	return b.do(ctx, http.MethodDelete, "/events/"+url.PathEscape(id), nil, nil)
+}
+
+func (b *apiBackend) ListEvents(ctx context.Context, filters listFilters) ([]domain.Event, error) {
+	q := filters.query()
+	q.Set("sort_key", filters.Sort)
+	q.Set("page_size", strconv.Itoa(filters.Limit))
+	// Sparse fieldsets are keyed by the stored field names, whatever the deployment's default projection
+	q.Set("fields", "id,event_name,city,price,start_time")
+	var rows []struct {
+		Id        string    `json:"id"`
+		EventName string    `json:"event_name"`
+		City      string    `json:"city"`
+		Price     float64   `json:"price"`
+		StartTime time.Time `json:"start_time"`
+	}
+	if err := b.do(ctx, http.MethodGet, "/events/?"+q.Encode(), nil, &rows); err != nil {
+		return nil, err
+	}
+	events := make([]domain.Event, len(rows))
+	for i, row := range rows {
+		events[i] = domain.Event{Id: row.Id, EventName: row.EventName, City: row.City, Price: row.Price, StartTime: row.StartTime}
+	}
+	return events, nil
+}
+
+func (b *apiBackend) PurgeTracking(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error) {
+	q := url.Values{}
+	if !filter.OlderThan.IsZero() {
+		q.Set("older_than", filter.OlderThan.Format(time.RFC3339))
+	}
+	if filter.Action != "" {
+		q.Set("action", filter.Action)
+	}
+	var result domain.PurgeResult
+	err := b.do(ctx, http.MethodDelete, "/tracking/?"+q.Encode(), nil, &result)
+	return &result, err
+}
+
+// ExportEvents copies the API's NDJSON export; a stream cut short ends with an {"error": ...} line
+func (b *apiBackend) ExportEvents(ctx context.Context, filters listFilters, w io.Writer) (int, error) {
+	resp, err := b.send(ctx, http.MethodGet, "/events/?"+filters.query().Encode(), nil, "application/x-ndjson")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	n := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var failure domain.APIResponse
+		if bytes.HasPrefix(line, []byte(`{"error"`)) && json.Unmarshal(line, &failure) == nil {
+			return n, fmt.Errorf("export interrupted after %d events: %s (request %s)", n, failure.Error, failure.RequestID)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, scanner.Err()
+}
+
+func (f listFilters) query() url.Values {
+	q := url.Values{}
+	if f.City != "" {
+		q.Set("city", f.City)
+	}
+	if f.Name != "" {
+		q.Set("event_name", f.Name)
+	}
+	return q
+}
+
+// adminToken returns an ID token of FIRESTORE_ADMIN_UID: unsigned for the Auth emulator, otherwise
+// a custom token signed with the service account credentials and exchanged using FIREBASE_API_KEY
+func adminToken(ctx context.Context, newApp func() (*firebase.App, error), cfg *config.Config) (string, error) {
+	uid := cfg.Auth.AdminUID
+	if uid == "" {
+		return "", errors.New("FIRESTORE_ADMIN_UID is not set")
+	}
+	if cfg.Server.AuthEmulatorHost != "" {
+		return emulatorAuth.GenerateEmulatorToken(cfg.ProjectID, uid), nil
+	}
+	apiKey := os.Getenv("FIREBASE_API_KEY")
+	if apiKey == "" {
+		return "", errors.New("FIREBASE_API_KEY is not set")
+	}
+
+	app, err := newApp()
+	if err != nil {
+		return "", err
+	}
+	client, err := app.Auth(ctx)
+	if err != nil {
+		return "", err
+	}
+	custom, err := client.CustomToken(ctx, uid)
+	if err != nil {
+		return "", fmt.Errorf("signing a custom token: %w", err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"token": custom, "returnSecureToken": true})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, signInWithCustomTokenURL+"?key="+url.QueryEscape(apiKey), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var out struct {
+		IDToken string `json:"idToken"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if out.IDToken == "" {
+		return "", fmt.Errorf("exchanging the custom token: %s %s", resp.Status, out.Error.Message)
+	}
+	return out.IDToken, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"io"
+
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+)
+
+// listFilters are the event filters the list and export commands take
+type listFilters struct {
+	City  string
+	Name  string
+	Sort  string
+	Limit int
+}
+
+func (f listFilters) search() domain.SearchRequest {
+	return domain.SearchRequest{
+		Filters: domain.FilterRequest{City: f.City, EventName: f.Name},
+		Sorting: domain.SortRequest{SortKey: cmp.Or(f.Sort, "start_time"), SortDirection: "asc", PageSize: f.Limit},
+	}
+}
+
+// backend carries out the data commands, against Firestore or the deployed API
+type backend interface {
+	CreateEvent(ctx context.Context, dto *domain.EventDTO) (string, error)
+	DeleteEvent(ctx context.Context, id string) error
+	ListEvents(ctx context.Context, filters listFilters) ([]domain.Event, error)
+	// PurgeTracking runs one capped deletion; Remaining asks for another call
+	PurgeTracking(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error)
+	// ExportEvents writes every matching event to w as NDJSON, the format of the API's exports
+	ExportEvents(ctx context.Context, filters listFilters, w io.Writer) (int, error)
+}
+
+// firestoreBackend goes through the services, so writes are validated, audited in the event
+// history and attributed to the admin like API calls
+type firestoreBackend struct {
+	pool     *repository.ClientPool
+	events   service.EventService
+	tracking service.TrackingService
+	admin    domain.Principal
+}
+
+func newFirestoreBackend(ctx context.Context, cfg *config.Config) (*firestoreBackend, error) {
+	pool := repository.NewClientPool(cfg.ProjectID)
+	client, err := pool.Client(ctx, cfg.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	var trackingOpts []service.TrackingServiceOption
+	if cfg.Tracking.TTL {
+		trackingOpts = append(trackingOpts, service.WithTrackingExpiry(cfg.Tracking.RetentionDays))
+	}
+	eventOpts := []service.EventServiceOption{service.WithDuplicatePolicy(cfg.Events.DuplicatePolicy)}
+	if cfg.Events.StripTrackingParams {
+		eventOpts = append(eventOpts, service.WithTrackingParamsStripped())
+	}
+	return &firestoreBackend{
+		pool:     pool,
+		events:   service.NewEventService(repository.NewEventRepository(client), repository.NewRevisionRepository(client), eventOpts...),
+		tracking: service.NewTrackingService(repository.NewTrackingRepository(client), trackingOpts...),
+		admin:    domain.Principal{UID: cmp.Or(cfg.Auth.AdminUID, "admin-cli"), Role: domain.RoleAdmin},
+	}, nil
+}
+
+func (b *firestoreBackend) Close() error {
+	return b.pool.Close()
+}
+
+func (b *firestoreBackend) CreateEvent(ctx context.Context, dto *domain.EventDTO) (string, error) {
+	event, err := domain.EventDTOToModel(dto)
+	if err != nil {
+		return "", err
+	}
+	if err := b.events.CreateEvent(domain.WithPrincipal(ctx, b.admin), event); err != nil {
+		return "", err
+	}
+	return event.Id, nil
+}
+
+func (b *firestoreBackend) DeleteEvent(ctx context.Context, id string) error {
+	return b.events.DeleteEvent(domain.WithPrincipal(ctx, b.admin), id)
+}
+
+func (b *firestoreBackend) ListEvents(ctx context.Context, filters listFilters) ([]domain.Event, error) {
+	events, _, err := b.events.ListEvents(domain.WithPrincipal(ctx, b.admin), filters.search())
+	return events, err
+}
+
+func (b *firestoreBackend) PurgeTracking(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error) {
+	return b.tracking.DeleteTrackingWhere(domain.WithPrincipal(ctx, b.admin), filter)
+}
+
+func (b *firestoreBackend) ExportEvents(ctx context.Context, filters listFilters, w io.Writer) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+	err := b.events.StreamEvents(domain.WithPrincipal(ctx, b.admin), filters.search(), func(event *domain.Event) error {
+		n++
+		return enc.Encode(event)
+	})
+	return n, err
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+
+	firebase "firebase.google.com/go/v4"
+)
+
+const usage = `usage: admin [-api URL] [-token TOKEN] <command> [flags] [args]
+
+Commands:
+  create-event    -name NAME -city CITY -type TYPE -start RFC3339 [-end RFC3339] [-price N] [-capacity N] [-organizer ID]
+  delete-event    ID
+  list            [-city CITY] [-name PREFIX] [-sort KEY] [-limit N]
+  grant-role      UID organizer|none
+  purge-tracking  [-older-than AGE] [-action ACTION]
+  export          [-city CITY] [-o FILE]
+  migrate-locations
+
+Without -api, commands read and write the Firestore database of the environment (.env, like
+make run) with the admin role. With -api (e.g. https://REGION-PROJECT.cloudfunctions.net/BibentlyFunctions)
+they call the deployed API with -token, $ADMIN_TOKEN, or an ID token minted for FIRESTORE_ADMIN_UID
+with the service account credentials (FIREBASE_API_KEY names the web API key to exchange it with).
+grant-role always sets the Firebase custom claim directly, and migrate-locations always works on
+Firestore: it converts the latitude/longitude strings of older events to location geopoints, in
+every tenant, and can be rerun.
+`
+
+// main runs one operational command against Firestore or the deployed API
+func main() {
+	log.SetFlags(0)
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	apiURL := flag.String("api", "", "base URL of the deployed API; empty talks to Firestore directly")
+	token := flag.String("token", os.Getenv("ADMIN_TOKEN"), "Firebase ID token of an admin, for -api")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command, args := flag.Arg(0), flag.Args()[1:]
+
+	ctx := context.Background()
+	cfg, err := config.FromEnv(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Only grant-role and token minting need Firebase Auth, and with it credentials
+	newApp := func() (*firebase.App, error) {
+		return firebase.NewApp(ctx, &firebase.Config{ProjectID: cfg.ProjectID})
+	}
+
+	if command == "grant-role" {
+		if err := grantRole(ctx, newApp, args); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if command == "migrate-locations" {
+		if err := migrateLocations(ctx, cfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var b backend
+	if *apiURL != "" {
+		if *token == "" {
+			if *token, err = adminToken(ctx, newApp, cfg); err != nil {
+				log.Fatalf("no -token given and none could be minted: %v", err)
+			}
+		}
+		b = newAPIBackend(*apiURL, *token)
+	} else {
+		fb, err := newFirestoreBackend(ctx, cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fb.Close()
+		b = fb
+	}
+
+	if err := run(ctx, b, command, args, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes a data command through b, writing its output to out
+func run(ctx context.Context, b backend, command string, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	switch command {
+	case "create-event":
+		var dto domain.EventDTO
+		fs.StringVar(&dto.EventName, "name", "", "event name")
+		fs.StringVar(&dto.City, "city", "", "city")
+		typ := fs.String("type", string(domain.TypeOther), "event type")
+		fs.StringVar(&dto.StartTime, "start", "", "start time, RFC 3339")
+		fs.StringVar(&dto.EndTime, "end", "", "end time, RFC 3339")
+		fs.Float64Var(&dto.Price, "price", 0, "price")
+		fs.IntVar(&dto.Capacity, "capacity", 0, "capacity, 0 for unlimited")
+		fs.StringVar(&dto.OrganizerID, "organizer", "", "organizer Id")
+		fs.Parse(args)
+		dto.Type = domain.EventType(*typ)
+		if err := domain.Validate.Struct(dto); err != nil {
+			return err
+		}
+		id, err := b.CreateEvent(ctx, &dto)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, id)
+
+	case "delete-event":
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: admin delete-event ID")
+		}
+		if err := b.DeleteEvent(ctx, fs.Arg(0)); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "deleted", fs.Arg(0))
+
+	case "list":
+		var filters listFilters
+		fs.StringVar(&filters.City, "city", "", "only events in this city")
+		fs.StringVar(&filters.Name, "name", "", "only events whose name starts with this")
+		fs.StringVar(&filters.Sort, "sort", "start_time", "sort key: "+strings.Join(domain.EventListSortKeys, ", "))
+		fs.IntVar(&filters.Limit, "limit", 20, "most events to list (at most 100)")
+		fs.Parse(args)
+		events, err := b.ListEvents(ctx, filters)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tSTART\tCITY\tPRICE\tNAME")
+		for _, e := range events {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%s\n", e.Id, e.StartTime.Format(time.DateTime), e.City, e.Price, e.EventName)
+		}
+		return tw.Flush()
+
+	case "purge-tracking":
+		var filter domain.TrackingDeleteFilter
+		olderThan := fs.Duration("older-than", 0, "delete tracking older than this age, e.g. 2160h")
+		fs.StringVar(&filter.Action, "action", "", "delete only tracking with this action")
+		fs.Parse(args)
+		if *olderThan > 0 {
+			filter.OlderThan = time.Now().UTC().Add(-*olderThan)
+		}
+		if filter.IsZero() {
+			return fmt.Errorf("purge-tracking needs -older-than or -action")
+		}
+		// One call deletes a capped number of events; repeat until none remain
+		total := 0
+		for {
+			result, err := b.PurgeTracking(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("purged %d before failing: %w", total, err)
+			}
+			total += result.Purged
+			if !result.Remaining {
+				break
+			}
+		}
+		fmt.Fprintf(out, "purged %d tracking events\n", total)
+
+	case "export":
+		var filters listFilters
+		fs.StringVar(&filters.City, "city", "", "only events in this city")
+		path := fs.String("o", "", "output file (default stdout)")
+		fs.Parse(args)
+		w := out
+		if *path != "" {
+			f, err := os.Create(*path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		n, err := b.ExportEvents(ctx, filters, w)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d events\n", n)
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+	return nil
+}
+
+// grantRole sets the "role" custom claim WithAuthProtection reads, keeping the user's other claims.
+// The admin role is not a claim: it belongs to FIRESTORE_ADMIN_UID.
+func grantRole(ctx context.Context, newApp func() (*firebase.App, error), args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: admin grant-role UID organizer|none")
+	}
+	uid, role := args[0], args[1]
+	if role != domain.RoleOrganizer && role != "none" {
+		return fmt.Errorf("role must be %s or none; the admin is FIRESTORE_ADMIN_UID", domain.RoleOrganizer)
+	}
+	app, err := newApp()
+	if err != nil {
+		return err
+	}
+	client, err := app.Auth(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := client.GetUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	claims := user.CustomClaims
+	if claims == nil {
+		claims = map[string]interface{}{}
+	}
+	if role == "none" {
+		delete(claims, "role")
+	} else {
+		claims["role"] = role
+	}
+	if err := client.SetCustomUserClaims(ctx, uid, claims); err != nil {
+		return err
+	}
+	encoded, _ := json.Marshal(claims)
+	fmt.Printf("%s (%s) now has claims %s; it applies to ID tokens issued from now on\n", uid, cmp.Or(user.Email, "no email"), encoded)
+	return nil
+}
+
+// migrateLocations runs repository.MigrateLocations on the database of the environment
+func migrateLocations(ctx context.Context, cfg *config.Config) error {
+	pool := repository.NewClientPool(cfg.ProjectID)
+	defer pool.Close()
+	client, err := pool.Client(ctx, cfg.DatabaseID)
+	if err != nil {
+		return err
+	}
+	migrated, invalid, err := repository.MigrateLocations(ctx, client)
+	fmt.Printf("migrated %d locations, %d events left with invalid coordinates\n", migrated, invalid)
+	return err
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// main compares two `go test -bench` outputs and exits non-zero when a benchmark of the new run
+// is slower than the baseline by more than -max-regression. Each benchmark is compared by the
+// median of its ns/op over the -count runs, which tolerates a few noisy emulator round trips.
+func main() {
+	maxRegression := flag.Float64("max-regression", 20, "largest accepted slowdown of a benchmark, in percent")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: benchguard [-max-regression percent] baseline.txt new.txt")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	baseline, err := readResults(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	current, err := readResults(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tBASELINE\tNEW\tDELTA\t")
+	regressed := 0
+	for _, name := range names {
+		now := median(current[name])
+		before, ok := baseline[name]
+		if !ok {
+			fmt.Fprintf(tw, "%s\t-\t%s\tnew\t\n", name, formatNs(now))
+			continue
+		}
+		was := median(before)
+		delta := (now - was) / was * 100
+		verdict := ""
+		if delta > *maxRegression {
+			verdict = "REGRESSION"
+			regressed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%+.1f%%\t%s\n", name, formatNs(was), formatNs(now), delta, verdict)
+	}
+	_ = tw.Flush()
+
+	if regressed > 0 {
+		log.Fatalf("%d benchmark(s) regressed by more than %.0f%%", regressed, *maxRegression)
+	}
+}
+
+// readResults returns the ns/op values of every benchmark in a `go test -bench` output, keyed by
+// name without the GOMAXPROCS suffix
+func readResults(path string) (map[string][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	results, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%s: no benchmark results", path)
+	}
+	return results, nil
+}
+
+func parse(r io.Reader) (map[string][]float64, error) {
+	results := make(map[string][]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// BenchmarkEventRepository_List/city-8   	     100	   1234567 ns/op	  2048 B/op	  30 allocs/op
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			if fields[i+1] != "ns/op" {
+				continue
+			}
+			ns, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fields[0], err)
+			}
+			name := fields[0]
+			if i := strings.LastIndex(name, "-"); i > 0 {
+				if _, err := strconv.Atoi(name[i+1:]); err == nil {
+					name = name[:i]
+				}
+			}
+			results[name] = append(results[name], ns)
+		}
+	}
+	return results, scanner.Err()
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Sorted(slices.Values(values))
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func formatNs(ns float64) string {
+	switch {
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fµs", ns/1e3)
+	}
+	return fmt.Sprintf("%.0fns", ns)
+}
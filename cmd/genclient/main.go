@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"bibently.com/backend/internal/clientgen"
+	"bibently.com/backend/internal/transport"
+)
+
+// main generates the Go and TypeScript clients in client/ from the OpenAPI document the API
+// serves at /openapi.json. Only api.gen.go and api.gen.ts are written; the request plumbing next
+// to them is maintained by hand.
+func main() {
+	goDir := flag.String("go", "client/bibently", "directory of the Go client package")
+	tsDir := flag.String("ts", "client/typescript", "directory of the TypeScript client")
+	check := flag.Bool("check", false, "exit non-zero if the clients are out of date instead of writing them")
+	flag.Parse()
+
+	doc := transport.OpenAPISpec()
+	goSrc, err := clientgen.Go(doc, filepath.Base(*goDir))
+	if err != nil {
+		log.Fatalf("generate Go client: %v", err)
+	}
+	tsSrc, err := clientgen.TypeScript(doc)
+	if err != nil {
+		log.Fatalf("generate TypeScript client: %v", err)
+	}
+
+	files := map[string][]byte{
+		filepath.Join(*goDir, "api.gen.go"): goSrc,
+		filepath.Join(*tsDir, "api.gen.ts"): tsSrc,
+	}
+	for path, out := range files {
+		current, _ := os.ReadFile(path)
+		if *check {
+			if !bytes.Equal(current, out) {
+				log.Fatalf("%s is out of date; run make client", path)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+)
+
+// firestoreIndexLimit is Firestore's default cap on composite indexes per database
+const firestoreIndexLimit = 200
+
+// indexFile is firestore.indexes.json; fieldOverrides are carried over untouched
+type indexFile struct {
+	Indexes        []repository.CompositeIndex `json:"indexes"`
+	FieldOverrides json.RawMessage             `json:"fieldOverrides,omitempty"`
+}
+
+// main derives the composite indexes the events collection needs from the repository's query
+// planning and writes them into firestore.indexes.json. Indexes of other collections are kept;
+// existing event indexes are kept too unless -prune is given.
+func main() {
+	path := flag.String("file", "firestore.indexes.json", "index definition file to update")
+	sortKeys := flag.String("sort-keys", strings.Join(domain.EventListSortKeys, ","), "comma-separated sort keys clients may request")
+	maxRange := flag.Int("max-range-filters", 0, "most range filters (name, city, price, dates) combined in one query; 0 means no limit")
+	group := flag.Bool("collection-group", false, "generate COLLECTION_GROUP indexes, for EVENTS_COLLECTION_GROUP deployments")
+	prune := flag.Bool("prune", false, "drop event indexes that are no longer derived")
+	check := flag.Bool("check", false, "exit non-zero if the file is out of date instead of writing it")
+	flag.Parse()
+
+	var file indexFile
+	if data, err := os.ReadFile(*path); err == nil {
+		if err := json.Unmarshal(data, &file); err != nil {
+			log.Fatalf("parse %s: %v", *path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+
+	derived := repository.EventIndexes(repository.IndexOptions{
+		SortKeys:        strings.Split(*sortKeys, ","),
+		MaxRangeFilters: *maxRange,
+		CollectionGroup: *group,
+	})
+	updated := merge(file.Indexes, derived, *prune)
+
+	if len(updated) > firestoreIndexLimit {
+		log.Fatalf("%d composite indexes (%d for events) exceed Firestore's limit of %d per database; "+
+			"narrow the query surface with -max-range-filters or -sort-keys", len(updated), len(derived), firestoreIndexLimit)
+	}
+
+	file.Indexes = updated
+	out := encode(file)
+	current, _ := os.ReadFile(*path)
+	if *check {
+		if !bytes.Equal(current, out) {
+			log.Fatalf("%s is out of date; run make indexes", *path)
+		}
+		return
+	}
+	if err := os.WriteFile(*path, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %d composite indexes (%d for events) to %s\n", len(updated), len(derived), *path)
+}
+
+// merge keeps other collections' indexes in their original order and appends the event indexes sorted
+func merge(existing, derived []repository.CompositeIndex, prune bool) []repository.CompositeIndex {
+	var result, events []repository.CompositeIndex
+	for _, idx := range existing {
+		if idx.CollectionGroup != repository.CollectionEvents {
+			result = append(result, idx)
+		} else if !prune {
+			events = append(events, idx)
+		}
+	}
+	for _, idx := range derived {
+		if !slices.ContainsFunc(events, func(e repository.CompositeIndex) bool { return e.Key() == idx.Key() }) {
+			events = append(events, idx)
+		}
+	}
+	slices.SortFunc(events, func(a, b repository.CompositeIndex) int {
+		return strings.Compare(a.Key(), b.Key())
+	})
+	return append(result, events...)
+}
+
+// encode writes the file in the layout it is kept in, one index field per line
+func encode(file indexFile) []byte {
+	var b bytes.Buffer
+	b.WriteString("{\n  \"indexes\": [\n")
+	for i, idx := range file.Indexes {
+		fmt.Fprintf(&b, "    {\n      \"collectionGroup\": %q,\n      \"queryScope\": %q,\n      \"fields\": [\n", idx.CollectionGroup, idx.QueryScope)
+		for j, f := range idx.Fields {
+			field, _ := json.Marshal(f)
+			line := strings.NewReplacer(`{"`, `{ "`, `":`, `": `, `,"`, `, "`, `"}`, `" }`).Replace(string(field))
+			b.WriteString("        " + line + separator(j, len(idx.Fields)) + "\n")
+		}
+		b.WriteString("      ]\n    }" + separator(i, len(file.Indexes)) + "\n")
+	}
+	b.WriteString("  ]")
+	if len(file.FieldOverrides) > 0 {
+		var overrides bytes.Buffer
+		_ = json.Indent(&overrides, file.FieldOverrides, "  ", "  ")
+		b.WriteString(",\n  \"fieldOverrides\": " + overrides.String())
+	}
+	b.WriteString("\n}")
+	return b.Bytes()
+}
+
+func separator(i, n int) string {
+	if i < n-1 {
+		return ","
+	}
+	return ""
+}
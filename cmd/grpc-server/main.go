@@ -0,0 +1,59 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	function "bibently.com/backend"
+	"bibently.com/backend/internal/config"
+)
+
+// main serves the gRPC API (api/events/v1) on PORT (default 50051). It runs next to the HTTP
+// function, e.g. on Cloud Run with HTTP/2 end-to-end enabled.
+func main() {
+	cfg, err := config.FromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	function.Configure(cfg)
+	port := cmp.Or(cfg.Server.Port, "50051")
+
+	server := function.GRPCServer()
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("failed to listen on port %s: %v", port, err)
+	}
+
+	// Cloud Run sends SIGTERM before stopping an instance; let open calls and watches finish
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
+		<-sig
+		log.Println("shutting down gRPC server")
+		// Watch streams only end when clients cancel, so graceful shutdown gets a deadline
+		timer := time.AfterFunc(8*time.Second, server.Stop)
+		server.GracefulStop()
+		timer.Stop()
+	}()
+
+	log.Println("gRPC server listening on :" + port)
+	if err := server.Serve(lis); err != nil {
+		log.Fatalf("grpc serve: %v", err)
+	}
+
+	// Serve returns once stopped; export the spans still queued before the instance goes away
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := function.Shutdown(ctx); err != nil {
+		log.Printf("flushing traces: %v", err)
+	}
+}
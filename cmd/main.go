@@ -1,18 +1,19 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"log"
-	"os"
 	"time"
 
 	// 1. Load .env BEFORE importing the function package
 	_ "github.com/joho/godotenv/autoload"
 
-	// Blank-import the function package so the init() runs
-	_ "bibently.com/backend"
+	// Importing the function package runs its init(), which registers the entry points
+	function "bibently.com/backend"
 
 	emulatorAuth "bibently.com/backend/internal/auth"
+	"bibently.com/backend/internal/config"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
@@ -21,26 +22,30 @@ import (
 
 // the main function starts the Functions Framework server - only needed when running locally
 func main() {
-	// 1. Setup Port
-	port := "3000"
-	if envPort := os.Getenv("PORT"); envPort != "" {
-		port = envPort
+	// 0. Load and check the configuration up front, so a bad .env fails here rather than on the first request
+	cfg, err := config.FromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
 	}
+	function.Configure(cfg)
+
+	// 1. Setup Port
+	port := cmp.Or(cfg.Server.Port, "3000")
 
 	// 2. Setup Hostname (Local Only)
 	hostname := ""
-	if localOnly := os.Getenv("LOCAL_ONLY"); localOnly == "true" {
+	if cfg.Server.LocalOnly {
 		hostname = "127.0.0.1"
 	}
 
 	// 3. NEW: Create Local Admin User if Emulator is detected
 	// This ensures the admin UID exists in the Auth Emulator so tokens are valid.
-	if os.Getenv("FIREBASE_AUTH_EMULATOR_HOST") != "" {
-		go createLocalAdminUser()
+	if cfg.Server.AuthEmulatorHost != "" {
+		go createLocalAdminUser(cfg.Auth.AdminUID, cfg.ProjectID)
 	}
 
 	log.Println("Server starting on http://127.0.0.1:" + port)
-	log.Println("Swagger UI: http://127.0.0.1:" + port + "/swagger/index.html")
+	log.Println("Swagger UI: http://127.0.0.1:" + port + "/swagger/")
 
 	// 4. Start Server
 	if err := funcframework.StartHostPort(hostname, port); err != nil {
@@ -48,22 +53,16 @@ func main() {
 	}
 }
 
-func createLocalAdminUser() {
+func createLocalAdminUser(adminUID, projectID string) {
 	// Give the server/emulator a split second to settle
 	time.Sleep(1 * time.Second)
 
 	ctx := context.Background()
-	adminUID := os.Getenv("FIRESTORE_ADMIN_UID")
 	if adminUID == "" {
 		log.Println("⚠️  Skipping local user creation: FIRESTORE_ADMIN_UID not set")
 		return
 	}
 
-	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
-	if projectID == "" {
-		projectID = "local-project-id"
-	}
-
 	conf := &firebase.Config{ProjectID: projectID}
 	app, err := firebase.NewApp(ctx, conf)
 	if err != nil {
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+)
+
+// main fills the Firestore database of the environment (.env, like make run) with generated
+// events and tracking: the emulator when FIRESTORE_EMULATOR_HOST is set, otherwise the project's
+// database, which must be named with -project so a dev project is never seeded by accident.
+func main() {
+	numEvents := flag.Int("events", domain.DefaultSeedEvents, "events to generate")
+	numTracking := flag.Int("tracking", domain.DefaultSeedTracking, "most tracking events per event")
+	cities := flag.String("cities", strings.Join(domain.DefaultSeedCities, ","), "comma-separated cities")
+	from := flag.String("from", "", "first start date, YYYY-MM-DD (default today)")
+	to := flag.String("to", "", "start dates end before this day, YYYY-MM-DD (default 90 days after -from)")
+	seed := flag.Int64("seed", 1, "random seed; a seed already used overwrites its data")
+	project := flag.String("project", "", "project to seed when not using the emulator")
+	flag.Parse()
+
+	cfg, err := config.FromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.Production {
+		log.Fatal("refusing to seed with APP_ENV=production")
+	}
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" && *project != cfg.ProjectID {
+		log.Fatalf("not using the emulator: pass -project %s to seed it", cfg.ProjectID)
+	}
+
+	req := domain.SeedRequest{
+		Events:           *numEvents,
+		TrackingPerEvent: *numTracking,
+		Cities:           strings.Split(*cities, ","),
+		From:             parseDate(*from),
+		To:               parseDate(*to),
+		Seed:             *seed,
+	}
+
+	ctx := context.Background()
+	pool := repository.NewClientPool(cfg.ProjectID)
+	defer pool.Close()
+	client, err := pool.Client(ctx, cfg.DatabaseID)
+	if err != nil {
+		log.Fatalf("firestore client: %v", err)
+	}
+	events := service.NewEventService(repository.NewEventRepository(client), repository.NewRevisionRepository(client))
+	tracking := service.NewTrackingService(repository.NewTrackingRepository(client))
+
+	start := time.Now()
+	result, err := service.NewSeedService(events, tracking).Seed(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("seeded %d events and %d tracking events into %s/%s in %s (%d failed)\n",
+		result.Events, result.Tracking, cfg.ProjectID, cmp.Or(cfg.DatabaseID, "(default)"), time.Since(start).Round(time.Millisecond), result.Failed)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseDate(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		log.Fatalf("invalid date %q: %v", s, err)
+	}
+	return t
+}
@@ -3,37 +3,57 @@ package function
 import (
 	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"strings"
+	"slices"
 	"sync"
 	"time"
 
+	"bibently.com/backend/internal/analytics"
+	"bibently.com/backend/internal/cache"
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/eventtypes"
+	"bibently.com/backend/internal/flags"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/notify"
+	"bibently.com/backend/internal/ratelimit"
 	"bibently.com/backend/internal/repository"
 	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/tasks"
+	"bibently.com/backend/internal/tracing"
 	"bibently.com/backend/internal/transport"
+	"bibently.com/backend/internal/triggers"
 
 	"cloud.google.com/go/firestore"
+	apiv1admin "cloud.google.com/go/firestore/apiv1/admin"
 	firebase "firebase.google.com/go/v4"
 	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
-
-	_ "bibently.com/backend/docs"
-
-	httpSwagger "github.com/swaggo/http-swagger"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
 )
 
 // Global variables to hold the initialized state
 var (
 	functionHandler http.Handler
+	eventTrigger    func(context.Context, cloudevents.Event) error
+	grpcServer      *grpc.Server
+	healthProbes    map[string]transport.HealthProbe
+	traceProvider   *sdktrace.TracerProvider
 	initOnce        sync.Once
+	// appConfig is set by Configure; otherwise setupApplication loads it from the environment
+	appConfig *config.Config
 )
 
-// @host 127.0.0.1:3000
-// @BasePath /
+// Configure sets the settings the application is built from, for entrypoints that load them
+// themselves (cmd/main). It has no effect once the application is initialized.
+func Configure(cfg *config.Config) {
+	appConfig = cfg
+}
 
-// @securityDefinitions.apikey BearerAuth
-// @in header
-// @name Authorization
 func init() {
 	log.Println("🔥 function init() executed")
 	// Register the entry point, but DO NOT initialize clients here.
@@ -46,17 +66,101 @@ func init() {
 		})
 		functionHandler.ServeHTTP(w, r)
 	})
+	// Second trigger surface: Firestore writes on events/{eventId}, delivered by Eventarc
+	functions.CloudEvent("BibentlyEventTrigger", func(ctx context.Context, e cloudevents.Event) error {
+		initOnce.Do(func() {
+			setupApplication()
+		})
+		return eventTrigger(ctx, e)
+	})
+	// WARMUP_ON_INIT=true initializes at instance start instead, for min-instances deployments
+	// whose instances would otherwise make their first request pay for clients and handshakes.
+	// It is read directly: init runs before the configuration is loaded.
+	if os.Getenv("WARMUP_ON_INIT") == "true" {
+		go warmUp()
+	}
+}
+
+// warmUp creates the clients and primes their connections with the readiness probes
+func warmUp() {
+	start := time.Now()
+	initOnce.Do(func() {
+		setupApplication()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), transport.WarmupTimeout)
+	defer cancel()
+	report := transport.RunProbes(ctx, healthProbes)
+	log.Printf("warm-up finished in %s: %s %+v", time.Since(start).Round(time.Millisecond), report.Status, report.Checks)
+}
+
+// GRPCServer returns the gRPC server (api/events/v1) backed by the same services as the HTTP
+// function, initializing the application on first use. Cloud Functions only speak HTTP/1.1,
+// so it is served by the separate cmd/grpc-server entrypoint.
+func GRPCServer() *grpc.Server {
+	initOnce.Do(func() {
+		setupApplication()
+	})
+	return grpcServer
+}
+
+// Shutdown exports the spans still queued, for long-running entrypoints (cmd/grpc-server) that
+// stop on a signal. HTTP requests flush their own spans.
+func Shutdown(ctx context.Context) error {
+	if traceProvider == nil {
+		return nil
+	}
+	return traceProvider.Shutdown(ctx)
 }
 
 // setupApplication contains the logic previously in init()
 // It panics on error instead of log.Fatal, allowing the runtime to handle the restart.
+// Settings are documented on config.Config; this only wires them.
 func setupApplication() {
 	ctx := context.Background()
-	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
-	databaseId := os.Getenv("FIRESTORE_DATABASE_ID")
+	cfg := appConfig
+	if cfg == nil {
+		var err error
+		// Sensitive values may be given as sm://projects/{project}/secrets/{secret}[/versions/{version}]
+		// references; they are resolved once here, at cold start.
+		if cfg, err = config.FromEnv(ctx); err != nil {
+			log.Panic(err)
+		}
+	}
+	projectID := cfg.ProjectID
+	databaseId := cfg.DatabaseID
+
+	// Structured logs for Cloud Logging. Requests, tasks and triggers carry it (or a child tagged
+	// with their Ids) in their context; see logging.FromContext.
+	// LOG_LEVEL is the starting level; PUT /admin/log-level changes it on a running instance.
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.LogLevel)
+	logger := logging.New(os.Stdout, projectID, logLevel)
+
+	// 0. Tracing: spans go to Cloud Trace; TRACE_SAMPLE_RATIO samples requests that arrive
+	// without a sampling decision. Local memory-backed runs skip it; failing to set it up is not fatal.
+	var flushTraces func(context.Context) error
+	if !cfg.MemoryStorage {
+		provider, err := tracing.Setup(ctx, projectID, cfg.TraceSampleRatio)
+		if err != nil {
+			log.Printf("tracing disabled: %v", err)
+		} else {
+			traceProvider = provider
+			flushTraces = provider.ForceFlush
+		}
+	}
 
-	// 1. Initialize Firestore
-	fsClient, err := firestore.NewClientWithDatabase(ctx, projectID, databaseId)
+	// STORAGE_BACKEND=memory keeps events and tracking in process memory, for local runs without
+	// the emulators. The clients are then built without credentials; the collections still kept
+	// in Firestore fail on use unless FIRESTORE_EMULATOR_HOST is set.
+	memoryStorage := cfg.MemoryStorage
+	var clientOpts []option.ClientOption
+	if memoryStorage {
+		clientOpts = append(clientOpts, option.WithoutAuthentication())
+	}
+
+	// 1. Initialize Firestore. The pool opens FIRESTORE_DATABASE_ID now and routed databases on first use.
+	clientPool := repository.NewClientPool(projectID, clientOpts...)
+	fsClient, err := clientPool.Client(ctx, databaseId)
 	if err != nil {
 		// Use Panic, not Fatal. Panic allows the runtime to catch and restart.
 		log.Panicf("Failed to create firestore client: %v", err)
@@ -64,7 +168,7 @@ func setupApplication() {
 
 	// 2. Initialize Firebase Auth
 	conf := &firebase.Config{ProjectID: projectID}
-	app, err := firebase.NewApp(ctx, conf)
+	app, err := firebase.NewApp(ctx, conf, clientOpts...)
 	if err != nil {
 		log.Panicf("error initializing firebase app: %v", err)
 	}
@@ -75,48 +179,378 @@ func setupApplication() {
 	}
 
 	// 3. Initialize Domain Layers
-	eventRepo := repository.NewEventRepository(fsClient)
-	trackingRepo := repository.NewTrackingRepository(fsClient)
+	// PAGE_TOKEN_SECRET seals list page tokens; every instance must share it or tokens fail across instances
+	if cfg.Events.PageTokenSecret == "" {
+		log.Printf("PAGE_TOKEN_SECRET is not set, page tokens are only valid on the instance that issued them")
+	}
+	eventRepoOpts := []repository.EventRepositoryOption{repository.WithPageTokenSecret([]byte(cfg.Events.PageTokenSecret))}
+	// EVENT_LIST_PROJECTION=card reads only the list card fields for lists that request no fieldset
+	if cfg.Events.CardProjection {
+		eventRepoOpts = append(eventRepoOpts, repository.WithListProjection(domain.EventCardFields))
+	}
+	// EVENTS_COLLECTION_GROUP lists events from the root collection and every tenants/{id}/events
+	if cfg.Events.CollectionGroup {
+		eventRepoOpts = append(eventRepoOpts, repository.WithCollectionGroup())
+	}
+	if memoryStorage {
+		log.Printf("STORAGE_BACKEND=memory: events and tracking are not persisted")
+	}
+	// REDIS_ADDR (host:port of a Memorystore instance) enables a read cache in front of event lookups
+	// and first list pages; the TTL bounds staleness from writes that bypass the repository.
+	var cacheStore cache.Store
+	cacheTTL := cfg.Cache.TTL
+	if cfg.Cache.RedisAddr != "" {
+		cacheStore = cache.NewRedisStore(cfg.Cache.RedisAddr, cfg.Cache.RedisPassword)
+	}
+	facetSvc := service.NewFacetService(repository.NewFacetRepository(fsClient))
+	writeTrigger := triggers.NewEventWriteHandler(facetSvc)
+	eventTrigger = func(ctx context.Context, e cloudevents.Event) error {
+		return writeTrigger(logging.WithLogger(ctx, logger), e)
+	}
+
+	// Feature flags: FEATURE_FLAGS overrides the defaults; with FEATURE_FLAGS_DYNAMIC, documents in
+	// feature_flags (of the default database) override both and take effect within FEATURE_FLAGS_TTL
+	var flagOpts []flags.Option
+	if cfg.Flags.Dynamic {
+		flagOpts = append(flagOpts, flags.WithSource(flags.NewFirestoreSource(fsClient), cfg.Flags.TTL))
+	}
+	featureFlags := flags.New(cfg.Flags.Overrides, flagOpts...)
 
-	eventSvc := service.NewEventService(eventRepo)
-	trackingSvc := service.NewTrackingService(trackingRepo)
+	// Event types: EVENT_TYPES replaces the defaults; with EVENT_TYPES_DYNAMIC, the types listed in
+	// settings/event_types (of the default database) replace both within EVENT_TYPES_TTL
+	var typeOpts []eventtypes.Option
+	if cfg.Events.TypesDynamic {
+		typeOpts = append(typeOpts, eventtypes.WithSource(eventtypes.NewFirestoreSource(fsClient), cfg.Events.TypesTTL))
+	}
+	domain.SetEventTypeRegistry(eventtypes.New(cfg.Events.Types, typeOpts...))
+
+	// Duplicate detection on create: allow | reject | return_existing
+	eventOpts := []service.EventServiceOption{
+		service.WithDuplicatePolicy(cfg.Events.DuplicatePolicy),
+		service.WithFlags(featureFlags),
+	}
+	if cfg.Events.StripTrackingParams {
+		eventOpts = append(eventOpts, service.WithTrackingParamsStripped())
+	}
+
+	// Background work goes through Cloud Tasks, calling back this function.
+	// TASKS_QUEUE: projects/{project}/locations/{location}/queues/{queue}
+	// TASKS_WORKER_URL: public base URL of this function (also the OIDC audience)
+	// TASKS_SERVICE_ACCOUNT: identity Cloud Tasks uses to call the worker
+	var taskQueue tasks.Queue
+	if t := cfg.Tasks; t.Queue != "" {
+		taskQueue, err = tasks.NewCloudTasksQueue(ctx, t.Queue, t.WorkerURL, t.ServiceAccount)
+		if err != nil {
+			log.Panicf("error creating cloud tasks client: %v", err)
+		}
+	}
 
-	router := transport.NewRouter(eventSvc, trackingSvc)
+	// Email about new events to the subscribers of their city
+	var mailer notify.Mailer
+	switch m := cfg.Mail; m.Provider {
+	case "sendgrid":
+		mailer = notify.NewSendGridMailer(m.APIKey, m.From, m.APIBaseURL)
+	case "mailgun":
+		mailer = notify.NewMailgunMailer(m.APIKey, m.MailgunDomain, m.From, m.APIBaseURL)
+	}
+
+	// Push notifications go through FCM on the same Firebase app
+	var pushSender notify.PushSender
+	if cfg.PushEnabled {
+		messagingClient, err := app.Messaging(ctx)
+		if err != nil {
+			log.Panicf("error getting messaging client: %v", err)
+		}
+		pushSender = messagingClient
+	}
+
+	// Slack incoming webhook or Discord webhook told about events admins create or cancel
+	if cfg.OpsWebhookURL != "" {
+		eventOpts = append(eventOpts, service.WithOpsNotifier(notify.NewChatWebhookNotifier(cfg.OpsWebhookURL, cfg.PublicSiteURL)))
+	}
+
+	// TRACKING_TTL stamps tracking documents with expire_at for a Firestore TTL policy
+	// (make tracking-ttl), which deletes them after the retention period without the cron job
+	var trackingOpts []service.TrackingServiceOption
+	if cfg.Tracking.TTL {
+		trackingOpts = append(trackingOpts, service.WithTrackingExpiry(cfg.Tracking.RetentionDays))
+	}
+	// Privacy mode anonymizes user agents and stops storing tracking from browsers sending DNT or Sec-GPC
+	trackingPrivacy := cfg.Tracking.Privacy
+	if trackingPrivacy != domain.TrackingPrivacyOff {
+		trackingOpts = append(trackingOpts, service.WithTrackingPrivacy(trackingPrivacy, []byte(cfg.Tracking.HashKey)))
+	}
+	// BIGQUERY_TRACKING_TABLE ([project.]dataset.table) streams stored tracking events into BigQuery.
+	// The table is created with analytics.TrackingSchema if missing; the dataset must exist.
+	if table := cfg.Tracking.BigQueryTable; table != "" {
+		exporter, err := analytics.NewBigQueryExporter(ctx, projectID, table)
+		if err != nil {
+			log.Panicf("error creating bigquery exporter: %v", err)
+		}
+		if err := exporter.EnsureTable(ctx); err != nil {
+			log.Printf("bigquery tracking table not ensured: %v", err)
+		}
+		trackingOpts = append(trackingOpts, service.WithTrackingExporter(exporter))
+	}
+
+	// newDatabaseServices builds the services whose data lives in the database of fsClient;
+	// cacheStore may be nil. Only the default database announces new events: the task worker
+	// is called back without a database to route to.
+	newDatabaseServices := func(fsClient *firestore.Client, cacheStore cache.Store, announce bool) transport.Services {
+		eventRepo := repository.NewEventRepository(fsClient, eventRepoOpts...)
+		trackingRepo := repository.NewTrackingRepository(fsClient)
+		if memoryStorage {
+			eventRepo = repository.NewMemoryEventRepository(eventRepoOpts...)
+			trackingRepo = repository.NewMemoryTrackingRepository()
+		}
+		// Identical concurrent list queries share one read; below the cache, so misses are collapsed too
+		eventRepo = repository.NewSingleflightEventRepository(eventRepo)
+		if cacheStore != nil {
+			eventRepo = repository.NewCachedEventRepository(eventRepo, cacheStore, cacheTTL)
+		}
+
+		subscriptionRepo := repository.NewSubscriptionRepository(fsClient)
+		pushRepo := repository.NewPushSubscriptionRepository(fsClient)
+		// Subscribers are notified by the announcement task worker after the create has answered;
+		// delivered batches are recorded per event so a retried task does not notify anyone twice
+		announcementRepo := repository.NewAnnouncementRepository(fsClient)
+		var notifiers []notify.Notifier
+		if mailer != nil {
+			notifiers = append(notifiers, notify.NewEmailNotifier(subscriptionRepo, announcementRepo, mailer, cfg.PublicSiteURL))
+		}
+		if pushSender != nil {
+			notifiers = append(notifiers, notify.NewPushNotifier(pushRepo, announcementRepo, pushSender))
+		}
+		opts := slices.Clone(eventOpts)
+		var announcements service.AnnouncementService
+		if announce && len(notifiers) > 0 && taskQueue != nil {
+			announcements = service.NewAnnouncementService(eventRepo, notify.Multi(notifiers...), taskQueue)
+			opts = append(opts, service.WithAnnouncer(announcements))
+		}
+
+		dbServices := transport.Services{
+			Events:            service.NewEventService(eventRepo, repository.NewRevisionRepository(fsClient), opts...),
+			Tracking:          service.NewTrackingService(trackingRepo, trackingOpts...),
+			TicketTiers:       service.NewTicketTierService(repository.NewTicketTierRepository(fsClient), eventRepo),
+			RSVPs:             service.NewRSVPService(repository.NewRSVPRepository(fsClient), eventRepo),
+			Favorites:         service.NewFavoriteService(repository.NewFavoriteRepository(fsClient)),
+			Organizers:        service.NewOrganizerService(repository.NewOrganizerRepository(fsClient)),
+			Users:             service.NewUserService(repository.NewUserRepository(fsClient)),
+			Subscriptions:     service.NewSubscriptionService(subscriptionRepo),
+			PushSubscriptions: service.NewPushSubscriptionService(pushRepo),
+			Announcements:     announcements,
+			HealthProbes: map[string]transport.HealthProbe{
+				"firestore": repository.NewFirestoreProbe(fsClient),
+			},
+		}
+		// POST /dev/seed fills the database with fake events and tracking, outside production only
+		if !cfg.Production {
+			dbServices.Seeds = service.NewSeedService(dbServices.Events, dbServices.Tracking)
+		}
+		return dbServices
+	}
+
+	// The audit log stays in the default database for every routed one
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(fsClient))
+	services := newDatabaseServices(fsClient, cacheStore, true)
+	services.Audit = auditSvc
+	eventSvc := services.Events
+	// /admin/backups dumps and restores the default database; BACKUP_BUCKET also enables managed
+	// exports and imports, through the Firestore admin API
+	if !memoryStorage {
+		var adminClient *apiv1admin.FirestoreAdminClient
+		if cfg.BackupBucket != "" {
+			adminClient, err = apiv1admin.NewFirestoreAdminClient(ctx, clientOpts...)
+			if err != nil {
+				log.Panicf("error creating firestore admin client: %v", err)
+			}
+		}
+		services.Backups = service.NewBackupService(repository.NewBackupRepository(fsClient, adminClient, projectID, databaseId), cfg.BackupBucket)
+	}
+	healthProbes = services.HealthProbes
+
+	// Async imports need a Cloud Tasks queue; without one the endpoints are not exposed.
+	if taskQueue != nil {
+		services.Imports = service.NewImportService(repository.NewJobRepository(fsClient), eventSvc, taskQueue)
+		services.TaskVerifier = tasks.NewOIDCVerifier(cfg.Tasks.WorkerURL, cfg.Tasks.ServiceAccount)
+	}
+
+	// Daily housekeeping for Cloud Scheduler; the endpoint is only exposed when CRON_SERVICE_ACCOUNT is set.
+	// The audience is the one configured on the job.
+	if c := cfg.Cron; c.ServiceAccount != "" {
+		services.Maintenance = service.NewMaintenanceService(eventSvc, services.Tracking, facetSvc, service.MaintenancePolicy{
+			ArchiveAfterDays:      c.ArchiveAfterDays,
+			TrackingRetentionDays: cfg.Tracking.RetentionDays,
+		})
+		services.CronVerifier = tasks.NewOIDCVerifier(c.Audience, c.ServiceAccount)
+	}
+
+	// Partner webhooks: PARTNER_WEBHOOK_SECRET is the shared HMAC key
+	if cfg.PartnerWebhookSecret != "" {
+		services.WebhookSecret = []byte(cfg.PartnerWebhookSecret)
+	}
+
+	// EVENT_CACHE_CONTROL overrides the Cache-Control of event reads, which always carry an ETag
+	services.EventCacheControl = cfg.EventCacheControl
+
+	// METRICS_ENABLED exposes GET /metrics (admin-only) for Prometheus scraping
+	services.MetricsEnabled = cfg.MetricsEnabled
+	// PPROF_ENABLED exposes the net/http/pprof profiles under /debug/pprof/ (admin-only)
+	services.PprofEnabled = cfg.PprofEnabled
+	// API_DOCS serves /openapi.json and Swagger UI at /swagger/ to everyone (public, the default
+	// outside production), to admins (admin, the production default) or not at all (off)
+	services.APIDocs = transport.APIDocsAccess(cfg.APIDocs)
+	services.AdminUID = cfg.Auth.AdminUID
+	services.Logger = logger
+	services.LogLevel = logLevel
+
+	services.HonorDoNotTrack = trackingPrivacy != domain.TrackingPrivacyOff
+
+	// gRPC surface served by cmd/grpc-server; WatchEvents listens to Firestore directly
+	services.EventWatch = service.NewEventWatchService(repository.NewEventWatcher(fsClient))
+	grpcServer = transport.NewGRPCServer(services, authClient)
+
+	// LEGACY_API_SUNSET is announced on unversioned paths, which alias /v1 until then
+	services.LegacySunset = cfg.LegacySunset
 
 	// 4. Configuration & Middleware
-	corsOrigin := os.Getenv("CORS_ALLOWED_ORIGIN")
-	isProduction := os.Getenv("APP_ENV") == "production"
+	corsOrigin := cfg.Auth.AllowedOrigins // comma-separated, e.g. "https://bibently.com,https://*.bibently.com"
+	isProduction := cfg.Production
 
 	// --- Middleware Chain (Order Matters) ---
 
 	// 1. Base business logic
-	handler := transport.WithCompression(router)
+	// Anonymous event listings are cached per instance for RESPONSE_CACHE_TTL;
+	// the cache sits inside compression so it stores plain bodies, and each database has its own
+	responseCacheTTL := cfg.ResponseCacheTTL
+	newRouter := func(services transport.Services) http.Handler {
+		handler := transport.NewRouter(services)
+		if responseCacheTTL > 0 {
+			handler = transport.WithResponseCache(handler, responseCacheTTL, transport.IsPublicEventList)
+		}
+		return handler
+	}
+	handler := newRouter(services)
+	// FIRESTORE_DATABASES (comma-separated IDs) serves more databases of the project from this deployment,
+	// picked per request by FIRESTORE_DATABASE_ROUTING: header (X-Firestore-Database, the default) or
+	// tenant (the token's Identity Platform tenant ID names its database). Routed databases serve the
+	// HTTP API; imports, announcements, housekeeping, triggers and gRPC stay on FIRESTORE_DATABASE_ID.
+	if len(cfg.Databases) > 0 {
+		resolve := transport.DatabaseFromHeader
+		if cfg.DatabaseRouting == "tenant" {
+			resolve = transport.DatabaseFromTenant
+		}
+		handler = transport.WithDatabaseRouting(handler, databaseId, cfg.Databases, resolve, func(databaseID string) (http.Handler, error) {
+			client, err := clientPool.Client(ctx, databaseID)
+			if err != nil {
+				return nil, err
+			}
+			var store cache.Store
+			if cacheStore != nil {
+				store = cache.WithPrefix(cacheStore, databaseID+":")
+			}
+			routed := newDatabaseServices(client, store, false)
+			routed.Audit = auditSvc
+			routed.EventCacheControl = services.EventCacheControl
+			routed.MetricsEnabled = services.MetricsEnabled
+			routed.PprofEnabled = services.PprofEnabled
+			routed.APIDocs = services.APIDocs
+			routed.WebhookSecret = services.WebhookSecret
+			routed.LegacySunset = services.LegacySunset
+			routed.HonorDoNotTrack = services.HonorDoNotTrack
+			return newRouter(routed), nil
+		})
+	}
+	handler = transport.WithCompression(handler)
+	// HEAD gets the GET headers with the body's length and no body
+	handler = transport.WithHeadResponses(handler)
+	// Write bodies must be JSON unless transport.DefaultContentTypes allows another format (415 otherwise)
+	handler = transport.WithContentTypes(handler, nil)
+
+	// 2. Audit log of every write (inside auth so the actor is known)
+	handler = transport.WithAuditLog(handler, auditSvc)
 
-	// 2. Auth & Security
-	handler = transport.WithAuthProtection(handler, authClient)
+	// 3. Rate limiting (inside auth so authenticated callers are limited per UID, others per IP)
+	// RATE_LIMIT_BACKEND=firestore shares buckets across instances; the default keeps them in memory.
+	newLimiter := ratelimit.NewMemoryLimiter
+	if cfg.RateLimit.Firestore {
+		newLimiter = func(rate float64, burst int) ratelimit.Limiter {
+			return ratelimit.NewFirestoreLimiter(fsClient, rate, burst)
+		}
+	}
+	handler = transport.WithRateLimit(handler,
+		newLimiter(cfg.RateLimit.TrackingRPS, cfg.RateLimit.TrackingBurst),
+		"tracking", transport.IsTrackingWrite)
+	handler = transport.WithRateLimit(handler,
+		newLimiter(cfg.RateLimit.RPS, cfg.RateLimit.Burst),
+		"api", nil)
+
+	// 4. Auth & Security
+	// AUTH_POLICY_FILE optionally replaces the built-in route policy table (JSON array of transport.RoutePolicy)
+	var policies []transport.RoutePolicy
+	if policyFile := cfg.Auth.PolicyFile; policyFile != "" {
+		policies, err = transport.LoadRoutePolicies(policyFile)
+		if err != nil {
+			log.Panicf("error loading route policies: %v", err)
+		}
+	}
+	// MULTI_TENANCY=true serves white-label frontends from tenants/{tenant}/events and tracking:
+	// the tenant comes from a /tenants/{tenant}/ path prefix or the token's Identity Platform tenant,
+	// and callers of one tenant cannot reach another's
+	multiTenancy := cfg.Auth.MultiTenancy
+	if multiTenancy {
+		handler = transport.WithTenantIsolation(handler)
+	}
+	// Guest reads switched off by a flag are told to sign in; this needs the principal auth verified
+	handler = transport.WithGuestGates(handler, featureFlags, nil)
+	policies = transport.APIDocsPolicies(policies, services.APIDocs)
+	handler = transport.WithAuthProtection(handler, authClient, policies, cfg.Auth.AdminUID)
+	// Routes switched off by a feature flag answer before auth, as if they did not exist
+	handler = transport.WithFeatureGates(handler, featureFlags, nil)
+	// DEBUG_LOG_ROUTES (never in production) logs redacted bodies, including of requests auth rejects
+	handler = transport.WithDebugLogging(handler, cfg.DebugLogRoutes)
+	if multiTenancy {
+		handler = transport.WithTenantPath(handler)
+	}
 	handler = transport.WithSecurityHeaders(handler, isProduction)
 	handler = transport.WithCORS(handler, corsOrigin)
 
-	// 3. Resilience & Observability
-	// TraceID must be outer to wrap context for logs
-	handler = transport.WithTraceID(handler)
-	// Recovery must be outer to catch panics in any middleware below
-	handler = transport.WithRecovery(handler)
+	// 5. Resilience & Observability
+	// Metrics sit outside auth so rejected (401/403/429) requests are counted too
+	handler = transport.WithMetrics(handler)
+	// Tracing must be outer so the server span covers every middleware and logs carry its trace.
+	// Sampled requests flush their spans before returning, while the instance still has CPU.
+	handler = transport.WithTracing(handler, flushTraces)
+	// Recovery must be outer to catch panics in any middleware below.
+	// ERROR_REPORTING_ENABLED shapes panic logs as Cloud Error Reporting events for K_SERVICE.
+	handler = transport.WithRecovery(handler, cfg.ErrorReportingService)
+	// Request Id wraps recovery so panic logs and the 500 payload carry it
+	handler = transport.WithRequestID(handler, logger)
 
-	// 4. Timeout (Standard Lib) - Outermost logic barrier
-	timeoutDuration := 15 * time.Second
+	// 6. Timeout (Standard Lib) - Outermost logic barrier
+	timeoutDuration := cfg.Timeouts.Request
 	timeoutMsg := `{"error": "Gateway Timeout: Upstream processing duration exceeded"}`
-	handler = http.TimeoutHandler(handler, timeoutDuration, timeoutMsg)
+	timeoutHandler := http.TimeoutHandler(handler, timeoutDuration, timeoutMsg)
 
-	if isProduction == false {
-		functionHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if strings.HasPrefix(r.URL.Path, "/swagger/") {
-				httpSwagger.Handler(httpSwagger.DeepLinking(false))(w, r)
-				return
-			}
-			handler.ServeHTTP(w, r)
-		})
-	} else {
-		functionHandler = handler
-	}
+	// TimeoutHandler buffers the whole response, which defeats NDJSON exports and server-sent events;
+	// those get a context deadline instead (EXPORT_TIMEOUT and SSE_TIMEOUT, both also bounded by
+	// the function's own timeout). EventSource reconnects when a stream ends.
+	streamHandler := handler
+	exportTimeout := cfg.Timeouts.Export
+	sseTimeout := cfg.Timeouts.SSE
+	handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := exportTimeout
+		switch {
+		case transport.WantsEventStream(r):
+			timeout = sseTimeout
+		case !transport.WantsNDJSON(r):
+			timeoutHandler.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		streamHandler.ServeHTTP(w, r.WithContext(ctx))
+	})
+
+	functionHandler = handler
 }
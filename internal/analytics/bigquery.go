@@ -0,0 +1,122 @@
+// Package analytics copies tracking events to a warehouse where analysts can query them with SQL.
+package analytics
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// Exporter receives tracking events once they are stored
+type Exporter interface {
+	ExportTracking(ctx context.Context, events []domain.TrackingEvent) error
+}
+
+// TrackingSchema is the layout of the BigQuery tracking table: one row per stored event, with the
+// Firestore field names. tenant is empty outside multi-tenancy.
+var TrackingSchema = &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+	{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+	{Name: "action", Type: "STRING", Mode: "REQUIRED"},
+	{Name: "user_name", Type: "STRING"},
+	{Name: "payload", Type: "STRING"},
+	{Name: "user_agent", Type: "STRING", Description: "Hashed or empty under TRACKING_PRIVACY"},
+	{Name: "session_id", Type: "STRING"},
+	{Name: "event_id", Type: "STRING", Description: "Event the action was about, if any"},
+	{Name: "user_id", Type: "STRING", Description: "Verified caller; empty for anonymous visitors"},
+	{Name: "tenant", Type: "STRING"},
+	{Name: "created_at", Type: "TIMESTAMP", Mode: "REQUIRED"},
+}}
+
+// BigQueryExporter streams tracking events into a table with the insertAll API. The event Id is
+// the insert Id, so a retried export is deduplicated on a best-effort basis.
+type BigQueryExporter struct {
+	svc       *bigquery.Service
+	projectID string
+	datasetID string
+	tableID   string
+}
+
+// NewBigQueryExporter exports to table, given as dataset.table or project.dataset.table;
+// projectID is used when table names no project
+func NewBigQueryExporter(ctx context.Context, projectID, table string, opts ...option.ClientOption) (*BigQueryExporter, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) == 2 {
+		parts = append([]string{projectID}, parts...)
+	}
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid BigQuery table %q, want [project.]dataset.table", table)
+	}
+	svc, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BigQueryExporter{svc: svc, projectID: parts[0], datasetID: parts[1], tableID: parts[2]}, nil
+}
+
+// EnsureTable creates the table with TrackingSchema, partitioned by day of created_at, unless it
+// exists. An existing table is left as it is.
+func (e *BigQueryExporter) EnsureTable(ctx context.Context) error {
+	_, err := e.svc.Tables.Get(e.projectID, e.datasetID, e.tableID).Context(ctx).Do()
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return err
+	}
+	table := &bigquery.Table{
+		TableReference:   &bigquery.TableReference{ProjectId: e.projectID, DatasetId: e.datasetID, TableId: e.tableID},
+		Schema:           TrackingSchema,
+		TimePartitioning: &bigquery.TimePartitioning{Type: "DAY", Field: "created_at"},
+		Clustering:       &bigquery.Clustering{Fields: []string{"action"}},
+	}
+	_, err = e.svc.Tables.Insert(e.projectID, e.datasetID, table).Context(ctx).Do()
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+		return nil // created concurrently by another instance
+	}
+	return err
+}
+
+func (e *BigQueryExporter) ExportTracking(ctx context.Context, events []domain.TrackingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tenant := domain.TenantFromContext(ctx)
+	req := &bigquery.TableDataInsertAllRequest{Rows: make([]*bigquery.TableDataInsertAllRequestRows, 0, len(events))}
+	for _, ev := range events {
+		req.Rows = append(req.Rows, &bigquery.TableDataInsertAllRequestRows{
+			InsertId: ev.Id,
+			Json: map[string]bigquery.JsonValue{
+				"id":         ev.Id,
+				"action":     ev.Action,
+				"user_name":  ev.UserName,
+				"payload":    ev.Payload,
+				"user_agent": ev.UserAgent,
+				"session_id": ev.SessionID,
+				"event_id":   ev.EventID,
+				"user_id":    ev.UserID,
+				"tenant":     tenant,
+				"created_at": ev.CreatedAt.UTC().Format(time.RFC3339Nano),
+			},
+		})
+	}
+
+	resp, err := e.svc.Tabledata.InsertAll(e.projectID, e.datasetID, e.tableID, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if len(resp.InsertErrors) > 0 {
+		first := resp.InsertErrors[0]
+		reason := "unknown"
+		if len(first.Errors) > 0 {
+			reason = first.Errors[0].Reason + ": " + first.Errors[0].Message
+		}
+		return fmt.Errorf("bigquery rejected %d of %d rows (row %d: %s)", len(resp.InsertErrors), len(events), first.Index, reason)
+	}
+	return nil
+}
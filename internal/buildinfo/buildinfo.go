@@ -0,0 +1,55 @@
+// Package buildinfo exposes the revision the binary was built from.
+//
+// Set the values at build time:
+//
+//	go build -ldflags "-X bibently.com/backend/internal/buildinfo.GitSHA=$(git rev-parse HEAD) \
+//	  -X bibently.com/backend/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Without ldflags the VCS stamp embedded by the Go toolchain is used when available.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	GitSHA    = ""
+	BuildTime = ""
+)
+
+// Info is returned by GET /version
+type Info struct {
+	GitSHA    string `json:"git_sha"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // built from a dirty working tree (VCS stamp only)
+}
+
+// Get returns the build information, preferring ldflags values over the VCS stamp
+func Get() Info {
+	info := Info{GitSHA: GitSHA, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.GitSHA == "" {
+					info.GitSHA = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	if info.GitSHA == "" {
+		info.GitSHA = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
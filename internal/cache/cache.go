@@ -0,0 +1,104 @@
+// Package cache provides the key-value stores behind read caches: Redis (Memorystore) shared
+// by all instances, or process memory for local runs and tests.
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Store is a key-value store with expiry. Get reports a missing or expired key as ok == false.
+type Store interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Incr atomically increments the integer at key, starting from 0, and returns the new value
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time // zero never expires
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemoryStore returns a Store local to the process. It does not evict before expiry,
+// so it suits tests and single-instance local runs only.
+func NewMemoryStore() Store {
+	return &memoryStore{entries: make(map[string]memoryEntry)}
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || (!entry.expires.IsZero() && time.Now().After(entry.expires)) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *memoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Incr(ctx context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, _ := strconv.ParseInt(string(s.entries[key].value), 10, 64)
+	n++
+	s.entries[key] = memoryEntry{value: []byte(strconv.FormatInt(n, 10))}
+	return n, nil
+}
+
+type prefixedStore struct {
+	Store
+	prefix string
+}
+
+// WithPrefix namespaces every key of store under prefix, so several caches can share one server
+func WithPrefix(store Store, prefix string) Store {
+	return &prefixedStore{Store: store, prefix: prefix}
+}
+
+func (s *prefixedStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return s.Store.Get(ctx, s.prefix+key)
+}
+
+func (s *prefixedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.Store.Set(ctx, s.prefix+key, value, ttl)
+}
+
+func (s *prefixedStore) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.prefix + key
+	}
+	return s.Store.Delete(ctx, prefixed...)
+}
+
+func (s *prefixedStore) Incr(ctx context.Context, key string) (int64, error) {
+	return s.Store.Incr(ctx, s.prefix+key)
+}
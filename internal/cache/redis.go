@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// redisTimeout bounds a command whose context has no deadline
+	redisTimeout = 500 * time.Millisecond
+	// redisIdleConns is the number of connections kept open between commands
+	redisIdleConns = 8
+)
+
+// RedisError is an error reply from the server
+type RedisError string
+
+func (e RedisError) Error() string { return "redis: " + string(e) }
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+type redisStore struct {
+	addr     string
+	password string
+	idle     chan *redisConn
+}
+
+// NewRedisStore returns a Store on the Redis server at addr (host:port), such as a Memorystore
+// instance reached through the function's VPC connector. A non-empty password is sent with AUTH
+// on every new connection. Connections are opened on demand.
+func NewRedisStore(addr, password string) Store {
+	return &redisStore{addr: addr, password: password, idle: make(chan *redisConn, redisIdleConns)}
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+func (s *redisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := s.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+func (s *redisStore) Incr(ctx context.Context, key string) (int64, error) {
+	reply, err := s.do(ctx, "INCR", key)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %T", reply)
+	}
+	return n, nil
+}
+
+// do sends one command and reads its reply. A connection that failed is closed rather than reused;
+// error replies leave it usable.
+func (s *redisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	reply, err := roundTrip(conn, args)
+	var redisErr RedisError
+	if err != nil && !errors.As(err, &redisErr) {
+		_ = conn.Close()
+		return nil, err
+	}
+	select {
+	case s.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+	return reply, err
+}
+
+func (s *redisStore) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-s.idle:
+		return conn, nil
+	default:
+	}
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	var d net.Dialer
+	c, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: c, r: bufio.NewReader(c)}
+	if s.password != "" {
+		_ = conn.SetDeadline(time.Now().Add(redisTimeout))
+		if _, err := roundTrip(conn, []string{"AUTH", s.password}); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// roundTrip writes args as a RESP array of bulk strings and reads the reply
+func roundTrip(conn *redisConn, args []string) (interface{}, error) {
+	buf := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		buf = fmt.Appendf(buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return readReply(conn.r)
+}
+
+// readReply parses one RESP reply: simple strings as string, integers as int64, bulk strings as
+// []byte, arrays as []interface{}, and nil bulk strings and arrays as nil
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, RedisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		return value[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,348 @@
+package clientgen
+
+import (
+	"bibently.com/backend/internal/openapi"
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// goInitialisms are written in capitals in Go names (event_id becomes EventID)
+var goInitialisms = map[string]bool{"id": true, "ids": true, "uid": true, "url": true, "uri": true, "ip": true, "api": true, "http": true, "json": true, "dnt": true, "gpc": true, "ttl": true}
+
+// Go returns the operations and types of doc as the Go file api.gen.go of package pkg, whose
+// hand-written client.go provides Client, Page, envelope, do and send
+func Go(doc *openapi.Document, pkg string) ([]byte, error) {
+	g := &goGen{schemas: doc.Components.Schemas}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n\n", Header, pkg)
+
+	var body bytes.Buffer
+	for _, name := range slices.Sorted(maps.Keys(g.schemas)) {
+		g.writeComponent(&body, name, g.schemas[name])
+	}
+	for _, op := range operations(doc) {
+		g.writeOperation(&body, op)
+	}
+
+	b.WriteString("import (\n")
+	for _, imp := range []string{"context", "fmt", "io", "net/http", "net/url", "time"} {
+		short := imp[strings.LastIndex(imp, "/")+1:]
+		if regexp.MustCompile(`\b` + short + `\.[A-Z]`).Match(body.Bytes()) {
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.Write(body.Bytes())
+	return format.Source(b.Bytes())
+}
+
+type goGen struct {
+	schemas map[string]*openapi.Schema
+}
+
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if goInitialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			b.WriteString(upperFirst(w))
+		}
+	}
+	name := b.String()
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "X" + name
+	}
+	return name
+}
+
+// goParamName is the lower camel case argument name of a parameter (tierId becomes tierID)
+func goParamName(s string) string {
+	w := words(s)
+	if len(w) == 1 {
+		return strings.ToLower(w[0])
+	}
+	return strings.ToLower(w[0]) + goName(strings.Join(w[1:], "_"))
+}
+
+func writeGoDoc(b *bytes.Buffer, indent, first, rest string) {
+	fmt.Fprintf(b, "%s// %s\n", indent, first)
+	for _, line := range wrap(rest, 96) {
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+func (g *goGen) writeComponent(b *bytes.Buffer, name string, s *openapi.Schema) {
+	if typ, _ := schemaType(s); typ == "string" && len(s.Enum) > 0 {
+		fmt.Fprintf(b, "type %s string\n\nconst (\n", name)
+		for _, v := range s.Enum {
+			fmt.Fprintf(b, "\t%s %s = %q\n", name+goName(fmt.Sprint(v)), name, fmt.Sprint(v))
+		}
+		b.WriteString(")\n\n")
+		return
+	}
+	if s.Description != "" {
+		writeGoDoc(b, "", name+" "+lowerFirst(s.Description), "")
+	}
+	if typ, _ := schemaType(s); typ == "object" && s.AdditionalProperties == nil {
+		fmt.Fprintf(b, "type %s %s\n\n", name, g.structType(s, ""))
+		return
+	}
+	fmt.Fprintf(b, "type %s = %s\n\n", name, g.typeOf(s))
+}
+
+func (g *goGen) structType(s *openapi.Schema, indent string) string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, prop := range slices.Sorted(maps.Keys(s.Properties)) {
+		ps := s.Properties[prop]
+		required := slices.Contains(s.Required, prop)
+		typ := g.fieldType(ps, required)
+		tag := prop
+		if !required {
+			tag += ",omitempty"
+		}
+		if ps.Description != "" {
+			fmt.Fprintf(&b, "%s\t// %s\n", indent, ps.Description)
+		}
+		fmt.Fprintf(&b, "%s\t%s %s `json:%q`\n", indent, goName(prop), typ, tag)
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// fieldType is the type of a field or parameter: optional and nullable values are pointers, unless
+// their zero value is nil already
+func (g *goGen) fieldType(s *openapi.Schema, required bool) string {
+	typ := g.typeOf(s)
+	if !required && !nilable(typ) {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+func nilable(typ string) bool {
+	return strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "any"
+}
+
+func (g *goGen) typeOf(s *openapi.Schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+	if ref := nullableRef(s); ref != nil {
+		return "*" + refName(ref.Ref)
+	}
+	typ, nullable := schemaType(s)
+	var t string
+	switch typ {
+	case "string":
+		switch {
+		case s.ContentEncoding == "base64":
+			return "[]byte"
+		case s.Format == "date-time":
+			t = "time.Time"
+		default:
+			t = "string"
+		}
+	case "integer":
+		t = "int64"
+		if s.Format == "int32" {
+			t = "int32"
+		}
+	case "number":
+		t = "float64"
+		if s.Format == "float" {
+			t = "float32"
+		}
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + g.typeOf(s.Items)
+	case "object":
+		switch {
+		case s.AdditionalProperties != nil:
+			return "map[string]" + g.typeOf(s.AdditionalProperties)
+		case len(s.Properties) > 0:
+			t = g.structType(s, "\t")
+		default:
+			return "map[string]any"
+		}
+	default:
+		return "any"
+	}
+	if nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// isStruct reports whether typ names an object component, which results return by pointer
+func (g *goGen) isStruct(typ string) bool {
+	s, ok := g.schemas[typ]
+	if !ok {
+		return false
+	}
+	t, _ := schemaType(s)
+	return t == "object" && s.AdditionalProperties == nil
+}
+
+func (g *goGen) writeOperation(b *bytes.Buffer, op operation) {
+	name := goName(op.ID)
+	hasParams := len(op.Params) > 0
+
+	if hasParams {
+		fmt.Fprintf(b, "// %sParams are the query and header parameters of %s\ntype %sParams struct {\n", name, name, name)
+		for _, p := range op.Params {
+			if p.Description != "" {
+				fmt.Fprintf(b, "\t// %s\n", p.Description)
+			}
+			fmt.Fprintf(b, "\t%s %s\n", goName(p.Name), g.fieldType(p.Schema, p.Required))
+		}
+		b.WriteString("}\n\n")
+		fmt.Fprintf(b, "func (p *%sParams) encode() (url.Values, http.Header) {\n\tquery, header := url.Values{}, http.Header{}\n\tif p == nil {\n\t\treturn query, header\n\t}\n", name)
+		for _, p := range op.Params {
+			target := "query"
+			if p.In == "header" {
+				target = "header"
+			}
+			field := "p." + goName(p.Name)
+			typ := g.fieldType(p.Schema, p.Required)
+			switch {
+			case strings.HasPrefix(typ, "*"):
+				fmt.Fprintf(b, "\tif %s != nil {\n\t\t%s.Set(%q, fmt.Sprint(*%s))\n\t}\n", field, target, p.Name, field)
+			default:
+				fmt.Fprintf(b, "\t%s.Set(%q, fmt.Sprint(%s))\n", target, p.Name, field)
+			}
+		}
+		b.WriteString("\treturn query, header\n}\n\n")
+	}
+
+	// Signature
+	args := []string{"ctx context.Context"}
+	for _, p := range op.PathParams {
+		args = append(args, goParamName(p.Name)+" string")
+	}
+	var bodyArg string
+	if op.Body != nil {
+		if op.Body.MediaType == "application/json" {
+			typ := g.typeOf(op.Body.Schema)
+			if !op.Body.Required && !nilable(typ) {
+				typ = "*" + typ
+			}
+			args = append(args, "body "+typ)
+			bodyArg = "body"
+			if !op.Body.Required {
+				bodyArg = "payload"
+			}
+		} else {
+			args = append(args, "body io.Reader")
+			if op.Body.MediaType == "multipart/form-data" {
+				// The boundary is part of the media type, e.g. multipart.Writer.FormDataContentType()
+				args = append(args, "contentType string")
+			}
+			bodyArg = "body"
+		}
+	}
+	if hasParams {
+		args = append(args, "params *"+name+"Params")
+	}
+
+	var resultType, zero string
+	switch op.Result.Kind {
+	case resultData, resultJSON:
+		resultType = g.typeOf(op.Result.Schema)
+		if g.isStruct(resultType) {
+			resultType = "*" + resultType
+			zero = "nil"
+		}
+	case resultPage:
+		resultType = "*Page[" + g.typeOf(op.Result.Schema) + "]"
+		zero = "nil"
+	case resultRaw:
+		resultType = "*http.Response"
+	}
+	returns := "error"
+	if resultType != "" {
+		returns = "(" + resultType + ", error)"
+	}
+
+	doc := fmt.Sprintf("%s is %s %s (%s).", name, op.Method, op.Path, op.Summary)
+	description := op.Description
+	if op.Result.Kind == resultRaw {
+		description = strings.TrimSpace(description + " The caller reads and closes the " + op.Result.MediaType + " response body.")
+	}
+	writeGoDoc(b, "", doc, description)
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	// Body
+	path := strconv.Quote(op.Path)
+	for _, p := range op.PathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", `"+url.PathEscape(`+goParamName(p.Name)+`)+"`, 1)
+	}
+	path = strings.ReplaceAll(path, `+""`, "")
+	query, header := "nil", "nil"
+	if hasParams {
+		b.WriteString("\tquery, header := params.encode()\n")
+		query, header = "query", "header"
+	}
+	if op.Body != nil && op.Body.MediaType != "application/json" {
+		if !hasParams {
+			b.WriteString("\theader := http.Header{}\n")
+			header = "header"
+		}
+		contentType := strconv.Quote(op.Body.MediaType)
+		if op.Body.MediaType == "multipart/form-data" {
+			contentType = "contentType"
+		}
+		fmt.Fprintf(b, "\theader.Set(\"Content-Type\", %s)\n", contentType)
+	}
+	if bodyArg == "payload" {
+		b.WriteString("\tvar payload any\n\tif body != nil {\n\t\tpayload = body\n\t}\n")
+	}
+	if bodyArg == "" {
+		bodyArg = "nil"
+	}
+	call := fmt.Sprintf("%s, %s, %s, %s", "http.Method"+methodName(op.Method), path, query, header)
+
+	switch op.Result.Kind {
+	case resultNone:
+		fmt.Fprintf(b, "\treturn c.do(ctx, %s, %s, nil)\n", call, bodyArg)
+	case resultRaw:
+		if header == "nil" {
+			b.WriteString("\theader := http.Header{}\n")
+			call = fmt.Sprintf("%s, %s, %s, header", "http.Method"+methodName(op.Method), path, query)
+		}
+		fmt.Fprintf(b, "\theader.Set(\"Accept\", %q)\n", op.Result.MediaType)
+		fmt.Fprintf(b, "\treturn c.send(ctx, %s, %s)\n", call, bodyArg)
+	case resultPage:
+		fmt.Fprintf(b, "\tvar out %s\n", strings.TrimPrefix(resultType, "*"))
+		fmt.Fprintf(b, "\tif err := c.do(ctx, %s, %s, &out); err != nil {\n\t\treturn %s, err\n\t}\n\treturn &out, nil\n", call, bodyArg, zero)
+	default:
+		target := "out"
+		if op.Result.Kind == resultData {
+			fmt.Fprintf(b, "\tvar out envelope[%s]\n", strings.TrimPrefix(resultType, "*"))
+			target = "out.Data"
+		} else {
+			fmt.Fprintf(b, "\tvar out %s\n", strings.TrimPrefix(resultType, "*"))
+		}
+		if zero == "nil" {
+			fmt.Fprintf(b, "\tif err := c.do(ctx, %s, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &%s, nil\n", call, bodyArg, target)
+		} else {
+			fmt.Fprintf(b, "\terr := c.do(ctx, %s, %s, &out)\n\treturn %s, err\n", call, bodyArg, target)
+		}
+	}
+	b.WriteString("}\n\n")
+}
+
+func methodName(method string) string {
+	return upperFirst(strings.ToLower(method))
+}
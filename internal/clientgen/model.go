@@ -0,0 +1,253 @@
+// Package clientgen generates the typed API clients in client/ from the OpenAPI document: Go
+// (package bibently) and TypeScript. Only the operations and types are generated; each client's
+// request plumbing is hand-written beside them.
+package clientgen
+
+import (
+	"bibently.com/backend/internal/openapi"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Header starts every generated file
+const Header = "Code generated by cmd/genclient from the OpenAPI document; DO NOT EDIT."
+
+// operation is an OpenAPI operation reduced to what a client method needs
+type operation struct {
+	ID          string
+	Method      string
+	Path        string // with the server prefix, e.g. /v1/events/{id}
+	Summary     string
+	Description string
+	PathParams  []param // in path order
+	Params      []param // query and header
+	Body        *requestBody
+	Result      result
+}
+
+type param struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Schema      *openapi.Schema
+}
+
+type requestBody struct {
+	MediaType string
+	Required  bool
+	Schema    *openapi.Schema
+}
+
+type resultKind int
+
+const (
+	resultNone resultKind = iota // no body
+	resultJSON                   // the schema as is
+	resultData                   // {"data": schema}, unwrapped
+	resultPage                   // {"data": [schema], "meta": Meta}
+	resultRaw                    // not JSON (NDJSON, SSE, text); the caller reads the response
+)
+
+type result struct {
+	Kind      resultKind
+	Schema    *openapi.Schema // the item schema of a page
+	MediaType string          // of raw results
+	Optional  bool            // another 2xx status answers without a body
+}
+
+// operations lists the operations of doc sorted by path, then method
+func operations(doc *openapi.Document) []operation {
+	var ops []operation
+	for _, path := range slices.Sorted(maps.Keys(doc.Paths)) {
+		item := doc.Paths[path]
+		servers := doc.Servers
+		if len(item.Servers) > 0 {
+			servers = item.Servers
+		}
+		prefix := ""
+		if len(servers) > 0 {
+			prefix = strings.TrimSuffix(servers[0].URL, "/")
+		}
+		for _, m := range []struct {
+			method string
+			op     *openapi.Operation
+		}{
+			{http.MethodGet, item.Get}, {http.MethodPost, item.Post}, {http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch}, {http.MethodDelete, item.Delete},
+		} {
+			if m.op != nil {
+				ops = append(ops, newOperation(m.method, prefix+path, m.op))
+			}
+		}
+	}
+	return ops
+}
+
+func newOperation(method, path string, o *openapi.Operation) operation {
+	op := operation{ID: o.OperationID, Method: method, Path: path, Summary: o.Summary, Description: o.Description}
+	for _, p := range o.Parameters {
+		pr := param{Name: p.Name, In: p.In, Description: p.Description, Required: p.Required, Schema: p.Schema}
+		if p.In == "path" {
+			op.PathParams = append(op.PathParams, pr)
+		} else {
+			op.Params = append(op.Params, pr)
+		}
+	}
+	slices.SortStableFunc(op.PathParams, func(a, b param) int {
+		return strings.Index(path, "{"+a.Name+"}") - strings.Index(path, "{"+b.Name+"}")
+	})
+
+	if rb := o.RequestBody; rb != nil && len(rb.Content) > 0 {
+		mediaType := slices.Sorted(maps.Keys(rb.Content))[0]
+		op.Body = &requestBody{MediaType: mediaType, Required: rb.Required, Schema: rb.Content[mediaType].Schema}
+	}
+
+	var fallback *result
+	for _, status := range slices.Sorted(maps.Keys(o.Responses)) {
+		code, err := strconv.Atoi(status)
+		resp := o.Responses[status]
+		if err != nil || code < 200 || code > 299 || resp.Ref != "" {
+			continue
+		}
+		if len(resp.Content) == 0 {
+			op.Result.Optional = true
+			continue
+		}
+		if media, ok := resp.Content["application/json"]; ok {
+			if op.Result.Kind == resultNone {
+				op.Result = classify(media.Schema, op.Result.Optional)
+			}
+		} else if fallback == nil {
+			mediaType := slices.Sorted(maps.Keys(resp.Content))[0]
+			fallback = &result{Kind: resultRaw, MediaType: mediaType}
+		}
+	}
+	if op.Result.Kind == resultNone && fallback != nil {
+		op.Result = *fallback
+	}
+	return op
+}
+
+// classify recognises the response envelopes
+func classify(s *openapi.Schema, optional bool) result {
+	if s != nil && s.Ref == "" && s.Properties["data"] != nil {
+		data := s.Properties["data"]
+		switch {
+		case len(s.Properties) == 1:
+			return result{Kind: resultData, Schema: data, Optional: optional}
+		case len(s.Properties) == 2 && s.Properties["meta"] != nil && data.Items != nil:
+			return result{Kind: resultPage, Schema: data.Items, Optional: optional}
+		}
+	}
+	return result{Kind: resultJSON, Schema: s, Optional: optional}
+}
+
+// schemaType returns the JSON type of s and whether null is allowed too. Type holds a string or,
+// for nullable schemas, a list ([]string when built, []any when decoded).
+func schemaType(s *openapi.Schema) (typ string, nullable bool) {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		return t, false
+	case []string:
+		types = t
+	case []any:
+		for _, v := range t {
+			if v, ok := v.(string); ok {
+				types = append(types, v)
+			}
+		}
+	}
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+		} else if typ == "" {
+			typ = t
+		}
+	}
+	return typ, nullable
+}
+
+// nullableRef returns the referenced schema of anyOf [ref, null]
+func nullableRef(s *openapi.Schema) *openapi.Schema {
+	if len(s.AnyOf) != 2 {
+		return nil
+	}
+	if typ, _ := schemaType(s.AnyOf[1]); typ == "null" && s.AnyOf[0].Ref != "" {
+		return s.AnyOf[0]
+	}
+	return nil
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// words splits a name at separators and lower-to-upper case changes: "event_name", "If-None-Match"
+// and "eventName" give two or three words each
+func words(s string) []string {
+	var out []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			out = append(out, string(current))
+			current = nil
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+	return out
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// wrap splits text into lines of at most width runes
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,283 @@
+package clientgen
+
+import (
+	"bibently.com/backend/internal/openapi"
+	"bytes"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScript returns the operations and types of doc as api.gen.ts, whose class Client extends the
+// hand-written BaseClient of runtime.ts
+func TypeScript(doc *openapi.Document) ([]byte, error) {
+	t := &tsGen{schemas: doc.Components.Schemas}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\nimport { BaseClient } from \"./runtime\";\n\n", Header)
+	b.WriteString("/** A page of a paginated list; pass meta.next_page_token as page_token to read the next one */\n")
+	b.WriteString("export interface Page<T> {\n  data: T[];\n  meta: Meta;\n}\n\n")
+
+	for _, name := range slices.Sorted(maps.Keys(t.schemas)) {
+		t.writeComponent(&b, name, t.schemas[name])
+	}
+
+	ops := operations(doc)
+	for _, op := range ops {
+		if len(op.Params) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "/** Query and header parameters of %s */\nexport interface %sParams {\n", lowerFirst(op.ID), upperFirst(op.ID))
+		for _, p := range op.Params {
+			if p.Description != "" {
+				fmt.Fprintf(&b, "  /** %s */\n", p.Description)
+			}
+			optional := "?"
+			if p.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", tsKey(p.Name), optional, t.typeOf(p.Schema))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString("export class Client extends BaseClient {\n")
+	for i, op := range ops {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		t.writeOperation(&b, op)
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+type tsGen struct {
+	schemas map[string]*openapi.Schema
+}
+
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// tsAccess reads the property name of object
+func tsAccess(object, name string) string {
+	if tsIdentifier.MatchString(name) {
+		return object + "." + name
+	}
+	return object + "[" + strconv.Quote(name) + "]"
+}
+
+func tsParamName(name string) string {
+	w := words(name)
+	for i := range w {
+		if i == 0 {
+			w[i] = strings.ToLower(w[i])
+		} else {
+			w[i] = upperFirst(strings.ToLower(w[i]))
+		}
+	}
+	return strings.Join(w, "")
+}
+
+func (t *tsGen) writeComponent(b *bytes.Buffer, name string, s *openapi.Schema) {
+	if s.Description != "" {
+		fmt.Fprintf(b, "/** %s */\n", s.Description)
+	}
+	if typ, _ := schemaType(s); typ == "string" && len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = strconv.Quote(fmt.Sprint(v))
+		}
+		fmt.Fprintf(b, "export type %s = %s;\n\n", name, strings.Join(values, " | "))
+		fmt.Fprintf(b, "export const %sValues: readonly %s[] = [%s];\n\n", name, name, strings.Join(values, ", "))
+		return
+	}
+	if typ, _ := schemaType(s); typ == "object" && s.AdditionalProperties == nil {
+		fmt.Fprintf(b, "export interface %s %s\n\n", name, t.objectType(s, ""))
+		return
+	}
+	fmt.Fprintf(b, "export type %s = %s;\n\n", name, t.typeOf(s))
+}
+
+func (t *tsGen) objectType(s *openapi.Schema, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, prop := range slices.Sorted(maps.Keys(s.Properties)) {
+		ps := s.Properties[prop]
+		optional := "?"
+		if slices.Contains(s.Required, prop) {
+			optional = ""
+		}
+		if ps.Description != "" {
+			fmt.Fprintf(&b, "%s  /** %s */\n", indent, ps.Description)
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, tsKey(prop), optional, t.typeOf(ps))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func (t *tsGen) typeOf(s *openapi.Schema) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+	if ref := nullableRef(s); ref != nil {
+		return refName(ref.Ref) + " | null"
+	}
+	typ, nullable := schemaType(s)
+	var ts string
+	switch typ {
+	case "string":
+		ts = "string"
+		if len(s.Enum) > 0 {
+			values := make([]string, len(s.Enum))
+			for i, v := range s.Enum {
+				values[i] = strconv.Quote(fmt.Sprint(v))
+			}
+			ts = strings.Join(values, " | ")
+		}
+	case "integer", "number":
+		ts = "number"
+	case "boolean":
+		ts = "boolean"
+	case "array":
+		item := t.typeOf(s.Items)
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+		ts = item + "[]"
+	case "object":
+		switch {
+		case s.AdditionalProperties != nil:
+			ts = "Record<string, " + t.typeOf(s.AdditionalProperties) + ">"
+		case len(s.Properties) > 0:
+			ts = t.objectType(s, "  ")
+		default:
+			ts = "Record<string, unknown>"
+		}
+	default:
+		return "unknown"
+	}
+	if nullable {
+		return ts + " | null"
+	}
+	return ts
+}
+
+func (t *tsGen) writeOperation(b *bytes.Buffer, op operation) {
+	var args []string
+	for _, p := range op.PathParams {
+		args = append(args, tsParamName(p.Name)+": string")
+	}
+	bodyMedia := ""
+	if op.Body != nil {
+		bodyMedia = op.Body.MediaType
+		typ := "BodyInit"
+		switch op.Body.MediaType {
+		case "application/json":
+			typ = t.typeOf(op.Body.Schema)
+		case "multipart/form-data":
+			typ = "FormData"
+		}
+		optional := ""
+		if !op.Body.Required {
+			optional = "?"
+		}
+		args = append(args, "body"+optional+": "+typ)
+	}
+	if len(op.Params) > 0 {
+		required := slices.ContainsFunc(op.Params, func(p param) bool { return p.Required })
+		if required {
+			args = append(args, "params: "+upperFirst(op.ID)+"Params")
+		} else {
+			args = append(args, "params: "+upperFirst(op.ID)+"Params = {}")
+		}
+	}
+
+	var result, call string
+	switch op.Result.Kind {
+	case resultNone:
+		result, call = "void", "request<void>"
+	case resultRaw:
+		result, call = "Response", "send"
+	case resultPage:
+		result = "Page<" + t.typeOf(op.Result.Schema) + ">"
+		call = "request<" + result + ">"
+	case resultData:
+		result = t.typeOf(op.Result.Schema)
+		call = "request<{ data: " + result + " }>"
+	default:
+		result = t.typeOf(op.Result.Schema)
+		call = "request<" + result + ">"
+	}
+	if op.Result.Optional && op.Result.Kind != resultNone && op.Result.Kind != resultRaw {
+		result += " | undefined"
+	}
+
+	fmt.Fprintf(b, "  /**\n   * %s (%s %s)\n", op.Summary, op.Method, op.Path)
+	for _, line := range wrap(op.Description, 96) {
+		fmt.Fprintf(b, "   * %s\n", line)
+	}
+	b.WriteString("   */\n")
+	fmt.Fprintf(b, "  async %s(%s): Promise<%s> {\n", lowerFirst(op.ID), strings.Join(args, ", "), result)
+
+	path := "\"" + op.Path + "\""
+	if len(op.PathParams) > 0 {
+		path = "`" + op.Path + "`"
+		for _, p := range op.PathParams {
+			path = strings.Replace(path, "{"+p.Name+"}", "${encodeURIComponent("+tsParamName(p.Name)+")}", 1)
+		}
+	}
+
+	var options []string
+	var query, headers []string
+	for _, p := range op.Params {
+		entry := tsKey(p.Name) + ": " + tsAccess("params", p.Name)
+		if p.In == "header" {
+			headers = append(headers, entry)
+		} else {
+			query = append(query, entry)
+		}
+	}
+	if op.Result.Kind == resultRaw {
+		headers = append(headers, "Accept: "+strconv.Quote(op.Result.MediaType))
+	}
+	if len(query) > 0 {
+		options = append(options, "query: { "+strings.Join(query, ", ")+" }")
+	}
+	if len(headers) > 0 {
+		options = append(options, "headers: { "+strings.Join(headers, ", ")+" }")
+	}
+	if op.Body != nil {
+		options = append(options, "body")
+		if bodyMedia != "application/json" {
+			options = append(options, "contentType: "+strconv.Quote(bodyMedia))
+		}
+	}
+	optionArg := ""
+	if len(options) > 0 {
+		optionArg = ", { " + strings.Join(options, ", ") + " }"
+	}
+
+	expr := fmt.Sprintf("this.%s(%q, %s%s)", call, op.Method, path, optionArg)
+	switch {
+	case op.Result.Kind == resultData && op.Result.Optional:
+		fmt.Fprintf(b, "    return (await %s)?.data;\n", expr)
+	case op.Result.Kind == resultData:
+		fmt.Fprintf(b, "    return (await %s).data;\n", expr)
+	default:
+		fmt.Fprintf(b, "    return %s;\n", expr)
+	}
+	b.WriteString("  }\n")
+}
@@ -0,0 +1,475 @@
+// Package config loads the function's settings from the environment once, at cold start.
+// Load checks every value before anything is built and reports all problems together,
+// so a bad deployment fails on its first request with one readable message.
+package config
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/flags"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/secrets"
+)
+
+// Config holds every setting of the HTTP function, the gRPC server and the local runner.
+// Comments name the environment variable each field is read from.
+type Config struct {
+	ProjectID  string // GOOGLE_CLOUD_PROJECT; required unless MemoryStorage
+	DatabaseID string // FIRESTORE_DATABASE_ID, "" is the default database
+	// Databases (FIRESTORE_DATABASES, comma-separated) are served besides DatabaseID, picked per
+	// request by DatabaseRouting (FIRESTORE_DATABASE_ROUTING): header (default) or tenant
+	Databases       []string
+	DatabaseRouting string
+	MemoryStorage   bool // STORAGE_BACKEND=memory
+	Production      bool // APP_ENV=production
+	// LogLevel (LOG_LEVEL: debug, info, warn or error; default info) is where logging starts;
+	// PUT /admin/log-level changes it on a running instance
+	LogLevel slog.Level
+	// DebugLogRoutes (DEBUG_LOG_ROUTES, comma-separated route patterns such as "/events/**") log
+	// redacted request and response bodies; not allowed with APP_ENV=production
+	DebugLogRoutes []string
+	// APIDocs (API_DOCS: public, admin or off) is who may read /openapi.json and use Swagger UI at
+	// /swagger/; the default is public, and admin with APP_ENV=production
+	APIDocs string
+
+	Server    Server
+	Auth      Auth
+	Events    Events
+	Cache     Cache
+	Mail      Mail
+	Tracking  Tracking
+	Tasks     Tasks
+	Cron      Cron
+	RateLimit RateLimit
+	Timeouts  Timeouts
+	Flags     Flags
+
+	PublicSiteURL         string        // PUBLIC_SITE_URL, for links in notifications
+	PushEnabled           bool          // PUSH_NOTIFICATIONS_ENABLED
+	OpsWebhookURL         string        // OPS_WEBHOOK_URL (secret)
+	PartnerWebhookSecret  string        // PARTNER_WEBHOOK_SECRET (secret)
+	MetricsEnabled        bool          // METRICS_ENABLED
+	PprofEnabled          bool          // PPROF_ENABLED
+	BackupBucket          string        // BACKUP_BUCKET, without the gs:// prefix
+	TraceSampleRatio      float64       // TRACE_SAMPLE_RATIO (default 0.1)
+	ErrorReportingService string        // K_SERVICE when ERROR_REPORTING_ENABLED, "" when disabled
+	LegacySunset          time.Time     // LEGACY_API_SUNSET (RFC 3339)
+	ResponseCacheTTL      time.Duration // RESPONSE_CACHE_TTL (default 10s, 0 disables)
+	EventCacheControl     string        // EVENT_CACHE_CONTROL
+}
+
+// Server configures the local and gRPC entrypoints; Cloud Functions ignore it
+type Server struct {
+	Port             string // PORT; each entrypoint has its own default
+	LocalOnly        bool   // LOCAL_ONLY binds to 127.0.0.1
+	AuthEmulatorHost string // FIREBASE_AUTH_EMULATOR_HOST
+}
+
+type Auth struct {
+	AdminUID       string // FIRESTORE_ADMIN_UID
+	PolicyFile     string // AUTH_POLICY_FILE
+	MultiTenancy   bool   // MULTI_TENANCY
+	AllowedOrigins string // CORS_ALLOWED_ORIGIN, comma-separated
+}
+
+type Events struct {
+	PageTokenSecret string                 // PAGE_TOKEN_SECRET (secret)
+	CardProjection  bool                   // EVENT_LIST_PROJECTION=card
+	CollectionGroup bool                   // EVENTS_COLLECTION_GROUP
+	DuplicatePolicy domain.DuplicatePolicy // EVENT_DUPLICATE_POLICY (default reject)
+	// Types (EVENT_TYPES) are the comma-separated event types clients may use, replacing
+	// domain.DefaultEventTypes, e.g. "concert,festival,workshop"
+	Types        []domain.EventType
+	TypesDynamic bool          // EVENT_TYPES_DYNAMIC reads the types from Firestore, over EVENT_TYPES
+	TypesTTL     time.Duration // EVENT_TYPES_TTL (default 60s) caches the Firestore list
+	// StripTrackingParams (EVENT_URL_STRIP_TRACKING) removes utm_* and click ids from event links
+	StripTrackingParams bool
+}
+
+type Cache struct {
+	RedisAddr     string        // REDIS_ADDR
+	RedisPassword string        // REDIS_PASSWORD (secret)
+	TTL           time.Duration // EVENT_CACHE_TTL_SECONDS (default 60)
+}
+
+type Mail struct {
+	Provider      string // MAIL_PROVIDER: sendgrid, mailgun or "" (disabled)
+	APIKey        string // MAIL_API_KEY (secret)
+	From          string // MAIL_FROM
+	APIBaseURL    string // MAIL_API_BASE_URL
+	MailgunDomain string // MAILGUN_DOMAIN
+}
+
+type Tracking struct {
+	TTL           bool                   // TRACKING_TTL
+	RetentionDays int                    // TRACKING_RETENTION_DAYS
+	Privacy       domain.TrackingPrivacy // TRACKING_PRIVACY
+	HashKey       string                 // TRACKING_HASH_KEY (secret)
+	BigQueryTable string                 // BIGQUERY_TRACKING_TABLE
+}
+
+type Tasks struct {
+	Queue          string // TASKS_QUEUE
+	WorkerURL      string // TASKS_WORKER_URL
+	ServiceAccount string // TASKS_SERVICE_ACCOUNT
+}
+
+type Cron struct {
+	ServiceAccount   string // CRON_SERVICE_ACCOUNT
+	Audience         string // CRON_AUDIENCE, defaults to TASKS_WORKER_URL
+	ArchiveAfterDays int    // ARCHIVE_AFTER_DAYS
+}
+
+type RateLimit struct {
+	Firestore     bool    // RATE_LIMIT_BACKEND=firestore
+	RPS           float64 // RATE_LIMIT_RPS (default 10)
+	Burst         int     // RATE_LIMIT_BURST (default 20)
+	TrackingRPS   float64 // TRACKING_RATE_LIMIT_RPS (default 1)
+	TrackingBurst int     // TRACKING_RATE_LIMIT_BURST (default 10)
+}
+
+type Timeouts struct {
+	Request time.Duration // REQUEST_TIMEOUT (default 15s)
+	Export  time.Duration // EXPORT_TIMEOUT (default 60s)
+	SSE     time.Duration // SSE_TIMEOUT (default 5m)
+}
+
+type Flags struct {
+	// Overrides (FEATURE_FLAGS) are comma-separated flag names, each optionally "=false",
+	// e.g. "guest_read=false,webhooks"
+	Overrides map[string]bool
+	Dynamic   bool          // FEATURE_FLAGS_DYNAMIC also reads overrides from Firestore
+	TTL       time.Duration // FEATURE_FLAGS_TTL (default 30s) caches the Firestore overrides
+}
+
+// Error lists every missing or invalid setting Load found
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// FromEnv loads the process environment, resolving Secret Manager references
+func FromEnv(ctx context.Context) (*Config, error) {
+	return Load(ctx, os.Getenv, secrets.NewResolver().Resolve)
+}
+
+// Load reads the settings through getenv; values of secret settings go through resolve, so they
+// may be sm:// references. The error is an *Error naming each bad variable.
+func Load(ctx context.Context, getenv func(string) string, resolve func(context.Context, string) (string, error)) (*Config, error) {
+	l := &loader{ctx: ctx, getenv: getenv, resolve: resolve}
+	storage := l.oneOf("STORAGE_BACKEND", "firestore", "memory")
+	cfg := &Config{
+		ProjectID:       l.str("GOOGLE_CLOUD_PROJECT"),
+		DatabaseID:      l.str("FIRESTORE_DATABASE_ID"),
+		Databases:       l.list("FIRESTORE_DATABASES"),
+		DatabaseRouting: cmp.Or(l.oneOf("FIRESTORE_DATABASE_ROUTING", "header", "tenant"), "header"),
+		MemoryStorage:   storage == "memory",
+		Production:      l.str("APP_ENV") == "production",
+		Server: Server{
+			Port:             l.str("PORT"),
+			LocalOnly:        l.bool("LOCAL_ONLY"),
+			AuthEmulatorHost: l.str("FIREBASE_AUTH_EMULATOR_HOST"),
+		},
+		Auth: Auth{
+			AdminUID:       l.str("FIRESTORE_ADMIN_UID"),
+			PolicyFile:     l.str("AUTH_POLICY_FILE"),
+			MultiTenancy:   l.bool("MULTI_TENANCY"),
+			AllowedOrigins: l.str("CORS_ALLOWED_ORIGIN"),
+		},
+		Events: Events{
+			PageTokenSecret:     l.secret("PAGE_TOKEN_SECRET"),
+			CardProjection:      l.oneOf("EVENT_LIST_PROJECTION", "full", "card") == "card",
+			CollectionGroup:     l.bool("EVENTS_COLLECTION_GROUP"),
+			DuplicatePolicy:     domain.DuplicatePolicy(l.str("EVENT_DUPLICATE_POLICY")),
+			Types:               l.eventTypes("EVENT_TYPES"),
+			TypesDynamic:        l.bool("EVENT_TYPES_DYNAMIC"),
+			TypesTTL:            l.duration("EVENT_TYPES_TTL", time.Minute),
+			StripTrackingParams: l.bool("EVENT_URL_STRIP_TRACKING"),
+		},
+		Cache: Cache{
+			RedisAddr:     l.str("REDIS_ADDR"),
+			RedisPassword: l.secret("REDIS_PASSWORD"),
+			TTL:           time.Duration(l.int("EVENT_CACHE_TTL_SECONDS", 60)) * time.Second,
+		},
+		Mail: Mail{
+			Provider:      l.oneOf("MAIL_PROVIDER", "sendgrid", "mailgun"),
+			APIKey:        l.secret("MAIL_API_KEY"),
+			From:          l.str("MAIL_FROM"),
+			APIBaseURL:    l.str("MAIL_API_BASE_URL"),
+			MailgunDomain: l.str("MAILGUN_DOMAIN"),
+		},
+		Tracking: Tracking{
+			TTL:           l.bool("TRACKING_TTL"),
+			RetentionDays: l.int("TRACKING_RETENTION_DAYS", domain.DefaultTrackingRetentionDays),
+			Privacy:       domain.TrackingPrivacy(l.str("TRACKING_PRIVACY")),
+			HashKey:       l.secret("TRACKING_HASH_KEY"),
+			BigQueryTable: l.str("BIGQUERY_TRACKING_TABLE"),
+		},
+		Tasks: Tasks{
+			Queue:          l.str("TASKS_QUEUE"),
+			WorkerURL:      l.str("TASKS_WORKER_URL"),
+			ServiceAccount: l.str("TASKS_SERVICE_ACCOUNT"),
+		},
+		Cron: Cron{
+			ServiceAccount:   l.str("CRON_SERVICE_ACCOUNT"),
+			Audience:         cmp.Or(l.str("CRON_AUDIENCE"), l.str("TASKS_WORKER_URL")),
+			ArchiveAfterDays: l.int("ARCHIVE_AFTER_DAYS", domain.DefaultArchiveAfterDays),
+		},
+		RateLimit: RateLimit{
+			Firestore:     l.oneOf("RATE_LIMIT_BACKEND", "memory", "firestore") == "firestore",
+			RPS:           l.float("RATE_LIMIT_RPS", 10),
+			Burst:         l.int("RATE_LIMIT_BURST", 20),
+			TrackingRPS:   l.float("TRACKING_RATE_LIMIT_RPS", 1),
+			TrackingBurst: l.int("TRACKING_RATE_LIMIT_BURST", 10),
+		},
+		Timeouts: Timeouts{
+			Request: l.duration("REQUEST_TIMEOUT", 15*time.Second),
+			Export:  l.duration("EXPORT_TIMEOUT", 60*time.Second),
+			SSE:     l.duration("SSE_TIMEOUT", 5*time.Minute),
+		},
+		Flags: Flags{
+			Overrides: l.flags("FEATURE_FLAGS"),
+			Dynamic:   l.bool("FEATURE_FLAGS_DYNAMIC"),
+			TTL:       l.duration("FEATURE_FLAGS_TTL", 30*time.Second),
+		},
+		PublicSiteURL:        l.str("PUBLIC_SITE_URL"),
+		PushEnabled:          l.bool("PUSH_NOTIFICATIONS_ENABLED"),
+		OpsWebhookURL:        l.secret("OPS_WEBHOOK_URL"),
+		PartnerWebhookSecret: l.secret("PARTNER_WEBHOOK_SECRET"),
+		MetricsEnabled:       l.bool("METRICS_ENABLED"),
+		PprofEnabled:         l.bool("PPROF_ENABLED"),
+		BackupBucket:         strings.TrimSuffix(strings.TrimPrefix(l.str("BACKUP_BUCKET"), "gs://"), "/"),
+		TraceSampleRatio:     l.float("TRACE_SAMPLE_RATIO", 0.1),
+		LegacySunset:         l.timestamp("LEGACY_API_SUNSET"),
+		ResponseCacheTTL:     l.duration("RESPONSE_CACHE_TTL", 10*time.Second),
+		EventCacheControl:    l.str("EVENT_CACHE_CONTROL"),
+		DebugLogRoutes:       l.list("DEBUG_LOG_ROUTES"),
+		APIDocs:              l.oneOf("API_DOCS", "public", "admin", "off"),
+	}
+	if v := l.str("LOG_LEVEL"); v != "" {
+		level, err := logging.ParseLevel(v)
+		if err != nil {
+			l.fail("LOG_LEVEL", "must be debug, info, warn or error, got %q", v)
+		}
+		cfg.LogLevel = level
+	}
+	if l.bool("ERROR_REPORTING_ENABLED") {
+		cfg.ErrorReportingService = cmp.Or(l.str("K_SERVICE"), "bibently-backend")
+	}
+
+	// Settings that only make sense together
+	if cfg.MemoryStorage {
+		cfg.ProjectID = cmp.Or(cfg.ProjectID, "local-project-id")
+	} else if cfg.ProjectID == "" {
+		l.fail("GOOGLE_CLOUD_PROJECT", "is required unless STORAGE_BACKEND=memory")
+	}
+	if cfg.Events.DuplicatePolicy == "" {
+		cfg.Events.DuplicatePolicy = domain.DuplicateReject
+	} else if !cfg.Events.DuplicatePolicy.IsValid() {
+		l.fail("EVENT_DUPLICATE_POLICY", "must be allow, reject or return_existing, got %q", cfg.Events.DuplicatePolicy)
+	}
+	if !cfg.Tracking.Privacy.IsValid() {
+		l.fail("TRACKING_PRIVACY", "must be hash or drop, got %q", cfg.Tracking.Privacy)
+	} else if cfg.Tracking.Privacy == domain.TrackingPrivacyHash && cfg.Tracking.HashKey == "" {
+		l.fail("TRACKING_HASH_KEY", "is required with TRACKING_PRIVACY=hash")
+	}
+	if cfg.Mail.Provider != "" {
+		l.require("MAIL_API_KEY", cfg.Mail.APIKey, "MAIL_PROVIDER")
+		l.require("MAIL_FROM", cfg.Mail.From, "MAIL_PROVIDER")
+		if cfg.Mail.Provider == "mailgun" {
+			l.require("MAILGUN_DOMAIN", cfg.Mail.MailgunDomain, "MAIL_PROVIDER=mailgun")
+		}
+	}
+	// Subscribers are notified by a task worker, never inside the create request
+	if cfg.Mail.Provider != "" {
+		l.require("TASKS_QUEUE", cfg.Tasks.Queue, "MAIL_PROVIDER")
+	} else if cfg.PushEnabled {
+		l.require("TASKS_QUEUE", cfg.Tasks.Queue, "PUSH_NOTIFICATIONS_ENABLED")
+	}
+	if cfg.Tasks.Queue != "" {
+		l.require("TASKS_WORKER_URL", cfg.Tasks.WorkerURL, "TASKS_QUEUE")
+		l.require("TASKS_SERVICE_ACCOUNT", cfg.Tasks.ServiceAccount, "TASKS_QUEUE")
+	}
+	if cfg.Cron.ServiceAccount != "" && cfg.Cron.Audience == "" {
+		l.fail("CRON_AUDIENCE", "is required with CRON_SERVICE_ACCOUNT unless TASKS_WORKER_URL is set")
+	}
+	if cfg.Production && len(cfg.DebugLogRoutes) > 0 {
+		l.fail("DEBUG_LOG_ROUTES", "is not allowed with APP_ENV=production")
+	}
+	if cfg.APIDocs == "" {
+		cfg.APIDocs = "public"
+		if cfg.Production {
+			cfg.APIDocs = "admin"
+		}
+	}
+	if cfg.Timeouts.Request == 0 {
+		l.fail("REQUEST_TIMEOUT", "must be positive")
+	}
+
+	if len(l.problems) > 0 {
+		return nil, &Error{Problems: l.problems}
+	}
+	return cfg, nil
+}
+
+// loader reads variables, recording a problem for each invalid one instead of stopping
+type loader struct {
+	ctx      context.Context
+	getenv   func(string) string
+	resolve  func(context.Context, string) (string, error)
+	problems []string
+}
+
+func (l *loader) fail(key, format string, args ...any) {
+	l.problems = append(l.problems, key+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *loader) require(key, value, by string) {
+	if value == "" {
+		l.fail(key, "is required with %s", by)
+	}
+}
+
+func (l *loader) str(key string) string {
+	return strings.TrimSpace(l.getenv(key))
+}
+
+func (l *loader) secret(key string) string {
+	v, err := l.resolve(l.ctx, l.str(key))
+	if err != nil {
+		l.fail(key, "cannot be resolved: %v", err)
+	}
+	return v
+}
+
+func (l *loader) list(key string) []string {
+	return strings.FieldsFunc(l.str(key), func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// oneOf returns the value if it is one of allowed; "" is always allowed
+func (l *loader) oneOf(key string, allowed ...string) string {
+	v := l.str(key)
+	if v != "" && !slices.Contains(allowed, v) {
+		l.fail(key, "must be one of %s, got %q", strings.Join(allowed, ", "), v)
+		return ""
+	}
+	return v
+}
+
+// flags reads "name[=bool]" items, reporting names this build does not know
+func (l *loader) flags(key string) map[string]bool {
+	items := l.list(key)
+	if len(items) == 0 {
+		return nil
+	}
+	overrides := make(map[string]bool, len(items))
+	for _, item := range items {
+		name, value, hasValue := strings.Cut(item, "=")
+		on := true
+		if hasValue {
+			var err error
+			if on, err = strconv.ParseBool(value); err != nil {
+				l.fail(key, "has an invalid value for %s: %q", name, value)
+				continue
+			}
+		}
+		if !flags.Known(name) {
+			l.fail(key, "names unknown flag %q (known: %s)", name, strings.Join(flags.Names(), ", "))
+			continue
+		}
+		overrides[name] = on
+	}
+	return overrides
+}
+
+// eventTypes reads a list of event type names, reporting names that cannot be types
+func (l *loader) eventTypes(key string) []domain.EventType {
+	var types []domain.EventType
+	for _, name := range l.list(key) {
+		if !domain.ValidEventTypeName(name) {
+			l.fail(key, "has an invalid event type %q (lowercase letters, digits and _, starting with a letter)", name)
+			continue
+		}
+		if !slices.Contains(types, domain.EventType(name)) {
+			types = append(types, domain.EventType(name))
+		}
+	}
+	return types
+}
+
+func (l *loader) bool(key string) bool {
+	v := l.str(key)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		l.fail(key, "must be true or false, got %q", v)
+	}
+	return b
+}
+
+// int reads a positive integer, def when unset
+func (l *loader) int(key string, def int) int {
+	v := l.str(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		l.fail(key, "must be a positive integer, got %q", v)
+		return def
+	}
+	return n
+}
+
+func (l *loader) float(key string, def float64) float64 {
+	v := l.str(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f <= 0 {
+		l.fail(key, "must be a positive number, got %q", v)
+		return def
+	}
+	return f
+}
+
+// duration reads a Go duration such as "30s"; 0 is allowed
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+	v := l.str(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		l.fail(key, "must be a duration such as 30s, got %q", v)
+		return def
+	}
+	return d
+}
+
+func (l *loader) timestamp(key string) time.Time {
+	v := l.str(key)
+	if v == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		l.fail(key, "must be an RFC 3339 timestamp, got %q", v)
+	}
+	return t
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "context"
+
+const (
+	RoleAdmin     = "admin"
+	RoleOrganizer = "organizer" // may manage only the events it created
+)
+
+// Principal is the authenticated caller as seen by the service layer
+type Principal struct {
+	UID  string
+	Role string // RoleAdmin, RoleOrganizer or empty for regular users
+}
+
+type principalKey struct{}
+
+// WithPrincipal stores the caller so lower layers can authorize and attribute changes
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the caller, or false for anonymous/system calls
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// WithActor stores the UID of the caller so lower layers can attribute changes
+func WithActor(ctx context.Context, uid string) context.Context {
+	return WithPrincipal(ctx, Principal{UID: uid})
+}
+
+// ActorFromContext returns the caller UID, or an empty string for anonymous/system calls
+func ActorFromContext(ctx context.Context) string {
+	p, _ := PrincipalFromContext(ctx)
+	return p.UID
+}
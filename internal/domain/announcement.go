@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// AnnouncementTask is the payload of the worker task that announces a newly created event
+type AnnouncementTask struct {
+	EventID string `json:"event_id"`
+}
+
+// Announcement records the notification batches already delivered for an event, so a retried
+// announcement task does not mail or push the same recipients twice
+type Announcement struct {
+	EventID   string    `firestore:"event_id"`
+	Delivered []string  `firestore:"delivered"` // Batch keys, e.g. "email:0" or "push:2"
+	UpdatedAt time.Time `firestore:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DefaultArchiveAfterDays is used when the caller does not say how old an event must be to be archived
+const DefaultArchiveAfterDays = 30
+
+// ArchiveResult reports one run of the past-events archiver.
+// Runs are capped; Remaining is true when more events are eligible and the call should be repeated.
+type ArchiveResult struct {
+	Archived  int       `json:"archived"`
+	Cutoff    time.Time `json:"cutoff"`
+	Remaining bool      `json:"remaining"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MaxAuditResources caps the per-resource diffs kept on one entry (batch writes touch thousands of events)
+const MaxAuditResources = 50
+
+// AuditEntry records one write request: who made it, what it targeted, when, and how it ended.
+// Services that know the previous state attach old-vs-new values to Changes.
+type AuditEntry struct {
+	Id         string                            `firestore:"id" json:"id"`
+	Actor      string                            `firestore:"actor" json:"actor"` // UID of the caller, empty for system calls
+	Method     string                            `firestore:"method" json:"method"`
+	Path       string                            `firestore:"path" json:"path"`
+	Resource   string                            `firestore:"resource" json:"resource"`                           // first path segment, e.g. "events"
+	ResourceID string                            `firestore:"resource_id,omitempty" json:"resource_id,omitempty"` // second path segment, when present
+	Status     int                               `firestore:"status" json:"status"`
+	Changes    map[string]map[string]FieldChange `firestore:"changes,omitempty" json:"changes,omitempty"` // resource Id -> field -> old/new
+	Truncated  bool                              `firestore:"truncated,omitempty" json:"truncated,omitempty"`
+	CreatedAt  time.Time                         `firestore:"created_at" json:"created_at"`
+}
+
+// AuditFilter narrows GET /admin/audit-logs
+type AuditFilter struct {
+	Actor      string
+	Resource   string
+	ResourceID string
+	Limit      int
+}
+
+// auditRecorder collects the changes of one request; services may report from several goroutines
+type auditRecorder struct {
+	mu    sync.Mutex
+	entry *AuditEntry
+}
+
+type auditKey struct{}
+
+// WithAuditEntry makes entry the target of RecordAuditChanges for the rest of the request
+func WithAuditEntry(ctx context.Context, entry *AuditEntry) context.Context {
+	return context.WithValue(ctx, auditKey{}, &auditRecorder{entry: entry})
+}
+
+// RecordAuditChanges attaches the changed fields of one resource to the request's audit entry, if any
+func RecordAuditChanges(ctx context.Context, resourceID string, changes map[string]FieldChange) {
+	rec, ok := ctx.Value(auditKey{}).(*auditRecorder)
+	if !ok || len(changes) == 0 {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.entry.Changes == nil {
+		rec.entry.Changes = make(map[string]map[string]FieldChange)
+	}
+	if _, seen := rec.entry.Changes[resourceID]; !seen && len(rec.entry.Changes) >= MaxAuditResources {
+		rec.entry.Truncated = true
+		return
+	}
+	rec.entry.Changes[resourceID] = changes
+}
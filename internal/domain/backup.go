@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DumpCollections are the collections a JSON dump covers and a dump restore accepts
+var DumpCollections = []string{"events", "tracking"}
+
+// BackupRequest starts a managed Firestore export into BACKUP_BUCKET
+type BackupRequest struct {
+	// Collections to export; empty exports every collection of the database
+	Collections []string `json:"collections"`
+}
+
+// RestoreRequest starts a managed Firestore import. Imported documents replace existing ones
+// with the same Id; use a JSON dump restore to choose another collision strategy.
+type RestoreRequest struct {
+	// Source is the uri of an export in BACKUP_BUCKET, e.g. gs://bucket/backups/20261016T120000Z
+	Source      string   `json:"source" validate:"required"`
+	Collections []string `json:"collections"`
+}
+
+// Kinds of BackupOperation
+const (
+	BackupExport = "export"
+	BackupImport = "import"
+)
+
+// BackupOperation is the state of a managed export or import, which runs in the background;
+// poll GET /admin/backups/operations/{id} until Done
+type BackupOperation struct {
+	Id          string    `json:"id"`
+	Kind        string    `json:"kind"`  // BackupExport or BackupImport
+	State       string    `json:"state"` // PROCESSING, SUCCESSFUL, FAILED, ...
+	Done        bool      `json:"done"`
+	URI         string    `json:"uri"` // where the export is written or the import read from
+	Collections []string  `json:"collections,omitempty"`
+	Documents   int64     `json:"documents"`       // processed so far
+	Estimated   int64     `json:"estimated_total"` // estimate of the documents to process
+	StartTime   time.Time `json:"start_time,omitzero"`
+	EndTime     time.Time `json:"end_time,omitzero"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// BackupRecord is one line of a JSON dump: a document of one of DumpCollections.
+// Only the fields of the model are kept; subcollections (e.g. view_shards) are not dumped.
+type BackupRecord struct {
+	Collection string         `json:"collection"`
+	Id         string         `json:"id"`
+	Event      *Event         `json:"event,omitempty"`
+	Tracking   *TrackingEvent `json:"tracking,omitempty"`
+}
+
+// Validate checks that the record holds the document its collection and Id name
+func (r *BackupRecord) Validate() error {
+	switch {
+	case !slices.Contains(DumpCollections, r.Collection):
+		return ErrValidation(fmt.Sprintf("collection must be one of %v, got %q", DumpCollections, r.Collection))
+	case r.Id == "":
+		return ErrValidation("id is required")
+	case r.Collection == "events" && (r.Event == nil || r.Tracking != nil || r.Event.Id != r.Id):
+		return ErrValidation("an events record needs an event with the record's id")
+	case r.Collection == "tracking" && (r.Tracking == nil || r.Event != nil || r.Tracking.Id != r.Id):
+		return ErrValidation("a tracking record needs a tracking event with the record's id")
+	}
+	return nil
+}
+
+// RestoreConflict is what a dump restore does with a record whose document already exists
+type RestoreConflict string
+
+const (
+	RestoreSkip      RestoreConflict = "skip"      // keep the existing document (default)
+	RestoreOverwrite RestoreConflict = "overwrite" // replace it
+	RestoreFail      RestoreConflict = "fail"      // stop the restore
+)
+
+func (c RestoreConflict) IsValid() bool {
+	return c == RestoreSkip || c == RestoreOverwrite || c == RestoreFail
+}
+
+// RestoreResult reports a dump restore. Lines are 1-based; Stopped is set when RestoreFail
+// ended the restore early, after the batch holding the first conflict was written.
+type RestoreResult struct {
+	Restored int              `json:"restored"`
+	Skipped  int              `json:"skipped"`
+	Failed   []RestoreFailure `json:"failed"`
+	Stopped  bool             `json:"stopped"`
+}
+
+type RestoreFailure struct {
+	Line  int    `json:"line"`
+	Id    string `json:"id,omitempty"`
+	Error string `json:"error"`
+}
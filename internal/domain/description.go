@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// descriptionPolicy keeps the formatting an event page needs (paragraphs, emphasis, lists, quotes,
+// code and links) and strips everything else: scripts, styles, iframes, images, forms and event
+// handler attributes. Markdown is plain text to it, so Markdown syntax survives; a literal '<' or
+// '&' in the text is escaped. Links must be http(s) or mailto and get rel="nofollow noopener".
+var descriptionPolicy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "strong", "b", "em", "i", "u", "s", "ul", "ol", "li", "blockquote",
+		"code", "pre", "h3", "h4", "hr")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}()
+
+// SanitizeDescription returns the description as it is stored: limited HTML and Markdown with
+// every dangerous tag and attribute removed, trimmed of surrounding whitespace
+func SanitizeDescription(description string) string {
+	return strings.TrimSpace(descriptionPolicy.Sanitize(description))
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"time"
 
@@ -10,10 +12,9 @@ import (
 var Validate = validator.New()
 
 func init() {
-	// Register a custom validation tag named "event_type"
-	err := Validate.RegisterValidation("event_type", func(fl validator.FieldLevel) bool {
-		// Convert the field value to your custom type and check validity
-		return EventType(fl.Field().String()).IsValid()
+	// Register a custom validation tag named "event_type", checked against the EventTypeRegistry
+	err := Validate.RegisterValidationCtx("event_type", func(ctx context.Context, fl validator.FieldLevel) bool {
+		return EventType(fl.Field().String()).IsAllowed(ctx)
 	})
 	if err != nil {
 		return
@@ -30,6 +31,10 @@ type TrackingEventDTO struct {
 	Payload   string `json:"payload"`
 	UserAgent string `json:"user_agent"`
 	UserName  string `json:"user_name"`
+	SessionID string `json:"session_id" validate:"omitempty,max=128"`
+	EventID   string `json:"event_id" validate:"omitempty,max=128"`
+	// ClientEventID lets flaky clients retry: the event is recorded once per Id and caller
+	ClientEventID string `json:"client_event_id" validate:"omitempty,max=128"`
 }
 
 // EventDTO is used for API input/output for events
@@ -37,22 +42,35 @@ type TrackingEventDTO struct {
 // Example: EventName and City are required
 
 type EventDTO struct {
-	EventName string    `json:"event_name" validate:"required"`
-	City      string    `json:"city" validate:"required"`
-	Type      EventType `json:"type" validate:"required,event_type" example:"concert"`
-	Price     float64   `json:"price" validate:"gte=0"`
-	StartTime string    `json:"start_time" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
-	EndTime   string    `json:"end_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
+	EventName   string    `json:"event_name" validate:"required"`
+	City        string    `json:"city" validate:"required"`
+	Type        EventType `json:"type" validate:"required,event_type" example:"concert"`
+	Price       float64   `json:"price" validate:"gte=0"`
+	StartTime   string    `json:"start_time" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
+	EndTime     string    `json:"end_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
+	Capacity    int       `json:"capacity" validate:"gte=0"` // 0 means unlimited
+	OrganizerID string    `json:"organizer_id" validate:"omitempty,max=64"`
+	// Description may hold limited HTML or Markdown; it is sanitized before it is stored
+	Description string `json:"description" validate:"omitempty,max=5000"`
+	// Visibility defaults to public; unlisted events are only reachable by id or slug
+	Visibility Visibility   `json:"visibility" validate:"omitempty,oneof=public unlisted private" example:"public"`
+	Location   *GeoPointDTO `json:"location" validate:"omitempty"`
+	// Links must be absolute http(s) URLs; the event service normalizes them (NormalizeURL)
+	EventURL string `json:"event_url" validate:"omitempty,max=2048,http_url" example:"https://example.com/events/jazz-night"`
+	ImageUrl string `json:"image_url" validate:"omitempty,max=2048,http_url" example:"https://example.com/images/jazz-night.jpg"`
 	// Add other fields as needed, with appropriate validation tags
 	// OrganizerName, Country, etc.
 }
 
+// EventListSortKeys are the sort keys EventListDTO accepts (keep in sync with its SortKey validation)
+var EventListSortKeys = []string{"event_name", "city", "price", "start_time", "created_at", "popularity"}
+
 type EventListDTO struct {
 	// Pagination & Sorting
-	PageSize  int    `validate:"gte=1,lte=100"`                                               // Hard limit: 1-100
-	PageToken string `validate:"omitempty,base64"`                                            // Must be valid base64
-	SortDir   string `validate:"omitempty,oneof=asc desc"`                                    // Only "asc" or "desc"
-	SortKey   string `validate:"omitempty,oneof=event_name city price start_time created_at"` // Whitelist allowed columns
+	PageSize  int    `validate:"gte=1,lte=100"`                                                          // Hard limit: 1-100
+	PageToken string `validate:"omitempty,base64url"`                                                    // Sealed cursor, URL-safe base64
+	SortDir   string `validate:"omitempty,oneof=asc desc"`                                               // Only "asc" or "desc"
+	SortKey   string `validate:"omitempty,oneof=event_name city price start_time created_at popularity"` // Whitelist allowed columns
 
 	// Filters - Numeric
 	MinPrice *float64 `validate:"omitempty,gte=0"` // Pointer allows distinguishing "0" from "not present"
@@ -62,26 +80,95 @@ type EventListDTO struct {
 	StartDate string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	EndDate   string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 
+	// Filters - Relative date window, resolved in Timezone (IANA name, defaults to UTC)
+	When     string `validate:"omitempty,oneof=upcoming past today this_weekend,excluded_with=StartDate EndDate"`
+	Timezone string `validate:"omitempty,timezone"`
+
 	// Filters - Text
-	City      string `validate:"omitempty,max=50,printascii"` // Prevent huge strings or weird chars
-	EventName string `validate:"omitempty,max=100"`
-	Type      string `validate:"omitempty,oneof=concert festival theater standup conference meetup other"`
+	City        string `validate:"omitempty,max=50,printascii"` // Prevent huge strings or weird chars
+	EventName   string `validate:"omitempty,max=100"`
+	Type        string `validate:"omitempty,event_type"`
+	OrganizerID string `validate:"omitempty,max=64"`
 }
 
+// UpdateEventDTO is the body of PUT /events/{id}. Every field is optional; nil means "leave unchanged".
+// Only fields declared here can be written, so 'id', 'created_at' and counters cannot be overwritten.
 type UpdateEventDTO struct {
-	EventName *string  `json:"event_name" validate:"omitempty,max=100"`
-	City      *string  `json:"city" validate:"omitempty,max=50,printascii"`
-	Price     *float64 `json:"price" validate:"omitempty,gte=0"`
-	Type      *string  `json:"type" validate:"omitempty,event_type"`
-	StartTime *string  `json:"start_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
-	EndTime   *string  `json:"end_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
-
-	// You can add other fields here as needed (e.g. OrganizerName, Description)
-	// Important: Do NOT include 'id' or 'created_at' to prevent overwriting.
+	EventName   *string      `json:"event_name" validate:"omitempty,max=100"`
+	City        *string      `json:"city" validate:"omitempty,max=50,printascii"`
+	Price       *float64     `json:"price" validate:"omitempty,gte=0"`
+	Type        *string      `json:"type" validate:"omitempty,event_type" example:"concert"`
+	StartTime   *string      `json:"start_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
+	EndTime     *string      `json:"end_time" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
+	Capacity    *int         `json:"capacity" validate:"omitempty,gte=0"`
+	OrganizerID *string      `json:"organizer_id" validate:"omitempty,max=64"`
+	Description *string      `json:"description" validate:"omitempty,max=5000"`
+	Visibility  *string      `json:"visibility" validate:"omitempty,oneof=public unlisted private" example:"public"`
+	Location    *GeoPointDTO `json:"location" validate:"omitempty"`
+	EventURL    *string      `json:"event_url" validate:"omitempty,max=2048,http_url" example:"https://example.com/events/jazz-night"`
+	ImageUrl    *string      `json:"image_url" validate:"omitempty,max=2048,http_url" example:"https://example.com/images/jazz-night.jpg"`
+}
+
+// UpdatableEventFields whitelists the firestore fields an update may write.
+// Derived fields (slug, *_lc, dedup_key) are maintained by the service and repository.
+var UpdatableEventFields = map[string]bool{
+	"event_name":     true,
+	"city":           true,
+	"price":          true,
+	"type":           true,
+	"start_time":     true,
+	"end_time":       true,
+	"capacity":       true,
+	"organizer_id":   true,
+	"description":    true,
+	"visibility":     true,
+	"location":       true,
+	"event_url":      true,
+	"image_url":      true,
+	"featured":       true,
+	"featured_until": true,
+}
+
+// FeatureEventDTO toggles the promotion of an event.
+// FeaturedUntil is required when Featured is true and ignored otherwise.
+type FeatureEventDTO struct {
+	Featured      bool   `json:"featured"`
+	FeaturedUntil string `json:"featured_until" validate:"required_if=Featured true,omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T22:00:00Z"`
 }
 
+// BatchEventRequest items are validated one by one so a bad item does not reject the whole batch
 type BatchEventRequest struct {
-	Events []EventDTO `json:"events" validate:"required,min=1,max=5000,dive"`
+	Events []EventDTO `json:"events" validate:"required,min=1,max=5000"`
+}
+
+// BatchItemResult reports the outcome of one item; Index is its position in the request
+type BatchItemResult struct {
+	Index int    `json:"index"`
+	Id    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// BatchCreateResult lists created and failed items so callers can retry only the failures
+type BatchCreateResult struct {
+	Created []BatchItemResult `json:"created"`
+	Failed  []BatchItemResult `json:"failed"`
+}
+
+// ImportRowResult describes the outcome of a single CSV row.
+// Row is 1-based and counts data rows only (the header is row 0).
+type ImportRowResult struct {
+	Row     int    `json:"row"`
+	Success bool   `json:"success"`
+	EventID string `json:"event_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport is returned by the CSV import endpoint
+type ImportReport struct {
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
 }
 
 func EventDTOToModel(dto *EventDTO) (*Event, error) {
@@ -103,12 +190,80 @@ func EventDTOToModel(dto *EventDTO) (*Event, error) {
 	}
 
 	return &Event{
-		EventName: dto.EventName,
-		City:      dto.City,
-		Type:      dto.Type,
-		Price:     dto.Price,
-		StartTime: startTime,
-		EndTime:   endTime,
+		EventName:   dto.EventName,
+		City:        dto.City,
+		Type:        dto.Type,
+		Price:       dto.Price,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Capacity:    dto.Capacity,
+		OrganizerID: dto.OrganizerID,
+		Description: SanitizeDescription(dto.Description),
+		Visibility:  cmp.Or(dto.Visibility, VisibilityPublic),
+		Location:    dto.Location.LatLng(),
+		EventURL:    dto.EventURL,
+		ImageUrl:    dto.ImageUrl,
 		// Map other fields if necessary
 	}, nil
 }
+
+// UpdateEventDTOToMap converts a validated UpdateEventDTO into the sanctioned update map.
+// Only fields present in the request (non-nil) are included.
+func UpdateEventDTOToMap(dto *UpdateEventDTO) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if dto.EventName != nil {
+		updates["event_name"] = *dto.EventName
+	}
+	if dto.City != nil {
+		updates["city"] = *dto.City
+	}
+	if dto.Price != nil {
+		updates["price"] = *dto.Price
+	}
+	if dto.Type != nil {
+		updates["type"] = *dto.Type
+	}
+	if dto.StartTime != nil {
+		t, err := time.Parse(time.RFC3339, *dto.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_time format: %w", err)
+		}
+		updates["start_time"] = t
+	}
+	if dto.EndTime != nil {
+		t, err := time.Parse(time.RFC3339, *dto.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_time format: %w", err)
+		}
+		updates["end_time"] = t
+	}
+	if start, ok := updates["start_time"].(time.Time); ok {
+		if end, ok := updates["end_time"].(time.Time); ok && end.Before(start) {
+			return nil, fmt.Errorf("end_time cannot be before start_time")
+		}
+	}
+	if dto.Capacity != nil {
+		updates["capacity"] = *dto.Capacity
+	}
+	if dto.OrganizerID != nil {
+		updates["organizer_id"] = *dto.OrganizerID
+	}
+	if dto.Description != nil {
+		updates["description"] = SanitizeDescription(*dto.Description)
+	}
+	if dto.Visibility != nil {
+		updates["visibility"] = *dto.Visibility
+	}
+	if dto.Location != nil {
+		updates["location"] = dto.Location.LatLng()
+	}
+	if dto.EventURL != nil {
+		updates["event_url"] = *dto.EventURL
+	}
+	if dto.ImageUrl != nil {
+		updates["image_url"] = *dto.ImageUrl
+	}
+
+	return updates, nil
+}
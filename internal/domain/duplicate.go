@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// DuplicatePolicy controls what CreateEvent does when an identical event already exists
+type DuplicatePolicy string
+
+const (
+	DuplicateAllow          DuplicatePolicy = "allow"           // no check (legacy behaviour)
+	DuplicateReject         DuplicatePolicy = "reject"          // fail with DuplicateEventError (409)
+	DuplicateReturnExisting DuplicatePolicy = "return_existing" // succeed with the existing event's Id
+)
+
+// IsValid reports whether p is a known policy
+func (p DuplicatePolicy) IsValid() bool {
+	switch p {
+	case DuplicateAllow, DuplicateReject, DuplicateReturnExisting:
+		return true
+	}
+	return false
+}
+
+// DuplicateEventError is returned when an event with the same name, city and start time exists
+type DuplicateEventError struct {
+	ExistingID string
+}
+
+func (e *DuplicateEventError) Error() string {
+	return fmt.Sprintf("event already exists: %s", e.ExistingID)
+}
+
+func (e *DuplicateEventError) Code() string {
+	return CodeDuplicateEvent
+}
+
+// DedupKey identifies "the same event": case-insensitive name and city plus the start instant
+func DedupKey(name, city string, start time.Time) string {
+	sum := sha256.Sum256([]byte(NormalizeSearchText(name) + "|" + NormalizeSearchText(city) + "|" + start.UTC().Format(time.RFC3339)))
+	return hex.EncodeToString(sum[:])
+}
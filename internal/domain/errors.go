@@ -1,5 +1,25 @@
 package domain
 
+import "fmt"
+
+// Machine-readable error codes returned in APIResponse.Code
+const (
+	CodeValidation       = "validation_failed"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeForbidden        = "forbidden"
+	CodeDuplicateEvent   = "duplicate_event"
+	CodeRateLimited      = "rate_limited"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeUnsupportedMedia = "unsupported_media_type"
+)
+
+// CodedError is implemented by every domain error that maps to a client-facing status
+type CodedError interface {
+	error
+	Code() string
+}
+
 type ValidationError struct {
 	Msg string
 }
@@ -8,6 +28,70 @@ func (e *ValidationError) Error() string {
 	return e.Msg
 }
 
+func (e *ValidationError) Code() string {
+	return CodeValidation
+}
+
 func ErrValidation(msg string) error {
 	return &ValidationError{Msg: msg}
 }
+
+// NotFoundError is returned when the requested resource does not exist
+type NotFoundError struct {
+	Msg string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Msg
+}
+
+func (e *NotFoundError) Code() string {
+	return CodeNotFound
+}
+
+func ErrNotFound(msg string) error {
+	return &NotFoundError{Msg: msg}
+}
+
+// ConflictError is returned when the request clashes with the current state (full event, double registration)
+type ConflictError struct {
+	Msg string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Msg
+}
+
+func (e *ConflictError) Code() string {
+	return CodeConflict
+}
+
+func ErrConflict(msg string) error {
+	return &ConflictError{Msg: msg}
+}
+
+// ForbiddenError is returned when the caller is authenticated but not allowed to perform the action
+type ForbiddenError struct {
+	Msg string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Msg
+}
+
+func (e *ForbiddenError) Code() string {
+	return CodeForbidden
+}
+
+func ErrForbidden(msg string) error {
+	return &ForbiddenError{Msg: msg}
+}
+
+// BatchSaveError reports which items of a batch write failed, keyed by their index
+type BatchSaveError struct {
+	Failed map[int]error
+}
+
+func (e *BatchSaveError) Error() string {
+	return fmt.Sprintf("%d items failed to save", len(e.Failed))
+}
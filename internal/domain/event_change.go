@@ -0,0 +1,22 @@
+package domain
+
+// EventChangeKind says how a watched event changed
+type EventChangeKind string
+
+const (
+	EventAdded    EventChangeKind = "added"
+	EventModified EventChangeKind = "modified"
+	EventRemoved  EventChangeKind = "removed"
+)
+
+// EventChange is one change delivered to a watcher. For removals only Event.Id is guaranteed.
+type EventChange struct {
+	Kind  EventChangeKind
+	Event Event
+}
+
+// WatchFilter narrows a watch to one city and/or type; empty fields match everything
+type WatchFilter struct {
+	City string
+	Type EventType
+}
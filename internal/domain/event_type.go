@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// EventTypeRegistry lists the event types a deployment accepts. The list is configuration
+// (EVENT_TYPES, or a Firestore document with EVENT_TYPES_DYNAMIC), so adding a type such as
+// "workshop" needs no release.
+type EventTypeRegistry interface {
+	EventTypes(ctx context.Context) []EventType
+}
+
+// StaticEventTypes is a registry with a fixed list
+type StaticEventTypes []EventType
+
+func (s StaticEventTypes) EventTypes(context.Context) []EventType {
+	return s
+}
+
+var (
+	eventTypesMu sync.RWMutex
+	eventTypes   EventTypeRegistry = StaticEventTypes(DefaultEventTypes)
+)
+
+// SetEventTypeRegistry installs the registry validation and GET /events/types consult; it is set
+// once at startup. Nil restores DefaultEventTypes.
+func SetEventTypeRegistry(r EventTypeRegistry) {
+	if r == nil {
+		r = StaticEventTypes(DefaultEventTypes)
+	}
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	eventTypes = r
+}
+
+// AllowedEventTypes returns the event types the deployment currently accepts
+func AllowedEventTypes(ctx context.Context) []EventType {
+	eventTypesMu.RLock()
+	r := eventTypes
+	eventTypesMu.RUnlock()
+	return r.EventTypes(ctx)
+}
+
+// IsValid reports whether the registry accepts e
+func (e EventType) IsValid() bool {
+	return e.IsAllowed(context.Background())
+}
+
+// IsAllowed reports whether the registry accepts e, reloading it within ctx when it is due
+func (e EventType) IsAllowed(ctx context.Context) bool {
+	return slices.Contains(AllowedEventTypes(ctx), e)
+}
+
+var eventTypeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// ValidEventTypeName reports whether name may be registered as an event type: lowercase letters,
+// digits and underscores, starting with a letter, at most 32 characters. Types end up in URLs,
+// Firestore equality filters and client code, so they are kept plain.
+func ValidEventTypeName(name string) bool {
+	return eventTypeName.MatchString(name)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// EventFacets are denormalized event counts per city and type, kept up to date by the
+// Firestore trigger so filter UIs can show them without scanning the collection.
+type EventFacets struct {
+	Total     int64            `firestore:"total" json:"total"`
+	Cities    map[string]int64 `firestore:"cities" json:"cities"`
+	Types     map[string]int64 `firestore:"types" json:"types"`
+	UpdatedAt time.Time        `firestore:"updated_at" json:"updated_at"`
+}
+
+// FacetDelta is a change to apply to EventFacets; zero entries are left out
+type FacetDelta struct {
+	Total  int64
+	Cities map[string]int64
+	Types  map[string]int64
+}
+
+// IsZero reports whether applying the delta would change nothing
+func (d FacetDelta) IsZero() bool {
+	return d.Total == 0 && len(d.Cities) == 0 && len(d.Types) == 0
+}
+
+// Apply adds a delta to the counters, dropping entries that fall to zero
+func (f *EventFacets) Apply(d FacetDelta) {
+	if f.Cities == nil {
+		f.Cities = map[string]int64{}
+	}
+	if f.Types == nil {
+		f.Types = map[string]int64{}
+	}
+	f.Total += d.Total
+	for k, n := range d.Cities {
+		addFacet(f.Cities, k, n)
+		if f.Cities[k] == 0 {
+			delete(f.Cities, k)
+		}
+	}
+	for k, n := range d.Types {
+		addFacet(f.Types, k, n)
+		if f.Types[k] == 0 {
+			delete(f.Types, k)
+		}
+	}
+}
+
+// EventFacetDelta computes the facet change of an event write.
+// before is nil for a create and after is nil for a delete.
+func EventFacetDelta(before, after *Event) FacetDelta {
+	d := FacetDelta{Cities: map[string]int64{}, Types: map[string]int64{}}
+	if before != nil {
+		d.Total--
+		addFacet(d.Cities, before.City, -1)
+		addFacet(d.Types, string(before.Type), -1)
+	}
+	if after != nil {
+		d.Total++
+		addFacet(d.Cities, after.City, 1)
+		addFacet(d.Types, string(after.Type), 1)
+	}
+	for k, v := range d.Cities {
+		if v == 0 {
+			delete(d.Cities, k)
+		}
+	}
+	for k, v := range d.Types {
+		if v == 0 {
+			delete(d.Types, k)
+		}
+	}
+	return d
+}
+
+func addFacet(counts map[string]int64, key string, n int64) {
+	if key = strings.TrimSpace(key); key != "" {
+		counts[key] += n
+	}
+}
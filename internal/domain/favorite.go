@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Favorite is an event bookmarked by a user.
+// Stored in users/{uid}/favorites with the event Id as document Id.
+type Favorite struct {
+	EventID   string    `firestore:"event_id" json:"event_id"`
+	UserID    string    `firestore:"user_id" json:"user_id"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+	// TenantID is the tenant of the event; empty for the root events and for favorites stored
+	// before tenants existed
+	TenantID string `firestore:"tenant_id,omitempty" json:"tenant_id,omitempty"`
+}
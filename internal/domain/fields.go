@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// eventResponseIndex maps the json names of EventResponse to their struct field index
+var eventResponseIndex = fieldIndex(reflect.TypeOf(EventResponse{}), "json")
+
+// storedEventIndex maps the firestore names of Event to their struct field index
+var storedEventIndex = fieldIndex(reflect.TypeOf(Event{}), "firestore")
+
+// eventFieldIndex is the whitelist for sparse fieldsets (?fields=...): the stored fields of Event
+// that EventResponse exposes. The names double as the repository's Firestore projection, so derived
+// flags such as is_past cannot be selected.
+var eventFieldIndex = func() map[string]int {
+	index := make(map[string]int)
+	for name, i := range eventResponseIndex {
+		if _, ok := storedEventIndex[name]; ok {
+			index[name] = i
+		}
+	}
+	return index
+}()
+
+// fieldIndex maps the names a struct tag gives the fields of t to their index
+func fieldIndex(t reflect.Type, tag string) map[string]int {
+	index := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get(tag), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		index[name] = i
+	}
+	return index
+}
+
+// EventCardFields are the fields an event list card renders
+var EventCardFields = []string{"id", "slug", "event_name", "city", "start_time", "price", "image_url"}
+
+// EventFieldNames lists every field name a sparse fieldset may select, in declaration order
+func EventFieldNames() []string {
+	names := make([]string, 0, len(eventFieldIndex))
+	for name := range eventFieldIndex {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return eventFieldIndex[names[i]] < eventFieldIndex[names[j]] })
+	return names
+}
+
+// ParseEventFields validates a comma-separated list of event field names.
+// The "id" field is always included so clients can identify the returned items.
+func ParseEventFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	fields := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := eventFieldIndex[name]; !ok {
+			return nil, ErrValidation(fmt.Sprintf("unknown field '%s' in fields parameter", name))
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// ProjectEvent returns the requested fields of the event (every field when fields is empty), keyed
+// by their json names. Like encoding/json, it leaves out unset omitzero fields such as end_time.
+func ProjectEvent(e *EventResponse, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		fields = slices.Collect(maps.Keys(eventResponseIndex))
+	}
+	v := reflect.ValueOf(e).Elem()
+	t := v.Type()
+	projected := make(map[string]interface{}, len(fields))
+	for _, name := range fields {
+		i, ok := eventResponseIndex[name]
+		if !ok {
+			continue
+		}
+		if v.Field(i).IsZero() && strings.Contains(t.Field(i).Tag.Get("json"), ",omitzero") {
+			continue
+		}
+		projected[name] = v.Field(i).Interface()
+	}
+	return projected
+}
@@ -0,0 +1,19 @@
+package domain
+
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// DependencyHealth is the result of probing one dependency
+type DependencyHealth struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthReport is returned by GET /readyz; Status is "fail" when any dependency failed
+type HealthReport struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyHealth `json:"checks,omitempty"`
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// MaxJobErrors caps the row errors stored on a job document (Firestore documents are limited to 1 MiB)
+const MaxJobErrors = 500
+
+// ImportJob tracks an asynchronous CSV import processed in chunks by the task worker
+type ImportJob struct {
+	Id          string            `firestore:"id" json:"id"`
+	Status      string            `firestore:"status" json:"status"`
+	Total       int               `firestore:"total" json:"total"`
+	Created     int               `firestore:"created" json:"created"`
+	Failed      int               `firestore:"failed" json:"failed"`
+	TotalChunks int               `firestore:"total_chunks" json:"total_chunks"`
+	DoneChunks  []int             `firestore:"done_chunks" json:"-"`
+	Errors      []ImportRowResult `firestore:"errors" json:"errors"`
+	Message     string            `firestore:"message" json:"message,omitempty"`
+	CreatedAt   time.Time         `firestore:"created_at" json:"created_at"`
+	UpdatedAt   time.Time         `firestore:"updated_at" json:"updated_at"`
+}
+
+// ImportChunkRow is one validated CSV row; Row is its 1-based data row number
+type ImportChunkRow struct {
+	Row   int   `json:"row"`
+	Event Event `json:"event"`
+}
+
+// ImportChunk is the payload of a worker task
+type ImportChunk struct {
+	JobID string           `json:"job_id"`
+	Index int              `json:"index"`
+	Rows  []ImportChunkRow `json:"rows"`
+}
+
+// ApplyChunk adds the outcome of one chunk to the job.
+// Tasks may be delivered more than once, so a chunk already applied is ignored.
+func (j *ImportJob) ApplyChunk(index, created int, failures []ImportRowResult, now time.Time) {
+	if slices.Contains(j.DoneChunks, index) {
+		return
+	}
+	j.DoneChunks = append(j.DoneChunks, index)
+	j.Created += created
+	j.AddErrors(failures)
+	j.UpdatedAt = now
+
+	if len(j.DoneChunks) >= j.TotalChunks {
+		j.Status = JobCompleted
+	} else {
+		j.Status = JobRunning
+	}
+}
+
+// AddErrors counts failed rows and keeps up to MaxJobErrors of them for the report
+func (j *ImportJob) AddErrors(failures []ImportRowResult) {
+	j.Failed += len(failures)
+	if room := MaxJobErrors - len(j.Errors); room > 0 {
+		j.Errors = append(j.Errors, failures[:min(room, len(failures))]...)
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams identify a campaign or a click rather than the page; utm_* is matched by prefix
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "gbraid": true, "wbraid": true, "msclkid": true,
+	"yclid": true, "igshid": true, "mc_cid": true, "mc_eid": true, "_hsenc": true, "_hsmi": true,
+}
+
+// NormalizeURL returns the canonical form of an event or image link: an absolute http(s) URL with
+// a lowercase scheme and host and without a default port. With stripTracking, campaign and click
+// parameters (utm_*, fbclid, gclid, ...) are removed from the query. The frontend renders links as
+// hrefs and image sources, so any other scheme (javascript:, data:, ...) is rejected.
+func NormalizeURL(raw string, stripTracking bool) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", fmt.Errorf("must be an absolute http or https URL")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch port := u.Port(); {
+	case port != "" && port != defaultPorts[u.Scheme]:
+		host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		host = "[" + host + "]" // IPv6
+	}
+	u.Host = host
+
+	if stripTracking && u.RawQuery != "" {
+		u.RawQuery = withoutTrackingParams(u.RawQuery)
+	}
+	u.ForceQuery = false
+	return u.String(), nil
+}
+
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// withoutTrackingParams drops the tracking parameters of a raw query, keeping the order and
+// encoding of the others
+func withoutTrackingParams(rawQuery string) string {
+	params := strings.Split(rawQuery, "&")
+	kept := params[:0]
+	for _, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, "utm_") || trackingParams[key] {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	return strings.Join(kept, "&")
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// GeoPoint is the position of an event in decimal degrees (WGS 84). Events store it as a
+// Firestore geopoint (Event.Location).
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewGeoPoint returns the position of a stored geopoint; nil for nil
+func NewGeoPoint(ll *latlng.LatLng) *GeoPoint {
+	if ll == nil {
+		return nil
+	}
+	return &GeoPoint{Latitude: ll.GetLatitude(), Longitude: ll.GetLongitude()}
+}
+
+// GeoPointDTO is a position in a request. Pointers tell a missing coordinate from 0, the equator
+// or the prime meridian.
+type GeoPointDTO struct {
+	Latitude  *float64 `json:"latitude" validate:"required,gte=-90,lte=90" example:"52.2297"`
+	Longitude *float64 `json:"longitude" validate:"required,gte=-180,lte=180" example:"21.0122"`
+}
+
+// LatLng is the geopoint Firestore stores for a validated position; nil for nil
+func (p *GeoPointDTO) LatLng() *latlng.LatLng {
+	if p == nil {
+		return nil
+	}
+	return &latlng.LatLng{Latitude: *p.Latitude, Longitude: *p.Longitude}
+}
+
+// ParseGeoPoint reads a latitude and longitude written as decimal strings, the way events stored
+// them before Location and CSV imports carry them. Two empty strings are no position (nil).
+func ParseGeoPoint(lat, lng string) (*GeoPointDTO, error) {
+	lat, lng = strings.TrimSpace(lat), strings.TrimSpace(lng)
+	if lat == "" && lng == "" {
+		return nil, nil
+	}
+	if lat == "" || lng == "" {
+		return nil, fmt.Errorf("latitude and longitude must be given together")
+	}
+	// Written as negated ranges so that NaN is rejected too
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil || !(latitude >= -90 && latitude <= 90) {
+		return nil, fmt.Errorf("latitude must be a number between -90 and 90, got %q", lat)
+	}
+	longitude, err := strconv.ParseFloat(lng, 64)
+	if err != nil || !(longitude >= -180 && longitude <= 180) {
+		return nil, fmt.Errorf("longitude must be a number between -180 and 180, got %q", lng)
+	}
+	return &GeoPointDTO{Latitude: &latitude, Longitude: &longitude}, nil
+}
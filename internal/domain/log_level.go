@@ -0,0 +1,6 @@
+package domain
+
+// LogLevel is the body and response of /admin/log-level: debug, info, warn or error
+type LogLevel struct {
+	Level string `json:"level"`
+}
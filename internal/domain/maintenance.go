@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// DefaultTrackingRetentionDays is how long tracking documents are kept before the daily job purges them
+const DefaultTrackingRetentionDays = 90
+
+// PurgeResult reports one run of a capped purge; Remaining is true when more documents are eligible
+type PurgeResult struct {
+	Purged    int       `json:"purged"`
+	Cutoff    time.Time `json:"cutoff,omitzero"`
+	Remaining bool      `json:"remaining"`
+}
+
+// TrackingDeleteFilter selects the tracking events an admin deletes in bulk. Set fields are
+// combined; at least one must be set.
+type TrackingDeleteFilter struct {
+	OlderThan time.Time // created before
+	Action    string
+}
+
+func (f TrackingDeleteFilter) IsZero() bool {
+	return f.OlderThan.IsZero() && f.Action == ""
+}
+
+// MaintenanceReport is the outcome of the daily housekeeping job.
+// Every step runs even if an earlier one fails; failed steps are listed in Errors and their result is nil.
+type MaintenanceReport struct {
+	Archive    *ArchiveResult    `json:"archive,omitempty"`
+	Tracking   *PurgeResult      `json:"tracking,omitempty"`
+	Popularity *PopularityResult `json:"popularity,omitempty"`
+	Facets     *EventFacets      `json:"facets,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
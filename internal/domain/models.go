@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"strings"
 	"time"
+
+	"google.golang.org/genproto/googleapis/type/latlng"
 )
 
 type EventType string
@@ -16,8 +19,8 @@ const (
 	TypeOther      EventType = "other"
 )
 
-// AllEventTypes is a registry of all allowed event types
-var AllEventTypes = []EventType{
+// DefaultEventTypes are the event types of a deployment that configures none (see EventTypeRegistry)
+var DefaultEventTypes = []EventType{
 	TypeConcert,
 	TypeFestival,
 	TypeTheater,
@@ -27,54 +30,105 @@ var AllEventTypes = []EventType{
 	TypeOther,
 }
 
+// JSON field names are snake_case across the REST API: request and response bodies, query
+// parameters, ?fields= selections and error payloads alike. Stored models carry json tags with the
+// same names as their firestore tags, so a field is called the same in a document, a backup dump and
+// a response. GraphQL keeps its own camelCase names and gRPC follows the proto JSON mapping.
+
 // Event represents the database entity and the DTO
 type Event struct {
-	Id            string    `firestore:"id"`
-	OrganizerName string    `firestore:"organizer_name"`
-	EventName     string    `firestore:"event_name"`
-	HasTickets    bool      `firestore:"has_tickets"`
-	City          string    `firestore:"city"`
-	Country       string    `firestore:"country"`
-	FullAddress   string    `firestore:"full_address"`
-	Latitude      string    `firestore:"latitude"`
-	Longitude     string    `firestore:"longitude"`
-	State         string    `firestore:"state"`
-	Street        string    `firestore:"street"`
-	StartTime     time.Time `firestore:"start_time"`
-	EndTime       time.Time `firestore:"end_time"`
-	Timezone      string    `firestore:"timezone"`
-	EventURL      string    `firestore:"event_url"`
-	Provider      string    `firestore:"provider"`
-	Price         float64   `firestore:"price"`
-	ImageUrl      string    `firestore:"image_url"`
-	Type          EventType `firestore:"type"`
-	CreatedAt     time.Time `firestore:"created_at"`
+	Id              string         `firestore:"id" json:"id"`
+	OrganizerID     string         `firestore:"organizer_id" json:"organizer_id"`
+	OrganizerName   string         `firestore:"organizer_name" json:"organizer_name"`
+	EventName       string         `firestore:"event_name" json:"event_name"`
+	EventNameLC     string         `firestore:"event_name_lc" json:"event_name_lc"` // Lowercase copy used for case-insensitive filtering
+	Description     string         `firestore:"description" json:"description"`     // Limited HTML/Markdown, sanitized on write (SanitizeDescription)
+	Slug            string         `firestore:"slug" json:"slug"`
+	HasTickets      bool           `firestore:"has_tickets" json:"has_tickets"`
+	City            string         `firestore:"city" json:"city"`
+	CityLC          string         `firestore:"city_lc" json:"city_lc"` // Lowercase copy used for case-insensitive filtering
+	Country         string         `firestore:"country" json:"country"`
+	FullAddress     string         `firestore:"full_address" json:"full_address"`
+	Location        *latlng.LatLng `firestore:"location" json:"location"` // Firestore geopoint, nil when unknown (see GeoPoint)
+	State           string         `firestore:"state" json:"state"`
+	Street          string         `firestore:"street" json:"street"`
+	StartTime       time.Time      `firestore:"start_time" json:"start_time"`
+	EndTime         time.Time      `firestore:"end_time" json:"end_time"`
+	Timezone        string         `firestore:"timezone" json:"timezone"`
+	EventURL        string         `firestore:"event_url" json:"event_url"`
+	Provider        string         `firestore:"provider" json:"provider"`
+	Price           float64        `firestore:"price" json:"price"`
+	ImageUrl        string         `firestore:"image_url" json:"image_url"`
+	Type            EventType      `firestore:"type" json:"type"`
+	Visibility      Visibility     `firestore:"visibility" json:"visibility"`             // Empty for events stored before the field existed, which are public
+	Capacity        int            `firestore:"capacity" json:"capacity"`                 // 0 means unlimited
+	AttendeeCount   int            `firestore:"attendee_count" json:"attendee_count"`     // Maintained transactionally by RSVPs
+	FavoritesCount  int            `firestore:"favorites_count" json:"favorites_count"`   // Maintained transactionally by favorites
+	ViewCount       int64          `firestore:"-" json:"view_count"`                      // Aggregated from the view_shards subcollection on read
+	PopularityScore float64        `firestore:"popularity_score" json:"popularity_score"` // Recomputed daily from recent tracking (PopularityWeight)
+	Featured        bool           `firestore:"featured" json:"featured"`
+	FeaturedUntil   time.Time      `firestore:"featured_until" json:"featured_until"` // Promotion ends at this instant
+	DedupKey        string         `firestore:"dedup_key" json:"dedup_key"`           // Hash of name, city and start_time used for duplicate detection
+	CreatedAt       time.Time      `firestore:"created_at" json:"created_at"`
+	CreatedBy       string         `firestore:"created_by" json:"created_by"` // UID of the creator, empty for system writes
+	UpdatedAt       time.Time      `firestore:"updated_at" json:"updated_at"` // Set server-side on every write
+	UpdatedBy       string         `firestore:"updated_by" json:"updated_by"` // UID of the last writer, empty for system writes
 }
 
 // TrackingEvent represents an analytics or tracking action
 type TrackingEvent struct {
-	Id        string    `firestore:"id"`
-	Action    string    `firestore:"action"`
-	UserName  string    `firestore:"user_name"`
-	Payload   string    `firestore:"payload"`
-	UserAgent string    `firestore:"user_agent"`
-	CreatedAt time.Time `firestore:"created_at"`
+	Id        string `firestore:"id" json:"id"`
+	Action    string `firestore:"action" json:"action"`
+	UserName  string `firestore:"user_name" json:"user_name"`
+	Payload   string `firestore:"payload" json:"payload"`
+	UserAgent string `firestore:"user_agent" json:"user_agent"`
+	// SessionID groups the events of one visit as reported by the client
+	SessionID string `firestore:"session_id,omitempty" json:"session_id,omitempty"`
+	// EventID is the event a view or click was about; it feeds the event's popularity_score
+	EventID string `firestore:"event_id,omitempty" json:"event_id,omitempty"`
+	// ClientEventID is the sender's Id for the event; resends with the same one are stored once
+	ClientEventID string `firestore:"client_event_id,omitempty" json:"client_event_id,omitempty"`
+	// UserID is the verified caller, empty for anonymous visitors; it is never taken from the client
+	UserID    string    `firestore:"user_id,omitempty" json:"user_id,omitempty"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+	// ExpireAt is when a Firestore TTL policy on expire_at may delete the document; unset without one
+	ExpireAt time.Time `firestore:"expire_at,omitempty" json:"expire_at,omitzero"`
 }
 
 // SearchRequest - helper structure for filters
 type SearchRequest struct {
 	Filters FilterRequest
 	Sorting SortRequest
+	Fields  []string // Optional sparse fieldset (firestore field names); empty means all fields
 }
 
 type FilterRequest struct {
-	City      string
-	EventName string
-	StartDate *time.Time
-	EndDate   *time.Time
-	MinPrice  *float64
-	MaxPrice  *float64
-	Type      EventType
+	City        string
+	EventName   string
+	StartDate   *time.Time
+	EndDate     *time.Time
+	MinPrice    *float64
+	MaxPrice    *float64
+	Type        EventType
+	OrganizerID string
+	When        string // Relative window StartDate/EndDate were resolved from; empty for explicit dates
+}
+
+// Matches reports whether e passes the filters the way the List query applies them: name and
+// city as case-insensitive prefixes, the other fields exactly or by range
+func (f FilterRequest) Matches(e *Event) bool {
+	switch {
+	case f.EventName != "" && !strings.HasPrefix(e.EventNameLC, NormalizeSearchText(f.EventName)),
+		f.City != "" && !strings.HasPrefix(e.CityLC, NormalizeSearchText(f.City)),
+		f.Type != "" && e.Type != f.Type,
+		f.OrganizerID != "" && e.OrganizerID != f.OrganizerID,
+		f.MinPrice != nil && e.Price < *f.MinPrice,
+		f.MaxPrice != nil && e.Price > *f.MaxPrice,
+		f.StartDate != nil && e.StartTime.Before(*f.StartDate),
+		f.EndDate != nil && e.EndTime.After(*f.EndDate):
+		return false
+	}
+	return true
 }
 
 type SortRequest struct {
@@ -87,10 +141,15 @@ type SortRequest struct {
 type APIResponse struct {
 	Data  interface{} `json:"data,omitempty"`
 	Error string      `json:"error,omitempty"`
+	Code  string      `json:"code,omitempty"`
+	// RequestID is set on errors so a reported failure can be found in the logs
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type Meta struct {
-	NextPageToken string `json:"nextPageToken,omitempty"`
+	NextPageToken string   `json:"next_page_token,omitempty"`
+	PrevPageToken string   `json:"prev_page_token,omitempty"`
+	Fields        []string `json:"fields,omitempty"` // Fields the page was read with when the repository projected it by default
 }
 
 type APIPaginationResponse struct {
@@ -99,11 +158,14 @@ type APIPaginationResponse struct {
 	Meta  *Meta       `json:"meta,omitempty"`
 }
 
-func (e EventType) IsValid() bool {
-	for _, valid := range AllEventTypes {
-		if e == valid {
-			return true
-		}
-	}
-	return false
+// NormalizeSearchText produces the value stored in the *_lc fields and used for prefix queries
+func NormalizeSearchText(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Normalize refreshes the derived search and dedup fields from their source fields
+func (e *Event) Normalize() {
+	e.EventNameLC = NormalizeSearchText(e.EventName)
+	e.CityLC = NormalizeSearchText(e.City)
+	e.DedupKey = DedupKey(e.EventName, e.City, e.StartTime)
 }
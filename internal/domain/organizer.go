@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Organizer owns events; events reference it through Event.OrganizerID
+type Organizer struct {
+	Id          string    `firestore:"id" json:"id"`
+	Name        string    `firestore:"name" json:"name"`
+	Email       string    `firestore:"email" json:"email"`
+	Website     string    `firestore:"website" json:"website"`
+	Description string    `firestore:"description" json:"description"`
+	CreatedAt   time.Time `firestore:"created_at" json:"created_at"`
+}
+
+// OrganizerDTO is used for creating organizers
+type OrganizerDTO struct {
+	Name        string `json:"name" validate:"required,max=100" example:"Blue Note Warsaw"`
+	Email       string `json:"email" validate:"omitempty,email"`
+	Website     string `json:"website" validate:"omitempty,url"`
+	Description string `json:"description" validate:"omitempty,max=2000"`
+}
+
+// UpdateOrganizerDTO only contains fields that may be changed after creation
+type UpdateOrganizerDTO struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Email       *string `json:"email" validate:"omitempty,email"`
+	Website     *string `json:"website" validate:"omitempty,url"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+}
+
+func OrganizerDTOToModel(dto *OrganizerDTO) *Organizer {
+	return &Organizer{
+		Name:        dto.Name,
+		Email:       dto.Email,
+		Website:     dto.Website,
+		Description: dto.Description,
+	}
+}
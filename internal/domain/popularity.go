@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// SortPopularity is the list sort key ordering events by popularity_score
+const SortPopularity = "popularity"
+
+// PopularityWindowDays is how far back tracking counts toward an event's popularity_score
+const PopularityWindowDays = 7
+
+// PopularityHalfLife is the age at which an interaction counts half, so fresh interest ranks first
+const PopularityHalfLife = 48 * time.Hour
+
+// PopularityWeights are the tracking actions that count toward popularity and what each is worth
+var PopularityWeights = map[string]float64{"view": 1, "click": 3}
+
+// PopularityWeight is what one tracking action of the given age adds to its event's score; 0 for
+// actions that do not count
+func PopularityWeight(action string, age time.Duration) float64 {
+	weight := PopularityWeights[action]
+	if weight == 0 || age < 0 {
+		return weight
+	}
+	return weight * math.Exp2(-age.Hours()/PopularityHalfLife.Hours())
+}
+
+// PopularityResult reports one recomputation of the events' popularity_score
+type PopularityResult struct {
+	Scored int `json:"scored"` // events with views or clicks in the window
+	Reset  int `json:"reset"`  // events whose score dropped back to 0
+}
+
+// HasRangeFilter reports whether f filters by name, city, price or dates. Firestore orders by those
+// fields before the requested sort, so they cannot be combined with SortPopularity.
+func (f FilterRequest) HasRangeFilter() bool {
+	return f.EventName != "" || f.City != "" || f.MinPrice != nil || f.MaxPrice != nil || f.StartDate != nil || f.EndDate != nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// PushSubscription delivers Firebase Cloud Messaging pushes to one device for new events in City,
+// optionally only those of Type. Stored in push_subscriptions; the Id is derived from its content,
+// so subscribing twice is a no-op.
+type PushSubscription struct {
+	Id        string    `firestore:"id" json:"id"`
+	UserID    string    `firestore:"user_id" json:"user_id"`
+	Token     string    `firestore:"token" json:"-"` // FCM registration token of the device
+	City      string    `firestore:"city" json:"city"`
+	Type      EventType `firestore:"type" json:"type,omitempty"` // empty matches every type
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
+
+// PushSubscriptionDTO is the body of POST /users/me/subscriptions
+type PushSubscriptionDTO struct {
+	Token string    `json:"token" validate:"required,max=4096"`
+	City  string    `json:"city" validate:"required,max=100"`
+	Type  EventType `json:"type" validate:"omitempty,event_type"`
+}
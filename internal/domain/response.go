@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// TrackingEventResponse is a tracking event as the API returns it. It is kept apart from the stored
+// TrackingEvent so storage-only fields (the TTL's expire_at) and later schema changes stay out of
+// responses.
+type TrackingEventResponse struct {
+	Id            string    `json:"id"`
+	Action        string    `json:"action"`
+	UserName      string    `json:"user_name"`
+	Payload       string    `json:"payload"`
+	UserAgent     string    `json:"user_agent"`
+	SessionID     string    `json:"session_id,omitempty"`
+	EventID       string    `json:"event_id,omitempty"`
+	ClientEventID string    `json:"client_event_id,omitempty"`
+	UserID        string    `json:"user_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewTrackingEventResponse(t *TrackingEvent) TrackingEventResponse {
+	return TrackingEventResponse{
+		Id:            t.Id,
+		Action:        t.Action,
+		UserName:      t.UserName,
+		Payload:       t.Payload,
+		UserAgent:     t.UserAgent,
+		SessionID:     t.SessionID,
+		EventID:       t.EventID,
+		ClientEventID: t.ClientEventID,
+		UserID:        t.UserID,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+// NewTrackingEventResponses maps a list of tracking events; an empty list gives [] rather than null
+func NewTrackingEventResponses(tracks []TrackingEvent) []TrackingEventResponse {
+	responses := make([]TrackingEventResponse, 0, len(tracks))
+	for i := range tracks {
+		responses = append(responses, NewTrackingEventResponse(&tracks[i]))
+	}
+	return responses
+}
+
+// EventResponse is the read model of an event: the fields clients may see, with times in the
+// event's timezone and flags derived at read time. Query helpers (*_lc, dedup_key) and the UIDs of
+// writers stay in storage; the service layer assembles it (service.NewEventResponse).
+type EventResponse struct {
+	Id              string     `json:"id"`
+	Slug            string     `json:"slug"`
+	EventName       string     `json:"event_name"`
+	Description     string     `json:"description"`
+	Type            EventType  `json:"type"`
+	Visibility      Visibility `json:"visibility"`
+	OrganizerID     string     `json:"organizer_id"`
+	OrganizerName   string     `json:"organizer_name"`
+	City            string     `json:"city"`
+	Country         string     `json:"country"`
+	State           string     `json:"state"`
+	Street          string     `json:"street"`
+	FullAddress     string     `json:"full_address"`
+	Location        *GeoPoint  `json:"location"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         time.Time  `json:"end_time,omitzero"`
+	Timezone        string     `json:"timezone"`
+	EventURL        string     `json:"event_url"`
+	Provider        string     `json:"provider"`
+	ImageUrl        string     `json:"image_url"`
+	Price           float64    `json:"price"`
+	Capacity        int        `json:"capacity"` // 0 means unlimited
+	AttendeeCount   int        `json:"attendee_count"`
+	FavoritesCount  int        `json:"favorites_count"`
+	ViewCount       int64      `json:"view_count"`
+	HasTickets      bool       `json:"has_tickets"`
+	PopularityScore float64    `json:"popularity_score"`
+	Featured        bool       `json:"featured"`
+	FeaturedUntil   time.Time  `json:"featured_until,omitzero"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// IsPast is set once the event has ended (started, without an end time)
+	IsPast bool `json:"is_past"`
+	IsFree bool `json:"is_free"`
+}
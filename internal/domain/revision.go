@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	RevisionCreate = "create"
+	RevisionUpdate = "update"
+	RevisionDelete = "delete"
+)
+
+// FieldChange holds the value of a field before and after a change.
+// Old is nil for creates and New is nil for deletes.
+type FieldChange struct {
+	Old interface{} `firestore:"old" json:"old"`
+	New interface{} `firestore:"new" json:"new"`
+}
+
+// EventRevision is one entry of an event's change history
+type EventRevision struct {
+	Id        string                 `firestore:"id" json:"id"`
+	EventID   string                 `firestore:"event_id" json:"event_id"`
+	Action    string                 `firestore:"action" json:"action"`
+	Actor     string                 `firestore:"actor" json:"actor"` // UID of the caller, empty for system changes
+	Changes   map[string]FieldChange `firestore:"changes" json:"changes"`
+	CreatedAt time.Time              `firestore:"created_at" json:"created_at"`
+}
+
+// SnapshotEvent returns the non-zero fields of the event keyed by their firestore names
+func SnapshotEvent(e *Event) map[string]interface{} {
+	snapshot := make(map[string]interface{})
+	if e == nil {
+		return snapshot
+	}
+	v := reflect.ValueOf(e).Elem()
+	for name, i := range storedEventIndex {
+		if f := v.Field(i); !f.IsZero() {
+			snapshot[name] = f.Interface()
+		}
+	}
+	return snapshot
+}
+
+// DiffEvent compares two snapshots and returns only the fields whose value changed
+func DiffEvent(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for name, newVal := range after {
+		if oldVal, ok := before[name]; !ok || !reflect.DeepEqual(oldVal, newVal) {
+			changes[name] = FieldChange{Old: before[name], New: newVal}
+		}
+	}
+	for name, oldVal := range before {
+		if _, ok := after[name]; !ok {
+			changes[name] = FieldChange{Old: oldVal}
+		}
+	}
+	return changes
+}
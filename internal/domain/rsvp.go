@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RSVP is a user's registration for an event.
+// Stored in events/{id}/rsvps with the user's UID as document Id, so a user can register only once.
+type RSVP struct {
+	Id        string    `firestore:"id" json:"id"`
+	EventID   string    `firestore:"event_id" json:"event_id"`
+	UserID    string    `firestore:"user_id" json:"user_id"`
+	Email     string    `firestore:"email" json:"email"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
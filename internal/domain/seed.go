@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Seeding limits; larger data sets are loaded by running cmd/seed repeatedly with other seeds
+const (
+	DefaultSeedEvents   = 50
+	DefaultSeedTracking = 20
+	MaxSeedEvents       = 1000
+	MaxSeedTracking     = 100
+)
+
+// DefaultSeedCities are used when a seed request names no cities
+var DefaultSeedCities = []string{"Warsaw", "Krakow", "Gdansk", "Berlin", "Prague"}
+
+// SeedRequest configures the fake events and tracking generated by cmd/seed and POST /dev/seed.
+// The same Seed generates the same data, so running it again overwrites rather than duplicates.
+type SeedRequest struct {
+	Events           int      `json:"events"`             // default DefaultSeedEvents
+	TrackingPerEvent int      `json:"tracking_per_event"` // default DefaultSeedTracking
+	Cities           []string `json:"cities"`             // default DefaultSeedCities
+	// Start times fall in [From, To); the default is the next 90 days
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Seed int64     `json:"seed"`
+}
+
+// WithDefaults fills the unset fields relative to now and checks the limits
+func (r SeedRequest) WithDefaults(now time.Time) (SeedRequest, error) {
+	if r.Events == 0 {
+		r.Events = DefaultSeedEvents
+	}
+	if r.TrackingPerEvent == 0 {
+		r.TrackingPerEvent = DefaultSeedTracking
+	}
+	if len(r.Cities) == 0 {
+		r.Cities = DefaultSeedCities
+	}
+	if r.From.IsZero() {
+		r.From = now
+	}
+	if r.To.IsZero() {
+		r.To = r.From.AddDate(0, 0, 90)
+	}
+	switch {
+	case r.Events < 0 || r.Events > MaxSeedEvents:
+		return r, ErrValidation(fmt.Sprintf("events must be between 1 and %d", MaxSeedEvents))
+	case r.TrackingPerEvent < 0 || r.TrackingPerEvent > MaxSeedTracking:
+		return r, ErrValidation(fmt.Sprintf("tracking_per_event must be between 1 and %d", MaxSeedTracking))
+	case !r.To.After(r.From):
+		return r, ErrValidation("to must be after from")
+	}
+	return r, nil
+}
+
+// SeedResult counts what a seed run stored
+type SeedResult struct {
+	Events   int `json:"events"`
+	Tracking int `json:"tracking"`
+	// Failed counts events and tracking that were generated but not stored
+	Failed int `json:"failed"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSlugLength keeps slugs readable and well below Firestore's field limits
+const maxSlugLength = 80
+
+// GenerateSlug builds a URL-friendly slug for an event, e.g. "jazz-night-warsaw-2025".
+// Diacritics are folded to ASCII so "Kraków" becomes "krakow".
+func GenerateSlug(event *Event) string {
+	parts := []string{event.EventName, event.City}
+	if !event.StartTime.IsZero() {
+		parts = append(parts, strconv.Itoa(event.StartTime.Year()))
+	}
+	return Slugify(strings.Join(parts, " "))
+}
+
+// Slugify lowercases the input, strips diacritics and joins words with single dashes
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Skip combining marks left behind by NFD decomposition
+			continue
+		case r == 'ł':
+			// Polish ł has no decomposition
+			b.WriteRune('l')
+			dash = false
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		default:
+			if !dash && b.Len() > 0 {
+				b.WriteRune('-')
+				dash = true
+			}
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimSuffix(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// StatsGroupByFields lists the event fields that can be used to group statistics
+var StatsGroupByFields = []string{"city", "type"}
+
+// EventStats holds aggregated figures for one group of events
+type EventStats struct {
+	Group      string  `json:"group"`
+	Count      int64   `json:"count"`
+	TotalPrice float64 `json:"total_price"`
+	AvgPrice   float64 `json:"avg_price"`
+	MinPrice   float64 `json:"min_price"`
+	MaxPrice   float64 `json:"max_price"`
+}
+
+// EventSummary holds aggregated figures for the events matching a set of list filters
+type EventSummary struct {
+	Count      int64   `json:"count"`
+	TotalPrice float64 `json:"total_price"`
+	AvgPrice   float64 `json:"avg_price"`
+}
+
+// DefaultPriceBounds are the bucket edges used when the client does not supply its own
+var DefaultPriceBounds = []float64{0, 25, 50, 100, 200}
+
+// MaxPriceBuckets caps the number of edges a client may request (one COUNT query each)
+const MaxPriceBuckets = 20
+
+// PriceBucket counts events with Min <= price < Max.
+// Max is nil for the last, open-ended bucket.
+type PriceBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"`
+	Count int64    `json:"count"`
+}
+
+// Tracking stats intervals; buckets start on UTC hours and days
+const (
+	TrackingIntervalHour = "hour"
+	TrackingIntervalDay  = "day"
+)
+
+// TrackingStatsGroupByFields lists the tracking fields that can be used to group statistics
+var TrackingStatsGroupByFields = []string{"action"}
+
+// MaxTrackingStatsBuckets caps the buckets of one request per interval: a week of hours, a quarter of days
+var MaxTrackingStatsBuckets = map[string]int{TrackingIntervalHour: 168, TrackingIntervalDay: 92}
+
+// TrackingStats counts tracking events per group (action) recorded in [Start, Start+interval)
+type TrackingStats struct {
+	Start  time.Time        `json:"start"`
+	Counts map[string]int64 `json:"counts"`
+	Total  int64            `json:"total"`
+}
+
+// TrackingBucketStart returns the start of the interval bucket holding t
+func TrackingBucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	if interval == TrackingIntervalHour {
+		return t.Truncate(time.Hour)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NextTrackingBucket returns the start of the bucket after the one starting at start
+func NextTrackingBucket(start time.Time, interval string) time.Time {
+	if interval == TrackingIntervalHour {
+		return start.Add(time.Hour)
+	}
+	return start.AddDate(0, 0, 1)
+}
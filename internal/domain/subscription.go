@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Subscription holds a user's notification preferences, stored in subscriptions/{uid}.
+// Email is taken from the verified ID token when the user opts in.
+type Subscription struct {
+	UserID     string    `firestore:"user_id" json:"user_id"`
+	Email      string    `firestore:"email" json:"email,omitempty"`
+	Cities     []string  `firestore:"cities" json:"cities"`
+	EmailOptIn bool      `firestore:"email_opt_in" json:"email_opt_in"`
+	UpdatedAt  time.Time `firestore:"updated_at" json:"updated_at"`
+}
+
+// SubscriptionDTO is the body of POST /users/me/subscription
+type SubscriptionDTO struct {
+	Cities     []string `json:"cities" validate:"max=20,dive,required,max=100"`
+	EmailOptIn bool     `json:"email_opt_in"`
+}
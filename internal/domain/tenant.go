@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+// maxTenantIDLength bounds tenant Ids, which become Firestore document Ids
+const maxTenantIDLength = 64
+
+type tenantKey struct{}
+
+// WithTenant scopes ctx to a tenant: repositories then read and write its data under
+// tenants/{tenant} instead of the root collections
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant of the request, or "" for the root (single-tenant) data
+func TenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantKey{}).(string)
+	return tenantID
+}
+
+// ValidTenantID accepts letters, digits and hyphens, which covers Identity Platform tenant Ids
+func ValidTenantID(id string) bool {
+	if id == "" || len(id) > maxTenantIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
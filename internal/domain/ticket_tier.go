@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TicketTier is a priced ticket category of an event (e.g. "Early Bird", "VIP").
+// Tiers are stored in the events/{id}/ticket_tiers subcollection.
+type TicketTier struct {
+	Id         string    `firestore:"id" json:"id"`
+	EventID    string    `firestore:"event_id" json:"event_id"`
+	Name       string    `firestore:"name" json:"name"`
+	Price      float64   `firestore:"price" json:"price"`
+	Quantity   int       `firestore:"quantity" json:"quantity"`
+	SalesStart time.Time `firestore:"sales_start" json:"sales_start"`
+	SalesEnd   time.Time `firestore:"sales_end" json:"sales_end"`
+	CreatedAt  time.Time `firestore:"created_at" json:"created_at"`
+}
+
+// TicketTierDTO is used for creating and replacing ticket tiers
+type TicketTierDTO struct {
+	Name       string  `json:"name" validate:"required,max=100" example:"Early Bird"`
+	Price      float64 `json:"price" validate:"gte=0"`
+	Quantity   int     `json:"quantity" validate:"gte=1,lte=1000000"`
+	SalesStart string  `json:"sales_start" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-01T00:00:00Z"`
+	SalesEnd   string  `json:"sales_end" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-07-20T20:00:00Z"`
+}
+
+func TicketTierDTOToModel(dto *TicketTierDTO) (*TicketTier, error) {
+	tier := &TicketTier{
+		Name:     dto.Name,
+		Price:    dto.Price,
+		Quantity: dto.Quantity,
+	}
+
+	var err error
+	if dto.SalesStart != "" {
+		tier.SalesStart, err = time.Parse(time.RFC3339, dto.SalesStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sales_start format: %w", err)
+		}
+	}
+	if dto.SalesEnd != "" {
+		tier.SalesEnd, err = time.Parse(time.RFC3339, dto.SalesEnd)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sales_end format: %w", err)
+		}
+	}
+
+	// Logical check: the sales window must not be inverted
+	if !tier.SalesStart.IsZero() && !tier.SalesEnd.IsZero() && tier.SalesEnd.Before(tier.SalesStart) {
+		return nil, fmt.Errorf("sales_end cannot be before sales_start")
+	}
+
+	return tier, nil
+}
@@ -0,0 +1,19 @@
+package domain
+
+// TrackingPrivacy controls what tracking keeps about visitors. IP addresses are never stored.
+type TrackingPrivacy string
+
+const (
+	TrackingPrivacyOff  TrackingPrivacy = ""     // user agents stored as sent
+	TrackingPrivacyHash TrackingPrivacy = "hash" // user agents stored as a keyed hash: countable, not readable
+	TrackingPrivacyDrop TrackingPrivacy = "drop" // user agents not stored
+)
+
+// IsValid reports whether p is a known mode
+func (p TrackingPrivacy) IsValid() bool {
+	switch p {
+	case TrackingPrivacyOff, TrackingPrivacyHash, TrackingPrivacyDrop:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package domain
+
+// CurrentUser describes the authenticated caller for GET /users/me.
+// Profile holds the fields of users/{uid}, if the document has any.
+type CurrentUser struct {
+	UID           string                 `json:"uid"`
+	Email         string                 `json:"email,omitempty"`
+	EmailVerified bool                   `json:"email_verified"`
+	Roles         []string               `json:"roles"`
+	Claims        map[string]interface{} `json:"claims"` // custom claims only; standard JWT claims are omitted
+	Profile       map[string]interface{} `json:"profile,omitempty"`
+}
+
+// DataDeletionReport counts what DELETE /users/{uid}/data removed for a right-to-erasure request.
+// Aggregate tracking rollups hold no user data and are kept.
+type DataDeletionReport struct {
+	UserID         string `json:"user_id"`
+	TrackingEvents int    `json:"tracking_events"`
+	RSVPs          int    `json:"rsvps"`
+	Favorites      int    `json:"favorites"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+// Visibility decides who can find and read an event
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"   // listed, searchable and readable by anyone
+	VisibilityUnlisted Visibility = "unlisted" // readable by anyone with its id or slug, but never listed
+	VisibilityPrivate  Visibility = "private"  // readable only by its creator and admins
+)
+
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// EventVisibility is the event's visibility; events stored before the field existed are public
+func (e *Event) EventVisibility() Visibility {
+	if e.Visibility == "" {
+		return VisibilityPublic
+	}
+	return e.Visibility
+}
+
+// ListedFor reports whether the event appears in the lists and searches of p (ok false: a guest).
+// Admins see every event; everyone else only public ones.
+func (e *Event) ListedFor(p Principal, ok bool) bool {
+	return ListsEveryEvent(p, ok) || e.EventVisibility() == VisibilityPublic
+}
+
+// ListsEveryEvent reports whether the lists, searches and aggregates of p (ok false: a guest)
+// include unlisted and private events; only admins' do
+func ListsEveryEvent(p Principal, ok bool) bool {
+	return ok && p.Role == RoleAdmin
+}
+
+// ReadableBy reports whether p (ok false: a guest) may read the event by its id or slug
+func (e *Event) ReadableBy(p Principal, ok bool) bool {
+	if e.EventVisibility() != VisibilityPrivate {
+		return true
+	}
+	return ok && (p.Role == RoleAdmin || (p.UID != "" && p.UID == e.CreatedBy))
+}
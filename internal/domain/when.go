@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	// Embed the IANA database so timezone validation works on minimal runtime images
+	_ "time/tzdata"
+)
+
+// Relative time windows accepted by the `when` list filter
+const (
+	WhenUpcoming    = "upcoming"
+	WhenPast        = "past"
+	WhenToday       = "today"
+	WhenThisWeekend = "this_weekend"
+)
+
+// ResolveWhen translates a relative window into absolute StartDate/EndDate bounds.
+// Day boundaries are computed in loc so "today" means the caller's local day.
+// A nil bound means the window is open on that side.
+func ResolveWhen(when string, now time.Time, loc *time.Location) (start, end *time.Time) {
+	now = now.In(loc)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch when {
+	case WhenUpcoming:
+		return &now, nil
+	case WhenPast:
+		return nil, &now
+	case WhenToday:
+		endOfDay := startOfDay.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		return &startOfDay, &endOfDay
+	case WhenThisWeekend:
+		// Saturday 00:00 until Sunday 23:59:59; on a weekend day this is the current weekend
+		var saturday time.Time
+		switch now.Weekday() {
+		case time.Saturday:
+			saturday = startOfDay
+		case time.Sunday:
+			saturday = startOfDay.AddDate(0, 0, -1)
+		default:
+			saturday = startOfDay.AddDate(0, 0, int(time.Saturday-now.Weekday()))
+		}
+		endOfSunday := saturday.AddDate(0, 0, 2).Add(-time.Nanosecond)
+		return &saturday, &endOfSunday
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,87 @@
+// Package eventtypes keeps the event types a deployment accepts without a redeploy.
+// The list starts at domain.DefaultEventTypes, may be replaced by EVENT_TYPES at cold start,
+// and, with a Source, by a document an operator edits at runtime.
+package eventtypes
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// loadTimeout bounds a reload; requests wait for it while holding the lock
+const loadTimeout = 2 * time.Second
+
+// Source supplies the runtime list; an empty list leaves the static one in place
+type Source interface {
+	LoadEventTypes(ctx context.Context) ([]domain.EventType, error)
+}
+
+// Registry is the domain.EventTypeRegistry of a deployment: the static list, replaced by the
+// Source's list when it has one, which is reloaded at most once per TTL
+type Registry struct {
+	static []domain.EventType
+	source Source
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	dynamic  []domain.EventType
+	loadedAt time.Time
+}
+
+// Option configures a Registry
+type Option func(*Registry)
+
+// WithSource reads the list from src, cached for ttl. When a reload fails the previous list is
+// kept until the next attempt, one ttl later.
+func WithSource(src Source, ttl time.Duration) Option {
+	return func(r *Registry) {
+		r.source = src
+		r.ttl = ttl
+	}
+}
+
+// New returns the registry of types; none means domain.DefaultEventTypes
+func New(types []domain.EventType, opts ...Option) *Registry {
+	if len(types) == 0 {
+		types = domain.DefaultEventTypes
+	}
+	r := &Registry{static: slices.Clone(types)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Registry) EventTypes(ctx context.Context) []domain.EventType {
+	if types := r.loaded(ctx); len(types) > 0 {
+		return types
+	}
+	return r.static
+}
+
+// loaded returns the Source's list, reloading it when the TTL has passed
+func (r *Registry) loaded(ctx context.Context) []domain.EventType {
+	if r.source == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.loadedAt) < r.ttl {
+		return r.dynamic
+	}
+	r.loadedAt = time.Now()
+	// The request that happens to reload must not cut the read short for everyone
+	loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+	defer cancel()
+	types, err := r.source.LoadEventTypes(loadCtx)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "event types not reloaded", "error", err)
+		return r.dynamic
+	}
+	r.dynamic = types
+	return r.dynamic
+}
@@ -0,0 +1,53 @@
+package eventtypes
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectionSettings holds deployment settings operators edit at runtime. The event types are
+// the document DocEventTypes: {"types": ["concert", "workshop", ...]}. Settings are per
+// deployment, so the collection is not tenant-scoped.
+const (
+	CollectionSettings = "settings"
+	DocEventTypes      = "event_types"
+)
+
+type eventTypesDoc struct {
+	Types []string `firestore:"types"`
+}
+
+type firestoreSource struct {
+	client *firestore.Client
+}
+
+// NewFirestoreSource reads the list from the DocEventTypes document; a missing document keeps the
+// static list and names that are not valid types are ignored
+func NewFirestoreSource(client *firestore.Client) Source {
+	return &firestoreSource{client: client}
+}
+
+func (s *firestoreSource) LoadEventTypes(ctx context.Context) ([]domain.EventType, error) {
+	snap, err := s.client.Collection(CollectionSettings).Doc(DocEventTypes).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var doc eventTypesDoc
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, err
+	}
+	types := make([]domain.EventType, 0, len(doc.Types))
+	for _, name := range doc.Types {
+		if domain.ValidEventTypeName(name) {
+			types = append(types, domain.EventType(name))
+		}
+	}
+	return types, nil
+}
@@ -0,0 +1,40 @@
+package flags
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+)
+
+// CollectionFeatureFlags holds one document per overridden flag, named after it: {"enabled": bool}.
+// Flags are per deployment, so the collection is not tenant-scoped.
+const CollectionFeatureFlags = "feature_flags"
+
+type flagDoc struct {
+	Enabled bool `firestore:"enabled"`
+}
+
+type firestoreSource struct {
+	client *firestore.Client
+}
+
+// NewFirestoreSource reads overrides from CollectionFeatureFlags; documents of unknown flags are ignored
+func NewFirestoreSource(client *firestore.Client) Source {
+	return &firestoreSource{client: client}
+}
+
+func (s *firestoreSource) LoadFlags(ctx context.Context) (map[string]bool, error) {
+	docs, err := s.client.Collection(CollectionFeatureFlags).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		var f flagDoc
+		if !Known(doc.Ref.ID) || doc.DataTo(&f) != nil {
+			continue
+		}
+		flags[doc.Ref.ID] = f.Enabled
+	}
+	return flags, nil
+}
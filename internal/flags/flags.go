@@ -0,0 +1,118 @@
+// Package flags switches features on and off per environment without a redeploy.
+// A flag starts at its built-in default, may be overridden by FEATURE_FLAGS at cold start,
+// and, with a Source, by documents an operator edits at runtime.
+package flags
+
+import (
+	"bibently.com/backend/internal/logging"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Known flags. Every one defaults to on, so a deployment without overrides behaves as before flags.
+const (
+	// GuestRead lets callers without a token read the public catalog (events, organizers, GraphQL)
+	GuestRead = "guest_read"
+	// Webhooks routes partner pushes to /webhooks/ (when a signing secret is configured too)
+	Webhooks = "webhooks"
+	// PopularitySort accepts sort_key=popularity on event lists
+	PopularitySort = "popularity_sort"
+)
+
+// Defaults holds the value of every known flag before overrides
+var Defaults = map[string]bool{
+	GuestRead:      true,
+	Webhooks:       true,
+	PopularitySort: true,
+}
+
+// Known reports whether name is a flag this build understands
+func Known(name string) bool {
+	_, ok := Defaults[name]
+	return ok
+}
+
+// Names returns the known flags, sorted
+func Names() []string {
+	return slices.Sorted(maps.Keys(Defaults))
+}
+
+// loadTimeout bounds a reload; requests wait for it while holding the lock
+const loadTimeout = 2 * time.Second
+
+// Flags answers whether a feature is on. Unknown flags are off.
+type Flags interface {
+	Enabled(ctx context.Context, name string) bool
+}
+
+// Source supplies runtime overrides, keyed by flag name
+type Source interface {
+	LoadFlags(ctx context.Context) (map[string]bool, error)
+}
+
+// Set is the Flags of a deployment: defaults, then static overrides, then the Source's overrides,
+// which are reloaded at most once per TTL
+type Set struct {
+	static map[string]bool
+	source Source
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	dynamic  map[string]bool
+	loadedAt time.Time
+}
+
+// Option configures a Set
+type Option func(*Set)
+
+// WithSource overrides flags from src, cached for ttl. When a reload fails the previous
+// overrides are kept until the next attempt, one ttl later.
+func WithSource(src Source, ttl time.Duration) Option {
+	return func(s *Set) {
+		s.source = src
+		s.ttl = ttl
+	}
+}
+
+// New returns the flags with overrides applied over Defaults
+func New(overrides map[string]bool, opts ...Option) *Set {
+	s := &Set{static: maps.Clone(Defaults)}
+	maps.Copy(s.static, overrides)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Set) Enabled(ctx context.Context, name string) bool {
+	if on, ok := s.overrides(ctx)[name]; ok {
+		return on
+	}
+	return s.static[name]
+}
+
+// overrides returns the Source's flags, reloading them when the TTL has passed
+func (s *Set) overrides(ctx context.Context) map[string]bool {
+	if s.source == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.loadedAt) < s.ttl {
+		return s.dynamic
+	}
+	s.loadedAt = time.Now()
+	// The request that happens to reload must not cut the read short for everyone
+	loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+	defer cancel()
+	loaded, err := s.source.LoadFlags(loadCtx)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "feature flags not reloaded", "error", err)
+		return s.dynamic
+	}
+	s.dynamic = loaded
+	return s.dynamic
+}
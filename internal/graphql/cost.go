@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxCostTerm caps intermediate cost values so the product of two never overflows
+const maxCostTerm = math.MaxInt32
+
+type queryCost struct {
+	cost    int
+	aliases int
+}
+
+// checkLimits estimates the cost of op and counts its aliases without resolving anything. The
+// probe executor evaluates @skip/@include like execution does; its errors are reported later.
+func (s *Schema) checkLimits(probe *executor, op *Operation) error {
+	if s.MaxCost <= 0 && s.MaxAliases <= 0 {
+		return nil
+	}
+	var total queryCost
+	probe.measure(s.Query, op.Selections, 1, map[string]bool{}, &total)
+	if s.MaxAliases > 0 && total.aliases > s.MaxAliases {
+		return fmt.Errorf("query uses %d aliases, more than the limit of %d", total.aliases, s.MaxAliases)
+	}
+	if s.MaxCost > 0 && total.cost > s.MaxCost {
+		return fmt.Errorf("query cost %d exceeds the limit of %d", total.cost, s.MaxCost)
+	}
+	return nil
+}
+
+// measure adds the cost of sels, resolved multiplier times, to total. A field costs its Cost for
+// every parent it is resolved for, and list fields multiply what is selected below them.
+func (e *executor) measure(obj *Object, sels []Selection, multiplier int, visited map[string]bool, total *queryCost) {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *FieldSelection:
+			if !e.included(sel.Directives, nil) {
+				continue
+			}
+			if sel.Alias != "" {
+				total.aliases++
+			}
+			def, ok := obj.Fields[sel.Name]
+			if !ok {
+				continue
+			}
+			total.cost = min(total.cost+min(multiplier*def.Cost, maxCostTerm), maxCostTerm)
+			child := objectOf(def.Type)
+			if child == nil {
+				continue
+			}
+			n := multiplier
+			if def.ListSize != nil {
+				// Invalid arguments fail the field at execution, so nothing below it is resolved
+				args, err := e.coerceArguments(def, sel.Arguments)
+				if err != nil {
+					continue
+				}
+				n = min(n*max(def.ListSize(args), 0), maxCostTerm)
+			}
+			e.measure(child, sel.Selections, n, visited, total)
+		case *FragmentSpread:
+			frag, ok := e.doc.Fragments[sel.Name]
+			if !ok || visited[sel.Name] || !e.included(sel.Directives, nil) || frag.TypeCondition != obj.Name {
+				continue
+			}
+			visited[sel.Name] = true
+			e.measure(obj, frag.Selections, multiplier, visited, total)
+			delete(visited, sel.Name)
+		case *InlineFragment:
+			if e.included(sel.Directives, nil) && (sel.TypeCondition == "" || sel.TypeCondition == obj.Name) {
+				e.measure(obj, sel.Selections, multiplier, visited, total)
+			}
+		}
+	}
+}
+
+// objectOf returns the object type under any list and non-null wrappers, or nil for scalars
+func objectOf(t Type) *Object {
+	for {
+		switch w := t.(type) {
+		case *NonNull:
+			t = w.OfType
+		case *List:
+			t = w.OfType
+		case *Object:
+			return w
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Request is the body of a GraphQL-over-HTTP request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response carries the data and the errors of an execution.
+// Data is absent when the request failed before execution (syntax or variable errors).
+type Response struct {
+	Data   *OrderedMap `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error. Resolvers may return one to attach extensions (e.g. an error code).
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// OrderedMap is a JSON object that keeps the order of the requested fields
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: map[string]interface{}{}}
+}
+
+func (m *OrderedMap) Set(key string, v interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+func (m *OrderedMap) Get(key string) interface{} { return m.values[key] }
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Execute parses and runs a query against the schema. Resolver errors are reported per field
+// and null their field (or the nearest nullable parent); they never fail the whole request.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	vars, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	if err := s.checkLimits(&executor{ctx: ctx, doc: doc, vars: vars}, op); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	e := &executor{ctx: ctx, doc: doc, vars: vars}
+	data, _ := e.selectionSet(s.Query, nil, op.Selections, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	var op *Operation
+	switch {
+	case name != "":
+		for _, o := range doc.Operations {
+			if o.Name == name {
+				op = o
+			}
+		}
+		if op == nil {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	default:
+		return nil, fmt.Errorf("operationName is required when the document contains several operations")
+	}
+	if op.Type != "query" {
+		return nil, fmt.Errorf("%s operations are not supported", op.Type)
+	}
+	return op, nil
+}
+
+func coerceVariables(op *Operation, input map[string]interface{}) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	for _, def := range op.Variables {
+		v, ok := input[def.Name]
+		if !ok && def.Default != nil {
+			v, _ = resolveValue(def.Default, nil)
+			ok = true
+		}
+		if strings.HasSuffix(def.Type, "!") && v == nil {
+			return nil, fmt.Errorf("variable $%s of type %s is required", def.Name, def.Type)
+		}
+		if ok {
+			vars[def.Name] = v
+		}
+	}
+	return vars, nil
+}
+
+// resolveValue turns an AST value into plain Go values, substituting variables.
+// An undefined variable resolves to absent (ok false).
+func resolveValue(v Value, vars map[string]interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case Variable:
+		val, ok := vars[string(v)]
+		return val, ok
+	case EnumValue:
+		return string(v), true
+	case ListValue:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			val, _ := resolveValue(item, vars)
+			list = append(list, val)
+		}
+		return list, true
+	case ObjectValue:
+		obj := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			if val, ok := resolveValue(item, vars); ok {
+				obj[k] = val
+			}
+		}
+		return obj, true
+	default:
+		return v, true
+	}
+}
+
+type executor struct {
+	ctx    context.Context
+	doc    *Document
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+func (e *executor) fail(path []interface{}, format string, args ...interface{}) {
+	e.errors = append(e.errors, &Error{Message: fmt.Sprintf(format, args...), Path: append([]interface{}(nil), path...)})
+}
+
+// collectedField is one response key and every selection merged into it
+type collectedField struct {
+	key    string
+	fields []*FieldSelection
+}
+
+func (e *executor) collect(obj *Object, sels []Selection, visited map[string]bool, out []*collectedField, path []interface{}) []*collectedField {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *FieldSelection:
+			if !e.included(sel.Directives, path) {
+				continue
+			}
+			key := sel.ResponseKey()
+			merged := false
+			for _, cf := range out {
+				if cf.key == key {
+					cf.fields = append(cf.fields, sel)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				out = append(out, &collectedField{key: key, fields: []*FieldSelection{sel}})
+			}
+		case *FragmentSpread:
+			if !e.included(sel.Directives, path) || visited[sel.Name] {
+				continue
+			}
+			frag, ok := e.doc.Fragments[sel.Name]
+			if !ok {
+				e.fail(path, "unknown fragment %q", sel.Name)
+				continue
+			}
+			visited[sel.Name] = true
+			if frag.TypeCondition == obj.Name {
+				out = e.collect(obj, frag.Selections, visited, out, path)
+			}
+		case *InlineFragment:
+			if !e.included(sel.Directives, path) {
+				continue
+			}
+			if sel.TypeCondition == "" || sel.TypeCondition == obj.Name {
+				out = e.collect(obj, sel.Selections, visited, out, path)
+			}
+		}
+	}
+	return out
+}
+
+// included evaluates @skip and @include
+func (e *executor) included(dirs []*Directive, path []interface{}) bool {
+	for _, d := range dirs {
+		if d.Name != "skip" && d.Name != "include" {
+			e.fail(path, "unknown directive @%s", d.Name)
+			continue
+		}
+		cond, _ := resolveValue(d.Arguments["if"], e.vars)
+		b, ok := cond.(bool)
+		if !ok {
+			e.fail(path, "@%s requires a Boolean \"if\" argument", d.Name)
+			return false
+		}
+		if (d.Name == "skip" && b) || (d.Name == "include" && !b) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectionSet resolves the selected fields of obj. ok is false when a non-null field came back
+// null, in which case the whole object is null.
+func (e *executor) selectionSet(obj *Object, source interface{}, sels []Selection, path []interface{}) (*OrderedMap, bool) {
+	result := newOrderedMap()
+	for _, cf := range e.collect(obj, sels, map[string]bool{}, nil, path) {
+		fieldPath := append(append([]interface{}(nil), path...), cf.key)
+		sel := cf.fields[0]
+		if sel.Name == "__typename" {
+			result.Set(cf.key, obj.Name)
+			continue
+		}
+
+		def, ok := obj.Fields[sel.Name]
+		if !ok {
+			e.fail(fieldPath, "cannot query field %q on type %q", sel.Name, obj.Name)
+			result.Set(cf.key, nil)
+			continue
+		}
+		value, ok := e.resolveField(def, cf.fields, source, fieldPath)
+		if !ok {
+			if _, nonNull := def.Type.(*NonNull); nonNull {
+				return nil, false
+			}
+		}
+		result.Set(cf.key, value)
+	}
+	return result, true
+}
+
+func (e *executor) resolveField(def *Field, fields []*FieldSelection, source interface{}, path []interface{}) (interface{}, bool) {
+	args, err := e.coerceArguments(def, fields[0].Arguments)
+	if err != nil {
+		e.fail(path, "%s", err)
+		return nil, false
+	}
+	if def.Resolve == nil {
+		e.fail(path, "field has no resolver")
+		return nil, false
+	}
+	value, err := def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+	if err != nil {
+		var gqlErr *Error
+		if errors.As(err, &gqlErr) {
+			e.errors = append(e.errors, &Error{Message: gqlErr.Message, Path: append([]interface{}(nil), path...), Extensions: gqlErr.Extensions})
+		} else {
+			e.fail(path, "%s", err)
+		}
+		return nil, false
+	}
+	return e.complete(def.Type, fields, value, path)
+}
+
+// complete converts a resolved value to its response form. ok is false when the value must be
+// null but its type is non-null, so the error propagates to the nearest nullable parent.
+func (e *executor) complete(typ Type, fields []*FieldSelection, value interface{}, path []interface{}) (interface{}, bool) {
+	if nn, ok := typ.(*NonNull); ok {
+		v, ok := e.completeValue(nn.OfType, fields, value, path)
+		if !ok {
+			return nil, false
+		}
+		if v == nil {
+			e.fail(path, "cannot return null for non-null field")
+			return nil, false
+		}
+		return v, true
+	}
+	if v, ok := e.completeValue(typ, fields, value, path); ok {
+		return v, true
+	}
+	return nil, true
+}
+
+// completeValue completes a value of a nullable type; ok is false when it failed
+func (e *executor) completeValue(typ Type, fields []*FieldSelection, value interface{}, path []interface{}) (interface{}, bool) {
+	if isNil(value) {
+		return nil, true
+	}
+
+	var sels []Selection
+	for _, f := range fields {
+		sels = append(sels, f.Selections...)
+	}
+
+	switch t := typ.(type) {
+	case *Scalar:
+		if len(sels) > 0 {
+			e.fail(path, "field of type %s cannot have a selection of subfields", t.Name)
+			return nil, false
+		}
+		v, err := serializeScalar(t, value)
+		if err != nil {
+			e.fail(path, "%s", err)
+			return nil, false
+		}
+		return v, true
+	case *Object:
+		if len(sels) == 0 {
+			e.fail(path, "field of type %s must have a selection of subfields", t.Name)
+			return nil, false
+		}
+		m, ok := e.selectionSet(t, value, sels, path)
+		if !ok {
+			return nil, false
+		}
+		return m, true
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fail(path, "expected a list, got %T", value)
+			return nil, false
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			v, ok := e.complete(t.OfType, fields, rv.Index(i).Interface(), append(path, i))
+			if !ok {
+				return nil, false
+			}
+			items[i] = v
+		}
+		return items, true
+	}
+	e.fail(path, "unsupported type %s", typ)
+	return nil, false
+}
+
+func (e *executor) coerceArguments(def *Field, given map[string]Value) (map[string]interface{}, error) {
+	for name := range given {
+		if _, ok := def.Args[name]; !ok {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+	}
+	args := map[string]interface{}{}
+	for name, arg := range def.Args {
+		var raw interface{}
+		present := false
+		if v, ok := given[name]; ok {
+			raw, present = resolveValue(v, e.vars)
+		}
+		typ := arg.Type
+		nn, required := typ.(*NonNull)
+		if required {
+			typ = nn.OfType
+		}
+		if !present || raw == nil {
+			if required {
+				return nil, fmt.Errorf("argument %q of type %s is required", name, arg.Type)
+			}
+			continue
+		}
+		v, err := coerceInput(typ, raw)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", name, err)
+		}
+		args[name] = v
+	}
+	return args, nil
+}
+
+// coerceInput converts an argument to the Go type resolvers receive:
+// Int -> int, Float -> float64, String/ID/custom scalars -> string, Boolean -> bool
+func coerceInput(typ Type, v interface{}) (interface{}, error) {
+	s, ok := typ.(*Scalar)
+	if !ok {
+		return v, nil
+	}
+	switch s {
+	case Int:
+		switch n := v.(type) {
+		case int64:
+			if n >= math.MinInt32 && n <= math.MaxInt32 {
+				return int(n), nil
+			}
+		case float64: // JSON variables
+			if n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32 {
+				return int(n), nil
+			}
+		}
+	case Float:
+		switch n := v.(type) {
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case Boolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case ID:
+		switch n := v.(type) {
+		case string:
+			return n, nil
+		case int64:
+			return fmt.Sprint(n), nil
+		case float64:
+			if n == math.Trunc(n) {
+				return fmt.Sprint(int64(n)), nil
+			}
+		}
+	default:
+		if str, ok := v.(string); ok {
+			return str, nil
+		}
+	}
+	return nil, fmt.Errorf("expected %s, got %v", s.Name, v)
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	// A nil slice is Go's empty list and is returned as [] rather than null
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
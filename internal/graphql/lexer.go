@@ -0,0 +1,193 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// bom is the byte order mark, which GraphQL treats as whitespace
+const bom = "\uFEFF"
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "<EOF>"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+// next returns the following significant token; whitespace, commas and comments are ignored
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], bom):
+			l.pos += len(bom)
+		default:
+			return l.scan()
+		}
+	}
+	return token{kind: tokEOF, pos: l.pos}, nil
+}
+
+func (l *lexer) scan() (token, error) {
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.ContainsRune("!$&()=:@[]{}|", rune(c)):
+		l.pos++
+		return token{kind: tokPunct, value: string(c), pos: start}, nil
+	case c == '.':
+		if strings.HasPrefix(l.src[l.pos:], "...") {
+			l.pos += 3
+			return token{kind: tokPunct, value: "...", pos: start}, nil
+		}
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokName, value: l.src[start:l.pos], pos: start}, nil
+	case c == '-' || isDigit(c):
+		return l.scanNumber()
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.scanBlockString()
+		}
+		return l.scanString()
+	}
+	return token{}, fmt.Errorf("unexpected character %q at offset %d", c, start)
+}
+
+func (l *lexer) scanNumber() (token, error) {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	if !l.digits() {
+		return token{}, fmt.Errorf("invalid number at offset %d", start)
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokFloat
+		l.pos++
+		if !l.digits() {
+			return token{}, fmt.Errorf("invalid number at offset %d", start)
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if !l.digits() {
+			return token{}, fmt.Errorf("invalid number at offset %d", start)
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.pos > start
+}
+
+func (l *lexer) scanString() (token, error) {
+	start := l.pos
+	l.pos++ // opening quote
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokString, value: b.String(), pos: start}, nil
+		case c == '\n' || c == '\r':
+			return token{}, fmt.Errorf("unterminated string at offset %d", start)
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			esc := l.src[l.pos+1]
+			l.pos += 2
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, fmt.Errorf("invalid unicode escape at offset %d", l.pos)
+				}
+				var r rune
+				if _, err := fmt.Sscanf(l.src[l.pos:l.pos+4], "%04x", &r); err != nil {
+					return token{}, fmt.Errorf("invalid unicode escape at offset %d", l.pos)
+				}
+				b.WriteRune(r)
+				l.pos += 4
+			default:
+				return token{}, fmt.Errorf("invalid escape \\%c at offset %d", esc, l.pos-2)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.pos += size
+		}
+	}
+	return token{}, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// scanBlockString reads a """block string"""; common indentation is not stripped
+func (l *lexer) scanBlockString() (token, error) {
+	start := l.pos
+	l.pos += 3
+	end := strings.Index(l.src[l.pos:], `"""`)
+	if end < 0 {
+		return token{}, fmt.Errorf("unterminated block string at offset %d", start)
+	}
+	value := strings.ReplaceAll(l.src[l.pos:l.pos+end], `\"""`, `"""`)
+	l.pos += end + 3
+	return token{kind: tokString, value: strings.Trim(value, "\n\r"), pos: start}, nil
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
@@ -0,0 +1,501 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Document is a parsed request: its operations and the named fragments they may spread
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type       string // query, mutation or subscription
+	Name       string
+	Variables  []*VariableDefinition
+	Selections []Selection
+}
+
+type VariableDefinition struct {
+	Name    string
+	Type    string // as written, e.g. "String!" or "[ID]"
+	Default Value
+}
+
+// Selection is a *FieldSelection, *FragmentSpread or *InlineFragment
+type Selection interface{}
+
+type FieldSelection struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]Value
+	Directives []*Directive
+	Selections []Selection
+}
+
+// ResponseKey is the name the field's value is returned under
+func (f *FieldSelection) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Selections    []Selection
+}
+
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is a literal (int64, float64, string, bool, nil), EnumValue, Variable, ListValue or ObjectValue
+type Value interface{}
+
+type (
+	Variable    string
+	EnumValue   string
+	ListValue   []Value
+	ObjectValue map[string]Value
+)
+
+// maxSelectionDepth bounds nesting so a hostile query cannot recurse the parser (or the services) deeply
+const maxSelectionDepth = 12
+
+type parser struct {
+	lex   lexer
+	tok   token
+	depth int
+}
+
+// Parse parses a GraphQL executable document
+func Parse(src string) (*Document, error) {
+	p := &parser{lex: lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.isPunct("{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", Selections: sels})
+		case p.isName("query"), p.isName("mutation"), p.isName("subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.isName("fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.Fragments[frag.Name]; dup {
+				return nil, fmt.Errorf("fragment %q is defined more than once", frag.Name)
+			}
+			doc.Fragments[frag.Name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("document contains no operation")
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) isPunct(v string) bool { return p.tok.kind == tokPunct && p.tok.value == v }
+func (p *parser) isName(v string) bool  { return p.tok.kind == tokName && p.tok.value == v }
+
+func (p *parser) unexpected() error {
+	return fmt.Errorf("syntax error: unexpected %s at offset %d", p.tok, p.tok.pos)
+}
+
+func (p *parser) expectPunct(v string) error {
+	if !p.isPunct(v) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokName {
+		op.Name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if p.isPunct("(") {
+		vars, err := p.variableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.Variables = vars
+	}
+	// Operation-level directives are accepted and ignored
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sels
+	return op, nil
+}
+
+func (p *parser) variableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var defs []*VariableDefinition
+	for !p.isPunct(")") {
+		if err := p.expectPunct("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		typ, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		def := &VariableDefinition{Name: name, Type: typ}
+		if p.isPunct("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if def.Default, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	return defs, p.advance()
+}
+
+func (p *parser) typeRef() (string, error) {
+	var typ string
+	if p.isPunct("[") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+	if p.isPunct("!") {
+		typ += "!"
+		return typ, p.advance()
+	}
+	return typ, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	if err := p.advance(); err != nil { // "fragment"
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, fmt.Errorf("syntax error: fragment cannot be named \"on\"")
+	}
+	if !p.isName("on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	cond, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &Fragment{Name: name, TypeCondition: cond, Selections: sels}, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	if p.depth++; p.depth > maxSelectionDepth {
+		return nil, fmt.Errorf("query is nested deeper than %d levels", maxSelectionDepth)
+	}
+	defer func() { p.depth-- }()
+
+	var sels []Selection
+	for !p.isPunct("}") {
+		if p.tok.kind == tokEOF {
+			return nil, p.unexpected()
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, fmt.Errorf("syntax error: empty selection set")
+	}
+	return sels, p.advance()
+}
+
+func (p *parser) selection() (Selection, error) {
+	if p.isPunct("...") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokName && p.tok.value != "on" {
+			name := p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			dirs, err := p.directives()
+			if err != nil {
+				return nil, err
+			}
+			return &FragmentSpread{Name: name, Directives: dirs}, nil
+		}
+		inline := &InlineFragment{}
+		if p.isName("on") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			cond, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			inline.TypeCondition = cond
+		}
+		var err error
+		if inline.Directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if inline.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+	return p.field()
+}
+
+func (p *parser) field() (*FieldSelection, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	f := &FieldSelection{Name: name}
+	if p.isPunct(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		f.Alias = name
+		if f.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.isPunct("(") {
+		if f.Arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+	}
+	if f.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if f.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) (map[string]Value, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	args := map[string]Value{}
+	for !p.isPunct(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if _, dup := args[name]; dup {
+			return nil, fmt.Errorf("argument %q is given more than once", name)
+		}
+		if args[name], err = p.value(constant); err != nil {
+			return nil, err
+		}
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var dirs []*Directive
+	for p.isPunct("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		d := &Directive{Name: name}
+		if p.isPunct("(") {
+			if d.Arguments, err = p.arguments(false); err != nil {
+				return nil, err
+			}
+		}
+		dirs = append(dirs, d)
+	}
+	return dirs, nil
+}
+
+// value parses an input value; constant values (variable defaults) may not reference variables
+func (p *parser) value(constant bool) (Value, error) {
+	tok := p.tok
+	switch {
+	case p.isPunct("$"):
+		if constant {
+			return nil, fmt.Errorf("syntax error: variable not allowed at offset %d", tok.pos)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return Variable(name), err
+	case p.isPunct("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := ListValue{}
+		for !p.isPunct("]") {
+			if p.tok.kind == tokEOF {
+				return nil, p.unexpected()
+			}
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.advance()
+	case p.isPunct("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		obj := ObjectValue{}
+		for !p.isPunct("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.advance()
+	case tok.kind == tokInt:
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("integer out of range at offset %d", tok.pos)
+		}
+		return n, p.advance()
+	case tok.kind == tokFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float at offset %d", tok.pos)
+		}
+		return f, p.advance()
+	case tok.kind == tokString:
+		return tok.value, p.advance()
+	case tok.kind == tokName:
+		var v Value
+		switch tok.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = EnumValue(tok.value)
+		}
+		return v, p.advance()
+	}
+	return nil, p.unexpected()
+}
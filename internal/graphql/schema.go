@@ -0,0 +1,222 @@
+// Package graphql is a small GraphQL query engine: a parser for executable documents and an
+// executor over a schema of Go resolvers. It supports queries with variables, aliases, fragments
+// and @skip/@include; mutations, subscriptions and introspection (__schema) are not implemented.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Type is a *Scalar, *Object, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+type Scalar struct {
+	Name        string
+	Description string
+	// Serialize converts a resolved Go value to its JSON form; nil uses the built-in rules
+	Serialize func(v interface{}) interface{}
+}
+
+type Object struct {
+	Name        string
+	Description string
+	Fields      map[string]*Field
+}
+
+type List struct {
+	OfType Type
+}
+
+type NonNull struct {
+	OfType Type
+}
+
+func (s *Scalar) String() string  { return s.Name }
+func (o *Object) String() string  { return o.Name }
+func (l *List) String() string    { return "[" + l.OfType.String() + "]" }
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+
+// Built-in scalars
+var (
+	String   = &Scalar{Name: "String"}
+	Int      = &Scalar{Name: "Int"}
+	Float    = &Scalar{Name: "Float"}
+	Boolean  = &Scalar{Name: "Boolean"}
+	ID       = &Scalar{Name: "ID"}
+	DateTime = &Scalar{
+		Name:        "DateTime",
+		Description: "RFC 3339 timestamp; unset times are null",
+		Serialize: func(v interface{}) interface{} {
+			if t, ok := v.(time.Time); ok {
+				if t.IsZero() {
+					return nil
+				}
+				return t.Format(time.RFC3339)
+			}
+			return v
+		},
+	}
+)
+
+// Field is a field of an object type. Args may only use scalar (or non-null scalar) types.
+type Field struct {
+	Type        Type
+	Description string
+	Args        map[string]*Argument
+	Resolve     ResolveFunc
+	// Cost is charged every time the field is resolved, e.g. 1 for a field that reads from a service
+	Cost int
+	// ListSize estimates how many items the field returns for its arguments; the cost of the
+	// fields selected below it is multiplied by it. nil counts as one.
+	ListSize func(args map[string]interface{}) int
+}
+
+type Argument struct {
+	Type        Type
+	Description string
+}
+
+// ResolveFunc produces the value of a field. Source is the parent object's resolved value;
+// Args holds the coerced arguments that were given (absent and null arguments are left out).
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+type Schema struct {
+	Query *Object
+	// MaxCost and MaxAliases reject a query before it runs when its estimated cost (see Field.Cost)
+	// or its number of aliased fields is higher; zero disables a check
+	MaxCost    int
+	MaxAliases int
+}
+
+// SDL prints the schema in the GraphQL schema definition language
+func (s *Schema) SDL() string {
+	objects := map[string]*Object{}
+	scalars := map[string]*Scalar{}
+	var walk func(t Type)
+	walk = func(t Type) {
+		switch t := t.(type) {
+		case *NonNull:
+			walk(t.OfType)
+		case *List:
+			walk(t.OfType)
+		case *Scalar:
+			scalars[t.Name] = t
+		case *Object:
+			if objects[t.Name] != nil {
+				return
+			}
+			objects[t.Name] = t
+			for _, f := range t.Fields {
+				walk(f.Type)
+				for _, a := range f.Args {
+					walk(a.Type)
+				}
+			}
+		}
+	}
+	walk(s.Query)
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n}\n")
+	for _, name := range sortedKeys(scalars) {
+		sc := scalars[name]
+		if sc == String || sc == Int || sc == Float || sc == Boolean || sc == ID {
+			continue
+		}
+		b.WriteString("\n")
+		writeDescription(&b, "", sc.Description)
+		b.WriteString("scalar " + name + "\n")
+	}
+	names := sortedKeys(objects)
+	// The root type first, the rest alphabetically
+	sort.SliceStable(names, func(i, j int) bool { return names[i] == s.Query.Name && names[j] != s.Query.Name })
+	for _, name := range names {
+		obj := objects[name]
+		b.WriteString("\n")
+		writeDescription(&b, "", obj.Description)
+		b.WriteString("type " + name + " {\n")
+		for _, fname := range sortedKeys(obj.Fields) {
+			f := obj.Fields[fname]
+			writeDescription(&b, "  ", f.Description)
+			b.WriteString("  " + fname)
+			if len(f.Args) > 0 {
+				var args []string
+				for _, aname := range sortedKeys(f.Args) {
+					args = append(args, aname+": "+f.Args[aname].Type.String())
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + f.Type.String() + "\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, indent, desc string) {
+	if desc != "" {
+		b.WriteString(indent + fmt.Sprintf("%q", desc) + "\n")
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// serializeScalar applies the built-in coercion rules for a resolved value
+func serializeScalar(s *Scalar, v interface{}) (interface{}, error) {
+	if s.Serialize != nil {
+		return s.Serialize(v), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch s {
+	case String, ID:
+		if rv.Kind() == reflect.String {
+			return rv.String(), nil
+		}
+		return fmt.Sprint(v), nil
+	case Int:
+		switch {
+		case rv.CanInt():
+			return rv.Int(), nil
+		case rv.CanUint():
+			return int64(rv.Uint()), nil
+		case rv.CanFloat():
+			return int64(rv.Float()), nil
+		}
+	case Float:
+		switch {
+		case rv.CanInt():
+			return float64(rv.Int()), nil
+		case rv.CanUint():
+			return float64(rv.Uint()), nil
+		case rv.CanFloat():
+			return rv.Float(), nil
+		}
+	case Boolean:
+		if rv.Kind() == reflect.Bool {
+			return rv.Bool(), nil
+		}
+	default:
+		return v, nil
+	}
+	return nil, fmt.Errorf("cannot represent %T as %s", v, s.Name)
+}
@@ -0,0 +1,97 @@
+// Package logging builds the structured logger of the service and carries it in contexts, so
+// handlers and services log through the instance they were given rather than a package global.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New returns a JSON logger in the Cloud Logging format that drops records below level (a
+// *slog.LevelVar changes it at runtime). Records logged with a context holding a span get its
+// trace fields; projectID qualifies the trace Id and leaves them out when empty.
+func New(w io.Writer, projectID string, level slog.Leveler) *slog.Logger {
+	return slog.New(traceHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level: level,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				// Map standard keys to Google Cloud Logging keys
+				if a.Key == slog.LevelKey {
+					a.Key = "severity"
+				}
+				if a.Key == slog.MessageKey {
+					a.Key = "message"
+				}
+				return a
+			},
+		}),
+		projectID: projectID,
+	})
+}
+
+// ParseLevel reads debug, info, warn or error, in any case
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("log level must be debug, info, warn or error, got %q", s)
+}
+
+// LevelName is the ParseLevel name of level
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// traceHandler adds the Cloud Logging trace fields of the active span to every record
+type traceHandler struct {
+	slog.Handler
+	projectID string
+}
+
+func (h traceHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && h.projectID != "" {
+		// GCP Format: projects/[PROJECT-ID]/traces/[TRACE-ID]
+		rec.AddAttrs(
+			slog.String("logging.googleapis.com/trace", "projects/"+h.projectID+"/traces/"+sc.TraceID().String()),
+			slog.String("logging.googleapis.com/spanId", sc.SpanID().String()),
+			slog.Bool("logging.googleapis.com/trace_sampled", sc.IsSampled()),
+		)
+	}
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs), h.projectID}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name), h.projectID}
+}
+
+type loggerKey struct{}
+
+// WithLogger stores the logger lower layers should use for work done under ctx
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored by WithLogger, or slog.Default() when there is none
+// (e.g. in tests that call a handler directly)
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
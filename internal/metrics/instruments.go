@@ -0,0 +1,19 @@
+package metrics
+
+// Instruments shared by the transport and repository layers
+var (
+	HTTPRequests = Default.NewCounterVec("http_requests_total",
+		"HTTP requests by method, route and status code.", "method", "route", "status")
+	HTTPDuration = Default.NewHistogramVec("http_request_duration_seconds",
+		"HTTP request latency by method and route.", DefaultBuckets, "method", "route")
+
+	FirestoreDuration = Default.NewHistogramVec("firestore_operation_duration_seconds",
+		"Firestore operation latency by repository operation.", DefaultBuckets, "op")
+	FirestoreDocsRead = Default.NewCounterVec("firestore_documents_read_total",
+		"Firestore documents read by repository operation.", "op")
+	FirestoreErrors = Default.NewCounterVec("firestore_operation_errors_total",
+		"Failed Firestore operations by repository operation.", "op")
+
+	CacheRequests = Default.NewCounterVec("cache_requests_total",
+		"Read cache lookups by repository operation and result (hit, miss, error).", "op", "result")
+)
@@ -0,0 +1,182 @@
+// Package metrics is a minimal Prometheus-compatible registry (counters and histograms with labels)
+// rendered in the text exposition format, so /metrics needs no client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are latency buckets in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type metric interface {
+	write(w io.Writer)
+}
+
+// Registry holds the metrics exposed by WritePrometheus
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// Default is the process-wide registry used by the instrumentation in this service
+var Default = &Registry{}
+
+func (reg *Registry) register(m metric) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.metrics = append(reg.metrics, m)
+}
+
+// WritePrometheus renders every registered metric in the Prometheus text format
+func (reg *Registry) WritePrometheus(w io.Writer) {
+	reg.mu.Lock()
+	metrics := append([]metric(nil), reg.metrics...)
+	reg.mu.Unlock()
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// vec holds one series per combination of label values
+type vec struct {
+	name   string
+	help   string
+	labels []string
+	mu     sync.Mutex
+}
+
+func (v *vec) key(values []string) string {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (v *vec) labelString(key string, extra ...string) string {
+	var parts []string
+	if len(v.labels) > 0 {
+		for i, val := range strings.Split(key, "\xff") {
+			parts = append(parts, v.labels[i]+"="+strconv.Quote(val))
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		parts = append(parts, extra[i]+"="+strconv.Quote(extra[i+1]))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// CounterVec is a monotonically increasing value per label combination
+type CounterVec struct {
+	vec
+	values map[string]float64
+}
+
+func (reg *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{vec: vec{name: name, help: help, labels: labels}, values: make(map[string]float64)}
+	reg.register(c)
+	return c
+}
+
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	key := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += delta
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelString(key), formatFloat(c.values[key]))
+	}
+}
+
+// HistogramVec counts observations into cumulative buckets per label combination
+type HistogramVec struct {
+	vec
+	buckets []float64
+	series  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, non-cumulative
+	count  uint64
+	sum    float64
+}
+
+func (reg *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{vec: vec{name: name, help: help, labels: labels}, buckets: buckets, series: make(map[string]*histogram)}
+	reg.register(h)
+	return h
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, upper := range h.buckets {
+		if value <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// ObserveSince records the seconds elapsed since start
+func (h *HistogramVec) ObserveSince(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(key, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(key, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelString(key), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelString(key), s.count)
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
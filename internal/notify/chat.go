@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type chatWebhookNotifier struct {
+	webhookURL string
+	discord    bool
+	siteURL    string
+	client     *http.Client
+}
+
+// NewChatWebhookNotifier posts to a Slack incoming webhook, or to a Discord webhook when the URL
+// is on discord.com, whenever an admin creates or cancels (deletes) an event.
+// Changes made by organizers or imports are not posted.
+func NewChatWebhookNotifier(webhookURL, siteURL string) OpsNotifier {
+	discord := false
+	if u, err := url.Parse(webhookURL); err == nil {
+		discord = u.Hostname() == "discord.com" || u.Hostname() == "discordapp.com"
+	}
+	return &chatWebhookNotifier{
+		webhookURL: webhookURL,
+		discord:    discord,
+		siteURL:    strings.TrimSuffix(siteURL, "/"),
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *chatWebhookNotifier) EventCreated(ctx context.Context, event *domain.Event) error {
+	return n.post(ctx, ":sparkles: New event", n.link(event), event)
+}
+
+func (n *chatWebhookNotifier) EventCancelled(ctx context.Context, event *domain.Event) error {
+	// The event page is gone, so the name is not linked
+	return n.post(ctx, ":x: Cancelled event", n.bold(event.EventName), event)
+}
+
+func (n *chatWebhookNotifier) post(ctx context.Context, headline, name string, event *domain.Event) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok || p.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	text := fmt.Sprintf("%s %s\n%s · %s · by %s", headline, name, event.City, formatStart(event), p.UID)
+	payload := map[string]string{"text": text}
+	if n.discord {
+		payload = map[string]string{"content": text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.discord {
+		return doProviderRequest(n.client, req, "discord")
+	}
+	return doProviderRequest(n.client, req, "slack")
+}
+
+// link renders the event name as a link in the target's markup
+func (n *chatWebhookNotifier) link(event *domain.Event) string {
+	if n.siteURL == "" || event.Slug == "" {
+		return n.bold(event.EventName)
+	}
+	href := n.siteURL + "/events/" + event.Slug
+	if n.discord {
+		// <...> suppresses Discord's link preview
+		return "[" + event.EventName + "](<" + href + ">)"
+	}
+	return "<" + href + "|" + event.EventName + ">"
+}
+
+func (n *chatWebhookNotifier) bold(text string) string {
+	if n.discord {
+		return "**" + text + "**"
+	}
+	return "*" + text + "*"
+}
@@ -0,0 +1,149 @@
+package notify
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// maxRecipientsPerEmail matches the batch limit of both SendGrid and Mailgun
+const maxRecipientsPerEmail = 1000
+
+// Email is one message sent individually to each recipient; recipients never see each other
+type Email struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer delivers email through a provider API
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+type emailNotifier struct {
+	subscriptions repository.SubscriptionRepository
+	deliveries    repository.AnnouncementRepository
+	mailer        Mailer
+	siteURL       string
+}
+
+// NewEmailNotifier mails opted-in subscribers of the event's city. siteURL is the public frontend
+// (e.g. "https://bibently.com"); links point to {siteURL}/events/{slug}.
+// Delivered batches are recorded in deliveries (optional), so announcing an event again only
+// mails the batches that failed.
+func NewEmailNotifier(subscriptions repository.SubscriptionRepository, deliveries repository.AnnouncementRepository, mailer Mailer, siteURL string) Notifier {
+	return &emailNotifier{subscriptions: subscriptions, deliveries: deliveries, mailer: mailer, siteURL: strings.TrimSuffix(siteURL, "/")}
+}
+
+func (n *emailNotifier) EventPublished(ctx context.Context, event *domain.Event) error {
+	if event.City == "" {
+		return nil
+	}
+	subs, err := n.subscriptions.ListEmailSubscribers(ctx, event.City)
+	if err != nil {
+		return err
+	}
+	recipients := make([]string, 0, len(subs))
+	for _, s := range subs {
+		if s.Email != "" {
+			recipients = append(recipients, s.Email)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+	// A stable order keeps batch numbers meaningful when a failed announcement is retried
+	slices.Sort(recipients)
+
+	email, err := n.render(event)
+	if err != nil {
+		return err
+	}
+	ledger, err := newBatchLedger(ctx, n.deliveries, event.Id, "email")
+	if err != nil {
+		return err
+	}
+	for batch, start := 0, 0; start < len(recipients); batch, start = batch+1, start+maxRecipientsPerEmail {
+		if ledger.delivered(batch) {
+			continue
+		}
+		email.To = recipients[start:min(start+maxRecipientsPerEmail, len(recipients))]
+		if err := n.mailer.Send(ctx, email); err != nil {
+			return err
+		}
+		if err := ledger.record(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventMail is the data passed to the templates
+type eventMail struct {
+	Name      string
+	City      string
+	Venue     string
+	StartTime string
+	Price     string
+	Link      string
+}
+
+var (
+	subjectTemplate = texttemplate.Must(texttemplate.New("subject").Parse(`New in {{.City}}: {{.Name}}`))
+	textTemplate    = texttemplate.Must(texttemplate.New("text").Parse(`{{.Name}}
+{{.StartTime}}{{if .Venue}}, {{.Venue}}{{end}}
+{{if .Price}}{{.Price}}
+{{end}}
+{{.Link}}
+
+You receive this email because you follow events in {{.City}}.
+`))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(`<h2><a href="{{.Link}}">{{.Name}}</a></h2>
+<p>{{.StartTime}}{{if .Venue}}<br>{{.Venue}}{{end}}{{if .Price}}<br>{{.Price}}{{end}}</p>
+<p><a href="{{.Link}}">See the event</a></p>
+<p style="color:#777;font-size:12px">You receive this email because you follow events in {{.City}}.</p>
+`))
+)
+
+func (n *emailNotifier) render(event *domain.Event) (Email, error) {
+	data := eventMail{
+		Name:      event.EventName,
+		City:      event.City,
+		Venue:     event.FullAddress,
+		StartTime: formatStart(event),
+		Link:      n.siteURL + "/events/" + event.Slug,
+	}
+	if event.Price > 0 {
+		data.Price = fmt.Sprintf("%.2f", event.Price)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := subjectTemplate.Execute(&subject, data); err != nil {
+		return Email{}, err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return Email{}, err
+	}
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return Email{}, err
+	}
+	return Email{Subject: subject.String(), Text: text.String(), HTML: html.String()}, nil
+}
+
+// formatStart renders the start time in the event's own timezone when it is known
+func formatStart(event *domain.Event) string {
+	start := event.StartTime
+	if loc, err := time.LoadLocation(event.Timezone); err == nil && event.Timezone != "" {
+		start = start.In(loc)
+	}
+	return start.Format("Mon, 2 Jan 2006 15:04 MST")
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MailgunUSBaseURL is the default API host; EU domains use https://api.eu.mailgun.net
+const MailgunUSBaseURL = "https://api.mailgun.net"
+
+type mailgunMailer struct {
+	apiKey  string
+	domain  string
+	from    string
+	baseURL string
+	client  *http.Client
+}
+
+// NewMailgunMailer sends through the Mailgun messages API for domain.
+// baseURL selects the region; empty means MailgunUSBaseURL.
+func NewMailgunMailer(apiKey, domain, from, baseURL string) Mailer {
+	if baseURL == "" {
+		baseURL = MailgunUSBaseURL
+	}
+	return &mailgunMailer{
+		apiKey:  apiKey,
+		domain:  domain,
+		from:    from,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (m *mailgunMailer) Send(ctx context.Context, email Email) error {
+	// recipient-variables turns the request into a batch send: every address gets its own copy
+	vars := make(map[string]struct{}, len(email.To))
+	for _, to := range email.To {
+		vars[to] = struct{}{}
+	}
+	recipientVars, err := json.Marshal(vars)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"from":                {m.from},
+		"to":                  {strings.Join(email.To, ",")},
+		"subject":             {email.Subject},
+		"text":                {email.Text},
+		"html":                {email.HTML},
+		"recipient-variables": {string(recipientVars)},
+	}
+	endpoint := m.baseURL + "/v3/" + url.PathEscape(m.domain) + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", m.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doProviderRequest(m.client, req, "mailgun")
+}
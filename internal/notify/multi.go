@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+)
+
+type multiNotifier []Notifier
+
+// Multi fans an event out to every notifier; all of them run even if one fails
+func Multi(notifiers ...Notifier) Notifier {
+	if len(notifiers) == 1 {
+		return notifiers[0]
+	}
+	return multiNotifier(notifiers)
+}
+
+func (m multiNotifier) EventPublished(ctx context.Context, event *domain.Event) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.EventPublished(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+// Package notify tells interested users about new events.
+package notify
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"fmt"
+)
+
+// Notifier is invoked by the event service once an event has been published
+type Notifier interface {
+	EventPublished(ctx context.Context, event *domain.Event) error
+}
+
+// OpsNotifier reports changes to the event catalog to an internal channel
+type OpsNotifier interface {
+	EventCreated(ctx context.Context, event *domain.Event) error
+	EventCancelled(ctx context.Context, event *domain.Event) error
+}
+
+// batchLedger tracks the batches of one event's announcement on one channel, so a retried
+// announcement skips the recipients already reached. Without a repository nothing is skipped.
+type batchLedger struct {
+	deliveries repository.AnnouncementRepository
+	eventID    string
+	channel    string
+	done       map[string]bool
+}
+
+func newBatchLedger(ctx context.Context, deliveries repository.AnnouncementRepository, eventID, channel string) (*batchLedger, error) {
+	l := &batchLedger{deliveries: deliveries, eventID: eventID, channel: channel, done: map[string]bool{}}
+	if deliveries == nil || eventID == "" {
+		return l, nil
+	}
+	done, err := deliveries.Delivered(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	l.done = done
+	return l, nil
+}
+
+func (l *batchLedger) key(index int) string {
+	return fmt.Sprintf("%s:%d", l.channel, index)
+}
+
+func (l *batchLedger) delivered(index int) bool {
+	return l.done[l.key(index)]
+}
+
+func (l *batchLedger) record(ctx context.Context, index int) error {
+	if l.deliveries == nil || l.eventID == "" {
+		return nil
+	}
+	return l.deliveries.MarkDelivered(ctx, l.eventID, l.key(index))
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"firebase.google.com/go/v4/messaging"
+)
+
+// maxTokensPerMulticast is the FCM limit for one multicast request
+const maxTokensPerMulticast = 500
+
+// PushSender is the part of *messaging.Client the push notifier uses
+type PushSender interface {
+	SendEachForMulticast(ctx context.Context, message *messaging.MulticastMessage) (*messaging.BatchResponse, error)
+}
+
+type pushNotifier struct {
+	subscriptions repository.PushSubscriptionRepository
+	deliveries    repository.AnnouncementRepository
+	sender        PushSender
+}
+
+// NewPushNotifier sends an FCM push to every device following the event's city (and type).
+// Tokens FCM reports as unregistered are removed from the subscriptions. Multicasts FCM accepted
+// are recorded in deliveries (optional) and not sent again when the event is announced again.
+func NewPushNotifier(subscriptions repository.PushSubscriptionRepository, deliveries repository.AnnouncementRepository, sender PushSender) Notifier {
+	return &pushNotifier{subscriptions: subscriptions, deliveries: deliveries, sender: sender}
+}
+
+func (n *pushNotifier) EventPublished(ctx context.Context, event *domain.Event) error {
+	if event.City == "" {
+		return nil
+	}
+	subs, err := n.subscriptions.ListPushTargets(ctx, event.City, event.Type)
+	if err != nil {
+		return err
+	}
+
+	// A device following both the city and the type gets a single push
+	seen := make(map[string]bool, len(subs))
+	tokens := make([]string, 0, len(subs))
+	for _, s := range subs {
+		if s.Token != "" && !seen[s.Token] {
+			seen[s.Token] = true
+			tokens = append(tokens, s.Token)
+		}
+	}
+	// A stable order keeps batch numbers meaningful when a failed announcement is retried
+	slices.Sort(tokens)
+
+	ledger, err := newBatchLedger(ctx, n.deliveries, event.Id, "push")
+	if err != nil {
+		return err
+	}
+	var stale []string
+	var errs []error
+	for index, start := 0, 0; start < len(tokens); index, start = index+1, start+maxTokensPerMulticast {
+		if ledger.delivered(index) {
+			continue
+		}
+		batch := tokens[start:min(start+maxTokensPerMulticast, len(tokens))]
+		resp, err := n.sender.SendEachForMulticast(ctx, pushMessage(event, batch))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// Per-token failures are not retried: resending the batch would push the devices that succeeded again
+		if err := ledger.record(ctx, index); err != nil {
+			errs = append(errs, err)
+		}
+		for i, r := range resp.Responses {
+			if r.Success {
+				continue
+			}
+			if messaging.IsUnregistered(r.Error) {
+				stale = append(stale, batch[i])
+			} else if len(errs) == 0 {
+				// One representative failure is enough for the log
+				errs = append(errs, fmt.Errorf("fcm: %d of %d pushes failed: %w", resp.FailureCount, len(batch), r.Error))
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := n.subscriptions.DeletePushTokens(ctx, stale); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func pushMessage(event *domain.Event, tokens []string) *messaging.MulticastMessage {
+	return &messaging.MulticastMessage{
+		Tokens: tokens,
+		Notification: &messaging.Notification{
+			Title: "New in " + event.City,
+			Body:  event.EventName + " · " + formatStart(event),
+		},
+		// Clients open the event from the data payload
+		Data: map[string]string{
+			"event_id": event.Id,
+			"slug":     event.Slug,
+		},
+	}
+}
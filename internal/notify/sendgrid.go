@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SendGridGlobalBaseURL is the default API host; EU subusers use https://api.eu.sendgrid.com
+const SendGridGlobalBaseURL = "https://api.sendgrid.com"
+
+type sendGridMailer struct {
+	apiKey  string
+	from    string
+	baseURL string
+	client  *http.Client
+}
+
+// NewSendGridMailer sends through the SendGrid v3 API; each recipient gets its own personalization.
+// baseURL selects the region; empty means SendGridGlobalBaseURL.
+func NewSendGridMailer(apiKey, from, baseURL string) Mailer {
+	if baseURL == "" {
+		baseURL = SendGridGlobalBaseURL
+	}
+	return &sendGridMailer{
+		apiKey:  apiKey,
+		from:    from,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridMessage struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (m *sendGridMailer) Send(ctx context.Context, email Email) error {
+	msg := sendGridMessage{
+		From:    sendGridAddress{Email: m.from},
+		Subject: email.Subject,
+		// SendGrid requires text/plain before text/html
+		Content: []sendGridContent{{Type: "text/plain", Value: email.Text}, {Type: "text/html", Value: email.HTML}},
+	}
+	for _, to := range email.To {
+		msg.Personalizations = append(msg.Personalizations, sendGridPersonalization{To: []sendGridAddress{{Email: to}}})
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.baseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return doProviderRequest(m.client, req, "sendgrid")
+}
+
+// doProviderRequest sends req and turns a non-2xx answer into an error carrying the start of the body
+func doProviderRequest(client *http.Client, req *http.Request, provider string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: unexpected status %d: %s", provider, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
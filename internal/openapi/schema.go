@@ -0,0 +1,377 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Schemas derives schemas from Go types and collects the named ones as components, which the
+// derived schemas $ref
+type Schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+	declared   map[reflect.Type]*Schema // by Enum and Named
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		declared:   make(map[reflect.Type]*Schema),
+	}
+}
+
+// Enum declares the values of a named string type; schemas of the type become a component listing them
+func Enum[T ~string](s *Schemas, values []T) {
+	enum := make([]any, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, string(v))
+	}
+	s.declared[reflect.TypeFor[T]()] = &Schema{Type: "string", Enum: enum}
+}
+
+// Named declares a named string type whose values are not fixed by the build (e.g. domain.EventType,
+// configured per deployment); schemas of the type become a string component with the description
+// and example, and no enum for clients to hard-code
+func Named[T ~string](s *Schemas, description string, example T) {
+	s.declared[reflect.TypeFor[T]()] = &Schema{Type: "string", Description: description, Example: string(example)}
+}
+
+// Components returns the named schemas derived so far, keyed by component name
+func (s *Schemas) Components() map[string]*Schema {
+	return s.components
+}
+
+// For returns the schema of the type of v; nil gives nil
+func (s *Schemas) For(v any) *Schema {
+	if v == nil {
+		return nil
+	}
+	return s.ofType(reflect.TypeOf(v))
+}
+
+// Ref returns the $ref of a component
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (s *Schemas) ofType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Custom encodings cannot be derived
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+	if declared, ok := s.declared[t]; ok {
+		return s.component(t, func() *Schema {
+			schema := *declared
+			return &schema
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes byte slices as base64 strings
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: s.ofType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.ofType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return s.component(t, func() *Schema { return s.structSchema(t) })
+	}
+	// Interfaces (and anything else) may hold any value
+	return &Schema{}
+}
+
+// component registers the schema of the named type t once and returns its $ref
+func (s *Schemas) component(t reflect.Type, build func() *Schema) *Schema {
+	if name, ok := s.names[t]; ok {
+		return Ref(name)
+	}
+	name := componentName(t)
+	if _, taken := s.components[name]; taken {
+		name = packagePrefix(t) + name
+	}
+	s.names[t] = name
+	// Registered before it is built, so recursive types end in a $ref
+	s.components[name] = &Schema{}
+	*s.components[name] = *build()
+	return Ref(name)
+}
+
+// componentName is the type's name; types outside the domain package get their package as a
+// prefix (graphql.Request becomes GraphqlRequest)
+func componentName(t reflect.Type) string {
+	name := alphanumeric(t.Name())
+	if path.Base(t.PkgPath()) == "domain" {
+		return name
+	}
+	return packagePrefix(t) + name
+}
+
+func packagePrefix(t reflect.Type) string {
+	pkg := alphanumeric(path.Base(t.PkgPath()))
+	if pkg == "" {
+		return ""
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:]
+}
+
+// alphanumeric drops what component names may not contain, e.g. the brackets of generic types
+func alphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func (s *Schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	// Request DTOs say what is required in validate tags; in other types, every field encoding/json
+	// always writes is required
+	s.addFields(schema, t, hasValidateTags(t))
+	return schema
+}
+
+func (s *Schemas) addFields(schema *Schema, t reflect.Type, validated bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			// Fields of embedded structs are promoted, as encoding/json does
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.addFields(schema, ft, validated)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+		omitted := hasOption(opts, "omitempty") || hasOption(opts, "omitzero")
+
+		prop := s.ofType(ft)
+		if hasOption(opts, "string") {
+			prop = &Schema{Type: "string"}
+		}
+		required := applyValidate(prop, ft, f.Tag.Get("validate"))
+		if example, ok := f.Tag.Lookup("example"); ok {
+			prop = withExample(prop, ft, example)
+		}
+		if !omitted && nullable(ft) {
+			prop = asNullable(prop)
+		}
+		schema.Properties[name] = prop
+		if (validated && required) || (!validated && !omitted) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// nullable reports whether encoding/json writes null for the zero value of t
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Slice:
+		return t != rawMessageType
+	}
+	return false
+}
+
+func asNullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+		return s
+	case nil:
+		if s.Ref != "" {
+			return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+		}
+	}
+	// Untyped schemas accept null already
+	return s
+}
+
+func withExample(s *Schema, t reflect.Type, example string) *Schema {
+	if s.Ref != "" {
+		// Siblings of $ref are allowed in 3.1; the example then documents the use site
+		s = &Schema{Ref: s.Ref}
+	}
+	switch kind := underlying(t).Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Float64:
+		if n, err := strconv.ParseFloat(example, 64); err == nil {
+			s.Example = n
+			return s
+		}
+	case kind == reflect.Bool:
+		if b, err := strconv.ParseBool(example); err == nil {
+			s.Example = b
+			return s
+		}
+	}
+	s.Example = example
+	return s
+}
+
+func underlying(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func hasOption(opts, option string) bool {
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func hasValidateTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("validate"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// applyValidate adds the constraints of a validate tag to s (bounds, enums, formats) and
+// reports whether it requires the field. Rules after "dive" apply to elements and are ignored.
+func applyValidate(s *Schema, t reflect.Type, tag string) (required bool) {
+	t = underlying(t)
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "oneof":
+			if s.Ref == "" {
+				for _, v := range strings.Fields(param) {
+					s.Enum = append(s.Enum, enumValue(t, v))
+				}
+			}
+		case "min", "gte":
+			setBound(s, t, param, true)
+		case "max", "lte":
+			setBound(s, t, param, false)
+		case "len":
+			setBound(s, t, param, true)
+			setBound(s, t, param, false)
+		case "email":
+			s.Format = "email"
+		case "url", "uri", "http_url":
+			s.Format = "uri"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "datetime":
+			switch param {
+			case time.RFC3339:
+				s.Format = "date-time"
+			case time.DateOnly:
+				s.Format = "date"
+			}
+		}
+	}
+	return required
+}
+
+func enumValue(t reflect.Type, v string) any {
+	if k := t.Kind(); k >= reflect.Int && k <= reflect.Float64 {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+func setBound(s *Schema, t reflect.Type, param string, lower bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil || s.Ref != "" {
+		return
+	}
+	switch k := t.Kind(); {
+	case k == reflect.String:
+		if lower {
+			s.MinLength = ptr(int(n))
+		} else {
+			s.MaxLength = ptr(int(n))
+		}
+	case k == reflect.Slice || k == reflect.Array || k == reflect.Map:
+		if lower {
+			s.MinItems = ptr(int(n))
+		} else {
+			s.MaxItems = ptr(int(n))
+		}
+	case k >= reflect.Int && k <= reflect.Float64:
+		if lower {
+			s.Minimum = ptr(n)
+		} else {
+			s.Maximum = ptr(n)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
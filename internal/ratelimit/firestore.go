@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectionRateLimits holds one document per bucket. Enable a TTL policy on "expires_at" to purge idle buckets.
+const CollectionRateLimits = "rate_limits"
+
+type bucketDoc struct {
+	Tokens    float64   `firestore:"tokens"`
+	UpdatedAt time.Time `firestore:"updated_at"`
+	ExpiresAt time.Time `firestore:"expires_at"`
+}
+
+type firestoreLimiter struct {
+	client *firestore.Client
+	rate   float64
+	burst  int
+}
+
+// NewFirestoreLimiter creates a limiter whose buckets are shared by all instances.
+// Every request costs a transaction, so use it for low-volume endpoints or when the in-memory limit is not enough.
+func NewFirestoreLimiter(client *firestore.Client, rate float64, burst int) Limiter {
+	return &firestoreLimiter{client: client, rate: rate, burst: burst}
+}
+
+func (l *firestoreLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	// Keys contain IPs and UIDs; hash them into a valid document Id
+	sum := sha256.Sum256([]byte(key))
+	ref := l.client.Collection(CollectionRateLimits).Doc(hex.EncodeToString(sum[:]))
+
+	var allowed bool
+	var wait time.Duration
+	err := l.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		now := time.Now().UTC()
+		state := bucketDoc{Tokens: float64(l.burst), UpdatedAt: now}
+
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := doc.DataTo(&state); err != nil {
+				return err
+			}
+		}
+
+		state.Tokens, allowed, wait = take(state.Tokens, state.UpdatedAt, now, l.rate, l.burst)
+		state.UpdatedAt = now
+		// Once idle long enough to refill, the bucket equals a fresh one and may be deleted
+		state.ExpiresAt = now.Add(time.Duration(float64(l.burst) / l.rate * float64(time.Second)))
+		return tx.Set(ref, state)
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return allowed, wait, nil
+}
@@ -0,0 +1,76 @@
+// Package ratelimit implements token-bucket rate limiting with in-memory and Firestore backends.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter takes one token from the bucket identified by key.
+// When the bucket is empty it reports how long until the next token is available.
+type Limiter interface {
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// take refills a bucket holding tokens (last updated at last) and tries to consume one token
+func take(tokens float64, last, now time.Time, rate float64, burst int) (float64, bool, time.Duration) {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens = math.Min(float64(burst), tokens+elapsed*rate)
+	}
+	if tokens >= 1 {
+		return tokens - 1, true, 0
+	}
+	wait := time.Duration((1 - tokens) / rate * float64(time.Second))
+	return tokens, false, wait
+}
+
+// maxMemoryBuckets bounds the memory used by idle keys; full buckets are evicted first
+const maxMemoryBuckets = 10000
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type memoryLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   int
+	buckets map[string]*bucket
+}
+
+// NewMemoryLimiter creates a per-instance limiter allowing rate requests per second with bursts of up to burst.
+// Each Cloud Functions instance keeps its own buckets, so the effective limit scales with the instance count.
+func NewMemoryLimiter(rate float64, burst int) Limiter {
+	return &memoryLimiter{rate: rate, burst: burst, buckets: make(map[string]*bucket)}
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxMemoryBuckets {
+			l.evictFull(now)
+		}
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	tokens, allowed, wait := take(b.tokens, b.last, now, l.rate, l.burst)
+	b.tokens, b.last = tokens, now
+	return allowed, wait, nil
+}
+
+// evictFull drops buckets that have refilled completely; they are indistinguishable from new ones
+func (l *memoryLimiter) evictFull(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
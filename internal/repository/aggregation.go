@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+)
+
+// aggregateNumber extracts a numeric value from a Firestore aggregation result.
+// SUM returns an integer when every summed value is an integer and a double otherwise.
+func aggregateNumber(result firestore.AggregationResult, alias string) float64 {
+	v, ok := result[alias].(*firestorepb.Value)
+	if !ok || v == nil {
+		return 0
+	}
+	switch val := v.GetValueType().(type) {
+	case *firestorepb.Value_IntegerValue:
+		return float64(val.IntegerValue)
+	case *firestorepb.Value_DoubleValue:
+		return val.DoubleValue
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const CollectionAnnouncements = "announcements"
+
+// AnnouncementRepository keeps one document per announced event, keyed by the event Id
+type AnnouncementRepository interface {
+	// Delivered returns the batch keys already delivered for the event
+	Delivered(ctx context.Context, eventID string) (map[string]bool, error)
+	// MarkDelivered records a delivered batch
+	MarkDelivered(ctx context.Context, eventID, batch string) error
+}
+
+type announcementRepo struct {
+	client *firestore.Client
+}
+
+func NewAnnouncementRepository(client *firestore.Client) AnnouncementRepository {
+	return &announcementRepo{client: client}
+}
+
+func (r *announcementRepo) Delivered(ctx context.Context, eventID string) (map[string]bool, error) {
+	doc, err := r.client.Collection(CollectionAnnouncements).Doc(eventID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a domain.Announcement
+	if err := doc.DataTo(&a); err != nil {
+		return nil, err
+	}
+	delivered := make(map[string]bool, len(a.Delivered))
+	for _, batch := range a.Delivered {
+		delivered[batch] = true
+	}
+	return delivered, nil
+}
+
+// MarkDelivered appends with ArrayUnion so batches of different channels finishing together are all kept
+func (r *announcementRepo) MarkDelivered(ctx context.Context, eventID, batch string) error {
+	_, err := r.client.Collection(CollectionAnnouncements).Doc(eventID).Set(ctx, map[string]interface{}{
+		"event_id":   eventID,
+		"delivered":  firestore.ArrayUnion(batch),
+		"updated_at": time.Now().UTC(),
+	}, firestore.MergeAll)
+	return err
+}
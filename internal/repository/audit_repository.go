@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+const CollectionAuditLogs = "audit_logs"
+
+type AuditRepository interface {
+	SaveAuditEntry(ctx context.Context, entry *domain.AuditEntry) error
+	ListAuditEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type auditRepo struct {
+	client *firestore.Client
+}
+
+func NewAuditRepository(client *firestore.Client) AuditRepository {
+	return &auditRepo{client: client}
+}
+
+func (r *auditRepo) SaveAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	_, err := r.client.Collection(CollectionAuditLogs).Doc(entry.Id).Set(ctx, entry)
+	return err
+}
+
+// ListAuditEntries returns matching entries, newest first
+func (r *auditRepo) ListAuditEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	q := r.client.Collection(CollectionAuditLogs).Query
+	if filter.Actor != "" {
+		q = q.Where("actor", "==", filter.Actor)
+	}
+	if filter.Resource != "" {
+		q = q.Where("resource", "==", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		q = q.Where("resource_id", "==", filter.ResourceID)
+	}
+	iter := q.OrderBy("created_at", firestore.Desc).Limit(filter.Limit).Documents(ctx)
+	defer iter.Stop()
+
+	entries := []domain.AuditEntry{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry domain.AuditEntry
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	apiv1admin "cloud.google.com/go/firestore/apiv1/admin"
+	"cloud.google.com/go/firestore/apiv1/admin/adminpb"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BackupRepository exports and imports the database with Firestore's managed export (to Cloud
+// Storage) and dumps or restores DumpCollections document by document
+type BackupRepository interface {
+	// Export starts a managed export of collections (every collection when empty) to outputURI
+	Export(ctx context.Context, outputURI string, collections []string) (*domain.BackupOperation, error)
+	// Import starts a managed import of collections (all exported ones when empty) from inputURI
+	Import(ctx context.Context, inputURI string, collections []string) (*domain.BackupOperation, error)
+	// Operation returns the current state of an export or import started by this database
+	Operation(ctx context.Context, id string) (*domain.BackupOperation, error)
+	// Dump calls fn for every document of collections, in no particular order
+	Dump(ctx context.Context, collections []string, fn func(domain.BackupRecord) error) error
+	// Restore writes records and returns an error per record that was not written, keyed by index.
+	// Without overwrite, records whose document exists fail with a *domain.ConflictError.
+	Restore(ctx context.Context, records []domain.BackupRecord, overwrite bool) map[int]error
+}
+
+type backupRepo struct {
+	client   *firestore.Client
+	admin    *apiv1admin.FirestoreAdminClient
+	database string // projects/{project}/databases/{database}
+}
+
+// NewBackupRepository backs up the database of client. admin may be nil, leaving only dumps and
+// restores: managed exports and imports then fail.
+func NewBackupRepository(client *firestore.Client, admin *apiv1admin.FirestoreAdminClient, projectID, databaseID string) BackupRepository {
+	if databaseID == "" {
+		databaseID = firestore.DefaultDatabaseID
+	}
+	return &backupRepo{client: client, admin: admin, database: "projects/" + projectID + "/databases/" + databaseID}
+}
+
+var errNoAdminClient = errors.New("managed exports are not configured")
+
+func (r *backupRepo) Export(ctx context.Context, outputURI string, collections []string) (*domain.BackupOperation, error) {
+	if r.admin == nil {
+		return nil, errNoAdminClient
+	}
+	op, err := r.admin.ExportDocuments(ctx, &adminpb.ExportDocumentsRequest{
+		Name:            r.database,
+		CollectionIds:   collections,
+		OutputUriPrefix: outputURI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.Operation(ctx, operationID(op.Name()))
+}
+
+func (r *backupRepo) Import(ctx context.Context, inputURI string, collections []string) (*domain.BackupOperation, error) {
+	if r.admin == nil {
+		return nil, errNoAdminClient
+	}
+	op, err := r.admin.ImportDocuments(ctx, &adminpb.ImportDocumentsRequest{
+		Name:           r.database,
+		CollectionIds:  collections,
+		InputUriPrefix: inputURI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.Operation(ctx, operationID(op.Name()))
+}
+
+func (r *backupRepo) Operation(ctx context.Context, id string) (*domain.BackupOperation, error) {
+	if r.admin == nil {
+		return nil, errNoAdminClient
+	}
+	if id == "" || strings.Contains(id, "/") {
+		return nil, domain.ErrNotFound("operation not found")
+	}
+	op, err := r.admin.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: r.database + "/operations/" + id})
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrNotFound("operation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return backupOperation(op)
+}
+
+// operationID is the last segment of an operation name
+func operationID(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// backupMetadata is what export and import operations both report
+type backupMetadata interface {
+	GetOperationState() adminpb.OperationState
+	GetProgressDocuments() *adminpb.Progress
+	GetStartTime() *timestamppb.Timestamp
+	GetEndTime() *timestamppb.Timestamp
+	GetCollectionIds() []string
+}
+
+// backupOperation reads the metadata of an export or import operation
+func backupOperation(op *longrunningpb.Operation) (*domain.BackupOperation, error) {
+	result := &domain.BackupOperation{Id: operationID(op.GetName()), Done: op.GetDone()}
+	if e := op.GetError(); e != nil {
+		result.Error = e.GetMessage()
+	}
+	if op.GetMetadata() == nil {
+		return nil, domain.ErrNotFound("operation not found")
+	}
+	meta, err := op.GetMetadata().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("operation %s metadata: %w", result.Id, err)
+	}
+	var m backupMetadata
+	switch meta := meta.(type) {
+	case *adminpb.ExportDocumentsMetadata:
+		result.Kind, result.URI, m = domain.BackupExport, meta.GetOutputUriPrefix(), meta
+	case *adminpb.ImportDocumentsMetadata:
+		result.Kind, result.URI, m = domain.BackupImport, meta.GetInputUriPrefix(), meta
+	default:
+		// Index builds and other operations of the database are not backups
+		return nil, domain.ErrNotFound("operation not found")
+	}
+	result.State = m.GetOperationState().String()
+	result.Collections = m.GetCollectionIds()
+	result.Documents = m.GetProgressDocuments().GetCompletedWork()
+	result.Estimated = m.GetProgressDocuments().GetEstimatedWork()
+	// AsTime of an unset timestamp would be the Unix epoch
+	if ts := m.GetStartTime(); ts != nil {
+		result.StartTime = ts.AsTime()
+	}
+	if ts := m.GetEndTime(); ts != nil {
+		result.EndTime = ts.AsTime()
+	}
+	return result, nil
+}
+
+func (r *backupRepo) Dump(ctx context.Context, collections []string, fn func(domain.BackupRecord) error) error {
+	for _, name := range collections {
+		iter := tenantCollection(ctx, r.client, name).Documents(ctx)
+		err := func() error {
+			defer iter.Stop()
+			for {
+				doc, err := iter.Next()
+				if errors.Is(err, iterator.Done) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				record := domain.BackupRecord{Collection: name, Id: doc.Ref.ID}
+				switch name {
+				case CollectionEvents:
+					record.Event = &domain.Event{}
+					err = doc.DataTo(record.Event)
+					record.Event.Id = doc.Ref.ID
+				case CollectionTracking:
+					record.Tracking = &domain.TrackingEvent{}
+					err = doc.DataTo(record.Tracking)
+					record.Tracking.Id = doc.Ref.ID
+				default:
+					return fmt.Errorf("collection %s cannot be dumped", name)
+				}
+				if err != nil {
+					return fmt.Errorf("%s/%s: %w", name, doc.Ref.ID, err)
+				}
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *backupRepo) Restore(ctx context.Context, records []domain.BackupRecord, overwrite bool) map[int]error {
+	bw := r.client.BulkWriter(ctx)
+	failed := make(map[int]error)
+	jobs := make([]*firestore.BulkWriterJob, len(records))
+	for i, record := range records {
+		ref := tenantCollection(ctx, r.client, record.Collection).Doc(record.Id)
+		var data interface{} = record.Tracking
+		if record.Event != nil {
+			record.Event.Normalize()
+			data = record.Event
+		}
+		var job *firestore.BulkWriterJob
+		var err error
+		if overwrite {
+			job, err = bw.Set(ref, data)
+		} else {
+			job, err = bw.Create(ref, data)
+		}
+		if err != nil {
+			failed[i] = err
+			continue
+		}
+		jobs[i] = job
+	}
+	bw.End()
+
+	for i, job := range jobs {
+		if job == nil {
+			continue
+		}
+		if _, err := job.Results(); status.Code(err) == codes.AlreadyExists {
+			failed[i] = domain.ErrConflict(fmt.Sprintf("%s/%s already exists", records[i].Collection, records[i].Id))
+		} else if err != nil {
+			failed[i] = err
+		}
+	}
+	return failed
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/cache"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/metrics"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Entries hold domain.Event as JSON; the v2 keys came with its snake_case json tags, so entries
+// written by older instances are not read back with empty fields
+const (
+	cacheKeyEvent = "event:v2:"
+	// cacheKeyListGen is bumped on every write; list entries are keyed by it, so a write orphans
+	// all cached lists at once and they expire with their TTL
+	cacheKeyListGen = "events:list:gen"
+	cacheKeyList    = "events:list:v2:"
+)
+
+// cachedList is the stored form of a List result
+type cachedList struct {
+	Events []domain.Event
+	Meta   domain.Meta
+}
+
+// cachedEventRepo is a cache-aside decorator for the public browse reads: GetByID and first pages
+// of List. Writes through it invalidate the cache; writes that bypass it (the RSVP and favorite
+// counters) show once the TTL expires. A failing store is logged and skipped, so Redis being
+// down costs Firestore reads, not availability.
+type cachedEventRepo struct {
+	EventRepository
+	store cache.Store
+	ttl   time.Duration
+}
+
+// NewCachedEventRepository caches next's GetByID and first-page List results in store for ttl
+func NewCachedEventRepository(next EventRepository, store cache.Store, ttl time.Duration) EventRepository {
+	return &cachedEventRepo{EventRepository: next, store: store, ttl: ttl}
+}
+
+// storeFor keeps each tenant's entries and list generation apart from the others'
+func (r *cachedEventRepo) storeFor(ctx context.Context) cache.Store {
+	if tenantID := domain.TenantFromContext(ctx); tenantID != "" {
+		return cache.WithPrefix(r.store, "tenant:"+tenantID+":")
+	}
+	return r.store
+}
+
+func (r *cachedEventRepo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
+	key := cacheKeyEvent + id
+	var event domain.Event
+	if r.lookup(ctx, "events.get", key, &event) {
+		return &event, nil
+	}
+	found, err := r.EventRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err // not found is not cached: the event may be created any moment
+	}
+	r.fill(ctx, key, found)
+	return found, nil
+}
+
+// List caches first pages only; later pages are keyed by one-off tokens and rarely repeat
+func (r *cachedEventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	if search.Sorting.PageToken != "" {
+		return r.EventRepository.List(ctx, search)
+	}
+	key, ok := r.listKey(ctx, search)
+	var cached cachedList
+	if ok && r.lookup(ctx, "events.list", key, &cached) {
+		return cached.Events, cached.Meta, nil
+	}
+	events, meta, err := r.EventRepository.List(ctx, search)
+	if err != nil {
+		return nil, domain.Meta{}, err
+	}
+	if ok {
+		r.fill(ctx, key, cachedList{Events: events, Meta: meta})
+	}
+	return events, meta, nil
+}
+
+// listKey derives the cache key of a List request under the current list generation.
+// ok is false when the generation cannot be read, so nothing is cached under a stale one.
+func (r *cachedEventRepo) listKey(ctx context.Context, search domain.SearchRequest) (string, bool) {
+	gen, found, err := r.storeFor(ctx).Get(ctx, cacheKeyListGen)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "event cache unavailable", "error", err)
+		return "", false
+	}
+	if !found {
+		gen = []byte("0")
+	}
+	spec, _ := json.Marshal(search)
+	sum := sha256.Sum256(spec)
+	return cacheKeyList + string(gen) + ":" + hex.EncodeToString(sum[:]), true
+}
+
+// lookup decodes the entry at key into v and reports whether it was found
+func (r *cachedEventRepo) lookup(ctx context.Context, op, key string, v interface{}) bool {
+	data, found, err := r.storeFor(ctx).Get(ctx, key)
+	switch {
+	case err != nil:
+		logging.FromContext(ctx).WarnContext(ctx, "event cache unavailable", "error", err)
+		metrics.CacheRequests.Inc(op, "error")
+		return false
+	case !found || json.Unmarshal(data, v) != nil:
+		metrics.CacheRequests.Inc(op, "miss")
+		return false
+	}
+	metrics.CacheRequests.Inc(op, "hit")
+	return true
+}
+
+func (r *cachedEventRepo) fill(ctx context.Context, key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = r.storeFor(ctx).Set(ctx, key, data, r.ttl)
+	}
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "event cache unavailable", "error", err)
+	}
+}
+
+// invalidate drops the cached events and every cached list. It runs after the write, so a read
+// racing the write can at worst re-cache the old value until the TTL.
+func (r *cachedEventRepo) invalidate(ctx context.Context, ids ...string) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = cacheKeyEvent + id
+	}
+	store := r.storeFor(ctx)
+	if err := store.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "event cache unavailable", "error", err)
+	}
+	if _, err := store.Incr(ctx, cacheKeyListGen); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "event cache unavailable", "error", err)
+	}
+}
+
+func (r *cachedEventRepo) Save(ctx context.Context, event *domain.Event) error {
+	err := r.EventRepository.Save(ctx, event)
+	r.invalidate(ctx, event.Id)
+	return err
+}
+
+func (r *cachedEventRepo) SaveUnique(ctx context.Context, event *domain.Event) (string, error) {
+	existingID, err := r.EventRepository.SaveUnique(ctx, event)
+	if err == nil && existingID == "" {
+		r.invalidate(ctx, event.Id)
+	}
+	return existingID, err
+}
+
+// BatchSave invalidates every event of the batch, including those that failed
+func (r *cachedEventRepo) BatchSave(ctx context.Context, events []*domain.Event) error {
+	err := r.EventRepository.BatchSave(ctx, events)
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.Id
+	}
+	r.invalidate(ctx, ids...)
+	return err
+}
+
+func (r *cachedEventRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	err := r.EventRepository.Update(ctx, id, updates)
+	r.invalidate(ctx, id)
+	return err
+}
+
+func (r *cachedEventRepo) UpdateInTransaction(ctx context.Context, id string, fn EventUpdateFunc) error {
+	err := r.EventRepository.UpdateInTransaction(ctx, id, fn)
+	r.invalidate(ctx, id)
+	return err
+}
+
+func (r *cachedEventRepo) Delete(ctx context.Context, id string) error {
+	err := r.EventRepository.Delete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// ArchivePastEvents does not report which events moved, so only the lists are invalidated;
+// archived events stay readable by Id until their entries expire
+func (r *cachedEventRepo) ArchivePastEvents(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	moved, err := r.EventRepository.ArchivePastEvents(ctx, cutoff, limit)
+	if moved > 0 {
+		r.invalidate(ctx)
+	}
+	return moved, err
+}
+
+// SetPopularity reorders popularity lists, so they are invalidated; events read by Id keep their
+// previous score until their entries expire
+func (r *cachedEventRepo) SetPopularity(ctx context.Context, scores map[string]float64) (int, int, error) {
+	scored, reset, err := r.EventRepository.SetPopularity(ctx, scores)
+	if scored+reset > 0 {
+		r.invalidate(ctx)
+	}
+	return scored, reset, err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/option"
+)
+
+// ClientPool holds one Firestore client per database of a project, so one deployment can serve
+// several databases (staging and production, or one per tenant) over shared credentials.
+// Clients are opened on first use and kept for the life of the instance.
+type ClientPool struct {
+	projectID string
+	opts      []option.ClientOption
+
+	mu      sync.Mutex
+	clients map[string]*firestore.Client
+}
+
+// NewClientPool returns an empty pool for projectID; opts apply to every client it opens
+func NewClientPool(projectID string, opts ...option.ClientOption) *ClientPool {
+	return &ClientPool{projectID: projectID, opts: opts, clients: make(map[string]*firestore.Client)}
+}
+
+// Client returns the client of databaseID, opening it on first use. "" is the default database.
+func (p *ClientPool) Client(ctx context.Context, databaseID string) (*firestore.Client, error) {
+	if databaseID == "" {
+		databaseID = firestore.DefaultDatabaseID
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[databaseID]; ok {
+		return client, nil
+	}
+	client, err := firestore.NewClientWithDatabase(ctx, p.projectID, databaseID, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[databaseID] = client
+	return client, nil
+}
+
+// Close closes every client opened by the pool
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for id, client := range p.clients {
+		errs = append(errs, client.Close())
+		delete(p.clients, id)
+	}
+	return errors.Join(errs...)
+}
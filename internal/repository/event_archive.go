@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+const (
+	// CollectionEventsArchive holds events moved out of the hot collection
+	CollectionEventsArchive = "events_archive"
+
+	// archiveChunk is the number of events moved per batch (copy + delete = 2 writes each)
+	archiveChunk = 250
+)
+
+// ArchivePastEvents moves up to limit events that ended before cutoff to the archive collection.
+// Events without an end_time are judged by their start_time. Returns the number of moved events.
+func (r *eventRepo) ArchivePastEvents(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+	queries := []firestore.Query{
+		coll.Where("end_time", ">", time.Time{}).Where("end_time", "<", cutoff),
+		coll.Where("end_time", "==", time.Time{}).Where("start_time", "<", cutoff),
+	}
+
+	moved := 0
+	for _, q := range queries {
+		for moved < limit {
+			docs, err := q.Limit(min(archiveChunk, limit-moved)).Documents(ctx).GetAll()
+			if err != nil {
+				return moved, err
+			}
+			if len(docs) == 0 {
+				break
+			}
+
+			batch := r.client.Batch()
+			archivedAt := time.Now().UTC()
+			for _, doc := range docs {
+				data := doc.Data()
+				data["archived_at"] = archivedAt
+				batch.Set(tenantCollection(ctx, r.client, CollectionEventsArchive).Doc(doc.Ref.ID), data)
+				batch.Delete(doc.Ref)
+			}
+			if _, err := batch.Commit(ctx); err != nil {
+				return moved, err
+			}
+			moved += len(docs)
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// IndexField is one field of a composite index in firestore.indexes.json
+type IndexField struct {
+	FieldPath   string `json:"fieldPath"`
+	Order       string `json:"order,omitempty"`
+	ArrayConfig string `json:"arrayConfig,omitempty"`
+}
+
+// CompositeIndex is an entry of the "indexes" array in firestore.indexes.json
+type CompositeIndex struct {
+	CollectionGroup string       `json:"collectionGroup"`
+	QueryScope      string       `json:"queryScope"`
+	Fields          []IndexField `json:"fields"`
+}
+
+// Key identifies the index by collection and fields, for de-duplication and stable ordering
+func (c CompositeIndex) Key() string {
+	parts := []string{c.CollectionGroup, c.QueryScope}
+	for _, f := range c.Fields {
+		parts = append(parts, f.FieldPath+":"+f.Order+f.ArrayConfig)
+	}
+	return strings.Join(parts, "|")
+}
+
+// fixedEventIndexes cover the event queries outside List and Stream
+var fixedEventIndexes = []CompositeIndex{
+	// ListFeatured: featured == true, featured_until > now
+	eventIndex(IndexField{"featured", "ASCENDING", ""}, IndexField{"featured_until", "ASCENDING", ""}),
+	// ArchivePastEvents, events without an end: end_time == zero, start_time < cutoff
+	eventIndex(IndexField{"end_time", "ASCENDING", ""}, IndexField{"start_time", "ASCENDING", ""}),
+}
+
+// IndexOptions narrows the List queries indexes are generated for
+type IndexOptions struct {
+	SortKeys        []string // sort keys clients may request; empty means every key List accepts
+	MaxRangeFilters int      // most range filters combined in one query; 0 means no limit
+	CollectionGroup bool     // indexes for a repository built WithCollectionGroup
+}
+
+// EventIndexes returns the composite indexes needed by the events collection: one for every
+// filter and sort combination List and Stream can issue within opts, plus the fixed queries.
+// The result is de-duplicated and sorted, so it can be compared with firestore.indexes.json.
+func EventIndexes(opts IndexOptions) []CompositeIndex {
+	seen := make(map[string]bool)
+	var indexes []CompositeIndex
+	add := func(idx CompositeIndex) {
+		if opts.CollectionGroup {
+			idx.QueryScope = "COLLECTION_GROUP"
+		}
+		if len(idx.Fields) < 2 || seen[idx.Key()] {
+			return // single-field queries are served by the automatic indexes
+		}
+		seen[idx.Key()] = true
+		indexes = append(indexes, idx)
+	}
+
+	sortKeys := opts.SortKeys
+	if len(sortKeys) == 0 {
+		sortKeys = listSortKeys
+	}
+	for _, search := range listSearchVariants(sortKeys) {
+		plan := planListQuery(search)
+		if opts.MaxRangeFilters > 0 && rangeFilterCount(search.Filters) > opts.MaxRangeFilters {
+			continue
+		}
+		// Rejected by the event service
+		if search.Sorting.SortKey == domain.SortPopularity && search.Filters.HasRangeFilter() {
+			continue
+		}
+		// Firestore merges per-field indexes to serve several equality filters at once,
+		// so only one equality field per index is needed
+		if len(plan.equalityFields) > 1 {
+			continue
+		}
+		add(listIndex(plan))
+	}
+	for _, idx := range fixedEventIndexes {
+		add(idx)
+	}
+
+	slices.SortFunc(indexes, func(a, b CompositeIndex) int {
+		return strings.Compare(a.Key(), b.Key())
+	})
+	return indexes
+}
+
+// rangeFilterCount counts the filters that constrain a range (text prefixes, price, dates)
+func rangeFilterCount(f domain.FilterRequest) int {
+	n := 0
+	for _, set := range []bool{f.EventName != "", f.City != "", f.MinPrice != nil || f.MaxPrice != nil, f.StartDate != nil || f.EndDate != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// listIndex lays out the index a planned query needs: equality filters, then the explicit
+// orders, then the range fields Firestore orders implicitly in the direction of the last order
+func listIndex(plan listPlan) CompositeIndex {
+	order := func(dir firestore.Direction) string {
+		if dir == firestore.Desc {
+			return "DESCENDING"
+		}
+		return "ASCENDING"
+	}
+
+	var fields []IndexField
+	for _, field := range plan.equalityFields {
+		fields = append(fields, IndexField{FieldPath: field, Order: "ASCENDING"})
+	}
+	for _, field := range plan.sortFields {
+		dir := plan.direction
+		if field == "id" {
+			dir = firestore.Asc
+		}
+		fields = append(fields, IndexField{FieldPath: field, Order: order(dir)})
+	}
+	for _, field := range plan.rangeFields {
+		fields = append(fields, IndexField{FieldPath: field, Order: "ASCENDING"})
+	}
+	return eventIndex(fields...)
+}
+
+func eventIndex(fields ...IndexField) CompositeIndex {
+	return CompositeIndex{CollectionGroup: CollectionEvents, QueryScope: "COLLECTION", Fields: fields}
+}
+
+// listSearchVariants enumerates every combination of active filters, sort key and direction.
+// Filter values are placeholders: only whether a filter is set affects the query shape.
+func listSearchVariants(sortKeys []string) []domain.SearchRequest {
+	price := 1.0
+	date := time.Unix(0, 0)
+	type toggle func(*domain.FilterRequest)
+	filterOptions := [][]toggle{
+		{nil, func(f *domain.FilterRequest) { f.EventName = "x" }},
+		{nil, func(f *domain.FilterRequest) { f.City = "x" }},
+		{nil, func(f *domain.FilterRequest) { f.MinPrice = &price }},
+		{nil, func(f *domain.FilterRequest) { f.StartDate = &date }},
+		{nil, func(f *domain.FilterRequest) { f.EndDate = &date }},
+		{nil, func(f *domain.FilterRequest) { f.Type = "x" }},
+		{nil, func(f *domain.FilterRequest) { f.OrganizerID = "x" }},
+	}
+
+	filters := []domain.FilterRequest{{}}
+	for _, options := range filterOptions {
+		var next []domain.FilterRequest
+		for _, f := range filters {
+			for _, apply := range options {
+				variant := f
+				if apply != nil {
+					apply(&variant)
+				}
+				next = append(next, variant)
+			}
+		}
+		filters = next
+	}
+
+	var searches []domain.SearchRequest
+	for _, f := range filters {
+		for _, key := range append([]string{""}, sortKeys...) {
+			for _, dir := range []string{"asc", "desc"} {
+				searches = append(searches, domain.SearchRequest{
+					Filters: f,
+					Sorting: domain.SortRequest{SortKey: key, SortDirection: dir},
+				})
+			}
+		}
+	}
+	return searches
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// MigrateLocations moves the coordinates events stored as "latitude"/"longitude" strings into
+// their "location" geopoint, in every events collection (the root one and each tenant's), through
+// a BulkWriter. Both strings are removed once converted. Events whose strings are not valid
+// coordinates are left as they are and counted as invalid. Rerunning finds nothing left to do.
+func MigrateLocations(ctx context.Context, client *firestore.Client) (migrated, invalid int, err error) {
+	bw := client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var errs []error
+	iter := client.CollectionGroup(CollectionEvents).Select("latitude", "longitude").Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		lat, hasLat := doc.Data()["latitude"].(string)
+		lng, hasLng := doc.Data()["longitude"].(string)
+		if !hasLat && !hasLng {
+			continue
+		}
+		location, err := domain.ParseGeoPoint(lat, lng)
+		if err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "location not migrated", "event", doc.Ref.Path, "error", err)
+			invalid++
+			continue
+		}
+		job, err := bw.Update(doc.Ref, []firestore.Update{
+			{Path: "location", Value: location.LatLng()},
+			{Path: "latitude", Value: firestore.Delete},
+			{Path: "longitude", Value: firestore.Delete},
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	iter.Stop()
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		migrated++
+	}
+	return migrated, invalid, errors.Join(errs...)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPopularity writes each score to the popularity_score of its event and resets the score of every
+// other event to 0, through a BulkWriter. Scores of events that no longer exist (e.g. archived) are
+// skipped. Returns how many events were scored and reset.
+//
+// The reset pass reads every event rather than querying popularity_score > 0: events written before
+// the field existed have no popularity_score and would be missing from sort_key=popularity, which
+// orders on the field, so they are backfilled with 0. Archiving keeps the live collection small.
+func (r *eventRepo) SetPopularity(ctx context.Context, scores map[string]float64) (int, int, error) {
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+	bw := r.client.BulkWriter(ctx)
+
+	var scoreJobs, resetJobs []*firestore.BulkWriterJob
+	var errs []error
+	for id, score := range scores {
+		job, err := bw.Update(coll.Doc(id), []firestore.Update{{Path: "popularity_score", Value: score}})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		scoreJobs = append(scoreJobs, job)
+	}
+
+	// Events whose tracking left the window, and events without a score yet
+	iter := coll.Select("popularity_score").Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if _, ok := scores[doc.Ref.ID]; ok {
+			continue
+		}
+		if score, err := doc.DataAt("popularity_score"); err == nil && score != nil && isZero(score) {
+			continue
+		}
+		job, err := bw.Update(doc.Ref, []firestore.Update{{Path: "popularity_score", Value: 0}})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resetJobs = append(resetJobs, job)
+	}
+	iter.Stop()
+	bw.End()
+
+	count := func(jobs []*firestore.BulkWriterJob) int {
+		n := 0
+		for _, job := range jobs {
+			_, err := job.Results()
+			switch {
+			case err == nil:
+				n++
+			case status.Code(err) != codes.NotFound:
+				errs = append(errs, err)
+			}
+		}
+		return n
+	}
+	scored, reset := count(scoreJobs), count(resetJobs)
+	return scored, reset, errors.Join(errs...)
+}
+
+// isZero reports whether a stored score is 0; Firestore returns whole numbers written from Go as int64
+func isZero(v interface{}) bool {
+	switch n := v.(type) {
+	case int64:
+		return n == 0
+	case float64:
+		return n == 0
+	}
+	return false
+}
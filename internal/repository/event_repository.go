@@ -2,11 +2,10 @@ package repository
 
 import (
 	"bibently.com/backend/internal/domain"
+	"cmp"
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"slices"
 	"time"
 
 	"cloud.google.com/go/firestore"
@@ -15,34 +14,142 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const CollectionEvents = "events"
+const (
+	CollectionEvents  = "events"
+	CollectionTenants = "tenants"
+)
 
 type EventRepository interface {
-	List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, string, error)
+	List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error)
+	Stream(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error
 	Delete(ctx context.Context, id string) error
 	GetByID(ctx context.Context, id string) (*domain.Event, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Event, error)
+	GetMulti(ctx context.Context, ids []string) ([]domain.Event, error)
 	Update(ctx context.Context, id string, updates map[string]interface{}) error
+	UpdateInTransaction(ctx context.Context, id string, fn EventUpdateFunc) error
 	Save(ctx context.Context, event *domain.Event) error
 	BatchSave(ctx context.Context, events []*domain.Event) error
+	IncrementViews(ctx context.Context, id string) error
+	GetViewCount(ctx context.Context, id string) (int64, error)
+	// Stats, Summarize and PriceBuckets aggregate server-side; publicOnly leaves out unlisted and
+	// private events
+	Stats(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error)
+	// Summarize counts the events matching the List filters and sums their prices
+	Summarize(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error)
+	PriceBuckets(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error)
+	ListFeatured(ctx context.Context, now time.Time) ([]domain.Event, error)
+	SaveUnique(ctx context.Context, event *domain.Event) (string, error)
+	ArchivePastEvents(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	// SetPopularity stores scores as the popularity_score of their events, resets every other event's
+	// score to 0 and returns how many events were scored and reset
+	SetPopularity(ctx context.Context, scores map[string]float64) (int, int, error)
 }
 
+// EventUpdateFunc computes the fields to write from the event as currently stored. It runs inside
+// a transaction and may be called again if the document changes concurrently, so it must not have
+// side effects. Returning an error (e.g. a failed version or capacity check) aborts without writing;
+// returning no updates commits nothing.
+type EventUpdateFunc func(current *domain.Event) (map[string]interface{}, error)
+
 type eventRepo struct {
-	client *firestore.Client
+	client          *firestore.Client
+	tokens          *pageTokenCodec
+	collectionGroup bool
+	listFields      []string
 }
 
-func NewEventRepository(client *firestore.Client) EventRepository {
-	return &eventRepo{client: client}
+// EventRepositoryOption configures optional behaviour of the event repository
+type EventRepositoryOption func(*eventRepo)
+
+// WithPageTokenSecret sets the key page tokens are sealed with. Every instance must share it;
+// without one, each instance seals with its own random key.
+func WithPageTokenSecret(secret []byte) EventRepositoryOption {
+	return func(r *eventRepo) {
+		r.tokens = newPageTokenCodec(secret)
+	}
+}
+
+// WithCollectionGroup makes the queries (List, Stream, GetBySlug, ListFeatured) read every
+// collection named "events", i.e. the root collection and the per-tenant tenants/{id}/events
+// subcollections. Reads and writes by Id still address the root collection. The queries then need
+// COLLECTION_GROUP indexes (genindexes -collection-group).
+func WithCollectionGroup() EventRepositoryOption {
+	return func(r *eventRepo) {
+		r.collectionGroup = true
+	}
+}
+
+// WithListProjection makes List read only fields (plus the sort fields) when the request names no
+// fieldset of its own, e.g. domain.EventCardFields for browse pages. The page's Meta.Fields reports
+// the projection so callers render only what was read. Stream still reads whole documents.
+func WithListProjection(fields []string) EventRepositoryOption {
+	return func(r *eventRepo) {
+		r.listFields = fields
+	}
+}
+
+// TenantEventsPath is the subcollection holding a tenant's events
+func TenantEventsPath(tenantID string) string {
+	return CollectionTenants + "/" + tenantID + "/" + CollectionEvents
+}
+
+// tenantCollection is the collection name of the tenant in ctx, tenants/{tenant}/{name}, or the
+// root collection outside a tenant
+func tenantCollection(ctx context.Context, client *firestore.Client, name string) *firestore.CollectionRef {
+	if tenantID := domain.TenantFromContext(ctx); tenantID != "" {
+		return client.Collection(CollectionTenants).Doc(tenantID).Collection(name)
+	}
+	return client.Collection(name)
+}
+
+// events is the base of every event query: the events of the tenant in ctx, the root collection or,
+// with WithCollectionGroup and no tenant, all event collections
+func (r *eventRepo) events(ctx context.Context) firestore.Query {
+	if r.collectionGroup && domain.TenantFromContext(ctx) == "" {
+		return r.client.CollectionGroup(CollectionEvents).Query
+	}
+	return tenantCollection(ctx, r.client, CollectionEvents).Query
+}
+
+func NewEventRepository(client *firestore.Client, opts ...EventRepositoryOption) EventRepository {
+	r := &eventRepo{client: client}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.tokens == nil {
+		r.tokens = newPageTokenCodec(nil)
+	}
+	return instrumentedEventRepo{EventRepository: r}
 }
 
 func (r *eventRepo) Delete(ctx context.Context, id string) error {
-	_, err := r.client.Collection(CollectionEvents).Doc(id).Delete(ctx)
+	_, err := tenantCollection(ctx, r.client, CollectionEvents).Doc(id).Delete(ctx)
 	return err
 }
 
 func (r *eventRepo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
-	doc, err := r.client.Collection(CollectionEvents).Doc(id).Get(ctx)
+	doc, err := tenantCollection(ctx, r.client, CollectionEvents).Doc(id).Get(ctx)
 	if status.Code(err) == codes.NotFound {
-		return nil, fmt.Errorf("event not found")
+		return nil, domain.ErrNotFound("event not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var event domain.Event
+	if err := doc.DataTo(&event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *eventRepo) GetBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	iter := r.events(ctx).Where("slug", "==", slug).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, domain.ErrNotFound("event not found")
 	}
 	if err != nil {
 		return nil, err
@@ -54,23 +161,153 @@ func (r *eventRepo) GetByID(ctx context.Context, id string) (*domain.Event, erro
 	return &event, nil
 }
 
+// GetMulti fetches the events with the given Ids in one round trip. Events are returned in the
+// order of ids; Ids without a document are left out.
+func (r *eventRepo) GetMulti(ctx context.Context, ids []string) ([]domain.Event, error) {
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = coll.Doc(id)
+	}
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var e domain.Event
+		if err := doc.DataTo(&e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
 func (r *eventRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
-	_, err := r.client.Collection(CollectionEvents).Doc(id).Set(ctx, updates, firestore.MergeAll)
+	syncSearchCopies(updates)
+	_, err := tenantCollection(ctx, r.client, CollectionEvents).Doc(id).Set(ctx, updates, firestore.MergeAll)
 	return err
 }
 
+// UpdateInTransaction reads the event and writes the updates fn derives from it in one transaction,
+// for read-modify-write changes (computed counters, capacity checks, version-checked edits) that a
+// blind MergeAll Set could apply on top of a stale read.
+func (r *eventRepo) UpdateInTransaction(ctx context.Context, id string, fn EventUpdateFunc) error {
+	ref := tenantCollection(ctx, r.client, CollectionEvents).Doc(id)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return domain.ErrNotFound("event not found")
+		}
+		if err != nil {
+			return err
+		}
+		var current domain.Event
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+
+		updates, err := fn(&current)
+		if err != nil || len(updates) == 0 {
+			return err
+		}
+		syncSearchCopies(updates)
+		return tx.Set(ref, updates, firestore.MergeAll)
+	})
+}
+
+// syncSearchCopies keeps the lowercase search copies in sync with their source fields
+func syncSearchCopies(updates map[string]interface{}) {
+	if name, ok := updates["event_name"].(string); ok {
+		updates["event_name_lc"] = domain.NormalizeSearchText(name)
+	}
+	if city, ok := updates["city"].(string); ok {
+		updates["city_lc"] = domain.NormalizeSearchText(city)
+	}
+}
+
+// SaveUnique creates the event unless one with the same dedup key exists.
+// The lookup and the create run in one transaction, so concurrent identical requests
+// cannot both succeed. It returns the Id of the existing event, or "" when the event was created.
+func (r *eventRepo) SaveUnique(ctx context.Context, event *domain.Event) (string, error) {
+	event.Normalize()
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+
+	var existingID string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		existingID = "" // the function may be retried
+
+		docs, err := tx.Documents(coll.Where("dedup_key", "==", event.DedupKey).Limit(1)).GetAll()
+		if err != nil {
+			return err
+		}
+		if len(docs) > 0 {
+			existingID = docs[0].Ref.ID
+			return nil
+		}
+		return tx.Create(coll.Doc(event.Id), event)
+	})
+	return existingID, err
+}
+
+// ListFeatured returns events whose promotion is still running, sorted by start_time.
+// The featured set is small, so sorting happens in memory rather than in a composite index.
+func (r *eventRepo) ListFeatured(ctx context.Context, now time.Time) ([]domain.Event, error) {
+	iter := r.events(ctx).
+		Where("featured", "==", true).
+		Where("featured_until", ">", now).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var events []domain.Event
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var e domain.Event
+		if err := doc.DataTo(&e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return events, nil
+}
+
 func (r *eventRepo) Save(ctx context.Context, event *domain.Event) error {
-	_, err := r.client.Collection(CollectionEvents).Doc(event.Id).Set(ctx, event)
+	event.Normalize()
+	_, err := tenantCollection(ctx, r.client, CollectionEvents).Doc(event.Id).Set(ctx, event)
 	return err
 }
 
-func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, string, error) {
+// listSortKeys are the sort keys List and Stream accept as the requested sort
+var listSortKeys = []string{"city", "created_at", "end_time", "event_name", "popularity", "price", "start_time"}
 
-	validSorts := map[string]bool{
-		"created_at": true, "price": true, "start_time": true,
-		"event_name": true, "city": true, "end_time": true,
-	}
+// sortKeyFields maps the sort keys that differ from the field they order by
+var sortKeyFields = map[string]string{"popularity": "popularity_score"}
+
+// listPlan is the shape of a List or Stream query, which also determines the composite index it needs
+type listPlan struct {
+	equalityFields []string // fields filtered with ==
+	sortFields     []string // explicit OrderBy fields; "id" always comes last
+	rangeFields    []string // fields filtered by range but not ordered explicitly
+	direction      firestore.Direction
+}
 
+// planListQuery decides the order and filter fields of a List or Stream query
+func planListQuery(search domain.SearchRequest) listPlan {
 	f := search.Filters
 	reqSort := search.Sorting.SortKey
 
@@ -79,12 +316,13 @@ func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]do
 	// you must also order by those fields in the query to utilize the index efficiently.
 	var inequalityFields []string
 
-	// Prefix matches (>= and <=) count as inequalities
+	// Prefix matches (>= and <=) count as inequalities.
+	// Text filters run against the lowercase copies so matching is case-insensitive.
 	if f.EventName != "" {
-		inequalityFields = append(inequalityFields, "event_name")
+		inequalityFields = append(inequalityFields, "event_name_lc")
 	}
 	if f.City != "" {
-		inequalityFields = append(inequalityFields, "city")
+		inequalityFields = append(inequalityFields, "city_lc")
 	}
 	// Numeric and Date ranges
 	if f.MinPrice != nil || f.MaxPrice != nil {
@@ -103,16 +341,9 @@ func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]do
 	}
 
 	// B. Add User's requested sort (if not already added via inequality)
-	if reqSort != "" && validSorts[reqSort] {
-		isDuplicate := false
-		for _, existing := range sortFields {
-			if existing == reqSort {
-				isDuplicate = true
-				break
-			}
-		}
-		if !isDuplicate {
-			sortFields = append(sortFields, reqSort)
+	if reqSort != "" && slices.Contains(listSortKeys, reqSort) {
+		if field := cmp.Or(sortKeyFields[reqSort], reqSort); !slices.Contains(sortFields, field) {
+			sortFields = append(sortFields, field)
 		}
 	}
 
@@ -124,43 +355,62 @@ func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]do
 	// D. Always tie-break with ID for stable pagination
 	sortFields = append(sortFields, "id")
 
-	// 3. Build Query (Apply Sorts)
-	coll := r.client.Collection(CollectionEvents)
-	var q firestore.Query
-
-	direction := firestore.Asc
+	plan := listPlan{sortFields: sortFields, direction: firestore.Asc}
 	if search.Sorting.SortDirection == "desc" {
-		direction = firestore.Desc
+		plan.direction = firestore.Desc
+	}
+	if f.Type != "" {
+		plan.equalityFields = append(plan.equalityFields, "type")
+	}
+	if f.OrganizerID != "" {
+		plan.equalityFields = append(plan.equalityFields, "organizer_id")
+	}
+	// end_date filters end_time, which is ordered implicitly by Firestore after the explicit orders
+	if f.EndDate != nil && !slices.Contains(sortFields, "end_time") {
+		plan.rangeFields = append(plan.rangeFields, "end_time")
 	}
+	return plan
+}
+
+// alwaysSelected are read by projected list queries whatever fields were asked for: the event
+// service hides events from callers by their visibility
+var alwaysSelected = []string{"visibility"}
 
-	for i, field := range sortFields {
+// listQuery builds the filtered and ordered query shared by List and Stream.
+// It returns the sort fields, which also define the page cursor.
+func (r *eventRepo) listQuery(ctx context.Context, search domain.SearchRequest) (firestore.Query, []string) {
+	f := search.Filters
+	plan := planListQuery(search)
+	sortFields := plan.sortFields
+
+	// 3. Build Query (Apply Sorts)
+	q := r.events(ctx)
+	for _, field := range sortFields {
 		// Calculate direction for this specific field
-		dir := direction
+		dir := plan.direction
 		if field == "id" {
 			dir = firestore.Asc // ID is always Ascending for stability
 		}
-
-		// First iteration applies to CollectionRef and returns a Query
-		// Subsequent iterations apply to Query and return a Query
-		if i == 0 {
-			q = coll.OrderBy(field, dir)
-		} else {
-			q = q.OrderBy(field, dir)
-		}
+		q = q.OrderBy(field, dir)
 	}
 
 	// 4. Apply Filters
 	lastUtf8Char := "\uf8ff"
 
 	if f.EventName != "" {
-		q = q.Where("event_name", ">=", f.EventName).Where("event_name", "<=", f.EventName+lastUtf8Char)
+		name := domain.NormalizeSearchText(f.EventName)
+		q = q.Where("event_name_lc", ">=", name).Where("event_name_lc", "<=", name+lastUtf8Char)
 	}
 	if f.City != "" {
-		q = q.Where("city", ">=", f.City).Where("city", "<=", f.City+lastUtf8Char)
+		city := domain.NormalizeSearchText(f.City)
+		q = q.Where("city_lc", ">=", city).Where("city_lc", "<=", city+lastUtf8Char)
 	}
 	if f.Type != "" {
 		q = q.Where("type", "==", f.Type)
 	}
+	if f.OrganizerID != "" {
+		q = q.Where("organizer_id", "==", f.OrganizerID)
+	}
 	if f.MinPrice != nil {
 		q = q.Where("price", ">=", *f.MinPrice)
 	}
@@ -174,45 +424,48 @@ func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]do
 		q = q.Where("end_time", "<=", *f.EndDate)
 	}
 
-	// 5. Pagination Limit
-	limit := search.Sorting.PageSize
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
-	q = q.Limit(limit)
-
-	// 6. Handle Page Token (Cursor)
-	if search.Sorting.PageToken != "" {
-		cursorVals, err := decodeCursor(search.Sorting.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token")
+	// 4b. Sparse fieldset: read only the requested fields (plus sort fields needed for the cursor
+	// and the fields the event service filters on)
+	if len(search.Fields) > 0 {
+		selected := append([]string{}, search.Fields...)
+		for _, field := range slices.Concat(sortFields, alwaysSelected) {
+			if !slices.Contains(selected, field) {
+				selected = append(selected, field)
+			}
 		}
+		q = q.Select(selected...)
+	}
+	return q, sortFields
+}
 
-		// Safety Check: Cursor length must match the number of OrderBy fields
-		if len(cursorVals) != len(sortFields) {
-			return nil, "", fmt.Errorf("cursor mismatch: sorting criteria changed")
-		}
+func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	projected := len(search.Fields) == 0 && len(r.listFields) > 0
+	if projected {
+		search.Fields = r.listFields
+	}
+	q, sortFields := r.listQuery(ctx, search)
 
-		// Correctly parse time strings based on the field type in that position
-		for i, field := range sortFields {
-			switch field {
-			case "created_at", "start_time", "end_time":
-				if strVal, ok := cursorVals[i].(string); ok {
-					t, err := time.Parse(time.RFC3339, strVal)
-					if err == nil {
-						cursorVals[i] = t
-					}
-				}
-			}
-		}
+	// 5. Pagination Limit
+	limit := pageLimit(search)
 
-		q = q.StartAfter(cursorVals...)
+	// 6. Handle Page Token (Cursor)
+	// Forward tokens resume after the last item of the previous page; backward tokens
+	// end before the first item of the current page and take the last `limit` items.
+	cursorVals, backward, err := r.tokens.openCursor(search, sortFields)
+	if err != nil {
+		return nil, domain.Meta{}, err
+	}
+	switch {
+	case cursorVals == nil:
+		q = q.Limit(limit)
+	case backward:
+		q = q.EndBefore(cursorVals...).LimitToLast(limit)
+	default:
+		q = q.StartAfter(cursorVals...).Limit(limit)
 	}
 
 	// 7. Execute Query
+	// LimitToLast results are returned by the client in the requested sort order.
 	iter := q.Documents(ctx)
 	defer iter.Stop()
 
@@ -223,59 +476,97 @@ func (r *eventRepo) List(ctx context.Context, search domain.SearchRequest) ([]do
 			break
 		}
 		if err != nil {
-			return nil, "", err
+			return nil, domain.Meta{}, err
 		}
 
 		var e domain.Event
 		if err := doc.DataTo(&e); err != nil {
-			return nil, "", err
+			return nil, domain.Meta{}, err
 		}
 
 		events = append(events, e)
 	}
 
-	// 8. Generate Next Page Token
-	nextToken := ""
-	if len(events) == limit {
-		lastEvent := events[len(events)-1]
+	// 8. Generate Page Tokens
+	meta := r.tokens.pageMeta(events, limit, backward, search, sortFields)
+	if projected {
+		meta.Fields = search.Fields
+	}
+	return events, meta, nil
+}
 
-		var cursorValues []interface{}
-		// Generate cursor values exactly matching the sortFields list
-		for _, field := range sortFields {
-			if field == "id" {
-				cursorValues = append(cursorValues, lastEvent.Id)
-			} else {
-				cursorValues = append(cursorValues, getSortValue(&lastEvent, field))
-			}
-		}
+// Stream runs the List query without paging and calls fn for each event as it is read,
+// so exports are not bounded by memory. Page tokens are ignored; an error from fn stops the stream.
+func (r *eventRepo) Stream(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error {
+	q, _ := r.listQuery(ctx, search)
+	iter := q.Documents(ctx)
+	defer iter.Stop()
 
-		nextToken = encodeCursor(cursorValues)
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var e domain.Event
+		if err := doc.DataTo(&e); err != nil {
+			return err
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
 	}
+}
 
-	return events, nextToken, nil
+// cursorValuesFor generates cursor values exactly matching the sortFields list
+func cursorValuesFor(e *domain.Event, sortFields []string) []interface{} {
+	var values []interface{}
+	for _, field := range sortFields {
+		if field == "id" {
+			values = append(values, e.Id)
+		} else {
+			values = append(values, getSortValue(e, field))
+		}
+	}
+	return values
 }
 
+// BatchSave writes the events through a BulkWriter, which sends them in parallel requests of 20
+// writes, throttles itself and retries contended writes, so there is no 500-item batch limit.
+// Writes are not atomic: each event succeeds or fails on its own and the failures are reported
+// by index in a domain.BatchSaveError.
 func (r *eventRepo) BatchSave(ctx context.Context, events []*domain.Event) error {
-	// Firestore limit is 500 operations per batch
-	const batchSize = 500
-	total := len(events)
-
-	for i := 0; i < total; i += batchSize {
-		end := i + batchSize
-		if end > total {
-			end = total
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+	bw := r.client.BulkWriter(ctx)
+	failed := make(map[int]error)
+
+	jobs := make([]*firestore.BulkWriterJob, len(events))
+	for i, event := range events {
+		event.Normalize()
+		// Rejected up front: a missing Id or an Id repeated within the batch
+		job, err := bw.Set(coll.Doc(event.Id), event)
+		if err != nil {
+			failed[i] = err
+			continue
 		}
+		jobs[i] = job
+	}
+	bw.End()
 
-		batch := r.client.Batch()
-		for _, event := range events[i:end] {
-			docRef := r.client.Collection(CollectionEvents).Doc(event.Id)
-			batch.Set(docRef, event)
+	for i, job := range jobs {
+		if job == nil {
+			continue
 		}
-
-		if _, err := batch.Commit(ctx); err != nil {
-			return err
+		if _, err := job.Results(); err != nil {
+			failed[i] = err
 		}
 	}
+
+	if len(failed) > 0 {
+		return &domain.BatchSaveError{Failed: failed}
+	}
 	return nil
 }
 
@@ -295,22 +586,24 @@ func getSortValue(e *domain.Event, key string) interface{} {
 		return e.CreatedAt
 	case "event_name":
 		return e.EventName
+	case "popularity_score":
+		return e.PopularityScore
+	case "event_name_lc":
+		return e.EventNameLC
+	case "city_lc":
+		return e.CityLC
 	default:
 		return e.CreatedAt
 	}
 }
 
-func encodeCursor(vals []interface{}) string {
-	b, _ := json.Marshal(vals)
-	return base64.StdEncoding.EncodeToString(b)
-}
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
 
-func decodeCursor(token string) ([]interface{}, error) {
-	b, err := base64.StdEncoding.DecodeString(token)
-	if err != nil {
-		return nil, err
-	}
-	var vals []interface{}
-	err = json.Unmarshal(b, &vals)
-	return vals, err
+// pageCursor is the decoded form of a page token
+type pageCursor struct {
+	Direction string        `json:"d"`
+	Values    []interface{} `json:"v"`
 }
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// Stats returns per-group counts and price figures.
+// COUNT, SUM and AVG run as server-side aggregation queries; Firestore has no MIN/MAX
+// aggregation, so those are read from the first document of a price-ordered query.
+// With publicOnly, the unlisted and private events are taken out of the figures (see hiddenEvents).
+func (r *eventRepo) Stats(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error) {
+	groups, err := r.groupValues(ctx, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := tenantCollection(ctx, r.client, CollectionEvents)
+	var hidden []domain.Event
+	if publicOnly {
+		if hidden, err = hiddenEvents(ctx, coll.Query); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make([]domain.EventStats, 0, len(groups))
+	for _, group := range groups {
+		q := coll.Where(groupBy, "==", group)
+
+		result, err := priceAggregation(q).Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s := domain.EventStats{
+			Group:      group,
+			Count:      int64(aggregateNumber(result, "count")),
+			TotalPrice: aggregateNumber(result, "total_price"),
+			AvgPrice:   aggregateNumber(result, "avg_price"),
+		}
+		skip := 0
+		for _, e := range hidden {
+			value := e.City
+			if groupBy == "type" {
+				value = string(e.Type)
+			}
+			if value == group {
+				s.Count--
+				s.TotalPrice -= e.Price
+				skip++
+			}
+		}
+		if s.Count <= 0 {
+			continue
+		}
+		if skip > 0 {
+			s.AvgPrice = s.TotalPrice / float64(s.Count)
+		}
+
+		if s.MinPrice, err = r.boundaryPrice(ctx, q, firestore.Asc, skip); err != nil {
+			return nil, err
+		}
+		if s.MaxPrice, err = r.boundaryPrice(ctx, q, firestore.Desc, skip); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	// Largest groups first; ties broken by name for a stable response
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Group < stats[j].Group
+	})
+	return stats, nil
+}
+
+// Summarize runs one aggregation over the List query, so the figures cover exactly the events
+// the list pages through, without reading them. With publicOnly, the unlisted and private events
+// matching the filters are taken out of the figures (see hiddenEvents).
+func (r *eventRepo) Summarize(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error) {
+	q, _ := r.listQuery(ctx, domain.SearchRequest{Filters: filters})
+	result, err := priceAggregation(q).Get(ctx)
+	if err != nil {
+		return domain.EventSummary{}, err
+	}
+	summary := domain.EventSummary{
+		Count:      int64(aggregateNumber(result, "count")),
+		TotalPrice: aggregateNumber(result, "total_price"),
+		AvgPrice:   aggregateNumber(result, "avg_price"),
+	}
+	if !publicOnly {
+		return summary, nil
+	}
+
+	hidden, err := hiddenEvents(ctx, r.events(ctx))
+	if err != nil {
+		return domain.EventSummary{}, err
+	}
+	for i := range hidden {
+		if filters.Matches(&hidden[i]) {
+			summary.Count--
+			summary.TotalPrice -= hidden[i].Price
+		}
+	}
+	summary.AvgPrice = 0
+	if summary.Count > 0 {
+		summary.AvgPrice = summary.TotalPrice / float64(summary.Count)
+	}
+	return summary, nil
+}
+
+// hiddenVisibilities are the visibilities left out of the aggregates of callers who are not admins
+var hiddenVisibilities = []string{string(domain.VisibilityUnlisted), string(domain.VisibilityPrivate)}
+
+// hiddenEvents reads the unlisted and private events of base, projected to the fields the
+// aggregates filter, group and sum by. Aggregates take them out of their figures rather than
+// filtering on visibility == public, which would need an index for every filter combination and
+// would miss the events stored before visibility existed (public ones). Hidden events are few.
+func hiddenEvents(ctx context.Context, base firestore.Query) ([]domain.Event, error) {
+	iter := base.Where("visibility", "in", hiddenVisibilities).
+		Select("event_name_lc", "city", "city_lc", "type", "organizer_id", "price", "start_time", "end_time").
+		Documents(ctx)
+	defer iter.Stop()
+
+	var events []domain.Event
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var e domain.Event
+		if err := doc.DataTo(&e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+}
+
+// priceAggregation counts the events of q and sums and averages their prices
+func priceAggregation(q firestore.Query) *firestore.AggregationQuery {
+	return q.NewAggregationQuery().
+		WithCount("count").
+		WithSum("price", "total_price").
+		WithAvg("price", "avg_price")
+}
+
+// groupValues lists the distinct values of the group field.
+// Types come from the deployment's registry; cities are discovered with a projection that reads only that field.
+func (r *eventRepo) groupValues(ctx context.Context, groupBy string) ([]string, error) {
+	switch groupBy {
+	case "type":
+		types := domain.AllowedEventTypes(ctx)
+		values := make([]string, 0, len(types))
+		for _, t := range types {
+			values = append(values, string(t))
+		}
+		return values, nil
+	case "city":
+		iter := tenantCollection(ctx, r.client, CollectionEvents).Select("city").Documents(ctx)
+		defer iter.Stop()
+
+		seen := make(map[string]bool)
+		var values []string
+		for {
+			doc, err := iter.Next()
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			city, _ := doc.Data()["city"].(string)
+			if city != "" && !seen[city] {
+				seen[city] = true
+				values = append(values, city)
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported group_by field: %s", groupBy)
+	}
+}
+
+// boundaryPrice returns the lowest (Asc) or highest (Desc) price matched by q, skipping the
+// unlisted and private events; skip is how many of those q matches at most
+func (r *eventRepo) boundaryPrice(ctx context.Context, q firestore.Query, dir firestore.Direction, skip int) (float64, error) {
+	iter := q.OrderBy("price", dir).Select("price", "visibility").Limit(skip + 1).Documents(ctx)
+	defer iter.Stop()
+
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if visibility, _ := doc.Data()["visibility"].(string); skip > 0 && slices.Contains(hiddenVisibilities, visibility) {
+			continue
+		}
+		switch price := doc.Data()["price"].(type) {
+		case float64:
+			return price, nil
+		case int64:
+			return float64(price), nil
+		default:
+			return 0, nil
+		}
+	}
+}
+
+// PriceBuckets counts events per price range using one COUNT aggregation per bucket.
+// Bounds must be ascending; the last bucket is open-ended. City matches case-insensitively.
+// With publicOnly, the unlisted and private events are taken out of the counts (see hiddenEvents).
+func (r *eventRepo) PriceBuckets(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error) {
+	base := tenantCollection(ctx, r.client, CollectionEvents).Query
+	var hidden []domain.Event
+	if publicOnly {
+		var err error
+		if hidden, err = hiddenEvents(ctx, base); err != nil {
+			return nil, err
+		}
+	}
+	cityLC := domain.NormalizeSearchText(city)
+	if city != "" {
+		base = base.Where("city_lc", "==", cityLC)
+	}
+
+	buckets := make([]domain.PriceBucket, 0, len(bounds))
+	for i, lower := range bounds {
+		q := base.Where("price", ">=", lower)
+		bucket := domain.PriceBucket{Min: lower}
+		if i+1 < len(bounds) {
+			upper := bounds[i+1]
+			q = q.Where("price", "<", upper)
+			bucket.Max = &upper
+		}
+
+		result, err := q.NewAggregationQuery().WithCount("count").Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bucket.Count = int64(aggregateNumber(result, "count"))
+		for _, e := range hidden {
+			if (city == "" || e.CityLC == cityLC) && e.Price >= lower && (bucket.Max == nil || e.Price < *bucket.Max) {
+				bucket.Count--
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
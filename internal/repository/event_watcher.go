@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EventWatcher interface {
+	// WatchEvents calls fn for every change to matching events of the tenant in ctx made after the
+	// call, until ctx is cancelled (returning nil) or fn returns an error. Events already stored are
+	// not replayed.
+	WatchEvents(ctx context.Context, filter domain.WatchFilter, fn func(domain.EventChange) error) error
+}
+
+type eventWatcher struct {
+	client *firestore.Client
+}
+
+func NewEventWatcher(client *firestore.Client) EventWatcher {
+	return &eventWatcher{client: client}
+}
+
+func (w *eventWatcher) WatchEvents(ctx context.Context, filter domain.WatchFilter, fn func(domain.EventChange) error) error {
+	q := tenantCollection(ctx, w.client, CollectionEvents).Query
+	if filter.City != "" {
+		q = q.Where("city_lc", "==", domain.NormalizeSearchText(filter.City))
+	}
+	if filter.Type != "" {
+		q = q.Where("type", "==", filter.Type)
+	}
+
+	it := q.Snapshots(ctx)
+	defer it.Stop()
+
+	// The first snapshot lists the current result set as additions; skip it
+	initial := true
+	for {
+		snap, err := it.Next()
+		if err != nil {
+			if ctx.Err() != nil || status.Code(err) == codes.Canceled || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		if initial {
+			initial = false
+			continue
+		}
+		for _, ch := range snap.Changes {
+			change := domain.EventChange{Event: domain.Event{Id: ch.Doc.Ref.ID}}
+			switch ch.Kind {
+			case firestore.DocumentAdded:
+				change.Kind = domain.EventAdded
+			case firestore.DocumentModified:
+				change.Kind = domain.EventModified
+			case firestore.DocumentRemoved:
+				change.Kind = domain.EventRemoved
+			}
+			if change.Kind != domain.EventRemoved {
+				if err := ch.Doc.DataTo(&change.Event); err != nil {
+					return err
+				}
+				change.Event.Id = ch.Doc.Ref.ID
+			}
+			if err := fn(change); err != nil {
+				return err
+			}
+		}
+	}
+}
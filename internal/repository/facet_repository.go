@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollectionFacets = "facets"
+	eventFacetsDoc   = "events"
+)
+
+// FacetRepository keeps the facets/events document of the tenant in ctx (see tenantCollection),
+// counting that tenant's events only
+type FacetRepository interface {
+	// ApplyEventFacetDelta increments the counters of facets/events atomically, creating it if needed
+	ApplyEventFacetDelta(ctx context.Context, delta domain.FacetDelta) error
+	// GetEventFacets returns empty facets when none have been recorded yet
+	GetEventFacets(ctx context.Context) (*domain.EventFacets, error)
+	// RebuildEventFacets recounts the events collection and overwrites facets/events,
+	// repairing drift from missed or duplicated trigger deliveries
+	RebuildEventFacets(ctx context.Context) (*domain.EventFacets, error)
+}
+
+type facetRepo struct {
+	client *firestore.Client
+}
+
+func NewFacetRepository(client *firestore.Client) FacetRepository {
+	return &facetRepo{client: client}
+}
+
+func (r *facetRepo) ApplyEventFacetDelta(ctx context.Context, delta domain.FacetDelta) error {
+	data := map[string]interface{}{"updated_at": firestore.ServerTimestamp}
+	if delta.Total != 0 {
+		data["total"] = firestore.Increment(delta.Total)
+	}
+	if len(delta.Cities) > 0 {
+		data["cities"] = increments(delta.Cities)
+	}
+	if len(delta.Types) > 0 {
+		data["types"] = increments(delta.Types)
+	}
+	// MergeAll applies each increment to its own leaf, leaving the other counters untouched
+	_, err := tenantCollection(ctx, r.client, CollectionFacets).Doc(eventFacetsDoc).Set(ctx, data, firestore.MergeAll)
+	return err
+}
+
+func (r *facetRepo) GetEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	doc, err := tenantCollection(ctx, r.client, CollectionFacets).Doc(eventFacetsDoc).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return &domain.EventFacets{Cities: map[string]int64{}, Types: map[string]int64{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var facets domain.EventFacets
+	if err := doc.DataTo(&facets); err != nil {
+		return nil, err
+	}
+	return &facets, nil
+}
+
+func (r *facetRepo) RebuildEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	// The projection reads only the two counted fields
+	iter := tenantCollection(ctx, r.client, CollectionEvents).Select("city", "type").Documents(ctx)
+	defer iter.Stop()
+
+	facets := &domain.EventFacets{Cities: map[string]int64{}, Types: map[string]int64{}}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var e domain.Event
+		if err := doc.DataTo(&e); err != nil {
+			continue
+		}
+		facets.Apply(domain.EventFacetDelta(nil, &e))
+	}
+
+	facets.UpdatedAt = time.Now().UTC()
+	if _, err := tenantCollection(ctx, r.client, CollectionFacets).Doc(eventFacetsDoc).Set(ctx, facets); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func increments(counts map[string]int64) map[string]interface{} {
+	m := make(map[string]interface{}, len(counts))
+	for k, n := range counts {
+		m[k] = firestore.Increment(n)
+	}
+	return m
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollectionUsers     = "users"
+	CollectionFavorites = "favorites"
+)
+
+type FavoriteRepository interface {
+	// AddFavorite and RemoveFavorite are idempotent and keep the event's favorites_count in sync
+	AddFavorite(ctx context.Context, userID, eventID string) error
+	RemoveFavorite(ctx context.Context, userID, eventID string) error
+	ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error)
+}
+
+type favoriteRepo struct {
+	client *firestore.Client
+}
+
+func NewFavoriteRepository(client *firestore.Client) FavoriteRepository {
+	return &favoriteRepo{client: client}
+}
+
+func (r *favoriteRepo) favoriteRef(userID, eventID string) *firestore.DocumentRef {
+	return r.client.Collection(CollectionUsers).Doc(userID).Collection(CollectionFavorites).Doc(eventID)
+}
+
+func (r *favoriteRepo) AddFavorite(ctx context.Context, userID, eventID string) error {
+	eventRef := tenantCollection(ctx, r.client, CollectionEvents).Doc(eventID)
+	favRef := r.favoriteRef(userID, eventID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(eventRef); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return domain.ErrNotFound("event not found")
+			}
+			return err
+		}
+
+		_, err := tx.Get(favRef)
+		if err == nil {
+			return nil // Already a favorite
+		}
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		fav := &domain.Favorite{EventID: eventID, UserID: userID, CreatedAt: time.Now().UTC(), TenantID: domain.TenantFromContext(ctx)}
+		if err := tx.Create(favRef, fav); err != nil {
+			return err
+		}
+		return tx.Update(eventRef, []firestore.Update{
+			{Path: "favorites_count", Value: firestore.Increment(1)},
+		})
+	})
+}
+
+func (r *favoriteRepo) RemoveFavorite(ctx context.Context, userID, eventID string) error {
+	eventRef := tenantCollection(ctx, r.client, CollectionEvents).Doc(eventID)
+	favRef := r.favoriteRef(userID, eventID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		_, err := tx.Get(favRef)
+		if status.Code(err) == codes.NotFound {
+			return nil // Nothing to remove
+		}
+		if err != nil {
+			return err
+		}
+
+		// The event may have been deleted meanwhile; only decrement if it still exists
+		_, eventErr := tx.Get(eventRef)
+		if eventErr != nil && status.Code(eventErr) != codes.NotFound {
+			return eventErr
+		}
+
+		if err := tx.Delete(favRef); err != nil {
+			return err
+		}
+		if eventErr == nil {
+			return tx.Update(eventRef, []firestore.Update{
+				{Path: "favorites_count", Value: firestore.Increment(-1)},
+			})
+		}
+		return nil
+	})
+}
+
+func (r *favoriteRepo) ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error) {
+	iter := r.client.Collection(CollectionUsers).Doc(userID).Collection(CollectionFavorites).
+		OrderBy("created_at", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	favorites := []domain.Favorite{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var f domain.Favorite
+		if err := doc.DataTo(&f); err != nil {
+			return nil, err
+		}
+		favorites = append(favorites, f)
+	}
+	return favorites, nil
+}
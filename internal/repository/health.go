@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// NewFirestoreProbe returns a readiness probe that reads at most one event document
+func NewFirestoreProbe(client *firestore.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		iter := client.Collection(CollectionEvents).Select().Limit(1).Documents(ctx)
+		defer iter.Stop()
+		if _, err := iter.Next(); err != nil && !errors.Is(err, iterator.Done) {
+			return err
+		}
+		return nil
+	}
+}
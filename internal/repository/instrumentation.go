@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/metrics"
+	"bibently.com/backend/internal/tracing"
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startOp opens a child span for one repository operation. The returned function ends it and
+// records latency, documents read and failures. A missing document is an expected outcome, not an error.
+func startOp(ctx context.Context, op string) (context.Context, func(docsRead int, err error)) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "firestore "+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "firestore"), attribute.String("db.operation", op)))
+
+	return ctx, func(docsRead int, err error) {
+		defer span.End()
+		metrics.FirestoreDuration.ObserveSince(start, op)
+		if docsRead > 0 {
+			metrics.FirestoreDocsRead.Add(float64(docsRead), op)
+			span.SetAttributes(attribute.Int("db.documents_read", docsRead))
+		}
+		var notFound *domain.NotFoundError
+		if err != nil && !errors.As(err, &notFound) {
+			metrics.FirestoreErrors.Inc(op)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
+
+// instrumentedEventRepo traces and measures the hot event operations; the rest pass through unchanged
+type instrumentedEventRepo struct {
+	EventRepository
+}
+
+func (r instrumentedEventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	ctx, done := startOp(ctx, "events.list")
+	events, meta, err := r.EventRepository.List(ctx, search)
+	done(len(events), err)
+	return events, meta, err
+}
+
+func (r instrumentedEventRepo) Stream(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error {
+	ctx, done := startOp(ctx, "events.stream")
+	read := 0
+	err := r.EventRepository.Stream(ctx, search, func(e *domain.Event) error {
+		read++
+		return fn(e)
+	})
+	done(read, err)
+	return err
+}
+
+func (r instrumentedEventRepo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
+	ctx, done := startOp(ctx, "events.get")
+	event, err := r.EventRepository.GetByID(ctx, id)
+	done(countOne(event), err)
+	return event, err
+}
+
+func (r instrumentedEventRepo) GetBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	ctx, done := startOp(ctx, "events.get_by_slug")
+	event, err := r.EventRepository.GetBySlug(ctx, slug)
+	done(countOne(event), err)
+	return event, err
+}
+
+func (r instrumentedEventRepo) UpdateInTransaction(ctx context.Context, id string, fn EventUpdateFunc) error {
+	ctx, done := startOp(ctx, "events.update_tx")
+	attempts := 0
+	err := r.EventRepository.UpdateInTransaction(ctx, id, func(current *domain.Event) (map[string]interface{}, error) {
+		attempts++
+		return fn(current)
+	})
+	done(attempts, err)
+	return err
+}
+
+func (r instrumentedEventRepo) GetMulti(ctx context.Context, ids []string) ([]domain.Event, error) {
+	ctx, done := startOp(ctx, "events.get_multi")
+	events, err := r.EventRepository.GetMulti(ctx, ids)
+	done(len(events), err)
+	return events, err
+}
+
+func (r instrumentedEventRepo) ListFeatured(ctx context.Context, now time.Time) ([]domain.Event, error) {
+	ctx, done := startOp(ctx, "events.list_featured")
+	events, err := r.EventRepository.ListFeatured(ctx, now)
+	done(len(events), err)
+	return events, err
+}
+
+func (r instrumentedEventRepo) Save(ctx context.Context, event *domain.Event) error {
+	ctx, done := startOp(ctx, "events.save")
+	err := r.EventRepository.Save(ctx, event)
+	done(0, err)
+	return err
+}
+
+func (r instrumentedEventRepo) SaveUnique(ctx context.Context, event *domain.Event) (string, error) {
+	ctx, done := startOp(ctx, "events.save_unique")
+	existingID, err := r.EventRepository.SaveUnique(ctx, event)
+	done(0, err)
+	return existingID, err
+}
+
+func (r instrumentedEventRepo) BatchSave(ctx context.Context, events []*domain.Event) error {
+	ctx, done := startOp(ctx, "events.batch_save")
+	err := r.EventRepository.BatchSave(ctx, events)
+	done(0, err)
+	return err
+}
+
+func (r instrumentedEventRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	ctx, done := startOp(ctx, "events.update")
+	err := r.EventRepository.Update(ctx, id, updates)
+	done(0, err)
+	return err
+}
+
+func (r instrumentedEventRepo) Delete(ctx context.Context, id string) error {
+	ctx, done := startOp(ctx, "events.delete")
+	err := r.EventRepository.Delete(ctx, id)
+	done(0, err)
+	return err
+}
+
+func (r instrumentedEventRepo) Summarize(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error) {
+	ctx, done := startOp(ctx, "events.summarize")
+	summary, err := r.EventRepository.Summarize(ctx, filters, publicOnly)
+	done(0, err)
+	return summary, err
+}
+
+func countOne[T any](v *T) int {
+	if v == nil {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const CollectionJobs = "jobs"
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, job *domain.ImportJob) error
+	GetJob(ctx context.Context, id string) (*domain.ImportJob, error)
+	UpdateJob(ctx context.Context, id string, updates map[string]interface{}) error
+	RecordChunk(ctx context.Context, id string, index, created int, failures []domain.ImportRowResult) error
+}
+
+type jobRepo struct {
+	client *firestore.Client
+}
+
+func NewJobRepository(client *firestore.Client) JobRepository {
+	return &jobRepo{client: client}
+}
+
+func (r *jobRepo) CreateJob(ctx context.Context, job *domain.ImportJob) error {
+	_, err := r.client.Collection(CollectionJobs).Doc(job.Id).Set(ctx, job)
+	return err
+}
+
+func (r *jobRepo) GetJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	doc, err := r.client.Collection(CollectionJobs).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrNotFound("job not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var job domain.ImportJob
+	if err := doc.DataTo(&job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepo) UpdateJob(ctx context.Context, id string, updates map[string]interface{}) error {
+	_, err := r.client.Collection(CollectionJobs).Doc(id).Set(ctx, updates, firestore.MergeAll)
+	return err
+}
+
+// RecordChunk applies a chunk result in a transaction so concurrent workers do not lose updates
+func (r *jobRepo) RecordChunk(ctx context.Context, id string, index, created int, failures []domain.ImportRowResult) error {
+	ref := r.client.Collection(CollectionJobs).Doc(id)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return domain.ErrNotFound("job not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		var job domain.ImportJob
+		if err := doc.DataTo(&job); err != nil {
+			return err
+		}
+		job.ApplyChunk(index, created, failures, time.Now().UTC())
+		return tx.Set(ref, &job)
+	})
+}
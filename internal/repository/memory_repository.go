@@ -0,0 +1,781 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryEventRepo keeps events in process memory with the semantics of the Firestore repository:
+// the same filters, sort orders, page tokens and error values. It backs local development without
+// the Firestore emulator (STORAGE_BACKEND=memory) and service tests. Data is lost on restart.
+type memoryEventRepo struct {
+	mu       sync.RWMutex
+	events   map[string]domain.Event
+	archived map[string]domain.Event
+	views    map[string]int64
+	tokens   *pageTokenCodec
+	fields   []string // WithListProjection
+
+	// tenants holds the data of each tenant (domain.WithTenant) in a repository of its own;
+	// nil in those, which are leaves
+	tenantsMu sync.Mutex
+	tenants   map[string]*memoryEventRepo
+}
+
+// NewMemoryEventRepository returns an empty, thread-safe in-memory EventRepository.
+// WithPageTokenSecret and WithListProjection apply as for NewEventRepository; other options are ignored.
+func NewMemoryEventRepository(opts ...EventRepositoryOption) EventRepository {
+	cfg := &eventRepo{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.tokens == nil {
+		cfg.tokens = newPageTokenCodec(nil)
+	}
+	r := newMemoryEventRepo(cfg.tokens, cfg.listFields)
+	r.tenants = make(map[string]*memoryEventRepo)
+	return r
+}
+
+func newMemoryEventRepo(tokens *pageTokenCodec, fields []string) *memoryEventRepo {
+	return &memoryEventRepo{
+		events:   make(map[string]domain.Event),
+		archived: make(map[string]domain.Event),
+		views:    make(map[string]int64),
+		tokens:   tokens,
+		fields:   fields,
+	}
+}
+
+// scope returns the repository holding the data of the tenant in ctx
+func (r *memoryEventRepo) scope(ctx context.Context) *memoryEventRepo {
+	tenantID := domain.TenantFromContext(ctx)
+	if tenantID == "" || r.tenants == nil {
+		return r
+	}
+	r.tenantsMu.Lock()
+	defer r.tenantsMu.Unlock()
+	tenant, ok := r.tenants[tenantID]
+	if !ok {
+		tenant = newMemoryEventRepo(r.tokens, r.fields)
+		r.tenants[tenantID] = tenant
+	}
+	return tenant
+}
+
+func (r *memoryEventRepo) Delete(ctx context.Context, id string) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.events, id)
+	return nil
+}
+
+func (r *memoryEventRepo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.events[id]
+	if !ok {
+		return nil, domain.ErrNotFound("event not found")
+	}
+	return &e, nil
+}
+
+func (r *memoryEventRepo) GetBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, e := range r.sorted() {
+		if e.Slug == slug {
+			return &e, nil
+		}
+	}
+	return nil, domain.ErrNotFound("event not found")
+}
+
+func (r *memoryEventRepo) GetMulti(ctx context.Context, ids []string) ([]domain.Event, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	events := make([]domain.Event, 0, len(ids))
+	for _, id := range ids {
+		if e, ok := r.events[id]; ok {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// Update merges updates into the event, creating it when missing as a MergeAll Set does
+func (r *memoryEventRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.events[id]
+	syncSearchCopies(updates)
+	if err := applyUpdates(&e, updates); err != nil {
+		return err
+	}
+	r.events[id] = e
+	return nil
+}
+
+// UpdateInTransaction holds the write lock while fn runs, so fn is called exactly once
+func (r *memoryEventRepo) UpdateInTransaction(ctx context.Context, id string, fn EventUpdateFunc) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.events[id]
+	if !ok {
+		return domain.ErrNotFound("event not found")
+	}
+	e := current
+	updates, err := fn(&current)
+	if err != nil || len(updates) == 0 {
+		return err
+	}
+	syncSearchCopies(updates)
+	if err := applyUpdates(&e, updates); err != nil {
+		return err
+	}
+	r.events[id] = e
+	return nil
+}
+
+func (r *memoryEventRepo) Save(ctx context.Context, event *domain.Event) error {
+	r = r.scope(ctx)
+	event.Normalize()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[event.Id] = *event
+	return nil
+}
+
+// BatchSave rejects what a BulkWriter rejects, a missing Id or one repeated within the batch,
+// and saves the rest
+func (r *memoryEventRepo) BatchSave(ctx context.Context, events []*domain.Event) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := make(map[int]error)
+	seen := make(map[string]bool)
+	for i, event := range events {
+		event.Normalize()
+		switch {
+		case event.Id == "":
+			failed[i] = fmt.Errorf("event at index %d has no id", i)
+		case seen[event.Id]:
+			failed[i] = fmt.Errorf("event %s is written twice in the batch", event.Id)
+		default:
+			seen[event.Id] = true
+			r.events[event.Id] = *event
+		}
+	}
+	if len(failed) > 0 {
+		return &domain.BatchSaveError{Failed: failed}
+	}
+	return nil
+}
+
+func (r *memoryEventRepo) SaveUnique(ctx context.Context, event *domain.Event) (string, error) {
+	r = r.scope(ctx)
+	event.Normalize()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.sorted() {
+		if e.DedupKey == event.DedupKey {
+			return e.Id, nil
+		}
+	}
+	if _, ok := r.events[event.Id]; ok {
+		return "", status.Errorf(codes.AlreadyExists, "event %s already exists", event.Id)
+	}
+	r.events[event.Id] = *event
+	return "", nil
+}
+
+func (r *memoryEventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	r = r.scope(ctx)
+	projected := len(search.Fields) == 0 && len(r.fields) > 0
+	if projected {
+		search.Fields = r.fields
+	}
+	plan := planListQuery(search)
+	limit := pageLimit(search)
+	cursorVals, backward, err := r.tokens.openCursor(search, plan.sortFields)
+	if err != nil {
+		return nil, domain.Meta{}, err
+	}
+
+	r.mu.RLock()
+	matched := r.query(search, plan)
+	r.mu.RUnlock()
+
+	// The same window StartAfter/Limit or EndBefore/LimitToLast selects
+	switch {
+	case cursorVals == nil:
+		matched = matched[:min(limit, len(matched))]
+	case backward:
+		end := slices.IndexFunc(matched, func(e domain.Event) bool {
+			return compareToCursor(&e, cursorVals, plan) >= 0
+		})
+		if end < 0 {
+			end = len(matched)
+		}
+		matched = matched[max(0, end-limit):end]
+	default:
+		start := slices.IndexFunc(matched, func(e domain.Event) bool {
+			return compareToCursor(&e, cursorVals, plan) > 0
+		})
+		if start < 0 {
+			start = len(matched)
+		}
+		matched = matched[start:min(start+limit, len(matched))]
+	}
+
+	events := make([]domain.Event, len(matched))
+	for i, e := range matched {
+		events[i] = selectFields(e, search.Fields, plan.sortFields)
+	}
+	meta := r.tokens.pageMeta(events, limit, backward, search, plan.sortFields)
+	if projected {
+		meta.Fields = search.Fields
+	}
+	return events, meta, nil
+}
+
+// Stream iterates over a snapshot taken when it starts, so fn may write to the repository
+func (r *memoryEventRepo) Stream(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error {
+	r = r.scope(ctx)
+	plan := planListQuery(search)
+	r.mu.RLock()
+	matched := r.query(search, plan)
+	r.mu.RUnlock()
+
+	for _, e := range matched {
+		e = selectFields(e, search.Fields, plan.sortFields)
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// query returns the events matching the List filters in plan order; the caller holds the lock
+func (r *memoryEventRepo) query(search domain.SearchRequest, plan listPlan) []domain.Event {
+	f := search.Filters
+	var events []domain.Event
+	for _, e := range r.events {
+		if f.Matches(&e) {
+			events = append(events, e)
+		}
+	}
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return compareToCursor(&a, cursorValuesFor(&b, plan.sortFields), plan)
+	})
+	return events
+}
+
+// sorted returns every event ordered by Id, for deterministic scans; the caller holds the lock
+func (r *memoryEventRepo) sorted() []domain.Event {
+	events := make([]domain.Event, 0, len(r.events))
+	for _, e := range r.events {
+		events = append(events, e)
+	}
+	slices.SortFunc(events, func(a, b domain.Event) int { return strings.Compare(a.Id, b.Id) })
+	return events
+}
+
+func (r *memoryEventRepo) IncrementViews(ctx context.Context, id string) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.views[id]++
+	return nil
+}
+
+func (r *memoryEventRepo) GetViewCount(ctx context.Context, id string) (int64, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.views[id], nil
+}
+
+func (r *memoryEventRepo) Stats(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error) {
+	r = r.scope(ctx)
+	if groupBy != "type" && groupBy != "city" {
+		return nil, fmt.Errorf("unsupported group_by field: %s", groupBy)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byGroup := make(map[string]*domain.EventStats)
+	for _, e := range r.sorted() {
+		if publicOnly && e.EventVisibility() != domain.VisibilityPublic {
+			continue
+		}
+		group := e.City
+		if groupBy == "type" {
+			if !e.Type.IsAllowed(ctx) {
+				continue // groups come from the type registry
+			}
+			group = string(e.Type)
+		}
+		if group == "" {
+			continue
+		}
+		s, ok := byGroup[group]
+		if !ok {
+			s = &domain.EventStats{Group: group, MinPrice: e.Price, MaxPrice: e.Price}
+			byGroup[group] = s
+		}
+		s.Count++
+		s.TotalPrice += e.Price
+		s.MinPrice = min(s.MinPrice, e.Price)
+		s.MaxPrice = max(s.MaxPrice, e.Price)
+	}
+
+	stats := make([]domain.EventStats, 0, len(byGroup))
+	for _, s := range byGroup {
+		s.AvgPrice = s.TotalPrice / float64(s.Count)
+		stats = append(stats, *s)
+	}
+	// Largest groups first; ties broken by name for a stable response
+	slices.SortFunc(stats, func(a, b domain.EventStats) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return strings.Compare(a.Group, b.Group)
+	})
+	return stats, nil
+}
+
+func (r *memoryEventRepo) Summarize(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error) {
+	r = r.scope(ctx)
+	search := domain.SearchRequest{Filters: filters}
+	r.mu.RLock()
+	matched := r.query(search, planListQuery(search))
+	r.mu.RUnlock()
+
+	var summary domain.EventSummary
+	for _, e := range matched {
+		if publicOnly && e.EventVisibility() != domain.VisibilityPublic {
+			continue
+		}
+		summary.Count++
+		summary.TotalPrice += e.Price
+	}
+	if summary.Count > 0 {
+		summary.AvgPrice = summary.TotalPrice / float64(summary.Count)
+	}
+	return summary, nil
+}
+
+func (r *memoryEventRepo) PriceBuckets(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cityLC := domain.NormalizeSearchText(city)
+	buckets := make([]domain.PriceBucket, 0, len(bounds))
+	for i, lower := range bounds {
+		bucket := domain.PriceBucket{Min: lower}
+		if i+1 < len(bounds) {
+			upper := bounds[i+1]
+			bucket.Max = &upper
+		}
+		for _, e := range r.events {
+			if publicOnly && e.EventVisibility() != domain.VisibilityPublic {
+				continue
+			}
+			if (city == "" || e.CityLC == cityLC) && e.Price >= lower && (bucket.Max == nil || e.Price < *bucket.Max) {
+				bucket.Count++
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+func (r *memoryEventRepo) ListFeatured(ctx context.Context, now time.Time) ([]domain.Event, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []domain.Event
+	for _, e := range r.sorted() {
+		if e.Featured && e.FeaturedUntil.After(now) {
+			events = append(events, e)
+		}
+	}
+	slices.SortStableFunc(events, func(a, b domain.Event) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return events, nil
+}
+
+func (r *memoryEventRepo) SetPopularity(ctx context.Context, scores map[string]float64) (int, int, error) {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scored, reset := 0, 0
+	for id, e := range r.events {
+		score, ok := scores[id]
+		switch {
+		case ok:
+			scored++
+		case e.PopularityScore > 0:
+			reset++
+		default:
+			continue
+		}
+		e.PopularityScore = score
+		r.events[id] = e
+	}
+	return scored, reset, nil
+}
+
+// ArchivePastEvents moves events to an in-memory archive, which is not readable through the interface
+func (r *memoryEventRepo) ArchivePastEvents(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	moved := 0
+	for _, e := range r.sorted() {
+		if moved >= limit {
+			break
+		}
+		ended := e.EndTime
+		if ended.IsZero() {
+			ended = e.StartTime
+		}
+		if ended.Before(cutoff) {
+			r.archived[e.Id] = e
+			delete(r.events, e.Id)
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+// compareToCursor orders e against cursor values in the order of plan, as Firestore
+// compares documents with StartAfter and EndBefore
+func compareToCursor(e *domain.Event, cursor []interface{}, plan listPlan) int {
+	values := cursorValuesFor(e, plan.sortFields)
+	for i, field := range plan.sortFields {
+		c := compareValues(values[i], cursor[i])
+		if field != "id" && plan.direction == firestore.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareValues compares two values of one sort field; values of different kinds compare equal
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv)
+		}
+	case domain.EventType:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(string(av), bv)
+		}
+		if bv, ok := b.(domain.EventType); ok {
+			return strings.Compare(string(av), string(bv))
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return cmp.Compare(av, bv)
+		}
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv)
+		}
+	}
+	return 0
+}
+
+// selectFields keeps only the requested, sort and alwaysSelected fields, as a Select projection does.
+// No requested fields keeps the whole event.
+func selectFields(e domain.Event, fields, sortFields []string) domain.Event {
+	if len(fields) == 0 {
+		return e
+	}
+	v := reflect.ValueOf(&e).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := firestoreName(v.Type().Field(i))
+		if !slices.Contains(fields, name) && !slices.Contains(sortFields, name) && !slices.Contains(alwaysSelected, name) {
+			v.Field(i).SetZero()
+		}
+	}
+	return e
+}
+
+// applyUpdates sets the fields named by the firestore tags of domain.Event. Values must have the
+// field's kind (numbers convert between sizes); unknown keys are ignored.
+func applyUpdates(e *domain.Event, updates map[string]interface{}) error {
+	v := reflect.ValueOf(e).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		value, ok := updates[firestoreName(v.Type().Field(i))]
+		if !ok {
+			continue
+		}
+		field := v.Field(i)
+		if value == nil {
+			field.SetZero()
+			continue
+		}
+		val := reflect.ValueOf(value)
+		switch {
+		case val.Type().AssignableTo(field.Type()):
+			field.Set(val)
+		case isNumber(val.Kind()) && isNumber(field.Kind()),
+			val.Kind() == reflect.String && field.Kind() == reflect.String:
+			field.Set(val.Convert(field.Type()))
+		default:
+			return fmt.Errorf("cannot set %s (%s) to %T", v.Type().Field(i).Name, field.Type(), value)
+		}
+	}
+	return nil
+}
+
+func firestoreName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("firestore"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func isNumber(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// memoryTrackingRepo is the in-memory TrackingRepository, see memoryEventRepo
+type memoryTrackingRepo struct {
+	mu     sync.RWMutex
+	tracks map[string]domain.TrackingEvent
+	nextID int
+	// watchers receive newly stored events; a watcher that falls behind misses events
+	watchers map[chan domain.TrackingEvent]struct{}
+
+	tenantsMu sync.Mutex
+	tenants   map[string]*memoryTrackingRepo // as in memoryEventRepo
+}
+
+// NewMemoryTrackingRepository returns an empty, thread-safe in-memory TrackingRepository
+func NewMemoryTrackingRepository() TrackingRepository {
+	return &memoryTrackingRepo{tracks: make(map[string]domain.TrackingEvent), tenants: make(map[string]*memoryTrackingRepo)}
+}
+
+// scope returns the repository holding the tracking of the tenant in ctx
+func (r *memoryTrackingRepo) scope(ctx context.Context) *memoryTrackingRepo {
+	tenantID := domain.TenantFromContext(ctx)
+	if tenantID == "" || r.tenants == nil {
+		return r
+	}
+	r.tenantsMu.Lock()
+	defer r.tenantsMu.Unlock()
+	tenant, ok := r.tenants[tenantID]
+	if !ok {
+		tenant = &memoryTrackingRepo{tracks: make(map[string]domain.TrackingEvent)}
+		r.tenants[tenantID] = tenant
+	}
+	return tenant
+}
+
+// SaveTracking stores events without an Id under a generated key, leaving their Id empty as Add does
+func (r *memoryTrackingRepo) SaveTracking(ctx context.Context, tracking *domain.TrackingEvent) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := tracking.Id
+	if key == "" {
+		r.nextID++
+		key = "auto-" + strconv.Itoa(r.nextID)
+	}
+	if _, ok := r.tracks[key]; ok && tracking.ClientEventID != "" {
+		return nil
+	}
+	r.tracks[key] = *tracking
+	for ch := range r.watchers {
+		select {
+		case ch <- *tracking:
+		default:
+		}
+	}
+	return nil
+}
+
+// memoryWatchBuffer is how many events a slow watcher may lag behind before it misses some
+const memoryWatchBuffer = 64
+
+func (r *memoryTrackingRepo) WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+	r = r.scope(ctx)
+	ch := make(chan domain.TrackingEvent, memoryWatchBuffer)
+	r.mu.Lock()
+	if r.watchers == nil {
+		r.watchers = make(map[chan domain.TrackingEvent]struct{})
+	}
+	r.watchers[ch] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.watchers, ch)
+		r.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case t := <-ch:
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *memoryTrackingRepo) ListTracking(ctx context.Context) ([]domain.TrackingEvent, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var tracks []domain.TrackingEvent
+	for _, t := range r.tracks {
+		tracks = append(tracks, t)
+	}
+	slices.SortFunc(tracks, func(a, b domain.TrackingEvent) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return tracks, nil
+}
+
+func (r *memoryTrackingRepo) StreamTracking(ctx context.Context, since time.Time, fn func(domain.TrackingEvent) error) error {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	var tracks []domain.TrackingEvent
+	for _, t := range r.tracks {
+		if !t.CreatedAt.Before(since) {
+			tracks = append(tracks, t)
+		}
+	}
+	r.mu.RUnlock()
+	for _, t := range tracks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryTrackingRepo) ListSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tracks := []domain.TrackingEvent{}
+	for _, t := range r.tracks {
+		if t.SessionID == sessionID {
+			tracks = append(tracks, t)
+		}
+	}
+	slices.SortFunc(tracks, func(a, b domain.TrackingEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return tracks, nil
+}
+
+func (r *memoryTrackingRepo) PurgeTracking(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	purged := 0
+	for key, t := range r.tracks {
+		if purged >= limit {
+			break
+		}
+		if t.CreatedAt.Before(cutoff) {
+			delete(r.tracks, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (r *memoryTrackingRepo) DeleteTracking(ctx context.Context, id string) error {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tracks[id]; !ok {
+		return domain.ErrNotFound("tracking event not found")
+	}
+	delete(r.tracks, id)
+	return nil
+}
+
+func (r *memoryTrackingRepo) DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error) {
+	r = r.scope(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted := 0
+	for key, t := range r.tracks {
+		if deleted >= limit {
+			break
+		}
+		if filter.Action != "" && t.Action != filter.Action {
+			continue
+		}
+		if !filter.OlderThan.IsZero() && !t.CreatedAt.Before(filter.OlderThan) {
+			continue
+		}
+		delete(r.tracks, key)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// TrackingStats counts the stored rows, so unlike the Firestore rollups it forgets purged ones
+func (r *memoryTrackingRepo) TrackingStats(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+	r = r.scope(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	buckets := make(map[time.Time]*domain.TrackingStats)
+	for _, t := range r.tracks {
+		start := domain.TrackingBucketStart(t.CreatedAt, interval)
+		if start.Before(from) || !start.Before(to) {
+			continue
+		}
+		bucket, ok := buckets[start]
+		if !ok {
+			bucket = &domain.TrackingStats{Start: start, Counts: make(map[string]int64)}
+			buckets[start] = bucket
+		}
+		bucket.Counts[t.Action]++
+		bucket.Total++
+	}
+	stats := make([]domain.TrackingStats, 0, len(buckets))
+	for _, bucket := range buckets {
+		stats = append(stats, *bucket)
+	}
+	slices.SortFunc(stats, func(a, b domain.TrackingStats) int { return a.Start.Compare(b.Start) })
+	return stats, nil
+}
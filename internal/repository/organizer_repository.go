@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const CollectionOrganizers = "organizers"
+
+type OrganizerRepository interface {
+	List(ctx context.Context) ([]domain.Organizer, error)
+	GetByID(ctx context.Context, id string) (*domain.Organizer, error)
+	GetMulti(ctx context.Context, ids []string) ([]domain.Organizer, error)
+	Save(ctx context.Context, organizer *domain.Organizer) error
+	Update(ctx context.Context, id string, updates map[string]interface{}) error
+	Delete(ctx context.Context, id string) error
+}
+
+type organizerRepo struct {
+	client *firestore.Client
+}
+
+func NewOrganizerRepository(client *firestore.Client) OrganizerRepository {
+	return &organizerRepo{client: client}
+}
+
+func (r *organizerRepo) List(ctx context.Context) ([]domain.Organizer, error) {
+	iter := r.client.Collection(CollectionOrganizers).OrderBy("name", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	organizers := []domain.Organizer{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var o domain.Organizer
+		if err := doc.DataTo(&o); err != nil {
+			return nil, err
+		}
+		organizers = append(organizers, o)
+	}
+	return organizers, nil
+}
+
+func (r *organizerRepo) GetByID(ctx context.Context, id string) (*domain.Organizer, error) {
+	doc, err := r.client.Collection(CollectionOrganizers).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrNotFound("organizer not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var organizer domain.Organizer
+	if err := doc.DataTo(&organizer); err != nil {
+		return nil, err
+	}
+	return &organizer, nil
+}
+
+// GetMulti fetches the organizers with the given Ids in one round trip; Ids without a document are left out
+func (r *organizerRepo) GetMulti(ctx context.Context, ids []string) ([]domain.Organizer, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.Collection(CollectionOrganizers).Doc(id)
+	}
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	organizers := make([]domain.Organizer, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var o domain.Organizer
+		if err := doc.DataTo(&o); err != nil {
+			return nil, err
+		}
+		organizers = append(organizers, o)
+	}
+	return organizers, nil
+}
+
+func (r *organizerRepo) Save(ctx context.Context, organizer *domain.Organizer) error {
+	_, err := r.client.Collection(CollectionOrganizers).Doc(organizer.Id).Set(ctx, organizer)
+	return err
+}
+
+func (r *organizerRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	_, err := r.client.Collection(CollectionOrganizers).Doc(id).Set(ctx, updates, firestore.MergeAll)
+	return err
+}
+
+func (r *organizerRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Collection(CollectionOrganizers).Doc(id).Delete(ctx)
+	return err
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// errInvalidPageToken covers malformed, tampered and foreign tokens alike; AES-GCM cannot tell them apart
+var errInvalidPageToken = domain.ErrValidation("invalid page token: it was altered or issued for a different query")
+
+// pageTokenCodec seals page cursors with AES-GCM, so clients can neither read the sort values a token
+// carries nor forge one. The query's filters and sort are authenticated alongside, so a token only
+// resumes the query that issued it.
+type pageTokenCodec struct {
+	aead cipher.AEAD
+}
+
+// newPageTokenCodec derives the key from secret. Without a secret a random key is used, which
+// only suits a single instance: tokens then stop working across instances and restarts.
+func newPageTokenCodec(secret []byte) *pageTokenCodec {
+	var key [32]byte
+	if len(secret) > 0 {
+		key = sha256.Sum256(secret)
+	} else {
+		_, _ = rand.Read(key[:])
+	}
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err) // unreachable: a 32-byte key is always valid
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return &pageTokenCodec{aead: aead}
+}
+
+func (c *pageTokenCodec) seal(cursor pageCursor, search domain.SearchRequest) string {
+	plain, _ := json.Marshal(cursor)
+	nonce := make([]byte, c.aead.NonceSize())
+	_, _ = rand.Read(nonce)
+	sealed := c.aead.Seal(nonce, nonce, plain, pageTokenScope(search))
+	return base64.URLEncoding.EncodeToString(sealed)
+}
+
+func (c *pageTokenCodec) open(token string, search domain.SearchRequest) (*pageCursor, error) {
+	sealed, err := base64.URLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, errInvalidPageToken
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, pageTokenScope(search))
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(plain, &cursor); err != nil {
+		return nil, errInvalidPageToken
+	}
+	return &cursor, nil
+}
+
+// pageTokenScope identifies the query a token belongs to: its filters and sort order.
+// Page size and sparse fieldsets may change between pages, and a relative window ("upcoming")
+// is identified by name since its resolved bounds move with the clock.
+func pageTokenScope(search domain.SearchRequest) []byte {
+	filters := search.Filters
+	if filters.When != "" {
+		filters.StartDate, filters.EndDate = nil, nil
+	}
+	scope, _ := json.Marshal(struct {
+		Filters   domain.FilterRequest
+		Key       string
+		Direction string
+	}{filters, search.Sorting.SortKey, search.Sorting.SortDirection})
+	return scope
+}
+
+// pageLimit is the page size List serves: 20 by default, at most 100
+func pageLimit(search domain.SearchRequest) int {
+	limit := search.Sorting.PageSize
+	if limit <= 0 {
+		limit = 20
+	}
+	return min(limit, 100)
+}
+
+// openCursor opens the request's page token into values matching sortFields, with the time
+// fields parsed back from JSON. It returns nil values when the request has no token.
+func (c *pageTokenCodec) openCursor(search domain.SearchRequest, sortFields []string) ([]interface{}, bool, error) {
+	if search.Sorting.PageToken == "" {
+		return nil, false, nil
+	}
+	cursor, err := c.open(search.Sorting.PageToken, search)
+	if err != nil {
+		return nil, false, err
+	}
+	cursorVals := cursor.Values
+
+	// Safety Check: Cursor length must match the number of OrderBy fields
+	if len(cursorVals) != len(sortFields) {
+		return nil, false, errInvalidPageToken
+	}
+
+	// Correctly parse time strings based on the field type in that position
+	for i, field := range sortFields {
+		switch field {
+		case "created_at", "start_time", "end_time":
+			if strVal, ok := cursorVals[i].(string); ok {
+				t, err := time.Parse(time.RFC3339, strVal)
+				if err == nil {
+					cursorVals[i] = t
+				}
+			}
+		}
+	}
+	return cursorVals, cursor.Direction == cursorPrev, nil
+}
+
+// pageMeta seals the tokens around a page. A full page means there may be more items in the
+// direction of travel; any token at all means there is a page in the opposite direction.
+func (c *pageTokenCodec) pageMeta(events []domain.Event, limit int, backward bool, search domain.SearchRequest, sortFields []string) domain.Meta {
+	var meta domain.Meta
+	if len(events) == 0 {
+		return meta
+	}
+	hasMore := len(events) == limit
+	hasToken := search.Sorting.PageToken != ""
+
+	if (!backward && hasMore) || (backward && hasToken) {
+		meta.NextPageToken = c.seal(pageCursor{Direction: cursorNext, Values: cursorValuesFor(&events[len(events)-1], sortFields)}, search)
+	}
+	if (backward && hasMore) || (!backward && hasToken) {
+		meta.PrevPageToken = c.seal(pageCursor{Direction: cursorPrev, Values: cursorValuesFor(&events[0], sortFields)}, search)
+	}
+	return meta
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const CollectionPushSubscriptions = "push_subscriptions"
+
+type PushSubscriptionRepository interface {
+	SavePushSubscription(ctx context.Context, sub *domain.PushSubscription) error
+	ListPushSubscriptions(ctx context.Context, userID string) ([]domain.PushSubscription, error)
+	// DeletePushSubscription only deletes a subscription owned by userID
+	DeletePushSubscription(ctx context.Context, userID, id string) error
+	// ListPushTargets returns the subscriptions matching an event in city of eventType
+	ListPushTargets(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error)
+	// DeletePushTokens removes every subscription of tokens FCM reported as unregistered
+	DeletePushTokens(ctx context.Context, tokens []string) error
+}
+
+type pushSubscriptionRepo struct {
+	client *firestore.Client
+}
+
+func NewPushSubscriptionRepository(client *firestore.Client) PushSubscriptionRepository {
+	return &pushSubscriptionRepo{client: client}
+}
+
+func (r *pushSubscriptionRepo) SavePushSubscription(ctx context.Context, sub *domain.PushSubscription) error {
+	_, err := r.client.Collection(CollectionPushSubscriptions).Doc(sub.Id).Set(ctx, sub)
+	return err
+}
+
+func (r *pushSubscriptionRepo) ListPushSubscriptions(ctx context.Context, userID string) ([]domain.PushSubscription, error) {
+	return r.collect(r.client.Collection(CollectionPushSubscriptions).Where("user_id", "==", userID).Documents(ctx))
+}
+
+func (r *pushSubscriptionRepo) DeletePushSubscription(ctx context.Context, userID, id string) error {
+	ref := r.client.Collection(CollectionPushSubscriptions).Doc(id)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return domain.ErrNotFound("subscription not found")
+		}
+		if err != nil {
+			return err
+		}
+		// Another user's subscription is reported as missing rather than forbidden
+		if owner, _ := doc.DataAt("user_id"); owner != userID {
+			return domain.ErrNotFound("subscription not found")
+		}
+		return tx.Delete(ref)
+	})
+}
+
+func (r *pushSubscriptionRepo) ListPushTargets(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error) {
+	coll := r.client.Collection(CollectionPushSubscriptions)
+	// Followers of the whole city plus followers of this type in the city
+	types := []domain.EventType{""}
+	if eventType != "" {
+		types = append(types, eventType)
+	}
+	return r.collect(coll.Where("city", "==", city).Where("type", "in", types).Documents(ctx))
+}
+
+func (r *pushSubscriptionRepo) DeletePushTokens(ctx context.Context, tokens []string) error {
+	coll := r.client.Collection(CollectionPushSubscriptions)
+	// "in" accepts at most 30 values
+	for start := 0; start < len(tokens); start += 30 {
+		docs, err := coll.Where("token", "in", tokens[start:min(start+30, len(tokens))]).Documents(ctx).GetAll()
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			continue
+		}
+		batch := r.client.Batch()
+		for _, doc := range docs {
+			batch.Delete(doc.Ref)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *pushSubscriptionRepo) collect(iter *firestore.DocumentIterator) ([]domain.PushSubscription, error) {
+	defer iter.Stop()
+
+	subs := []domain.PushSubscription{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var sub domain.PushSubscription
+		if err := doc.DataTo(&sub); err != nil {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// CollectionEventRevisions is a subcollection of each event document.
+// Firestore keeps subcollections when the parent is deleted, so history survives deletes.
+const CollectionEventRevisions = "event_revisions"
+
+type RevisionRepository interface {
+	Record(ctx context.Context, revisions ...*domain.EventRevision) error
+	ListRevisions(ctx context.Context, eventID string) ([]domain.EventRevision, error)
+}
+
+type revisionRepo struct {
+	client *firestore.Client
+}
+
+func NewRevisionRepository(client *firestore.Client) RevisionRepository {
+	return &revisionRepo{client: client}
+}
+
+func (r *revisionRepo) revisions(ctx context.Context, eventID string) *firestore.CollectionRef {
+	return tenantCollection(ctx, r.client, CollectionEvents).Doc(eventID).Collection(CollectionEventRevisions)
+}
+
+// Record stores revisions in batches of 500 (the Firestore batch limit)
+func (r *revisionRepo) Record(ctx context.Context, revisions ...*domain.EventRevision) error {
+	const batchSize = 500
+	for i := 0; i < len(revisions); i += batchSize {
+		end := min(i+batchSize, len(revisions))
+
+		batch := r.client.Batch()
+		for _, rev := range revisions[i:end] {
+			batch.Set(r.revisions(ctx, rev.EventID).Doc(rev.Id), rev)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListRevisions returns the history of an event, newest first
+func (r *revisionRepo) ListRevisions(ctx context.Context, eventID string) ([]domain.EventRevision, error) {
+	iter := r.revisions(ctx, eventID).OrderBy("created_at", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	revisions := []domain.EventRevision{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rev domain.EventRevision
+		if err := doc.DataTo(&rev); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
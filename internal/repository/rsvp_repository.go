@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectionRSVPs is a subcollection of each event document
+const CollectionRSVPs = "rsvps"
+
+type RSVPRepository interface {
+	// CreateRSVP stores the RSVP and increments the event's attendee_count atomically.
+	// A capacity of 0 means unlimited.
+	CreateRSVP(ctx context.Context, rsvp *domain.RSVP, capacity int) error
+	ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error)
+}
+
+type rsvpRepo struct {
+	client *firestore.Client
+}
+
+func NewRSVPRepository(client *firestore.Client) RSVPRepository {
+	return &rsvpRepo{client: client}
+}
+
+func (r *rsvpRepo) CreateRSVP(ctx context.Context, rsvp *domain.RSVP, capacity int) error {
+	eventRef := tenantCollection(ctx, r.client, CollectionEvents).Doc(rsvp.EventID)
+	rsvpRef := eventRef.Collection(CollectionRSVPs).Doc(rsvp.Id)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// 1. All reads must happen before any writes in a Firestore transaction
+		eventDoc, err := tx.Get(eventRef)
+		if status.Code(err) == codes.NotFound {
+			return domain.ErrNotFound("event not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Get(rsvpRef)
+		if err == nil {
+			return domain.ErrConflict("already registered")
+		}
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		// 2. Capacity re-check inside the transaction guards against concurrent registrations
+		var event domain.Event
+		if err := eventDoc.DataTo(&event); err != nil {
+			return err
+		}
+		if capacity > 0 && event.AttendeeCount >= capacity {
+			return domain.ErrConflict("event is full")
+		}
+
+		// 3. Writes
+		if err := tx.Create(rsvpRef, rsvp); err != nil {
+			return err
+		}
+		return tx.Update(eventRef, []firestore.Update{
+			{Path: "attendee_count", Value: firestore.Increment(1)},
+		})
+	})
+}
+
+func (r *rsvpRepo) ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error) {
+	iter := tenantCollection(ctx, r.client, CollectionEvents).Doc(eventID).Collection(CollectionRSVPs).
+		OrderBy("created_at", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	attendees := []domain.RSVP{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var a domain.RSVP
+		if err := doc.DataTo(&a); err != nil {
+			return nil, err
+		}
+		attendees = append(attendees, a)
+	}
+	return attendees, nil
+}
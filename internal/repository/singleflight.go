@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"encoding/json"
+	"slices"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// singleflightEventRepo collapses identical concurrent List calls into one query: a spike on the
+// homepage costs one Firestore read of the page rather than one per request. Only calls in flight
+// at the same time are shared; nothing is kept afterwards (see NewCachedEventRepository).
+type singleflightEventRepo struct {
+	EventRepository
+	group singleflight.Group
+}
+
+// NewSingleflightEventRepository deduplicates next's concurrent List calls by normalized request
+func NewSingleflightEventRepository(next EventRepository) EventRepository {
+	return &singleflightEventRepo{EventRepository: next}
+}
+
+type listResult struct {
+	events []domain.Event
+	meta   domain.Meta
+}
+
+// List waits for an identical query already in flight, or runs one that later callers join.
+// The query runs detached from the cancellation of the caller that started it, so one client
+// going away does not fail the others; each caller still stops waiting when its own ctx ends.
+func (r *singleflightEventRepo) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	ch := r.group.DoChan(listFlightKey(ctx, search), func() (interface{}, error) {
+		events, meta, err := r.EventRepository.List(context.WithoutCancel(ctx), search)
+		return listResult{events: events, meta: meta}, err
+	})
+	select {
+	case <-ctx.Done():
+		return nil, domain.Meta{}, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, domain.Meta{}, res.Err
+		}
+		// Callers own their page: the service and handlers may modify the events they get
+		page := res.Val.(listResult)
+		meta := page.meta
+		meta.Fields = slices.Clone(meta.Fields)
+		return slices.Clone(page.events), meta, nil
+	}
+}
+
+// listFlightKey identifies requests with the same result. Filters and sort stay as given, since page
+// tokens are sealed to them; the page size is keyed as served, the fieldset as a set, and the
+// "upcoming" and "past" windows by name, as their bounds are the current instant. Tenants never share.
+func listFlightKey(ctx context.Context, search domain.SearchRequest) string {
+	search.Sorting.PageSize = pageLimit(search)
+	search.Fields = slices.Sorted(slices.Values(search.Fields))
+	if search.Filters.When == domain.WhenUpcoming || search.Filters.When == domain.WhenPast {
+		search.Filters.StartDate, search.Filters.EndDate = nil, nil
+	}
+	key, _ := json.Marshal(search)
+	return domain.TenantFromContext(ctx) + "|" + string(key)
+}
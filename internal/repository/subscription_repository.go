@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const CollectionSubscriptions = "subscriptions"
+
+type SubscriptionRepository interface {
+	GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error)
+	SaveSubscription(ctx context.Context, sub *domain.Subscription) error
+	DeleteSubscription(ctx context.Context, userID string) error
+	// ListEmailSubscribers returns the opted-in subscriptions following city
+	ListEmailSubscribers(ctx context.Context, city string) ([]domain.Subscription, error)
+}
+
+type subscriptionRepo struct {
+	client *firestore.Client
+}
+
+func NewSubscriptionRepository(client *firestore.Client) SubscriptionRepository {
+	return &subscriptionRepo{client: client}
+}
+
+func (r *subscriptionRepo) GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error) {
+	doc, err := r.client.Collection(CollectionSubscriptions).Doc(userID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrNotFound("subscription not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sub domain.Subscription
+	if err := doc.DataTo(&sub); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *subscriptionRepo) SaveSubscription(ctx context.Context, sub *domain.Subscription) error {
+	_, err := r.client.Collection(CollectionSubscriptions).Doc(sub.UserID).Set(ctx, sub)
+	return err
+}
+
+func (r *subscriptionRepo) DeleteSubscription(ctx context.Context, userID string) error {
+	_, err := r.client.Collection(CollectionSubscriptions).Doc(userID).Delete(ctx)
+	return err
+}
+
+func (r *subscriptionRepo) ListEmailSubscribers(ctx context.Context, city string) ([]domain.Subscription, error) {
+	iter := r.client.Collection(CollectionSubscriptions).
+		Where("cities", "array-contains", city).
+		Where("email_opt_in", "==", true).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var subs []domain.Subscription
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var sub domain.Subscription
+		if err := doc.DataTo(&sub); err != nil {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
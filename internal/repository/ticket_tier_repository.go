@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectionTicketTiers is a subcollection of each event document
+const CollectionTicketTiers = "ticket_tiers"
+
+type TicketTierRepository interface {
+	ListTiers(ctx context.Context, eventID string) ([]domain.TicketTier, error)
+	GetTier(ctx context.Context, eventID, tierID string) (*domain.TicketTier, error)
+	SaveTier(ctx context.Context, tier *domain.TicketTier) error
+}
+
+type ticketTierRepo struct {
+	client *firestore.Client
+}
+
+func NewTicketTierRepository(client *firestore.Client) TicketTierRepository {
+	return &ticketTierRepo{client: client}
+}
+
+func (r *ticketTierRepo) tiers(ctx context.Context, eventID string) *firestore.CollectionRef {
+	return tenantCollection(ctx, r.client, CollectionEvents).Doc(eventID).Collection(CollectionTicketTiers)
+}
+
+func (r *ticketTierRepo) ListTiers(ctx context.Context, eventID string) ([]domain.TicketTier, error) {
+	iter := r.tiers(ctx, eventID).OrderBy("price", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	tiers := []domain.TicketTier{}
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var t domain.TicketTier
+		if err := doc.DataTo(&t); err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, t)
+	}
+	return tiers, nil
+}
+
+func (r *ticketTierRepo) GetTier(ctx context.Context, eventID, tierID string) (*domain.TicketTier, error) {
+	doc, err := r.tiers(ctx, eventID).Doc(tierID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrNotFound("ticket tier not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tier domain.TicketTier
+	if err := doc.DataTo(&tier); err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *ticketTierRepo) SaveTier(ctx context.Context, tier *domain.TicketTier) error {
+	_, err := r.tiers(ctx, tier.EventID).Doc(tier.Id).Set(ctx, tier)
+	return err
+}
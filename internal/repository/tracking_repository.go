@@ -4,16 +4,59 @@ import (
 	"bibently.com/backend/internal/domain"
 	"context"
 	"errors"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const CollectionTracking = "tracking"
+const (
+	CollectionTracking = "tracking"
+	// CollectionTrackingRollups holds sharded hourly and daily counts of tracking events per action.
+	// Unlike the rows, they are kept past the retention purge and carry no user data.
+	CollectionTrackingRollups = "tracking_rollups"
+
+	// maxSessionEvents bounds the events returned for one session
+	maxSessionEvents = 1000
+
+	// trackingRollupShards spreads the writes of one bucket, as viewShardCount does for views
+	trackingRollupShards = 10
+
+	// purgeChunk is the number of tracking documents deleted per batch
+	purgeChunk = 500
+	// deleteChunk leaves room in a batch for the two rollup updates each deleted document may need
+	deleteChunk = 150
+)
 
 type TrackingRepository interface {
+	// SaveTracking stores the event. One carrying a ClientEventID is stored once: resending it
+	// with the same Id succeeds without recording it again.
 	SaveTracking(ctx context.Context, tracking *domain.TrackingEvent) error
 	ListTracking(ctx context.Context) ([]domain.TrackingEvent, error)
+	// ListSession returns the events of a session, oldest first
+	ListSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error)
+	// PurgeTracking deletes up to limit tracking documents created before cutoff and returns how many were deleted
+	PurgeTracking(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	// DeleteTracking deletes one tracking event and takes it out of the rollups, unlike the
+	// retention purge which keeps the counts
+	DeleteTracking(ctx context.Context, id string) error
+	// DeleteTrackingWhere deletes up to limit tracking events matching filter, taking them out of the
+	// rollups, and returns how many were deleted
+	DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error)
+	// TrackingStats counts tracking events per action in the interval buckets starting in [from, to).
+	// Buckets without events are left out.
+	TrackingStats(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error)
+	// StreamTracking calls fn for every tracking event created at or after since, in no particular
+	// order, stopping at the first error fn returns
+	StreamTracking(ctx context.Context, since time.Time, fn func(domain.TrackingEvent) error) error
+	// WatchTracking calls fn for every tracking event stored after the call, until ctx is cancelled
+	// (returning nil) or fn returns an error. Events already stored are not replayed.
+	WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error
 }
 
 type trackingRepo struct {
@@ -24,17 +67,121 @@ func NewTrackingRepository(client *firestore.Client) TrackingRepository {
 	return &trackingRepo{client: client}
 }
 
+// SaveTracking stores the event and counts it in its hourly and daily rollups, in one batch
 func (r *trackingRepo) SaveTracking(ctx context.Context, tracking *domain.TrackingEvent) error {
+	coll := tenantCollection(ctx, r.client, CollectionTracking)
+	ref := coll.NewDoc()
 	if tracking.Id != "" {
-		_, err := r.client.Collection(CollectionTracking).Doc(tracking.Id).Set(ctx, tracking)
-		return err
+		ref = coll.Doc(tracking.Id)
+	}
+
+	batch := r.client.Batch()
+	if tracking.ClientEventID != "" {
+		// Create fails the whole batch on a resend, so the rollups are not counted twice either
+		batch.Create(ref, tracking)
+	} else {
+		batch.Set(ref, tracking)
+	}
+	rollups := tenantCollection(ctx, r.client, CollectionTrackingRollups)
+	shard := rand.IntN(trackingRollupShards)
+	for _, interval := range []string{domain.TrackingIntervalHour, domain.TrackingIntervalDay} {
+		start := domain.TrackingBucketStart(tracking.CreatedAt, interval)
+		batch.Set(rollups.Doc(rollupDocID(interval, start, shard)), map[string]interface{}{
+			rollupStartField(interval): start,
+			"counts":                   map[string]interface{}{tracking.Action: firestore.Increment(1)},
+		}, firestore.MergeAll)
+	}
+	_, err := batch.Commit(ctx)
+	if status.Code(err) == codes.AlreadyExists {
+		return nil
 	}
-	_, _, err := r.client.Collection(CollectionTracking).Add(ctx, tracking)
 	return err
 }
 
+// trackingRollup is one shard of a bucket. Hourly and daily shards set different start fields,
+// so each interval is read with a single-field range query.
+type trackingRollup struct {
+	HourStart time.Time        `firestore:"hour_start"`
+	DayStart  time.Time        `firestore:"day_start"`
+	Counts    map[string]int64 `firestore:"counts"`
+}
+
+func rollupStartField(interval string) string {
+	return interval + "_start"
+}
+
+func rollupDocID(interval string, start time.Time, shard int) string {
+	return interval + "_" + start.Format("2006010215") + "_" + strconv.Itoa(shard)
+}
+
+// uncountRollups adds to batch the decrements taking tracks out of their hourly and daily rollups.
+// Any shard of a bucket will do, since the stats sum them.
+func uncountRollups(rollups *firestore.CollectionRef, batch *firestore.WriteBatch, tracks []domain.TrackingEvent) {
+	type bucket struct {
+		interval string
+		start    time.Time
+	}
+	counts := make(map[bucket]map[string]int)
+	for _, t := range tracks {
+		for _, interval := range []string{domain.TrackingIntervalHour, domain.TrackingIntervalDay} {
+			b := bucket{interval, domain.TrackingBucketStart(t.CreatedAt, interval)}
+			if counts[b] == nil {
+				counts[b] = make(map[string]int)
+			}
+			counts[b][t.Action]++
+		}
+	}
+	for b, actions := range counts {
+		decrements := make(map[string]interface{}, len(actions))
+		for action, n := range actions {
+			decrements[action] = firestore.Increment(-n)
+		}
+		batch.Set(rollups.Doc(rollupDocID(b.interval, b.start, rand.IntN(trackingRollupShards))), map[string]interface{}{
+			rollupStartField(b.interval): b.start,
+			"counts":                     decrements,
+		}, firestore.MergeAll)
+	}
+}
+
+// TrackingStats sums the rollup shards of the buckets; raw rows are not read
+func (r *trackingRepo) TrackingStats(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+	field := rollupStartField(interval)
+	iter := tenantCollection(ctx, r.client, CollectionTrackingRollups).
+		Where(field, ">=", from).Where(field, "<", to).OrderBy(field, firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	var stats []domain.TrackingStats
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rollup trackingRollup
+		if err := doc.DataTo(&rollup); err != nil {
+			return nil, err
+		}
+		start := rollup.DayStart
+		if interval == domain.TrackingIntervalHour {
+			start = rollup.HourStart
+		}
+		// Shards of a bucket arrive together, ordered by start
+		if len(stats) == 0 || !stats[len(stats)-1].Start.Equal(start) {
+			stats = append(stats, domain.TrackingStats{Start: start, Counts: make(map[string]int64)})
+		}
+		bucket := &stats[len(stats)-1]
+		for action, n := range rollup.Counts {
+			bucket.Counts[action] += n
+			bucket.Total += n
+		}
+	}
+	return stats, nil
+}
+
 func (r *trackingRepo) ListTracking(ctx context.Context) ([]domain.TrackingEvent, error) {
-	iter := r.client.Collection(CollectionTracking).OrderBy("created_at", firestore.Desc).Documents(ctx)
+	iter := tenantCollection(ctx, r.client, CollectionTracking).OrderBy("created_at", firestore.Desc).Documents(ctx)
 	defer iter.Stop()
 
 	var tracks []domain.TrackingEvent
@@ -54,3 +201,135 @@ func (r *trackingRepo) ListTracking(ctx context.Context) ([]domain.TrackingEvent
 	}
 	return tracks, nil
 }
+
+// StreamTracking reads only the fields aggregations use: id, action, event_id and created_at.
+// The single range filter needs no composite index.
+func (r *trackingRepo) StreamTracking(ctx context.Context, since time.Time, fn func(domain.TrackingEvent) error) error {
+	iter := tenantCollection(ctx, r.client, CollectionTracking).Where("created_at", ">=", since).
+		Select("id", "action", "event_id", "created_at").Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var t domain.TrackingEvent
+		if err := doc.DataTo(&t); err != nil {
+			continue
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *trackingRepo) DeleteTracking(ctx context.Context, id string) error {
+	ref := tenantCollection(ctx, r.client, CollectionTracking).Doc(id)
+	doc, err := ref.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrNotFound("tracking event not found")
+	}
+	if err != nil {
+		return err
+	}
+	var t domain.TrackingEvent
+	if err := doc.DataTo(&t); err != nil {
+		return err
+	}
+
+	batch := r.client.Batch()
+	// The precondition keeps a concurrent delete from uncounting the event twice
+	batch.Delete(ref, firestore.Exists)
+	uncountRollups(tenantCollection(ctx, r.client, CollectionTrackingRollups), batch, []domain.TrackingEvent{t})
+	_, err = batch.Commit(ctx)
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrNotFound("tracking event not found")
+	}
+	return err
+}
+
+// DeleteTrackingWhere filtering on both fields relies on the (action, created_at) index
+func (r *trackingRepo) DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error) {
+	q := tenantCollection(ctx, r.client, CollectionTracking).Query
+	if filter.Action != "" {
+		q = q.Where("action", "==", filter.Action)
+	}
+	if !filter.OlderThan.IsZero() {
+		q = q.Where("created_at", "<", filter.OlderThan)
+	}
+	rollups := tenantCollection(ctx, r.client, CollectionTrackingRollups)
+
+	deleted := 0
+	for deleted < limit {
+		docs, err := q.Limit(min(deleteChunk, limit-deleted)).Documents(ctx).GetAll()
+		if err != nil {
+			return deleted, err
+		}
+		if len(docs) == 0 {
+			break
+		}
+
+		batch := r.client.Batch()
+		tracks := make([]domain.TrackingEvent, 0, len(docs))
+		for _, doc := range docs {
+			var t domain.TrackingEvent
+			if err := doc.DataTo(&t); err == nil {
+				tracks = append(tracks, t)
+			}
+			batch.Delete(doc.Ref)
+		}
+		uncountRollups(rollups, batch, tracks)
+		if _, err := batch.Commit(ctx); err != nil {
+			return deleted, err
+		}
+		deleted += len(docs)
+	}
+	return deleted, nil
+}
+
+// ListSession sorts in memory: a session is small, and an equality filter alone needs no composite index
+func (r *trackingRepo) ListSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+	docs, err := tenantCollection(ctx, r.client, CollectionTracking).
+		Where("session_id", "==", sessionID).Limit(maxSessionEvents).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	tracks := make([]domain.TrackingEvent, 0, len(docs))
+	for _, doc := range docs {
+		var t domain.TrackingEvent
+		if err := doc.DataTo(&t); err != nil {
+			continue
+		}
+		tracks = append(tracks, t)
+	}
+	slices.SortStableFunc(tracks, func(a, b domain.TrackingEvent) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return tracks, nil
+}
+
+func (r *trackingRepo) PurgeTracking(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	q := tenantCollection(ctx, r.client, CollectionTracking).Where("created_at", "<", cutoff)
+
+	purged := 0
+	for purged < limit {
+		docs, err := q.Limit(min(purgeChunk, limit-purged)).Documents(ctx).GetAll()
+		if err != nil {
+			return purged, err
+		}
+		if len(docs) == 0 {
+			break
+		}
+
+		batch := r.client.Batch()
+		for _, doc := range docs {
+			batch.Delete(doc.Ref)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return purged, err
+		}
+		purged += len(docs)
+	}
+	return purged, nil
+}
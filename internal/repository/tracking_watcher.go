@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchTracking listens to the tracking of the tenant in ctx created from now on, so the listener
+// never loads the stored history, and sees events stored by every instance
+func (r *trackingRepo) WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+	it := tenantCollection(ctx, r.client, CollectionTracking).
+		Where("created_at", ">=", time.Now().UTC()).Snapshots(ctx)
+	defer it.Stop()
+
+	for {
+		snap, err := it.Next()
+		if err != nil {
+			if ctx.Err() != nil || status.Code(err) == codes.Canceled || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		for _, ch := range snap.Changes {
+			if ch.Kind != firestore.DocumentAdded {
+				continue
+			}
+			var t domain.TrackingEvent
+			if err := ch.Doc.DataTo(&t); err != nil {
+				return err
+			}
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserRepository interface {
+	// GetProfile returns the fields of users/{uid}, or nil when the document does not exist
+	GetProfile(ctx context.Context, uid string) (map[string]interface{}, error)
+	// DeleteUserData removes the user's tracking events, RSVPs and favorites in the tenant in ctx,
+	// keeping the attendee and favorites counts of the events they pointed to in sync
+	DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error)
+}
+
+// eraseChunk bounds the documents deleted per batch; each adds at most one counter update,
+// well within the 500 writes of a batch
+const eraseChunk = 200
+
+type userRepo struct {
+	client *firestore.Client
+}
+
+func NewUserRepository(client *firestore.Client) UserRepository {
+	return &userRepo{client: client}
+}
+
+func (r *userRepo) GetProfile(ctx context.Context, uid string) (map[string]interface{}, error) {
+	doc, err := r.client.Collection(CollectionUsers).Doc(uid).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		// users/{uid} may exist only as the parent of subcollections such as favorites
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Data(), nil
+}
+
+func (r *userRepo) DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+	report := &domain.DataDeletionReport{UserID: uid}
+	events := tenantCollection(ctx, r.client, CollectionEvents)
+	var err error
+
+	tracking := tenantCollection(ctx, r.client, CollectionTracking).Where("user_id", "==", uid)
+	if report.TrackingEvents, err = r.deleteAll(ctx, tracking, nil, ""); err != nil {
+		return report, err
+	}
+
+	// RSVPs live under each event; the collection group query spans tenants, so only those under
+	// the events of this tenant are kept
+	rsvps := r.client.CollectionGroup(CollectionRSVPs).Where("user_id", "==", uid)
+	rsvpEvent := func(doc *firestore.DocumentSnapshot) *firestore.DocumentRef {
+		if event := doc.Ref.Parent.Parent; event.Parent.Path == events.Path {
+			return event
+		}
+		return nil
+	}
+	if report.RSVPs, err = r.deleteAll(ctx, rsvps, rsvpEvent, "attendee_count"); err != nil {
+		return report, err
+	}
+
+	// Favorites live under the user, whatever the tenant of their event; likewise only those of this
+	// tenant's events are kept, so favorites_count is decremented where it was incremented
+	favorites := r.client.Collection(CollectionUsers).Doc(uid).Collection(CollectionFavorites).Query
+	tenantID := domain.TenantFromContext(ctx)
+	favoriteEvent := func(doc *firestore.DocumentSnapshot) *firestore.DocumentRef {
+		if favoriteTenant, _ := doc.Data()["tenant_id"].(string); favoriteTenant == tenantID {
+			return events.Doc(doc.Ref.ID)
+		}
+		return nil
+	}
+	if report.Favorites, err = r.deleteAll(ctx, favorites, favoriteEvent, "favorites_count"); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// deleteAll deletes the documents of q in batches and returns how many it deleted. With eventOf,
+// each document counts toward counter on its event, which is decremented if it still exists;
+// documents eventOf maps to nil are skipped.
+func (r *userRepo) deleteAll(ctx context.Context, q firestore.Query, eventOf func(*firestore.DocumentSnapshot) *firestore.DocumentRef, counter string) (int, error) {
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	deleted := 0
+	var docs []*firestore.DocumentSnapshot
+	flush := func() error {
+		if len(docs) == 0 {
+			return nil
+		}
+		batch := r.client.Batch()
+		var eventRefs []*firestore.DocumentRef
+		for _, doc := range docs {
+			batch.Delete(doc.Ref)
+			if eventOf != nil {
+				eventRefs = append(eventRefs, eventOf(doc))
+			}
+		}
+		if len(eventRefs) > 0 {
+			// Updating a deleted event would fail the whole batch
+			snaps, err := r.client.GetAll(ctx, eventRefs)
+			if err != nil {
+				return err
+			}
+			for _, snap := range snaps {
+				if snap.Exists() {
+					batch.Update(snap.Ref, []firestore.Update{{Path: counter, Value: firestore.Increment(-1)}})
+				}
+			}
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return err
+		}
+		deleted += len(docs)
+		docs = docs[:0]
+		return nil
+	}
+
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return deleted, err
+		}
+		if eventOf != nil && eventOf(doc) == nil {
+			continue
+		}
+		if docs = append(docs, doc); len(docs) == eraseChunk {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	return deleted, flush()
+}
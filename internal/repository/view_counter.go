@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+
+	"cloud.google.com/go/firestore"
+)
+
+const (
+	// CollectionViewShards is a subcollection of each event holding distributed counter shards
+	CollectionViewShards = "view_shards"
+
+	// viewShardCount spreads writes across documents; a single Firestore document
+	// sustains roughly one write per second, so N shards allow ~N views/second.
+	viewShardCount = 10
+)
+
+// IncrementViews bumps a randomly chosen shard of the event's view counter
+func (r *eventRepo) IncrementViews(ctx context.Context, id string) error {
+	shard := strconv.Itoa(rand.IntN(viewShardCount))
+	ref := tenantCollection(ctx, r.client, CollectionEvents).Doc(id).Collection(CollectionViewShards).Doc(shard)
+	_, err := ref.Set(ctx, map[string]interface{}{
+		"count": firestore.Increment(1),
+	}, firestore.MergeAll)
+	return err
+}
+
+// GetViewCount sums all shards with a single aggregation query
+func (r *eventRepo) GetViewCount(ctx context.Context, id string) (int64, error) {
+	shards := tenantCollection(ctx, r.client, CollectionEvents).Doc(id).Collection(CollectionViewShards)
+	result, err := shards.NewAggregationQuery().WithSum("count", "total").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int64(aggregateNumber(result, "total")), nil
+}
@@ -0,0 +1,78 @@
+// Package secrets resolves Google Secret Manager references in configuration values.
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// Prefix marks a reference: sm://projects/{project}/secrets/{secret}[/versions/{version}]
+const Prefix = "sm://"
+
+// Resolver replaces Secret Manager references with the secret payload.
+// The API client is created on the first reference, so plain values cost nothing.
+type Resolver struct {
+	mu    sync.Mutex
+	svc   *secretmanager.Service
+	cache map[string]string
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{cache: make(map[string]string)}
+}
+
+// Getenv returns the environment variable key, resolved if it holds a reference
+func (r *Resolver) Getenv(ctx context.Context, key string) (string, error) {
+	val, err := r.Resolve(ctx, os.Getenv(key))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+	return val, nil
+}
+
+// Resolve returns value unchanged unless it starts with Prefix; then it returns the secret payload.
+// A reference without a version reads "latest".
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	name, ok := strings.CutPrefix(value, Prefix)
+	if !ok {
+		return value, nil
+	}
+	if !strings.HasPrefix(name, "projects/") || !strings.Contains(name, "/secrets/") {
+		return "", fmt.Errorf("invalid secret reference %q", value)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.cache[name]; ok {
+		return cached, nil
+	}
+	if r.svc == nil {
+		svc, err := secretmanager.NewService(ctx)
+		if err != nil {
+			return "", err
+		}
+		r.svc = svc
+	}
+
+	resp, err := r.svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("accessing secret %s: %w", name, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("decoding secret %s: %w", name, err)
+	}
+
+	r.cache[name] = string(data)
+	return r.cache[name], nil
+}
@@ -0,0 +1,130 @@
+// Package seed generates realistic fake events and tracking for local and dev databases
+// (cmd/seed, POST /dev/seed) and for tests that need volume rather than specific fixtures.
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"bibently.com/backend/internal/domain"
+
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// TrackingDays is how far back generated tracking goes, so it feeds stats and popularity
+const TrackingDays = 7
+
+type place struct {
+	country  string
+	lat, lng float64
+	timezone string
+}
+
+// places locates the default cities; other cities are generated without a location
+var places = map[string]place{
+	"Warsaw": {"PL", 52.2297, 21.0122, "Europe/Warsaw"},
+	"Krakow": {"PL", 50.0647, 19.9450, "Europe/Warsaw"},
+	"Gdansk": {"PL", 54.3520, 18.6466, "Europe/Warsaw"},
+	"Berlin": {"DE", 52.5200, 13.4050, "Europe/Berlin"},
+	"Prague": {"CZ", 50.0755, 14.4378, "Europe/Prague"},
+}
+
+var (
+	adjectives = []string{"Midnight", "Golden", "Electric", "Open Air", "Acoustic", "Winter", "Summer", "Late Night", "Indie", "Grand"}
+	topics     = map[domain.EventType][]string{
+		domain.TypeConcert:    {"Jazz Night", "Rock Session", "Piano Recital", "Hip-Hop Show", "Orchestra"},
+		domain.TypeFestival:   {"Music Festival", "Food Festival", "Film Festival", "Street Art Days"},
+		domain.TypeTheater:    {"Hamlet", "Musical", "Improv Theatre", "Opera Gala"},
+		domain.TypeStandUp:    {"Comedy Night", "Open Mic", "Stand-up Special"},
+		domain.TypeConference: {"Go Conference", "Cloud Summit", "Design Days", "Startup Forum"},
+		domain.TypeMeetup:     {"Board Games Meetup", "Runners Meetup", "Photography Walk", "Language Exchange"},
+		domain.TypeOther:      {"Market", "Workshop", "Exhibition"},
+	}
+	// hours is how long each type of event lasts
+	hours = map[domain.EventType]int{
+		domain.TypeConcert: 3, domain.TypeFestival: 48, domain.TypeTheater: 3, domain.TypeStandUp: 2,
+		domain.TypeConference: 8, domain.TypeMeetup: 2, domain.TypeOther: 4,
+	}
+	organizers = []string{"Live Nation PL", "City Culture House", "Indie Collective", "Tech Events Co", "Local Heroes"}
+	userAgents = []string{
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148 Safari/604.1",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/124.0 Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/124.0 Mobile Safari/537.36",
+	}
+	// actions are weighted like real traffic: mostly views, some clicks, few shares and purchases
+	actions = []string{"view", "view", "view", "view", "view", "view", "click", "click", "share", "purchase"}
+)
+
+// Generate returns req.Events events starting in [req.From, req.To) and req.TrackingPerEvent
+// tracking events about each, created in the TrackingDays before now. req must have its defaults
+// (domain.SeedRequest.WithDefaults). Ids derive from the seed and position, so tracking Ids are
+// client event Ids and a rerun writes the same documents.
+func Generate(req domain.SeedRequest, now time.Time) ([]*domain.Event, []domain.TrackingEvent) {
+	rng := rand.New(rand.NewPCG(uint64(req.Seed), 0))
+	span := req.To.Sub(req.From)
+
+	events := make([]*domain.Event, req.Events)
+	var tracking []domain.TrackingEvent
+	for i := range events {
+		typ := domain.DefaultEventTypes[rng.IntN(len(domain.DefaultEventTypes))]
+		city := req.Cities[rng.IntN(len(req.Cities))]
+		// Evenings on the half hour, mostly
+		start := req.From.Add(time.Duration(rng.Int64N(int64(span)))).Truncate(24 * time.Hour)
+		start = start.Add(time.Duration(17*60+30*rng.IntN(8)) * time.Minute)
+		if start.Before(req.From) || !start.Before(req.To) {
+			start = req.From
+		}
+		event := &domain.Event{
+			Id:            fmt.Sprintf("seed-%04d-%d", i, req.Seed),
+			EventName:     pick(rng, adjectives) + " " + pick(rng, topics[typ]),
+			City:          city,
+			Type:          typ,
+			OrganizerName: pick(rng, organizers),
+			Provider:      "seed",
+			StartTime:     start,
+			EndTime:       start.Add(time.Duration(hours[typ]) * time.Hour),
+			Price:         price(rng, typ),
+			Capacity:      []int{0, 50, 100, 300, 1000}[rng.IntN(5)],
+			CreatedAt:     now.Add(-time.Duration(rng.IntN(30*24)) * time.Hour),
+		}
+		if p, ok := places[city]; ok {
+			event.Country, event.Timezone = p.country, p.timezone
+			event.Location = &latlng.LatLng{Latitude: p.lat, Longitude: p.lng}
+		}
+		events[i] = event
+
+		// Popularity is skewed: a few events get most of the traffic
+		n := req.TrackingPerEvent
+		if rng.IntN(4) > 0 {
+			n = n / 4
+		}
+		for j := 0; j < n; j++ {
+			id := fmt.Sprintf("%s-t%03d", event.Id, j)
+			tracking = append(tracking, domain.TrackingEvent{
+				Action:        pick(rng, actions),
+				EventID:       event.Id,
+				ClientEventID: id,
+				SessionID:     fmt.Sprintf("seed-%d-s%03d", req.Seed, rng.IntN(max(req.Events*req.TrackingPerEvent/5, 1))),
+				UserAgent:     pick(rng, userAgents),
+				CreatedAt:     now.Add(-time.Duration(rng.Int64N(int64(TrackingDays * 24 * time.Hour)))).Truncate(time.Second),
+			})
+		}
+	}
+	return events, tracking
+}
+
+func pick[T any](rng *rand.Rand, items []T) T {
+	return items[rng.IntN(len(items))]
+}
+
+// price is free for some meetups and in the usual range of the type otherwise, in whole units
+func price(rng *rand.Rand, typ domain.EventType) float64 {
+	switch typ {
+	case domain.TypeMeetup:
+		return float64(rng.IntN(3) * 10)
+	case domain.TypeFestival, domain.TypeConference:
+		return float64(150 + rng.IntN(50)*10)
+	}
+	return float64(30 + rng.IntN(25)*10)
+}
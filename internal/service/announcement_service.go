@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/notify"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/tasks"
+	"context"
+	"errors"
+)
+
+// AnnounceEventPath is the worker route that notifies subscribers about one new event
+const AnnounceEventPath = "/internal/tasks/announce-event"
+
+// AnnouncementService tells subscribers about new events outside the request that created them:
+// Schedule enqueues a task and the task worker calls Announce, which Cloud Tasks retries on failure.
+type AnnouncementService interface {
+	Schedule(ctx context.Context, eventID string) error
+	// Announce notifies the event's subscribers. It is safe to repeat: delivered batches are
+	// recorded per event by the notifiers and skipped.
+	Announce(ctx context.Context, eventID string) error
+}
+
+type announcementService struct {
+	events   repository.EventRepository
+	notifier notify.Notifier
+	queue    tasks.Queue
+}
+
+func NewAnnouncementService(events repository.EventRepository, notifier notify.Notifier, queue tasks.Queue) AnnouncementService {
+	return &announcementService{events: events, notifier: notifier, queue: queue}
+}
+
+func (s *announcementService) Schedule(ctx context.Context, eventID string) error {
+	return s.queue.Enqueue(ctx, AnnounceEventPath, domain.AnnouncementTask{EventID: eventID})
+}
+
+func (s *announcementService) Announce(ctx context.Context, eventID string) error {
+	if eventID == "" {
+		return domain.ErrValidation("event id is required")
+	}
+	event, err := s.events.GetByID(ctx, eventID)
+	var notFound *domain.NotFoundError
+	if errors.As(err, &notFound) {
+		// Deleted before the task ran: there is nothing left to announce, and retrying will not change that
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.notifier.EventPublished(ctx, event)
+}
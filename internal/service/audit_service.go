@@ -0,0 +1,49 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+type AuditService interface {
+	RecordAudit(ctx context.Context, entry *domain.AuditEntry) error
+	ListAuditLogs(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+func NewAuditService(repo repository.AuditRepository) AuditService {
+	return &auditService{repo: repo}
+}
+
+func (s *auditService) RecordAudit(ctx context.Context, entry *domain.AuditEntry) error {
+	if entry.Id == "" {
+		entry.Id = uuid.New().String()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	return s.repo.SaveAuditEntry(ctx, entry)
+}
+
+func (s *auditService) ListAuditLogs(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if filter.Limit < 0 {
+		return nil, domain.ErrValidation("limit must not be negative")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	filter.Limit = min(filter.Limit, maxAuditPageSize)
+	return s.repo.ListAuditEntries(ctx, filter)
+}
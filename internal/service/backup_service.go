@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// restoreBatchSize is how many dump records are written together
+const restoreBatchSize = 500
+
+// collectionID is what a managed export accepts as a collection Id
+var collectionID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,100}$`)
+
+type BackupService interface {
+	// Managed reports whether managed exports and imports are configured (BACKUP_BUCKET)
+	Managed() bool
+	// StartExport exports the database to a new folder of the backup bucket
+	StartExport(ctx context.Context, req domain.BackupRequest) (*domain.BackupOperation, error)
+	// StartImport imports an export of the backup bucket, replacing documents with the same Ids
+	StartImport(ctx context.Context, req domain.RestoreRequest) (*domain.BackupOperation, error)
+	GetOperation(ctx context.Context, id string) (*domain.BackupOperation, error)
+	// Dump calls fn for every document of collections (default domain.DumpCollections)
+	Dump(ctx context.Context, collections []string, fn func(domain.BackupRecord) error) error
+	// Restore writes the records next returns until io.EOF. Invalid records, and lines next
+	// rejects with a *domain.ValidationError, are reported and skipped; other errors of next end
+	// the restore. Restored tracking is not added to the stats rollups.
+	Restore(ctx context.Context, next func() (domain.BackupRecord, error), conflict domain.RestoreConflict) (*domain.RestoreResult, error)
+}
+
+type backupService struct {
+	repo   repository.BackupRepository
+	bucket string
+}
+
+// NewBackupService backs up through repo; bucket ("" disables managed exports) is the Cloud
+// Storage bucket exports are written to and imports are read from
+func NewBackupService(repo repository.BackupRepository, bucket string) BackupService {
+	return &backupService{repo: repo, bucket: strings.TrimSuffix(strings.TrimPrefix(bucket, "gs://"), "/")}
+}
+
+func (s *backupService) Managed() bool {
+	return s.bucket != ""
+}
+
+func (s *backupService) StartExport(ctx context.Context, req domain.BackupRequest) (*domain.BackupOperation, error) {
+	if err := s.checkManaged(req.Collections); err != nil {
+		return nil, err
+	}
+	uri := "gs://" + s.bucket + "/backups/" + time.Now().UTC().Format("20060102T150405Z")
+	return s.repo.Export(ctx, uri, req.Collections)
+}
+
+func (s *backupService) StartImport(ctx context.Context, req domain.RestoreRequest) (*domain.BackupOperation, error) {
+	if err := s.checkManaged(req.Collections); err != nil {
+		return nil, err
+	}
+	// Only exports of this deployment are imported, never a bucket named by the caller
+	if !strings.HasPrefix(req.Source, "gs://"+s.bucket+"/") || strings.Contains(req.Source, "..") {
+		return nil, domain.ErrValidation(fmt.Sprintf("source must be an export in gs://%s/", s.bucket))
+	}
+	return s.repo.Import(ctx, strings.TrimSuffix(req.Source, "/"), req.Collections)
+}
+
+func (s *backupService) checkManaged(collections []string) error {
+	if !s.Managed() {
+		return domain.ErrValidation("managed backups need BACKUP_BUCKET")
+	}
+	for _, c := range collections {
+		if !collectionID.MatchString(c) {
+			return domain.ErrValidation(fmt.Sprintf("invalid collection %q", c))
+		}
+	}
+	return nil
+}
+
+func (s *backupService) GetOperation(ctx context.Context, id string) (*domain.BackupOperation, error) {
+	return s.repo.Operation(ctx, id)
+}
+
+func (s *backupService) Dump(ctx context.Context, collections []string, fn func(domain.BackupRecord) error) error {
+	if len(collections) == 0 {
+		collections = domain.DumpCollections
+	}
+	for _, c := range collections {
+		if !slices.Contains(domain.DumpCollections, c) {
+			return domain.ErrValidation(fmt.Sprintf("collections must be among %v, got %q", domain.DumpCollections, c))
+		}
+	}
+	return s.repo.Dump(ctx, collections, fn)
+}
+
+func (s *backupService) Restore(ctx context.Context, next func() (domain.BackupRecord, error), conflict domain.RestoreConflict) (*domain.RestoreResult, error) {
+	if conflict == "" {
+		conflict = domain.RestoreSkip
+	}
+	if !conflict.IsValid() {
+		return nil, domain.ErrValidation("on_conflict must be skip, overwrite or fail")
+	}
+	result := &domain.RestoreResult{Failed: []domain.RestoreFailure{}}
+	var batch []domain.BackupRecord
+	var lines []int
+
+	// flush writes the batch; it reports whether RestoreFail stops the restore
+	flush := func() bool {
+		failed := s.repo.Restore(ctx, batch, conflict == domain.RestoreOverwrite)
+		for i, record := range batch {
+			var conflictErr *domain.ConflictError
+			err := failed[i]
+			switch {
+			case err == nil:
+				result.Restored++
+			case errors.As(err, &conflictErr) && conflict == domain.RestoreSkip:
+				result.Skipped++
+			default:
+				result.Stopped = result.Stopped || errors.As(err, &conflictErr)
+				result.Failed = append(result.Failed, domain.RestoreFailure{Line: lines[i], Id: record.Id, Error: err.Error()})
+			}
+		}
+		batch, lines = batch[:0], lines[:0]
+		return result.Stopped
+	}
+
+	for line := 1; ; line++ {
+		record, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var invalid *domain.ValidationError
+		if err != nil && !errors.As(err, &invalid) {
+			return nil, err
+		}
+		if err == nil {
+			err = record.Validate()
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, domain.RestoreFailure{Line: line, Id: record.Id, Error: err.Error()})
+			continue
+		}
+		batch = append(batch, record)
+		lines = append(lines, line)
+		if len(batch) == restoreBatchSize && flush() {
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if len(batch) > 0 {
+		flush()
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"sync"
+	"time"
+)
+
+// locations caches parsed timezones; time.LoadLocation reads the zone database on every call
+var locations sync.Map
+
+// eventLocation is the event's IANA timezone, UTC when it has none or an unknown one
+func eventLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// NewEventResponse assembles the read model of e as of now. Times are given in the event's
+// timezone, so clients can show the local date without a zone database of their own.
+func NewEventResponse(e *domain.Event, now time.Time) domain.EventResponse {
+	loc := eventLocation(e.Timezone)
+	end := e.EndTime
+	if end.IsZero() {
+		end = e.StartTime
+	}
+	return domain.EventResponse{
+		Id:              e.Id,
+		Slug:            e.Slug,
+		EventName:       e.EventName,
+		Description:     e.Description,
+		Type:            e.Type,
+		Visibility:      e.EventVisibility(),
+		OrganizerID:     e.OrganizerID,
+		OrganizerName:   e.OrganizerName,
+		City:            e.City,
+		Country:         e.Country,
+		State:           e.State,
+		Street:          e.Street,
+		FullAddress:     e.FullAddress,
+		Location:        domain.NewGeoPoint(e.Location),
+		StartTime:       e.StartTime.In(loc),
+		EndTime:         e.EndTime.In(loc),
+		Timezone:        e.Timezone,
+		EventURL:        e.EventURL,
+		Provider:        e.Provider,
+		ImageUrl:        e.ImageUrl,
+		Price:           e.Price,
+		Capacity:        e.Capacity,
+		AttendeeCount:   e.AttendeeCount,
+		FavoritesCount:  e.FavoritesCount,
+		ViewCount:       e.ViewCount,
+		HasTickets:      e.HasTickets,
+		PopularityScore: e.PopularityScore,
+		Featured:        e.Featured,
+		FeaturedUntil:   e.FeaturedUntil,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+		IsPast:          !end.IsZero() && end.Before(now),
+		IsFree:          e.Price == 0,
+	}
+}
+
+// NewEventResponses assembles the read models of a list; an empty list gives [] rather than null
+func NewEventResponses(events []domain.Event, now time.Time) []domain.EventResponse {
+	responses := make([]domain.EventResponse, 0, len(events))
+	for i := range events {
+		responses = append(responses, NewEventResponse(&events[i], now))
+	}
+	return responses
+}
@@ -2,8 +2,15 @@ package service
 
 import (
 	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/flags"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/notify"
 	"bibently.com/backend/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,30 +20,175 @@ type EventService interface {
 	CreateEvent(ctx context.Context, event *domain.Event) error
 	UpdateEvent(ctx context.Context, id string, updates map[string]interface{}) error
 	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+	GetEventBySlug(ctx context.Context, slug string) (*domain.Event, error)
+	GetEvents(ctx context.Context, ids []string) ([]domain.Event, error)
 	DeleteEvent(ctx context.Context, id string) error
-	ListEvents(ctx context.Context, request domain.SearchRequest) ([]domain.Event, string, error)
-	BatchCreateEvents(ctx context.Context, events []*domain.Event) error
+	ListEvents(ctx context.Context, request domain.SearchRequest) ([]domain.Event, domain.Meta, error)
+	StreamEvents(ctx context.Context, request domain.SearchRequest, fn func(*domain.Event) error) error
+	BatchCreateEvents(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error)
+	GetEventStats(ctx context.Context, groupBy string) ([]domain.EventStats, error)
+	SummarizeEvents(ctx context.Context, filters domain.FilterRequest) (domain.EventSummary, error)
+	GetPriceBuckets(ctx context.Context, city string, bounds []float64) ([]domain.PriceBucket, error)
+	SetFeatured(ctx context.Context, id string, featured bool, until time.Time) error
+	ListFeaturedEvents(ctx context.Context) ([]domain.Event, error)
+	GetEventHistory(ctx context.Context, id string) ([]domain.EventRevision, error)
+	ArchivePastEvents(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error)
+	// UpdatePopularity stores scores as the events' popularity_score; events left out drop to 0
+	UpdatePopularity(ctx context.Context, scores map[string]float64) (*domain.PopularityResult, error)
 }
 
 type eventService struct {
-	repo repository.EventRepository
+	repo       repository.EventRepository
+	revisions  repository.RevisionRepository
+	duplicates domain.DuplicatePolicy
+	announcer  AnnouncementService
+	ops        notify.OpsNotifier
+	flags      flags.Flags
+	// stripTracking removes campaign and click parameters from event links
+	stripTracking bool
 }
 
-func NewEventService(repo repository.EventRepository) EventService {
-	return &eventService{repo: repo}
+// EventServiceOption configures optional behaviour of the event service
+type EventServiceOption func(*eventService)
+
+// WithDuplicatePolicy enables duplicate detection on CreateEvent (default: DuplicateAllow)
+func WithDuplicatePolicy(policy domain.DuplicatePolicy) EventServiceOption {
+	return func(s *eventService) {
+		s.duplicates = policy
+	}
+}
+
+// WithAnnouncer schedules the announcement of events created through CreateEvent; subscribers are
+// notified by the task worker, after the response. Scheduling failures are logged and never fail
+// the create; bulk imports are deliberately not announced.
+func WithAnnouncer(a AnnouncementService) EventServiceOption {
+	return func(s *eventService) {
+		s.announcer = a
+	}
+}
+
+// WithOpsNotifier reports single creates and deletes to an internal channel; failures are only logged
+func WithOpsNotifier(n notify.OpsNotifier) EventServiceOption {
+	return func(s *eventService) {
+		s.ops = n
+	}
+}
+
+// WithFlags lets feature flags switch off optional behaviour such as flags.PopularitySort
+// (default: flags.Defaults)
+func WithFlags(f flags.Flags) EventServiceOption {
+	return func(s *eventService) {
+		s.flags = f
+	}
+}
+
+// WithTrackingParamsStripped removes campaign and click parameters (utm_*, fbclid, ...) from the
+// event_url and image_url of the events it writes; links are normalized either way
+func WithTrackingParamsStripped() EventServiceOption {
+	return func(s *eventService) {
+		s.stripTracking = true
+	}
+}
+
+func NewEventService(repo repository.EventRepository, revisions repository.RevisionRepository, opts ...EventServiceOption) EventService {
+	s := &eventService{repo: repo, revisions: revisions, duplicates: domain.DuplicateAllow, flags: flags.New(nil)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *eventService) CreateEvent(ctx context.Context, event *domain.Event) error {
 	if event.Id == "" {
 		event.Id = uuid.New().String()
 	}
-	if event.CreatedAt.IsZero() {
-		event.CreatedAt = time.Now().UTC()
-	}
 	if event.EventName == "" {
 		return domain.ErrValidation("event name is required")
 	}
-	return s.repo.Save(ctx, event)
+	if err := s.normalizeLinks(event); err != nil {
+		return err
+	}
+	stampCreated(ctx, event, time.Now().UTC())
+
+	slug, err := s.uniqueSlug(ctx, event, nil)
+	if err != nil {
+		return err
+	}
+	event.Slug = slug
+
+	if s.duplicates == domain.DuplicateAllow {
+		if err := s.repo.Save(ctx, event); err != nil {
+			return err
+		}
+	} else {
+		existingID, err := s.repo.SaveUnique(ctx, event)
+		if err != nil {
+			return err
+		}
+		if existingID != "" {
+			if s.duplicates == domain.DuplicateReject {
+				return &domain.DuplicateEventError{ExistingID: existingID}
+			}
+			// Idempotent create: report the existing event as the result
+			event.Id = existingID
+			return nil
+		}
+	}
+	if err := s.recordRevisions(ctx, s.newRevision(ctx, event.Id, domain.RevisionCreate, nil, domain.SnapshotEvent(event))); err != nil {
+		return err
+	}
+	s.announce(ctx, event)
+	return nil
+}
+
+// announce schedules the subscriber notifications and tells the ops channel about a newly published
+// event. Subscribers only hear of public events.
+func (s *eventService) announce(ctx context.Context, event *domain.Event) {
+	if s.announcer != nil && event.EventVisibility() == domain.VisibilityPublic {
+		if err := s.announcer.Schedule(ctx, event.Id); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "event announcement not scheduled", "event_id", event.Id, "error", err)
+		}
+	}
+	if s.ops != nil {
+		if err := s.ops.EventCreated(ctx, event); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "ops notification failed", "event_id", event.Id, "error", err)
+		}
+	}
+}
+
+// normalizeLinks puts the links of a new event in canonical form
+func (s *eventService) normalizeLinks(event *domain.Event) (err error) {
+	if event.EventURL, err = s.normalizeLink("event_url", event.EventURL); err != nil {
+		return err
+	}
+	event.ImageUrl, err = s.normalizeLink("image_url", event.ImageUrl)
+	return err
+}
+
+// normalizeLink returns the canonical form of a link (domain.NormalizeURL); links that are not
+// http(s) are a validation error and an empty link stays empty
+func (s *eventService) normalizeLink(field, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+	normalized, err := domain.NormalizeURL(link, s.stripTracking)
+	if err != nil {
+		return "", domain.ErrValidation(fmt.Sprintf("%s %v", field, err))
+	}
+	return normalized, nil
+}
+
+// stampCreated fills the audit fields of a new event; a preset CreatedAt (e.g. from an import) is kept
+func stampCreated(ctx context.Context, event *domain.Event, now time.Time) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = now
+	}
+	actor := domain.ActorFromContext(ctx)
+	event.CreatedBy = actor
+	event.UpdatedAt = now
+	event.UpdatedBy = actor
+	// New events start unscored, written as an explicit 0 so sort_key=popularity includes them
+	event.PopularityScore = 0
 }
 
 func (s *eventService) UpdateEvent(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -47,49 +199,464 @@ func (s *eventService) UpdateEvent(ctx context.Context, id string, updates map[s
 		return domain.ErrValidation("no fields to update")
 	}
 
-	// remove "id" from updates map if present to prevent primary key tampering
-	delete(updates, "id")
+	// Only whitelisted fields may be written; 'id', 'created_at' and counters are never client-settable
+	for field := range updates {
+		if !domain.UpdatableEventFields[field] {
+			return domain.ErrValidation(fmt.Sprintf("field %q cannot be updated", field))
+		}
+	}
+	for _, field := range []string{"event_url", "image_url"} {
+		if link, ok := updates[field].(string); ok {
+			normalized, err := s.normalizeLink(field, link)
+			if err != nil {
+				return err
+			}
+			updates[field] = normalized
+		}
+	}
+
+	// Read, authorize and write in one transaction so the revision's old values are the ones replaced
+	// and the dedup key is derived from the state being updated
+	var before, after map[string]interface{}
+	err := s.repo.UpdateInTransaction(ctx, id, func(current *domain.Event) (map[string]interface{}, error) {
+		if err := authorizeEventWrite(ctx, current); err != nil {
+			return nil, err
+		}
+		before = domain.SnapshotEvent(current)
+		after = make(map[string]interface{}, len(before)+len(updates))
+		for k, v := range before {
+			after[k] = v
+		}
+		for k, v := range updates {
+			after[k] = v
+		}
+
+		write := make(map[string]interface{}, len(updates)+3)
+		for k, v := range updates {
+			write[k] = v
+		}
+		// Keep the dedup key in sync when any of its inputs change
+		if hasAnyKey(updates, "event_name", "city", "start_time") {
+			name, _ := after["event_name"].(string)
+			city, _ := after["city"].(string)
+			start, _ := after["start_time"].(time.Time)
+			write["dedup_key"] = domain.DedupKey(name, city, start)
+		}
 
-	return s.repo.Update(ctx, id, updates)
+		// Stamped after the diff is built so history only shows content changes
+		write["updated_at"] = time.Now().UTC()
+		write["updated_by"] = domain.ActorFromContext(ctx)
+		return write, nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.recordRevisions(ctx, s.newRevision(ctx, id, domain.RevisionUpdate, before, after))
 }
 
 func (s *eventService) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
 	if id == "" {
 		return nil, domain.ErrValidation("id is required")
 	}
-	return s.repo.GetByID(ctx, id)
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeEventRead(ctx, event); err != nil {
+		return nil, err
+	}
+
+	// View counting is best-effort: a failing counter must never break the read
+	if views, err := s.repo.GetViewCount(ctx, id); err == nil {
+		event.ViewCount = views
+	}
+	if err := s.repo.IncrementViews(ctx, id); err == nil {
+		event.ViewCount++
+	}
+
+	return event, nil
+}
+
+func (s *eventService) GetEventBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	if slug == "" {
+		return nil, domain.ErrValidation("slug is required")
+	}
+	event, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeEventRead(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// MaxBatchGetIDs bounds GetEvents so one request cannot fan out into an unbounded read
+const MaxBatchGetIDs = 100
+
+// GetEvents returns the events with the given Ids in request order, skipping unknown Ids and
+// private events the caller may not read. Blank and repeated Ids are ignored. Batch reads do not
+// count as views.
+func (s *eventService) GetEvents(ctx context.Context, ids []string) ([]domain.Event, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		if strings.Contains(id, "/") {
+			return nil, domain.ErrValidation(fmt.Sprintf("invalid event id %q", id))
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return nil, domain.ErrValidation("at least one id is required")
+	}
+	if len(unique) > MaxBatchGetIDs {
+		return nil, domain.ErrValidation(fmt.Sprintf("at most %d ids may be requested at once", MaxBatchGetIDs))
+	}
+	events, err := s.repo.GetMulti(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := domain.PrincipalFromContext(ctx)
+	readable := make([]domain.Event, 0, len(events))
+	for i := range events {
+		if events[i].ReadableBy(p, ok) {
+			readable = append(readable, events[i])
+		}
+	}
+	return readable, nil
 }
 
 func (s *eventService) DeleteEvent(ctx context.Context, id string) error {
 	if id == "" {
 		return domain.ErrValidation("id is required")
 	}
-	return s.repo.Delete(ctx, id)
+
+	// Keep the last state in the history; the revisions subcollection outlives the document
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := authorizeEventWrite(ctx, current); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.recordRevisions(ctx, s.newRevision(ctx, id, domain.RevisionDelete, domain.SnapshotEvent(current), nil)); err != nil {
+		return err
+	}
+	if s.ops != nil {
+		if err := s.ops.EventCancelled(ctx, current); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "ops notification failed", "event_id", id, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *eventService) GetEventHistory(ctx context.Context, id string) ([]domain.EventRevision, error) {
+	if id == "" {
+		return nil, domain.ErrValidation("id is required")
+	}
+	return s.revisions.ListRevisions(ctx, id)
 }
 
-func (s *eventService) ListEvents(ctx context.Context, req domain.SearchRequest) ([]domain.Event, string, error) {
+// authorizeEventRead hides private events from callers other than their creator and admins. They
+// are reported as not found so their Ids are not confirmed to exist.
+func authorizeEventRead(ctx context.Context, event *domain.Event) error {
+	if !event.ReadableBy(domain.PrincipalFromContext(ctx)) {
+		return domain.ErrNotFound("event not found")
+	}
+	return nil
+}
+
+// listedEvents drops the events the caller may not see in lists and searches (see
+// domain.Event.ListedFor). A page can come out shorter than its page size; its tokens still
+// continue after the last event read.
+func listedEvents(ctx context.Context, events []domain.Event) []domain.Event {
+	p, ok := domain.PrincipalFromContext(ctx)
+	listed := make([]domain.Event, 0, len(events))
+	for i := range events {
+		if events[i].ListedFor(p, ok) {
+			listed = append(listed, events[i])
+		}
+	}
+	return listed
+}
+
+// publicOnly reports whether aggregates must leave out the unlisted and private events, as the
+// caller's lists do, so counts and totals do not reveal that they exist
+func publicOnly(ctx context.Context) bool {
+	return !domain.ListsEveryEvent(domain.PrincipalFromContext(ctx))
+}
+
+// authorizeEventWrite lets organizers modify only their own events.
+// Admins, regular callers already vetted by the router, and system calls are not restricted here.
+func authorizeEventWrite(ctx context.Context, event *domain.Event) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if ok && p.Role == domain.RoleOrganizer && event.CreatedBy != p.UID {
+		return domain.ErrForbidden("organizers can only modify their own events")
+	}
+	return nil
+}
+
+func hasAnyKey(m map[string]interface{}, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// maxArchivePerRun keeps one archive run well inside the request timeout
+const maxArchivePerRun = 2000
+
+// ArchivePastEvents moves events that ended more than olderThanDays ago out of the hot collection
+func (s *eventService) ArchivePastEvents(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error) {
+	if olderThanDays == 0 {
+		olderThanDays = domain.DefaultArchiveAfterDays
+	}
+	if olderThanDays < 1 {
+		return nil, domain.ErrValidation("older_than_days must be at least 1")
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -olderThanDays)
+	archived, err := s.repo.ArchivePastEvents(ctx, cutoff, maxArchivePerRun)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ArchiveResult{Archived: archived, Cutoff: cutoff, Remaining: archived == maxArchivePerRun}, nil
+}
+
+func (s *eventService) UpdatePopularity(ctx context.Context, scores map[string]float64) (*domain.PopularityResult, error) {
+	scored, reset, err := s.repo.SetPopularity(ctx, scores)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PopularityResult{Scored: scored, Reset: reset}, nil
+}
+
+// recordRevisions stores event history and mirrors the field changes into the request's audit entry
+func (s *eventService) recordRevisions(ctx context.Context, revisions ...*domain.EventRevision) error {
+	for _, rev := range revisions {
+		domain.RecordAuditChanges(ctx, rev.EventID, rev.Changes)
+	}
+	return s.revisions.Record(ctx, revisions...)
+}
+
+// newRevision builds a history entry attributed to the caller found in ctx
+func (s *eventService) newRevision(ctx context.Context, eventID, action string, before, after map[string]interface{}) *domain.EventRevision {
+	return &domain.EventRevision{
+		Id:        uuid.New().String(),
+		EventID:   eventID,
+		Action:    action,
+		Actor:     domain.ActorFromContext(ctx),
+		Changes:   domain.DiffEvent(before, after),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func (s *eventService) ListEvents(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+	if err := s.validateSearch(ctx, req); err != nil {
+		return nil, domain.Meta{}, err
+	}
 	if req.Sorting.PageSize > 100 {
 		req.Sorting.PageSize = 100
 	}
-	return s.repo.List(ctx, req)
+	events, meta, err := s.repo.List(ctx, req)
+	if err != nil {
+		return nil, domain.Meta{}, err
+	}
+	return listedEvents(ctx, events), meta, nil
+}
+
+// StreamEvents calls fn for every event matching the request's filters and sort, without the page size cap
+func (s *eventService) StreamEvents(ctx context.Context, req domain.SearchRequest, fn func(*domain.Event) error) error {
+	if err := s.validateSearch(ctx, req); err != nil {
+		return err
+	}
+	p, ok := domain.PrincipalFromContext(ctx)
+	return s.repo.Stream(ctx, req, func(e *domain.Event) error {
+		if !e.ListedFor(p, ok) {
+			return nil
+		}
+		return fn(e)
+	})
+}
+
+// validateSearch rejects sorts that are switched off and combinations the list queries have no index for
+func (s *eventService) validateSearch(ctx context.Context, req domain.SearchRequest) error {
+	if req.Sorting.SortKey != domain.SortPopularity {
+		return nil
+	}
+	if !s.flags.Enabled(ctx, flags.PopularitySort) {
+		return domain.ErrValidation("sort_key=popularity is not available")
+	}
+	if req.Filters.HasRangeFilter() {
+		return domain.ErrValidation("sort_key=popularity cannot be combined with event_name, city, price or date filters")
+	}
+	return nil
+}
+
+func (s *eventService) GetEventStats(ctx context.Context, groupBy string) ([]domain.EventStats, error) {
+	if !slices.Contains(domain.StatsGroupByFields, groupBy) {
+		return nil, domain.ErrValidation("group_by must be one of: " + strings.Join(domain.StatsGroupByFields, ", "))
+	}
+	return s.repo.Stats(ctx, groupBy, publicOnly(ctx))
 }
 
-func (s *eventService) BatchCreateEvents(ctx context.Context, events []*domain.Event) error {
+func (s *eventService) SummarizeEvents(ctx context.Context, filters domain.FilterRequest) (domain.EventSummary, error) {
+	return s.repo.Summarize(ctx, filters, publicOnly(ctx))
+}
+
+func (s *eventService) GetPriceBuckets(ctx context.Context, city string, bounds []float64) ([]domain.PriceBucket, error) {
+	if len(bounds) == 0 {
+		bounds = domain.DefaultPriceBounds
+	}
+	if len(bounds) > domain.MaxPriceBuckets {
+		return nil, domain.ErrValidation(fmt.Sprintf("at most %d bucket bounds are allowed", domain.MaxPriceBuckets))
+	}
+	for i, b := range bounds {
+		if b < 0 {
+			return nil, domain.ErrValidation("bucket bounds must not be negative")
+		}
+		if i > 0 && b <= bounds[i-1] {
+			return nil, domain.ErrValidation("bucket bounds must be strictly ascending")
+		}
+	}
+	return s.repo.PriceBuckets(ctx, city, bounds, publicOnly(ctx))
+}
+
+func (s *eventService) SetFeatured(ctx context.Context, id string, featured bool, until time.Time) error {
+	if id == "" {
+		return domain.ErrValidation("id is required")
+	}
+	if featured && !until.After(time.Now()) {
+		return domain.ErrValidation("featured_until must be in the future")
+	}
+
+	updates := map[string]interface{}{"featured": featured}
+	if featured {
+		updates["featured_until"] = until
+	} else {
+		updates["featured_until"] = time.Time{}
+	}
+	return s.UpdateEvent(ctx, id, updates)
+}
+
+func (s *eventService) ListFeaturedEvents(ctx context.Context) ([]domain.Event, error) {
+	events, err := s.repo.ListFeatured(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return listedEvents(ctx, events), nil
+}
+
+// BatchCreateEvents stores the valid events and reports every item individually.
+// The returned error is reserved for problems with the request as a whole.
+func (s *eventService) BatchCreateEvents(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error) {
 	if len(events) == 0 {
-		return domain.ErrValidation("no events to create")
+		return nil, domain.ErrValidation("no events to create")
 	}
 
+	result := &domain.BatchCreateResult{Created: []domain.BatchItemResult{}, Failed: []domain.BatchItemResult{}}
+	fail := func(i int, err error) {
+		result.Failed = append(result.Failed, domain.BatchItemResult{Index: i, Id: events[i].Id, Error: err.Error()})
+	}
+
+	// 1. Prepare each item; invalid ones are reported and skipped
 	now := time.Now().UTC()
-	for _, event := range events {
+	reserved := make(map[string]bool, len(events)) // slugs must also be unique within the batch
+	var valid []*domain.Event
+	var indexes []int
+	for i, event := range events {
+		if event.EventName == "" {
+			fail(i, domain.ErrValidation("event name is required"))
+			continue
+		}
+		if err := s.normalizeLinks(event); err != nil {
+			fail(i, err)
+			continue
+		}
 		if event.Id == "" {
 			event.Id = uuid.New().String()
 		}
-		if event.CreatedAt.IsZero() {
-			event.CreatedAt = now
+		stampCreated(ctx, event, now)
+
+		slug, err := s.uniqueSlug(ctx, event, reserved)
+		if err != nil {
+			fail(i, err)
+			continue
 		}
-		if event.EventName == "" {
-			return domain.ErrValidation("event name is required for all items")
+		event.Slug = slug
+		reserved[slug] = true
+
+		valid = append(valid, event)
+		indexes = append(indexes, i)
+	}
+
+	// 2. Persist; storage errors are attributed to the affected items only
+	var saveErrs map[int]error
+	if len(valid) > 0 {
+		if err := s.repo.BatchSave(ctx, valid); err != nil {
+			var batchErr *domain.BatchSaveError
+			if errors.As(err, &batchErr) {
+				saveErrs = batchErr.Failed
+			} else {
+				saveErrs = make(map[int]error, len(valid))
+				for j := range valid {
+					saveErrs[j] = err
+				}
+			}
+		}
+	}
+
+	// 3. Record history for the stored events
+	revisions := make([]*domain.EventRevision, 0, len(valid))
+	for j, event := range valid {
+		if err, ok := saveErrs[j]; ok {
+			fail(indexes[j], err)
+			continue
+		}
+		result.Created = append(result.Created, domain.BatchItemResult{Index: indexes[j], Id: event.Id})
+		revisions = append(revisions, s.newRevision(ctx, event.Id, domain.RevisionCreate, nil, domain.SnapshotEvent(event)))
+	}
+	if len(revisions) > 0 {
+		if err := s.recordRevisions(ctx, revisions...); err != nil {
+			return nil, err
 		}
 	}
-	return s.repo.BatchSave(ctx, events)
+
+	slices.SortFunc(result.Failed, func(a, b domain.BatchItemResult) int { return a.Index - b.Index })
+	return result, nil
+}
+
+// uniqueSlug returns the readable slug for the event, falling back to a suffix
+// derived from the event Id when the slug is already taken (in storage or in reserved).
+func (s *eventService) uniqueSlug(ctx context.Context, event *domain.Event, reserved map[string]bool) (string, error) {
+	slug := domain.GenerateSlug(event)
+	if slug == "" {
+		return event.Id, nil
+	}
+
+	if !reserved[slug] {
+		_, err := s.repo.GetBySlug(ctx, slug)
+		var notFound *domain.NotFoundError
+		if errors.As(err, &notFound) {
+			return slug, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	suffix := strings.ReplaceAll(event.Id, "-", "")
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+	return slug + "-" + suffix, nil
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+)
+
+type EventWatchService interface {
+	// WatchEvents blocks, delivering changes to fn until ctx is cancelled or fn fails
+	WatchEvents(ctx context.Context, filter domain.WatchFilter, fn func(domain.EventChange) error) error
+}
+
+type eventWatchService struct {
+	repo repository.EventWatcher
+}
+
+func NewEventWatchService(repo repository.EventWatcher) EventWatchService {
+	return &eventWatchService{repo: repo}
+}
+
+func (s *eventWatchService) WatchEvents(ctx context.Context, filter domain.WatchFilter, fn func(domain.EventChange) error) error {
+	if filter.Type != "" && !filter.Type.IsAllowed(ctx) {
+		return domain.ErrValidation("invalid event type: " + string(filter.Type))
+	}
+	// Watchers see the changes of the events they would find in a list
+	p, ok := domain.PrincipalFromContext(ctx)
+	return s.repo.WatchEvents(ctx, filter, func(change domain.EventChange) error {
+		if !change.Event.ListedFor(p, ok) {
+			return nil
+		}
+		return fn(change)
+	})
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+)
+
+type FacetService interface {
+	// ApplyEventWrite updates the event facets for one document write (before nil: create, after nil: delete)
+	ApplyEventWrite(ctx context.Context, before, after *domain.Event) error
+	GetEventFacets(ctx context.Context) (*domain.EventFacets, error)
+	// RefreshEventFacets recomputes the facets from the events collection
+	RefreshEventFacets(ctx context.Context) (*domain.EventFacets, error)
+}
+
+type facetService struct {
+	repo repository.FacetRepository
+}
+
+func NewFacetService(repo repository.FacetRepository) FacetService {
+	return &facetService{repo: repo}
+}
+
+func (s *facetService) ApplyEventWrite(ctx context.Context, before, after *domain.Event) error {
+	delta := domain.EventFacetDelta(before, after)
+	if delta.IsZero() {
+		// Most updates touch neither city nor type
+		return nil
+	}
+	return s.repo.ApplyEventFacetDelta(ctx, delta)
+}
+
+func (s *facetService) GetEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	return s.repo.GetEventFacets(ctx)
+}
+
+func (s *facetService) RefreshEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	return s.repo.RebuildEventFacets(ctx)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+)
+
+type FavoriteService interface {
+	AddFavorite(ctx context.Context, userID, eventID string) error
+	RemoveFavorite(ctx context.Context, userID, eventID string) error
+	ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error)
+}
+
+type favoriteService struct {
+	repo repository.FavoriteRepository
+}
+
+func NewFavoriteService(repo repository.FavoriteRepository) FavoriteService {
+	return &favoriteService{repo: repo}
+}
+
+func (s *favoriteService) AddFavorite(ctx context.Context, userID, eventID string) error {
+	if userID == "" || eventID == "" {
+		return domain.ErrValidation("user id and event id are required")
+	}
+	return s.repo.AddFavorite(ctx, userID, eventID)
+}
+
+func (s *favoriteService) RemoveFavorite(ctx context.Context, userID, eventID string) error {
+	if userID == "" || eventID == "" {
+		return domain.ErrValidation("user id and event id are required")
+	}
+	return s.repo.RemoveFavorite(ctx, userID, eventID)
+}
+
+func (s *favoriteService) ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error) {
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	return s.repo.ListFavorites(ctx, userID)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/tasks"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ImportChunkPath is the worker route that processes one chunk of an async import
+	ImportChunkPath = "/internal/tasks/import-chunk"
+
+	// importChunkSize keeps each task payload well below the Cloud Tasks 1 MB limit
+	importChunkSize = 500
+)
+
+type ImportService interface {
+	StartImport(ctx context.Context, rows []domain.ImportChunkRow, rejected []domain.ImportRowResult) (*domain.ImportJob, error)
+	ProcessChunk(ctx context.Context, chunk *domain.ImportChunk) error
+	GetJob(ctx context.Context, id string) (*domain.ImportJob, error)
+}
+
+type importService struct {
+	jobs   repository.JobRepository
+	events EventService
+	queue  tasks.Queue
+}
+
+func NewImportService(jobs repository.JobRepository, events EventService, queue tasks.Queue) ImportService {
+	return &importService{jobs: jobs, events: events, queue: queue}
+}
+
+// StartImport records the job and enqueues one task per chunk of valid rows.
+// Rows rejected during parsing are reported on the job straight away.
+func (s *importService) StartImport(ctx context.Context, rows []domain.ImportChunkRow, rejected []domain.ImportRowResult) (*domain.ImportJob, error) {
+	if len(rows) == 0 && len(rejected) == 0 {
+		return nil, domain.ErrValidation("no rows to import")
+	}
+
+	now := time.Now().UTC()
+	job := &domain.ImportJob{
+		Id:          uuid.New().String(),
+		Status:      domain.JobPending,
+		Total:       len(rows) + len(rejected),
+		TotalChunks: (len(rows) + importChunkSize - 1) / importChunkSize,
+		DoneChunks:  []int{},
+		Errors:      []domain.ImportRowResult{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	job.AddErrors(rejected)
+	if job.TotalChunks == 0 {
+		job.Status = domain.JobCompleted
+	}
+
+	// Ids are assigned up front so a redelivered task overwrites instead of duplicating
+	for i := range rows {
+		if rows[i].Event.Id == "" {
+			rows[i].Event.Id = uuid.New().String()
+		}
+	}
+
+	if err := s.jobs.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	for index := 0; index < job.TotalChunks; index++ {
+		start := index * importChunkSize
+		end := min(start+importChunkSize, len(rows))
+		chunk := domain.ImportChunk{JobID: job.Id, Index: index, Rows: rows[start:end]}
+
+		if err := s.queue.Enqueue(ctx, ImportChunkPath, chunk); err != nil {
+			// Chunks already enqueued still run; the job shows why it will not complete
+			job.Status = domain.JobFailed
+			job.Message = fmt.Sprintf("failed to enqueue chunk %d of %d", index+1, job.TotalChunks)
+			_ = s.jobs.UpdateJob(ctx, job.Id, map[string]interface{}{
+				"status":     job.Status,
+				"message":    job.Message,
+				"updated_at": time.Now().UTC(),
+			})
+			return nil, err
+		}
+	}
+
+	return job, nil
+}
+
+// ProcessChunk stores the events of one chunk and records the per-row outcome on the job
+func (s *importService) ProcessChunk(ctx context.Context, chunk *domain.ImportChunk) error {
+	if chunk.JobID == "" {
+		return domain.ErrValidation("job id is required")
+	}
+
+	events := make([]*domain.Event, len(chunk.Rows))
+	for i := range chunk.Rows {
+		events[i] = &chunk.Rows[i].Event
+	}
+
+	created := 0
+	var failures []domain.ImportRowResult
+	if len(events) > 0 {
+		result, err := s.events.BatchCreateEvents(ctx, events)
+		if err != nil {
+			return err
+		}
+		created = len(result.Created)
+		for _, item := range result.Failed {
+			failures = append(failures, domain.ImportRowResult{Row: chunk.Rows[item.Index].Row, Error: item.Error})
+		}
+	}
+
+	return s.jobs.RecordChunk(ctx, chunk.JobID, chunk.Index, created, failures)
+}
+
+func (s *importService) GetJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	if id == "" {
+		return nil, domain.ErrValidation("id is required")
+	}
+	return s.jobs.GetJob(ctx, id)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+)
+
+type MaintenanceService interface {
+	// RunDaily archives past events, purges old tracking documents, recomputes the events'
+	// popularity_score from recent tracking and refreshes the event facets.
+	// A failing step does not stop the others; the report is returned together with the joined errors.
+	RunDaily(ctx context.Context) (*domain.MaintenanceReport, error)
+}
+
+// MaintenancePolicy configures the daily job; zero values use the domain defaults
+type MaintenancePolicy struct {
+	ArchiveAfterDays      int
+	TrackingRetentionDays int
+}
+
+type maintenanceService struct {
+	events   EventService
+	tracking TrackingService
+	facets   FacetService
+	policy   MaintenancePolicy
+}
+
+func NewMaintenanceService(events EventService, tracking TrackingService, facets FacetService, policy MaintenancePolicy) MaintenanceService {
+	return &maintenanceService{events: events, tracking: tracking, facets: facets, policy: policy}
+}
+
+func (s *maintenanceService) RunDaily(ctx context.Context) (*domain.MaintenanceReport, error) {
+	report := &domain.MaintenanceReport{}
+	var errs []error
+	fail := func(step string, err error) {
+		if report.Errors == nil {
+			report.Errors = map[string]string{}
+		}
+		report.Errors[step] = err.Error()
+		errs = append(errs, fmt.Errorf("%s: %w", step, err))
+	}
+
+	if result, err := s.events.ArchivePastEvents(ctx, s.policy.ArchiveAfterDays); err != nil {
+		fail("archive", err)
+	} else {
+		report.Archive = result
+	}
+
+	if result, err := s.tracking.PurgeOldTracking(ctx, s.policy.TrackingRetentionDays); err != nil {
+		fail("tracking", err)
+	} else {
+		report.Tracking = result
+	}
+
+	// After the archive, so archived events are not scored
+	if scores, err := s.tracking.EventPopularity(ctx); err != nil {
+		fail("popularity", err)
+	} else if result, err := s.events.UpdatePopularity(ctx, scores); err != nil {
+		fail("popularity", err)
+	} else {
+		report.Popularity = result
+	}
+
+	// Facets last, so they reflect the events just archived
+	if facets, err := s.facets.RefreshEventFacets(ctx); err != nil {
+		fail("facets", err)
+	} else {
+		report.Facets = facets
+	}
+
+	return report, errors.Join(errs...)
+}
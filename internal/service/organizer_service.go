@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrganizerService interface {
+	CreateOrganizer(ctx context.Context, organizer *domain.Organizer) error
+	UpdateOrganizer(ctx context.Context, id string, updates map[string]interface{}) error
+	GetOrganizer(ctx context.Context, id string) (*domain.Organizer, error)
+	// GetOrganizers fetches several organizers at once; unknown Ids are left out
+	GetOrganizers(ctx context.Context, ids []string) ([]domain.Organizer, error)
+	DeleteOrganizer(ctx context.Context, id string) error
+	ListOrganizers(ctx context.Context) ([]domain.Organizer, error)
+}
+
+type organizerService struct {
+	repo repository.OrganizerRepository
+}
+
+func NewOrganizerService(repo repository.OrganizerRepository) OrganizerService {
+	return &organizerService{repo: repo}
+}
+
+func (s *organizerService) CreateOrganizer(ctx context.Context, organizer *domain.Organizer) error {
+	if organizer.Name == "" {
+		return domain.ErrValidation("organizer name is required")
+	}
+	if organizer.Id == "" {
+		organizer.Id = uuid.New().String()
+	}
+	if organizer.CreatedAt.IsZero() {
+		organizer.CreatedAt = time.Now().UTC()
+	}
+	return s.repo.Save(ctx, organizer)
+}
+
+func (s *organizerService) UpdateOrganizer(ctx context.Context, id string, updates map[string]interface{}) error {
+	if id == "" {
+		return domain.ErrValidation("id is required for update")
+	}
+	if len(updates) == 0 {
+		return domain.ErrValidation("no fields to update")
+	}
+	// Updates must target an existing organizer; MergeAll would otherwise create one
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, id, updates)
+}
+
+func (s *organizerService) GetOrganizer(ctx context.Context, id string) (*domain.Organizer, error) {
+	if id == "" {
+		return nil, domain.ErrValidation("id is required")
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *organizerService) GetOrganizers(ctx context.Context, ids []string) ([]domain.Organizer, error) {
+	if len(ids) == 0 {
+		return []domain.Organizer{}, nil
+	}
+	return s.repo.GetMulti(ctx, ids)
+}
+
+func (s *organizerService) DeleteOrganizer(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrValidation("id is required")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *organizerService) ListOrganizers(ctx context.Context) ([]domain.Organizer, error) {
+	return s.repo.List(ctx)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+type PushSubscriptionService interface {
+	Subscribe(ctx context.Context, userID string, dto *domain.PushSubscriptionDTO) (*domain.PushSubscription, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]domain.PushSubscription, error)
+	Unsubscribe(ctx context.Context, userID, id string) error
+}
+
+type pushSubscriptionService struct {
+	repo repository.PushSubscriptionRepository
+}
+
+func NewPushSubscriptionService(repo repository.PushSubscriptionRepository) PushSubscriptionService {
+	return &pushSubscriptionService{repo: repo}
+}
+
+func (s *pushSubscriptionService) Subscribe(ctx context.Context, userID string, dto *domain.PushSubscriptionDTO) (*domain.PushSubscription, error) {
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	city := strings.TrimSpace(dto.City)
+	if city == "" || dto.Token == "" {
+		return nil, domain.ErrValidation("token and city are required")
+	}
+
+	sub := &domain.PushSubscription{
+		Id:        pushSubscriptionID(userID, dto.Token, city, dto.Type),
+		UserID:    userID,
+		Token:     dto.Token,
+		City:      city,
+		Type:      dto.Type,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.SavePushSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *pushSubscriptionService) ListSubscriptions(ctx context.Context, userID string) ([]domain.PushSubscription, error) {
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	return s.repo.ListPushSubscriptions(ctx, userID)
+}
+
+func (s *pushSubscriptionService) Unsubscribe(ctx context.Context, userID, id string) error {
+	if userID == "" || id == "" {
+		return domain.ErrValidation("user id and subscription id are required")
+	}
+	return s.repo.DeletePushSubscription(ctx, userID, id)
+}
+
+// pushSubscriptionID makes subscribing idempotent per user, device, city and type
+func pushSubscriptionID(userID, token, city string, eventType domain.EventType) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{userID, token, city, string(eventType)}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
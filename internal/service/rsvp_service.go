@@ -0,0 +1,66 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"time"
+)
+
+type RSVPService interface {
+	RSVP(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error)
+	ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error)
+}
+
+type rsvpService struct {
+	repo      repository.RSVPRepository
+	eventRepo repository.EventRepository
+}
+
+func NewRSVPService(repo repository.RSVPRepository, eventRepo repository.EventRepository) RSVPService {
+	return &rsvpService{repo: repo, eventRepo: eventRepo}
+}
+
+func (s *rsvpService) RSVP(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error) {
+	if eventID == "" {
+		return nil, domain.ErrValidation("event id is required")
+	}
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast on a full event; the repository re-checks atomically
+	if event.Capacity > 0 && event.AttendeeCount >= event.Capacity {
+		return nil, domain.ErrConflict("event is full")
+	}
+	if !event.StartTime.IsZero() && event.StartTime.Before(time.Now()) {
+		return nil, domain.ErrValidation("registration is closed for past events")
+	}
+
+	rsvp := &domain.RSVP{
+		Id:        userID,
+		EventID:   eventID,
+		UserID:    userID,
+		Email:     email,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.CreateRSVP(ctx, rsvp, event.Capacity); err != nil {
+		return nil, err
+	}
+	return rsvp, nil
+}
+
+func (s *rsvpService) ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error) {
+	if eventID == "" {
+		return nil, domain.ErrValidation("event id is required")
+	}
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttendees(ctx, eventID)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/seed"
+	"context"
+	"time"
+)
+
+// SeedService fills a development database with generated events and tracking
+type SeedService interface {
+	// Seed stores the data seed.Generate derives from req. Items that fail to store are counted,
+	// not returned as an error; the error is for requests that are invalid.
+	Seed(ctx context.Context, req domain.SeedRequest) (*domain.SeedResult, error)
+}
+
+type seedService struct {
+	events   EventService
+	tracking TrackingService
+}
+
+// NewSeedService writes through the services, so seeded data gets slugs, revisions and rollups
+// like real data
+func NewSeedService(events EventService, tracking TrackingService) SeedService {
+	return &seedService{events: events, tracking: tracking}
+}
+
+func (s *seedService) Seed(ctx context.Context, req domain.SeedRequest) (*domain.SeedResult, error) {
+	now := time.Now().UTC()
+	req, err := req.WithDefaults(now)
+	if err != nil {
+		return nil, err
+	}
+	events, tracking := seed.Generate(req, now)
+
+	created, err := s.events.BatchCreateEvents(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.SeedResult{Events: len(created.Created), Failed: len(created.Failed)}
+	stored := make(map[string]bool, len(created.Created))
+	for _, item := range created.Created {
+		stored[item.Id] = true
+	}
+	for i := range tracking {
+		if !stored[tracking[i].EventID] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := s.tracking.TrackEvent(ctx, &tracking[i]); err != nil {
+			result.Failed++
+			continue
+		}
+		result.Tracking++
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"strings"
+	"time"
+)
+
+type SubscriptionService interface {
+	GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error)
+	// UpdateSubscription replaces the caller's preferences. verifiedEmail is the token's email
+	// when it is verified, and is required to opt in to email.
+	UpdateSubscription(ctx context.Context, userID, verifiedEmail string, dto *domain.SubscriptionDTO) (*domain.Subscription, error)
+	DeleteSubscription(ctx context.Context, userID string) error
+}
+
+type subscriptionService struct {
+	repo repository.SubscriptionRepository
+}
+
+func NewSubscriptionService(repo repository.SubscriptionRepository) SubscriptionService {
+	return &subscriptionService{repo: repo}
+}
+
+func (s *subscriptionService) GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error) {
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	return s.repo.GetSubscription(ctx, userID)
+}
+
+func (s *subscriptionService) UpdateSubscription(ctx context.Context, userID, verifiedEmail string, dto *domain.SubscriptionDTO) (*domain.Subscription, error) {
+	if userID == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	if dto.EmailOptIn && verifiedEmail == "" {
+		return nil, domain.ErrValidation("a verified email address is required for email notifications")
+	}
+
+	// Cities are matched exactly against Event.City, so only surrounding whitespace is dropped
+	cities := make([]string, 0, len(dto.Cities))
+	seen := make(map[string]bool, len(dto.Cities))
+	for _, c := range dto.Cities {
+		if c = strings.TrimSpace(c); c != "" && !seen[c] {
+			seen[c] = true
+			cities = append(cities, c)
+		}
+	}
+
+	sub := &domain.Subscription{
+		UserID:     userID,
+		Cities:     cities,
+		EmailOptIn: dto.EmailOptIn,
+		UpdatedAt:  time.Now().UTC(),
+	}
+	if dto.EmailOptIn {
+		sub.Email = verifiedEmail
+	}
+	if err := s.repo.SaveSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, userID string) error {
+	if userID == "" {
+		return domain.ErrValidation("user id is required")
+	}
+	return s.repo.DeleteSubscription(ctx, userID)
+}
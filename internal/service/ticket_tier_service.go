@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketTierService interface {
+	ListTiers(ctx context.Context, eventID string) ([]domain.TicketTier, error)
+	CreateTier(ctx context.Context, eventID string, tier *domain.TicketTier) error
+	UpdateTier(ctx context.Context, eventID, tierID string, tier *domain.TicketTier) error
+}
+
+type ticketTierService struct {
+	repo      repository.TicketTierRepository
+	eventRepo repository.EventRepository
+}
+
+func NewTicketTierService(repo repository.TicketTierRepository, eventRepo repository.EventRepository) TicketTierService {
+	return &ticketTierService{repo: repo, eventRepo: eventRepo}
+}
+
+func (s *ticketTierService) ListTiers(ctx context.Context, eventID string) ([]domain.TicketTier, error) {
+	if eventID == "" {
+		return nil, domain.ErrValidation("event id is required")
+	}
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTiers(ctx, eventID)
+}
+
+func (s *ticketTierService) CreateTier(ctx context.Context, eventID string, tier *domain.TicketTier) error {
+	if eventID == "" {
+		return domain.ErrValidation("event id is required")
+	}
+	if tier.Name == "" {
+		return domain.ErrValidation("tier name is required")
+	}
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return err
+	}
+
+	tier.Id = uuid.New().String()
+	tier.EventID = eventID
+	tier.CreatedAt = time.Now().UTC()
+
+	if err := s.repo.SaveTier(ctx, tier); err != nil {
+		return err
+	}
+
+	// Keep the denormalized flag on the event in sync for list views
+	return s.eventRepo.Update(ctx, eventID, map[string]interface{}{"has_tickets": true})
+}
+
+func (s *ticketTierService) UpdateTier(ctx context.Context, eventID, tierID string, tier *domain.TicketTier) error {
+	if eventID == "" || tierID == "" {
+		return domain.ErrValidation("event id and tier id are required")
+	}
+	if tier.Name == "" {
+		return domain.ErrValidation("tier name is required")
+	}
+
+	existing, err := s.repo.GetTier(ctx, eventID, tierID)
+	if err != nil {
+		return err
+	}
+
+	// Identity and creation time are immutable
+	tier.Id = existing.Id
+	tier.EventID = existing.EventID
+	tier.CreatedAt = existing.CreatedAt
+
+	return s.repo.SaveTier(ctx, tier)
+}
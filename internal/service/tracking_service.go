@@ -1,9 +1,17 @@
 package service
 
 import (
+	"bibently.com/backend/internal/analytics"
 	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
 	"bibently.com/backend/internal/repository"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,30 +20,235 @@ import (
 type TrackingService interface {
 	TrackEvent(ctx context.Context, event *domain.TrackingEvent) error
 	GetAllTracking(ctx context.Context) ([]domain.TrackingEvent, error)
+	// GetSession returns the events of a session in the order they happened
+	GetSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error)
+	PurgeOldTracking(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error)
+	// DeleteTracking removes one tracking event, e.g. test traffic, from the rows and the stats
+	DeleteTracking(ctx context.Context, id string) error
+	// DeleteTrackingWhere removes the tracking events matching filter from the rows and the stats,
+	// as many as one purge run allows; Remaining reports whether to call it again
+	DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error)
+	// GetTrackingStats counts tracking events per group in every interval bucket of [from, to).
+	// Zero from and to default to the last 7 days (hours: 24 hours) up to now.
+	GetTrackingStats(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error)
+	// WatchTracking blocks, delivering tracking events stored from now on to fn until ctx is
+	// cancelled or fn fails
+	WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error
+	// EventPopularity scores the events tracked in the last domain.PopularityWindowDays by their
+	// views and clicks, recent ones weighing more (domain.PopularityWeight)
+	EventPopularity(ctx context.Context) (map[string]float64, error)
 }
 
 type trackingService struct {
-	repo repository.TrackingRepository
+	repo       repository.TrackingRepository
+	ttl        time.Duration
+	privacy    domain.TrackingPrivacy
+	privacyKey []byte
+	exporter   analytics.Exporter
 }
 
-func NewTrackingService(repo repository.TrackingRepository) TrackingService {
-	return &trackingService{repo: repo}
+// TrackingServiceOption configures optional behaviour of the tracking service
+type TrackingServiceOption func(*trackingService)
+
+// WithTrackingExpiry stamps new tracking events with an expire_at retentionDays after creation, for
+// a Firestore TTL policy to delete them. Unlike the daily purge, TTL reaches every tenant's tracking.
+func WithTrackingExpiry(retentionDays int) TrackingServiceOption {
+	return func(s *trackingService) {
+		s.ttl = time.Duration(retentionDays) * 24 * time.Hour
+	}
+}
+
+// WithTrackingPrivacy anonymizes the user agent of new tracking events: dropped, or replaced by
+// its HMAC under key so visitors can still be counted but not fingerprinted from the data
+func WithTrackingPrivacy(mode domain.TrackingPrivacy, key []byte) TrackingServiceOption {
+	return func(s *trackingService) {
+		s.privacy = mode
+		s.privacyKey = key
+	}
+}
+
+// WithTrackingExporter copies each stored tracking event to exp, e.g. BigQuery. A failed export is
+// logged and does not fail the request; the event is kept in Firestore either way.
+func WithTrackingExporter(exp analytics.Exporter) TrackingServiceOption {
+	return func(s *trackingService) {
+		s.exporter = exp
+	}
+}
+
+func NewTrackingService(repo repository.TrackingRepository, opts ...TrackingServiceOption) TrackingService {
+	s := &trackingService{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *trackingService) TrackEvent(ctx context.Context, event *domain.TrackingEvent) error {
+	// Attributed to the verified caller only; anonymous visitors keep an empty user_id
+	event.UserID = domain.ActorFromContext(ctx)
+	if event.Id == "" && event.ClientEventID != "" {
+		event.Id = clientTrackingID(event.UserID, event.ClientEventID)
+	}
 	if event.Id == "" {
 		event.Id = uuid.New().String()
 	}
 	if event.CreatedAt.IsZero() {
 		event.CreatedAt = time.Now().UTC()
 	}
+	if s.ttl > 0 {
+		event.ExpireAt = event.CreatedAt.Add(s.ttl)
+	}
+	switch s.privacy {
+	case domain.TrackingPrivacyDrop:
+		event.UserAgent = ""
+	case domain.TrackingPrivacyHash:
+		if event.UserAgent != "" {
+			mac := hmac.New(sha256.New, s.privacyKey)
+			mac.Write([]byte(event.UserAgent))
+			event.UserAgent = "h_" + hex.EncodeToString(mac.Sum(nil)[:16])
+		}
+	}
 	// Basic validation
 	if event.Action == "" {
 		return domain.ErrValidation("action is required")
 	}
-	return s.repo.SaveTracking(ctx, event)
+	if err := s.repo.SaveTracking(ctx, event); err != nil {
+		return err
+	}
+	if s.exporter != nil {
+		if err := s.exporter.ExportTracking(ctx, []domain.TrackingEvent{*event}); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "tracking export failed", "tracking_id", event.Id, "error", err)
+		}
+	}
+	return nil
+}
+
+// clientTrackingID derives the document Id of a client event Id. The caller is part of it, so one
+// user's Ids never suppress another's events.
+func clientTrackingID(userID, clientEventID string) string {
+	sum := sha256.Sum256([]byte(userID + "\x00" + clientEventID))
+	return "c_" + hex.EncodeToString(sum[:16])
+}
+
+func (s *trackingService) WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+	return s.repo.WatchTracking(ctx, fn)
+}
+
+func (s *trackingService) EventPopularity(ctx context.Context) (map[string]float64, error) {
+	now := time.Now().UTC()
+	scores := make(map[string]float64)
+	err := s.repo.StreamTracking(ctx, now.AddDate(0, 0, -domain.PopularityWindowDays), func(t domain.TrackingEvent) error {
+		if t.EventID != "" {
+			if w := domain.PopularityWeight(t.Action, now.Sub(t.CreatedAt)); w > 0 {
+				scores[t.EventID] += w
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
 }
 
 func (s *trackingService) GetAllTracking(ctx context.Context) ([]domain.TrackingEvent, error) {
 	return s.repo.ListTracking(ctx)
 }
+
+func (s *trackingService) GetSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+	if sessionID == "" {
+		return nil, domain.ErrValidation("session id is required")
+	}
+	return s.repo.ListSession(ctx, sessionID)
+}
+
+// maxPurgePerRun keeps one purge run well inside the request timeout
+const maxPurgePerRun = 5000
+
+// PurgeOldTracking deletes tracking documents recorded more than olderThanDays ago
+func (s *trackingService) PurgeOldTracking(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error) {
+	if olderThanDays == 0 {
+		olderThanDays = domain.DefaultTrackingRetentionDays
+	}
+	if olderThanDays < 1 {
+		return nil, domain.ErrValidation("older_than_days must be at least 1")
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -olderThanDays)
+	purged, err := s.repo.PurgeTracking(ctx, cutoff, maxPurgePerRun)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PurgeResult{Purged: purged, Cutoff: cutoff, Remaining: purged == maxPurgePerRun}, nil
+}
+
+func (s *trackingService) DeleteTracking(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrValidation("tracking id is required")
+	}
+	return s.repo.DeleteTracking(ctx, id)
+}
+
+func (s *trackingService) DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error) {
+	if filter.IsZero() {
+		return nil, domain.ErrValidation("older_than or action is required")
+	}
+	deleted, err := s.repo.DeleteTrackingWhere(ctx, filter, maxPurgePerRun)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PurgeResult{Purged: deleted, Cutoff: filter.OlderThan, Remaining: deleted == maxPurgePerRun}, nil
+}
+
+func (s *trackingService) GetTrackingStats(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+	if groupBy == "" {
+		groupBy = "action"
+	}
+	if !slices.Contains(domain.TrackingStatsGroupByFields, groupBy) {
+		return nil, domain.ErrValidation("group_by must be one of: " + strings.Join(domain.TrackingStatsGroupByFields, ", "))
+	}
+	if interval == "" {
+		interval = domain.TrackingIntervalDay
+	}
+	maxBuckets, ok := domain.MaxTrackingStatsBuckets[interval]
+	if !ok {
+		return nil, domain.ErrValidation("interval must be one of: hour, day")
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	// The bucket holding to is included, so the current day or hour shows up
+	to = domain.NextTrackingBucket(domain.TrackingBucketStart(to, interval), interval)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -7)
+		if interval == domain.TrackingIntervalHour {
+			from = to.Add(-24 * time.Hour)
+		}
+	}
+	from = domain.TrackingBucketStart(from, interval)
+	if !from.Before(to) {
+		return nil, domain.ErrValidation("from must be before to")
+	}
+
+	// Every bucket is reported, empty ones with zero counts, so charts get a continuous series
+	var stats []domain.TrackingStats
+	for start := from; start.Before(to); start = domain.NextTrackingBucket(start, interval) {
+		if len(stats) == maxBuckets {
+			return nil, domain.ErrValidation(fmt.Sprintf("at most %d %s buckets can be requested", maxBuckets, interval))
+		}
+		stats = append(stats, domain.TrackingStats{Start: start, Counts: map[string]int64{}})
+	}
+	counted, err := s.repo.TrackingStats(ctx, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range counted {
+		i := slices.IndexFunc(stats, func(s domain.TrackingStats) bool { return s.Start.Equal(bucket.Start) })
+		if i >= 0 {
+			bucket.Start = stats[i].Start
+			stats[i] = bucket
+		}
+	}
+	return stats, nil
+}
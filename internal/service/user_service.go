@@ -0,0 +1,39 @@
+package service
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"context"
+)
+
+type UserService interface {
+	GetProfile(ctx context.Context, uid string) (map[string]interface{}, error)
+	// DeleteUserData erases the user's personal data; callers other than admins may only erase their own
+	DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error)
+}
+
+type userService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) UserService {
+	return &userService{repo: repo}
+}
+
+func (s *userService) GetProfile(ctx context.Context, uid string) (map[string]interface{}, error) {
+	if uid == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	return s.repo.GetProfile(ctx, uid)
+}
+
+func (s *userService) DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+	if uid == "" {
+		return nil, domain.ErrValidation("user id is required")
+	}
+	// Fails closed: a call without a principal (e.g. a route that lost its auth policy) deletes nothing
+	if p, ok := domain.PrincipalFromContext(ctx); !ok || (p.UID != uid && p.Role != domain.RoleAdmin) {
+		return nil, domain.ErrForbidden("users can only delete their own data")
+	}
+	return s.repo.DeleteUserData(ctx, uid)
+}
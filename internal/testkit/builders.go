@@ -0,0 +1,178 @@
+// Package testkit holds what the unit and integration tests share: builders for valid events and
+// tracking, Firestore emulator setup, and assertions on HTTP responses. Only tests import it.
+package testkit
+
+import (
+	"bibently.com/backend/internal/domain"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// builtIDs numbers the Ids of built documents so tests in one process never share one
+var builtIDs atomic.Int64
+
+func nextID(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, builtIDs.Add(1))
+}
+
+// EventBuilder builds a valid event, starting tomorrow in Warsaw unless told otherwise:
+//
+//	event := testkit.NewEventBuilder().InCity("Warsaw").StartingIn(2 * time.Hour).Build()
+type EventBuilder struct {
+	event domain.Event
+	now   time.Time
+}
+
+func NewEventBuilder() *EventBuilder {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &EventBuilder{
+		now: now,
+		event: domain.Event{
+			Id:        nextID("event"),
+			EventName: "Test Event",
+			City:      "Warsaw",
+			Country:   "Poland",
+			Type:      domain.TypeConcert,
+			Price:     50,
+			StartTime: now.Add(24 * time.Hour),
+			EndTime:   now.Add(26 * time.Hour),
+			Timezone:  "Europe/Warsaw",
+			CreatedAt: now,
+		},
+	}
+}
+
+func (b *EventBuilder) WithID(id string) *EventBuilder {
+	b.event.Id = id
+	return b
+}
+
+func (b *EventBuilder) Named(name string) *EventBuilder {
+	b.event.EventName = name
+	return b
+}
+
+func (b *EventBuilder) InCity(city string) *EventBuilder {
+	b.event.City = city
+	return b
+}
+
+func (b *EventBuilder) OfType(t domain.EventType) *EventBuilder {
+	b.event.Type = t
+	return b
+}
+
+func (b *EventBuilder) Priced(price float64) *EventBuilder {
+	b.event.Price = price
+	return b
+}
+
+func (b *EventBuilder) Free() *EventBuilder {
+	return b.Priced(0)
+}
+
+func (b *EventBuilder) WithCapacity(capacity int) *EventBuilder {
+	b.event.Capacity = capacity
+	return b
+}
+
+func (b *EventBuilder) ByOrganizer(id string) *EventBuilder {
+	b.event.OrganizerID = id
+	return b
+}
+
+// StartingAt keeps the event's duration
+func (b *EventBuilder) StartingAt(start time.Time) *EventBuilder {
+	duration := b.event.EndTime.Sub(b.event.StartTime)
+	b.event.StartTime = start
+	b.event.EndTime = start.Add(duration)
+	return b
+}
+
+// StartingIn starts the event d after the builder was created; a negative d makes it past
+func (b *EventBuilder) StartingIn(d time.Duration) *EventBuilder {
+	return b.StartingAt(b.now.Add(d))
+}
+
+func (b *EventBuilder) Lasting(d time.Duration) *EventBuilder {
+	b.event.EndTime = b.event.StartTime.Add(d)
+	return b
+}
+
+// Build returns a copy of the event, with the lowercase fields the repository filters on
+func (b *EventBuilder) Build() *domain.Event {
+	event := b.event
+	event.Normalize()
+	return &event
+}
+
+// BuildDTO is the create request of the event, for the HTTP API
+func (b *EventBuilder) BuildDTO() domain.EventDTO {
+	dto := domain.EventDTO{
+		EventName:   b.event.EventName,
+		City:        b.event.City,
+		Type:        b.event.Type,
+		Price:       b.event.Price,
+		StartTime:   b.event.StartTime.Format(time.RFC3339),
+		Capacity:    b.event.Capacity,
+		OrganizerID: b.event.OrganizerID,
+	}
+	if !b.event.EndTime.IsZero() {
+		dto.EndTime = b.event.EndTime.Format(time.RFC3339)
+	}
+	return dto
+}
+
+// TrackingBuilder builds a tracking event, a view recorded now unless told otherwise
+type TrackingBuilder struct {
+	tracking domain.TrackingEvent
+}
+
+func NewTrackingBuilder() *TrackingBuilder {
+	return &TrackingBuilder{tracking: domain.TrackingEvent{
+		Id:        nextID("tracking"),
+		Action:    "view",
+		CreatedAt: time.Now().UTC(),
+	}}
+}
+
+func (b *TrackingBuilder) WithID(id string) *TrackingBuilder {
+	b.tracking.Id = id
+	return b
+}
+
+func (b *TrackingBuilder) Action(action string) *TrackingBuilder {
+	b.tracking.Action = action
+	return b
+}
+
+func (b *TrackingBuilder) ForEvent(eventID string) *TrackingBuilder {
+	b.tracking.EventID = eventID
+	return b
+}
+
+func (b *TrackingBuilder) ByUser(uid string) *TrackingBuilder {
+	b.tracking.UserID = uid
+	return b
+}
+
+func (b *TrackingBuilder) InSession(id string) *TrackingBuilder {
+	b.tracking.SessionID = id
+	return b
+}
+
+func (b *TrackingBuilder) At(t time.Time) *TrackingBuilder {
+	b.tracking.CreatedAt = t
+	return b
+}
+
+// Ago records the event d before now
+func (b *TrackingBuilder) Ago(d time.Duration) *TrackingBuilder {
+	return b.At(time.Now().UTC().Add(-d))
+}
+
+func (b *TrackingBuilder) Build() *domain.TrackingEvent {
+	tracking := b.tracking
+	return &tracking
+}
@@ -0,0 +1,79 @@
+package testkit
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// NewRequest builds a request to the router. A string or []byte body is sent as is, anything
+// else other than nil as JSON.
+func NewRequest(t testing.TB, method, target string, body any) *http.Request {
+	t.Helper()
+	var r io.Reader
+	switch body := body.(type) {
+	case nil:
+	case string:
+		r = bytes.NewBufferString(body)
+	case []byte:
+		r = bytes.NewReader(body)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to encode request body: %v", err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, target, r)
+	if r != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// WithToken authenticates req with a bearer token (e.g. EmulatorToken)
+func WithToken(req *http.Request, token string) *http.Request {
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// Serve runs req through h and returns the recorded response
+func Serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+// AssertStatus stops the test when the response does not have status want
+func AssertStatus(t testing.TB, rr *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rr.Code != want {
+		t.Fatalf("Expected status %d, got %d: %s", want, rr.Code, rr.Body.String())
+	}
+}
+
+// DecodeData asserts status want and decodes the data of the APIResponse body into v
+func DecodeData(t testing.TB, rr *httptest.ResponseRecorder, want int, v any) {
+	t.Helper()
+	AssertStatus(t, rr, want)
+	if err := json.Unmarshal(rr.Body.Bytes(), &domain.APIResponse{Data: v}); err != nil {
+		t.Fatalf("Failed to decode response %s: %v", rr.Body.String(), err)
+	}
+}
+
+// AssertError asserts an error response with status want and machine-readable code
+func AssertError(t testing.TB, rr *httptest.ResponseRecorder, want int, code string) {
+	t.Helper()
+	AssertStatus(t, rr, want)
+	var resp domain.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response %s: %v", rr.Body.String(), err)
+	}
+	if resp.Error == "" || resp.Code != code {
+		t.Fatalf("Expected an error with code %q, got %s", code, rr.Body.String())
+	}
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	cloudtrace "google.golang.org/api/cloudtrace/v2"
+)
+
+// Cloud Trace limits
+const (
+	maxAttributes    = 32
+	maxDisplayName   = 128
+	maxAttributeSize = 256
+)
+
+// exporter writes finished spans with the Cloud Trace v2 REST API
+type exporter struct {
+	svc       *cloudtrace.Service
+	projectID string
+}
+
+func newExporter(svc *cloudtrace.Service, projectID string) *exporter {
+	return &exporter{svc: svc, projectID: projectID}
+}
+
+func (e *exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	req := &cloudtrace.BatchWriteSpansRequest{Spans: make([]*cloudtrace.Span, 0, len(spans))}
+	for _, s := range spans {
+		req.Spans = append(req.Spans, e.convert(s))
+	}
+	_, err := e.svc.Projects.Traces.BatchWrite("projects/"+e.projectID, req).Context(ctx).Do()
+	return err
+}
+
+func (e *exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (e *exporter) convert(s sdktrace.ReadOnlySpan) *cloudtrace.Span {
+	sc := s.SpanContext()
+	span := &cloudtrace.Span{
+		Name:        fmt.Sprintf("projects/%s/traces/%s/spans/%s", e.projectID, sc.TraceID(), sc.SpanID()),
+		SpanId:      sc.SpanID().String(),
+		DisplayName: truncatable(s.Name(), maxDisplayName),
+		StartTime:   s.StartTime().UTC().Format(time.RFC3339Nano),
+		EndTime:     s.EndTime().UTC().Format(time.RFC3339Nano),
+		SpanKind:    spanKind(s.SpanKind()),
+		Attributes:  attributes(s.Attributes()),
+	}
+	if parent := s.Parent(); parent.IsValid() {
+		span.ParentSpanId = parent.SpanID().String()
+		span.SameProcessAsParentSpan = !parent.IsRemote()
+	}
+	if st := s.Status(); st.Code == codes.Error {
+		// google.rpc.Code UNKNOWN; Cloud Trace only distinguishes OK from failed
+		span.Status = &cloudtrace.Status{Code: 2, Message: st.Description}
+	}
+	return span
+}
+
+func attributes(kvs []attribute.KeyValue) *cloudtrace.Attributes {
+	if len(kvs) == 0 {
+		return nil
+	}
+	attrs := &cloudtrace.Attributes{AttributeMap: make(map[string]cloudtrace.AttributeValue, len(kvs))}
+	for i, kv := range kvs {
+		if i == maxAttributes {
+			attrs.DroppedAttributesCount = int64(len(kvs) - maxAttributes)
+			break
+		}
+		var v cloudtrace.AttributeValue
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			v.BoolValue = kv.Value.AsBool()
+			v.ForceSendFields = []string{"BoolValue"}
+		case attribute.INT64:
+			v.IntValue = kv.Value.AsInt64()
+			v.ForceSendFields = []string{"IntValue"}
+		default:
+			v.StringValue = truncatable(kv.Value.Emit(), maxAttributeSize)
+		}
+		attrs.AttributeMap[string(kv.Key)] = v
+	}
+	return attrs
+}
+
+func truncatable(s string, limit int) *cloudtrace.TruncatableString {
+	if len(s) <= limit {
+		return &cloudtrace.TruncatableString{Value: s}
+	}
+	return &cloudtrace.TruncatableString{Value: s[:limit], TruncatedByteCount: int64(len(s) - limit)}
+}
+
+func spanKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		return "INTERNAL"
+	}
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CloudTraceHeader is set by Google front ends: TRACE_ID/SPAN_ID;o=OPTIONS, with a decimal span id
+const CloudTraceHeader = "X-Cloud-Trace-Context"
+
+// CloudTraceContext propagates trace context in the X-Cloud-Trace-Context format
+type CloudTraceContext struct{}
+
+func (CloudTraceContext) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	spanID := sc.SpanID()
+	sampled := 0
+	if sc.IsSampled() {
+		sampled = 1
+	}
+	carrier.Set(CloudTraceHeader, fmt.Sprintf("%s/%d;o=%d", sc.TraceID(), beUint64(spanID[:]), sampled))
+}
+
+func (CloudTraceContext) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	// A W3C traceparent extracted earlier takes precedence
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	sc, ok := ParseCloudTraceContext(carrier.Get(CloudTraceHeader))
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+func (CloudTraceContext) Fields() []string {
+	return []string{CloudTraceHeader}
+}
+
+// ParseCloudTraceContext decodes an X-Cloud-Trace-Context value. The span id and options are optional.
+func ParseCloudTraceContext(header string) (trace.SpanContext, bool) {
+	tracepart, rest, _ := strings.Cut(header, "/")
+	traceID, err := trace.TraceIDFromHex(tracepart)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanpart, options, _ := strings.Cut(rest, ";")
+
+	var spanID trace.SpanID
+	if n, err := strconv.ParseUint(spanpart, 10, 64); err == nil {
+		for i := 7; i >= 0; i-- {
+			spanID[i] = byte(n)
+			n >>= 8
+		}
+	}
+	if !spanID.IsValid() {
+		// Tracing needs a parent span id; reuse the high trace bytes so the span context is valid
+		copy(spanID[:], traceID[:8])
+	}
+
+	var flags trace.TraceFlags
+	if options == "o=1" {
+		flags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	}), true
+}
+
+func beUint64(b []byte) uint64 {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+// Package tracing configures OpenTelemetry for the service: spans are exported to Cloud Trace
+// and trace context is propagated in both the W3C and the X-Cloud-Trace-Context formats.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	cloudtrace "google.golang.org/api/cloudtrace/v2"
+)
+
+// instrumentationName identifies spans created by this service
+const instrumentationName = "bibently.com/backend"
+
+func init() {
+	// Propagation works even before Setup, so logs correlate with inbound traces when export is off
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		CloudTraceContext{},
+	))
+}
+
+// Tracer returns the service tracer from the global provider (a no-op until Setup runs)
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs a global tracer provider exporting to Cloud Trace in projectID.
+// sampleRatio applies to root spans; inbound sampled traces are always continued.
+// Spans are exported in batches; callers flush the returned provider before the instance may be
+// throttled (after each request on Cloud Functions) and shut it down when the process exits.
+func Setup(ctx context.Context, projectID string, sampleRatio float64) (*sdktrace.TracerProvider, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("tracing: project id is required")
+	}
+	svc, err := cloudtrace.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: creating cloud trace client: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(newExporter(svc, projectID)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider, nil
+}
@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AdminHandler serves maintenance operations under /admin (admin only, enforced by WithAuthProtection)
+type AdminHandler struct {
+	events   service.EventService
+	audit    service.AuditService
+	tracking service.TrackingService
+	logLevel *slog.LevelVar
+	mux      *http.ServeMux
+}
+
+func NewAdminHandler(events service.EventService, audit service.AuditService, tracking service.TrackingService, logLevel *slog.LevelVar) *AdminHandler {
+	h := &AdminHandler{
+		events:   events,
+		audit:    audit,
+		tracking: tracking,
+		logLevel: logLevel,
+		mux:      http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *AdminHandler) routes() {
+	h.mux.HandleFunc("POST /admin/archive-past-events", h.handleArchivePastEvents)
+	h.mux.HandleFunc("GET /admin/tracking/stream", h.handleTrackingStream)
+	if h.audit != nil {
+		h.mux.HandleFunc("GET /admin/audit-logs", h.handleListAuditLogs)
+	}
+	if h.logLevel != nil {
+		h.mux.HandleFunc("GET /admin/log-level", h.handleGetLogLevel)
+		h.mux.HandleFunc("PUT /admin/log-level", h.handleSetLogLevel)
+	}
+}
+
+func (h *AdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleArchivePastEvents moves finished events to the events_archive collection
+func (h *AdminHandler) handleArchivePastEvents(w http.ResponseWriter, r *http.Request) {
+	days := 0
+	if val := r.URL.Query().Get("older_than_days"); val != "" {
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			respondError(w, r, domain.ErrValidation("older_than_days must be a valid integer"))
+			return
+		}
+		days = i
+	}
+
+	result, err := h.events.ArchivePastEvents(r.Context(), days)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: result})
+}
+
+// handleListAuditLogs returns recorded write operations, newest first
+func (h *AdminHandler) handleListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.AuditFilter{
+		Actor:      q.Get("actor"),
+		Resource:   q.Get("resource"),
+		ResourceID: q.Get("resource_id"),
+	}
+	if val := q.Get("limit"); val != "" {
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			respondError(w, r, domain.ErrValidation("limit must be a valid integer"))
+			return
+		}
+		filter.Limit = i
+	}
+
+	entries, err := h.audit.ListAuditLogs(r.Context(), filter)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: entries})
+}
+
+// handleGetLogLevel returns the level this instance logs at
+func (h *AdminHandler) handleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: domain.LogLevel{Level: logging.LevelName(h.logLevel.Level())}})
+}
+
+// handleSetLogLevel changes the level this instance logs at, e.g. to debug a misbehaving instance
+func (h *AdminHandler) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req domain.LogLevel
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+	previous := h.logLevel.Level()
+	h.logLevel.Set(level)
+	// Logged at warn so the change is visible whatever the old and new levels are
+	logging.FromContext(r.Context()).WarnContext(r.Context(), "log level changed",
+		"from", logging.LevelName(previous), "to", logging.LevelName(level), "actor", domain.ActorFromContext(r.Context()))
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: domain.LogLevel{Level: logging.LevelName(level)}})
+}
+
+// handleTrackingStream pushes tracking events to an admin dashboard as they are stored
+func (h *AdminHandler) handleTrackingStream(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// The listener runs on its own goroutine; this one owns the writer
+	events := make(chan domain.TrackingEvent)
+	done := make(chan error, 1)
+	go func() {
+		done <- h.tracking.WatchTracking(ctx, func(t domain.TrackingEvent) error {
+			select {
+			case events <- t:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	stream := startSSE(w)
+	heartbeat := time.NewTicker(SSEHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case err = <-done:
+			if err != nil {
+				logError(r.Context(), "tracking stream interrupted", err)
+				_ = stream.send("error", "", domain.APIResponse{Error: "stream interrupted", RequestID: w.Header().Get(RequestIDHeader)})
+			}
+			return
+		case t := <-events:
+			err = stream.send("tracking", t.Id, domain.NewTrackingEventResponse(&t))
+		case <-heartbeat.C:
+			err = stream.ping()
+		}
+		if err != nil {
+			return // the client is gone
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/tasks"
+	"encoding/json"
+	"net/http"
+)
+
+// AnnouncementHandler is the task worker that notifies subscribers about a newly created event
+type AnnouncementHandler struct {
+	service service.AnnouncementService
+	verify  tasks.Verifier
+	mux     *http.ServeMux
+}
+
+func NewAnnouncementHandler(svc service.AnnouncementService, verify tasks.Verifier) *AnnouncementHandler {
+	h := &AnnouncementHandler{
+		service: svc,
+		verify:  verify,
+		mux:     http.NewServeMux(),
+	}
+	h.mux.HandleFunc("POST "+service.AnnounceEventPath, h.handleAnnounce)
+	return h
+}
+
+func (h *AnnouncementHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleAnnounce is called by Cloud Tasks once per created event.
+// Any non-2xx response makes Cloud Tasks retry; batches already delivered are skipped then.
+func (h *AnnouncementHandler) handleAnnounce(w http.ResponseWriter, r *http.Request) {
+	if !authenticateTask(w, r, h.verify) {
+		return
+	}
+
+	var task domain.AnnouncementTask
+	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+		respondError(w, r, domain.ErrValidation("Invalid JSON body"))
+		return
+	}
+
+	if err := h.service.Announce(r.Context(), task.EventID); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
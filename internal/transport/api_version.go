@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersionPrefix is the current API version. Routes are served under it and, for existing
+// clients, at their unversioned paths, which answer with Deprecation and Sunset headers.
+const APIVersionPrefix = "/v1"
+
+// legacyDeprecatedSince is when the unversioned paths were deprecated in favour of /v1
+var legacyDeprecatedSince = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+// unversionedPrefixes are infrastructure endpoints (probes, scrapes, Cloud Tasks and Scheduler
+// targets) and the API description, which stay outside API versioning and are never deprecated
+var unversionedPrefixes = []string{"/healthz", "/readyz", "/warmup", "/version", "/openapi.json", "/swagger/", "/metrics", "/debug/pprof/", "/internal/"}
+
+// apiPath returns path without the version prefix, so route policies and request matchers
+// treat /v1/events and /events alike
+func apiPath(path string) string {
+	if path == APIVersionPrefix {
+		return "/"
+	}
+	if strings.HasPrefix(path, APIVersionPrefix+"/") {
+		return strings.TrimPrefix(path, APIVersionPrefix)
+	}
+	return path
+}
+
+// withAPIVersions mounts next under APIVersionPrefix and keeps the unversioned paths as deprecated
+// aliases. sunset, when set, announces the date the aliases stop working (RFC 8594).
+func withAPIVersions(next http.Handler, sunset time.Time) http.Handler {
+	versioned := stripPrefix(APIVersionPrefix, next)
+	deprecation := "@" + strconv.FormatInt(legacyDeprecatedSince.Unix(), 10) // RFC 9745
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiPath(r.URL.Path) != r.URL.Path {
+			versioned.ServeHTTP(w, r)
+			return
+		}
+		if !isUnversioned(r.URL.Path) {
+			h := w.Header()
+			h.Set("Deprecation", deprecation)
+			if !sunset.IsZero() {
+				h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			h.Add("Link", "<"+APIVersionPrefix+r.URL.EscapedPath()+`>; rel="successor-version"`)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isUnversioned(path string) bool {
+	for _, prefix := range unversionedPrefixes {
+		if path == strings.TrimSuffix(prefix, "/") || strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withTrailingSlash serves a collection path without its trailing slash as if the slash were there.
+// Rewriting in place rather than redirecting saves clients a round trip and keeps fetch clients that
+// refuse to replay a POST body on 307 working.
+func withTrailingSlash(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = r.URL.Path + "/"
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = r.URL.RawPath + "/"
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxDumpLine bounds one line of a restored dump
+const maxDumpLine = 1 << 20 // 1 MB
+
+// BackupHandler serves database backups and restores under /admin/backups (admin only)
+type BackupHandler struct {
+	backups service.BackupService
+	mux     *http.ServeMux
+}
+
+func NewBackupHandler(backups service.BackupService) *BackupHandler {
+	h := &BackupHandler{backups: backups, mux: http.NewServeMux()}
+	h.routes()
+	return h
+}
+
+func (h *BackupHandler) routes() {
+	h.mux.HandleFunc("GET /admin/backups/dump", h.handleDump)
+	h.mux.HandleFunc("POST /admin/backups/dump", h.handleRestoreDump)
+	if h.backups.Managed() {
+		h.mux.HandleFunc("POST /admin/backups", h.handleExport)
+		h.mux.HandleFunc("POST /admin/backups/restore", h.handleImport)
+		h.mux.HandleFunc("GET /admin/backups/operations/{id}", h.handleGetOperation)
+	}
+}
+
+func (h *BackupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleExport starts a managed export of the database to BACKUP_BUCKET
+func (h *BackupHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	var req domain.BackupRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, r, err)
+			return
+		}
+	}
+
+	op, err := h.backups.StartExport(r.Context(), req)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: op})
+}
+
+// handleImport starts a managed import of an export of BACKUP_BUCKET
+func (h *BackupHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	var req domain.RestoreRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(req); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	op, err := h.backups.StartImport(r.Context(), req)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: op})
+}
+
+// handleGetOperation returns the progress of a managed export or import
+func (h *BackupHandler) handleGetOperation(w http.ResponseWriter, r *http.Request) {
+	op, err := h.backups.GetOperation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: op})
+}
+
+// handleDump streams the documents of the dumped collections, one record per line
+func (h *BackupHandler) handleDump(w http.ResponseWriter, r *http.Request) {
+	var collections []string
+	if val := r.URL.Query().Get("collections"); val != "" {
+		collections = strings.Split(val, ",")
+	}
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	written := 0
+
+	err := h.backups.Dump(r.Context(), collections, func(record domain.BackupRecord) error {
+		if written == 0 {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(http.StatusOK)
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+		written++
+		if written%ndjsonFlushEvery == 0 {
+			_ = rc.Flush()
+		}
+		return nil
+	})
+
+	// Same contract as event exports: an error after the first line ends the stream with an error line
+	switch {
+	case err != nil && written == 0:
+		respondError(w, r, err)
+	case err != nil:
+		logError(r.Context(), "dump interrupted", err)
+		_ = enc.Encode(domain.APIResponse{Error: "stream interrupted", RequestID: w.Header().Get(RequestIDHeader)})
+	case written == 0:
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleRestoreDump writes the records of a dump back to the database
+func (h *BackupHandler) handleRestoreDump(w http.ResponseWriter, r *http.Request) {
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxDumpLine)
+	next := func() (domain.BackupRecord, error) {
+		var record domain.BackupRecord
+		if !scanner.Scan() {
+			if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+				// The scanner stops here, so this failure is also the end of the restore
+				return record, domain.ErrValidation("line longer than 1 MB")
+			}
+			return record, cmp.Or(scanner.Err(), io.EOF)
+		}
+		// A line that is not a record is reported and skipped, like an invalid record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return record, domain.ErrValidation("not a JSON record: " + err.Error())
+		}
+		return record, nil
+	}
+
+	result, err := h.backups.Restore(r.Context(), next, domain.RestoreConflict(r.URL.Query().Get("on_conflict")))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: result})
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/tasks"
+	"encoding/json"
+	"net/http"
+)
+
+// CronDailyPath is the target of the daily Cloud Scheduler job
+const CronDailyPath = "/internal/cron/daily"
+
+// CronHandler serves the scheduled housekeeping jobs. Callers authenticate with a Google OIDC token.
+type CronHandler struct {
+	maintenance service.MaintenanceService
+	verify      tasks.Verifier
+	mux         *http.ServeMux
+}
+
+func NewCronHandler(svc service.MaintenanceService, verify tasks.Verifier) *CronHandler {
+	h := &CronHandler{
+		maintenance: svc,
+		verify:      verify,
+		mux:         http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *CronHandler) routes() {
+	h.mux.HandleFunc("POST "+CronDailyPath, h.handleDaily)
+}
+
+func (h *CronHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleDaily runs the daily housekeeping: archiving, tracking purge and facet refresh.
+// A non-2xx response makes Cloud Scheduler retry; every step is safe to repeat.
+func (h *CronHandler) handleDaily(w http.ResponseWriter, r *http.Request) {
+	if h.verify == nil {
+		http.Error(w, "Forbidden: scheduler is not configured", http.StatusForbidden)
+		return
+	}
+	if err := h.verify(r); err != nil {
+		logError(r.Context(), "scheduler authentication failed", err)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	report, err := h.maintenance.RunDaily(r.Context())
+	if err != nil {
+		logError(r.Context(), "daily maintenance failed", err)
+		writeErrorResponse(w, http.StatusInternalServerError, domain.APIResponse{Data: report, Error: "daily maintenance failed"})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: report})
+}
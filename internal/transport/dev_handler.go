@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// DevHandler serves development tools; the router only mounts it outside production
+type DevHandler struct {
+	seeds service.SeedService
+	mux   *http.ServeMux
+}
+
+func NewDevHandler(seeds service.SeedService) *DevHandler {
+	h := &DevHandler{seeds: seeds, mux: http.NewServeMux()}
+	h.routes()
+	return h
+}
+
+func (h *DevHandler) routes() {
+	h.mux.HandleFunc("POST /dev/seed", h.handleSeed)
+}
+
+func (h *DevHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleSeed generates fake events and tracking into the database serving the request
+func (h *DevHandler) handleSeed(w http.ResponseWriter, r *http.Request) {
+	var req domain.SeedRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, r, err)
+			return
+		}
+	}
+
+	result, err := h.seeds.Seed(r.Context(), req)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: result})
+}
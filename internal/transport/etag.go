@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// defaultCacheControl lets clients keep responses but revalidate them with If-None-Match
+const defaultCacheControl = "no-cache"
+
+// eventETag versions a single event by its last write, and by is_past, which flips without one.
+// Counters (views, attendees) change without a write too, so the tag is weak: a 304 may serve
+// slightly stale counters, never stale content.
+func eventETag(event *domain.EventResponse, variant string) string {
+	version := event.UpdatedAt
+	if version.IsZero() {
+		version = event.CreatedAt
+	}
+	tag := event.Id + "-" + strconv.FormatInt(version.UnixNano(), 36)
+	if event.IsPast {
+		tag += "-past"
+	}
+	if variant != "" {
+		sum := sha256.Sum256([]byte(variant))
+		tag += "-" + hex.EncodeToString(sum[:4])
+	}
+	return `W/"` + tag + `"`
+}
+
+// writeCacheable writes resp as JSON with an ETag and Cache-Control, answering 304 Not Modified
+// when If-None-Match already names the tag. An empty etag is derived from the encoded body.
+func writeCacheable(w http.ResponseWriter, r *http.Request, etag, cacheControl string, resp interface{}) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(resp); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if etag == "" {
+		sum := sha256.Sum256(body.Bytes())
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	if cacheControl == "" {
+		cacheControl = defaultCacheControl
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	_, _ = w.Write(body.Bytes())
+}
+
+// etagMatches applies the weak comparison used by If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -3,22 +3,45 @@ package transport
 import (
 	"bibently.com/backend/internal/domain"
 	"bibently.com/backend/internal/service"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	// maxImportSize caps the multipart body accepted by the CSV import (10 MB)
+	maxImportSize = 10 << 20
+	// maxImportRows mirrors the limit enforced on BatchEventRequest
+	maxImportRows = 5000
+
+	// Async imports are processed in chunks by the task worker, so they accept larger files.
+	// 30 MB stays under the 32 MB request limit of Cloud Functions.
+	maxAsyncImportSize = 30 << 20
+	maxAsyncImportRows = 100000
+)
+
+// importRequiredColumns must be present in the CSV header row
+var importRequiredColumns = []string{"event_name", "city", "type", "start_time"}
+
 type EventHandler struct {
-	service service.EventService
-	mux     *http.ServeMux
+	service      service.EventService
+	mux          *http.ServeMux
+	cacheControl string // Cache-Control for cacheable reads; "" uses defaultCacheControl
 }
 
-func NewEventHandler(svc service.EventService) *EventHandler {
+func NewEventHandler(svc service.EventService, cacheControl string) *EventHandler {
 	h := &EventHandler{
-		service: svc,
-		mux:     http.NewServeMux(),
+		service:      svc,
+		mux:          http.NewServeMux(),
+		cacheControl: cacheControl,
 	}
 	h.routes()
 	return h
@@ -29,47 +52,59 @@ func (h *EventHandler) routes() {
 	h.mux.HandleFunc("GET /{$}", h.handleList)
 	h.mux.HandleFunc("POST /{$}", h.handleCreate)
 	h.mux.HandleFunc("POST /batch", h.handleBatchCreate)
+	h.mux.HandleFunc("POST /import", h.handleImport)
+	h.mux.HandleFunc("GET /stats", h.handleStats)
+	h.mux.HandleFunc("GET /stats/summary", h.handleStatsSummary)
+	h.mux.HandleFunc("GET /price-buckets", h.handlePriceBuckets)
+	h.mux.HandleFunc("GET /featured", h.handleListFeatured)
+	h.mux.HandleFunc("GET /types", h.handleListTypes)
+	h.mux.HandleFunc("GET /batch-get", h.handleBatchGet)
 
 	// Item routes (matched with path value)
+	h.mux.HandleFunc("GET /slug/{slug}", h.handleGetBySlug)
 	h.mux.HandleFunc("GET /{id}", h.handleGet)
 	h.mux.HandleFunc("PUT /{id}", h.handleUpdate)
 	h.mux.HandleFunc("DELETE /{id}", h.handleDelete)
+	h.mux.HandleFunc("PUT /{id}/featured", h.handleSetFeatured)
+	// "GET /{id}/history" would be ambiguous with "GET /slug/{slug}" for ServeMux, so sub-resources dispatch here
+	h.mux.HandleFunc("GET /{id}/{sub}", h.handleItemSubresource)
+}
+
+func (h *EventHandler) handleItemSubresource(w http.ResponseWriter, r *http.Request) {
+	switch r.PathValue("sub") {
+	case "history":
+		h.handleHistory(w, r)
+	default:
+		respondError(w, r, domain.ErrNotFound("Not found"))
+	}
 }
 
 func (h *EventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	h.mux.ServeHTTP(w, r)
+	// Reads are also served as NDJSON and JSON:API depending on Accept
+	w.Header().Add("Vary", "Accept")
+	serveMux(h.mux, w, r)
 }
 
 // handleCreate creates a new event
-// @Summary Create Event
-// @Description Create a new event item
-// @Tags events
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param event body domain.EventDTO true "Event Data"
-// @Success 201 {object} domain.APIResponse{data=string} "Returns Event Id"
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Router /events [post]
 func (h *EventHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	var eventDTO domain.EventDTO
-	if err := json.NewDecoder(r.Body).Decode(&eventDTO); err != nil {
-		respondError(w, domain.ErrValidation("Invalid JSON body"))
+	if err := decodeJSON(r, &eventDTO); err != nil {
+		respondError(w, r, err)
 		return
 	}
 	if err := domain.Validate.Struct(eventDTO); err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+		respondError(w, r, domain.ErrValidation(err.Error()))
 		return
 	}
 	event, err := domain.EventDTOToModel(&eventDTO)
 
 	if err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+		respondError(w, r, domain.ErrValidation(err.Error()))
 		return
 	}
 	if err := h.service.CreateEvent(r.Context(), event); err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -77,115 +112,255 @@ func (h *EventHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleBatchCreate creates multiple events
-// @Summary Batch Create Events
-// @Description Create multiple events in one go
-// @Tags events
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param batch body domain.BatchEventRequest true "Batch Data"
-// @Success 201 {object} domain.APIResponse{data=string}
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Router /events/batch [post]
 func (h *EventHandler) handleBatchCreate(w http.ResponseWriter, r *http.Request) {
 	var req domain.BatchEventRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, domain.ErrValidation("Invalid JSON body"))
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, r, err)
 		return
 	}
 
 	if err := domain.Validate.Struct(req); err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+		respondError(w, r, domain.ErrValidation(err.Error()))
 		return
 	}
 
+	// 1. Validate and convert each item; invalid items are reported, not fatal
+	var invalid []domain.BatchItemResult
 	var events []*domain.Event
-	for i, dto := range req.Events {
-		model, err := domain.EventDTOToModel(&dto)
+	var indexes []int // request index of each entry in events
+	for i := range req.Events {
+		if err := domain.Validate.Struct(req.Events[i]); err != nil {
+			invalid = append(invalid, domain.BatchItemResult{Index: i, Error: err.Error()})
+			continue
+		}
+		model, err := domain.EventDTOToModel(&req.Events[i])
 		if err != nil {
-			respondError(w, domain.ErrValidation(fmt.Sprintf("Item %d: %v", i, err)))
-			return
+			invalid = append(invalid, domain.BatchItemResult{Index: i, Error: err.Error()})
+			continue
 		}
 		events = append(events, model)
+		indexes = append(indexes, i)
 	}
 
-	if err := h.service.BatchCreateEvents(r.Context(), events); err != nil {
-		respondError(w, err)
+	// 2. Store the valid items and map their results back to request positions
+	result := &domain.BatchCreateResult{Created: []domain.BatchItemResult{}, Failed: []domain.BatchItemResult{}}
+	if len(events) > 0 {
+		saved, err := h.service.BatchCreateEvents(r.Context(), events)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		for _, item := range saved.Created {
+			item.Index = indexes[item.Index]
+			result.Created = append(result.Created, item)
+		}
+		for _, item := range saved.Failed {
+			item.Index = indexes[item.Index]
+			result.Failed = append(result.Failed, item)
+		}
+	}
+	result.Failed = append(result.Failed, invalid...)
+	slices.SortFunc(result.Failed, func(a, b domain.BatchItemResult) int { return a.Index - b.Index })
+
+	// 3. 201 only when everything succeeded; otherwise 207 so callers inspect the items
+	if len(result.Failed) > 0 {
+		w.WriteHeader(http.StatusMultiStatus)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: result})
+}
+
+// handleImport creates events from an uploaded CSV file
+func (h *EventHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	rows, rejected, err := readImportCSV(w, r, maxImportSize, maxImportRows)
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: fmt.Sprintf("Successfully created %d events", len(events))})
+	// 1. Lay out the report in file order
+	report := domain.ImportReport{Rows: make([]domain.ImportRowResult, len(rows)+len(rejected))}
+	for _, res := range rejected {
+		report.Rows[res.Row-1] = res
+	}
+	events := make([]*domain.Event, len(rows))
+	for i := range rows {
+		events[i] = &rows[i].Event
+		report.Rows[rows[i].Row-1] = domain.ImportRowResult{Row: rows[i].Row}
+	}
+
+	// 2. Persist the valid rows in one batch
+	if len(events) > 0 {
+		saved, err := h.service.BatchCreateEvents(r.Context(), events)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		for _, item := range saved.Created {
+			row := &report.Rows[rows[item.Index].Row-1]
+			row.Success = true
+			row.EventID = item.Id
+		}
+		for _, item := range saved.Failed {
+			report.Rows[rows[item.Index].Row-1].Error = item.Error
+		}
+	}
+
+	report.Total = len(report.Rows)
+	for _, row := range report.Rows {
+		if row.Success {
+			report.Created++
+		}
+	}
+	report.Failed = report.Total - report.Created
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: report})
+}
+
+// readImportCSV parses the uploaded "file" form field. Valid rows are converted to events;
+// rows that fail parsing or validation are returned as results carrying the error.
+// A non-nil error means the upload as a whole is unusable.
+func readImportCSV(w http.ResponseWriter, r *http.Request, maxSize int64, maxRows int) ([]domain.ImportChunkRow, []domain.ImportRowResult, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	if err := r.ParseMultipartForm(maxSize); err != nil {
+		return nil, nil, domain.ErrValidation("Invalid multipart form or file too large")
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, nil, domain.ErrValidation("Missing 'file' form field")
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// 1. Read the header row and map column names to their positions
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, domain.ErrValidation("CSV file is empty or has an invalid header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range importRequiredColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, domain.ErrValidation(fmt.Sprintf("CSV header is missing required column '%s'", required))
+		}
+	}
+
+	// 2. Parse and validate each row independently
+	var rows []domain.ImportChunkRow
+	var rejected []domain.ImportRowResult
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row > maxRows {
+			return nil, nil, domain.ErrValidation(fmt.Sprintf("CSV file exceeds the limit of %d rows", maxRows))
+		}
+		if err != nil {
+			rejected = append(rejected, domain.ImportRowResult{Row: row, Error: err.Error()})
+			continue
+		}
+
+		event, err := csvRecordToEvent(record, columns)
+		if err != nil {
+			rejected = append(rejected, domain.ImportRowResult{Row: row, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, domain.ImportChunkRow{Row: row, Event: *event})
+	}
+
+	return rows, rejected, nil
+}
+
+// csvRecordToEvent maps a CSV record onto an EventDTO, validates it and converts it to the model
+func csvRecordToEvent(record []string, columns map[string]int) (*domain.Event, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	dto := domain.EventDTO{
+		EventName:   field("event_name"),
+		City:        field("city"),
+		Type:        domain.EventType(field("type")),
+		StartTime:   field("start_time"),
+		EndTime:     field("end_time"),
+		Description: field("description"),
+		Visibility:  domain.Visibility(field("visibility")),
+		EventURL:    field("event_url"),
+		ImageUrl:    field("image_url"),
+	}
+
+	if val := field("price"); val != "" {
+		price, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("price must be a valid number")
+		}
+		dto.Price = price
+	}
+
+	location, err := domain.ParseGeoPoint(field("latitude"), field("longitude"))
+	if err != nil {
+		return nil, err
+	}
+	dto.Location = location
+
+	if err := domain.Validate.Struct(dto); err != nil {
+		return nil, err
+	}
+	return domain.EventDTOToModel(&dto)
 }
 
 // handleUpdate updates an existing event
-// @Summary Update Event
-// @Description Update specific fields of an event
-// @Tags events
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Event Id"
-// @Param event body map[string]interface{} true "Fields to update"
-// @Success 200 {object} domain.APIResponse{data=string}
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Failure 500 {object} domain.APIResponse{error=string}
-// @Router /events/{id} [put]
 func (h *EventHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		respondError(w, domain.ErrValidation("Missing id path parameter"))
+		respondError(w, r, domain.ErrValidation("Missing id path parameter"))
 		return
 	}
 
 	// 1. Decode into the strict DTO instead of a generic map
 	var dto domain.UpdateEventDTO
-	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		respondError(w, domain.ErrValidation("Invalid JSON body or type mismatch"))
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
 		return
 	}
 
 	// 2. Validate the DTO (checks max length, number ranges, formats)
 	if err := domain.Validate.Struct(dto); err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+		respondError(w, r, domain.ErrValidation(err.Error()))
 		return
 	}
 
-	// 3. Convert validated DTO to a safe map for the repository
-	// Only fields that were actually present (non-nil) are added.
-	updates := make(map[string]interface{})
-
-	if dto.EventName != nil {
-		updates["event_name"] = *dto.EventName
-	}
-	if dto.City != nil {
-		updates["city"] = *dto.City
-	}
-	if dto.Price != nil {
-		updates["price"] = *dto.Price
-	}
-	if dto.Type != nil {
-		updates["type"] = *dto.Type
-	}
-	if dto.StartTime != nil {
-		// We already validated the format in the DTO, so parsing is safe
-		t, _ := time.Parse(time.RFC3339, *dto.StartTime)
-		updates["start_time"] = t
-	}
-	if dto.EndTime != nil {
-		t, _ := time.Parse(time.RFC3339, *dto.EndTime)
-		updates["end_time"] = t
+	// 3. Convert validated DTO to the whitelisted update map
+	updates, err := domain.UpdateEventDTOToMap(&dto)
+	if err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
 	}
 
 	// 4. Fail if the request contained no valid updatable fields
 	if len(updates) == 0 {
-		respondError(w, domain.ErrValidation("No valid fields provided for update"))
+		respondError(w, r, domain.ErrValidation("No valid fields provided for update"))
 		return
 	}
 
 	// 5. Call Service
 	if err := h.service.UpdateEvent(r.Context(), id, updates); err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 
@@ -194,47 +369,77 @@ func (h *EventHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleList lists events with strict validation and filtering
-// @Summary List Events
-// @Description Get a list of events with optional filters
-// @Tags events
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param event_name query string false "Filter by Event Name"
-// @Param city query string false "Filter by City"
-// @Param type query domain.EventType false "Filter by Type"
-// @Param min_price query number false "Minimum Price"
-// @Param max_price query number false "Maximum Price"
-// @Param start_date query string false "Start Date (RFC3339)"
-// @Param end_date query string false "End Date (RFC3339)"
-// @Param page_size query int false "Page Size (1-100)"
-// @Param page_token query string false "Pagination Token"
-// @Param sort_key query string false "Sort Key (e.g. price, start_time)"
-// @Param sort_dir query string false "Sort Direction (asc, desc)"
-// @Success 200 {object} domain.APIResponse{data=[]domain.Event}
-// @Router /events [get]
 func (h *EventHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
 	// 1. Bind Query Params to DTO
+	dto, err := eventListDTOFromQuery(q)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	// 2. Validate and convert to a domain search
+	searchReq, err := searchRequestFromDTO(dto, q.Get("fields"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	fields := searchReq.Fields
+
+	// 3. Call Service (exports stream every match instead of one page)
+	if WantsNDJSON(r) {
+		h.streamList(w, r, searchReq)
+		return
+	}
+	events, meta, err := h.service.ListEvents(r.Context(), searchReq)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if len(fields) == 0 {
+		fields = meta.Fields // the repository's default list projection
+	}
+
+	// 4. Response
+	responses := service.NewEventResponses(events, time.Now())
+	if WantsJSONAPI(r) {
+		writeJSONAPI(w, r, "", h.cacheControl, eventResources(responses, fields), paginationLinks(r, meta))
+		return
+	}
+	var data interface{} = responses
+	if len(fields) > 0 {
+		data = projectEvents(responses, fields)
+	}
+	resp := domain.APIPaginationResponse{
+		Data: data,
+		Meta: &meta,
+	}
+	writeCacheable(w, r, "", h.cacheControl, resp)
+}
+
+// eventListDTOFromQuery binds the list query parameters, rejecting numbers that do not parse
+func eventListDTOFromQuery(q url.Values) (domain.EventListDTO, error) {
 	// We map strings directly and parse numbers manually to catch type errors early.
 	dto := domain.EventListDTO{
-		PageToken: q.Get("page_token"),
-		SortDir:   q.Get("sort_dir"),
-		SortKey:   q.Get("sort_key"),
-		StartDate: q.Get("start_date"),
-		EndDate:   q.Get("end_date"),
-		City:      q.Get("city"),
-		EventName: q.Get("event_name"),
-		Type:      q.Get("type"),
+		PageToken:   q.Get("page_token"),
+		SortDir:     q.Get("sort_dir"),
+		SortKey:     q.Get("sort_key"),
+		StartDate:   q.Get("start_date"),
+		EndDate:     q.Get("end_date"),
+		When:        q.Get("when"),
+		Timezone:    q.Get("tz"),
+		City:        q.Get("city"),
+		EventName:   q.Get("event_name"),
+		Type:        q.Get("type"),
+		OrganizerID: q.Get("organizer_id"),
 	}
 
 	// Safe Parsing: PageSize
 	if val := q.Get("page_size"); val != "" {
 		i, err := strconv.Atoi(val)
 		if err != nil {
-			respondError(w, domain.ErrValidation("page_size must be a valid integer"))
-			return
+			return dto, domain.ErrValidation("page_size must be a valid integer")
 		}
 		dto.PageSize = i
 	} else {
@@ -245,8 +450,7 @@ func (h *EventHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	if val := q.Get("min_price"); val != "" {
 		f, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			respondError(w, domain.ErrValidation("min_price must be a valid number"))
-			return
+			return dto, domain.ErrValidation("min_price must be a valid number")
 		}
 		dto.MinPrice = &f
 	}
@@ -255,135 +459,334 @@ func (h *EventHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	if val := q.Get("max_price"); val != "" {
 		f, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			respondError(w, domain.ErrValidation("max_price must be a valid number"))
-			return
+			return dto, domain.ErrValidation("max_price must be a valid number")
 		}
 		dto.MaxPrice = &f
 	}
+	return dto, nil
+}
 
-	// 2. Struct Validation (Check constraints like gte=0, oneof, etc.)
-	if err := domain.Validate.Struct(dto); err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+// handleGet retrieves a single event
+func (h *EventHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		respondError(w, r, domain.ErrValidation("Missing id path parameter"))
 		return
 	}
 
-	// 3. Logical Cross-Field Validation
-	if dto.MinPrice != nil && dto.MaxPrice != nil && *dto.MinPrice > *dto.MaxPrice {
-		respondError(w, domain.ErrValidation("min_price cannot be greater than max_price"))
+	fields, err := domain.ParseEventFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
 
-	// 4. Convert DTO to Domain Request
-	// Time parsing is safe here because validation ensured the format is correct.
-	var startTime, endTime *time.Time
+	event, err := h.service.GetEvent(r.Context(), id)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
 
-	if dto.StartDate != "" {
-		t, _ := time.Parse(time.RFC3339, dto.StartDate)
-		startTime = &t
+	// A single document read costs the same regardless of fields, so the projection happens here
+	resp := service.NewEventResponse(event, time.Now())
+	cacheControl := h.eventCacheControl(&resp)
+	if WantsJSONAPI(r) {
+		writeJSONAPI(w, r, "", cacheControl, eventResource(&resp, fields), nil)
+		return
 	}
-	if dto.EndDate != "" {
-		t, _ := time.Parse(time.RFC3339, dto.EndDate)
-		endTime = &t
+	etag := eventETag(&resp, strings.Join(fields, ","))
+	if len(fields) > 0 {
+		writeCacheable(w, r, etag, cacheControl, domain.APIResponse{Data: domain.ProjectEvent(&resp, fields)})
+		return
+	}
+	writeCacheable(w, r, etag, cacheControl, domain.APIResponse{Data: resp})
+}
+
+// eventCacheControl keeps private events out of shared caches, which would serve them to callers
+// other than their creator and admins
+func (h *EventHandler) eventCacheControl(resp *domain.EventResponse) string {
+	if resp.Visibility == domain.VisibilityPrivate {
+		return "private, no-cache"
 	}
+	return h.cacheControl
+}
 
-	if startTime != nil && endTime != nil && endTime.Before(*startTime) {
-		respondError(w, domain.ErrValidation("end_date cannot be before start_date"))
+// projectEvents applies a sparse fieldset to every event of a list response
+func projectEvents(events []domain.EventResponse, fields []string) []map[string]interface{} {
+	projected := make([]map[string]interface{}, 0, len(events))
+	for i := range events {
+		projected = append(projected, domain.ProjectEvent(&events[i], fields))
+	}
+	return projected
+}
+
+// handleGetBySlug retrieves a single event by its URL-friendly slug
+func (h *EventHandler) handleGetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	if slug == "" {
+		respondError(w, r, domain.ErrValidation("Missing slug path parameter"))
 		return
 	}
 
-	// Set defaults for Sorting if empty (though logic is also in Repo, it's good to be explicit)
-	if dto.SortKey == "" {
-		dto.SortKey = "created_at"
+	event, err := h.service.GetEventBySlug(r.Context(), slug)
+	if err != nil {
+		respondError(w, r, err)
+		return
 	}
-	if dto.SortDir == "" {
-		dto.SortDir = "asc"
+
+	resp := service.NewEventResponse(event, time.Now())
+	cacheControl := h.eventCacheControl(&resp)
+	if WantsJSONAPI(r) {
+		writeJSONAPI(w, r, "", cacheControl, eventResource(&resp, nil), nil)
+		return
 	}
+	writeCacheable(w, r, eventETag(&resp, ""), cacheControl, domain.APIResponse{Data: resp})
+}
 
-	searchReq := domain.SearchRequest{
-		Filters: domain.FilterRequest{
-			City:      dto.City,
-			EventName: dto.EventName,
-			Type:      domain.EventType(dto.Type), // Safe cast due to validation
-			MinPrice:  dto.MinPrice,
-			MaxPrice:  dto.MaxPrice,
-			StartDate: startTime,
-			EndDate:   endTime,
-		},
-		Sorting: domain.SortRequest{
-			PageSize:      dto.PageSize,
-			PageToken:     dto.PageToken,
-			SortKey:       dto.SortKey,
-			SortDirection: dto.SortDir,
-		},
+// handleBatchGet returns several events by Id in one request
+func (h *EventHandler) handleBatchGet(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, v := range r.URL.Query()["ids"] {
+		for _, id := range strings.Split(v, ",") {
+			ids = append(ids, strings.TrimSpace(id))
+		}
 	}
 
-	// 5. Call Service
-	events, nextToken, err := h.service.ListEvents(r.Context(), searchReq)
+	events, err := h.service.GetEvents(r.Context(), ids)
 	if err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 
-	// 6. Response
-	resp := domain.APIPaginationResponse{
-		Data: events,
-		Meta: &domain.Meta{
-			NextPageToken: nextToken,
-		},
+	responses := service.NewEventResponses(events, time.Now())
+	if WantsJSONAPI(r) {
+		writeJSONAPI(w, r, "", h.cacheControl, eventResources(responses, nil), nil)
+		return
 	}
-	_ = json.NewEncoder(w).Encode(resp)
+	writeCacheable(w, r, "", h.cacheControl, domain.APIResponse{Data: responses})
 }
 
-// handleGet retrieves a single event
-// @Summary Get Event
-// @Description Get details of a specific event by Id
-// @Tags events
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Event Id"
-// @Success 200 {object} domain.APIResponse{data=domain.Event}
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Failure 404 {object} domain.APIResponse{error=string}
-// @Router /events/{id} [get]
-func (h *EventHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+// handleStats returns event counts and price figures grouped by a field
+func (h *EventHandler) handleStats(w http.ResponseWriter, r *http.Request) {
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		respondError(w, r, domain.ErrValidation("Missing group_by query parameter"))
+		return
+	}
+
+	stats, err := h.service.GetEventStats(r.Context(), groupBy)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: stats})
+}
+
+// handleStatsSummary counts the events matching the list filters and totals their prices
+func (h *EventHandler) handleStatsSummary(w http.ResponseWriter, r *http.Request) {
+	dto, err := eventListDTOFromQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	searchReq, err := searchRequestFromDTO(dto, "")
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	summary, err := h.service.SummarizeEvents(r.Context(), searchReq.Filters)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: summary})
+}
+
+// handlePriceBuckets returns event counts per price range for the price filter slider
+func (h *EventHandler) handlePriceBuckets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var bounds []float64
+	if raw := q.Get("bounds"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				respondError(w, r, domain.ErrValidation("bounds must be a comma-separated list of numbers"))
+				return
+			}
+			bounds = append(bounds, f)
+		}
+	}
+
+	buckets, err := h.service.GetPriceBuckets(r.Context(), q.Get("city"), bounds)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: buckets})
+}
+
+// handleListFeatured returns currently promoted events
+func (h *EventHandler) handleListFeatured(w http.ResponseWriter, r *http.Request) {
+	events, err := h.service.ListFeaturedEvents(r.Context())
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	responses := service.NewEventResponses(events, time.Now())
+	if WantsJSONAPI(r) {
+		writeJSONAPI(w, r, "", h.cacheControl, eventResources(responses, nil), nil)
+		return
+	}
+	writeCacheable(w, r, "", h.cacheControl, domain.APIResponse{Data: responses})
+}
+
+// handleListTypes returns the event types the deployment accepts, for type pickers and filters
+func (h *EventHandler) handleListTypes(w http.ResponseWriter, r *http.Request) {
+	writeCacheable(w, r, "", h.cacheControl, domain.APIResponse{Data: domain.AllowedEventTypes(r.Context())})
+}
+
+// handleSetFeatured promotes or demotes an event (admin only)
+func (h *EventHandler) handleSetFeatured(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		respondError(w, domain.ErrValidation("Missing id path parameter"))
+		respondError(w, r, domain.ErrValidation("Missing id path parameter"))
 		return
 	}
 
-	event, err := h.service.GetEvent(r.Context(), id)
+	var dto domain.FeatureEventDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	var until time.Time
+	if dto.Featured {
+		// Format already validated by the DTO
+		until, _ = time.Parse(time.RFC3339, dto.FeaturedUntil)
+	}
+
+	if err := h.service.SetFeatured(r.Context(), id, dto.Featured, until); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Updated successfully"})
+}
+
+// handleHistory returns the change history of an event (admin only)
+func (h *EventHandler) handleHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		respondError(w, r, domain.ErrValidation("Missing id path parameter"))
+		return
+	}
+
+	revisions, err := h.service.GetEventHistory(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 
-	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: event})
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: revisions})
 }
 
 // handleDelete deletes an event
-// @Summary Delete Event
-// @Description Remove an event by Id
-// @Tags events
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Event Id"
-// @Success 200 {object} domain.APIResponse{data=string}
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Router /events/{id} [delete]
 func (h *EventHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		respondError(w, domain.ErrValidation("Missing id path parameter"))
+		respondError(w, r, domain.ErrValidation("Missing id path parameter"))
 		return
 	}
 
 	if err := h.service.DeleteEvent(r.Context(), id); err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Deleted successfully"})
 }
+
+// searchRequestFromDTO validates a list request and converts it to a domain search.
+// fieldsParam is the raw comma-separated sparse fieldset. Shared by GET /events and GraphQL.
+func searchRequestFromDTO(dto domain.EventListDTO, fieldsParam string) (domain.SearchRequest, error) {
+	// 1. Struct Validation (Check constraints like gte=0, oneof, etc.)
+	if err := domain.Validate.Struct(dto); err != nil {
+		return domain.SearchRequest{}, domain.ErrValidation(err.Error())
+	}
+
+	fields, err := domain.ParseEventFields(fieldsParam)
+	if err != nil {
+		return domain.SearchRequest{}, err
+	}
+
+	// 2. Logical Cross-Field Validation
+	if dto.MinPrice != nil && dto.MaxPrice != nil && *dto.MinPrice > *dto.MaxPrice {
+		return domain.SearchRequest{}, domain.ErrValidation("min_price cannot be greater than max_price")
+	}
+
+	// 3. Convert DTO to Domain Request
+	// Time parsing is safe here because validation ensured the format is correct.
+	var startTime, endTime *time.Time
+
+	if dto.StartDate != "" {
+		t, _ := time.Parse(time.RFC3339, dto.StartDate)
+		startTime = &t
+	}
+	if dto.EndDate != "" {
+		t, _ := time.Parse(time.RFC3339, dto.EndDate)
+		endTime = &t
+	}
+
+	// Relative windows ("today", "this_weekend", ...) are resolved in the caller's timezone.
+	// Validation guarantees they are not combined with explicit start/end dates.
+	if dto.When != "" {
+		loc := time.UTC
+		if dto.Timezone != "" {
+			loc, _ = time.LoadLocation(dto.Timezone)
+		}
+		startTime, endTime = domain.ResolveWhen(dto.When, time.Now(), loc)
+	}
+
+	if startTime != nil && endTime != nil && endTime.Before(*startTime) {
+		return domain.SearchRequest{}, domain.ErrValidation("end_date cannot be before start_date")
+	}
+
+	// Set defaults for Sorting if empty (though logic is also in Repo, it's good to be explicit)
+	if dto.SortKey == "" {
+		dto.SortKey = "created_at"
+	}
+	if dto.SortDir == "" {
+		dto.SortDir = "asc"
+	}
+
+	return domain.SearchRequest{
+		Filters: domain.FilterRequest{
+			City:        dto.City,
+			EventName:   dto.EventName,
+			Type:        domain.EventType(dto.Type), // Safe cast due to validation
+			OrganizerID: dto.OrganizerID,
+			MinPrice:    dto.MinPrice,
+			MaxPrice:    dto.MaxPrice,
+			StartDate:   startTime,
+			EndDate:     endTime,
+			When:        dto.When,
+		},
+		Sorting: domain.SortRequest{
+			PageSize:      dto.PageSize,
+			PageToken:     dto.PageToken,
+			SortKey:       dto.SortKey,
+			SortDirection: dto.SortDir,
+		},
+		Fields: fields,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// FavoriteHandler serves the authenticated user's bookmarks under /users/me/favorites
+type FavoriteHandler struct {
+	service service.FavoriteService
+	mux     *http.ServeMux
+}
+
+func NewFavoriteHandler(svc service.FavoriteService) *FavoriteHandler {
+	h := &FavoriteHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *FavoriteHandler) routes() {
+	h.mux.HandleFunc("GET /users/me/favorites", h.handleList)
+	h.mux.HandleFunc("POST /users/me/favorites/{eventId}", h.handleAdd)
+	h.mux.HandleFunc("DELETE /users/me/favorites/{eventId}", h.handleRemove)
+}
+
+func (h *FavoriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleList lists the authenticated user's favorite events
+func (h *FavoriteHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	favorites, err := h.service.ListFavorites(r.Context(), user.UID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: favorites})
+}
+
+// handleAdd bookmarks an event for the authenticated user
+func (h *FavoriteHandler) handleAdd(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	if err := h.service.AddFavorite(r.Context(), user.UID, r.PathValue("eventId")); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Added to favorites"})
+}
+
+// handleRemove removes an event from the authenticated user's favorites
+func (h *FavoriteHandler) handleRemove(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	if err := h.service.RemoveFavorite(r.Context(), user.UID, r.PathValue("eventId")); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Removed from favorites"})
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/graphql"
+	"encoding/json"
+	"net/http"
+)
+
+const maxGraphQLBodySize = 1 << 20 // 1 MB
+
+// GraphQLHandler serves read-only GraphQL queries over events, organizers and tracking stats
+type GraphQLHandler struct {
+	schema *graphql.Schema
+	mux    *http.ServeMux
+}
+
+func NewGraphQLHandler(svc Services) *GraphQLHandler {
+	h := &GraphQLHandler{
+		schema: newGraphQLSchema(svc),
+		mux:    http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *GraphQLHandler) routes() {
+	h.mux.HandleFunc("GET /graphql", h.handleGet)
+	h.mux.HandleFunc("POST /graphql", h.handlePost)
+	h.mux.HandleFunc("GET /graphql/schema.graphql", h.handleSchema)
+}
+
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handlePost executes a GraphQL query
+func (h *GraphQLHandler) handlePost(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBodySize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeResponse(w, &graphql.Response{Errors: []*graphql.Error{{Message: "invalid request body"}}})
+		return
+	}
+	h.writeResponse(w, h.schema.Execute(withGraphQLLoader(r.Context()), req))
+}
+
+// handleGet executes a GraphQL query passed in the URL, which lets CDNs and browsers cache it
+func (h *GraphQLHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := graphql.Request{Query: q.Get("query"), OperationName: q.Get("operationName")}
+	if v := q.Get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			h.writeResponse(w, &graphql.Response{Errors: []*graphql.Error{{Message: "variables must be a JSON object"}}})
+			return
+		}
+	}
+	h.writeResponse(w, h.schema.Execute(withGraphQLLoader(r.Context()), req))
+}
+
+// handleSchema returns the schema in SDL for client code generators
+func (h *GraphQLHandler) handleSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(h.schema.SDL()))
+}
+
+func (h *GraphQLHandler) writeResponse(w http.ResponseWriter, resp *graphql.Response) {
+	if resp.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
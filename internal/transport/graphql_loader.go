@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"context"
+	"slices"
+)
+
+// graphQLLoader batches and caches the lookups of one GraphQL request. Event lists queue the
+// organizers of their items, which are then fetched together the first time one is resolved,
+// and an event or organizer selected several times is read once.
+type graphQLLoader struct {
+	pending    []string
+	organizers map[string]*domain.Organizer // nil for Ids known not to exist
+	events     map[string]*domain.Event
+}
+
+type graphQLLoaderKey struct{}
+
+func withGraphQLLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, graphQLLoaderKey{}, &graphQLLoader{
+		organizers: map[string]*domain.Organizer{},
+		events:     map[string]*domain.Event{},
+	})
+}
+
+// loaderFrom returns the request's loader; without one (a schema executed directly) nothing is shared
+func loaderFrom(ctx context.Context) *graphQLLoader {
+	if l, ok := ctx.Value(graphQLLoaderKey{}).(*graphQLLoader); ok {
+		return l
+	}
+	return withGraphQLLoader(ctx).Value(graphQLLoaderKey{}).(*graphQLLoader)
+}
+
+// queueOrganizers marks the organizers of events to be fetched with the next organizer lookup
+func (l *graphQLLoader) queueOrganizers(events []domain.Event) {
+	for _, e := range events {
+		if _, known := l.organizers[e.OrganizerID]; e.OrganizerID != "" && !known && !slices.Contains(l.pending, e.OrganizerID) {
+			l.pending = append(l.pending, e.OrganizerID)
+		}
+	}
+}
+
+// organizer returns the organizer with id, or nil when it does not exist, fetching every queued
+// organizer in the same round trip
+func (l *graphQLLoader) organizer(ctx context.Context, svc Services, id string) (*domain.Organizer, error) {
+	if o, known := l.organizers[id]; known {
+		return o, nil
+	}
+	ids := l.pending
+	if !slices.Contains(ids, id) {
+		ids = append(ids, id)
+	}
+	found, err := svc.Organizers.GetOrganizers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	l.pending = nil
+	for _, id := range ids {
+		l.organizers[id] = nil
+	}
+	for i := range found {
+		l.organizers[found[i].Id] = &found[i]
+	}
+	return l.organizers[id], nil
+}
+
+// event returns the event with id, reading it once per request
+func (l *graphQLLoader) event(ctx context.Context, svc Services, id string) (*domain.Event, error) {
+	if e, ok := l.events[id]; ok {
+		return e, nil
+	}
+	e, err := svc.Events.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	l.events[id] = e
+	return e, nil
+}
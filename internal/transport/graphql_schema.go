@@ -0,0 +1,366 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/graphql"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	// maxGraphQLCost bounds the estimated service reads of a query: every field backed by a
+	// service costs 1 per parent it is resolved for, and lists multiply by their page size
+	maxGraphQLCost = 500
+	// maxGraphQLAliases bounds the aliases of a query, each of which repeats a field's reads
+	maxGraphQLAliases = 20
+	// featuredEstimate and organizersEstimate stand in for the size of the unpaged lists
+	featuredEstimate   = 20
+	organizersEstimate = 50
+)
+
+// newGraphQLSchema builds the read-only GraphQL schema; every resolver delegates to the services
+// behind the REST API, so authorization and validation are the same on both surfaces.
+func newGraphQLSchema(svc Services) *graphql.Schema {
+	nn := func(t graphql.Type) graphql.Type { return &graphql.NonNull{OfType: t} }
+	list := func(t graphql.Type) graphql.Type { return &graphql.NonNull{OfType: &graphql.List{OfType: nn(t)}} }
+
+	organizer := &graphql.Object{Name: "Organizer"}
+	ticketTier := &graphql.Object{Name: "TicketTier", Fields: map[string]*graphql.Field{
+		"id":         prop(nn(graphql.ID), func(t *domain.TicketTier) interface{} { return t.Id }),
+		"name":       prop(nn(graphql.String), func(t *domain.TicketTier) interface{} { return t.Name }),
+		"price":      prop(nn(graphql.Float), func(t *domain.TicketTier) interface{} { return t.Price }),
+		"quantity":   prop(nn(graphql.Int), func(t *domain.TicketTier) interface{} { return t.Quantity }),
+		"salesStart": prop(graphql.DateTime, func(t *domain.TicketTier) interface{} { return t.SalesStart }),
+		"salesEnd":   prop(graphql.DateTime, func(t *domain.TicketTier) interface{} { return t.SalesEnd }),
+	}}
+
+	geoPoint := &graphql.Object{Name: "GeoPoint", Fields: map[string]*graphql.Field{
+		"latitude":  prop(nn(graphql.Float), func(p *domain.GeoPoint) interface{} { return p.Latitude }),
+		"longitude": prop(nn(graphql.Float), func(p *domain.GeoPoint) interface{} { return p.Longitude }),
+	}}
+
+	event := &graphql.Object{Name: "Event", Fields: map[string]*graphql.Field{
+		"id":             prop(nn(graphql.ID), func(e *domain.Event) interface{} { return e.Id }),
+		"slug":           prop(graphql.String, func(e *domain.Event) interface{} { return e.Slug }),
+		"name":           prop(nn(graphql.String), func(e *domain.Event) interface{} { return e.EventName }),
+		"description":    prop(graphql.String, func(e *domain.Event) interface{} { return e.Description }),
+		"type":           prop(graphql.String, func(e *domain.Event) interface{} { return e.Type }),
+		"visibility":     prop(graphql.String, func(e *domain.Event) interface{} { return e.EventVisibility() }),
+		"city":           prop(graphql.String, func(e *domain.Event) interface{} { return e.City }),
+		"country":        prop(graphql.String, func(e *domain.Event) interface{} { return e.Country }),
+		"address":        prop(graphql.String, func(e *domain.Event) interface{} { return e.FullAddress }),
+		"location":       prop(geoPoint, func(e *domain.Event) interface{} { return domain.NewGeoPoint(e.Location) }),
+		"startTime":      prop(graphql.DateTime, func(e *domain.Event) interface{} { return e.StartTime }),
+		"endTime":        prop(graphql.DateTime, func(e *domain.Event) interface{} { return e.EndTime }),
+		"timezone":       prop(graphql.String, func(e *domain.Event) interface{} { return e.Timezone }),
+		"url":            prop(graphql.String, func(e *domain.Event) interface{} { return e.EventURL }),
+		"imageUrl":       prop(graphql.String, func(e *domain.Event) interface{} { return e.ImageUrl }),
+		"price":          prop(graphql.Float, func(e *domain.Event) interface{} { return e.Price }),
+		"hasTickets":     prop(graphql.Boolean, func(e *domain.Event) interface{} { return e.HasTickets }),
+		"capacity":       prop(graphql.Int, func(e *domain.Event) interface{} { return e.Capacity }),
+		"attendeeCount":  prop(graphql.Int, func(e *domain.Event) interface{} { return e.AttendeeCount }),
+		"favoritesCount": prop(graphql.Int, func(e *domain.Event) interface{} { return e.FavoritesCount }),
+		"viewCount":      prop(graphql.Int, func(e *domain.Event) interface{} { return e.ViewCount }),
+		"featured":       prop(graphql.Boolean, func(e *domain.Event) interface{} { return e.Featured }),
+		"organizerName":  prop(graphql.String, func(e *domain.Event) interface{} { return e.OrganizerName }),
+		"createdAt":      prop(graphql.DateTime, func(e *domain.Event) interface{} { return e.CreatedAt }),
+		"updatedAt":      prop(graphql.DateTime, func(e *domain.Event) interface{} { return e.UpdatedAt }),
+		"organizer": {
+			Type: organizer,
+			Cost: 1,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				e := source[domain.Event](p)
+				if e.OrganizerID == "" {
+					return nil, nil
+				}
+				o, err := loaderFrom(p.Context).organizer(p.Context, svc, e.OrganizerID)
+				return o, graphQLError(p.Context, err)
+			},
+		},
+		"ticketTiers": {
+			Type: list(ticketTier),
+			Cost: 1,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				tiers, err := svc.TicketTiers.ListTiers(p.Context, source[domain.Event](p).Id)
+				return tiers, graphQLError(p.Context, err)
+			},
+		},
+	}}
+
+	eventPage := &graphql.Object{Name: "EventPage", Fields: map[string]*graphql.Field{
+		"items":         prop(list(event), func(pg *eventPage) interface{} { return pg.Items }),
+		"nextPageToken": prop(graphql.String, func(pg *eventPage) interface{} { return nullIfEmpty(pg.Meta.NextPageToken) }),
+		"prevPageToken": prop(graphql.String, func(pg *eventPage) interface{} { return nullIfEmpty(pg.Meta.PrevPageToken) }),
+	}}
+	pageArgs := map[string]*graphql.Argument{
+		"first": {Type: graphql.Int, Description: "Page size, 1-100 (default 20)"},
+		"after": {Type: graphql.String, Description: "nextPageToken of the previous page"},
+	}
+
+	organizer.Fields = map[string]*graphql.Field{
+		"id":          prop(nn(graphql.ID), func(o *domain.Organizer) interface{} { return o.Id }),
+		"name":        prop(nn(graphql.String), func(o *domain.Organizer) interface{} { return o.Name }),
+		"email":       prop(graphql.String, func(o *domain.Organizer) interface{} { return o.Email }),
+		"website":     prop(graphql.String, func(o *domain.Organizer) interface{} { return o.Website }),
+		"description": prop(graphql.String, func(o *domain.Organizer) interface{} { return o.Description }),
+		"createdAt":   prop(graphql.DateTime, func(o *domain.Organizer) interface{} { return o.CreatedAt }),
+		"events": {
+			Type:     nn(eventPage),
+			Args:     pageArgs,
+			Cost:     1,
+			ListSize: pageSize,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				dto := eventListArgs(p.Args)
+				dto.OrganizerID = source[domain.Organizer](p).Id
+				return listEventPage(p.Context, svc, dto)
+			},
+		},
+	}
+
+	eventStats := &graphql.Object{Name: "EventStats", Fields: map[string]*graphql.Field{
+		"group":    prop(nn(graphql.String), func(s *domain.EventStats) interface{} { return s.Group }),
+		"count":    prop(nn(graphql.Int), func(s *domain.EventStats) interface{} { return s.Count }),
+		"avgPrice": prop(graphql.Float, func(s *domain.EventStats) interface{} { return s.AvgPrice }),
+		"minPrice": prop(graphql.Float, func(s *domain.EventStats) interface{} { return s.MinPrice }),
+		"maxPrice": prop(graphql.Float, func(s *domain.EventStats) interface{} { return s.MaxPrice }),
+	}}
+
+	actionCount := &graphql.Object{Name: "ActionCount", Fields: map[string]*graphql.Field{
+		"action": prop(nn(graphql.String), func(a *trackingActionCount) interface{} { return a.Action }),
+		"count":  prop(nn(graphql.Int), func(a *trackingActionCount) interface{} { return a.Count }),
+	}}
+	trackingStats := &graphql.Object{Name: "TrackingStats", Fields: map[string]*graphql.Field{
+		"total":   prop(nn(graphql.Int), func(s *trackingSummary) interface{} { return s.Total }),
+		"actions": prop(list(actionCount), func(s *trackingSummary) interface{} { return s.Actions }),
+	}}
+
+	eventsArgs := map[string]*graphql.Argument{
+		"city":        {Type: graphql.String},
+		"type":        {Type: graphql.String},
+		"name":        {Type: graphql.String, Description: "Case-insensitive name prefix"},
+		"organizerId": {Type: graphql.ID},
+		"minPrice":    {Type: graphql.Float},
+		"maxPrice":    {Type: graphql.Float},
+		"startDate":   {Type: graphql.DateTime},
+		"endDate":     {Type: graphql.DateTime},
+		"when":        {Type: graphql.String, Description: "upcoming, past, today or this_weekend"},
+		"tz":          {Type: graphql.String, Description: "IANA timezone for when (default UTC)"},
+		"sortKey":     {Type: graphql.String, Description: "event_name, city, price, start_time, created_at or popularity"},
+		"sortDir":     {Type: graphql.String, Description: "asc or desc"},
+	}
+	for k, v := range pageArgs {
+		eventsArgs[k] = v
+	}
+
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"event": {
+			Type:        event,
+			Description: "An event by id or slug",
+			Cost:        1,
+			Args:        map[string]*graphql.Argument{"id": {Type: graphql.ID}, "slug": {Type: graphql.String}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				id, _ := p.Args["id"].(string)
+				slug, _ := p.Args["slug"].(string)
+				var (
+					e   *domain.Event
+					err error
+				)
+				switch {
+				case id != "":
+					e, err = loaderFrom(p.Context).event(p.Context, svc, id)
+				case slug != "":
+					e, err = svc.Events.GetEventBySlug(p.Context, slug)
+				default:
+					return nil, graphQLError(p.Context, domain.ErrValidation("id or slug is required"))
+				}
+				return e, graphQLError(p.Context, err)
+			},
+		},
+		"events": {
+			Type:     nn(eventPage),
+			Args:     eventsArgs,
+			Cost:     1,
+			ListSize: pageSize,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return listEventPage(p.Context, svc, eventListArgs(p.Args))
+			},
+		},
+		"featuredEvents": {
+			Type:     list(event),
+			Cost:     1,
+			ListSize: func(map[string]interface{}) int { return featuredEstimate },
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				events, err := svc.Events.ListFeaturedEvents(p.Context)
+				loaderFrom(p.Context).queueOrganizers(events)
+				return events, graphQLError(p.Context, err)
+			},
+		},
+		"eventStats": {
+			Type: list(eventStats),
+			Args: map[string]*graphql.Argument{"groupBy": {Type: nn(graphql.String), Description: "city or type"}},
+			Cost: 1,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				stats, err := svc.Events.GetEventStats(p.Context, p.Args["groupBy"].(string))
+				return stats, graphQLError(p.Context, err)
+			},
+		},
+		"organizer": {
+			Type: organizer,
+			Args: map[string]*graphql.Argument{"id": {Type: nn(graphql.ID)}},
+			Cost: 1,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				o, err := svc.Organizers.GetOrganizer(p.Context, p.Args["id"].(string))
+				return o, graphQLError(p.Context, err)
+			},
+		},
+		"organizers": {
+			Type:     list(organizer),
+			Cost:     1,
+			ListSize: func(map[string]interface{}) int { return organizersEstimate },
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				organizers, err := svc.Organizers.ListOrganizers(p.Context)
+				return organizers, graphQLError(p.Context, err)
+			},
+		},
+		"trackingStats": {
+			Type:        trackingStats,
+			Description: "Tracked actions and their counts over the last seven days; admins only, like GET /tracking/stats",
+			Cost:        1,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if principal, ok := domain.PrincipalFromContext(p.Context); !ok || principal.Role != domain.RoleAdmin {
+					return nil, graphQLError(p.Context, domain.ErrForbidden("admins only"))
+				}
+				// Read the daily rollups; the raw tracking collection is far too large to scan per query
+				stats, err := svc.Tracking.GetTrackingStats(p.Context, "action", domain.TrackingIntervalDay, time.Time{}, time.Time{})
+				if err != nil {
+					return nil, graphQLError(p.Context, err)
+				}
+				return summarizeTracking(stats), nil
+			},
+		},
+	}}
+
+	return &graphql.Schema{Query: query, MaxCost: maxGraphQLCost, MaxAliases: maxGraphQLAliases}
+}
+
+// prop is a field read from the parent value, which list items pass by value and lookups by pointer
+func prop[T any](t graphql.Type, get func(*T) interface{}) *graphql.Field {
+	return &graphql.Field{Type: t, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return get(source[T](p)), nil
+	}}
+}
+
+func source[T any](p graphql.ResolveParams) *T {
+	if v, ok := p.Source.(T); ok {
+		return &v
+	}
+	return p.Source.(*T)
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// graphQLError exposes typed domain errors with their code and hides everything else, as respondError does
+func graphQLError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	var coded domain.CodedError
+	if errors.As(err, &coded) {
+		return &graphql.Error{Message: coded.Error(), Extensions: map[string]interface{}{"code": coded.Code()}}
+	}
+	logError(ctx, "graphql resolver failed", err)
+	return &graphql.Error{Message: "Internal Server Error"}
+}
+
+type eventPage struct {
+	Items []domain.Event
+	Meta  domain.Meta
+}
+
+func eventListArgs(args map[string]interface{}) domain.EventListDTO {
+	str := func(k string) string { s, _ := args[k].(string); return s }
+	dto := domain.EventListDTO{
+		City:        str("city"),
+		Type:        str("type"),
+		EventName:   str("name"),
+		OrganizerID: str("organizerId"),
+		StartDate:   str("startDate"),
+		EndDate:     str("endDate"),
+		When:        str("when"),
+		Timezone:    str("tz"),
+		SortKey:     str("sortKey"),
+		SortDir:     str("sortDir"),
+		PageToken:   str("after"),
+		PageSize:    20,
+	}
+	if n, ok := args["first"].(int); ok {
+		dto.PageSize = n
+	}
+	if f, ok := args["minPrice"].(float64); ok {
+		dto.MinPrice = &f
+	}
+	if f, ok := args["maxPrice"].(float64); ok {
+		dto.MaxPrice = &f
+	}
+	return dto
+}
+
+// pageSize is the number of items a "first" argument asks for, as eventListArgs reads it
+func pageSize(args map[string]interface{}) int {
+	return eventListArgs(args).PageSize
+}
+
+func listEventPage(ctx context.Context, svc Services, dto domain.EventListDTO) (*eventPage, error) {
+	req, err := searchRequestFromDTO(dto, "")
+	if err != nil {
+		return nil, graphQLError(ctx, err)
+	}
+	// Queries may select any event field, so opt out of a default list projection
+	req.Fields = domain.EventFieldNames()
+	events, meta, err := svc.Events.ListEvents(ctx, req)
+	if err != nil {
+		return nil, graphQLError(ctx, err)
+	}
+	loaderFrom(ctx).queueOrganizers(events)
+	return &eventPage{Items: events, Meta: meta}, nil
+}
+
+type trackingActionCount struct {
+	Action string
+	Count  int64
+}
+
+type trackingSummary struct {
+	Total   int64
+	Actions []trackingActionCount
+}
+
+// summarizeTracking adds up the per-action counts of every bucket
+func summarizeTracking(buckets []domain.TrackingStats) *trackingSummary {
+	counts := map[string]int64{}
+	s := &trackingSummary{}
+	for _, b := range buckets {
+		for action, n := range b.Counts {
+			counts[action] += n
+			s.Total += n
+		}
+	}
+	for action, n := range counts {
+		s.Actions = append(s.Actions, trackingActionCount{Action: action, Count: n})
+	}
+	// Most frequent first; ties by name for a stable response
+	sort.Slice(s.Actions, func(i, j int) bool {
+		if s.Actions[i].Count != s.Actions[j].Count {
+			return s.Actions[i].Count > s.Actions[j].Count
+		}
+		return s.Actions[i].Action < s.Actions[j].Action
+	})
+	return s
+}
@@ -0,0 +1,375 @@
+package transport
+
+import (
+	eventsv1 "bibently.com/backend/api/events/v1"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/service"
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// IDTokenVerifier verifies Firebase ID tokens; *auth.Client implements it
+type IDTokenVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error)
+}
+
+// grpcMethodRoles mirrors DefaultRoutePolicies for the gRPC surface; methods not listed are public
+var grpcMethodRoles = map[string]string{
+	eventsv1.EventService_CreateEvent_FullMethodName: domain.RoleOrganizer,
+	eventsv1.EventService_UpdateEvent_FullMethodName: domain.RoleOrganizer,
+	eventsv1.EventService_DeleteEvent_FullMethodName: domain.RoleOrganizer,
+}
+
+// NewGRPCServer serves eventsv1.EventService over the same services as the HTTP router.
+// Callers authenticate with "authorization: Bearer <Firebase ID token>" metadata.
+func NewGRPCServer(svc Services, verifier IDTokenVerifier, opts ...grpc.ServerOption) *grpc.Server {
+	logger := svc.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(grpcUnaryInterceptor(verifier, svc.AdminUID, logger)),
+		grpc.ChainStreamInterceptor(grpcStreamInterceptor(verifier, svc.AdminUID, logger)),
+	)
+	s := grpc.NewServer(opts...)
+	eventsv1.RegisterEventServiceServer(s, &eventGRPCServer{events: svc.Events, watch: svc.EventWatch})
+	return s
+}
+
+func grpcUnaryInterceptor(verifier IDTokenVerifier, adminUID string, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx = logging.WithLogger(ctx, logger.With("grpc_method", info.FullMethod))
+		defer func() {
+			if p := recover(); p != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "PANIC RECOVERED", "error", p, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		ctx, err = authenticateGRPC(ctx, verifier, adminUID, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func grpcStreamInterceptor(verifier IDTokenVerifier, adminUID string, logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := logging.WithLogger(ss.Context(), logger.With("grpc_method", info.FullMethod))
+		defer func() {
+			if p := recover(); p != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "PANIC RECOVERED", "error", p, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		ctx, err = authenticateGRPC(ctx, verifier, adminUID, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
+
+// authenticateGRPC is WithAuthProtection for gRPC: it verifies the bearer token, enforces the
+// method's role and injects the token and principal the services read.
+func authenticateGRPC(ctx context.Context, verifier IDTokenVerifier, adminUID, method string) (context.Context, error) {
+	var token *auth.Token
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 && strings.HasPrefix(values[0], "Bearer ") && verifier != nil {
+			if t, err := verifier.VerifyIDToken(ctx, strings.TrimPrefix(values[0], "Bearer ")); err == nil {
+				token = t
+			}
+		}
+	}
+
+	required, restricted := grpcMethodRoles[method]
+	if token == nil {
+		if restricted {
+			return nil, status.Error(codes.Unauthenticated, "a valid Firebase ID token is required")
+		}
+		return ctx, nil
+	}
+
+	role := roleOf(token, adminUID)
+	if restricted && !satisfiesRole(role, required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s role required", required)
+	}
+	ctx = context.WithValue(ctx, UserContextKey, token)
+	return domain.WithPrincipal(ctx, domain.Principal{UID: token.UID, Role: role}), nil
+}
+
+type eventGRPCServer struct {
+	eventsv1.UnimplementedEventServiceServer
+	events service.EventService
+	watch  service.EventWatchService
+}
+
+func (s *eventGRPCServer) ListEvents(ctx context.Context, req *eventsv1.ListEventsRequest) (*eventsv1.ListEventsResponse, error) {
+	dto := domain.EventListDTO{
+		City:        req.GetCity(),
+		Type:        req.GetType(),
+		EventName:   req.GetName(),
+		OrganizerID: req.GetOrganizerId(),
+		MinPrice:    req.MinPrice,
+		MaxPrice:    req.MaxPrice,
+		StartDate:   formatTimestamp(req.GetStartTime()),
+		EndDate:     formatTimestamp(req.GetEndTime()),
+		SortKey:     req.GetSortKey(),
+		SortDir:     req.GetSortDir(),
+		PageSize:    int(req.GetPageSize()),
+		PageToken:   req.GetPageToken(),
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = 20
+	}
+	searchReq, err := searchRequestFromDTO(dto, "")
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	// Messages carry whole events, so opt out of a default list projection
+	searchReq.Fields = domain.EventFieldNames()
+	events, meta, err := s.events.ListEvents(ctx, searchReq)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	resp := &eventsv1.ListEventsResponse{NextPageToken: meta.NextPageToken, PrevPageToken: meta.PrevPageToken}
+	for i := range events {
+		resp.Events = append(resp.Events, eventToProto(&events[i]))
+	}
+	return resp, nil
+}
+
+func (s *eventGRPCServer) GetEvent(ctx context.Context, req *eventsv1.GetEventRequest) (*eventsv1.Event, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	event, err := s.events.GetEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return eventToProto(event), nil
+}
+
+func (s *eventGRPCServer) CreateEvent(ctx context.Context, req *eventsv1.CreateEventRequest) (*eventsv1.Event, error) {
+	in := req.GetEvent()
+	dto := domain.EventDTO{
+		EventName:   in.GetName(),
+		City:        in.GetCity(),
+		Type:        domain.EventType(in.GetType()),
+		Price:       in.GetPrice(),
+		StartTime:   formatTimestamp(in.GetStartTime()),
+		EndTime:     formatTimestamp(in.GetEndTime()),
+		Capacity:    int(in.GetCapacity()),
+		OrganizerID: in.GetOrganizerId(),
+		EventURL:    in.GetUrl(),
+		ImageUrl:    in.GetImageUrl(),
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		return nil, grpcError(ctx, domain.ErrValidation(err.Error()))
+	}
+	event, err := domain.EventDTOToModel(&dto)
+	if err != nil {
+		return nil, grpcError(ctx, domain.ErrValidation(err.Error()))
+	}
+	if err := s.events.CreateEvent(ctx, event); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return eventToProto(event), nil
+}
+
+func (s *eventGRPCServer) UpdateEvent(ctx context.Context, req *eventsv1.UpdateEventRequest) (*eventsv1.Event, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	in := req.GetEvent()
+	var dto domain.UpdateEventDTO
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "name":
+			dto.EventName = ptr(in.GetName())
+		case "city":
+			dto.City = ptr(in.GetCity())
+		case "type":
+			dto.Type = ptr(in.GetType())
+		case "price":
+			dto.Price = ptr(in.GetPrice())
+		case "start_time":
+			dto.StartTime = ptr(formatTimestamp(in.GetStartTime()))
+		case "end_time":
+			dto.EndTime = ptr(formatTimestamp(in.GetEndTime()))
+		case "capacity":
+			dto.Capacity = ptr(int(in.GetCapacity()))
+		case "organizer_id":
+			dto.OrganizerID = ptr(in.GetOrganizerId())
+		case "url":
+			dto.EventURL = ptr(in.GetUrl())
+		case "image_url":
+			dto.ImageUrl = ptr(in.GetImageUrl())
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		return nil, grpcError(ctx, domain.ErrValidation(err.Error()))
+	}
+	updates, err := domain.UpdateEventDTOToMap(&dto)
+	if err != nil {
+		return nil, grpcError(ctx, domain.ErrValidation(err.Error()))
+	}
+	if len(updates) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask must name at least one field")
+	}
+	if err := s.events.UpdateEvent(ctx, req.GetId(), updates); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	event, err := s.events.GetEvent(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return eventToProto(event), nil
+}
+
+func (s *eventGRPCServer) DeleteEvent(ctx context.Context, req *eventsv1.DeleteEventRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := s.events.DeleteEvent(ctx, req.GetId()); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *eventGRPCServer) WatchEvents(req *eventsv1.WatchEventsRequest, stream grpc.ServerStreamingServer[eventsv1.EventChange]) error {
+	if s.watch == nil {
+		return status.Error(codes.Unimplemented, "watching events is not enabled")
+	}
+	filter := domain.WatchFilter{City: req.GetCity(), Type: domain.EventType(req.GetType())}
+	err := s.watch.WatchEvents(stream.Context(), filter, func(change domain.EventChange) error {
+		return stream.Send(&eventsv1.EventChange{Kind: changeKindToProto(change.Kind), Event: eventToProto(&change.Event)})
+	})
+	return grpcError(stream.Context(), err)
+}
+
+// grpcError maps typed domain errors to status codes, as respondError does to HTTP statuses
+func grpcError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var (
+		validation *domain.ValidationError
+		notFound   *domain.NotFoundError
+		conflict   *domain.ConflictError
+		forbidden  *domain.ForbiddenError
+		dup        *domain.DuplicateEventError
+	)
+	switch {
+	case errors.As(err, &validation):
+		return status.Error(codes.InvalidArgument, validation.Error())
+	case errors.As(err, &notFound):
+		return status.Error(codes.NotFound, notFound.Error())
+	case errors.As(err, &dup):
+		return status.Error(codes.AlreadyExists, dup.Error())
+	case errors.As(err, &conflict):
+		return status.Error(codes.FailedPrecondition, conflict.Error())
+	case errors.As(err, &forbidden):
+		return status.Error(codes.PermissionDenied, forbidden.Error())
+	}
+	logError(ctx, "grpc call failed", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func eventToProto(e *domain.Event) *eventsv1.Event {
+	lat, lng := coordinates(e.Location)
+	return &eventsv1.Event{
+		Id:             e.Id,
+		Name:           e.EventName,
+		Slug:           e.Slug,
+		Type:           string(e.Type),
+		City:           e.City,
+		Country:        e.Country,
+		Address:        e.FullAddress,
+		Latitude:       lat,
+		Longitude:      lng,
+		StartTime:      timestampOrNil(e.StartTime),
+		EndTime:        timestampOrNil(e.EndTime),
+		Timezone:       e.Timezone,
+		Url:            e.EventURL,
+		Provider:       e.Provider,
+		Price:          e.Price,
+		ImageUrl:       e.ImageUrl,
+		Capacity:       int32(e.Capacity),
+		AttendeeCount:  int32(e.AttendeeCount),
+		FavoritesCount: int32(e.FavoritesCount),
+		ViewCount:      e.ViewCount,
+		Featured:       e.Featured,
+		FeaturedUntil:  timestampOrNil(e.FeaturedUntil),
+		HasTickets:     e.HasTickets,
+		OrganizerId:    e.OrganizerID,
+		OrganizerName:  e.OrganizerName,
+		CreateTime:     timestampOrNil(e.CreatedAt),
+		UpdateTime:     timestampOrNil(e.UpdatedAt),
+	}
+}
+
+// coordinates formats a stored geopoint as the decimal strings of the proto, empty without one
+func coordinates(ll *latlng.LatLng) (lat, lng string) {
+	if ll == nil {
+		return "", ""
+	}
+	return strconv.FormatFloat(ll.GetLatitude(), 'f', -1, 64), strconv.FormatFloat(ll.GetLongitude(), 'f', -1, 64)
+}
+
+func changeKindToProto(kind domain.EventChangeKind) eventsv1.EventChange_Kind {
+	switch kind {
+	case domain.EventAdded:
+		return eventsv1.EventChange_ADDED
+	case domain.EventModified:
+		return eventsv1.EventChange_MODIFIED
+	case domain.EventRemoved:
+		return eventsv1.EventChange_REMOVED
+	}
+	return eventsv1.EventChange_KIND_UNSPECIFIED
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// formatTimestamp renders a request timestamp in the RFC 3339 form the DTO validators expect
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+func ptr[T any](v T) *T { return &v }
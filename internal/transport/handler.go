@@ -2,157 +2,288 @@ package transport
 
 import (
 	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
 	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/tasks"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 	"strings"
-
-	"github.com/andybalholm/brotli"
+	"time"
 )
 
-// ProjectID is needed for trace formatting. Fetch it once.
-var googleProjectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
-
-// Setup logger with a Handler that handles context for Tracing
-var logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-	ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-		// Map standard keys to Google Cloud Logging keys
-		if a.Key == slog.LevelKey {
-			a.Key = "severity"
-		}
-		if a.Key == slog.MessageKey {
-			a.Key = "message"
-		}
-		return a
-	},
-}))
-
-// Log helper to extract trace from context and inject it into the log entry
+// Log helper; the request Id and trace in ctx are attached by the logger WithRequestID stores
 func logError(ctx context.Context, msg string, err error) {
-	args := []any{"error", err}
+	logging.FromContext(ctx).ErrorContext(ctx, msg, "error", err)
+}
 
-	// Add Trace ID to the log entry if present in context
-	if traceID, ok := ctx.Value("trace_id").(string); ok && googleProjectID != "" {
-		// GCP Format: projects/[PROJECT-ID]/traces/[TRACE-ID]
-		traceVal := fmt.Sprintf("projects/%s/traces/%s", googleProjectID, traceID)
-		args = append(args, "logging.googleapis.com/trace", traceVal)
-	}
+// Services groups the business services exposed by the router
+type Services struct {
+	Events      service.EventService
+	Tracking    service.TrackingService
+	TicketTiers service.TicketTierService
+	RSVPs       service.RSVPService
+	Favorites   service.FavoriteService
+	Organizers  service.OrganizerService
+	Users       service.UserService
+	// Optional: /users/me/subscription (email) and /users/me/subscriptions (push) are only routed when set
+	Subscriptions     service.SubscriptionService
+	PushSubscriptions service.PushSubscriptionService
+	// EventWatch backs the gRPC WatchEvents stream; nil leaves it unimplemented
+	EventWatch service.EventWatchService
 
-	logger.ErrorContext(ctx, msg, args...)
+	// Optional: async imports are only routed when Imports is set
+	Imports      service.ImportService
+	TaskVerifier tasks.Verifier
+	// Optional: the announcement task worker is only routed when Announcements is set
+	Announcements service.AnnouncementService
+	// Optional: POST /internal/cron/daily is only routed when Maintenance is set
+	Maintenance  service.MaintenanceService
+	CronVerifier tasks.Verifier
+	Audit        service.AuditService // optional: enables GET /admin/audit-logs
+	// Cache-Control for event reads (e.g. "public, max-age=30"); defaults to "no-cache" (revalidate via ETag)
+	EventCacheControl string
+	// Optional: /openapi.json and Swagger UI at /swagger/ are only routed when public or admin (API_DOCS);
+	// admin also needs APIDocsPolicies in front of the route policies
+	APIDocs APIDocsAccess
+	// Optional: GET /metrics is only routed when enabled (METRICS_ENABLED)
+	MetricsEnabled bool
+	// Optional: /debug/pprof/ (admin-only) is only routed when enabled (PPROF_ENABLED)
+	PprofEnabled bool
+	// Optional: /admin/backups is only routed when set; managed exports also need BACKUP_BUCKET
+	Backups service.BackupService
+	// Optional: POST /dev/seed is only routed when set, which setupApplication never does in production
+	Seeds service.SeedService
+	// Optional: partner webhooks are only routed when a signing secret is configured
+	WebhookSecret []byte
+	// Dependencies probed by GET /readyz, keyed by name
+	HealthProbes map[string]HealthProbe
+	// Optional: announced in the Sunset header of unversioned (pre-/v1) paths
+	LegacySunset time.Time
+	// HonorDoNotTrack drops tracking sent with DNT: 1 or Sec-GPC: 1 without storing it
+	HonorDoNotTrack bool
+	// AdminUID is the Firebase UID holding the admin role on the gRPC API; the HTTP router gets it
+	// from WithAuthProtection
+	AdminUID string
+	// Logger is what gRPC calls log through (nil uses slog.Default()); HTTP requests log through
+	// the request-scoped child WithRequestID derives from its logger
+	Logger *slog.Logger
+	// Optional: GET/PUT /admin/log-level are only routed when set; the level applies to this instance
+	LogLevel *slog.LevelVar
 }
 
-func NewRouter(eventSvc service.EventService, trackingSvc service.TrackingService) http.Handler {
+func NewRouter(svc Services) http.Handler {
 	mux := http.NewServeMux()
 
+	// --- Health checks & build info ---
+	healthHandler := NewHealthHandler(svc.HealthProbes)
+	mux.Handle("/healthz", healthHandler)
+	mux.Handle("/readyz", healthHandler)
+	mux.Handle("/warmup", healthHandler)
+	mux.Handle("/version", healthHandler)
+
+	if svc.APIDocs == APIDocsPublic || svc.APIDocs == APIDocsAdmin {
+		mux.Handle("GET "+OpenAPIPath, OpenAPIHandler())
+		mux.Handle("GET "+SwaggerUIPath, SwaggerUIHandler(svc.APIDocs))
+	}
+	if svc.MetricsEnabled {
+		mux.Handle("GET /metrics", MetricsHandler())
+	}
+	if svc.PprofEnabled {
+		mux.Handle("/debug/pprof/", PprofHandler())
+	}
+
 	// --- Events ---
-	eventHandler := NewEventHandler(eventSvc)
+	eventHandler := NewEventHandler(svc.Events, svc.EventCacheControl)
 	// 1. Main registration with trailing slash (canonical)
-	mux.Handle("/events/", http.StripPrefix("/events", eventHandler))
-
-	// 2. Fix: Explicitly handle missing slash.
-	// Redirect using 307 (Temporary Redirect) to preserve POST method and body.
-	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
-		target := "/events/"
-		if len(r.URL.RawQuery) > 0 {
-			target += "?" + r.URL.RawQuery
-		}
-		http.Redirect(w, r, target, http.StatusTemporaryRedirect)
-	})
+	events := stripPrefix("/events", eventHandler)
+	mux.Handle("/events/", events)
 
-	// --- Tracking ---
-	trackingHandler := NewTrackingHandler(trackingSvc)
-	mux.Handle("/tracking/", http.StripPrefix("/tracking", trackingHandler))
+	// 2. Fix: Explicitly handle missing slash (served in place, no redirect).
+	mux.Handle("/events", withTrailingSlash(events))
 
-	// Apply the same fix for tracking
-	mux.HandleFunc("/tracking", func(w http.ResponseWriter, r *http.Request) {
-		target := "/tracking/"
-		if len(r.URL.RawQuery) > 0 {
-			target += "?" + r.URL.RawQuery
-		}
-		http.Redirect(w, r, target, http.StatusTemporaryRedirect)
-	})
+	// --- Ticket Tiers (sub-resource of events) ---
+	// These patterns are more specific than "/events/", so the mux prefers them.
+	tierHandler := NewTicketTierHandler(svc.TicketTiers)
+	mux.Handle("/events/{id}/tiers", tierHandler)
+	mux.Handle("/events/{id}/tiers/{tierId}", tierHandler)
 
-	return mux
-}
+	// --- RSVPs (sub-resource of events) ---
+	rsvpHandler := NewRSVPHandler(svc.RSVPs)
+	mux.Handle("/events/{id}/rsvp", rsvpHandler)
+	mux.Handle("/events/{id}/attendees", rsvpHandler)
 
-// WithTraceID extracts the Google Cloud Trace ID header.
-// This allows logs to be correlated in the Logs Explorer.
-func WithTraceID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		traceHeader := r.Header.Get("X-Cloud-Trace-Context")
-		var traceID string
-		if traceHeader != "" {
-			// Header format: TRACE_ID/SPAN_ID;o=TRACE_TRUE
-			parts := strings.Split(traceHeader, "/")
-			if len(parts) > 0 {
-				traceID = parts[0]
-			}
-		}
+	// --- Current user ---
+	userHandler := NewUserHandler(svc.Users)
+	mux.Handle("/users/me", userHandler)
+	mux.Handle("/users/{uid}/data", userHandler)
 
-		if traceID != "" {
-			ctx := context.WithValue(r.Context(), "trace_id", traceID)
-			next.ServeHTTP(w, r.WithContext(ctx))
-		} else {
-			next.ServeHTTP(w, r)
-		}
-	})
-}
+	// --- Favorites (scoped to the authenticated user) ---
+	favoriteHandler := NewFavoriteHandler(svc.Favorites)
+	mux.Handle("/users/me/favorites", favoriteHandler)
+	mux.Handle("/users/me/favorites/{eventId}", favoriteHandler)
 
-// WithRecovery recovers from panics and logs them as critical errors
-func WithRecovery(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if rec := recover(); rec != nil {
-				err, ok := rec.(error)
-				if !ok {
-					err = fmt.Errorf("%v", rec)
-				}
-				// Log with Trace ID context
-				logError(r.Context(), "PANIC RECOVERED", err)
-
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(domain.APIResponse{Error: "Internal Server Error"})
-			}
-		}()
-		next.ServeHTTP(w, r)
+	// --- Notification preferences (scoped to the authenticated user) ---
+	if svc.Subscriptions != nil {
+		mux.Handle("/users/me/subscription", NewSubscriptionHandler(svc.Subscriptions))
+	}
+	if svc.PushSubscriptions != nil {
+		pushHandler := NewPushSubscriptionHandler(svc.PushSubscriptions)
+		mux.Handle("/users/me/subscriptions", pushHandler)
+		mux.Handle("/users/me/subscriptions/{id}", pushHandler)
+	}
+
+	// --- Organizers ---
+	organizerHandler := NewOrganizerHandler(svc.Organizers)
+	organizers := stripPrefix("/organizers", organizerHandler)
+	mux.Handle("/organizers/", organizers)
+	mux.Handle("/organizers", withTrailingSlash(organizers))
+
+	// --- Admin maintenance ---
+	mux.Handle("/admin/", NewAdminHandler(svc.Events, svc.Audit, svc.Tracking, svc.LogLevel))
+	if svc.Backups != nil {
+		backupHandler := NewBackupHandler(svc.Backups)
+		mux.Handle("/admin/backups", backupHandler)
+		mux.Handle("/admin/backups/", backupHandler)
+	}
+
+	// --- Development tools ---
+	if svc.Seeds != nil {
+		mux.Handle("/dev/", NewDevHandler(svc.Seeds))
+	}
+
+	// --- Async jobs (import upload, status, Cloud Tasks worker) ---
+	if svc.Imports != nil {
+		jobHandler := NewJobHandler(svc.Imports, svc.TaskVerifier)
+		mux.Handle("/events/import-async", jobHandler)
+		mux.Handle("/jobs/{id}", jobHandler)
+		mux.Handle(service.ImportChunkPath, jobHandler)
+	}
+	if svc.Announcements != nil {
+		mux.Handle(service.AnnounceEventPath, NewAnnouncementHandler(svc.Announcements, svc.TaskVerifier))
+	}
+
+	// --- Scheduled housekeeping (Cloud Scheduler, OIDC) ---
+	if svc.Maintenance != nil {
+		mux.Handle(CronDailyPath, NewCronHandler(svc.Maintenance, svc.CronVerifier))
+	}
+
+	// --- Partner webhooks (HMAC-signed, no Firebase token) ---
+	if len(svc.WebhookSecret) > 0 {
+		mux.Handle("/webhooks/", NewWebhookHandler(svc.Events, svc.WebhookSecret))
+	}
+
+	// --- GraphQL ---
+	graphQLHandler := NewGraphQLHandler(svc)
+	mux.Handle("/graphql", graphQLHandler)
+	mux.Handle("/graphql/schema.graphql", graphQLHandler)
+
+	// --- Tracking ---
+	trackingHandler := NewTrackingHandler(svc.Tracking, svc.HonorDoNotTrack)
+	tracking := stripPrefix("/tracking", trackingHandler)
+	mux.Handle("/tracking/", tracking)
+
+	// Apply the same fix for tracking
+	mux.Handle("/tracking", withTrailingSlash(tracking))
+
+	root := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveMux(mux, w, r)
 	})
+	return withAPIVersions(recordRoute(root), svc.LegacySunset)
 }
 
-func respondError(w http.ResponseWriter, err error) {
-	if _, ok := err.(*domain.ValidationError); ok {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(domain.APIResponse{Error: err.Error()})
+func respondError(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		validation *domain.ValidationError
+		notFound   *domain.NotFoundError
+		conflict   *domain.ConflictError
+		forbidden  *domain.ForbiddenError
+		dup        *domain.DuplicateEventError
+	)
+	switch {
+	case errors.As(err, &validation):
+		writeCodedError(w, http.StatusBadRequest, validation, nil)
 		return
-	}
-	if err.Error() == "event not found" {
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(domain.APIResponse{Error: err.Error()})
+	case errors.As(err, &notFound):
+		writeCodedError(w, http.StatusNotFound, notFound, nil)
+		return
+	case errors.As(err, &dup):
+		writeCodedError(w, http.StatusConflict, dup, dup.ExistingID)
+		return
+	case errors.As(err, &conflict):
+		writeCodedError(w, http.StatusConflict, conflict, nil)
+		return
+	case errors.As(err, &forbidden):
+		writeCodedError(w, http.StatusForbidden, forbidden, nil)
 		return
 	}
 
-	// Use context-aware logger
-	// We need request context here, but respondError signature doesn't have it.
-	// In a real scenario, pass r.Context() to respondError.
-	// For now, we log without context or you can refactor respondError to take ctx.
-	logger.Error("SERVER ERROR", "error", err.Error(), "component", "api_handler")
+	logging.FromContext(r.Context()).ErrorContext(r.Context(), "SERVER ERROR", "error", err.Error(), "component", "api_handler")
+
+	writeErrorResponse(w, http.StatusInternalServerError, domain.APIResponse{Error: "Internal Server Error"})
+}
+
+func writeCodedError(w http.ResponseWriter, status int, err domain.CodedError, data interface{}) {
+	writeErrorResponse(w, status, domain.APIResponse{Data: data, Error: err.Error(), Code: err.Code()})
+}
 
-	w.WriteHeader(http.StatusInternalServerError)
-	_ = json.NewEncoder(w).Encode(domain.APIResponse{Error: "Internal Server Error"})
+// writeErrorResponse writes an error payload tagged with the request Id set by WithRequestID
+func writeErrorResponse(w http.ResponseWriter, status int, resp domain.APIResponse) {
+	resp.RequestID = w.Header().Get(RequestIDHeader)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func WithCORS(next http.Handler, origin string) http.Handler {
+// decodeJSON decodes a request body into a DTO. Fields the DTO does not declare are rejected
+// so that typos (e.g. "strat_time") fail loudly instead of silently falling back to defaults.
+func decodeJSON(r *http.Request, dst interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		// encoding/json has no typed error for unknown fields; the message is `json: unknown field "name"`
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return domain.ErrValidation("Unknown field " + field)
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return domain.ErrValidation(fmt.Sprintf("Invalid type for field \"%s\"", typeErr.Field))
+		}
+		return domain.ErrValidation("Invalid JSON body")
+	}
+	return nil
+}
+
+func respondUnauthorized(w http.ResponseWriter) {
+	writeErrorResponse(w, http.StatusUnauthorized, domain.APIResponse{Error: "Unauthorized: Valid Bearer token required"})
+}
+
+// corsMaxAge lets browsers cache preflight responses (seconds; Chromium caps it at 7200)
+const corsMaxAge = "3600"
+
+// WithCORS allows cross-origin calls from origins, a comma-separated list of exact origins
+// and wildcard subdomain patterns (e.g. "https://app.bibently.com, https://*.bibently.com").
+// An empty list or "*" allows any origin.
+func WithCORS(next http.Handler, origins string) http.Handler {
+	allowAll, exact, wildcards := parseCORSOrigins(origins)
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if origin == "" {
-			origin = "*"
+		if allowAll {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			// The response depends on the Origin header, so caches must key on it
+			w.Header().Add("Vary", "Origin")
+			if origin := r.Header.Get("Origin"); origin != "" && corsOriginAllowed(origin, exact, wildcards) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
 		}
-		w.Header().Set("Access-Control-Allow-Origin", origin)
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, X-Firestore-Database")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 		if r.Method == "OPTIONS" {
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -160,27 +291,45 @@ func WithCORS(next http.Handler, origin string) http.Handler {
 	})
 }
 
-func WithCompression(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "br") {
-			next.ServeHTTP(w, r)
-			return
-		}
-		w.Header().Set("Content-Encoding", "br")
-		br := brotli.NewWriter(w)
-		defer func(br *brotli.Writer) {
-			_ = br.Close()
-		}(br)
-		cw := &compressedWriter{w: w, cw: br}
-		next.ServeHTTP(cw, r)
-	})
+// corsWildcard matches "scheme://<one or more labels>.suffix"
+type corsWildcard struct {
+	scheme string // e.g. "https://"
+	suffix string // e.g. ".bibently.com"
 }
 
-type compressedWriter struct {
-	w  http.ResponseWriter
-	cw *brotli.Writer
+func parseCORSOrigins(origins string) (bool, map[string]bool, []corsWildcard) {
+	exact := make(map[string]bool)
+	var wildcards []corsWildcard
+	for _, o := range strings.Split(origins, ",") {
+		o = strings.TrimSuffix(strings.TrimSpace(o), "/")
+		switch {
+		case o == "":
+			continue
+		case o == "*":
+			return true, nil, nil
+		case strings.Contains(o, "://*."):
+			scheme, host, _ := strings.Cut(o, "*")
+			wildcards = append(wildcards, corsWildcard{scheme: scheme, suffix: host})
+		default:
+			exact[o] = true
+		}
+	}
+	return len(exact) == 0 && len(wildcards) == 0, exact, wildcards
 }
 
-func (cw *compressedWriter) Header() http.Header         { return cw.w.Header() }
-func (cw *compressedWriter) Write(b []byte) (int, error) { return cw.cw.Write(b) }
-func (cw *compressedWriter) WriteHeader(statusCode int)  { cw.w.WriteHeader(statusCode) }
+func corsOriginAllowed(origin string, exact map[string]bool, wildcards []corsWildcard) bool {
+	if exact[origin] {
+		return true
+	}
+	for _, wc := range wildcards {
+		sub, ok := strings.CutPrefix(origin, wc.scheme)
+		if !ok {
+			continue
+		}
+		label, ok := strings.CutSuffix(sub, wc.suffix)
+		if ok && label != "" && !strings.ContainsAny(label, "/:") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/buildinfo"
+	"bibently.com/backend/internal/domain"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// probeTimeout keeps /readyz fast enough for Cloud Run probes even when a dependency hangs
+const probeTimeout = 2 * time.Second
+
+// WarmupTimeout bounds a warm-up, whose probes include first connections and TLS handshakes
+const WarmupTimeout = 10 * time.Second
+
+// HealthProbe checks one dependency; a nil error means healthy
+type HealthProbe func(ctx context.Context) error
+
+// HealthHandler serves the operational endpoints: liveness (/healthz), readiness (/readyz),
+// warm-up (/warmup) and /version
+type HealthHandler struct {
+	probes map[string]HealthProbe
+	mux    *http.ServeMux
+}
+
+func NewHealthHandler(probes map[string]HealthProbe) *HealthHandler {
+	h := &HealthHandler{
+		probes: probes,
+		mux:    http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *HealthHandler) routes() {
+	h.mux.HandleFunc("GET /healthz", h.handleLiveness)
+	h.mux.HandleFunc("GET /readyz", h.handleReadiness)
+	h.mux.HandleFunc("GET /warmup", h.handleWarmup)
+	h.mux.HandleFunc("GET /version", h.handleVersion)
+}
+
+func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	serveMux(h.mux, w, r)
+}
+
+// handleLiveness reports that the process is serving requests; it never touches dependencies
+func (h *HealthHandler) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(domain.HealthReport{Status: domain.HealthOK})
+}
+
+// handleReadiness probes every dependency concurrently and reports status and latency for each
+func (h *HealthHandler) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+	defer cancel()
+	writeHealthReport(w, RunProbes(ctx, h.probes))
+}
+
+// handleWarmup runs the probes with a deadline that allows for connection setup, so the first
+// Firestore read of a new instance is paid here rather than by a user. Point min-instances
+// startup or a scheduler at it.
+func (h *HealthHandler) handleWarmup(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), WarmupTimeout)
+	defer cancel()
+	writeHealthReport(w, RunProbes(ctx, h.probes))
+}
+
+func writeHealthReport(w http.ResponseWriter, report domain.HealthReport) {
+	if report.Status != domain.HealthOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// RunProbes runs every probe concurrently and reports status and latency for each
+func RunProbes(ctx context.Context, probes map[string]HealthProbe) domain.HealthReport {
+	report := domain.HealthReport{Status: domain.HealthOK, Checks: make(map[string]domain.DependencyHealth, len(probes))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, probe := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := probe(ctx)
+			result := domain.DependencyHealth{Status: domain.HealthOK, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = domain.HealthFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = domain.HealthFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// handleVersion reports the revision this instance is running
+func (h *HealthHandler) handleVersion(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(buildinfo.Get())
+}
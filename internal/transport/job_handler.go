@@ -0,0 +1,102 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/tasks"
+	"encoding/json"
+	"net/http"
+)
+
+// JobHandler serves asynchronous imports: the upload endpoint, job status and the task worker
+type JobHandler struct {
+	service service.ImportService
+	verify  tasks.Verifier
+	mux     *http.ServeMux
+}
+
+func NewJobHandler(svc service.ImportService, verify tasks.Verifier) *JobHandler {
+	h := &JobHandler{
+		service: svc,
+		verify:  verify,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *JobHandler) routes() {
+	h.mux.HandleFunc("POST /events/import-async", h.handleImportAsync)
+	h.mux.HandleFunc("GET /jobs/{id}", h.handleGetJob)
+	h.mux.HandleFunc("POST "+service.ImportChunkPath, h.handleImportChunk)
+}
+
+func (h *JobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleImportAsync accepts a large CSV file and processes it in the background
+func (h *JobHandler) handleImportAsync(w http.ResponseWriter, r *http.Request) {
+	rows, rejected, err := readImportCSV(w, r, maxAsyncImportSize, maxAsyncImportRows)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	job, err := h.service.StartImport(r.Context(), rows, rejected)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.Id)
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: job})
+}
+
+// handleGetJob returns the progress of an asynchronous job
+func (h *JobHandler) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.GetJob(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: job})
+}
+
+// handleImportChunk is called by Cloud Tasks for each chunk of an async import.
+// Any non-2xx response makes Cloud Tasks retry the chunk.
+func (h *JobHandler) handleImportChunk(w http.ResponseWriter, r *http.Request) {
+	if !authenticateTask(w, r, h.verify) {
+		return
+	}
+
+	var chunk domain.ImportChunk
+	if err := json.NewDecoder(r.Body).Decode(&chunk); err != nil {
+		respondError(w, r, domain.ErrValidation("Invalid JSON body"))
+		return
+	}
+
+	if err := h.service.ProcessChunk(r.Context(), &chunk); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticateTask admits Cloud Tasks callbacks verify accepts and answers 403 to anything else
+func authenticateTask(w http.ResponseWriter, r *http.Request, verify tasks.Verifier) bool {
+	if verify == nil {
+		http.Error(w, "Forbidden: task worker is not configured", http.StatusForbidden)
+		return false
+	}
+	if err := verify(r); err != nil {
+		logError(r.Context(), "task authentication failed", err)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const jsonAPIContentType = "application/vnd.api+json"
+
+// jsonAPIHiddenAttributes are event fields that are not attributes: the id is top level and the
+// organizer is a relationship
+var jsonAPIHiddenAttributes = map[string]bool{
+	"id":           true,
+	"organizer_id": true,
+}
+
+// WantsJSONAPI reports whether the client asked for a JSON:API document
+func WantsJSONAPI(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == jsonAPIContentType {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonAPIDocument struct {
+	Data    interface{}       `json:"data"`
+	Links   map[string]string `json:"links,omitempty"`
+	JSONAPI map[string]string `json:"jsonapi"`
+}
+
+type jsonAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]interface{}         `json:"attributes"`
+	Relationships map[string]jsonAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+type jsonAPIRelationship struct {
+	Data  *jsonAPIIdentifier `json:"data"` // null for an empty to-one relationship
+	Links map[string]string  `json:"links,omitempty"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// eventResource renders an event as a JSON:API resource; fields is an optional sparse fieldset
+func eventResource(e *domain.EventResponse, fields []string) jsonAPIResource {
+	attrs := domain.ProjectEvent(e, fields)
+	for name := range jsonAPIHiddenAttributes {
+		delete(attrs, name)
+	}
+
+	organizer := jsonAPIRelationship{}
+	if e.OrganizerID != "" {
+		organizer.Data = &jsonAPIIdentifier{Type: "organizers", ID: e.OrganizerID}
+		organizer.Links = map[string]string{"related": APIVersionPrefix + "/organizers/" + url.PathEscape(e.OrganizerID)}
+	}
+	return jsonAPIResource{
+		Type:          "events",
+		ID:            e.Id,
+		Attributes:    attrs,
+		Relationships: map[string]jsonAPIRelationship{"organizer": organizer},
+		Links:         map[string]string{"self": APIVersionPrefix + "/events/" + url.PathEscape(e.Id)},
+	}
+}
+
+func eventResources(events []domain.EventResponse, fields []string) []jsonAPIResource {
+	resources := make([]jsonAPIResource, 0, len(events))
+	for i := range events {
+		resources = append(resources, eventResource(&events[i], fields))
+	}
+	return resources
+}
+
+// paginationLinks builds self/first/prev/next from the URI the client requested, swapping page_token.
+// RequestURI is used because handlers behind stripPrefix see a shortened path.
+func paginationLinks(r *http.Request, meta domain.Meta) map[string]string {
+	u, err := url.ParseRequestURI(r.RequestURI)
+	if err != nil {
+		u = r.URL
+	}
+	withToken := func(token string) string {
+		q := u.Query()
+		q.Del("page_token")
+		if token != "" {
+			q.Set("page_token", token)
+		}
+		link := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		return link.String()
+	}
+
+	links := map[string]string{
+		"self":  u.RequestURI(),
+		"first": withToken(""),
+	}
+	if meta.PrevPageToken != "" {
+		links["prev"] = withToken(meta.PrevPageToken)
+	}
+	if meta.NextPageToken != "" {
+		links["next"] = withToken(meta.NextPageToken)
+	}
+	return links
+}
+
+// writeJSONAPI writes a JSON:API top-level document with the same ETag handling as JSON responses
+func writeJSONAPI(w http.ResponseWriter, r *http.Request, etag, cacheControl string, data interface{}, links map[string]string) {
+	w.Header().Set("Content-Type", jsonAPIContentType)
+	writeCacheable(w, r, etag, cacheControl, jsonAPIDocument{Data: data, Links: links, JSONAPI: map[string]string{"version": "1.1"}})
+}
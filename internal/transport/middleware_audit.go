@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"context"
+	"net/http"
+	"strings"
+)
+
+// WithAuditLog records every write request (who, what, when, outcome) to the audit log.
+// Services add old-vs-new values through domain.RecordAuditChanges.
+// It must run after WithAuthProtection so the caller is known.
+func WithAuditLog(next http.Handler, audit service.AuditService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		resource, resourceID := auditTarget(apiPath(r.URL.Path))
+		entry := &domain.AuditEntry{
+			Actor:      domain.ActorFromContext(r.Context()),
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Resource:   resource,
+			ResourceID: resourceID,
+		}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(domain.WithAuditEntry(r.Context(), entry)))
+
+		entry.Status = sw.status
+		// The response is already written; a failed audit write is logged, not surfaced to the caller.
+		// Detach from the request context so a client disconnect does not drop the entry.
+		if err := audit.RecordAudit(context.WithoutCancel(r.Context()), entry); err != nil {
+			logError(r.Context(), "failed to write audit log", err)
+		}
+	})
+}
+
+// auditTarget splits "/events/123/tiers" into ("events", "123")
+func auditTarget(path string) (string, string) {
+	parts := strings.SplitN(strings.Trim(path, "/"), "/", 3)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// statusWriter remembers the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(code int) {
+	sw.status = code
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (e.g. to flush streams)
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
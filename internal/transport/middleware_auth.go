@@ -1,9 +1,9 @@
 package transport
 
 import (
+	"bibently.com/backend/internal/domain"
 	"context"
 	"net/http"
-	"os"
 	"strings"
 
 	"firebase.google.com/go/v4/auth"
@@ -15,8 +15,19 @@ type contextKey string
 // 2. Define the key constant. We export it so other packages in your app can read it.
 const UserContextKey contextKey = "user"
 
-func WithAuthProtection(next http.Handler, authClient *auth.Client) http.Handler {
+// WithAuthProtection verifies the Firebase ID token and enforces the route policy table.
+// policies defaults to DefaultRoutePolicies when nil; adminUID names the Firebase user with the admin role.
+func WithAuthProtection(next http.Handler, authClient *auth.Client, policies []RoutePolicy, adminUID string) http.Handler {
+	if policies == nil {
+		policies = DefaultRoutePolicies
+	}
+	adminOnly := &RoutePolicy{Path: "/**", Role: domain.RoleAdmin}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := matchPolicy(policies, r)
+		if policy == nil {
+			policy = adminOnly
+		}
 
 		authHeader := r.Header.Get("Authorization")
 		var token *auth.Token
@@ -31,36 +42,58 @@ func WithAuthProtection(next http.Handler, authClient *auth.Client) http.Handler
 		// Check if user is fully authenticated
 		isAuthenticated := token != nil && err == nil
 
-		// 2. NOW check for Admin Role (Fix applied here)
-		// If it's a write operation (POST/PUT/DELETE), ensure the user is the Admin
-		adminUID := os.Getenv("FIRESTORE_ADMIN_UID")
-		if r.Method != http.MethodGet {
-			if !isAuthenticated || token.UID != adminUID {
-				http.Error(w, "Forbidden: Admins only", http.StatusForbidden)
+		role := ""
+		if isAuthenticated {
+			role = roleOf(token, adminUID)
+		}
+
+		// 2. Enforce the policy. Routes open to any user ask guests to sign in (401);
+		// routes needing a role are forbidden (403) to everyone without it.
+		if !policy.Public {
+			if !isAuthenticated && policy.Role == RoleUser {
+				w.Header().Set("Content-Type", "application/json")
+				respondUnauthorized(w)
+				return
+			}
+			if !isAuthenticated || !satisfiesRole(role, policy.Role) {
+				if policy.Role == domain.RoleAdmin {
+					http.Error(w, "Forbidden: Admins only", http.StatusForbidden)
+				} else {
+					http.Error(w, "Forbidden: "+policy.Role+" role required", http.StatusForbidden)
+				}
 				return
 			}
 		}
 
 		if isAuthenticated {
-			// Inject user info into context
+			// Inject user info into context (the principal is read by the service layer for authorization and history)
 			ctx := context.WithValue(r.Context(), UserContextKey, token)
+			ctx = domain.WithPrincipal(ctx, domain.Principal{UID: token.UID, Role: role})
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
-		// 3. Guest Access Logic (Restricted to /events only)
-		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/events") {
+		// 3. Guest Access (public routes only)
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
 			w.Header().Set("X-Access-Type", "Public-Preview")
-			next.ServeHTTP(w, r)
-			return
-		}
-
-		// 4. Deny Access
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		_, err = w.Write([]byte(`{"error": "Unauthorized: Valid Bearer token required"}`))
-		if err != nil {
-			return
 		}
+		next.ServeHTTP(w, r)
 	})
 }
+
+// UserFromContext returns the verified Firebase token injected by WithAuthProtection
+func UserFromContext(ctx context.Context) (*auth.Token, bool) {
+	token, ok := ctx.Value(UserContextKey).(*auth.Token)
+	return token, ok && token != nil
+}
+
+// roleOf resolves the caller's role: the configured admin UID, or the "role" custom claim
+func roleOf(token *auth.Token, adminUID string) string {
+	if adminUID != "" && token.UID == adminUID {
+		return domain.RoleAdmin
+	}
+	if role, _ := token.Claims["role"].(string); role == domain.RoleOrganizer {
+		return domain.RoleOrganizer
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bytes"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCachedResponses bounds memory; when full, expired entries are dropped and then the cache is reset
+const maxCachedResponses = 500
+
+// cachedHeaders are the handler-set headers replayed on a hit
+var cachedHeaders = []string{"Content-Type", "ETag", "Cache-Control"}
+
+type cachedResponse struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+func (c *responseCache) get(key string, now time.Time) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) put(key string, entry *cachedResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCachedResponses {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedResponses {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = entry
+}
+
+func (c *responseCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// WithResponseCache serves anonymous GET (and HEAD) requests matched by applies from an in-memory cache for ttl.
+// Any successful write through this instance empties the cache; other instances converge within ttl.
+// It must run after WithAuthProtection so authenticated callers are recognised and bypass the cache.
+func WithResponseCache(next http.Handler, ttl time.Duration, applies func(r *http.Request) bool) http.Handler {
+	cache := &responseCache{entries: make(map[string]*cachedResponse)}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+			if sw.status < http.StatusBadRequest {
+				cache.purge()
+			}
+			return
+		}
+		if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !applies(r) || !anonymous(r) || WantsNDJSON(r) || WantsJSONAPI(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// WithTenantPath strips the tenant from the path, so it is keyed separately
+		key := domain.TenantFromContext(r.Context()) + "|" + r.URL.Path + "?" + normalizeQuery(r.URL.Query())
+		now := time.Now()
+		if entry, ok := cache.get(key, now); ok {
+			h := w.Header()
+			for name, values := range entry.header {
+				h[name] = slices.Clone(values)
+			}
+			h.Set("X-Cache", "HIT")
+			if etagMatches(r.Header.Get("If-None-Match"), entry.header.Get("ETag")) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write(entry.body)
+			return
+		}
+
+		// Conditional requests are answered by the handler and have no body worth caching
+		if r.Header.Get("If-None-Match") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-Cache", "MISS")
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status == http.StatusOK {
+			header := make(http.Header, len(cachedHeaders))
+			for _, name := range cachedHeaders {
+				if v := w.Header().Values(name); len(v) > 0 {
+					header[http.CanonicalHeaderKey(name)] = slices.Clone(v)
+				}
+			}
+			cache.put(key, &cachedResponse{header: header, body: rec.body.Bytes(), expires: now.Add(ttl)}, now)
+		}
+	})
+}
+
+// IsPublicEventList matches the event listing that anonymous homepages poll
+func IsPublicEventList(r *http.Request) bool {
+	path := apiPath(r.URL.Path)
+	return path == "/events/" || path == "/events"
+}
+
+// anonymous reports whether the request carries no caller identity
+func anonymous(r *http.Request) bool {
+	if r.Header.Get("Authorization") != "" {
+		return false
+	}
+	_, ok := domain.PrincipalFromContext(r.Context())
+	return !ok
+}
+
+// normalizeQuery makes equivalent query strings share a cache entry: empty parameters are dropped
+// and keys and values are sorted
+func normalizeQuery(q url.Values) string {
+	normalized := make(url.Values, len(q))
+	for key, values := range q {
+		var kept []string
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) > 0 {
+			slices.Sort(kept)
+			normalized[key] = kept
+		}
+	}
+	return normalized.Encode() // Encode sorts by key
+}
+
+// recordingWriter passes the response through while keeping a copy of the status and body
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(code int) {
+	rw.status = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+func (rw *recordingWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,219 @@
+package transport
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressionMinSize: smaller bodies are not worth the CPU; up to this many bytes are buffered
+// before deciding whether to compress
+const compressionMinSize = 1024
+
+// supportedEncodings in server preference order, used to break q-value ties
+var supportedEncodings = []string{"br", "gzip"}
+
+// WithCompression compresses responses with the best encoding the client accepts (Brotli, then gzip).
+// Headers are decided once the body is known to reach compressionMinSize (or the handler ends or flushes),
+// so already-encoded bodies, incompressible content types and small responses are passed through untouched.
+func WithCompression(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Caches must key on Accept-Encoding whether or not this response ends up compressed
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressedWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks the supported encoding with the highest q-value, or "" for identity
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	q := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				weight = f
+			}
+		}
+		q[strings.ToLower(strings.TrimSpace(name))] = weight
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range supportedEncodings {
+		weight, ok := q[enc]
+		if !ok {
+			weight, ok = q["*"]
+		}
+		if ok && weight > bestQ {
+			best, bestQ = enc, weight
+		}
+	}
+	return best
+}
+
+// compressible reports whether a body of this Content-Type benefits from compression
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true // unknown types are usually text
+	}
+	switch {
+	case mediaType == "image/svg+xml":
+		return true
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "video/"), strings.HasPrefix(mediaType, "audio/"):
+		return false
+	}
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/x-7z-compressed",
+		"application/x-brotli", "application/zstd", "font/woff", "font/woff2":
+		return false
+	}
+	return true
+}
+
+// compressedWriter holds back the status and the first compressionMinSize bytes, then either
+// switches to a compressor or passes everything through unchanged.
+type compressedWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int            // pending status; 0 until the handler sets one
+	buf      []byte         // body held back until the compression decision
+	started  bool           // headers have been sent downstream
+	cw       io.WriteCloser // nil when the response is passed through
+}
+
+func (cw *compressedWriter) WriteHeader(statusCode int) {
+	if cw.started || cw.status != 0 {
+		return
+	}
+	if statusCode < http.StatusOK {
+		// Informational responses (e.g. 103 Early Hints) go straight out
+		cw.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	cw.status = statusCode
+	if !cw.eligible() {
+		cw.start(false)
+	}
+}
+
+func (cw *compressedWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.started {
+		return cw.writeThrough(b)
+	}
+	cw.buf = append(cw.buf, b...)
+	if len(cw.buf) >= compressionMinSize {
+		if err := cw.release(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends what is buffered so streaming handlers are not held back by the size threshold
+func (cw *compressedWriter) Flush() {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.started {
+		_ = cw.release(true)
+	}
+	if f, ok := cw.cw.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressedWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close releases a response that ended below the threshold and flushes the compressor
+func (cw *compressedWriter) Close() error {
+	if cw.status == 0 {
+		return nil // nothing was written; net/http sends its default response
+	}
+	if !cw.started {
+		if err := cw.release(false); err != nil {
+			return err
+		}
+	}
+	if cw.cw == nil {
+		return nil
+	}
+	return cw.cw.Close()
+}
+
+// eligible reports whether the status and headers allow compressing this response
+func (cw *compressedWriter) eligible() bool {
+	if cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" || !compressible(h.Get("Content-Type")) {
+		return false
+	}
+	if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil && n < compressionMinSize {
+		return false
+	}
+	return true
+}
+
+// start sends the headers, switching to the negotiated encoding when compress is set
+func (cw *compressedWriter) start(compress bool) {
+	cw.started = true
+	if compress && cw.eligible() {
+		h := cw.Header()
+		h.Set("Content-Encoding", cw.encoding)
+		// The handler's length describes the uncompressed body
+		h.Del("Content-Length")
+		switch cw.encoding {
+		case "br":
+			cw.cw = brotli.NewWriter(cw.ResponseWriter)
+		case "gzip":
+			cw.cw = gzip.NewWriter(cw.ResponseWriter)
+		}
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// release starts the response and writes out the buffered bytes
+func (cw *compressedWriter) release(compress bool) error {
+	cw.start(compress)
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := cw.writeThrough(buf)
+	return err
+}
+
+func (cw *compressedWriter) writeThrough(b []byte) (int, error) {
+	if cw.cw == nil {
+		return cw.ResponseWriter.Write(b)
+	}
+	return cw.cw.Write(b)
+}
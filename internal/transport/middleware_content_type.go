@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// AnyMediaType in ContentTypeRule.MediaTypes accepts every body, for callers whose body is ignored
+const AnyMediaType = "*/*"
+
+// ContentTypeRule lists the request media types accepted by the writes matching Methods and Path,
+// which are matched like RoutePolicy (first match wins).
+type ContentTypeRule struct {
+	Methods    []string // empty matches every write method
+	Path       string
+	MediaTypes []string
+}
+
+// DefaultContentTypes accepts JSON on every write; CSV imports are multipart uploads and
+// Cloud Scheduler posts an unused body of whatever type the job was created with.
+// New formats are added as entries ahead of the catch-all.
+var DefaultContentTypes = []ContentTypeRule{
+	{Methods: []string{http.MethodPost}, Path: "/events/import", MediaTypes: []string{"multipart/form-data"}},
+	{Methods: []string{http.MethodPost}, Path: "/events/import-async", MediaTypes: []string{"multipart/form-data"}},
+	{Methods: []string{http.MethodPost}, Path: "/internal/cron/**", MediaTypes: []string{AnyMediaType}},
+	{Methods: []string{http.MethodPost}, Path: "/admin/backups/dump", MediaTypes: []string{ndjsonContentType}},
+	{Path: "/**", MediaTypes: []string{"application/json"}},
+}
+
+// WithContentTypes rejects POST, PUT and PATCH bodies whose Content-Type is not accepted by the
+// first matching rule with 415 Unsupported Media Type. Requests without a body pass through.
+// A nil rules uses DefaultContentTypes.
+func WithContentTypes(next http.Handler, rules []ContentTypeRule) http.Handler {
+	if rules == nil {
+		rules = DefaultContentTypes
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasWriteBody(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rule := matchContentTypeRule(rules, r)
+		if rule == nil || acceptsMediaType(rule.MediaTypes, r.Header.Get("Content-Type")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeErrorResponse(w, http.StatusUnsupportedMediaType, domain.APIResponse{
+			Error: "Unsupported Content-Type; expected " + strings.Join(rule.MediaTypes, " or "),
+			Code:  domain.CodeUnsupportedMedia,
+		})
+	})
+}
+
+// hasWriteBody reports whether r is a POST, PUT or PATCH carrying a body (or one of unknown length)
+func hasWriteBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return r.ContentLength != 0 && r.Body != nil && r.Body != http.NoBody
+	}
+	return false
+}
+
+func matchContentTypeRule(rules []ContentTypeRule, r *http.Request) *ContentTypeRule {
+	for i := range rules {
+		rule := &rules[i]
+		if len(rule.Methods) > 0 && !containsFold(rule.Methods, r.Method) {
+			continue
+		}
+		if matchPath(rule.Path, apiPath(r.URL.Path)) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// acceptsMediaType compares the media type of contentType, ignoring parameters such as charset
+func acceptsMediaType(allowed []string, contentType string) bool {
+	if containsFold(allowed, AnyMediaType) {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return containsFold(allowed, mediaType)
+}
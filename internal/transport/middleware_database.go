@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// DatabaseHeader names the Firestore database of a request under header routing
+const DatabaseHeader = "X-Firestore-Database"
+
+// DatabaseResolver picks the Firestore database of a request; "" means the default database
+type DatabaseResolver func(r *http.Request) string
+
+// DatabaseFromHeader routes by the X-Firestore-Database header, e.g. staging and production
+// served by one deployment
+func DatabaseFromHeader(r *http.Request) string {
+	return r.Header.Get(DatabaseHeader)
+}
+
+// DatabaseFromTenant routes by the Identity Platform tenant of the verified token, with one
+// database per tenant named after it. Guests get the default database.
+func DatabaseFromTenant(r *http.Request) string {
+	if token, ok := UserFromContext(r.Context()); ok {
+		return token.Firebase.Tenant
+	}
+	return ""
+}
+
+// WithDatabaseRouting serves requests resolved to one of databases with the handler build returns
+// for it, and everything else with next, which serves defaultDatabase. Handlers are built on first
+// use and kept; a failed build is retried by the next request. Databases outside the list are
+// rejected with 400, so a typo never falls back to production data.
+// It must run inside WithAuthProtection for DatabaseFromTenant to see the token.
+func WithDatabaseRouting(next http.Handler, defaultDatabase string, databases []string,
+	resolve DatabaseResolver, build func(databaseID string) (http.Handler, error)) http.Handler {
+	var mu sync.Mutex
+	handlers := make(map[string]http.Handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Shared caches must not serve one database's response for another
+		w.Header().Add("Vary", DatabaseHeader)
+
+		databaseID := resolve(r)
+		if databaseID == "" || databaseID == defaultDatabase {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !slices.Contains(databases, databaseID) {
+			respondError(w, r, domain.ErrValidation("unknown database "+databaseID))
+			return
+		}
+
+		mu.Lock()
+		handler, ok := handlers[databaseID]
+		if !ok {
+			var err error
+			if handler, err = build(databaseID); err != nil {
+				mu.Unlock()
+				respondError(w, r, err)
+				return
+			}
+			handlers[databaseID] = handler
+		}
+		mu.Unlock()
+		handler.ServeHTTP(w, r)
+	})
+}
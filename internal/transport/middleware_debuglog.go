@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/logging"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDebugLogBody bounds the part of a body that is logged
+const maxDebugLogBody = 16 << 10 // 16 KB
+
+const debugRedacted = "[REDACTED]"
+
+// sensitiveKeyParts mark a field as secret or personal when its lowercased name contains one
+var sensitiveKeyParts = []string{"password", "secret", "token", "authorization", "api_key", "apikey", "credential", "email", "phone"}
+
+// sensitiveKeys are redacted on an exact (lowercased) match; as parts they would hit unrelated fields
+var sensitiveKeys = map[string]bool{"ip": true, "ip_address": true, "user_agent": true, "uid": true, "user_id": true, "session_id": true}
+
+// rawJSONMember finds `"key": value` pairs in bodies that are not valid JSON
+var rawJSONMember = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*:\s*("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+
+// WithDebugLogging logs the requests to routes (patterns matched like RoutePolicy paths) and their
+// responses, bodies included, to diagnose malformed client payloads. Secret and personal fields are
+// redacted; bodies that are neither JSON nor text are only described. Streams (NDJSON exports and
+// server-sent events) pass through unlogged. It is meant for development and staging: config rejects
+// DEBUG_LOG_ROUTES in production.
+func WithDebugLogging(next http.Handler, routes []string) http.Handler {
+	if len(routes) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := apiPath(r.URL.Path)
+		if WantsNDJSON(r) || WantsEventStream(r) || !slices.ContainsFunc(routes, func(p string) bool { return matchPath(p, path) }) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var reqBody []byte
+		if r.Body != nil && r.Body != http.NoBody {
+			// Only the logged prefix is read ahead; the handler still gets the whole body
+			reqBody, _ = io.ReadAll(io.LimitReader(r.Body, maxDebugLogBody+1))
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(reqBody), r.Body), r.Body}
+		}
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		logging.FromContext(r.Context()).InfoContext(r.Context(), "debug request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", redactQuery(r.URL.Query()),
+			"request_body", redactBody(reqBody, r.Header.Get("Content-Type")),
+			"status", rec.status,
+			"response_body", redactBody(rec.body.Bytes(), w.Header().Get("Content-Type")),
+		)
+	})
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return sensitiveKeys[key] || slices.ContainsFunc(sensitiveKeyParts, func(part string) bool { return strings.Contains(key, part) })
+}
+
+func redactQuery(q url.Values) string {
+	for key := range q {
+		if sensitiveKey(key) {
+			q[key] = []string{debugRedacted}
+		}
+	}
+	return q.Encode()
+}
+
+// redactBody returns what is logged for body: parsed JSON with sensitive values replaced, text with
+// sensitive "key": value pairs replaced (e.g. malformed JSON), or a description of anything else
+func redactBody(body []byte, contentType string) any {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	if len(body) <= maxDebugLogBody && json.Unmarshal(body, &v) == nil {
+		return redactValue(v)
+	}
+	text := body[:min(len(body), maxDebugLogBody)]
+	if strings.HasPrefix(contentType, "multipart/") || !utf8.Valid(text) {
+		return fmt.Sprintf("%d bytes of %s, not logged", len(text), cmp.Or(contentType, "unknown content"))
+	}
+	return rawJSONMember.ReplaceAllStringFunc(string(text), func(member string) string {
+		m := rawJSONMember.FindStringSubmatch(member)
+		if !sensitiveKey(m[1]) {
+			return member
+		}
+		return `"` + m[1] + `":"` + debugRedacted + `"`
+	})
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if sensitiveKey(key) {
+				v[key] = debugRedacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return v
+}
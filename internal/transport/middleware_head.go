@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// WithHeadResponses answers HEAD requests with the headers the matching GET would send
+// (Content-Type, ETag, Cache-Control and the body's Content-Length) and no body.
+// ServeMux already routes HEAD to GET patterns; this only withholds the body and sizes it.
+// It must wrap WithCompression so Content-Length describes the body a GET would receive.
+func WithHeadResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		hw := &headWriter{ResponseWriter: w}
+		next.ServeHTTP(hw, r)
+		hw.finish()
+	})
+}
+
+// headWriter counts and discards the body, holding the headers back until its length is known.
+// A flush (streamed responses) sends them early, without a length.
+type headWriter struct {
+	http.ResponseWriter
+	status  int
+	written int
+	sent    bool
+}
+
+func (hw *headWriter) WriteHeader(statusCode int) {
+	if statusCode < http.StatusOK {
+		hw.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	if hw.status == 0 {
+		hw.status = statusCode
+	}
+}
+
+func (hw *headWriter) Write(b []byte) (int, error) {
+	if hw.status == 0 {
+		hw.status = http.StatusOK
+	}
+	hw.written += len(b)
+	return len(b), nil
+}
+
+// Flush sends the headers so clients probing a stream are not held until it ends
+func (hw *headWriter) Flush() {
+	hw.send(false)
+	if f, ok := hw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (hw *headWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
+
+func (hw *headWriter) finish() {
+	if hw.status == 0 {
+		return // nothing was written; net/http sends its default response
+	}
+	hw.send(true)
+}
+
+func (hw *headWriter) send(withLength bool) {
+	if hw.sent {
+		return
+	}
+	hw.sent = true
+	if hw.status == 0 {
+		hw.status = http.StatusOK
+	}
+	h := hw.Header()
+	if withLength && h.Get("Content-Length") == "" && h.Get("Transfer-Encoding") == "" &&
+		hw.status != http.StatusNoContent && hw.status != http.StatusNotModified {
+		h.Set("Content-Length", strconv.Itoa(hw.written))
+	}
+	hw.ResponseWriter.WriteHeader(hw.status)
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/metrics"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// routeHolder lets the router report the matched pattern back to WithMetrics,
+// which sees a different *http.Request after intermediate middlewares copied it
+type routeHolder struct {
+	pattern string
+}
+
+type routeHolderKey struct{}
+
+// withRouteHolder returns r carrying a route holder, reusing one an outer middleware already installed
+func withRouteHolder(r *http.Request) (*http.Request, *routeHolder) {
+	if holder, ok := r.Context().Value(routeHolderKey{}).(*routeHolder); ok {
+		return r, holder
+	}
+	holder := &routeHolder{}
+	return r.WithContext(context.WithValue(r.Context(), routeHolderKey{}, holder)), holder
+}
+
+// label returns the matched route, or "unmatched" when no pattern handled the request
+func (h *routeHolder) label() string {
+	if h.pattern == "" {
+		return "unmatched"
+	}
+	return h.pattern
+}
+
+// WithMetrics records request count, status and latency per route.
+// Routes are labelled by their pattern (e.g. "/events/{id}") so Ids do not explode cardinality.
+func WithMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		r, holder := withRouteHolder(r)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		route := holder.label()
+		metrics.HTTPRequests.Inc(r.Method, route, strconv.Itoa(sw.status))
+		metrics.HTTPDuration.ObserveSince(start, r.Method, route)
+	})
+}
+
+// recordRoute wraps the root mux and publishes the matched pattern to WithMetrics
+func recordRoute(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		if holder, ok := r.Context().Value(routeHolderKey{}).(*routeHolder); ok {
+			_, path, found := strings.Cut(r.Pattern, " ")
+			if !found {
+				path = r.Pattern
+			}
+			holder.pattern = path
+		}
+	})
+}
+
+// stripPrefix is http.StripPrefix that also reports the inner handler's pattern, prefixed, on r
+func stripPrefix(prefix string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, prefix)
+		rp := strings.TrimPrefix(r.URL.RawPath, prefix)
+		if len(p) == len(r.URL.Path) || (r.URL.RawPath != "" && len(rp) == len(r.URL.RawPath)) {
+			http.NotFound(w, r)
+			return
+		}
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = p
+		r2.URL.RawPath = rp
+		h.ServeHTTP(w, r2)
+
+		if method, path, found := strings.Cut(r2.Pattern, " "); found {
+			r.Pattern = method + " " + prefix + path
+		} else if r2.Pattern != "" {
+			r.Pattern = prefix + r2.Pattern
+		}
+	})
+}
+
+// MetricsHandler serves the registry in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		metrics.Default.WritePrometheus(w)
+	})
+}
@@ -0,0 +1,68 @@
+package transport
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/ratelimit"
+)
+
+// WithRateLimit throttles requests matched by applies (all requests when nil).
+// Authenticated callers get a bucket per UID, anonymous ones a bucket per client IP;
+// name separates the buckets of limiters sharing a backend.
+// It must run after WithAuthProtection so the caller's UID is in the context.
+func WithRateLimit(next http.Handler, limiter ratelimit.Limiter, name string, applies func(r *http.Request) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if applies != nil && !applies(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter, err := limiter.Allow(r.Context(), name+":"+rateLimitKey(r))
+		if err != nil {
+			// Fail open: an unavailable backend must not take the API down with it
+			logError(r.Context(), "rate limiter unavailable", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			w.Header().Set("Content-Type", "application/json")
+			writeErrorResponse(w, http.StatusTooManyRequests, domain.APIResponse{Error: "Too Many Requests", Code: domain.CodeRateLimited})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rateLimitKey(r *http.Request) string {
+	if user, ok := UserFromContext(r.Context()); ok {
+		return "uid:" + user.UID
+	}
+	return "ip:" + clientIP(r)
+}
+
+// clientIP returns the caller address. Behind Google's front end the rightmost X-Forwarded-For
+// entry is the one it appended; entries to its left are client-supplied and can be spoofed.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		parts := strings.Split(fwd, ",")
+		if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// IsTrackingWrite matches the public tracking endpoint, which needs a stricter limit than the rest of the API
+func IsTrackingWrite(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(apiPath(r.URL.Path), "/tracking")
+}
@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/buildinfo"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// errorReportingType marks a log entry as an error event for Google Cloud Error Reporting
+const errorReportingType = "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent"
+
+// WithRecovery recovers from panics and logs them as critical errors with the goroutine stack.
+// When reportService is set, the entry is also shaped as a Cloud Error Reporting event for that
+// service, so crashes are grouped and alerted on there.
+func WithRecovery(next http.Handler, reportService string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// The standard sentinel for aborting a response; net/http handles it silently
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("%v", rec)
+			}
+			// Go panic format, which Error Reporting parses into frames
+			stack := fmt.Sprintf("panic: %v\n\n%s", rec, debug.Stack())
+
+			args := []any{"error", err, "stack_trace", stack}
+			if reportService != "" {
+				args = append(args,
+					"@type", errorReportingType,
+					slog.Group("serviceContext", "service", reportService, "version", buildinfo.Get().GitSHA),
+					slog.Group("context", slog.Group("httpRequest",
+						"method", r.Method,
+						"url", r.URL.String(),
+						"userAgent", r.UserAgent(),
+					)),
+				)
+			}
+			logging.FromContext(r.Context()).ErrorContext(r.Context(), "PANIC RECOVERED", args...)
+
+			writeErrorResponse(w, http.StatusInternalServerError, domain.APIResponse{Error: "Internal Server Error"})
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
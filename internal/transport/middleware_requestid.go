@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/logging"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request Id in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds inbound Ids so callers cannot bloat every log line
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// WithRequestID honors a well-formed inbound X-Request-ID or generates one, stores it in the context
+// and echoes it in the response so a user-reported failure can be located. Handlers below log through
+// a child of logger carrying the Id (see logging.FromContext); nil uses slog.Default().
+// It must wrap WithRecovery so panics are logged with the Id as well.
+func WithRequestID(next http.Handler, logger *slog.Logger) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		// Set before next runs: error payloads read it back from the response headers
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
+		ctx = logging.WithLogger(ctx, logger.With("request_id", requestID))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the Id assigned by WithRequestID, or "" outside a request
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID accepts printable ASCII without spaces, which covers UUIDs and common trace-style Ids
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
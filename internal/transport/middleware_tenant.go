@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"net/http"
+	"strings"
+)
+
+// tenantPathPrefix starts the paths of a tenant's API: /tenants/{tenant}/v1/events
+const tenantPathPrefix = "/tenants/"
+
+// WithTenantPath serves /tenants/{tenant}/... as the path after the tenant, scoped to that tenant,
+// so each white-label frontend can call its own base URL. It must wrap WithAuthProtection, which
+// matches route policies on the stripped path.
+func WithTenantPath(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, tenantPathPrefix)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tenantID, path, _ := strings.Cut(rest, "/")
+		if !domain.ValidTenantID(tenantID) {
+			respondError(w, r, domain.ErrValidation("invalid tenant "+tenantID))
+			return
+		}
+
+		r = r.WithContext(domain.WithTenant(r.Context(), tenantID))
+		r.URL.Path = "/" + path
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithTenantIsolation completes the tenant of a request from the verified token (its Identity
+// Platform tenant) and rejects callers of another tenant's path with 403. Signed-in users outside
+// any tenant are only let into tenant paths as admins; guests reach what the route policy makes public.
+// It must run inside WithAuthProtection.
+func WithTenantIsolation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := UserFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pathTenant := domain.TenantFromContext(r.Context())
+		tokenTenant := token.Firebase.Tenant
+		switch {
+		case pathTenant == "" && tokenTenant != "":
+			r = r.WithContext(domain.WithTenant(r.Context(), tokenTenant))
+		case pathTenant != "" && tokenTenant != pathTenant:
+			if principal, _ := domain.PrincipalFromContext(r.Context()); tokenTenant != "" || principal.Role != domain.RoleAdmin {
+				respondError(w, r, domain.ErrForbidden("cross-tenant access"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/tracing"
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceFlushTimeout bounds the span export WithTracing waits for at the end of a request
+const traceFlushTimeout = 2 * time.Second
+
+// WithTracing starts a server span per request, continuing the caller's trace
+// (traceparent or X-Cloud-Trace-Context). The span is named after the matched route once it is known.
+// flush, when set, exports the spans of sampled requests before the handler returns: Cloud Functions
+// throttle the CPU between requests, so spans left in the batch queue may never be sent.
+func WithTracing(next http.Handler, flush func(context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+r.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			))
+		defer func() {
+			span.End()
+			if flush != nil && span.SpanContext().IsSampled() {
+				// The request may already be cancelled; the export still gets its own short deadline
+				flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), traceFlushTimeout)
+				defer cancel()
+				_ = flush(flushCtx)
+			}
+		}()
+
+		r, holder := withRouteHolder(r.WithContext(ctx))
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		route := holder.label()
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", sw.status),
+		)
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sw.status))
+		}
+	})
+}
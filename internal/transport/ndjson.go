@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	ndjsonContentType = "application/x-ndjson"
+	// ndjsonFlushEvery bounds how many lines are held back before being pushed to the client
+	ndjsonFlushEvery = 100
+)
+
+// WantsNDJSON reports whether the client asked for a newline-delimited JSON stream
+func WantsNDJSON(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == ndjsonContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// streamList writes every event matching searchReq as one JSON object per line while it is read.
+// Failures before the first line get a regular error response; later ones end the stream
+// with an {"error": ...} line, since the status has already been sent.
+func (h *EventHandler) streamList(w http.ResponseWriter, r *http.Request, searchReq domain.SearchRequest) {
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	written := 0
+	now := time.Now()
+
+	err := h.service.StreamEvents(r.Context(), searchReq, func(event *domain.Event) error {
+		if written == 0 {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(http.StatusOK)
+		}
+		resp := service.NewEventResponse(event, now)
+		var line interface{} = resp
+		if len(searchReq.Fields) > 0 {
+			line = domain.ProjectEvent(&resp, searchReq.Fields)
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+		written++
+		if written%ndjsonFlushEvery == 0 {
+			_ = rc.Flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err != nil && written == 0:
+		respondError(w, r, err)
+	case err != nil:
+		logError(r.Context(), "event stream interrupted", err)
+		_ = enc.Encode(domain.APIResponse{Error: "stream interrupted", RequestID: w.Header().Get(RequestIDHeader)})
+	case written == 0:
+		// An empty export is still a valid, empty stream
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+}
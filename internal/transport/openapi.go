@@ -0,0 +1,705 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/buildinfo"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/graphql"
+	"bibently.com/backend/internal/openapi"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OpenAPIPath serves the OpenAPI document of the API
+const OpenAPIPath = "/openapi.json"
+
+const bearerAuth = "bearerAuth"
+
+// errorCodes are the values of APIResponse.Code
+var errorCodes = []string{
+	domain.CodeValidation, domain.CodeNotFound, domain.CodeConflict, domain.CodeForbidden,
+	domain.CodeDuplicateEvent, domain.CodeRateLimited, domain.CodeMethodNotAllowed, domain.CodeUnsupportedMedia,
+}
+
+// errorResponses name the shared error responses operations list by status
+var errorResponses = map[int]string{
+	http.StatusBadRequest:           "BadRequest",
+	http.StatusUnauthorized:         "Unauthorized",
+	http.StatusForbidden:            "Forbidden",
+	http.StatusNotFound:             "NotFound",
+	http.StatusConflict:             "Conflict",
+	http.StatusUnsupportedMediaType: "UnsupportedMediaType",
+	http.StatusTooManyRequests:      "TooManyRequests",
+	http.StatusInternalServerError:  "InternalError",
+}
+
+// apiOperation documents one route. Path uses OpenAPI templates ({id}) and no version prefix.
+type apiOperation struct {
+	Method, Path         string
+	ID                   string
+	Tag                  string
+	Summary, Description string
+	Params               []apiParam
+	Body                 *apiBody
+	Responses            []apiResponse
+	Errors               []int // statuses answered with the error model
+}
+
+type apiParam struct {
+	Name, In, Description string
+	Required              bool
+	Type                  any // Go value of the parameter's type
+	Enum                  []string
+}
+
+type apiBody struct {
+	Description string
+	Optional    bool
+	MediaType   string // default application/json
+	Type        any
+}
+
+type apiResponse struct {
+	Status      int
+	Description string
+	MediaType   string // default application/json
+	Type        any    // nil: no body
+	Envelope    envelope
+}
+
+// envelope is how a response wraps its Type
+type envelope int
+
+const (
+	envelopeData envelope = iota // {"data": T}
+	envelopePage                 // {"data": [T], "meta": Meta}
+	envelopeNone                 // T
+)
+
+func pathParam(name, description string) apiParam {
+	return apiParam{Name: name, In: "path", Description: description, Required: true, Type: ""}
+}
+
+func queryParam(name, description string, typ any) apiParam {
+	return apiParam{Name: name, In: "query", Description: description, Type: typ}
+}
+
+func enumParam(name, description string, values ...string) apiParam {
+	return apiParam{Name: name, In: "query", Description: description, Type: "", Enum: values}
+}
+
+func body(typ any, description string) *apiBody {
+	return &apiBody{Type: typ, Description: description}
+}
+
+func data(status int, description string, typ any) apiResponse {
+	return apiResponse{Status: status, Description: description, Type: typ}
+}
+
+func page(description string, item any) apiResponse {
+	return apiResponse{Status: http.StatusOK, Description: description, Type: item, Envelope: envelopePage}
+}
+
+func bare(status int, description, mediaType string, typ any) apiResponse {
+	return apiResponse{Status: status, Description: description, MediaType: mediaType, Type: typ, Envelope: envelopeNone}
+}
+
+func noBody(status int, description string) apiResponse {
+	return apiResponse{Status: status, Description: description}
+}
+
+var ifNoneMatch = apiParam{Name: "If-None-Match", In: "header", Description: "ETag of a cached copy", Type: ""}
+
+var fieldsParam = queryParam("fields", "Comma-separated sparse fieldset (e.g. id,event_name,start_time,price)", "")
+
+// eventFilterParams are the filters event lists and summaries share
+var eventFilterParams = []apiParam{
+	queryParam("event_name", "Filter by event name (case-insensitive prefix)", ""),
+	queryParam("city", "Filter by city (case-insensitive)", ""),
+	queryParam("type", "Filter by type", domain.EventType("")),
+	queryParam("organizer_id", "Filter by organizer Id", ""),
+	queryParam("min_price", "Minimum price", 0.0),
+	queryParam("max_price", "Maximum price", 0.0),
+	queryParam("start_date", "Start date (RFC3339)", ""),
+	queryParam("end_date", "End date (RFC3339)", ""),
+	enumParam("when", "Relative window; excludes start_date/end_date", "upcoming", "past", "today", "this_weekend"),
+	queryParam("tz", "IANA timezone for 'when' (e.g. Europe/Warsaw), defaults to UTC", ""),
+}
+
+// apiOperations documents every public route of NewRouter. Internal callbacks (tasks, cron),
+// metrics and profiles are left out.
+var apiOperations = []apiOperation{
+	// --- Events ---
+	{Method: http.MethodGet, Path: "/events", ID: "listEvents", Tag: "events", Summary: "List Events",
+		Description: "Events matching the filters, one page at a time. Accept: application/x-ndjson streams every match, one event per line, ignoring page_size and page_token; application/vnd.api+json returns a JSON:API document with pagination links. Only public events are listed, except to admins, so a page can hold fewer than page_size events; keep following next_page_token.",
+		Params: append(append([]apiParam{}, eventFilterParams...),
+			queryParam("page_size", "Page size (1-100, default 20)", 0),
+			queryParam("page_token", "Token of the page to read, from meta", ""),
+			enumParam("sort_key", "Sort key", domain.EventListSortKeys...),
+			enumParam("sort_dir", "Sort direction", "asc", "desc"),
+			queryParam("fields", "Comma-separated sparse fieldset (e.g. id,event_name,start_time,price); without it, deployments with EVENT_LIST_PROJECTION=card return the card fields listed in meta.fields", ""),
+			ifNoneMatch),
+		Responses: []apiResponse{
+			page("A page of events", domain.EventResponse{}),
+			bare(http.StatusOK, "Every matching event, one per line", ndjsonContentType, domain.EventResponse{}),
+			bare(http.StatusOK, "JSON:API document", jsonAPIContentType, map[string]any{}),
+			noBody(http.StatusNotModified, "Not Modified"),
+		},
+		Errors: []int{http.StatusBadRequest}},
+	{Method: http.MethodPost, Path: "/events", ID: "createEvent", Tag: "events", Summary: "Create Event",
+		Body:      body(domain.EventDTO{}, "Event data"),
+		Responses: []apiResponse{data(http.StatusCreated, "Id of the created event", "")},
+		Errors:    []int{http.StatusBadRequest, http.StatusConflict}},
+	{Method: http.MethodPost, Path: "/events/batch", ID: "batchCreateEvents", Tag: "events", Summary: "Batch Create Events",
+		Description: "Items are validated and stored independently: 201 when every item was created, 207 with per-item results when some failed.",
+		Body:        body(domain.BatchEventRequest{}, "Events to create"),
+		Responses: []apiResponse{
+			data(http.StatusCreated, "Every event was created", domain.BatchCreateResult{}),
+			data(http.StatusMultiStatus, "Some events failed", domain.BatchCreateResult{}),
+		},
+		Errors: []int{http.StatusBadRequest}},
+	{Method: http.MethodPost, Path: "/events/import", ID: "importEvents", Tag: "events", Summary: "Import Events from CSV",
+		Description: "Upload a CSV file (header: event_name,city,type,price,start_time,end_time; optional columns: description,visibility,latitude,longitude,event_url,image_url) and get a per-row report.",
+		Body:        &apiBody{Type: csvUpload, MediaType: "multipart/form-data", Description: "CSV file"},
+		Responses:   []apiResponse{data(http.StatusOK, "Per-row report", domain.ImportReport{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodPost, Path: "/events/import-async", ID: "importEventsAsync", Tag: "events", Summary: "Import Events from CSV (async)",
+		Description: "Same format as /events/import. Rows are stored in chunks by a background worker; poll GET /jobs/{id} for progress. Only routed when a task queue is configured.",
+		Body:        &apiBody{Type: csvUpload, MediaType: "multipart/form-data", Description: "CSV file"},
+		Responses:   []apiResponse{data(http.StatusAccepted, "The import job", domain.ImportJob{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/events/{id}", ID: "getEvent", Tag: "events", Summary: "Get Event",
+		Description: "Public and unlisted events are readable by anyone with the Id; private events only by their creator and admins, and are not found for everyone else.",
+		Params:      []apiParam{pathParam("id", "Event Id"), fieldsParam, ifNoneMatch},
+		Responses: []apiResponse{
+			data(http.StatusOK, "The event", domain.EventResponse{}),
+			bare(http.StatusOK, "JSON:API document", jsonAPIContentType, map[string]any{}),
+			noBody(http.StatusNotModified, "Not Modified"),
+		},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/events/{id}", ID: "updateEvent", Tag: "events", Summary: "Update Event",
+		Description: "Update specific fields of an event. Organizers may only update their own events.",
+		Params:      []apiParam{pathParam("id", "Event Id")},
+		Body:        body(domain.UpdateEventDTO{}, "Fields to update"),
+		Responses:   []apiResponse{data(http.StatusOK, "Id of the updated event", "")},
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/events/{id}", ID: "deleteEvent", Tag: "events", Summary: "Delete Event",
+		Params:    []apiParam{pathParam("id", "Event Id")},
+		Responses: []apiResponse{data(http.StatusOK, "Id of the deleted event", "")},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/events/slug/{slug}", ID: "getEventBySlug", Tag: "events", Summary: "Get Event by Slug",
+		Params: []apiParam{pathParam("slug", "Event slug (e.g. jazz-night-warsaw-2025)")},
+		Responses: []apiResponse{
+			data(http.StatusOK, "The event", domain.EventResponse{}),
+			bare(http.StatusOK, "JSON:API document", jsonAPIContentType, map[string]any{}),
+		},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/events/batch-get", ID: "getEvents", Tag: "events", Summary: "Get Events by Ids",
+		Description: "Events in the order requested; unknown Ids are left out. Up to 100 Ids, comma-separated or repeated.",
+		Params:      []apiParam{{Name: "ids", In: "query", Description: "Comma-separated event Ids", Required: true, Type: ""}},
+		Responses: []apiResponse{
+			data(http.StatusOK, "The events found", []domain.EventResponse{}),
+			bare(http.StatusOK, "JSON:API document", jsonAPIContentType, map[string]any{}),
+		},
+		Errors: []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/events/stats", ID: "eventStats", Tag: "events", Summary: "Event Statistics",
+		Description: "Count, average, minimum and maximum price of events per city or type.",
+		Params:      []apiParam{{Name: "group_by", In: "query", Description: "Group by field", Required: true, Type: "", Enum: []string{"city", "type"}}},
+		Responses:   []apiResponse{data(http.StatusOK, "One entry per group", []domain.EventStats{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/events/stats/summary", ID: "eventSummary", Tag: "events", Summary: "Event Summary",
+		Description: "Count, total and average price of the events matching the list filters, computed by aggregation queries.",
+		Params:      eventFilterParams,
+		Responses:   []apiResponse{data(http.StatusOK, "The summary", domain.EventSummary{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/events/price-buckets", ID: "priceBuckets", Tag: "events", Summary: "Price Histogram",
+		Description: "Count events in consecutive price ranges [bound_i, bound_i+1); the last range is open-ended.",
+		Params: []apiParam{
+			queryParam("city", "Filter by city", ""),
+			queryParam("bounds", "Comma-separated ascending bucket edges (default 0,25,50,100,200)", ""),
+		},
+		Responses: []apiResponse{data(http.StatusOK, "One entry per range", []domain.PriceBucket{})},
+		Errors:    []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/events/featured", ID: "listFeaturedEvents", Tag: "events", Summary: "List Featured Events",
+		Description: "Events whose promotion has not expired, sorted by start_time.",
+		Responses: []apiResponse{
+			data(http.StatusOK, "The featured events", []domain.EventResponse{}),
+			bare(http.StatusOK, "JSON:API document", jsonAPIContentType, map[string]any{}),
+		}},
+	{Method: http.MethodGet, Path: "/events/types", ID: "listEventTypes", Tag: "events", Summary: "List Event Types",
+		Description: "Event types this deployment accepts in type fields and filters. Operators can change them without a release, so clients should not hard-code them.",
+		Responses:   []apiResponse{data(http.StatusOK, "The accepted types", []domain.EventType{})}},
+	{Method: http.MethodPut, Path: "/events/{id}/featured", ID: "setEventFeatured", Tag: "events", Summary: "Toggle Featured",
+		Description: "Mark an event as featured until a given time, or remove the promotion.",
+		Params:      []apiParam{pathParam("id", "Event Id")},
+		Body:        body(domain.FeatureEventDTO{}, "Promotion settings"),
+		Responses:   []apiResponse{data(http.StatusOK, "Id of the event", "")},
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/events/{id}/history", ID: "eventHistory", Tag: "events", Summary: "Event History",
+		Description: "Create, update and delete revisions of an event, newest first, with the acting user and field diff.",
+		Params:      []apiParam{pathParam("id", "Event Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "The revisions", []domain.EventRevision{})}},
+
+	// --- Ticket tiers ---
+	{Method: http.MethodGet, Path: "/events/{id}/tiers", ID: "listTicketTiers", Tag: "tiers", Summary: "List Ticket Tiers",
+		Description: "Ticket tiers of an event, cheapest first.",
+		Params:      []apiParam{pathParam("id", "Event Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "The tiers", []domain.TicketTier{})},
+		Errors:      []int{http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/events/{id}/tiers", ID: "createTicketTier", Tag: "tiers", Summary: "Create Ticket Tier",
+		Params:    []apiParam{pathParam("id", "Event Id")},
+		Body:      body(domain.TicketTierDTO{}, "Tier data"),
+		Responses: []apiResponse{data(http.StatusCreated, "Id of the created tier", "")},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/events/{id}/tiers/{tierId}", ID: "updateTicketTier", Tag: "tiers", Summary: "Update Ticket Tier",
+		Params:    []apiParam{pathParam("id", "Event Id"), pathParam("tierId", "Tier Id")},
+		Body:      body(domain.TicketTierDTO{}, "Tier data"),
+		Responses: []apiResponse{data(http.StatusOK, "Id of the updated tier", "")},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound}},
+
+	// --- RSVPs ---
+	{Method: http.MethodPost, Path: "/events/{id}/rsvp", ID: "rsvp", Tag: "rsvp", Summary: "RSVP to Event",
+		Description: "Register the authenticated user for an event, capacity permitting. 409 when the event is full or the user is already registered.",
+		Params:      []apiParam{pathParam("id", "Event Id")},
+		Responses:   []apiResponse{data(http.StatusCreated, "The registration", domain.RSVP{})},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: http.MethodGet, Path: "/events/{id}/attendees", ID: "listAttendees", Tag: "rsvp", Summary: "List Attendees",
+		Params:    []apiParam{pathParam("id", "Event Id")},
+		Responses: []apiResponse{data(http.StatusOK, "The registrations", []domain.RSVP{})},
+		Errors:    []int{http.StatusNotFound}},
+
+	// --- Organizers ---
+	{Method: http.MethodGet, Path: "/organizers", ID: "listOrganizers", Tag: "organizers", Summary: "List Organizers",
+		Description: "Every organizer, sorted by name.",
+		Responses:   []apiResponse{data(http.StatusOK, "The organizers", []domain.Organizer{})}},
+	{Method: http.MethodPost, Path: "/organizers", ID: "createOrganizer", Tag: "organizers", Summary: "Create Organizer",
+		Body:      body(domain.OrganizerDTO{}, "Organizer data"),
+		Responses: []apiResponse{data(http.StatusCreated, "Id of the created organizer", "")},
+		Errors:    []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/organizers/{id}", ID: "getOrganizer", Tag: "organizers", Summary: "Get Organizer",
+		Params:    []apiParam{pathParam("id", "Organizer Id")},
+		Responses: []apiResponse{data(http.StatusOK, "The organizer", domain.Organizer{})},
+		Errors:    []int{http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/organizers/{id}", ID: "updateOrganizer", Tag: "organizers", Summary: "Update Organizer",
+		Params:    []apiParam{pathParam("id", "Organizer Id")},
+		Body:      body(domain.UpdateOrganizerDTO{}, "Fields to update"),
+		Responses: []apiResponse{data(http.StatusOK, "Id of the updated organizer", "")},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/organizers/{id}", ID: "deleteOrganizer", Tag: "organizers", Summary: "Delete Organizer",
+		Params:    []apiParam{pathParam("id", "Organizer Id")},
+		Responses: []apiResponse{data(http.StatusOK, "Id of the deleted organizer", "")},
+		Errors:    []int{http.StatusBadRequest}},
+
+	// --- Users ---
+	{Method: http.MethodGet, Path: "/users/me", ID: "currentUser", Tag: "users", Summary: "Current User",
+		Description: "UID, email, roles and custom claims from the verified token, plus the users/{uid} profile document if present.",
+		Responses:   []apiResponse{data(http.StatusOK, "The caller", domain.CurrentUser{})}},
+	{Method: http.MethodDelete, Path: "/users/{uid}/data", ID: "deleteUserData", Tag: "users", Summary: "Delete User Data",
+		Description: `Deletes the user's tracking events, RSVPs and favorites and reports how many of each were removed. "me" stands for the caller; only admins may erase other users.`,
+		Params:      []apiParam{pathParam("uid", "User UID or me")},
+		Responses:   []apiResponse{data(http.StatusOK, "What was deleted", domain.DataDeletionReport{})}},
+	{Method: http.MethodGet, Path: "/users/me/favorites", ID: "listFavorites", Tag: "favorites", Summary: "List Favorites",
+		Description: "Events bookmarked by the caller, newest first.",
+		Responses:   []apiResponse{data(http.StatusOK, "The favorites", []domain.Favorite{})}},
+	{Method: http.MethodPost, Path: "/users/me/favorites/{eventId}", ID: "addFavorite", Tag: "favorites", Summary: "Add Favorite",
+		Description: "Bookmark an event (idempotent).",
+		Params:      []apiParam{pathParam("eventId", "Event Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "Id of the event", "")},
+		Errors:      []int{http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/users/me/favorites/{eventId}", ID: "removeFavorite", Tag: "favorites", Summary: "Remove Favorite",
+		Description: "Remove a bookmarked event (idempotent).",
+		Params:      []apiParam{pathParam("eventId", "Event Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "Id of the event", "")}},
+
+	// --- Subscriptions ---
+	{Method: http.MethodGet, Path: "/users/me/subscription", ID: "getSubscription", Tag: "subscriptions", Summary: "Get Subscription",
+		Description: "Cities the caller follows and whether new events are emailed. Only routed when email notifications are configured.",
+		Responses:   []apiResponse{data(http.StatusOK, "The subscription", domain.Subscription{})},
+		Errors:      []int{http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/users/me/subscription", ID: "updateSubscription", Tag: "subscriptions", Summary: "Update Subscription",
+		Description: "Follow cities and opt in or out of email about new events there. Opting in requires a verified email on the account.",
+		Body:        body(domain.SubscriptionDTO{}, "Preferences"),
+		Responses:   []apiResponse{data(http.StatusOK, "The subscription", domain.Subscription{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodDelete, Path: "/users/me/subscription", ID: "deleteSubscription", Tag: "subscriptions", Summary: "Delete Subscription",
+		Description: "Unfollow all cities and stop email notifications (idempotent).",
+		Responses:   []apiResponse{data(http.StatusOK, "The caller's UID", "")}},
+	{Method: http.MethodGet, Path: "/users/me/subscriptions", ID: "listPushSubscriptions", Tag: "subscriptions", Summary: "List Push Subscriptions",
+		Description: "Cities (and event types) the caller's devices receive pushes for.",
+		Responses:   []apiResponse{data(http.StatusOK, "The push subscriptions", []domain.PushSubscription{})}},
+	{Method: http.MethodPost, Path: "/users/me/subscriptions", ID: "subscribePush", Tag: "subscriptions", Summary: "Subscribe to Push Notifications",
+		Description: "Send an FCM push to the device (token) when an event is created in city; type narrows it to one event type. Idempotent.",
+		Body:        body(domain.PushSubscriptionDTO{}, "Device token, city and optional type"),
+		Responses:   []apiResponse{data(http.StatusCreated, "The push subscription", domain.PushSubscription{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodDelete, Path: "/users/me/subscriptions/{id}", ID: "unsubscribePush", Tag: "subscriptions", Summary: "Unsubscribe from Push Notifications",
+		Params:    []apiParam{pathParam("id", "Subscription Id")},
+		Responses: []apiResponse{data(http.StatusOK, "Id of the subscription", "")},
+		Errors:    []int{http.StatusNotFound}},
+
+	// --- Tracking ---
+	{Method: http.MethodPost, Path: "/tracking", ID: "track", Tag: "tracking", Summary: "Create Tracking Event",
+		Body: body(domain.TrackingEventDTO{}, "Tracking event"),
+		Responses: []apiResponse{
+			data(http.StatusCreated, "Id of the tracking event", ""),
+			noBody(http.StatusNoContent, "Not stored: the browser opted out with DNT or Sec-GPC"),
+		},
+		Errors: []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/tracking", ID: "listTracking", Tag: "tracking", Summary: "List Tracking Events",
+		Responses: []apiResponse{data(http.StatusOK, "The tracking events", []domain.TrackingEventResponse{})}},
+	{Method: http.MethodDelete, Path: "/tracking", ID: "purgeTracking", Tag: "tracking", Summary: "Delete Tracking Events",
+		Description: "Remove tracking events older than a cutoff and/or of one action, and take them out of the stats. At least one filter is required. One call deletes at most 5000 events; repeat it while remaining is true.",
+		Params: []apiParam{
+			queryParam("older_than", "Cutoff: an RFC3339 timestamp or an age such as 720h", ""),
+			queryParam("action", "Only events with this action", ""),
+		},
+		Responses: []apiResponse{data(http.StatusOK, "What was deleted", domain.PurgeResult{})},
+		Errors:    []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/tracking/stats", ID: "trackingStats", Tag: "tracking", Summary: "Tracking Statistics",
+		Description: "Count tracking events per action in each UTC hour or day of a window, from rollups maintained on write; empty buckets are included.",
+		Params: []apiParam{
+			enumParam("group_by", "Group by field (default action)", "action"),
+			enumParam("interval", "Bucket size (default day)", "hour", "day"),
+			queryParam("from", "Window start (RFC3339), defaults to 7 days (hour: 24 hours) before to", ""),
+			queryParam("to", "Window end (RFC3339), defaults to now", ""),
+		},
+		Responses: []apiResponse{data(http.StatusOK, "One entry per bucket", []domain.TrackingStats{})},
+		Errors:    []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/tracking/sessions/{id}", ID: "trackingSession", Tag: "tracking", Summary: "Tracking Session",
+		Description: "Tracking events of a session, oldest first.",
+		Params:      []apiParam{pathParam("id", "Session Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "The tracking events", []domain.TrackingEventResponse{})}},
+	{Method: http.MethodDelete, Path: "/tracking/{id}", ID: "deleteTracking", Tag: "tracking", Summary: "Delete Tracking Event",
+		Description: "Remove a tracking event, e.g. test traffic, and take it out of the stats.",
+		Params:      []apiParam{pathParam("id", "Tracking event Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "Id of the deleted tracking event", "")},
+		Errors:      []int{http.StatusNotFound}},
+
+	// --- Jobs ---
+	{Method: http.MethodGet, Path: "/jobs/{id}", ID: "getJob", Tag: "jobs", Summary: "Get Job Status",
+		Description: "Status, counters and row errors of an async import.",
+		Params:      []apiParam{pathParam("id", "Job Id")},
+		Responses:   []apiResponse{data(http.StatusOK, "The job", domain.ImportJob{})},
+		Errors:      []int{http.StatusNotFound}},
+
+	// --- Admin ---
+	{Method: http.MethodPost, Path: "/admin/archive-past-events", ID: "archivePastEvents", Tag: "admin", Summary: "Archive Past Events",
+		Description: `Move events that ended more than older_than_days ago (default 30) to events_archive. Repeat while "remaining" is true.`,
+		Params:      []apiParam{queryParam("older_than_days", "Minimum age in days since the event ended", 0)},
+		Responses:   []apiResponse{data(http.StatusOK, "What was archived", domain.ArchiveResult{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/admin/audit-logs", ID: "listAuditLogs", Tag: "admin", Summary: "List Audit Logs",
+		Description: "Write operations with actor, target, outcome and (for events) old-vs-new field values.",
+		Params: []apiParam{
+			queryParam("actor", "Filter by actor UID", ""),
+			queryParam("resource", "Filter by resource (e.g. events, organizers)", ""),
+			queryParam("resource_id", "Filter by resource Id", ""),
+			queryParam("limit", "Maximum number of entries (default 50, max 500)", 0),
+		},
+		Responses: []apiResponse{data(http.StatusOK, "The entries, newest first", []domain.AuditEntry{})},
+		Errors:    []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/admin/log-level", ID: "getLogLevel", Tag: "admin", Summary: "Get Log Level",
+		Description: "The minimum level of the logs this instance writes (LOG_LEVEL unless changed since the instance started).",
+		Responses:   []apiResponse{data(http.StatusOK, "The level", domain.LogLevel{})}},
+	{Method: http.MethodPut, Path: "/admin/log-level", ID: "setLogLevel", Tag: "admin", Summary: "Set Log Level",
+		Description: "Changes the minimum log level without a redeploy. It applies to the instance serving the request until it stops; new instances start at LOG_LEVEL.",
+		Body:        body(domain.LogLevel{}, "debug, info, warn or error"),
+		Responses:   []apiResponse{data(http.StatusOK, "The level", domain.LogLevel{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/admin/tracking/stream", ID: "trackingFeed", Tag: "admin", Summary: "Tracking Feed",
+		Description: `Server-sent events: one "tracking" event per tracking event stored after the connection opened, across all instances. Idle streams get a comment line every 25s; EventSource reconnects when the stream ends.`,
+		Responses:   []apiResponse{bare(http.StatusOK, "Stream of tracking events", eventStreamContentType, domain.TrackingEventResponse{})}},
+	{Method: http.MethodPost, Path: "/admin/backups", ID: "startBackup", Tag: "admin", Summary: "Start Backup",
+		Description: "Starts a Firestore managed export to a new folder of BACKUP_BUCKET and returns the operation; poll it until done. Only routed when BACKUP_BUCKET is set.",
+		Body:        &apiBody{Type: domain.BackupRequest{}, Description: "Collections to export (default: all)", Optional: true},
+		Responses:   []apiResponse{data(http.StatusAccepted, "The export operation", domain.BackupOperation{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodPost, Path: "/admin/backups/restore", ID: "restoreBackup", Tag: "admin", Summary: "Restore Backup",
+		Description: "Starts a Firestore managed import of an export in BACKUP_BUCKET. Imported documents replace existing documents with the same Id. Only routed when BACKUP_BUCKET is set.",
+		Body:        body(domain.RestoreRequest{}, "Export to import and collections to restore (default: all exported)"),
+		Responses:   []apiResponse{data(http.StatusAccepted, "The import operation", domain.BackupOperation{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodGet, Path: "/admin/backups/operations/{id}", ID: "getBackupOperation", Tag: "admin", Summary: "Get Backup Operation",
+		Params:    []apiParam{pathParam("id", "Operation Id")},
+		Responses: []apiResponse{data(http.StatusOK, "The operation", domain.BackupOperation{})},
+		Errors:    []int{http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/admin/backups/dump", ID: "dumpDatabase", Tag: "admin", Summary: "Dump Database",
+		Description: "Streams every event and tracking document as newline-delimited records, which POST /admin/backups/dump restores. Meant for small databases and moving data between environments; use POST /admin/backups for full backups.",
+		Params:      []apiParam{queryParam("collections", "Comma-separated collections (events, tracking); default both", "")},
+		Responses:   []apiResponse{bare(http.StatusOK, "One record per line", ndjsonContentType, domain.BackupRecord{})},
+		Errors:      []int{http.StatusBadRequest}},
+	{Method: http.MethodPost, Path: "/admin/backups/dump", ID: "restoreDump", Tag: "admin", Summary: "Restore Dump",
+		Description: "Restores a dump produced by GET /admin/backups/dump. on_conflict decides what happens to records whose document exists: skip it (default), overwrite it, or fail, which stops after the current batch of 500. Invalid lines are reported by line number; restored tracking is not added to the stats rollups.",
+		Params:      []apiParam{enumParam("on_conflict", "What to do with records whose document exists", string(domain.RestoreSkip), string(domain.RestoreOverwrite), string(domain.RestoreFail))},
+		Body:        &apiBody{Type: domain.BackupRecord{}, MediaType: ndjsonContentType, Description: "One record per line"},
+		Responses:   []apiResponse{data(http.StatusOK, "What was restored", domain.RestoreResult{})},
+		Errors:      []int{http.StatusBadRequest}},
+
+	// --- Development ---
+	{Method: http.MethodPost, Path: "/dev/seed", ID: "seed", Tag: "dev", Summary: "Seed Fake Data",
+		Description: "Development only. Generates events starting between from and to (default: the next 90 days) in the given cities, with tracking from the last 7 days. The same seed generates the same data, so repeating a request overwrites it. Large requests may outlast REQUEST_TIMEOUT; use cmd/seed for those.",
+		Body:        &apiBody{Type: domain.SeedRequest{}, Description: "Counts, cities, date range and seed; every field is optional", Optional: true},
+		Responses:   []apiResponse{data(http.StatusCreated, "What was generated", domain.SeedResult{})},
+		Errors:      []int{http.StatusBadRequest}},
+
+	// --- Webhooks ---
+	{Method: http.MethodPost, Path: "/webhooks/events", ID: "partnerEventWebhook", Tag: "webhooks", Summary: "Partner Event Webhook",
+		Description: `Create an event on behalf of a partner system. The body must be signed: X-Signature-Timestamp holds the Unix seconds it was signed at (within 5 minutes of the server clock) and X-Signature "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<raw body>" with the shared secret.`,
+		Params: []apiParam{
+			{Name: "X-Signature-Timestamp", In: "header", Description: "Unix time the request was signed at", Required: true, Type: 0},
+			{Name: "X-Signature", In: "header", Description: "sha256=<hex HMAC>", Required: true, Type: ""},
+		},
+		Body:      body(domain.EventDTO{}, "Event data"),
+		Responses: []apiResponse{data(http.StatusCreated, "Id of the created event", "")},
+		Errors:    []int{http.StatusBadRequest}},
+
+	// --- GraphQL ---
+	{Method: http.MethodPost, Path: "/graphql", ID: "graphqlQuery", Tag: "graphql", Summary: "GraphQL Query",
+		Description: "Executes a read-only GraphQL query. Partial results come back with 200 and an errors list; requests that cannot run at all, including queries over the cost or alias limit, get 400.",
+		Body:        body(graphql.Request{}, "Query, operation name and variables"),
+		Responses: []apiResponse{
+			bare(http.StatusOK, "Data and errors of the query", "", graphql.Response{}),
+			bare(http.StatusBadRequest, "The query could not run", "", graphql.Response{}),
+		}},
+	{Method: http.MethodGet, Path: "/graphql", ID: "graphqlQueryGet", Tag: "graphql", Summary: "GraphQL Query (GET)",
+		Params: []apiParam{
+			{Name: "query", In: "query", Description: "GraphQL document", Required: true, Type: ""},
+			queryParam("operationName", "Operation to run", ""),
+			queryParam("variables", "JSON object of variables", ""),
+		},
+		Responses: []apiResponse{
+			bare(http.StatusOK, "Data and errors of the query", "", graphql.Response{}),
+			bare(http.StatusBadRequest, "The query could not run", "", graphql.Response{}),
+		}},
+	{Method: http.MethodGet, Path: "/graphql/schema.graphql", ID: "graphqlSchema", Tag: "graphql", Summary: "GraphQL Schema",
+		Description: "The GraphQL schema in schema definition language.",
+		Responses:   []apiResponse{bare(http.StatusOK, "The schema", "text/plain", "")}},
+
+	// --- Health & build info (outside the versioned API) ---
+	{Method: http.MethodGet, Path: "/healthz", ID: "liveness", Tag: "health", Summary: "Liveness",
+		Responses: []apiResponse{bare(http.StatusOK, "The instance is up", "", domain.HealthReport{})}},
+	{Method: http.MethodGet, Path: "/readyz", ID: "readiness", Tag: "health", Summary: "Readiness",
+		Description: "Probes dependencies (Firestore) with a short deadline. 503 when any probe fails.",
+		Responses: []apiResponse{
+			bare(http.StatusOK, "Every probe passed", "", domain.HealthReport{}),
+			bare(http.StatusServiceUnavailable, "A probe failed", "", domain.HealthReport{}),
+		}},
+	{Method: http.MethodGet, Path: "/warmup", ID: "warmup", Tag: "health", Summary: "Warm-up",
+		Description: "Initializes the instance and primes dependency connections with trivial reads. 503 when any probe fails.",
+		Responses: []apiResponse{
+			bare(http.StatusOK, "Every probe passed", "", domain.HealthReport{}),
+			bare(http.StatusServiceUnavailable, "A probe failed", "", domain.HealthReport{}),
+		}},
+	{Method: http.MethodGet, Path: "/version", ID: "version", Tag: "health", Summary: "Version",
+		Description: "Git SHA, build time and Go version of the deployed binary.",
+		Responses:   []apiResponse{bare(http.StatusOK, "Build info", "", buildinfo.Info{})}},
+	{Method: http.MethodGet, Path: OpenAPIPath, ID: "openapi", Tag: "health", Summary: "OpenAPI Document",
+		Description: "This document. Admin-only with API_DOCS=admin and not served with API_DOCS=off.",
+		Responses:   []apiResponse{bare(http.StatusOK, "OpenAPI 3.1 document", "", map[string]any{})}},
+}
+
+// csvUpload is the multipart form of the CSV imports, described inline; in OpenAPI 3.1 a file part
+// is a plain string
+var csvUpload = struct {
+	File string `json:"file" validate:"required"`
+}{}
+
+var pathTemplate = regexp.MustCompile(`\{[^}]+\}`)
+
+// OpenAPISpec builds the OpenAPI 3.1 document of the API. Security follows DefaultRoutePolicies:
+// public routes accept an optional bearer token and the others name the role they need in
+// x-required-role.
+func OpenAPISpec() *openapi.Document {
+	schemas := openapi.NewSchemas()
+	openapi.Named(schemas, "One of the event types configured for the deployment, as listed by GET /events/types", domain.TypeConcert)
+
+	errorSchema := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"error":      {Type: "string", Description: "Human-readable message"},
+			"code":       {Type: "string", Description: "Machine-readable code", Enum: stringsToAny(errorCodes)},
+			"request_id": {Type: "string", Description: "Id of the request in the logs"},
+			"data":       {Description: "Details, e.g. the Id of the existing event on duplicate_event"},
+		},
+		Required: []string{"error"},
+	}
+	components := openapi.Components{
+		Responses: make(map[string]*openapi.Response),
+		SecuritySchemes: map[string]*openapi.SecurityScheme{
+			bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Firebase ID token"},
+		},
+	}
+	for status, name := range errorResponses {
+		components.Responses[name] = &openapi.Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*openapi.MediaType{"application/json": {Schema: openapi.Ref("Error")}},
+		}
+	}
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "Bibently API",
+			Version:     strings.TrimPrefix(APIVersionPrefix, "/"),
+			Description: "Events, tracking and the tools around them. Responses wrap their payload in data; errors carry error, code and request_id.",
+		},
+		Servers: []openapi.Server{{URL: APIVersionPrefix}},
+		Paths:   make(map[string]*openapi.PathItem),
+	}
+	tags := make(map[string]bool)
+	for _, op := range apiOperations {
+		item := doc.Paths[op.Path]
+		if item == nil {
+			item = &openapi.PathItem{}
+			if isUnversioned(op.Path) {
+				item.Servers = []openapi.Server{{URL: "/"}}
+			}
+			doc.Paths[op.Path] = item
+		}
+		operation := buildOperation(schemas, op)
+		switch op.Method {
+		case http.MethodGet:
+			item.Get = operation
+		case http.MethodPost:
+			item.Post = operation
+		case http.MethodPut:
+			item.Put = operation
+		case http.MethodDelete:
+			item.Delete = operation
+		case http.MethodPatch:
+			item.Patch = operation
+		}
+		if !tags[op.Tag] {
+			tags[op.Tag] = true
+			doc.Tags = append(doc.Tags, openapi.Tag{Name: op.Tag})
+		}
+	}
+
+	components.Schemas = schemas.Components()
+	components.Schemas["Error"] = errorSchema
+	doc.Components = components
+	return doc
+}
+
+func buildOperation(schemas *openapi.Schemas, op apiOperation) *openapi.Operation {
+	operation := &openapi.Operation{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        []string{op.Tag},
+		Responses:   make(map[string]*openapi.Response),
+	}
+	for _, p := range op.Params {
+		schema := schemas.For(p.Type)
+		if len(p.Enum) > 0 {
+			schema.Enum = stringsToAny(p.Enum)
+		}
+		operation.Parameters = append(operation.Parameters, &openapi.Parameter{
+			Name: p.Name, In: p.In, Description: p.Description, Required: p.Required, Schema: schema,
+		})
+	}
+	if b := op.Body; b != nil {
+		operation.RequestBody = &openapi.RequestBody{
+			Description: b.Description,
+			Required:    !b.Optional,
+			Content:     map[string]*openapi.MediaType{mediaTypeOr(b.MediaType): {Schema: schemas.For(b.Type)}},
+		}
+	}
+
+	for _, resp := range op.Responses {
+		status := strconv.Itoa(resp.Status)
+		r := operation.Responses[status]
+		if r == nil {
+			r = &openapi.Response{Description: resp.Description}
+			operation.Responses[status] = r
+		}
+		if resp.Type == nil {
+			continue
+		}
+		if r.Content == nil {
+			r.Content = make(map[string]*openapi.MediaType)
+		}
+		r.Content[mediaTypeOr(resp.MediaType)] = &openapi.MediaType{Schema: responseSchema(schemas, resp)}
+	}
+
+	// Security and the errors every route can answer with come from its policy
+	statuses := append([]int{}, op.Errors...)
+	policy := matchPolicy(DefaultRoutePolicies, &http.Request{Method: op.Method, URL: &url.URL{Path: pathTemplate.ReplaceAllString(op.Path, "x")}})
+	switch {
+	case policy == nil || !policy.Public:
+		operation.Security = []openapi.SecurityRequirement{{bearerAuth: {}}}
+		operation.RequiredRole = domain.RoleAdmin
+		if policy != nil {
+			operation.RequiredRole = policy.Role
+		}
+		statuses = append(statuses, http.StatusUnauthorized, http.StatusForbidden)
+	default:
+		// Optional: a valid token still identifies the caller
+		operation.Security = []openapi.SecurityRequirement{{}, {bearerAuth: {}}}
+	}
+	if op.Body != nil {
+		statuses = append(statuses, http.StatusUnsupportedMediaType)
+	}
+	statuses = append(statuses, http.StatusTooManyRequests, http.StatusInternalServerError)
+	for _, status := range statuses {
+		key := strconv.Itoa(status)
+		if _, ok := operation.Responses[key]; !ok {
+			operation.Responses[key] = &openapi.Response{Ref: "#/components/responses/" + errorResponses[status]}
+		}
+	}
+	return operation
+}
+
+func responseSchema(schemas *openapi.Schemas, resp apiResponse) *openapi.Schema {
+	schema := schemas.For(resp.Type)
+	switch resp.Envelope {
+	case envelopeData:
+		return &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{"data": schema}, Required: []string{"data"}}
+	case envelopePage:
+		return &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"data": {Type: "array", Items: schema},
+				"meta": schemas.For(domain.Meta{}),
+			},
+			Required: []string{"data", "meta"},
+		}
+	}
+	return schema
+}
+
+func mediaTypeOr(mediaType string) string {
+	if mediaType == "" {
+		return "application/json"
+	}
+	return mediaType
+}
+
+func stringsToAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// OpenAPIHandler serves the OpenAPI document, built once
+func OpenAPIHandler() http.Handler {
+	spec := sync.OnceValues(func() ([]byte, error) {
+		return json.MarshalIndent(OpenAPISpec(), "", "  ")
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := spec()
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	})
+}
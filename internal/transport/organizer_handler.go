@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+type OrganizerHandler struct {
+	service service.OrganizerService
+	mux     *http.ServeMux
+}
+
+func NewOrganizerHandler(svc service.OrganizerService) *OrganizerHandler {
+	h := &OrganizerHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *OrganizerHandler) routes() {
+	h.mux.HandleFunc("GET /{$}", h.handleList)
+	h.mux.HandleFunc("POST /{$}", h.handleCreate)
+	h.mux.HandleFunc("GET /{id}", h.handleGet)
+	h.mux.HandleFunc("PUT /{id}", h.handleUpdate)
+	h.mux.HandleFunc("DELETE /{id}", h.handleDelete)
+}
+
+func (h *OrganizerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleCreate creates a new organizer
+func (h *OrganizerHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var dto domain.OrganizerDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	organizer := domain.OrganizerDTOToModel(&dto)
+	if err := h.service.CreateOrganizer(r.Context(), organizer); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: organizer.Id})
+}
+
+// handleList lists all organizers
+func (h *OrganizerHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	organizers, err := h.service.ListOrganizers(r.Context())
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: organizers})
+}
+
+// handleGet retrieves a single organizer
+func (h *OrganizerHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	organizer, err := h.service.GetOrganizer(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: organizer})
+}
+
+// handleUpdate updates an existing organizer
+func (h *OrganizerHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var dto domain.UpdateOrganizerDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	// Only fields that were actually present (non-nil) are updated
+	updates := make(map[string]interface{})
+	if dto.Name != nil {
+		updates["name"] = *dto.Name
+	}
+	if dto.Email != nil {
+		updates["email"] = *dto.Email
+	}
+	if dto.Website != nil {
+		updates["website"] = *dto.Website
+	}
+	if dto.Description != nil {
+		updates["description"] = *dto.Description
+	}
+	if len(updates) == 0 {
+		respondError(w, r, domain.ErrValidation("No valid fields provided for update"))
+		return
+	}
+
+	if err := h.service.UpdateOrganizer(r.Context(), r.PathValue("id"), updates); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Updated successfully"})
+}
+
+// handleDelete deletes an organizer
+func (h *OrganizerHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteOrganizer(r.Context(), r.PathValue("id")); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Deleted successfully"})
+}
@@ -0,0 +1,33 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// pprofDefaultSeconds replaces the 30 s default of CPU profiles and execution traces, which
+// would outlast REQUEST_TIMEOUT; a longer ?seconds= must still fit within it
+const pprofDefaultSeconds = "10"
+
+// PprofHandler serves the net/http/pprof profiles under /debug/pprof/, e.g.
+// `go tool pprof -http=: -H "Authorization: Bearer $TOKEN" $URL/debug/pprof/heap`.
+// Profiles cover the instance that answers, not the whole deployment.
+func PprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", withDefaultSeconds(pprof.Profile))
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", withDefaultSeconds(pprof.Trace))
+	return mux
+}
+
+func withDefaultSeconds(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("seconds") == "" {
+			q.Set("seconds", pprofDefaultSeconds)
+			r.URL.RawQuery = q.Encode()
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// PushSubscriptionHandler serves the authenticated user's push subscriptions under /users/me/subscriptions
+type PushSubscriptionHandler struct {
+	service service.PushSubscriptionService
+	mux     *http.ServeMux
+}
+
+func NewPushSubscriptionHandler(svc service.PushSubscriptionService) *PushSubscriptionHandler {
+	h := &PushSubscriptionHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *PushSubscriptionHandler) routes() {
+	h.mux.HandleFunc("GET /users/me/subscriptions", h.handleList)
+	h.mux.HandleFunc("POST /users/me/subscriptions", h.handleSubscribe)
+	h.mux.HandleFunc("DELETE /users/me/subscriptions/{id}", h.handleUnsubscribe)
+}
+
+func (h *PushSubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleList lists the caller's push subscriptions
+func (h *PushSubscriptionHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	subs, err := h.service.ListSubscriptions(r.Context(), user.UID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: subs})
+}
+
+// handleSubscribe registers a device for pushes about new events in a city
+func (h *PushSubscriptionHandler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	var dto domain.PushSubscriptionDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	sub, err := h.service.Subscribe(r.Context(), user.UID, &dto)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: sub})
+}
+
+// handleUnsubscribe removes one of the caller's push subscriptions
+func (h *PushSubscriptionHandler) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	if err := h.service.Unsubscribe(r.Context(), user.UID, r.PathValue("id")); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Unsubscribed"})
+}
@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// RoleUser is satisfied by any authenticated caller
+const RoleUser = "user"
+
+// RoutePolicy declares who may call the routes matching Methods and Path.
+// Path is matched segment by segment: "*" matches exactly one segment and a trailing "**"
+// matches any remainder, including nothing ("/events/**" matches "/events" and "/events/1/tiers").
+type RoutePolicy struct {
+	Methods []string `json:"methods,omitempty"` // empty matches every method; GET also matches HEAD
+	Path    string   `json:"path"`
+	Role    string   `json:"role,omitempty"`   // RoleUser, domain.RoleOrganizer or domain.RoleAdmin
+	Public  bool     `json:"public,omitempty"` // no token required; a valid token still identifies the caller
+}
+
+// DefaultRoutePolicies is the built-in table. The first matching entry wins; requests matching
+// nothing are admin-only, so a new resource is locked down until it is given an entry.
+var DefaultRoutePolicies = []RoutePolicy{
+	// Cloud Tasks callbacks carry a Google OIDC token, not a Firebase one; the worker verifies it
+	{Methods: []string{http.MethodPost}, Path: "/internal/tasks/**", Public: true},
+	// Cloud Scheduler jobs are verified the same way by the cron handler
+	{Methods: []string{http.MethodPost}, Path: "/internal/cron/**", Public: true},
+	// Uptime monitoring, Cloud Run probes, build info and the API description
+	{Methods: []string{http.MethodGet}, Path: "/healthz", Public: true},
+	{Methods: []string{http.MethodGet}, Path: "/readyz", Public: true},
+	{Methods: []string{http.MethodGet}, Path: "/warmup", Public: true},
+	{Methods: []string{http.MethodGet}, Path: "/version", Public: true},
+	// API_DOCS=admin puts the document behind the admin role (APIDocsPolicies); the Swagger UI page
+	// holds no API details and asks for a token then
+	{Methods: []string{http.MethodGet}, Path: "/openapi.json", Public: true},
+	{Methods: []string{http.MethodGet}, Path: "/swagger/**", Public: true},
+
+	// Partner pushes are authenticated by WithHMACSignature
+	{Methods: []string{http.MethodPost}, Path: "/webhooks/**", Public: true},
+
+	// Personal data, audit history, job reports and admin tools
+	{Methods: []string{http.MethodGet}, Path: "/jobs/**", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/admin/**", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/metrics", Role: domain.RoleAdmin},
+	// Development tools write fake data
+	{Path: "/dev/**", Role: domain.RoleAdmin},
+	// Profiles expose memory contents and cost CPU; symbol lookups are also POSTed
+	{Path: "/debug/pprof/**", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/tracking/stats", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/tracking/sessions/*", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodDelete}, Path: "/tracking/**", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/events/*/attendees", Role: domain.RoleAdmin},
+	{Methods: []string{http.MethodGet}, Path: "/events/*/history", Role: domain.RoleAdmin},
+
+	// Self-service writes scoped to the caller
+	{Methods: []string{http.MethodPost}, Path: "/events/*/rsvp", Role: RoleUser},
+	{Methods: []string{http.MethodPost, http.MethodDelete}, Path: "/users/me/**", Role: RoleUser},
+	// Right to erasure: the user service lets only admins erase someone else's data
+	{Methods: []string{http.MethodDelete}, Path: "/users/*/data", Role: RoleUser},
+
+	// Organizers manage their own events; ownership is enforced by EventService
+	{Methods: []string{http.MethodPost}, Path: "/events", Role: domain.RoleOrganizer},
+	{Methods: []string{http.MethodPut, http.MethodDelete}, Path: "/events/*", Role: domain.RoleOrganizer},
+
+	// Public catalog
+	{Methods: []string{http.MethodGet}, Path: "/events/**", Public: true},
+	{Methods: []string{http.MethodGet}, Path: "/organizers/**", Public: true},
+	// GraphQL is read-only; resolvers that need a user check for one themselves
+	{Methods: []string{http.MethodGet, http.MethodPost}, Path: "/graphql/**", Public: true},
+
+	// Remaining reads need a signed-in user, remaining writes an admin
+	{Methods: []string{http.MethodGet}, Path: "/**", Role: RoleUser},
+	{Path: "/**", Role: domain.RoleAdmin},
+}
+
+// LoadRoutePolicies reads a JSON array of RoutePolicy from path
+func LoadRoutePolicies(path string) ([]RoutePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var policies []RoutePolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("invalid route policy file %s: %w", path, err)
+	}
+	for i, p := range policies {
+		if !strings.HasPrefix(p.Path, "/") {
+			return nil, fmt.Errorf("route policy %d: path must start with '/'", i)
+		}
+		switch p.Role {
+		case "", RoleUser, domain.RoleOrganizer, domain.RoleAdmin:
+		default:
+			return nil, fmt.Errorf("route policy %d: unknown role %q", i, p.Role)
+		}
+		if p.Role == "" && !p.Public {
+			return nil, fmt.Errorf("route policy %d: either role or public must be set", i)
+		}
+	}
+	return policies, nil
+}
+
+// matchPolicy returns the first policy matching the request, or nil
+func matchPolicy(policies []RoutePolicy, r *http.Request) *RoutePolicy {
+	for i := range policies {
+		p := &policies[i]
+		if len(p.Methods) > 0 && !containsFold(p.Methods, policyMethod(p.Methods, r.Method)) {
+			continue
+		}
+		if matchPath(p.Path, apiPath(r.URL.Path)) {
+			return p
+		}
+	}
+	return nil
+}
+
+// policyMethod treats HEAD as the GET it mirrors unless the policy names HEAD itself
+func policyMethod(methods []string, method string) string {
+	if method == http.MethodHead && !containsFold(methods, http.MethodHead) {
+		return http.MethodGet
+	}
+	return method
+}
+
+func matchPath(pattern, path string) bool {
+	patternSegs := splitPath(pattern)
+	pathSegs := splitPath(path)
+	for i, seg := range patternSegs {
+		if seg == "**" && i == len(patternSegs)-1 {
+			return true
+		}
+		if i >= len(pathSegs) || (seg != "*" && seg != pathSegs[i]) {
+			return false
+		}
+	}
+	return len(patternSegs) == len(pathSegs)
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesRole reports whether a caller with role may use a route requiring required
+func satisfiesRole(role, required string) bool {
+	switch required {
+	case RoleUser:
+		return true
+	case domain.RoleOrganizer:
+		return role == domain.RoleOrganizer || role == domain.RoleAdmin
+	default:
+		return role == domain.RoleAdmin
+	}
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bytes"
+	"net/http"
+)
+
+// serveMux dispatches r on mux. Requests no pattern matches would get ServeMux's plain-text
+// 404 or 405; they get the JSON error envelope instead, keeping the Allow header of a 405.
+func serveMux(mux *http.ServeMux, w http.ResponseWriter, r *http.Request) {
+	h, pattern := mux.Handler(r)
+	if pattern != "" {
+		mux.ServeHTTP(w, r)
+		return
+	}
+
+	// Unmatched: ServeMux answers with 404, 405 or a redirect to the canonical path
+	rec := &capturedResponse{header: http.Header{}, status: http.StatusOK}
+	h.ServeHTTP(rec, r)
+	switch rec.status {
+	case http.StatusNotFound:
+		w.Header().Set("Content-Type", "application/json")
+		writeErrorResponse(w, http.StatusNotFound, domain.APIResponse{Error: "Not found", Code: domain.CodeNotFound})
+	case http.StatusMethodNotAllowed:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Allow", rec.header.Get("Allow"))
+		writeErrorResponse(w, http.StatusMethodNotAllowed, domain.APIResponse{
+			Error: "Method " + r.Method + " not allowed; allowed: " + rec.header.Get("Allow"),
+			Code:  domain.CodeMethodNotAllowed,
+		})
+	default:
+		for name, values := range rec.header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.body.Bytes())
+	}
+}
+
+// capturedResponse records the small responses ServeMux writes for unmatched requests
+type capturedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (c *capturedResponse) Header() http.Header { return c.header }
+
+func (c *capturedResponse) Write(b []byte) (int, error) { return c.body.Write(b) }
+
+func (c *capturedResponse) WriteHeader(status int) { c.status = status }
@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// RSVPHandler serves the /events/{id}/rsvp and /events/{id}/attendees sub-resources
+type RSVPHandler struct {
+	service service.RSVPService
+	mux     *http.ServeMux
+}
+
+func NewRSVPHandler(svc service.RSVPService) *RSVPHandler {
+	h := &RSVPHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *RSVPHandler) routes() {
+	h.mux.HandleFunc("POST /events/{id}/rsvp", h.handleRSVP)
+	h.mux.HandleFunc("GET /events/{id}/attendees", h.handleListAttendees)
+}
+
+func (h *RSVPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleRSVP registers the authenticated user for an event
+func (h *RSVPHandler) handleRSVP(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	email, _ := user.Claims["email"].(string)
+	rsvp, err := h.service.RSVP(r.Context(), r.PathValue("id"), user.UID, email)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: rsvp})
+}
+
+// handleListAttendees lists everyone registered for an event
+func (h *RSVPHandler) handleListAttendees(w http.ResponseWriter, r *http.Request) {
+	attendees, err := h.service.ListAttendees(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: attendees})
+}
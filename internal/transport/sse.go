@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const eventStreamContentType = "text/event-stream"
+
+// SSEHeartbeat is how often an idle event stream sends a comment line, so proxies and load
+// balancers do not close it
+const SSEHeartbeat = 25 * time.Second
+
+// WantsEventStream reports whether the client asked for server-sent events, as EventSource does
+func WantsEventStream(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == eventStreamContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// sseWriter writes server-sent events and pushes each one to the client at once
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// startSSE sends the headers of an event stream
+func startSSE(w http.ResponseWriter) *sseWriter {
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx-style proxies would hold the stream back
+	w.WriteHeader(http.StatusOK)
+	s := &sseWriter{w: w, rc: http.NewResponseController(w)}
+	_ = s.rc.Flush()
+	return s
+}
+
+// send writes one event named event with data encoded as JSON; id lets a reconnecting client tell
+// where it left off
+func (s *sseWriter) send(event, id string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, body); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// ping writes a comment line, which EventSource ignores
+func (s *sseWriter) ping() error {
+	if _, err := fmt.Fprint(s.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
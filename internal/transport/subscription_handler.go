@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// SubscriptionHandler serves the authenticated user's notification preferences under /users/me/subscription
+type SubscriptionHandler struct {
+	service service.SubscriptionService
+	mux     *http.ServeMux
+}
+
+func NewSubscriptionHandler(svc service.SubscriptionService) *SubscriptionHandler {
+	h := &SubscriptionHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *SubscriptionHandler) routes() {
+	h.mux.HandleFunc("GET /users/me/subscription", h.handleGet)
+	h.mux.HandleFunc("POST /users/me/subscription", h.handleUpdate)
+	h.mux.HandleFunc("DELETE /users/me/subscription", h.handleDelete)
+}
+
+func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleGet returns the caller's notification preferences
+func (h *SubscriptionHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	sub, err := h.service.GetSubscription(r.Context(), user.UID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: sub})
+}
+
+// handleUpdate replaces the caller's notification preferences
+func (h *SubscriptionHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	var dto domain.SubscriptionDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return
+	}
+
+	// Only a verified address may receive mail, so it comes from the token rather than the body
+	var email string
+	if verified, _ := user.Claims["email_verified"].(bool); verified {
+		email, _ = user.Claims["email"].(string)
+	}
+
+	sub, err := h.service.UpdateSubscription(r.Context(), user.UID, email, &dto)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: sub})
+}
+
+// handleDelete removes the caller's preferences and stops all notifications
+func (h *SubscriptionHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	if err := h.service.DeleteSubscription(r.Context(), user.UID); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Subscription removed"})
+}
@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// APIDocsAccess says who may read the OpenAPI document (API_DOCS). Swagger UI at /swagger/ is a
+// static page without API details, so it is public whenever the docs are served; with
+// APIDocsAdmin it asks for an admin's ID token to fetch the document and try operations.
+type APIDocsAccess string
+
+const (
+	APIDocsOff    APIDocsAccess = "off"
+	APIDocsPublic APIDocsAccess = "public"
+	APIDocsAdmin  APIDocsAccess = "admin"
+)
+
+// SwaggerUIPath serves Swagger UI for the document at OpenAPIPath
+const SwaggerUIPath = "/swagger/"
+
+// swaggerUIVersion is pinned: the page runs with the caller's token, so the CDN must not change it
+// under us. 5 is the first major version rendering OpenAPI 3.1.
+const swaggerUIVersion = "5.17.14"
+
+const swaggerUIDist = "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Bibently API</title>
+  <link rel="stylesheet" href="` + swaggerUIDist + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="` + swaggerUIDist + `/swagger-ui-bundle.js" crossorigin></script>
+  <script src="swagger-initializer.js"></script>
+</body>
+</html>
+`
+
+// swaggerUIInitializer is served as a script of its own so the page's CSP needs no inline scripts.
+// With adminOnly the ID token is kept for the browser tab and dropped when the API rejects it.
+const swaggerUIInitializer = `const adminOnly = %ADMIN_ONLY%;
+const tokenKey = "bibently-api-docs-token";
+
+function idToken() {
+  let token = sessionStorage.getItem(tokenKey);
+  if (!token) {
+    token = window.prompt("The API description is admin-only. Paste the Firebase ID token of an admin:");
+    if (token) {
+      sessionStorage.setItem(tokenKey, token.trim());
+    }
+  }
+  return sessionStorage.getItem(tokenKey) || "";
+}
+
+window.ui = SwaggerUIBundle({
+  url: "` + OpenAPIPath + `",
+  dom_id: "#swagger-ui",
+  deepLinking: false,
+  requestInterceptor: (req) => {
+    // A token entered with Authorize wins over the one the page asked for
+    if (adminOnly && !req.headers.Authorization) {
+      const token = idToken();
+      if (token) {
+        req.headers.Authorization = "Bearer " + token;
+      }
+    }
+    return req;
+  },
+  responseInterceptor: (res) => {
+    if (res.status === 401) {
+      sessionStorage.removeItem(tokenKey);
+    }
+    return res;
+  },
+});
+`
+
+// swaggerUICSP replaces the API's "default-src 'none'" for the page: Swagger UI's script and
+// stylesheet come from the pinned CDN release and it calls the API on this origin only
+const swaggerUICSP = "default-src 'none'; script-src 'self' " + swaggerUIDist + "/; " +
+	"style-src 'unsafe-inline' " + swaggerUIDist + "/; img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'"
+
+// SwaggerUIHandler serves Swagger UI for the document at OpenAPIPath on every path it is mounted at,
+// asking for an admin's ID token when access is APIDocsAdmin
+func SwaggerUIHandler(access APIDocsAccess) http.Handler {
+	initializer := []byte(strings.Replace(swaggerUIInitializer, "%ADMIN_ONLY%", strconv.FormatBool(access == APIDocsAdmin), 1))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("Content-Security-Policy", swaggerUICSP)
+		h.Set("Cache-Control", "no-cache")
+		if strings.HasSuffix(r.URL.Path, "/swagger-initializer.js") {
+			h.Set("Content-Type", "text/javascript; charset=utf-8")
+			_, _ = w.Write(initializer)
+			return
+		}
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(swaggerUIPage))
+	})
+}
+
+// APIDocsPolicies puts the OpenAPI document behind the admin role ahead of policies (nil:
+// DefaultRoutePolicies) when access is APIDocsAdmin, and returns policies unchanged otherwise
+func APIDocsPolicies(policies []RoutePolicy, access APIDocsAccess) []RoutePolicy {
+	if access != APIDocsAdmin {
+		return policies
+	}
+	if policies == nil {
+		policies = DefaultRoutePolicies
+	}
+	docs := RoutePolicy{Methods: []string{http.MethodGet}, Path: OpenAPIPath, Role: domain.RoleAdmin}
+	return append([]RoutePolicy{docs}, policies...)
+}
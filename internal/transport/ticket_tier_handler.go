@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// TicketTierHandler serves the /events/{id}/tiers sub-resource
+type TicketTierHandler struct {
+	service service.TicketTierService
+	mux     *http.ServeMux
+}
+
+func NewTicketTierHandler(svc service.TicketTierService) *TicketTierHandler {
+	h := &TicketTierHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *TicketTierHandler) routes() {
+	// Registered with full paths because the parent router does not strip a prefix
+	h.mux.HandleFunc("GET /events/{id}/tiers", h.handleList)
+	h.mux.HandleFunc("POST /events/{id}/tiers", h.handleCreate)
+	h.mux.HandleFunc("PUT /events/{id}/tiers/{tierId}", h.handleUpdate)
+}
+
+func (h *TicketTierHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleList lists the ticket tiers of an event
+func (h *TicketTierHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	tiers, err := h.service.ListTiers(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: tiers})
+}
+
+// handleCreate adds a ticket tier to an event
+func (h *TicketTierHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	tier, ok := decodeTicketTier(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.CreateTier(r.Context(), r.PathValue("id"), tier); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: tier.Id})
+}
+
+// handleUpdate replaces a ticket tier
+func (h *TicketTierHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	tier, ok := decodeTicketTier(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.UpdateTier(r.Context(), r.PathValue("id"), r.PathValue("tierId"), tier); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Updated successfully"})
+}
+
+// decodeTicketTier decodes and validates the request body, writing the error response on failure
+func decodeTicketTier(w http.ResponseWriter, r *http.Request) (*domain.TicketTier, bool) {
+	var dto domain.TicketTierDTO
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
+		return nil, false
+	}
+	if err := domain.Validate.Struct(dto); err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return nil, false
+	}
+	tier, err := domain.TicketTierDTOToModel(&dto)
+	if err != nil {
+		respondError(w, r, domain.ErrValidation(err.Error()))
+		return nil, false
+	}
+	return tier, true
+}
@@ -5,17 +5,22 @@ import (
 	"bibently.com/backend/internal/service"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
 type TrackingHandler struct {
-	service service.TrackingService
-	mux     *http.ServeMux
+	service     service.TrackingService
+	mux         *http.ServeMux
+	honorOptOut bool
 }
 
-func NewTrackingHandler(svc service.TrackingService) *TrackingHandler {
+// NewTrackingHandler serves /tracking; with honorOptOut, events from browsers sending DNT or
+// Sec-GPC are acknowledged but not stored
+func NewTrackingHandler(svc service.TrackingService, honorOptOut bool) *TrackingHandler {
 	h := &TrackingHandler{
-		service: svc,
-		mux:     http.NewServeMux(),
+		service:     svc,
+		mux:         http.NewServeMux(),
+		honorOptOut: honorOptOut,
 	}
 	h.routes()
 	return h
@@ -26,45 +31,50 @@ func (h *TrackingHandler) routes() {
 	h.mux.HandleFunc("GET /{$}", h.handleList)
 	// POST /tracking/ (Create)
 	h.mux.HandleFunc("POST /{$}", h.handleCreate)
+	// GET /tracking/stats (Counts per action and time bucket)
+	h.mux.HandleFunc("GET /stats", h.handleStats)
+	// GET /tracking/sessions/{id} (One session's events in order)
+	h.mux.HandleFunc("GET /sessions/{id}", h.handleSession)
+	// DELETE /tracking/ (Bulk cleanup by age and action)
+	h.mux.HandleFunc("DELETE /{$}", h.handleDeleteWhere)
+	// DELETE /tracking/{id} (Delete one event)
+	h.mux.HandleFunc("DELETE /{id}", h.handleDelete)
 }
 
 func (h *TrackingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	h.mux.ServeHTTP(w, r)
+	serveMux(h.mux, w, r)
 }
 
 // handleCreate creates a new tracking event
-// @Summary Create Tracking Event
-// @Description Create a new tracking event
-// @Tags tracking
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param tracking body domain.TrackingEvent true "Tracking Event Data"
-// @Success 201 {object} domain.APIResponse{data=string} "Returns Tracking Event Id"
-// @Failure 400 {object} domain.APIResponse{error=string}
-// @Router /tracking [post]
 func (h *TrackingHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if h.honorOptOut && optedOut(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
 	var dto domain.TrackingEventDTO
-	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
-		respondError(w, domain.ErrValidation("Invalid JSON"))
+	if err := decodeJSON(r, &dto); err != nil {
+		respondError(w, r, err)
 		return
 	}
 	if err := domain.Validate.Struct(dto); err != nil {
-		respondError(w, domain.ErrValidation(err.Error()))
+		respondError(w, r, domain.ErrValidation(err.Error()))
 		return
 	}
 	trackingEvent := domain.TrackingEvent{
-		Action:    dto.Action,
-		Payload:   dto.Payload,
-		UserAgent: dto.UserAgent,
-		UserName:  dto.UserName,
+		Action:        dto.Action,
+		Payload:       dto.Payload,
+		UserAgent:     dto.UserAgent,
+		UserName:      dto.UserName,
+		SessionID:     dto.SessionID,
+		EventID:       dto.EventID,
+		ClientEventID: dto.ClientEventID,
 	}
 	if trackingEvent.UserAgent == "" {
 		trackingEvent.UserAgent = r.UserAgent()
 	}
 	if err := h.service.TrackEvent(r.Context(), &trackingEvent); err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -72,19 +82,83 @@ func (h *TrackingHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleList lists all tracking events
-// @Summary List Tracking Events
-// @Description Get a list of all tracking events
-// @Tags tracking
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Success 200 {object} domain.APIResponse{data=[]domain.TrackingEvent}
-// @Router /tracking [get]
 func (h *TrackingHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	tracks, err := h.service.GetAllTracking(r.Context())
 	if err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: domain.NewTrackingEventResponses(tracks)})
+}
+
+// handleStats counts tracking events per action in hourly or daily buckets
+func (h *TrackingHandler) handleStats(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var window [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				respondError(w, r, domain.ErrValidation(name+" must be an RFC3339 timestamp"))
+				return
+			}
+			window[i] = t
+		}
+	}
+
+	stats, err := h.service.GetTrackingStats(r.Context(), q.Get("group_by"), q.Get("interval"), window[0], window[1])
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: stats})
+}
+
+// handleSession returns the events of one session
+func (h *TrackingHandler) handleSession(w http.ResponseWriter, r *http.Request) {
+	tracks, err := h.service.GetSession(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
-	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: tracks})
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: domain.NewTrackingEventResponses(tracks)})
+}
+
+// handleDelete deletes one tracking event
+func (h *TrackingHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteTracking(r.Context(), r.PathValue("id")); err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: "Deleted successfully"})
+}
+
+// handleDeleteWhere deletes the tracking events matching the filters
+func (h *TrackingHandler) handleDeleteWhere(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.TrackingDeleteFilter{Action: q.Get("action")}
+	if v := q.Get("older_than"); v != "" {
+		cutoff, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			age, durErr := time.ParseDuration(v)
+			if durErr != nil || age <= 0 {
+				respondError(w, r, domain.ErrValidation("older_than must be an RFC3339 timestamp or a positive duration"))
+				return
+			}
+			cutoff = time.Now().UTC().Add(-age)
+		}
+		filter.OlderThan = cutoff
+	}
+
+	result, err := h.service.DeleteTrackingWhere(r.Context(), filter)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: result})
+}
+
+// optedOut reports a Do Not Track or Global Privacy Control signal
+func optedOut(r *http.Request) bool {
+	return r.Header.Get("DNT") == "1" || r.Header.Get("Sec-GPC") == "1"
 }
@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// standardClaims are set by Firebase Auth on every ID token; everything else is a custom claim
+var standardClaims = map[string]bool{
+	"iss": true, "aud": true, "sub": true, "iat": true, "exp": true, "auth_time": true,
+	"user_id": true, "email": true, "email_verified": true, "name": true, "picture": true,
+	"phone_number": true, "firebase": true,
+}
+
+// UserHandler serves the authenticated principal under /users/me and personal data erasure
+type UserHandler struct {
+	service service.UserService
+	mux     *http.ServeMux
+}
+
+func NewUserHandler(svc service.UserService) *UserHandler {
+	h := &UserHandler{
+		service: svc,
+		mux:     http.NewServeMux(),
+	}
+	h.routes()
+	return h
+}
+
+func (h *UserHandler) routes() {
+	h.mux.HandleFunc("GET /users/me", h.handleMe)
+	h.mux.HandleFunc("DELETE /users/{uid}/data", h.handleDeleteData)
+}
+
+func (h *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleMe returns the caller's identity so frontends can bootstrap a session
+func (h *UserHandler) handleMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+
+	me := &domain.CurrentUser{
+		UID:    user.UID,
+		Roles:  []string{RoleUser},
+		Claims: make(map[string]interface{}),
+	}
+	me.Email, _ = user.Claims["email"].(string)
+	me.EmailVerified, _ = user.Claims["email_verified"].(bool)
+	if p, ok := domain.PrincipalFromContext(r.Context()); ok && p.Role != "" {
+		me.Roles = append(me.Roles, p.Role)
+	}
+	for k, v := range user.Claims {
+		if !standardClaims[k] {
+			me.Claims[k] = v
+		}
+	}
+
+	profile, err := h.service.GetProfile(r.Context(), user.UID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	me.Profile = profile
+
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: me})
+}
+
+// handleDeleteData erases a user's personal data for a right-to-erasure request
+func (h *UserHandler) handleDeleteData(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w)
+		return
+	}
+	uid := r.PathValue("uid")
+	if uid == "me" {
+		uid = user.UID
+	}
+
+	report, err := h.service.DeleteUserData(r.Context(), uid)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(domain.APIResponse{Data: report})
+}
@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"bibently.com/backend/internal/service"
+	"net/http"
+)
+
+// WebhookHandler accepts pushes from partner systems under /webhooks, authenticated by HMAC signature
+type WebhookHandler struct {
+	events *EventHandler
+	mux    *http.ServeMux
+}
+
+func NewWebhookHandler(events service.EventService, secret []byte) http.Handler {
+	h := &WebhookHandler{
+		events: NewEventHandler(events, ""),
+		mux:    http.NewServeMux(),
+	}
+	h.routes()
+	return WithHMACSignature(h, secret)
+}
+
+func (h *WebhookHandler) routes() {
+	h.mux.HandleFunc("POST /webhooks/events", h.handleEvent)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	serveMux(h.mux, w, r)
+}
+
+// handleEvent creates an event pushed by a partner
+func (h *WebhookHandler) handleEvent(w http.ResponseWriter, r *http.Request) {
+	h.events.handleCreate(w, r)
+}
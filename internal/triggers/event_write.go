@@ -0,0 +1,160 @@
+// Package triggers holds the CloudEvent (background function) entry points fed by Eventarc.
+package triggers
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Eventarc delivers Firestore changes as protobuf unless the trigger asks for JSON
+const (
+	contentTypeProtobuf = "application/protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// NewEventWriteHandler handles google.cloud.firestore.document.v1.written events for events/{eventId}
+// and tenants/{tenantId}/events/{eventId}, and keeps the denormalized facets of the event's tenant in
+// step with its collection.
+func NewEventWriteHandler(facets service.FacetService) func(context.Context, cloudevents.Event) error {
+	return func(ctx context.Context, e cloudevents.Event) error {
+		// Documents of subcollections (revisions, view shards, tiers...) are not events
+		tenantID, ok := eventTenant(e.Subject())
+		if !ok {
+			return nil
+		}
+		if tenantID != "" {
+			ctx = domain.WithTenant(ctx, tenantID)
+		}
+		before, after, err := decodeEventWrite(e)
+		if err != nil {
+			// A malformed payload will not decode on retry either; returning nil avoids a retry loop
+			return nil
+		}
+		return facets.ApplyEventWrite(ctx, before, after)
+	}
+}
+
+// eventTenant matches subjects of the form documents/events/{eventId} (tenant "") and
+// documents/tenants/{tenantId}/events/{eventId}
+func eventTenant(subject string) (tenantID string, ok bool) {
+	path, ok := strings.CutPrefix(subject, "documents/")
+	if !ok {
+		return "", false
+	}
+	if rest, tenant := strings.CutPrefix(path, repository.CollectionTenants+"/"); tenant {
+		tenantID, path, _ = strings.Cut(rest, "/")
+		if !domain.ValidTenantID(tenantID) {
+			return "", false
+		}
+	}
+	id, ok := strings.CutPrefix(path, repository.CollectionEvents+"/")
+	return tenantID, ok && id != "" && !strings.Contains(id, "/")
+}
+
+// decodeEventWrite returns the document before and after the write; either is nil for a create or delete.
+// Only the fields the triggers read are decoded.
+func decodeEventWrite(e cloudevents.Event) (before, after *domain.Event, err error) {
+	var value, oldValue *firestorepb.Document
+	switch e.DataContentType() {
+	case contentTypeJSON:
+		value, oldValue, err = decodeJSONDocuments(e.Data())
+	case contentTypeProtobuf, "":
+		value, oldValue, err = decodeProtoDocuments(e.Data())
+	default:
+		err = fmt.Errorf("unsupported data content type %q", e.DataContentType())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return eventFromDocument(oldValue), eventFromDocument(value), nil
+}
+
+// decodeProtoDocuments reads google.events.cloud.firestore.v1.DocumentEventData, whose value (1) and
+// old_value (2) are wire-compatible with google.firestore.v1.Document
+func decodeProtoDocuments(data []byte) (value, oldValue *firestorepb.Document, err error) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ != protowire.BytesType || (num != 1 && num != 2) {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return nil, nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			continue
+		}
+		raw, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return nil, nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		doc := &firestorepb.Document{}
+		if err := proto.Unmarshal(raw, doc); err != nil {
+			return nil, nil, err
+		}
+		if num == 1 {
+			value = doc
+		} else {
+			oldValue = doc
+		}
+	}
+	return value, oldValue, nil
+}
+
+// jsonDocument is the JSON form of a Firestore document; only string fields are needed
+type jsonDocument struct {
+	Name   string `json:"name"`
+	Fields map[string]struct {
+		StringValue *string `json:"stringValue"`
+	} `json:"fields"`
+}
+
+func decodeJSONDocuments(data []byte) (value, oldValue *firestorepb.Document, err error) {
+	var payload struct {
+		Value    *jsonDocument `json:"value"`
+		OldValue *jsonDocument `json:"oldValue"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, nil, err
+	}
+	return payload.Value.toProto(), payload.OldValue.toProto(), nil
+}
+
+func (d *jsonDocument) toProto() *firestorepb.Document {
+	if d == nil {
+		return nil
+	}
+	doc := &firestorepb.Document{Name: d.Name, Fields: map[string]*firestorepb.Value{}}
+	for k, v := range d.Fields {
+		if v.StringValue != nil {
+			doc.Fields[k] = &firestorepb.Value{ValueType: &firestorepb.Value_StringValue{StringValue: *v.StringValue}}
+		}
+	}
+	return doc
+}
+
+func eventFromDocument(doc *firestorepb.Document) *domain.Event {
+	if doc == nil || doc.GetName() == "" {
+		return nil
+	}
+	fields := doc.GetFields()
+	return &domain.Event{
+		Id:   doc.GetName()[strings.LastIndex(doc.GetName(), "/")+1:],
+		City: fields["city"].GetStringValue(),
+		Type: domain.EventType(fields["type"].GetStringValue()),
+	}
+}
@@ -1,48 +1,20 @@
 package integration_tests
 
 import (
-	"bibently.com/backend/internal/repository"
-	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/testkit"
 	"bibently.com/backend/internal/transport"
 	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
-	"time"
 
-	"cloud.google.com/go/firestore"
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 )
 
 const TestAdminUID = "admin_user_xyz_123_secret_id"
-const TestProjectID = "local-project-id"
-
-// generateValidEmulatorToken creates a fresh, unsigned JWT for the emulator
-func generateValidEmulatorToken(uid, projectID string) string {
-	header := `{"alg":"none","typ":"JWT"}`
-	now := time.Now().Unix()
-
-	payload := map[string]interface{}{
-		"iss":       "https://securetoken.google.com/" + projectID,
-		"aud":       projectID,
-		"auth_time": now,
-		"user_id":   uid,
-		"sub":       uid,
-		"iat":       now,
-		"exp":       now + 3600, // Expires in 1 hour
-		"email":     uid + "@test.local",
-	}
-
-	pBytes, _ := json.Marshal(payload)
-	enc := base64.RawURLEncoding
-	// Important: The trailing dot indicates an empty signature
-	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString(pBytes) + "."
-}
 
 // ensureUserExists creates the user in the Auth Emulator if missing
 func ensureUserExists(ctx context.Context, client *auth.Client, uid string) error {
@@ -62,24 +34,14 @@ func ensureUserExists(ctx context.Context, client *auth.Client, uid string) erro
 	return err
 }
 
-func setupAuthIntegration(t *testing.T) (http.Handler, *firestore.Client) {
+func setupAuthIntegration(t *testing.T) http.Handler {
 	t.Helper()
 
-	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
-		t.Skip("Skipping auth integration test: Emulator not running")
-	}
-
+	client := testkit.NewFirestoreClient(t)
 	ctx := context.Background()
 
-	// 2. Initialize Firestore
-	client, err := firestore.NewClient(ctx, TestProjectID)
-	if err != nil {
-		t.Fatalf("Failed to create firestore client: %v", err)
-	}
-
-	// 3. Initialize Firebase App & Auth
-	conf := &firebase.Config{ProjectID: TestProjectID}
-	app, err := firebase.NewApp(ctx, conf)
+	// Initialize Firebase App & Auth
+	app, err := firebase.NewApp(ctx, &firebase.Config{ProjectID: testkit.ProjectID})
 	if err != nil {
 		t.Fatalf("Failed to init firebase app: %v", err)
 	}
@@ -88,31 +50,18 @@ func setupAuthIntegration(t *testing.T) (http.Handler, *firestore.Client) {
 		t.Fatalf("Failed to get auth client: %v", err)
 	}
 
-	// 4. Create Admin User in Emulator
+	// Create Admin User in Emulator
 	if err := ensureUserExists(ctx, authClient, TestAdminUID); err != nil {
 		t.Fatalf("Failed to ensure admin user exists: %v", err)
 	}
 
-	// 5. Build Application Stack
-	eventRepo := repository.NewEventRepository(client)
-	trackingRepo := repository.NewTrackingRepository(client)
-	eventSvc := service.NewEventService(eventRepo)
-	trackingSvc := service.NewTrackingService(trackingRepo)
-
-	router := transport.NewRouter(eventSvc, trackingSvc)
-	protectedHandler := transport.WithAuthProtection(router, authClient)
-
-	return protectedHandler, client
+	// Build Application Stack
+	router := transport.NewRouter(testkit.NewServices(client))
+	return transport.WithAuthProtection(router, authClient, nil, os.Getenv("FIRESTORE_ADMIN_UID"))
 }
 
 func TestAuth_Strict_Blocking(t *testing.T) {
-	handler, client := setupAuthIntegration(t)
-	t.Cleanup(func() {
-		err := client.Close()
-		if err != nil {
-			return
-		}
-	})
+	handler := setupAuthIntegration(t)
 
 	t.Run("Allow_Unauthenticated_Read", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/events/", nil)
@@ -138,16 +87,10 @@ func TestAuth_Strict_Blocking(t *testing.T) {
 
 // TestAuth_Authenticated_Access: Verifies Role-Based Access Control
 func TestAuth_Authenticated_Access(t *testing.T) {
-	handler, client := setupAuthIntegration(t)
-	t.Cleanup(func() {
-		err := client.Close()
-		if err != nil {
-			return
-		}
-	})
+	handler := setupAuthIntegration(t)
 
 	// Generate tokens
-	adminToken := generateValidEmulatorToken(TestAdminUID, TestProjectID)
+	adminToken := testkit.EmulatorToken(TestAdminUID, testkit.ProjectID)
 	adminAuthHeader := "Bearer " + adminToken
 
 	t.Run("Allow_Admin_Write", func(t *testing.T) {
@@ -167,7 +110,7 @@ func TestAuth_Authenticated_Access(t *testing.T) {
 
 	t.Run("Block_NonAdmin_Write", func(t *testing.T) {
 		// Scenario: A regular user (valid token, but wrong UID) tries to write
-		regularToken := generateValidEmulatorToken("regular_user", TestProjectID)
+		regularToken := testkit.EmulatorToken("regular_user", testkit.ProjectID)
 		regularHeader := "Bearer " + regularToken
 
 		bodyStr := `{"event_name": "Illegal Event", "city": "Nowhere", "type": "concert", "price": 0, "start_time": "2024-12-31T20:00:00Z"}`
@@ -198,13 +141,7 @@ func TestAuth_Authenticated_Access(t *testing.T) {
 }
 
 func TestAuth_Guest_Mode(t *testing.T) {
-	handler, client := setupAuthIntegration(t)
-	t.Cleanup(func() {
-		err := client.Close()
-		if err != nil {
-			return
-		}
-	})
+	handler := setupAuthIntegration(t)
 
 	t.Run("Allow_Guest_Read", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/events/", nil)
@@ -232,10 +169,7 @@ func TestAuth_Guest_Mode(t *testing.T) {
 }
 
 func TestAuth_Guest_Mode_Restricted(t *testing.T) {
-	handler, client := setupAuthIntegration(t)
-	t.Cleanup(func() {
-		_ = client.Close()
-	})
+	handler := setupAuthIntegration(t)
 
 	// 1. Events should be ALLOWED
 	t.Run("Allow_Guest_Events", func(t *testing.T) {
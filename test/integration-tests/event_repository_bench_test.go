@@ -0,0 +1,87 @@
+package integration_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/testkit"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// benchEvents is the size of the dataset List is benchmarked on
+const benchEvents = 600
+
+var benchCities = []string{"Warsaw", "Cracow", "Gdansk", "Wroclaw", "Poznan", "Lodz"}
+
+// BenchmarkEventRepository_List measures List over the filter, sort and pagination combinations
+// the API serves. Compare runs with make bench-compare, which fails on regressions against the
+// baseline recorded by make bench-baseline.
+func BenchmarkEventRepository_List(b *testing.B) {
+	client := testkit.NewFirestoreClient(b)
+	testkit.ClearCollections(b, client)
+	b.Cleanup(func() { testkit.ClearCollections(b, client) })
+
+	base := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	events := make([]*domain.Event, 0, benchEvents)
+	for i := range benchEvents {
+		events = append(events, testkit.NewEventBuilder().
+			WithID(fmt.Sprintf("bench_%04d", i)).
+			Named(fmt.Sprintf("Bench Event %d", i)).
+			InCity(benchCities[i%len(benchCities)]).
+			OfType(domain.DefaultEventTypes[i%len(domain.DefaultEventTypes)]).
+			Priced(float64(i%20)*10).
+			StartingAt(base.Add(time.Duration(i)*6*time.Hour)).
+			Build())
+	}
+	testkit.SaveEvents(b, client, events...)
+
+	repo := repository.NewEventRepository(client)
+	ctx := context.Background()
+	from, to := base.AddDate(0, 1, 0), base.AddDate(0, 2, 0)
+	minPrice, maxPrice := 50.0, 120.0
+
+	cases := []struct {
+		name   string
+		search domain.SearchRequest
+		// pages is how many pages each iteration reads, following NextPageToken
+		pages int
+	}{
+		{name: "default", search: domain.SearchRequest{}},
+		{name: "page_size=100", search: domain.SearchRequest{Sorting: domain.SortRequest{PageSize: 100}}},
+		{name: "city", search: domain.SearchRequest{Filters: domain.FilterRequest{City: "Warsaw"}}},
+		{name: "city+type", search: domain.SearchRequest{Filters: domain.FilterRequest{City: "Warsaw", Type: domain.TypeConcert}}},
+		{name: "name_prefix", search: domain.SearchRequest{Filters: domain.FilterRequest{EventName: "bench event 1"}}},
+		{name: "date_range", search: domain.SearchRequest{Filters: domain.FilterRequest{StartDate: &from, EndDate: &to}}},
+		{name: "price_range", search: domain.SearchRequest{Filters: domain.FilterRequest{MinPrice: &minPrice, MaxPrice: &maxPrice}}},
+		{name: "city+date_range", search: domain.SearchRequest{Filters: domain.FilterRequest{City: "Gdansk", StartDate: &from, EndDate: &to}}},
+		{name: "sort=price_desc", search: domain.SearchRequest{Sorting: domain.SortRequest{SortKey: "price", SortDirection: "desc"}}},
+		{name: "sort=event_name", search: domain.SearchRequest{Sorting: domain.SortRequest{SortKey: "event_name", SortDirection: "asc"}}},
+		{name: "city+sort=price", search: domain.SearchRequest{Filters: domain.FilterRequest{City: "Cracow"}, Sorting: domain.SortRequest{SortKey: "price", SortDirection: "asc"}}},
+		{name: "fields", search: domain.SearchRequest{Fields: domain.EventCardFields}},
+		{name: "pages=5", search: domain.SearchRequest{Sorting: domain.SortRequest{PageSize: 20}}, pages: 5},
+		{name: "city+pages=5", search: domain.SearchRequest{Filters: domain.FilterRequest{City: "Poznan"}, Sorting: domain.SortRequest{PageSize: 10}}, pages: 5},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				search := tc.search
+				for page := 0; page < max(tc.pages, 1); page++ {
+					found, meta, err := repo.List(ctx, search)
+					if err != nil {
+						b.Fatalf("List failed: %v", err)
+					}
+					if len(found) == 0 {
+						b.Fatalf("Expected %s to match events", tc.name)
+					}
+					if meta.NextPageToken == "" {
+						break
+					}
+					search.Sorting.PageToken = meta.NextPageToken
+				}
+			}
+		})
+	}
+}
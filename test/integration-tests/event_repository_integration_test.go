@@ -3,9 +3,12 @@ package integration_tests
 import (
 	"bibently.com/backend/internal/domain"
 	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/testkit"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
@@ -16,7 +19,7 @@ func TestEventRepository_List_MultipleFilters_RoughMatch(t *testing.T) {
 	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
 		// 0. CLEANUP: Delete all existing events before seeding.
 		// This ensures we don't count leftover data from previous runs.
-		cleanupFirestore(t, client)
+		testkit.ClearCollections(t, client)
 
 		repo := repository.NewEventRepository(client)
 		ctx := context.Background()
@@ -111,3 +114,445 @@ func TestEventRepository_List_MultipleFilters_RoughMatch(t *testing.T) {
 		}
 	})
 }
+
+func TestEventRepository_List_CaseInsensitiveTextFilters(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+
+		for i, name := range []string{"Jazz Night", "JAZZ Brunch", "Rock Night"} {
+			event := &domain.Event{
+				Id:        fmt.Sprintf("case_%d", i),
+				EventName: name,
+				City:      "Warsaw",
+				CreatedAt: time.Now(),
+			}
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		results, _, err := repo.List(ctx, domain.SearchRequest{
+			Filters: domain.FilterRequest{EventName: "jazz", City: "warsaw"},
+			Sorting: domain.SortRequest{PageSize: 10},
+		})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+
+		if len(results) != 2 {
+			t.Errorf("Expected 2 case-insensitive matches for 'jazz' in 'warsaw', got %d", len(results))
+		}
+		for _, e := range results {
+			if e.EventNameLC == "" || e.CityLC != "warsaw" {
+				t.Errorf("Expected lowercase copies to be stored, got %q / %q", e.EventNameLC, e.CityLC)
+			}
+		}
+	})
+}
+
+func TestEventRepository_List_PaginatesBackwards(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+
+		baseTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < 5; i++ {
+			event := &domain.Event{
+				Id:        fmt.Sprintf("page_%d", i),
+				EventName: "Paged",
+				CreatedAt: baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		sorting := domain.SortRequest{SortKey: "created_at", SortDirection: "asc", PageSize: 2}
+
+		// 1. First page has no previous token
+		page1, meta1, err := repo.List(ctx, domain.SearchRequest{Sorting: sorting})
+		if err != nil {
+			t.Fatalf("List page 1 failed: %v", err)
+		}
+		if len(page1) != 2 || meta1.PrevPageToken != "" || meta1.NextPageToken == "" {
+			t.Fatalf("Unexpected first page: %d items, meta %+v", len(page1), meta1)
+		}
+
+		// 2. Second page links both ways
+		sorting.PageToken = meta1.NextPageToken
+		page2, meta2, err := repo.List(ctx, domain.SearchRequest{Sorting: sorting})
+		if err != nil {
+			t.Fatalf("List page 2 failed: %v", err)
+		}
+		if len(page2) != 2 || page2[0].Id != "page_2" || meta2.PrevPageToken == "" {
+			t.Fatalf("Unexpected second page: %v, meta %+v", page2, meta2)
+		}
+
+		// 3. Going back returns the first page in the same order
+		sorting.PageToken = meta2.PrevPageToken
+		back, _, err := repo.List(ctx, domain.SearchRequest{Sorting: sorting})
+		if err != nil {
+			t.Fatalf("List previous page failed: %v", err)
+		}
+		if len(back) != 2 || back[0].Id != page1[0].Id || back[1].Id != page1[1].Id {
+			t.Errorf("Expected previous page to equal first page, got %v", back)
+		}
+	})
+}
+
+func TestEventRepository_UpdateInTransaction(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, &domain.Event{Id: "tx_1", EventName: "Counter", City: "Warsaw", Capacity: 2}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		// 1. Concurrent computed updates do not lose increments
+		const writers = 5
+		errs := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			go func() {
+				errs <- repo.UpdateInTransaction(ctx, "tx_1", func(current *domain.Event) (map[string]interface{}, error) {
+					return map[string]interface{}{"capacity": current.Capacity + 1}, nil
+				})
+			}()
+		}
+		for i := 0; i < writers; i++ {
+			if err := <-errs; err != nil {
+				t.Fatalf("UpdateInTransaction failed: %v", err)
+			}
+		}
+		got, err := repo.GetByID(ctx, "tx_1")
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if got.Capacity != 2+writers {
+			t.Errorf("Expected capacity %d, got %d", 2+writers, got.Capacity)
+		}
+
+		// 2. A failed check aborts without writing
+		conflict := domain.ErrConflict("stale version")
+		err = repo.UpdateInTransaction(ctx, "tx_1", func(current *domain.Event) (map[string]interface{}, error) {
+			return nil, conflict
+		})
+		if err == nil {
+			t.Fatal("Expected the check's error")
+		}
+
+		// 3. Search copies follow renamed fields and missing events are reported
+		err = repo.UpdateInTransaction(ctx, "tx_1", func(current *domain.Event) (map[string]interface{}, error) {
+			return map[string]interface{}{"city": "Kraków"}, nil
+		})
+		if err != nil {
+			t.Fatalf("UpdateInTransaction failed: %v", err)
+		}
+		if got, _ := repo.GetByID(ctx, "tx_1"); got.CityLC != domain.NormalizeSearchText("Kraków") {
+			t.Errorf("Expected city_lc to be synced, got %q", got.CityLC)
+		}
+		var notFound *domain.NotFoundError
+		err = repo.UpdateInTransaction(ctx, "missing", func(current *domain.Event) (map[string]interface{}, error) {
+			return map[string]interface{}{"city": "x"}, nil
+		})
+		if !errors.As(err, &notFound) {
+			t.Errorf("Expected NotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestEventRepository_BatchSave_BeyondBatchLimit(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+
+		// 1. More than one 500-write batch, with one Id repeated inside the same call
+		const total = 1200
+		events := make([]*domain.Event, total)
+		for i := range events {
+			events[i] = &domain.Event{Id: fmt.Sprintf("bulk_%d", i), EventName: "Bulk", CreatedAt: time.Now()}
+		}
+		events[total-1].Id = "bulk_0"
+
+		err := repo.BatchSave(ctx, events)
+		var batchErr *domain.BatchSaveError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("Expected a BatchSaveError for the duplicate Id, got %v", err)
+		}
+		if len(batchErr.Failed) != 1 || batchErr.Failed[total-1] == nil {
+			t.Fatalf("Expected only the repeated item to fail, got %v", batchErr.Failed)
+		}
+
+		// 2. Every other item was written
+		docs, err := client.Collection(repository.CollectionEvents).Where("event_name", "==", "Bulk").Documents(ctx).GetAll()
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if len(docs) != total-1 {
+			t.Errorf("Expected %d stored events, got %d", total-1, len(docs))
+		}
+	})
+}
+
+func TestEventRepository_GetMulti(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+
+		for _, id := range []string{"multi_a", "multi_b", "multi_c"} {
+			if err := repo.Save(ctx, &domain.Event{Id: id, EventName: id, CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		events, err := repo.GetMulti(ctx, []string{"multi_c", "missing", "multi_a"})
+		if err != nil {
+			t.Fatalf("GetMulti failed: %v", err)
+		}
+		if len(events) != 2 || events[0].Id != "multi_c" || events[1].Id != "multi_a" {
+			t.Errorf("Expected [multi_c multi_a] in request order, got %v", events)
+		}
+	})
+}
+
+func TestEventRepository_List_PageTokensAreSealed(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		secret := repository.WithPageTokenSecret([]byte("test-secret"))
+		repo := repository.NewEventRepository(client, secret)
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			event := &domain.Event{Id: fmt.Sprintf("sealed_%d", i), EventName: "Sealed", City: "Warsaw", CreatedAt: time.Now()}
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		search := domain.SearchRequest{
+			Filters: domain.FilterRequest{City: "Warsaw"},
+			Sorting: domain.SortRequest{SortKey: "created_at", SortDirection: "asc", PageSize: 2},
+		}
+		_, meta, err := repo.List(ctx, search)
+		if err != nil || meta.NextPageToken == "" {
+			t.Fatalf("List failed: %v %+v", err, meta)
+		}
+		token := meta.NextPageToken
+
+		// 1. Another instance sharing the secret resumes the query
+		search.Sorting.PageToken = token
+		if _, _, err := repository.NewEventRepository(client, secret).List(ctx, search); err != nil {
+			t.Errorf("Expected the token to work on another instance, got %v", err)
+		}
+
+		// 2. Tampered tokens, tokens for another query and tokens from another key are rejected as bad input
+		tampered := []byte(token)
+		tampered[len(tampered)/2] ^= 1
+		otherQuery := search
+		otherQuery.Filters.City = "Berlin"
+		cases := map[string]struct {
+			repo   repository.EventRepository
+			search domain.SearchRequest
+			token  string
+		}{
+			"tampered":    {repo, search, string(tampered)},
+			"other query": {repo, otherQuery, token},
+			"other key":   {repository.NewEventRepository(client), search, token},
+		}
+		for name, tc := range cases {
+			tc.search.Sorting.PageToken = tc.token
+			_, _, err := tc.repo.List(ctx, tc.search)
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("%s: expected ValidationError, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestEventRepository_List_CollectionGroupSpansTenants(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		ctx := context.Background()
+		if err := repository.NewEventRepository(client).Save(ctx, &domain.Event{Id: "group_root", EventName: "Root", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		for _, tenant := range []string{"t1", "t2"} {
+			ref := client.Collection(repository.TenantEventsPath(tenant)).Doc("group_" + tenant)
+			if _, err := ref.Set(ctx, domain.Event{Id: ref.ID, EventName: tenant, CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+			defer func() { _, _ = ref.Delete(ctx) }()
+		}
+
+		search := domain.SearchRequest{Sorting: domain.SortRequest{SortKey: "created_at", SortDirection: "asc", PageSize: 10}}
+
+		// 1. By default only the root collection is read
+		events, _, err := repository.NewEventRepository(client).List(ctx, search)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(events) != 1 || events[0].Id != "group_root" {
+			t.Errorf("Expected only group_root, got %v", events)
+		}
+
+		// 2. A collection group query also reads every tenant's events
+		events, _, err = repository.NewEventRepository(client, repository.WithCollectionGroup()).List(ctx, search)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		var ids []string
+		for _, e := range events {
+			ids = append(ids, e.Id)
+		}
+		if !reflect.DeepEqual(ids, []string{"group_root", "group_t1", "group_t2"}) {
+			t.Errorf("Expected root and tenant events, got %v", ids)
+		}
+	})
+}
+
+func TestEventRepository_Summarize(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+		for i, price := range []float64{10, 20, 60} {
+			event := &domain.Event{Id: fmt.Sprintf("sum_%d", i), EventName: "Sum", City: "Gdansk", Price: price, CreatedAt: time.Now()}
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		maxPrice := 50.0
+		summary, err := repo.Summarize(ctx, domain.FilterRequest{City: "gdansk", MaxPrice: &maxPrice}, false)
+		if err != nil {
+			t.Fatalf("Summarize failed: %v", err)
+		}
+		if want := (domain.EventSummary{Count: 2, TotalPrice: 30, AvgPrice: 15}); summary != want {
+			t.Errorf("Expected %+v, got %+v", want, summary)
+		}
+	})
+}
+
+func TestEventRepository_AggregatesLeaveOutHiddenEvents(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := context.Background()
+		// "" is an event stored before visibility existed, which is public
+		visibilities := []domain.Visibility{"", domain.VisibilityPublic, domain.VisibilityUnlisted, domain.VisibilityPrivate}
+		for i, v := range visibilities {
+			event := &domain.Event{Id: fmt.Sprintf("vis_%d", i), EventName: "Vis", City: "Gdansk", Type: domain.TypeConcert, Price: float64(10 * (i + 1)), Visibility: v, CreatedAt: time.Now()}
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		summary, err := repo.Summarize(ctx, domain.FilterRequest{City: "gdansk"}, true)
+		if err != nil {
+			t.Fatalf("Summarize failed: %v", err)
+		}
+		if want := (domain.EventSummary{Count: 2, TotalPrice: 30, AvgPrice: 15}); summary != want {
+			t.Errorf("Expected %+v, got %+v", want, summary)
+		}
+
+		stats, err := repo.Stats(ctx, "city", true)
+		if err != nil {
+			t.Fatalf("Stats failed: %v", err)
+		}
+		if len(stats) != 1 || stats[0].Count != 2 || stats[0].TotalPrice != 30 || stats[0].MinPrice != 10 || stats[0].MaxPrice != 20 {
+			t.Errorf("Expected the public events only, got %+v", stats)
+		}
+
+		buckets, err := repo.PriceBuckets(ctx, "Gdansk", []float64{0, 25}, true)
+		if err != nil {
+			t.Fatalf("PriceBuckets failed: %v", err)
+		}
+		if len(buckets) != 2 || buckets[0].Count != 2 || buckets[1].Count != 0 {
+			t.Errorf("Expected both public events below 25, got %+v", buckets)
+		}
+
+		if stats, err := repo.Stats(ctx, "city", false); err != nil || len(stats) != 1 || stats[0].Count != 4 || stats[0].MaxPrice != 40 {
+			t.Errorf("Expected every event without publicOnly, got %+v (err %v)", stats, err)
+		}
+	})
+}
+
+func TestEventRepository_TenantScopesEveryOperation(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+
+		repo := repository.NewEventRepository(client)
+		ctx := domain.WithTenant(context.Background(), "acme")
+		if err := repo.Save(ctx, &domain.Event{Id: "tenant_event", EventName: "Acme", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		defer func() { _, _ = client.Collection(repository.TenantEventsPath("acme")).Doc("tenant_event").Delete(ctx) }()
+
+		// 1. The event lands in the tenant's collection and is read back there
+		if _, err := client.Collection(repository.TenantEventsPath("acme")).Doc("tenant_event").Get(ctx); err != nil {
+			t.Fatalf("Expected the event under tenants/acme/events: %v", err)
+		}
+		if event, err := repo.GetByID(ctx, "tenant_event"); err != nil || event.EventName != "Acme" {
+			t.Errorf("Expected the tenant's event, got %+v %v", event, err)
+		}
+
+		// 2. Neither the root nor another tenant sees it, even through a collection group repository
+		var notFound *domain.NotFoundError
+		if _, err := repo.GetByID(context.Background(), "tenant_event"); !errors.As(err, &notFound) {
+			t.Errorf("Expected NotFoundError at the root, got %v", err)
+		}
+		other := domain.WithTenant(context.Background(), "globex")
+		events, _, err := repository.NewEventRepository(client, repository.WithCollectionGroup()).List(other, domain.SearchRequest{})
+		if err != nil || len(events) != 0 {
+			t.Errorf("Expected no events for another tenant, got %v %v", events, err)
+		}
+	})
+}
+
+func TestFacetRepository_RebuildCountsTheTenantsEvents(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+		testkit.ClearCollections(t, client, repository.CollectionFacets)
+
+		repo := repository.NewEventRepository(client)
+		facets := repository.NewFacetRepository(client)
+		ctx := domain.WithTenant(context.Background(), "acme")
+		if err := repo.Save(context.Background(), &domain.Event{Id: "root_event", City: "Warsaw", Type: domain.TypeConcert, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := repo.Save(ctx, &domain.Event{Id: "tenant_event", City: "Krakow", Type: domain.TypeConcert, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		defer func() {
+			_, _ = client.Collection(repository.TenantEventsPath("acme")).Doc("tenant_event").Delete(ctx)
+			_, _ = client.Collection(repository.CollectionTenants).Doc("acme").Collection(repository.CollectionFacets).Doc("events").Delete(ctx)
+		}()
+
+		rebuilt, err := facets.RebuildEventFacets(ctx)
+		if err != nil {
+			t.Fatalf("RebuildEventFacets failed: %v", err)
+		}
+		if rebuilt.Total != 1 || rebuilt.Cities["Krakow"] != 1 || rebuilt.Cities["Warsaw"] != 0 {
+			t.Errorf("Expected the tenant's event only, got %+v", rebuilt)
+		}
+		if root, err := facets.GetEventFacets(context.Background()); err != nil || root.Total != 0 {
+			t.Errorf("Expected the root facets untouched, got %+v (err %v)", root, err)
+		}
+	})
+}
@@ -1,9 +1,8 @@
 package integration_tests
 
 import (
+	"bibently.com/backend/internal/testkit"
 	"bytes"
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -28,7 +27,7 @@ func TestFirestoreSecurityRules(t *testing.T) {
 
 	// 1. Test Admin User (Should Succeed)
 	t.Run("AdminUser_CanWrite", func(t *testing.T) {
-		token := createEmulatorToken(adminUID, projectID)
+		token := testkit.EmulatorToken(adminUID, projectID)
 		if err := tryWriteEvent(emulatorHost, projectID, token); err != nil {
 			t.Errorf("Admin user failed to write to DB: %v", err)
 		}
@@ -36,7 +35,7 @@ func TestFirestoreSecurityRules(t *testing.T) {
 
 	// 2. Test Stranger User (Should Fail)
 	t.Run("OtherUser_CannotWrite", func(t *testing.T) {
-		token := createEmulatorToken("stranger_user_123", projectID)
+		token := testkit.EmulatorToken("stranger_user_123", projectID)
 		err := tryWriteEvent(emulatorHost, projectID, token)
 		if err == nil {
 			t.Error("Security breach: Non-admin user was able to write to DB!")
@@ -46,25 +45,6 @@ func TestFirestoreSecurityRules(t *testing.T) {
 	})
 }
 
-// createEmulatorToken generates an unsigned JWT that the Firestore Emulator accepts
-// This allows us to "create" a user session on the fly for testing.
-func createEmulatorToken(uid string, projectID string) string {
-	header := `{"alg":"none","typ":"JWT"}`
-	payload := map[string]interface{}{
-		"iss":       "https://securetoken.google.com/" + projectID,
-		"aud":       projectID,
-		"auth_time": 1,
-		"user_id":   uid,
-		"sub":       uid,
-		"iat":       1,
-		"exp":       9999999999,
-	}
-
-	pBytes, _ := json.Marshal(payload)
-	enc := base64.RawURLEncoding
-	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString(pBytes) + "."
-}
-
 // tryWriteEvent attempts to write a document via the Firestore REST API
 func tryWriteEvent(host, project, token string) error {
 	// Construct Emulator REST URL
@@ -3,46 +3,20 @@ package integration_tests
 import (
 	"bibently.com/backend/internal/domain"
 	"bibently.com/backend/internal/repository"
-	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/testkit"
 	"bibently.com/backend/internal/transport"
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 )
 
-func setupIntegration(t *testing.T) (http.Handler, *firestore.Client) {
-
-	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
-		t.Skip("Skipping integration test: FIRESTORE_EMULATOR_HOST not set")
-	}
-
-	ctx := context.Background()
-	projectID := "local-project-id"
-
-	client, err := firestore.NewClient(ctx, projectID)
-	if err != nil {
-		t.Fatalf("Failed to create firestore client: %v", err)
-	}
-
-	eventRepo := repository.NewEventRepository(client)
-	trackingRepo := repository.NewTrackingRepository(client)
-	eventSvc := service.NewEventService(eventRepo)
-	trackingSvc := service.NewTrackingService(trackingRepo)
-
-	router := transport.NewRouter(eventSvc, trackingSvc)
-
-	return router, client
-}
 func TestIntegration_Tracking(t *testing.T) {
 	withFirestore(t, func(t *testing.T, router http.Handler, client *firestore.Client) {
 
@@ -82,52 +56,81 @@ func TestIntegration_Tracking(t *testing.T) {
 	})
 }
 
-func TestIntegration_CreateAndGetEvent(t *testing.T) {
+func TestIntegration_TrackingStats(t *testing.T) {
 	withFirestore(t, func(t *testing.T, router http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
 
-		newEvent := map[string]interface{}{
-			"event_name": "Integration Concert",
-			"city":       "Warsaw",
-			"type":       "concert",
-			"start_time": time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+		for _, action := range []string{"view", "view", "signup"} {
+			body, _ := json.Marshal(map[string]string{"action": action})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tracking/", bytes.NewReader(body)))
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Expected 201 Created, got %d", w.Code)
+			}
 		}
-		body, _ := json.Marshal(newEvent)
 
-		// Use trailing slash for collection
-		req := httptest.NewRequest(http.MethodPost, "/events/", bytes.NewReader(body))
 		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
-
-		if w.Code != http.StatusCreated {
-			t.Fatalf("Expected 201 Created, got %d. Body: %s", w.Code, w.Body.String())
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tracking/stats?interval=hour", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 OK, got %d", w.Code)
 		}
+		var resp struct {
+			Data []domain.TrackingStats `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		// The current hour is the last bucket and sums every rollup shard
+		if len(resp.Data) != 24 {
+			t.Fatalf("Expected 24 hourly buckets, got %d", len(resp.Data))
+		}
+		last := resp.Data[len(resp.Data)-1]
+		if last.Counts["view"] != 2 || last.Counts["signup"] != 1 || last.Total != 3 {
+			t.Errorf("Unexpected counts in the current hour: %+v", last)
+		}
+	})
+}
 
-		var resp domain.APIResponse
-		_ = json.NewDecoder(w.Body).Decode(&resp)
-		eventID := resp.Data.(string)
-
-		// --- Use Path Parameter for GET ---
-		reqGet := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/events/%s", eventID), nil)
-		wGet := httptest.NewRecorder()
-
-		router.ServeHTTP(wGet, reqGet)
+func TestIntegration_TrackingClientEventIDDeduplicates(t *testing.T) {
+	withFirestore(t, func(t *testing.T, router http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
 
-		if wGet.Code != http.StatusOK {
-			t.Fatalf("Expected 200 OK, got %d", wGet.Code)
+		body, _ := json.Marshal(map[string]string{"action": "purchase", "client_event_id": "evt-retry"})
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tracking/", bytes.NewReader(body)))
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Send %d: expected 201 Created, got %d", i, w.Code)
+			}
 		}
 
-		var respGet domain.APIResponse
-		// Dekodujemy do mapy, bo domain.Event ma typy czasowe, które JSON unmarshaluje różnie
-		if err := json.NewDecoder(wGet.Body).Decode(&respGet); err != nil {
-			t.Fatalf("Failed to decode GET response: %v", err)
+		docs, err := client.Collection("tracking").Documents(context.Background()).GetAll()
+		if err != nil || len(docs) != 1 {
+			t.Fatalf("Expected one tracking document, got %d %v", len(docs), err)
+		}
+		stats, err := repository.NewTrackingRepository(client).TrackingStats(context.Background(),
+			domain.TrackingIntervalDay, time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1))
+		if err != nil || len(stats) != 1 || stats[0].Counts["purchase"] != 1 {
+			t.Errorf("Expected the resends not to be counted, got %+v %v", stats, err)
 		}
+	})
+}
 
-		// Konwersja mapy z powrotem na struct lub asercja na mapie
-		// Tutaj użyjemy prostego sprawdzenia JSON
-		respJSON, _ := json.Marshal(respGet.Data)
-		if !bytes.Contains(respJSON, []byte("Integration Concert")) {
-			t.Errorf("Response does not contain event name. Got: %s", string(respJSON))
+func TestIntegration_CreateAndGetEvent(t *testing.T) {
+	withFirestore(t, func(t *testing.T, router http.Handler, client *firestore.Client) {
+		dto := testkit.NewEventBuilder().Named("Integration Concert").InCity("Warsaw").StartingIn(2 * time.Hour).BuildDTO()
+
+		// Use trailing slash for collection
+		var eventID string
+		testkit.DecodeData(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodPost, "/events/", dto)), http.StatusCreated, &eventID)
+
+		var event domain.Event
+		testkit.DecodeData(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodGet, "/events/"+eventID, nil)), http.StatusOK, &event)
+		if event.Id != eventID || event.EventName != dto.EventName || event.City != dto.City {
+			t.Errorf("Unexpected event %+v", event)
 		}
+
+		testkit.AssertError(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodGet, "/events/does-not-exist", nil)), http.StatusNotFound, domain.CodeNotFound)
 	})
 }
 
@@ -261,8 +264,8 @@ func TestIntegration_Pagination(t *testing.T) {
 		}
 
 		// Verify different data (simple check)
-		title1 := data1[0].(map[string]interface{})["EventName"]
-		title2 := data2[0].(map[string]interface{})["EventName"]
+		title1 := data1[0].(map[string]interface{})["event_name"]
+		title2 := data2[0].(map[string]interface{})["event_name"]
 		if title1 == title2 {
 			t.Error("Page 1 and Page 2 data appear identical")
 		}
@@ -295,7 +298,7 @@ func TestIntegration_ComplexFilter(t *testing.T) {
 		found := false
 		for _, e := range events {
 			eMap := e.(map[string]interface{})
-			name := eMap["EventName"].(string)
+			name := eMap["event_name"].(string)
 
 			if name == "Cheap Concert" {
 				found = true
@@ -345,12 +348,14 @@ func TestIntegration_BatchCreateEvents(t *testing.T) {
 			t.Fatalf("Expected 201 Created, got %d. Body: %s", w.Code, w.Body.String())
 		}
 
-		var resp domain.APIResponse
+		var resp struct {
+			Data domain.BatchCreateResult `json:"data"`
+		}
 		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 			t.Fatal(err)
 		}
-		if resp.Data != "Successfully created 2 events" {
-			t.Errorf("Unexpected response message: %v", resp.Data)
+		if len(resp.Data.Created) != 2 || len(resp.Data.Failed) != 0 {
+			t.Errorf("Expected 2 created and 0 failed items, got %+v", resp.Data)
 		}
 
 		// 4. Verify Persistence (Check count)
@@ -365,60 +370,71 @@ func TestIntegration_BatchCreateEvents(t *testing.T) {
 	})
 }
 
-func withFirestore(t *testing.T, testFunc func(t *testing.T, router http.Handler, client *firestore.Client)) {
-	t.Helper()
+func TestIntegration_TicketTiers(t *testing.T) {
+	withFirestore(t, func(t *testing.T, router http.Handler, client *firestore.Client) {
+		// 1. Create the parent event
+		body := []byte(`{"event_name": "Tiered Festival", "city": "Wroclaw", "type": "festival", "start_time": "2025-08-01T12:00:00Z"}`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/events/", bytes.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected 201 Created, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var created domain.APIResponse
+		_ = json.NewDecoder(w.Body).Decode(&created)
+		eventID := created.Data.(string)
+
+		// 2. Add two tiers
+		for _, tier := range []string{
+			`{"name": "VIP", "price": 300, "quantity": 50}`,
+			`{"name": "Early Bird", "price": 80, "quantity": 200, "sales_end": "2025-06-01T00:00:00Z"}`,
+		} {
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/events/%s/tiers", eventID), bytes.NewReader([]byte(tier))))
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Expected 201 Created for tier, got %d. Body: %s", w.Code, w.Body.String())
+			}
+		}
 
-	router, client := setupIntegration(t)
+		// 3. List tiers (cheapest first)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/events/%s/tiers", eventID), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 OK, got %d", w.Code)
+		}
+		var listResp domain.APIResponse
+		_ = json.NewDecoder(w.Body).Decode(&listResp)
+		tiers := listResp.Data.([]interface{})
+		if len(tiers) != 2 {
+			t.Fatalf("Expected 2 tiers, got %d", len(tiers))
+		}
+		if name := tiers[0].(map[string]interface{})["name"]; name != "Early Bird" {
+			t.Errorf("Expected cheapest tier 'Early Bird' first, got %v", name)
+		}
 
-	t.Cleanup(func() {
-		cleanupFirestore(t, client)
-		err := client.Close()
+		// 4. The event is flagged as having tickets
+		doc, err := client.Collection("events").Doc(eventID).Get(context.Background())
 		if err != nil {
-			return
+			t.Fatalf("Failed to read event: %v", err)
+		}
+		if hasTickets, _ := doc.DataAt("has_tickets"); hasTickets != true {
+			t.Errorf("Expected has_tickets=true, got %v", hasTickets)
 		}
-	})
 
-	testFunc(t, router, client)
+		// 5. Tiers of a missing event return 404
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/does-not-exist/tiers", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected 404 Not Found, got %d", w.Code)
+		}
+	})
 }
 
-func cleanupFirestore(t *testing.T, client *firestore.Client) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	collections := []string{"events", "tracking"}
-
-	for _, colName := range collections {
-		iter := client.Collection(colName).Documents(ctx)
-		defer iter.Stop()
-
-		batch := client.Batch()
-		count := 0
-
-		for {
-			doc, err := iter.Next()
-			if errors.Is(err, iterator.Done) {
-				break
-			}
-			if err != nil {
-				t.Fatalf("Failed to iterate documents in %s: %v", colName, err)
-			}
-
-			batch.Delete(doc.Ref)
-			count++
+func withFirestore(t *testing.T, testFunc func(t *testing.T, router http.Handler, client *firestore.Client)) {
+	t.Helper()
 
-			if count == 500 {
-				if _, err := batch.Commit(ctx); err != nil {
-					t.Fatalf("Batch commit failed: %v", err)
-				}
-				batch = client.Batch()
-				count = 0
-			}
-		}
+	client := testkit.NewFirestoreClient(t)
+	// Registered after the client's Close, so it runs first
+	t.Cleanup(func() { testkit.ClearCollections(t, client) })
 
-		if count > 0 {
-			if _, err := batch.Commit(ctx); err != nil {
-				t.Fatalf("Final batch commit failed: %v", err)
-			}
-		}
-	}
+	testFunc(t, transport.NewRouter(testkit.NewServices(client)), client)
 }
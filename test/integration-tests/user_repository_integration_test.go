@@ -0,0 +1,118 @@
+package integration_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/testkit"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestUserRepository_DeleteUserData(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+		ctx := context.Background()
+		const uid = "erase_me"
+
+		events := repository.NewEventRepository(client)
+		for _, id := range []string{"erase_rsvp", "erase_fav"} {
+			if err := events.Save(ctx, &domain.Event{Id: id, EventName: id, CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+		if err := repository.NewRSVPRepository(client).CreateRSVP(ctx, &domain.RSVP{Id: uid, EventID: "erase_rsvp", UserID: uid, CreatedAt: time.Now()}, 0); err != nil {
+			t.Fatalf("CreateRSVP failed: %v", err)
+		}
+		favorites := repository.NewFavoriteRepository(client)
+		if err := favorites.AddFavorite(ctx, uid, "erase_fav"); err != nil {
+			t.Fatalf("AddFavorite failed: %v", err)
+		}
+		// A favorite of a deleted event must not fail the batch
+		if err := events.Save(ctx, &domain.Event{Id: "erase_gone", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := favorites.AddFavorite(ctx, uid, "erase_gone"); err != nil {
+			t.Fatalf("AddFavorite failed: %v", err)
+		}
+		_ = events.Delete(ctx, "erase_gone")
+
+		tracking := repository.NewTrackingRepository(client)
+		for _, user := range []string{uid, uid, "someone_else"} {
+			if err := tracking.SaveTracking(ctx, &domain.TrackingEvent{Action: "view", UserID: user, CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("SaveTracking failed: %v", err)
+			}
+		}
+
+		report, err := repository.NewUserRepository(client).DeleteUserData(ctx, uid)
+		if err != nil {
+			t.Fatalf("DeleteUserData failed: %v", err)
+		}
+		if report.TrackingEvents != 2 || report.RSVPs != 1 || report.Favorites != 2 {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+
+		// Counters follow the deleted documents; other users' tracking is kept
+		if event, _ := events.GetByID(ctx, "erase_rsvp"); event == nil || event.AttendeeCount != 0 {
+			t.Errorf("Expected attendee_count 0, got %+v", event)
+		}
+		if event, _ := events.GetByID(ctx, "erase_fav"); event == nil || event.FavoritesCount != 0 {
+			t.Errorf("Expected favorites_count 0, got %+v", event)
+		}
+		if tracks, _ := tracking.ListTracking(ctx); len(tracks) != 1 || tracks[0].UserID != "someone_else" {
+			t.Errorf("Expected only the other user's tracking, got %+v", tracks)
+		}
+	})
+}
+
+func TestUserRepository_DeleteUserDataKeepsOtherTenantsFavorites(t *testing.T) {
+	withFirestore(t, func(t *testing.T, _ http.Handler, client *firestore.Client) {
+		testkit.ClearCollections(t, client)
+		const uid = "erase_tenant"
+		root := context.Background()
+		acme := domain.WithTenant(root, "acme")
+		userFavorites := client.Collection(repository.CollectionUsers).Doc(uid).Collection(repository.CollectionFavorites)
+		// users/{uid} has no fields, so ClearCollections does not reach the favorites of a previous run
+		_, _ = userFavorites.Doc("erase_root_fav").Delete(root)
+		defer func() {
+			_, _ = userFavorites.Doc("erase_root_fav").Delete(root)
+			_, _ = client.Collection(repository.TenantEventsPath("acme")).Doc("erase_acme_fav").Delete(root)
+		}()
+
+		events := repository.NewEventRepository(client)
+		favorites := repository.NewFavoriteRepository(client)
+		for _, fav := range []struct {
+			ctx context.Context
+			id  string
+		}{{root, "erase_root_fav"}, {acme, "erase_acme_fav"}} {
+			if err := events.Save(fav.ctx, &domain.Event{Id: fav.id, EventName: fav.id, CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if err := favorites.AddFavorite(fav.ctx, uid, fav.id); err != nil {
+				t.Fatalf("AddFavorite failed: %v", err)
+			}
+		}
+
+		report, err := repository.NewUserRepository(client).DeleteUserData(acme, uid)
+		if err != nil {
+			t.Fatalf("DeleteUserData failed: %v", err)
+		}
+		if report.Favorites != 1 {
+			t.Errorf("Expected the tenant's favorite only, got %+v", report)
+		}
+
+		// The root favorite and its counter are left to an erase in the root
+		if event, _ := events.GetByID(acme, "erase_acme_fav"); event == nil || event.FavoritesCount != 0 {
+			t.Errorf("Expected favorites_count 0 in the tenant, got %+v", event)
+		}
+		if event, _ := events.GetByID(root, "erase_root_fav"); event == nil || event.FavoritesCount != 1 {
+			t.Errorf("Expected favorites_count 1 in the root, got %+v", event)
+		}
+		if left, _ := favorites.ListFavorites(root, uid); len(left) != 1 || left[0].EventID != "erase_root_fav" {
+			t.Errorf("Expected the root favorite to be kept, got %+v", left)
+		}
+	})
+}
@@ -2,7 +2,9 @@ package test
 
 import (
 	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
 	"context"
+	"time"
 )
 
 // MockRepository manually implements Repository for testing
@@ -10,9 +12,23 @@ type MockRepository struct {
 	SaveFunc      func(ctx context.Context, event *domain.Event) error
 	BatchSaveFunc func(ctx context.Context, events []*domain.Event) error
 	UpdateFunc    func(ctx context.Context, id string, updates map[string]interface{}) error
+	UpdateTxFunc  func(ctx context.Context, id string, fn repository.EventUpdateFunc) error
 	GetByIDFunc   func(ctx context.Context, id string) (*domain.Event, error)
+	GetBySlugFunc func(ctx context.Context, slug string) (*domain.Event, error)
+	GetMultiFunc  func(ctx context.Context, ids []string) ([]domain.Event, error)
 	DeleteFunc    func(ctx context.Context, id string) error
-	ListFunc      func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, string, error)
+	ListFunc      func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error)
+	StreamFunc    func(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error
+
+	IncrementViewsFunc func(ctx context.Context, id string) error
+	GetViewCountFunc   func(ctx context.Context, id string) (int64, error)
+	StatsFunc          func(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error)
+	SummarizeFunc      func(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error)
+	PriceBucketsFunc   func(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error)
+	ListFeaturedFunc   func(ctx context.Context, now time.Time) ([]domain.Event, error)
+	SaveUniqueFunc     func(ctx context.Context, event *domain.Event) (string, error)
+	ArchiveFunc        func(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	SetPopularityFunc  func(ctx context.Context, scores map[string]float64) (int, int, error)
 }
 
 func (m *MockRepository) Save(ctx context.Context, event *domain.Event) error {
@@ -36,6 +52,23 @@ func (m *MockRepository) Update(ctx context.Context, id string, updates map[stri
 	return nil
 }
 
+// UpdateInTransaction defaults to GetByID, fn and Update in sequence, so tests stubbing those
+// two see transactional updates like plain ones
+func (m *MockRepository) UpdateInTransaction(ctx context.Context, id string, fn repository.EventUpdateFunc) error {
+	if m.UpdateTxFunc != nil {
+		return m.UpdateTxFunc(ctx, id, fn)
+	}
+	current, err := m.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	updates, err := fn(current)
+	if err != nil || len(updates) == 0 {
+		return err
+	}
+	return m.Update(ctx, id, updates)
+}
+
 func (m *MockRepository) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 	if m.GetByIDFunc != nil {
 		return m.GetByIDFunc(ctx, id)
@@ -43,6 +76,20 @@ func (m *MockRepository) GetByID(ctx context.Context, id string) (*domain.Event,
 	return nil, nil
 }
 
+func (m *MockRepository) GetBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	if m.GetBySlugFunc != nil {
+		return m.GetBySlugFunc(ctx, slug)
+	}
+	return nil, domain.ErrNotFound("event not found")
+}
+
+func (m *MockRepository) GetMulti(ctx context.Context, ids []string) ([]domain.Event, error) {
+	if m.GetMultiFunc != nil {
+		return m.GetMultiFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
 func (m *MockRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
@@ -50,9 +97,101 @@ func (m *MockRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockRepository) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, string, error) {
+func (m *MockRepository) List(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx, search)
 	}
-	return nil, "", nil
+	return nil, domain.Meta{}, nil
+}
+
+func (m *MockRepository) Stream(ctx context.Context, search domain.SearchRequest, fn func(*domain.Event) error) error {
+	if m.StreamFunc != nil {
+		return m.StreamFunc(ctx, search, fn)
+	}
+	return nil
+}
+
+func (m *MockRepository) IncrementViews(ctx context.Context, id string) error {
+	if m.IncrementViewsFunc != nil {
+		return m.IncrementViewsFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockRepository) GetViewCount(ctx context.Context, id string) (int64, error) {
+	if m.GetViewCountFunc != nil {
+		return m.GetViewCountFunc(ctx, id)
+	}
+	return 0, nil
+}
+
+func (m *MockRepository) Stats(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, groupBy, publicOnly)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) Summarize(ctx context.Context, filters domain.FilterRequest, publicOnly bool) (domain.EventSummary, error) {
+	if m.SummarizeFunc != nil {
+		return m.SummarizeFunc(ctx, filters, publicOnly)
+	}
+	return domain.EventSummary{}, nil
+}
+
+func (m *MockRepository) PriceBuckets(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error) {
+	if m.PriceBucketsFunc != nil {
+		return m.PriceBucketsFunc(ctx, city, bounds, publicOnly)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) ListFeatured(ctx context.Context, now time.Time) ([]domain.Event, error) {
+	if m.ListFeaturedFunc != nil {
+		return m.ListFeaturedFunc(ctx, now)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) SaveUnique(ctx context.Context, event *domain.Event) (string, error) {
+	if m.SaveUniqueFunc != nil {
+		return m.SaveUniqueFunc(ctx, event)
+	}
+	return "", nil
+}
+
+func (m *MockRepository) ArchivePastEvents(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	if m.ArchiveFunc != nil {
+		return m.ArchiveFunc(ctx, cutoff, limit)
+	}
+	return 0, nil
+}
+
+func (m *MockRepository) SetPopularity(ctx context.Context, scores map[string]float64) (int, int, error) {
+	if m.SetPopularityFunc != nil {
+		return m.SetPopularityFunc(ctx, scores)
+	}
+	return len(scores), 0, nil
+}
+
+// MockRevisionRepository records revisions in memory
+type MockRevisionRepository struct {
+	Recorded   []*domain.EventRevision
+	RecordFunc func(ctx context.Context, revisions ...*domain.EventRevision) error
+	ListFunc   func(ctx context.Context, eventID string) ([]domain.EventRevision, error)
+}
+
+func (m *MockRevisionRepository) Record(ctx context.Context, revisions ...*domain.EventRevision) error {
+	if m.RecordFunc != nil {
+		return m.RecordFunc(ctx, revisions...)
+	}
+	m.Recorded = append(m.Recorded, revisions...)
+	return nil
+}
+
+func (m *MockRevisionRepository) ListRevisions(ctx context.Context, eventID string) ([]domain.EventRevision, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, eventID)
+	}
+	return nil, nil
 }
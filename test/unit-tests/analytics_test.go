@@ -0,0 +1,110 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/analytics"
+	"bibently.com/backend/internal/domain"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+)
+
+// fakeBigQuery serves the tables and insertAll calls of one table, missing until created
+func fakeBigQuery(t *testing.T, insertResponse string) (*httptest.Server, *[]map[string]interface{}, *bool) {
+	var rows []map[string]interface{}
+	created := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tables/events"):
+			if !created {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Not found"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/datasets/analytics/tables"):
+			var table struct {
+				TimePartitioning struct{ Field string } `json:"timePartitioning"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&table)
+			if table.TimePartitioning.Field != "created_at" {
+				t.Errorf("Expected partitioning by created_at, got %q", table.TimePartitioning.Field)
+			}
+			created = true
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tables/events/insertAll"):
+			var req struct {
+				Rows []struct {
+					InsertID string                 `json:"insertId"`
+					JSON     map[string]interface{} `json:"json"`
+				} `json:"rows"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			for _, row := range req.Rows {
+				row.JSON["insert_id"] = row.InsertID
+				rows = append(rows, row.JSON)
+			}
+			_, _ = w.Write([]byte(insertResponse))
+		default:
+			t.Errorf("Unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &rows, &created
+}
+
+func newTestExporter(t *testing.T, srv *httptest.Server) *analytics.BigQueryExporter {
+	exporter, err := analytics.NewBigQueryExporter(context.Background(), "proj", "analytics.events",
+		option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewBigQueryExporter failed: %v", err)
+	}
+	return exporter
+}
+
+func TestBigQueryExporter_EnsureTableAndExport(t *testing.T) {
+	srv, rows, created := fakeBigQuery(t, `{}`)
+	exporter := newTestExporter(t, srv)
+
+	if err := exporter.EnsureTable(context.Background()); err != nil || !*created {
+		t.Fatalf("Expected the table to be created, got %v", err)
+	}
+	if err := exporter.EnsureTable(context.Background()); err != nil {
+		t.Fatalf("Expected an existing table to be kept, got %v", err)
+	}
+
+	ctx := domain.WithTenant(context.Background(), "acme")
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := exporter.ExportTracking(ctx, []domain.TrackingEvent{{Id: "t1", Action: "view", UserID: "u1", CreatedAt: at}}); err != nil {
+		t.Fatalf("ExportTracking failed: %v", err)
+	}
+	if len(*rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(*rows))
+	}
+	row := (*rows)[0]
+	if row["insert_id"] != "t1" || row["action"] != "view" || row["tenant"] != "acme" || row["created_at"] != "2026-05-01T12:00:00Z" {
+		t.Errorf("Unexpected row: %v", row)
+	}
+}
+
+func TestBigQueryExporter_RowErrors(t *testing.T) {
+	srv, _, _ := fakeBigQuery(t, `{"insertErrors": [{"index": 0, "errors": [{"reason": "invalid", "message": "no such field"}]}]}`)
+	exporter := newTestExporter(t, srv)
+
+	err := exporter.ExportTracking(context.Background(), []domain.TrackingEvent{{Id: "t1", Action: "view"}})
+	if err == nil || !strings.Contains(err.Error(), "no such field") {
+		t.Errorf("Expected the row error to be reported, got %v", err)
+	}
+}
+
+func TestNewBigQueryExporter_InvalidTable(t *testing.T) {
+	if _, err := analytics.NewBigQueryExporter(context.Background(), "proj", "events", option.WithoutAuthentication()); err == nil {
+		t.Error("Expected an error for a table without a dataset")
+	}
+}
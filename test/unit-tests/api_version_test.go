@@ -0,0 +1,97 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func versionTestRouter(sunset time.Time) http.Handler {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+	}
+	return transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}, LegacySunset: sunset})
+}
+
+func TestAPIVersion_V1RoutesAreServedWithoutDeprecation(t *testing.T) {
+	rr := httptest.NewRecorder()
+	versionTestRouter(time.Time{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/events/e1", nil))
+
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "e1") {
+		t.Fatalf("expected the event under /v1, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("Deprecation") != "" || rr.Header().Get("Sunset") != "" {
+		t.Errorf("versioned route should not be deprecated: %v", rr.Header())
+	}
+}
+
+func TestAPIVersion_LegacyPathsAreDeprecatedAliases(t *testing.T) {
+	sunset := time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)
+	rr := httptest.NewRecorder()
+	versionTestRouter(sunset).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events/e1", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected the legacy path to keep working, got %d", rr.Code)
+	}
+	if dep := rr.Header().Get("Deprecation"); !strings.HasPrefix(dep, "@") {
+		t.Errorf("expected an RFC 9745 Deprecation header, got %q", dep)
+	}
+	if got := rr.Header().Get("Sunset"); got != "Wed, 30 Jun 2027 00:00:00 GMT" {
+		t.Errorf("unexpected Sunset %q", got)
+	}
+	if link := rr.Header().Get("Link"); link != `</v1/events/e1>; rel="successor-version"` {
+		t.Errorf("unexpected Link %q", link)
+	}
+}
+
+func TestAPIVersion_InfrastructureEndpointsAreNotDeprecated(t *testing.T) {
+	rr := httptest.NewRecorder()
+	versionTestRouter(time.Time{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rr.Code != http.StatusOK || rr.Header().Get("Deprecation") != "" {
+		t.Errorf("expected /healthz unversioned and not deprecated, got %d %v", rr.Code, rr.Header())
+	}
+}
+
+func TestAPIVersion_MissingTrailingSlashIsServedWithoutRedirect(t *testing.T) {
+	var gotCity string
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			gotCity = req.Filters.City
+			return []domain.Event{}, domain.Meta{}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/events?city=Berlin", nil))
+	if rr.Code != http.StatusOK || rr.Header().Get("Location") != "" {
+		t.Fatalf("expected the listing in place, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if gotCity != "Berlin" {
+		t.Errorf("query was not passed through, city = %q", gotCity)
+	}
+}
+
+func TestAPIVersion_PostWithoutTrailingSlashIsServedWithoutRedirect(t *testing.T) {
+	tracked := false
+	mockTrack := &MockTrackingService{
+		TrackFunc: func(ctx context.Context, event *domain.TrackingEvent) error {
+			tracked = true
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/tracking", strings.NewReader(`{"action": "login", "payload": "user_123"}`)))
+	if rr.Code != http.StatusCreated || !tracked {
+		t.Errorf("expected the POST to be handled in place, got %d", rr.Code)
+	}
+}
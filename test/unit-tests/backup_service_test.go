@@ -0,0 +1,236 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeBackupRepo keeps the documents a restore writes, keyed by collection/id
+type fakeBackupRepo struct {
+	docs      map[string]domain.BackupRecord
+	exportURI string
+	importURI string
+	restores  int
+}
+
+func newFakeBackupRepo(records ...domain.BackupRecord) *fakeBackupRepo {
+	r := &fakeBackupRepo{docs: make(map[string]domain.BackupRecord)}
+	for _, record := range records {
+		r.docs[record.Collection+"/"+record.Id] = record
+	}
+	return r
+}
+
+func (r *fakeBackupRepo) Export(_ context.Context, uri string, collections []string) (*domain.BackupOperation, error) {
+	r.exportURI = uri
+	return &domain.BackupOperation{Id: "op-1", Kind: domain.BackupExport, URI: uri, Collections: collections}, nil
+}
+
+func (r *fakeBackupRepo) Import(_ context.Context, uri string, collections []string) (*domain.BackupOperation, error) {
+	r.importURI = uri
+	return &domain.BackupOperation{Id: "op-2", Kind: domain.BackupImport, URI: uri, Collections: collections}, nil
+}
+
+func (r *fakeBackupRepo) Operation(_ context.Context, id string) (*domain.BackupOperation, error) {
+	return nil, domain.ErrNotFound("operation not found")
+}
+
+func (r *fakeBackupRepo) Dump(_ context.Context, collections []string, fn func(domain.BackupRecord) error) error {
+	for _, c := range collections {
+		for _, record := range r.docs {
+			if record.Collection != c {
+				continue
+			}
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeBackupRepo) Restore(_ context.Context, records []domain.BackupRecord, overwrite bool) map[int]error {
+	r.restores++
+	failed := make(map[int]error)
+	for i, record := range records {
+		key := record.Collection + "/" + record.Id
+		if _, ok := r.docs[key]; ok && !overwrite {
+			failed[i] = domain.ErrConflict(key + " already exists")
+			continue
+		}
+		r.docs[key] = record
+	}
+	return failed
+}
+
+func eventRecord(id, name string) domain.BackupRecord {
+	return domain.BackupRecord{Collection: "events", Id: id, Event: &domain.Event{Id: id, EventName: name}}
+}
+
+// recordReader returns the records, then io.EOF, the way the dump restore endpoint reads lines
+func recordReader(records ...domain.BackupRecord) func() (domain.BackupRecord, error) {
+	return func() (domain.BackupRecord, error) {
+		if len(records) == 0 {
+			return domain.BackupRecord{}, io.EOF
+		}
+		record := records[0]
+		records = records[1:]
+		return record, nil
+	}
+}
+
+func TestBackupService_ManagedBackupsStayInTheBucket(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeBackupRepo()
+	svc := service.NewBackupService(repo, "gs://my-backups/")
+
+	op, err := svc.StartExport(ctx, domain.BackupRequest{Collections: []string{"events"}})
+	if err != nil {
+		t.Fatalf("StartExport failed: %v", err)
+	}
+	if !strings.HasPrefix(repo.exportURI, "gs://my-backups/backups/") || op.URI != repo.exportURI {
+		t.Errorf("Expected an export into gs://my-backups/backups/, got %q", repo.exportURI)
+	}
+
+	for _, source := range []string{"gs://other-bucket/backups/x", "gs://my-backups-evil/x", "gs://my-backups/../x"} {
+		var ve *domain.ValidationError
+		if _, err := svc.StartImport(ctx, domain.RestoreRequest{Source: source}); !errors.As(err, &ve) {
+			t.Errorf("Expected a validation error importing %s, got %v", source, err)
+		}
+	}
+	if _, err := svc.StartImport(ctx, domain.RestoreRequest{Source: "gs://my-backups/backups/20261016T120000Z/"}); err != nil {
+		t.Fatalf("StartImport failed: %v", err)
+	}
+	if repo.importURI != "gs://my-backups/backups/20261016T120000Z" {
+		t.Errorf("Unexpected import source %q", repo.importURI)
+	}
+
+	var ve *domain.ValidationError
+	if _, err := service.NewBackupService(repo, "").StartExport(ctx, domain.BackupRequest{}); !errors.As(err, &ve) {
+		t.Errorf("Expected exports without BACKUP_BUCKET to fail validation, got %v", err)
+	}
+}
+
+func TestBackupService_RestoreConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	existing := eventRecord("e1", "Old")
+	records := []domain.BackupRecord{eventRecord("e1", "New"), eventRecord("e2", "Second")}
+
+	tests := []struct {
+		conflict domain.RestoreConflict
+		restored int
+		skipped  int
+		failed   int
+		stopped  bool
+		name     string
+	}{
+		{conflict: "", restored: 1, skipped: 1, name: "Old"},
+		{conflict: domain.RestoreOverwrite, restored: 2, name: "New"},
+		{conflict: domain.RestoreFail, restored: 1, failed: 1, stopped: true, name: "Old"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.conflict), func(t *testing.T) {
+			repo := newFakeBackupRepo(existing)
+			result, err := service.NewBackupService(repo, "").Restore(ctx, recordReader(records...), tt.conflict)
+			if err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+			if result.Restored != tt.restored || result.Skipped != tt.skipped || len(result.Failed) != tt.failed || result.Stopped != tt.stopped {
+				t.Errorf("Unexpected result %+v", result)
+			}
+			if got := repo.docs["events/e1"].Event.EventName; got != tt.name {
+				t.Errorf("Expected e1 to be named %q, got %q", tt.name, got)
+			}
+		})
+	}
+
+	var ve *domain.ValidationError
+	if _, err := service.NewBackupService(newFakeBackupRepo(), "").Restore(ctx, recordReader(), "merge"); !errors.As(err, &ve) {
+		t.Errorf("Expected an unknown on_conflict to fail validation, got %v", err)
+	}
+}
+
+func TestBackupService_RestoreStopsAfterTheFailingBatch(t *testing.T) {
+	var records []domain.BackupRecord
+	for i := range 1200 {
+		records = append(records, eventRecord(fmt.Sprintf("e%04d", i), "Event"))
+	}
+	repo := newFakeBackupRepo(records[10])
+
+	result, err := service.NewBackupService(repo, "").Restore(context.Background(), recordReader(records...), domain.RestoreFail)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !result.Stopped || repo.restores != 1 || result.Restored != 499 || result.Failed[0].Line != 11 {
+		t.Errorf("Expected the restore to stop after the first batch, got %+v after %d batches", result, repo.restores)
+	}
+}
+
+func TestBackupHandler_DumpRoundTrip(t *testing.T) {
+	source := newFakeBackupRepo(
+		eventRecord("e1", "Concert"),
+		domain.BackupRecord{Collection: "tracking", Id: "t1", Tracking: &domain.TrackingEvent{Id: "t1", EventID: "e1"}},
+	)
+	handler := transport.NewBackupHandler(service.NewBackupService(source, ""))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/backups/dump?collections=events,tracking", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("Expected an NDJSON dump, got %d %s: %s", rr.Code, rr.Header().Get("Content-Type"), rr.Body.String())
+	}
+	lines := 0
+	for scanner := bufio.NewScanner(strings.NewReader(rr.Body.String())); scanner.Scan(); lines++ {
+		var record domain.BackupRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Validate() != nil {
+			t.Errorf("Unexpected dump line %s", scanner.Text())
+		}
+	}
+	if lines != 2 {
+		t.Errorf("Expected 2 lines, got %d", lines)
+	}
+
+	// The dump restores into another database; a bad line is reported by its number
+	target := newFakeBackupRepo()
+	body := rr.Body.String() + "not json\n"
+	req = httptest.NewRequest(http.MethodPost, "/admin/backups/dump", strings.NewReader(body))
+	rr = httptest.NewRecorder()
+	transport.NewBackupHandler(service.NewBackupService(target, "")).ServeHTTP(rr, req)
+
+	var resp struct {
+		Data domain.RestoreResult `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("Expected a restore result, got %d (%v)", rr.Code, err)
+	}
+	if resp.Data.Restored != 2 || len(resp.Data.Failed) != 1 || resp.Data.Failed[0].Line != 3 || len(target.docs) != 2 {
+		t.Errorf("Unexpected restore result %+v", resp.Data)
+	}
+}
+
+func TestBackupHandler_ManagedRoutesNeedABucket(t *testing.T) {
+	handler := transport.NewBackupHandler(service.NewBackupService(newFakeBackupRepo(), ""))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/backups", nil))
+	if rr.Code != http.StatusNotFound && rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected managed exports to be unrouted without BACKUP_BUCKET, got %d", rr.Code)
+	}
+
+	handler = transport.NewBackupHandler(service.NewBackupService(newFakeBackupRepo(), "my-backups"))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/backups", nil))
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("Expected 202, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
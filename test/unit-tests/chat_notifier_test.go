@@ -0,0 +1,78 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/notify"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func chatServer(t *testing.T, posts *[]map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		*posts = append(*posts, payload)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChatWebhookNotifier_PostsAdminChangesOnly(t *testing.T) {
+	var posts []map[string]string
+	server := chatServer(t, &posts)
+	notifier := notify.NewChatWebhookNotifier(server.URL+"/services/T000/B000/xyz", "https://bibently.com")
+
+	event := &domain.Event{EventName: "Jazz Night", City: "Krakow", Slug: "jazz-night", StartTime: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC)}
+	admin := domain.WithPrincipal(context.Background(), domain.Principal{UID: "admin_1", Role: domain.RoleAdmin})
+	organizer := domain.WithPrincipal(context.Background(), domain.Principal{UID: "org_1", Role: domain.RoleOrganizer})
+
+	if err := notifier.EventCreated(organizer, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := notifier.EventCreated(admin, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := notifier.EventCancelled(admin, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(posts) != 2 {
+		t.Fatalf("expected 2 posts (admin only), got %d", len(posts))
+	}
+	if text := posts[0]["text"]; !strings.Contains(text, "<https://bibently.com/events/jazz-night|Jazz Night>") || !strings.Contains(text, "admin_1") {
+		t.Errorf("unexpected Slack message: %q", text)
+	}
+	if text := posts[1]["text"]; !strings.Contains(text, "Cancelled") || !strings.Contains(text, "*Jazz Night*") {
+		t.Errorf("unexpected cancel message: %q", text)
+	}
+}
+
+func TestEventService_DeleteReportsCancellation(t *testing.T) {
+	var posts []map[string]string
+	server := chatServer(t, &posts)
+
+	repo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Jazz Night", City: "Krakow"}, nil
+		},
+	}
+	svc := service.NewEventService(repo, &test.MockRevisionRepository{}, service.WithOpsNotifier(notify.NewChatWebhookNotifier(server.URL, "")))
+
+	admin := domain.WithPrincipal(context.Background(), domain.Principal{UID: "admin_1", Role: domain.RoleAdmin})
+	if err := svc.DeleteEvent(admin, "evt-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posts) != 1 || !strings.Contains(posts[0]["text"], "Cancelled event *Jazz Night*") {
+		t.Errorf("expected a cancellation post, got %v", posts)
+	}
+}
@@ -0,0 +1,71 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/client/bibently"
+	"bibently.com/backend/internal/clientgen"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestClientGen_GeneratedClientsAreUpToDate(t *testing.T) {
+	doc := transport.OpenAPISpec()
+	goSrc, err := clientgen.Go(doc, "bibently")
+	if err != nil {
+		t.Fatalf("generate Go client: %v", err)
+	}
+	tsSrc, err := clientgen.TypeScript(doc)
+	if err != nil {
+		t.Fatalf("generate TypeScript client: %v", err)
+	}
+
+	for path, want := range map[string][]byte{
+		"../../client/bibently/api.gen.go":   goSrc,
+		"../../client/typescript/api.gen.ts": tsSrc,
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run make client", path)
+		}
+	}
+}
+
+func TestClientGen_GoClientAgainstRouter(t *testing.T) {
+	var gotCity string
+	events := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			gotCity = req.Filters.City
+			return []domain.Event{{Id: "e1", City: "Berlin"}}, domain.Meta{NextPageToken: "next"}, nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	server := httptest.NewServer(transport.NewRouter(transport.Services{Events: events, Tracking: &MockTrackingService{}}))
+	defer server.Close()
+	client := bibently.New(server.URL)
+
+	city := "Berlin"
+	page, err := client.ListEvents(context.Background(), &bibently.ListEventsParams{City: &city})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	if gotCity != "Berlin" || len(page.Data) != 1 || page.Data[0].ID != "e1" || page.Meta.NextPageToken == nil || *page.Meta.NextPageToken != "next" {
+		t.Errorf("Expected the page of Berlin events, got city %q and %+v", gotCity, page)
+	}
+
+	_, err = client.GetEvent(context.Background(), "missing", nil)
+	var apiErr *bibently.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound || apiErr.Code != domain.CodeNotFound {
+		t.Errorf("Expected a 404 APIError, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/config"
+	"bibently.com/backend/internal/domain"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadConfig(env map[string]string) (*config.Config, error) {
+	resolve := func(ctx context.Context, value string) (string, error) {
+		if name, ok := strings.CutPrefix(value, "sm://"); ok {
+			if name == "missing" {
+				return "", errors.New("secret not found")
+			}
+			return "resolved-" + name, nil
+		}
+		return value, nil
+	}
+	return config.Load(context.Background(), func(key string) string { return env[key] }, resolve)
+}
+
+func TestConfigLoad_Defaults(t *testing.T) {
+	cfg, err := loadConfig(map[string]string{"GOOGLE_CLOUD_PROJECT": "bibently"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ProjectID != "bibently" || cfg.DatabaseRouting != "header" || cfg.Events.DuplicatePolicy != domain.DuplicateReject {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.Timeouts.Request != 15*time.Second || cfg.Timeouts.SSE != 5*time.Minute || cfg.Cache.TTL != time.Minute {
+		t.Errorf("unexpected timeouts: %+v, cache TTL %v", cfg.Timeouts, cfg.Cache.TTL)
+	}
+	if cfg.RateLimit.RPS != 10 || cfg.RateLimit.Burst != 20 || cfg.Tracking.RetentionDays != domain.DefaultTrackingRetentionDays {
+		t.Errorf("unexpected limits: %+v, retention %d", cfg.RateLimit, cfg.Tracking.RetentionDays)
+	}
+	if cfg.APIDocs != "public" {
+		t.Errorf("expected public API docs outside production, got %q", cfg.APIDocs)
+	}
+
+	// Production keeps the API description for admins
+	cfg, err = loadConfig(map[string]string{"GOOGLE_CLOUD_PROJECT": "bibently", "APP_ENV": "production"})
+	if err != nil || cfg.APIDocs != "admin" {
+		t.Errorf("expected admin-only API docs in production, got %q (%v)", cfg.APIDocs, err)
+	}
+
+	// Memory storage runs without a project
+	cfg, err = loadConfig(map[string]string{"STORAGE_BACKEND": "memory"})
+	if err != nil || cfg.ProjectID != "local-project-id" || !cfg.MemoryStorage {
+		t.Errorf("expected a local project for memory storage, got %+v (%v)", cfg, err)
+	}
+}
+
+func TestConfigLoad_ParsesValues(t *testing.T) {
+	cfg, err := loadConfig(map[string]string{
+		"GOOGLE_CLOUD_PROJECT":     "bibently",
+		"FIRESTORE_DATABASES":      "staging, prod",
+		"PAGE_TOKEN_SECRET":        "sm://page-token",
+		"TASKS_QUEUE":              "projects/p/locations/l/queues/q",
+		"TASKS_WORKER_URL":         "https://worker",
+		"TASKS_SERVICE_ACCOUNT":    "tasks@p",
+		"CRON_SERVICE_ACCOUNT":     "cron@p",
+		"ERROR_REPORTING_ENABLED":  "true",
+		"LEGACY_API_SUNSET":        "2027-06-30T00:00:00Z",
+		"RESPONSE_CACHE_TTL":       "0",
+		"FEATURE_FLAGS":            "guest_read=false, webhooks",
+		"LOG_LEVEL":                "DEBUG",
+		"EVENT_TYPES":              "concert, workshop, concert",
+		"EVENT_URL_STRIP_TRACKING": "true",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Databases) != 2 || cfg.Databases[1] != "prod" {
+		t.Errorf("expected two databases, got %q", cfg.Databases)
+	}
+	if cfg.Events.PageTokenSecret != "resolved-page-token" {
+		t.Errorf("expected the secret reference resolved, got %q", cfg.Events.PageTokenSecret)
+	}
+	if cfg.Cron.Audience != "https://worker" || cfg.ErrorReportingService != "bibently-backend" {
+		t.Errorf("unexpected defaults: audience %q, error reporting %q", cfg.Cron.Audience, cfg.ErrorReportingService)
+	}
+	if !cfg.Flags.Overrides["webhooks"] || cfg.Flags.Overrides["guest_read"] || cfg.Flags.TTL != 30*time.Second {
+		t.Errorf("unexpected flags: %+v", cfg.Flags)
+	}
+	if cfg.LegacySunset.Year() != 2027 || cfg.ResponseCacheTTL != 0 {
+		t.Errorf("unexpected sunset %v or response cache TTL %v", cfg.LegacySunset, cfg.ResponseCacheTTL)
+	}
+	if !slices.Equal(cfg.Events.Types, []domain.EventType{"concert", "workshop"}) || cfg.Events.TypesTTL != time.Minute {
+		t.Errorf("unexpected event types %q or TTL %v", cfg.Events.Types, cfg.Events.TypesTTL)
+	}
+	if !cfg.Events.StripTrackingParams {
+		t.Error("expected tracking parameters to be stripped from event links")
+	}
+	if cfg.LogLevel != slog.LevelDebug {
+		t.Errorf("expected the debug log level, got %v", cfg.LogLevel)
+	}
+}
+
+func TestConfigLoad_ReportsEveryProblem(t *testing.T) {
+	_, err := loadConfig(map[string]string{
+		"RATE_LIMIT_RPS":   "fast",
+		"METRICS_ENABLED":  "yes please",
+		"TRACKING_PRIVACY": "hash",
+		"MAIL_PROVIDER":    "mailgun",
+		"MAIL_API_KEY":     "sm://missing",
+		"SSE_TIMEOUT":      "5",
+		"FEATURE_FLAGS":    "guest_reads",
+		"LOG_LEVEL":        "verbose",
+		"APP_ENV":          "production",
+		"DEBUG_LOG_ROUTES": "/events/**",
+		"EVENT_TYPES":      "concert,Open Air",
+	})
+	var cfgErr *config.Error
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("expected *config.Error, got %v", err)
+	}
+	for _, key := range []string{
+		"GOOGLE_CLOUD_PROJECT", "RATE_LIMIT_RPS", "METRICS_ENABLED", "TRACKING_HASH_KEY",
+		"MAIL_API_KEY", "MAIL_FROM", "MAILGUN_DOMAIN", "TASKS_QUEUE", "SSE_TIMEOUT", "FEATURE_FLAGS",
+		"LOG_LEVEL", "DEBUG_LOG_ROUTES", "EVENT_TYPES",
+	} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected %s in the report:\n%v", key, err)
+		}
+	}
+}
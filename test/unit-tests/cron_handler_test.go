@@ -0,0 +1,114 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newCronRouter(events *MockEventService, tracking *MockTrackingService, verify func(*http.Request) error) http.Handler {
+	maintenance := service.NewMaintenanceService(events, tracking, &recordingFacetService{}, service.MaintenancePolicy{ArchiveAfterDays: 7})
+	return transport.NewRouter(transport.Services{
+		Events:       events,
+		Tracking:     tracking,
+		Maintenance:  maintenance,
+		CronVerifier: verify,
+	})
+}
+
+func TestCronDaily_RunsAllSteps(t *testing.T) {
+	var archiveDays int
+	events := &MockEventService{
+		ArchiveFunc: func(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error) {
+			archiveDays = olderThanDays
+			return &domain.ArchiveResult{Archived: 3}, nil
+		},
+	}
+	tracking := &MockTrackingService{
+		PurgeFunc: func(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error) {
+			return &domain.PurgeResult{Purged: 40}, nil
+		},
+	}
+	router := newCronRouter(events, tracking, func(*http.Request) error { return nil })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/cron/daily", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data domain.MaintenanceReport `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if archiveDays != 7 {
+		t.Errorf("expected archive policy of 7 days, got %d", archiveDays)
+	}
+	if resp.Data.Archive == nil || resp.Data.Archive.Archived != 3 || resp.Data.Tracking == nil || resp.Data.Tracking.Purged != 40 || resp.Data.Popularity == nil || resp.Data.Facets == nil {
+		t.Errorf("unexpected report: %+v", resp.Data)
+	}
+}
+
+func TestCronDaily_FailingStepDoesNotStopOthers(t *testing.T) {
+	purged := false
+	events := &MockEventService{
+		ArchiveFunc: func(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error) {
+			return nil, errors.New("deadline exceeded")
+		},
+	}
+	tracking := &MockTrackingService{
+		PurgeFunc: func(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error) {
+			purged = true
+			return &domain.PurgeResult{}, nil
+		},
+	}
+	router := newCronRouter(events, tracking, func(*http.Request) error { return nil })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/cron/daily", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 so the scheduler retries, got %d", w.Code)
+	}
+	if !purged {
+		t.Error("expected tracking purge to run after the archive failure")
+	}
+	var resp struct {
+		Data domain.MaintenanceReport `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Errors["archive"] == "" || resp.Data.Tracking == nil {
+		t.Errorf("unexpected report: %+v", resp.Data)
+	}
+}
+
+func TestCronDaily_RejectsUnverifiedCaller(t *testing.T) {
+	ran := false
+	events := &MockEventService{
+		ArchiveFunc: func(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error) {
+			ran = true
+			return &domain.ArchiveResult{}, nil
+		},
+	}
+	router := newCronRouter(events, &MockTrackingService{}, func(*http.Request) error { return errors.New("bad audience") })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/cron/daily", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+	if ran {
+		t.Error("expected no maintenance for an unverified caller")
+	}
+}
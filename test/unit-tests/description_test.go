@@ -0,0 +1,77 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"markdown", "## Line-up\n\n- **Band** at *9pm*", "## Line-up\n\n- **Band** at *9pm*"},
+		{"formatting", "<p>Doors at <strong>8</strong></p><ul><li>Bar</li></ul>", "<p>Doors at <strong>8</strong></p><ul><li>Bar</li></ul>"},
+		{"script", `Hello<script>alert("x")</script>`, "Hello"},
+		{"event handler", `<p onclick="steal()">Hi</p>`, "<p>Hi</p>"},
+		{"javascript link", `<a href="javascript:alert(1)">click</a>`, "click"},
+		{"link", `<a href="https://example.com/tickets">tickets</a>`, `<a href="https://example.com/tickets" rel="nofollow noopener" target="_blank">tickets</a>`},
+		{"iframe and image", `<iframe src="https://evil.example"></iframe><img src=x onerror=alert(1)>Text`, "Text"},
+		{"style", `<style>body{display:none}</style><em style="color:red">hi</em>`, "<em>hi</em>"},
+		{"whitespace", "  plain  ", "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.SanitizeDescription(tt.in); got != tt.want {
+				t.Errorf("SanitizeDescription(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventDTOToModel_SanitizesDescription(t *testing.T) {
+	dto := domain.EventDTO{
+		EventName:   "Jazz",
+		City:        "Berlin",
+		Type:        domain.TypeConcert,
+		StartTime:   "2030-07-20T22:00:00Z",
+		Description: `<b>Live</b><script>alert(1)</script>`,
+	}
+
+	event, err := domain.EventDTOToModel(&dto)
+	if err != nil {
+		t.Fatalf("EventDTOToModel: %v", err)
+	}
+	if event.Description != "<b>Live</b>" {
+		t.Errorf("Expected the script to be stripped, got %q", event.Description)
+	}
+}
+
+func TestHandler_UpdateEvent_SanitizesDescription(t *testing.T) {
+	var got interface{}
+	mockSvc := &MockEventService{
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			got = updates["description"]
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	body := `{"description": "<p onmouseover=\"x()\">Doors at 8</p><script>x()</script>"}`
+	req := httptest.NewRequest(http.MethodPut, "/events/123", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got != "<p>Doors at 8</p>" {
+		t.Errorf("Expected the sanitized description to be written, got %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetEvent_ConditionalGet(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Concert", UpdatedAt: updatedAt}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/evt-1", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d and %q", w.Code, etag)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected default Cache-Control no-cache, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/events/evt-1", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected an empty 304 body, got %q", w.Body.String())
+	}
+
+	// A write moves updated_at and invalidates the tag
+	updatedAt = updatedAt.Add(time.Minute)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 after the event changed, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListEvents_ETagAndCacheControl(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			return []domain.Event{{Id: "1", EventName: "Concert"}}, domain.Meta{}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{
+		Events:            mockSvc,
+		Tracking:          &MockTrackingService{},
+		EventCacheControl: "public, max-age=30",
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/", nil))
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=30" {
+		t.Errorf("Expected configured Cache-Control, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+	req.Header.Set("If-None-Match", `"other", `+w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 when any listed ETag matches, got %d", w.Code)
+	}
+}
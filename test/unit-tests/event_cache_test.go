@@ -0,0 +1,223 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/cache"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/test"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachedEventRepository_CachesReadsUntilAWrite(t *testing.T) {
+	gets, lists := 0, 0
+	mock := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			gets++
+			return &domain.Event{Id: id, EventName: fmt.Sprintf("read %d", gets)}, nil
+		},
+		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			lists++
+			return []domain.Event{{Id: "1"}}, domain.Meta{NextPageToken: "next"}, nil
+		},
+	}
+	repo := repository.NewCachedEventRepository(mock, cache.NewMemoryStore(), time.Minute)
+	ctx := context.Background()
+	search := domain.SearchRequest{Filters: domain.FilterRequest{City: "Warsaw"}}
+
+	for i := 0; i < 3; i++ {
+		event, err := repo.GetByID(ctx, "1")
+		if err != nil || event.EventName != "read 1" {
+			t.Fatalf("expected the cached first read, got %+v %v", event, err)
+		}
+		_, meta, err := repo.List(ctx, search)
+		if err != nil || meta.NextPageToken != "next" {
+			t.Fatalf("expected the cached page, got %+v %v", meta, err)
+		}
+	}
+	if gets != 1 || lists != 1 {
+		t.Fatalf("expected one read each, got %d gets and %d lists", gets, lists)
+	}
+
+	// Later pages are not cached
+	next := search
+	next.Sorting.PageToken = "next"
+	_, _, _ = repo.List(ctx, next)
+	_, _, _ = repo.List(ctx, next)
+	if lists != 3 {
+		t.Errorf("expected later pages to read through, got %d lists", lists)
+	}
+
+	// A write drops the event and every cached list
+	if err := repo.Update(ctx, "1", map[string]interface{}{"price": 10.0}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	event, _ := repo.GetByID(ctx, "1")
+	_, _, _ = repo.List(ctx, search)
+	if event.EventName != "read 2" || lists != 4 {
+		t.Errorf("expected fresh reads after the write, got %q and %d lists", event.EventName, lists)
+	}
+}
+
+func TestCachedEventRepository_NotFoundIsNotCached(t *testing.T) {
+	gets := 0
+	mock := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			gets++
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	repo := repository.NewCachedEventRepository(mock, cache.NewMemoryStore(), time.Minute)
+
+	for i := 0; i < 2; i++ {
+		var notFound *domain.NotFoundError
+		if _, err := repo.GetByID(context.Background(), "missing"); !errors.As(err, &notFound) {
+			t.Fatalf("expected NotFoundError, got %v", err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("expected both lookups to read through, got %d", gets)
+	}
+}
+
+// A failing store degrades to reading through, not to errors
+func TestCachedEventRepository_StoreDownReadsThrough(t *testing.T) {
+	mock := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+	}
+	store := cache.NewRedisStore("127.0.0.1:1", "")
+	repo := repository.NewCachedEventRepository(mock, store, time.Minute)
+
+	if event, err := repo.GetByID(context.Background(), "1"); err != nil || event.Id != "1" {
+		t.Errorf("expected a read-through, got %+v %v", event, err)
+	}
+}
+
+// fakeRedis serves GET, SET (with PX), DEL, INCR and AUTH over RESP from a map
+func fakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	data := make(map[string]string)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				authed := password == ""
+				for {
+					args, err := readCommand(r)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					reply := "+OK\r\n"
+					switch cmd := strings.ToUpper(args[0]); {
+					case cmd == "AUTH":
+						if authed = args[1] == password; !authed {
+							reply = "-WRONGPASS invalid password\r\n"
+						}
+					case !authed:
+						reply = "-NOAUTH Authentication required.\r\n"
+					case cmd == "GET":
+						if v, ok := data[args[1]]; ok {
+							reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+						} else {
+							reply = "$-1\r\n"
+						}
+					case cmd == "SET":
+						data[args[1]] = args[2]
+					case cmd == "DEL":
+						for _, key := range args[1:] {
+							delete(data, key)
+						}
+						reply = fmt.Sprintf(":%d\r\n", len(args)-1)
+					case cmd == "INCR":
+						n, _ := strconv.Atoi(data[args[1]])
+						data[args[1]] = strconv.Itoa(n + 1)
+						reply = fmt.Sprintf(":%d\r\n", n+1)
+					}
+					mu.Unlock()
+					if _, err := io.WriteString(conn, reply); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil { // $len
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisStore_Commands(t *testing.T) {
+	store := cache.NewRedisStore(fakeRedis(t, "secret"), "secret")
+	ctx := context.Background()
+
+	if _, ok, err := store.Get(ctx, "k"); ok || err != nil {
+		t.Fatalf("expected a miss, got %v %v", ok, err)
+	}
+	if err := store.Set(ctx, "k", []byte("v 1"), time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if v, ok, err := store.Get(ctx, "k"); !ok || err != nil || string(v) != "v 1" {
+		t.Fatalf("expected v 1, got %q %v %v", v, ok, err)
+	}
+	if err := store.Delete(ctx, "k"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, "k"); ok {
+		t.Error("expected the key to be deleted")
+	}
+	for want := int64(1); want <= 2; want++ {
+		if n, err := store.Incr(ctx, "gen"); err != nil || n != want {
+			t.Errorf("expected %d, got %d %v", want, n, err)
+		}
+	}
+}
+
+func TestRedisStore_WrongPassword(t *testing.T) {
+	store := cache.NewRedisStore(fakeRedis(t, "secret"), "wrong")
+	var redisErr cache.RedisError
+	if _, _, err := store.Get(context.Background(), "k"); !errors.As(err, &redisErr) {
+		t.Errorf("expected a RedisError, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestEventIndexes_CoverFilteredAndSortedLists(t *testing.T) {
+	indexes := repository.EventIndexes(repository.IndexOptions{})
+
+	// type == x, city prefix, sorted by price descending
+	want := repository.CompositeIndex{CollectionGroup: "events", QueryScope: "COLLECTION", Fields: []repository.IndexField{
+		{FieldPath: "type", Order: "ASCENDING"},
+		{FieldPath: "city_lc", Order: "DESCENDING"},
+		{FieldPath: "price", Order: "DESCENDING"},
+		{FieldPath: "id", Order: "ASCENDING"},
+	}}
+	if !containsIndex(indexes, want) {
+		t.Errorf("missing index %s", want.Key())
+	}
+
+	// end_date filters end_time, which follows the explicit orders
+	want = repository.CompositeIndex{CollectionGroup: "events", QueryScope: "COLLECTION", Fields: []repository.IndexField{
+		{FieldPath: "start_time", Order: "ASCENDING"},
+		{FieldPath: "id", Order: "ASCENDING"},
+		{FieldPath: "end_time", Order: "ASCENDING"},
+	}}
+	if !containsIndex(indexes, want) {
+		t.Errorf("missing index %s", want.Key())
+	}
+
+	seen := make(map[string]bool)
+	for _, idx := range indexes {
+		if seen[idx.Key()] {
+			t.Errorf("duplicate index %s", idx.Key())
+		}
+		seen[idx.Key()] = true
+		if len(idx.Fields) < 2 {
+			t.Errorf("single-field index %s is served automatically", idx.Key())
+		}
+	}
+}
+
+func TestEventIndexes_MaxRangeFiltersNarrowsTheSet(t *testing.T) {
+	all := repository.EventIndexes(repository.IndexOptions{SortKeys: domain.EventListSortKeys})
+	single := repository.EventIndexes(repository.IndexOptions{SortKeys: domain.EventListSortKeys, MaxRangeFilters: 1})
+	if len(single) == 0 || len(single) >= len(all) {
+		t.Errorf("expected a smaller non-empty set, got %d of %d", len(single), len(all))
+	}
+}
+
+// The checked-in index file must cover the scope `make indexes` generates
+func TestFirestoreIndexesFile_IsUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../firestore.indexes.json")
+	if err != nil {
+		t.Fatalf("read index file: %v", err)
+	}
+	var file struct {
+		Indexes []repository.CompositeIndex `json:"indexes"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("parse index file: %v", err)
+	}
+	for _, idx := range repository.EventIndexes(repository.IndexOptions{SortKeys: domain.EventListSortKeys, MaxRangeFilters: 1}) {
+		if !containsIndex(file.Indexes, idx) {
+			t.Errorf("firestore.indexes.json lacks %s; run make indexes", idx.Key())
+		}
+	}
+}
+
+func containsIndex(indexes []repository.CompositeIndex, want repository.CompositeIndex) bool {
+	for _, idx := range indexes {
+		if idx.Key() == want.Key() {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEventIndexes_CollectionGroupScope(t *testing.T) {
+	for _, idx := range repository.EventIndexes(repository.IndexOptions{CollectionGroup: true}) {
+		if idx.QueryScope != "COLLECTION_GROUP" {
+			t.Errorf("index %s is not collection group scoped", idx.Key())
+		}
+	}
+}
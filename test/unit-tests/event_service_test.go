@@ -2,12 +2,16 @@ package unit_tests
 
 import (
 	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/flags"
+	"bibently.com/backend/internal/repository"
 	"bibently.com/backend/internal/service"
 	"bibently.com/backend/test"
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestCreateEvent(t *testing.T) {
@@ -20,7 +24,7 @@ func TestCreateEvent(t *testing.T) {
 		},
 	}
 
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 	event := &domain.Event{EventName: "Go Meetup"}
 
 	err := svc.CreateEvent(context.Background(), event)
@@ -34,7 +38,7 @@ func TestCreateEvent(t *testing.T) {
 
 func TestCreateEvent_Validation(t *testing.T) {
 	mockRepo := &test.MockRepository{} // No methods needed, should fail before repo call
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	// Case: Empty EventName
 	event := &domain.Event{
@@ -71,16 +75,84 @@ func TestBatchCreateEvents(t *testing.T) {
 		},
 	}
 
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	events := []*domain.Event{
 		{EventName: "Event 1", City: "Warsaw"},
 		{EventName: "Event 2", City: "Krakow"},
 	}
 
-	err := svc.BatchCreateEvents(context.Background(), events)
+	result, err := svc.BatchCreateEvents(context.Background(), events)
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Failed) != 0 {
+		t.Errorf("Expected 2 created and 0 failed, got %+v", result)
+	}
+}
+
+func TestBatchCreateEvents_PartialFailure(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		BatchSaveFunc: func(ctx context.Context, events []*domain.Event) error {
+			if len(events) != 2 {
+				t.Errorf("Expected the invalid item to be skipped, got %d events", len(events))
+			}
+			return &domain.BatchSaveError{Failed: map[int]error{1: errors.New("unavailable")}}
+		},
+	}
+	revisions := &test.MockRevisionRepository{}
+	svc := service.NewEventService(mockRepo, revisions)
+
+	events := []*domain.Event{
+		{EventName: "Event 1"},
+		{EventName: ""},
+		{EventName: "Event 3"},
+	}
+	result, err := svc.BatchCreateEvents(context.Background(), events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].Index != 0 {
+		t.Errorf("Expected item 0 to be created, got %+v", result.Created)
+	}
+	if len(result.Failed) != 2 || result.Failed[0].Index != 1 || result.Failed[1].Index != 2 {
+		t.Errorf("Expected items 1 and 2 to fail, got %+v", result.Failed)
+	}
+	if len(revisions.Recorded) != 1 {
+		t.Errorf("Expected history only for the created item, got %d revisions", len(revisions.Recorded))
+	}
+}
+
+func TestEventRevisions_RecordStoredFields(t *testing.T) {
+	ctx := context.Background()
+	revisions := &test.MockRevisionRepository{}
+	svc := service.NewEventService(repository.NewMemoryEventRepository(), revisions)
+
+	event := &domain.Event{Id: "jazz", EventName: "Jazz Night", City: "Berlin", Price: 25}
+	if err := svc.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	if err := svc.UpdateEvent(ctx, "jazz", map[string]interface{}{"event_name": "Jazz Evening", "price": 30.0}); err != nil {
+		t.Fatalf("UpdateEvent: %v", err)
+	}
+	if len(revisions.Recorded) != 2 {
+		t.Fatalf("Expected a create and an update revision, got %d", len(revisions.Recorded))
+	}
+
+	created := revisions.Recorded[0].Changes
+	if created["event_name"].New != "Jazz Night" || created["city"].New != "Berlin" || created["price"].New != 25.0 {
+		t.Errorf("Expected the created fields under their own names, got %v", created)
+	}
+
+	updated := revisions.Recorded[1].Changes
+	if name := updated["event_name"]; name.Old != "Jazz Night" || name.New != "Jazz Evening" {
+		t.Errorf("Expected event_name to change from Jazz Night to Jazz Evening, got %+v", name)
+	}
+	if price := updated["price"]; price.Old != 25.0 || price.New != 30.0 {
+		t.Errorf("Expected price to change from 25 to 30, got %+v", price)
+	}
+	if _, ok := updated["city"]; ok {
+		t.Errorf("Expected no change for the unchanged city, got %+v", updated["city"])
 	}
 }
 
@@ -91,7 +163,7 @@ func TestUpdateEvent(t *testing.T) {
 		},
 	}
 
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	// Case 1: Missing Id
 	err := svc.UpdateEvent(context.Background(), "", map[string]interface{}{"name": "test"})
@@ -115,6 +187,105 @@ func TestUpdateEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_StampsAuditFields(t *testing.T) {
+	var saved *domain.Event
+	var updated map[string]interface{}
+	mockRepo := &test.MockRepository{
+		SaveFunc: func(ctx context.Context, event *domain.Event) error {
+			saved = event
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Old"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			updated = updates
+			return nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+	ctx := domain.WithActor(context.Background(), "user_42")
+
+	if err := svc.CreateEvent(ctx, &domain.Event{EventName: "Concert", PopularityScore: 99}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved.PopularityScore != 0 {
+		t.Errorf("Expected a new event to start with popularity_score 0, got %v", saved.PopularityScore)
+	}
+	if saved.CreatedBy != "user_42" || saved.UpdatedBy != "user_42" {
+		t.Errorf("Expected created_by/updated_by 'user_42', got %q/%q", saved.CreatedBy, saved.UpdatedBy)
+	}
+	if saved.UpdatedAt.IsZero() {
+		t.Error("Expected updated_at to be set on create")
+	}
+
+	if err := svc.UpdateEvent(ctx, "1", map[string]interface{}{"event_name": "New"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated["updated_by"] != "user_42" {
+		t.Errorf("Expected updated_by 'user_42', got %v", updated["updated_by"])
+	}
+	if ts, ok := updated["updated_at"].(time.Time); !ok || ts.IsZero() {
+		t.Errorf("Expected updated_at to be set on update, got %v", updated["updated_at"])
+	}
+}
+
+func TestEventService_OrganizerOwnership(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Gig", CreatedBy: "org_owner"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+	updates := func() map[string]interface{} { return map[string]interface{}{"event_name": "Renamed"} }
+
+	owner := domain.WithPrincipal(context.Background(), domain.Principal{UID: "org_owner", Role: domain.RoleOrganizer})
+	if err := svc.UpdateEvent(owner, "1", updates()); err != nil {
+		t.Errorf("Owner update should succeed, got %v", err)
+	}
+	if err := svc.DeleteEvent(owner, "1"); err != nil {
+		t.Errorf("Owner delete should succeed, got %v", err)
+	}
+
+	other := domain.WithPrincipal(context.Background(), domain.Principal{UID: "org_other", Role: domain.RoleOrganizer})
+	var forbidden *domain.ForbiddenError
+	if err := svc.UpdateEvent(other, "1", updates()); !errors.As(err, &forbidden) {
+		t.Errorf("Expected forbidden error on foreign update, got %v", err)
+	}
+	if err := svc.DeleteEvent(other, "1"); !errors.As(err, &forbidden) {
+		t.Errorf("Expected forbidden error on foreign delete, got %v", err)
+	}
+
+	admin := domain.WithPrincipal(context.Background(), domain.Principal{UID: "admin", Role: domain.RoleAdmin})
+	if err := svc.UpdateEvent(admin, "1", updates()); err != nil {
+		t.Errorf("Admin update should succeed, got %v", err)
+	}
+}
+
+func TestUpdateEvent_RejectsNonWhitelistedFields(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			t.Error("Repository should NOT be called with non-whitelisted fields")
+			return nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	for _, field := range []string{"id", "created_at", "attendee_count", "slug"} {
+		err := svc.UpdateEvent(context.Background(), "123", map[string]interface{}{"event_name": "x", field: "tampered"})
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error for field %q, got %v", field, err)
+		}
+	}
+}
+
 func TestGetEvent(t *testing.T) {
 	expectedEvent := &domain.Event{Id: "123", EventName: "Test Event"}
 	mockRepo := &test.MockRepository{
@@ -126,7 +297,7 @@ func TestGetEvent(t *testing.T) {
 		},
 	}
 
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	// Case 1: Validation
 	_, err := svc.GetEvent(context.Background(), "")
@@ -153,7 +324,7 @@ func TestDeleteEvent(t *testing.T) {
 			return errors.New("db error")
 		},
 	}
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	if err := svc.DeleteEvent(context.Background(), ""); err == nil {
 		t.Error("Expected error for empty Id")
@@ -166,15 +337,15 @@ func TestDeleteEvent(t *testing.T) {
 
 func TestListEvents_PageSizeCap(t *testing.T) {
 	mockRepo := &test.MockRepository{
-		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, string, error) {
+		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
 			if search.Sorting.PageSize != 100 {
 				t.Errorf("Expected PageSize to be capped at 100, got %d", search.Sorting.PageSize)
 			}
-			return []domain.Event{}, "", nil
+			return []domain.Event{}, domain.Meta{}, nil
 		},
 	}
 
-	svc := service.NewEventService(mockRepo)
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
 
 	req := domain.SearchRequest{
 		Sorting: domain.SortRequest{PageSize: 500},
@@ -185,3 +356,388 @@ func TestListEvents_PageSizeCap(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestCreateEvent_Slug(t *testing.T) {
+	taken := "jazz-night-krakow-2025"
+	mockRepo := &test.MockRepository{
+		GetBySlugFunc: func(ctx context.Context, slug string) (*domain.Event, error) {
+			if slug == taken {
+				return &domain.Event{Id: "existing", Slug: slug}, nil
+			}
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+	start := time.Date(2025, 7, 20, 20, 0, 0, 0, time.UTC)
+
+	// Case 1: Free slug, diacritics folded
+	event := &domain.Event{EventName: "Jazz Night!", City: "Łódź", StartTime: start}
+	if err := svc.CreateEvent(context.Background(), event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if event.Slug != "jazz-night-lodz-2025" {
+		t.Errorf("Expected slug 'jazz-night-lodz-2025', got '%s'", event.Slug)
+	}
+
+	// Case 2: Taken slug gets an Id-based suffix
+	event = &domain.Event{Id: "abcdef12-3456", EventName: "Jazz Night", City: "Kraków", StartTime: start}
+	if err := svc.CreateEvent(context.Background(), event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if event.Slug != taken+"-abcdef12" {
+		t.Errorf("Expected slug '%s-abcdef12', got '%s'", taken, event.Slug)
+	}
+}
+
+func TestBatchCreateEvents_UniqueSlugsWithinBatch(t *testing.T) {
+	mockRepo := &test.MockRepository{}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	events := []*domain.Event{
+		{Id: "11111111-aaaa", EventName: "Open Mic", City: "Warsaw"},
+		{Id: "22222222-bbbb", EventName: "Open Mic", City: "Warsaw"},
+	}
+	if _, err := svc.BatchCreateEvents(context.Background(), events); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if events[0].Slug == events[1].Slug {
+		t.Errorf("Expected distinct slugs, both got '%s'", events[0].Slug)
+	}
+}
+
+func TestGetEvent_CountsView(t *testing.T) {
+	increments := 0
+	mockRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+		GetViewCountFunc: func(ctx context.Context, id string) (int64, error) {
+			return 41, nil
+		},
+		IncrementViewsFunc: func(ctx context.Context, id string) error {
+			increments++
+			return nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	event, err := svc.GetEvent(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if increments != 1 {
+		t.Errorf("Expected exactly 1 view increment, got %d", increments)
+	}
+	if event.ViewCount != 42 {
+		t.Errorf("Expected ViewCount 42 (including this view), got %d", event.ViewCount)
+	}
+}
+
+func TestGetEvent_CounterFailureDoesNotBreakRead(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+		IncrementViewsFunc: func(ctx context.Context, id string) error {
+			return errors.New("contention")
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	if _, err := svc.GetEvent(context.Background(), "123"); err != nil {
+		t.Errorf("Expected read to succeed despite counter failure, got %v", err)
+	}
+}
+
+func TestGetEventStats_GroupByWhitelist(t *testing.T) {
+	called := ""
+	mockRepo := &test.MockRepository{
+		StatsFunc: func(ctx context.Context, groupBy string, publicOnly bool) ([]domain.EventStats, error) {
+			called = groupBy
+			return []domain.EventStats{{Group: "Warsaw", Count: 3}}, nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	stats, err := svc.GetEventStats(context.Background(), "city")
+	if err != nil || called != "city" || len(stats) != 1 {
+		t.Errorf("Expected repository stats for 'city', got %v (called with %q, err %v)", stats, called, err)
+	}
+
+	called = ""
+	if _, err := svc.GetEventStats(context.Background(), "price"); err == nil {
+		t.Error("Expected validation error for unsupported group_by")
+	}
+	if called != "" {
+		t.Error("Repository should not be queried for unsupported group_by")
+	}
+}
+
+func TestGetPriceBuckets_Bounds(t *testing.T) {
+	var got []float64
+	mockRepo := &test.MockRepository{
+		PriceBucketsFunc: func(ctx context.Context, city string, bounds []float64, publicOnly bool) ([]domain.PriceBucket, error) {
+			got = bounds
+			return nil, nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	// 1. Defaults apply when no bounds are given
+	if _, err := svc.GetPriceBuckets(context.Background(), "Warsaw", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, domain.DefaultPriceBounds) {
+		t.Errorf("Expected default bounds %v, got %v", domain.DefaultPriceBounds, got)
+	}
+
+	// 2. Invalid bounds are rejected
+	for _, bounds := range [][]float64{{50, 10}, {-5, 10}, {10, 10}} {
+		if _, err := svc.GetPriceBuckets(context.Background(), "", bounds); err == nil {
+			t.Errorf("Expected validation error for bounds %v", bounds)
+		}
+	}
+}
+
+func TestSetFeatured(t *testing.T) {
+	var saved map[string]interface{}
+	mockRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			saved = updates
+			return nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	// 1. Promotion must end in the future
+	if err := svc.SetFeatured(context.Background(), "1", true, time.Now().Add(-time.Hour)); err == nil {
+		t.Error("Expected validation error for featured_until in the past")
+	}
+
+	// 2. Valid promotion stores both fields
+	until := time.Now().Add(24 * time.Hour)
+	if err := svc.SetFeatured(context.Background(), "1", true, until); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved["featured"] != true || saved["featured_until"] != until {
+		t.Errorf("Unexpected updates: %v", saved)
+	}
+
+	// 3. Removing the promotion clears the end time
+	if err := svc.SetFeatured(context.Background(), "1", false, time.Time{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved["featured"] != false || !saved["featured_until"].(time.Time).IsZero() {
+		t.Errorf("Unexpected updates: %v", saved)
+	}
+}
+
+func TestEventHistory_RecordsDiffAndActor(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Old Name", City: "Warsaw", Price: 10}, nil
+		},
+	}
+	revisions := &test.MockRevisionRepository{}
+	svc := service.NewEventService(mockRepo, revisions)
+	ctx := domain.WithActor(context.Background(), "admin-uid")
+
+	// 1. Update records only the changed fields with old and new values
+	if err := svc.UpdateEvent(ctx, "1", map[string]interface{}{"event_name": "New Name", "city": "Warsaw"}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if len(revisions.Recorded) != 1 {
+		t.Fatalf("Expected 1 revision, got %d", len(revisions.Recorded))
+	}
+	rev := revisions.Recorded[0]
+	if rev.Action != domain.RevisionUpdate || rev.Actor != "admin-uid" || rev.EventID != "1" {
+		t.Errorf("Unexpected revision metadata: %+v", rev)
+	}
+	if len(rev.Changes) != 1 || rev.Changes["event_name"].Old != "Old Name" || rev.Changes["event_name"].New != "New Name" {
+		t.Errorf("Expected only event_name in diff, got %v", rev.Changes)
+	}
+
+	// 2. Delete keeps the last state as old values
+	if err := svc.DeleteEvent(ctx, "1"); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+	rev = revisions.Recorded[1]
+	if rev.Action != domain.RevisionDelete || rev.Changes["city"].Old != "Warsaw" || rev.Changes["city"].New != nil {
+		t.Errorf("Unexpected delete revision: %+v", rev)
+	}
+}
+
+func TestCreateEvent_DuplicatePolicy(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		SaveUniqueFunc: func(ctx context.Context, event *domain.Event) (string, error) {
+			return "existing-id", nil
+		},
+	}
+	start := time.Date(2025, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	// 1. Reject surfaces the existing Id in a typed error
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{}, service.WithDuplicatePolicy(domain.DuplicateReject))
+	err := svc.CreateEvent(context.Background(), &domain.Event{EventName: "Jazz", City: "Warsaw", StartTime: start})
+	var dup *domain.DuplicateEventError
+	if !errors.As(err, &dup) || dup.ExistingID != "existing-id" {
+		t.Errorf("Expected DuplicateEventError with existing Id, got %v", err)
+	}
+
+	// 2. ReturnExisting succeeds and reports the existing Id
+	revisions := &test.MockRevisionRepository{}
+	svc = service.NewEventService(mockRepo, revisions, service.WithDuplicatePolicy(domain.DuplicateReturnExisting))
+	event := &domain.Event{EventName: "Jazz", City: "Warsaw", StartTime: start}
+	if err := svc.CreateEvent(context.Background(), event); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.Id != "existing-id" || len(revisions.Recorded) != 0 {
+		t.Errorf("Expected existing Id and no new revision, got %q and %d revisions", event.Id, len(revisions.Recorded))
+	}
+}
+
+func TestDedupKey_CaseInsensitive(t *testing.T) {
+	start := time.Date(2025, 5, 1, 22, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	a := domain.DedupKey("Jazz Night", "Warsaw", start)
+	b := domain.DedupKey(" jazz night", "WARSAW ", start.UTC())
+	if a != b {
+		t.Error("Expected the same key regardless of case, whitespace and time zone")
+	}
+	if a == domain.DedupKey("Jazz Night", "Krakow", start) {
+		t.Error("Expected different keys for different cities")
+	}
+}
+
+func TestArchivePastEvents(t *testing.T) {
+	var gotCutoff time.Time
+	mockRepo := &test.MockRepository{
+		ArchiveFunc: func(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+			gotCutoff = cutoff
+			return 3, nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	// 1. Default age applies when none is given
+	result, err := svc.ArchivePastEvents(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := time.Now().UTC().AddDate(0, 0, -domain.DefaultArchiveAfterDays)
+	if result.Archived != 3 || result.Remaining || gotCutoff.Sub(expected).Abs() > time.Minute {
+		t.Errorf("Unexpected result %+v with cutoff %v", result, gotCutoff)
+	}
+
+	// 2. Negative ages are rejected
+	if _, err := svc.ArchivePastEvents(context.Background(), -1); err == nil {
+		t.Error("Expected validation error for negative age")
+	}
+}
+
+func TestListEvents_PopularityRejectsRangeFilters(t *testing.T) {
+	svc := service.NewEventService(&test.MockRepository{}, &test.MockRevisionRepository{})
+	popular := domain.SortRequest{SortKey: domain.SortPopularity, SortDirection: "desc"}
+
+	var validationErr *domain.ValidationError
+	_, _, err := svc.ListEvents(context.Background(), domain.SearchRequest{
+		Filters: domain.FilterRequest{City: "Warsaw"},
+		Sorting: popular,
+	})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for popularity with a city filter, got %v", err)
+	}
+
+	if _, _, err := svc.ListEvents(context.Background(), domain.SearchRequest{Sorting: popular}); err != nil {
+		t.Errorf("expected popularity without range filters to be accepted, got %v", err)
+	}
+}
+
+func TestListEvents_PopularityBehindFlag(t *testing.T) {
+	off := flags.New(map[string]bool{flags.PopularitySort: false})
+	svc := service.NewEventService(&test.MockRepository{}, &test.MockRevisionRepository{}, service.WithFlags(off))
+
+	var validationErr *domain.ValidationError
+	_, _, err := svc.ListEvents(context.Background(), domain.SearchRequest{
+		Sorting: domain.SortRequest{SortKey: domain.SortPopularity, SortDirection: "desc"},
+	})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError while popularity_sort is off, got %v", err)
+	}
+}
+
+func TestUpdateEvent_RetriedTransactionDiffsAgainstLatestState(t *testing.T) {
+	var written map[string]interface{}
+	mockRepo := &test.MockRepository{
+		// Simulate a concurrent write: the first attempt sees a stale name and is retried
+		UpdateTxFunc: func(ctx context.Context, id string, fn repository.EventUpdateFunc) error {
+			if _, err := fn(&domain.Event{Id: id, EventName: "Stale", City: "Warsaw"}); err != nil {
+				return err
+			}
+			updates, err := fn(&domain.Event{Id: id, EventName: "Latest", City: "Berlin"})
+			written = updates
+			return err
+		},
+	}
+	revisions := &test.MockRevisionRepository{}
+	svc := service.NewEventService(mockRepo, revisions)
+
+	if err := svc.UpdateEvent(context.Background(), "1", map[string]interface{}{"event_name": "New"}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if got := revisions.Recorded[0].Changes["event_name"].Old; got != "Latest" {
+		t.Errorf("revision should record the value replaced by the committed attempt, got %v", got)
+	}
+	if written["dedup_key"] != domain.DedupKey("New", "Berlin", time.Time{}) {
+		t.Errorf("dedup key should use the latest city, got %v", written["dedup_key"])
+	}
+}
+
+func TestUpdateEvent_AbortedTransactionRecordsNothing(t *testing.T) {
+	mockRepo := &test.MockRepository{
+		UpdateTxFunc: func(ctx context.Context, id string, fn repository.EventUpdateFunc) error {
+			return domain.ErrNotFound("event not found")
+		},
+	}
+	revisions := &test.MockRevisionRepository{}
+	svc := service.NewEventService(mockRepo, revisions)
+
+	var notFound *domain.NotFoundError
+	if err := svc.UpdateEvent(context.Background(), "1", map[string]interface{}{"event_name": "New"}); !errors.As(err, &notFound) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if len(revisions.Recorded) != 0 {
+		t.Errorf("no revision should be recorded for an aborted update, got %d", len(revisions.Recorded))
+	}
+}
+
+func TestGetEvents_DedupesAndValidatesIDs(t *testing.T) {
+	var gotIDs []string
+	mockRepo := &test.MockRepository{
+		GetMultiFunc: func(ctx context.Context, ids []string) ([]domain.Event, error) {
+			gotIDs = ids
+			return []domain.Event{{Id: "a"}}, nil
+		},
+	}
+	svc := service.NewEventService(mockRepo, &test.MockRevisionRepository{})
+
+	events, err := svc.GetEvents(context.Background(), []string{"a", "", "b", "a"})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("GetEvents failed: %v %v", events, err)
+	}
+	if !reflect.DeepEqual(gotIDs, []string{"a", "b"}) {
+		t.Errorf("Expected blank and repeated ids to be dropped, got %v", gotIDs)
+	}
+
+	tooMany := make([]string, service.MaxBatchGetIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("id-%d", i)
+	}
+	var validationErr *domain.ValidationError
+	for name, ids := range map[string][]string{"empty": {"", ""}, "slash": {"a/b"}, "too many": tooMany} {
+		if _, err := svc.GetEvents(context.Background(), ids); !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected ValidationError, got %v", name, err)
+		}
+	}
+}
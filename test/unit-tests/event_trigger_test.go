@@ -0,0 +1,156 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/triggers"
+	"context"
+	"testing"
+
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+type recordingFacetService struct {
+	calls         int
+	tenant        string
+	before, after *domain.Event
+}
+
+func (s *recordingFacetService) ApplyEventWrite(ctx context.Context, before, after *domain.Event) error {
+	s.calls++
+	s.tenant = domain.TenantFromContext(ctx)
+	s.before, s.after = before, after
+	return nil
+}
+
+func (s *recordingFacetService) GetEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	return &domain.EventFacets{}, nil
+}
+
+func (s *recordingFacetService) RefreshEventFacets(ctx context.Context) (*domain.EventFacets, error) {
+	return &domain.EventFacets{}, nil
+}
+
+const eventDocName = "projects/p/databases/(default)/documents/events/evt-1"
+
+func firestoreEvent(t *testing.T, subject, contentType string, data []byte) cloudevents.Event {
+	t.Helper()
+	e := cloudevents.NewEvent()
+	e.SetType("google.cloud.firestore.document.v1.written")
+	e.SetSource("//firestore.googleapis.com/projects/p/databases/(default)")
+	e.SetSubject(subject)
+	if err := e.SetData(contentType, data); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func protoDocument(city, eventType string) *firestorepb.Document {
+	str := func(s string) *firestorepb.Value {
+		return &firestorepb.Value{ValueType: &firestorepb.Value_StringValue{StringValue: s}}
+	}
+	return &firestorepb.Document{Name: eventDocName, Fields: map[string]*firestorepb.Value{
+		"city": str(city), "type": str(eventType),
+	}}
+}
+
+func TestEventTrigger_JSONUpdate(t *testing.T) {
+	facets := &recordingFacetService{}
+	handler := triggers.NewEventWriteHandler(facets)
+
+	data := []byte(`{
+		"value": {"name": "` + eventDocName + `", "fields": {"city": {"stringValue": "Krakow"}, "type": {"stringValue": "concert"}}},
+		"oldValue": {"name": "` + eventDocName + `", "fields": {"city": {"stringValue": "Warsaw"}, "type": {"stringValue": "concert"}}}
+	}`)
+	if err := handler(context.Background(), firestoreEvent(t, "documents/events/evt-1", "application/json", data)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if facets.calls != 1 || facets.before == nil || facets.after == nil {
+		t.Fatalf("expected one update call, got %d (%v, %v)", facets.calls, facets.before, facets.after)
+	}
+	if facets.before.City != "Warsaw" || facets.after.City != "Krakow" || facets.after.Id != "evt-1" {
+		t.Errorf("unexpected decode: before=%+v after=%+v", facets.before, facets.after)
+	}
+}
+
+func TestEventTrigger_ProtobufCreate(t *testing.T) {
+	facets := &recordingFacetService{}
+	handler := triggers.NewEventWriteHandler(facets)
+
+	doc, err := proto.Marshal(protoDocument("Gdansk", "festival"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DocumentEventData{value: doc}; old_value is absent on create
+	data := protowire.AppendTag(nil, 1, protowire.BytesType)
+	data = protowire.AppendBytes(data, doc)
+
+	if err := handler(context.Background(), firestoreEvent(t, "documents/events/evt-1", "application/protobuf", data)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if facets.before != nil {
+		t.Errorf("expected no previous document, got %+v", facets.before)
+	}
+	if facets.after == nil || facets.after.City != "Gdansk" || facets.after.Type != "festival" {
+		t.Errorf("unexpected decode: %+v", facets.after)
+	}
+}
+
+func TestEventTrigger_IgnoresSubcollections(t *testing.T) {
+	facets := &recordingFacetService{}
+	handler := triggers.NewEventWriteHandler(facets)
+
+	e := firestoreEvent(t, "documents/events/evt-1/revisions/r1", "application/json", []byte(`{}`))
+	if err := handler(context.Background(), e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if facets.calls != 0 {
+		t.Errorf("expected subcollection write to be ignored, got %d calls", facets.calls)
+	}
+}
+
+func TestEventTrigger_ScopesTenantEvents(t *testing.T) {
+	data := []byte(`{"value": {"name": "projects/p/databases/(default)/documents/tenants/acme/events/evt-1", "fields": {"city": {"stringValue": "Krakow"}}}}`)
+	for subject, want := range map[string]struct {
+		calls  int
+		tenant string
+	}{
+		"documents/events/evt-1":                          {1, ""},
+		"documents/tenants/acme/events/evt-1":             {1, "acme"},
+		"documents/tenants/acme/events/evt-1/revisions/r": {0, ""},
+		"documents/tenants/acme/facets/events":            {0, ""},
+		"documents/tenants/a.b/events/evt-1":              {0, ""},
+	} {
+		facets := &recordingFacetService{}
+		handler := triggers.NewEventWriteHandler(facets)
+		if err := handler(context.Background(), firestoreEvent(t, subject, "application/json", data)); err != nil {
+			t.Fatalf("%s: unexpected error: %v", subject, err)
+		}
+		if facets.calls != want.calls || facets.tenant != want.tenant {
+			t.Errorf("%s: expected %d calls for tenant %q, got %d for %q", subject, want.calls, want.tenant, facets.calls, facets.tenant)
+		}
+	}
+}
+
+func TestEventFacetDelta(t *testing.T) {
+	before := &domain.Event{City: "Warsaw", Type: "concert"}
+	after := &domain.Event{City: "Krakow", Type: "concert"}
+
+	d := domain.EventFacetDelta(before, after)
+	if d.Total != 0 || d.Cities["Warsaw"] != -1 || d.Cities["Krakow"] != 1 || len(d.Types) != 0 {
+		t.Errorf("unexpected move delta: %+v", d)
+	}
+	if !domain.EventFacetDelta(before, before).IsZero() {
+		t.Error("expected unchanged facets to give a zero delta")
+	}
+	if d := domain.EventFacetDelta(nil, after); d.Total != 1 || d.Types["concert"] != 1 {
+		t.Errorf("unexpected create delta: %+v", d)
+	}
+	if d := domain.EventFacetDelta(before, nil); d.Total != -1 || d.Cities["Warsaw"] != -1 {
+		t.Errorf("unexpected delete delta: %+v", d)
+	}
+}
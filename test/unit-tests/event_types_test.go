@@ -0,0 +1,104 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/eventtypes"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeEventTypeSource struct {
+	types []domain.EventType
+	err   error
+	loads int
+}
+
+func (s *fakeEventTypeSource) LoadEventTypes(ctx context.Context) ([]domain.EventType, error) {
+	s.loads++
+	return s.types, s.err
+}
+
+// useEventTypes installs a registry for the test, restoring the defaults afterwards
+func useEventTypes(t *testing.T, r domain.EventTypeRegistry) {
+	t.Helper()
+	domain.SetEventTypeRegistry(r)
+	t.Cleanup(func() { domain.SetEventTypeRegistry(nil) })
+}
+
+func TestEventTypes_Precedence(t *testing.T) {
+	ctx := context.Background()
+	if got := eventtypes.New(nil).EventTypes(ctx); !slices.Equal(got, domain.DefaultEventTypes) {
+		t.Errorf("Expected the default types without configuration, got %v", got)
+	}
+
+	source := &fakeEventTypeSource{}
+	registry := eventtypes.New([]domain.EventType{"concert", "workshop"}, eventtypes.WithSource(source, time.Hour))
+	if got := registry.EventTypes(ctx); !slices.Equal(got, []domain.EventType{"concert", "workshop"}) {
+		t.Errorf("Expected the static types while the source has none, got %v", got)
+	}
+
+	source.types = []domain.EventType{"hackathon"}
+	if got := registry.EventTypes(ctx); !slices.Equal(got, []domain.EventType{"concert", "workshop"}) {
+		t.Errorf("Expected the cached list within the TTL, got %v", got)
+	}
+	if source.loads != 1 {
+		t.Errorf("Expected one load within the TTL, got %d", source.loads)
+	}
+}
+
+func TestEventTypes_FailedReloadKeepsTypes(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeEventTypeSource{types: []domain.EventType{"workshop"}}
+	registry := eventtypes.New(nil, eventtypes.WithSource(source, 0))
+
+	if got := registry.EventTypes(ctx); !slices.Equal(got, []domain.EventType{"workshop"}) {
+		t.Fatalf("Expected the source to replace the static types, got %v", got)
+	}
+	source.types, source.err = nil, errors.New("firestore unavailable")
+	if got := registry.EventTypes(ctx); !slices.Equal(got, []domain.EventType{"workshop"}) {
+		t.Errorf("Expected the last list to survive a failed reload, got %v", got)
+	}
+}
+
+func TestEventTypes_ValidationFollowsRegistry(t *testing.T) {
+	dto := domain.EventDTO{EventName: "Pottery", City: "Berlin", Type: "workshop", StartTime: "2030-07-20T18:00:00Z"}
+	if err := domain.Validate.Struct(dto); err == nil {
+		t.Fatal("Expected workshop to be rejected by the default types")
+	}
+
+	useEventTypes(t, domain.StaticEventTypes{"concert", "workshop"})
+	if err := domain.Validate.Struct(dto); err != nil {
+		t.Errorf("Expected a configured type to validate, got %v", err)
+	}
+	dto.Type = domain.TypeFestival
+	if err := domain.Validate.Struct(dto); err == nil {
+		t.Error("Expected a type the deployment dropped to be rejected")
+	}
+}
+
+func TestHandler_ListEventTypes(t *testing.T) {
+	useEventTypes(t, domain.StaticEventTypes{"concert", "workshop"})
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/types", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var resp struct {
+		Data []domain.EventType `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !slices.Equal(resp.Data, []domain.EventType{"concert", "workshop"}) {
+		t.Errorf("Expected the configured types, got %v", resp.Data)
+	}
+}
@@ -0,0 +1,165 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var (
+	visibilityGuest = context.Background()
+	visibilityUser  = domain.WithPrincipal(context.Background(), domain.Principal{UID: "user"})
+	visibilityOwner = domain.WithPrincipal(context.Background(), domain.Principal{UID: "owner", Role: domain.RoleOrganizer})
+	visibilityAdmin = domain.WithPrincipal(context.Background(), domain.Principal{UID: "admin", Role: domain.RoleAdmin})
+	// Events by Id; "legacy" was stored before events had a visibility
+	visibilityEvents = map[string]domain.Visibility{"public": domain.VisibilityPublic, "unlisted": domain.VisibilityUnlisted, "private": domain.VisibilityPrivate, "legacy": ""}
+)
+
+// newVisibilityService stores one event per visibility, all created by "owner"
+func newVisibilityService(t *testing.T) service.EventService {
+	t.Helper()
+	repo := repository.NewMemoryEventRepository()
+	start := time.Date(2030, 7, 20, 20, 0, 0, 0, time.UTC)
+	for id, v := range visibilityEvents {
+		event := &domain.Event{Id: id, EventName: id, City: "Berlin", StartTime: start, CreatedAt: start, Visibility: v, CreatedBy: "owner"}
+		if err := repo.Save(context.Background(), event); err != nil {
+			t.Fatalf("save %s: %v", id, err)
+		}
+	}
+	return service.NewEventService(repo, &test.MockRevisionRepository{})
+}
+
+func sortedEventIDs(events []domain.Event) []string {
+	return slices.Sorted(slices.Values(eventIDs(events)))
+}
+
+func TestEventService_GetEventHonorsVisibility(t *testing.T) {
+	svc := newVisibilityService(t)
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		readable []string
+	}{
+		{"guest", visibilityGuest, []string{"legacy", "public", "unlisted"}},
+		{"other user", visibilityUser, []string{"legacy", "public", "unlisted"}},
+		{"creator", visibilityOwner, []string{"legacy", "private", "public", "unlisted"}},
+		{"admin", visibilityAdmin, []string{"legacy", "private", "public", "unlisted"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for id := range visibilityEvents {
+				_, err := svc.GetEvent(tt.ctx, id)
+				var notFound *domain.NotFoundError
+				switch want := slices.Contains(tt.readable, id); {
+				case want && err != nil:
+					t.Errorf("Expected %s to be readable, got %v", id, err)
+				case !want && !errors.As(err, &notFound):
+					t.Errorf("Expected %s to be not found, got %v", id, err)
+				}
+			}
+		})
+	}
+}
+
+func TestEventService_ListsOnlyPublicEvents(t *testing.T) {
+	svc := newVisibilityService(t)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"guest", visibilityGuest, []string{"legacy", "public"}},
+		{"creator", visibilityOwner, []string{"legacy", "public"}},
+		{"admin", visibilityAdmin, []string{"legacy", "private", "public", "unlisted"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A sparse fieldset without visibility must not let hidden events through
+			req := domain.SearchRequest{Sorting: domain.SortRequest{PageSize: 10}, Fields: []string{"id", "event_name"}}
+			events, _, err := svc.ListEvents(tt.ctx, req)
+			if err != nil {
+				t.Fatalf("ListEvents: %v", err)
+			}
+			if got := eventIDs(events); !slices.Equal(got, tt.want) {
+				t.Errorf("ListEvents: expected %v, got %v", tt.want, got)
+			}
+
+			var streamed []domain.Event
+			err = svc.StreamEvents(tt.ctx, req, func(e *domain.Event) error {
+				streamed = append(streamed, *e)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("StreamEvents: %v", err)
+			}
+			if got := sortedEventIDs(streamed); !slices.Equal(got, tt.want) {
+				t.Errorf("StreamEvents: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEventService_BatchGetSkipsPrivateEvents(t *testing.T) {
+	svc := newVisibilityService(t)
+
+	events, err := svc.GetEvents(visibilityGuest, []string{"public", "unlisted", "private"})
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+	if got := eventIDs(events); !slices.Equal(got, []string{"public", "unlisted"}) {
+		t.Errorf("Expected unlisted events to be readable by Id and private ones skipped, got %v", got)
+	}
+}
+
+func TestEventDTOToModel_DefaultsToPublic(t *testing.T) {
+	dto := domain.EventDTO{EventName: "Jazz", City: "Berlin", Type: domain.TypeConcert, StartTime: "2030-07-20T22:00:00Z"}
+	event, err := domain.EventDTOToModel(&dto)
+	if err != nil {
+		t.Fatalf("EventDTOToModel: %v", err)
+	}
+	if event.Visibility != domain.VisibilityPublic {
+		t.Errorf("Expected public, got %q", event.Visibility)
+	}
+
+	dto.Visibility = "secret"
+	if err := domain.Validate.Struct(dto); err == nil {
+		t.Error("Expected an unknown visibility to fail validation")
+	}
+}
+
+func TestEventService_AggregatesHonorVisibility(t *testing.T) {
+	svc := newVisibilityService(t)
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		count int64
+	}{
+		{"guest", visibilityGuest, 2},
+		{"creator", visibilityOwner, 2},
+		{"admin", visibilityAdmin, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := svc.SummarizeEvents(tt.ctx, domain.FilterRequest{City: "Berlin"})
+			if err != nil || summary.Count != tt.count {
+				t.Errorf("Expected a summary of %d events, got %+v (err %v)", tt.count, summary, err)
+			}
+
+			stats, err := svc.GetEventStats(tt.ctx, "city")
+			if err != nil || len(stats) != 1 || stats[0].Count != tt.count {
+				t.Errorf("Expected stats of %d events, got %+v (err %v)", tt.count, stats, err)
+			}
+
+			buckets, err := svc.GetPriceBuckets(tt.ctx, "Berlin", []float64{0})
+			if err != nil || len(buckets) != 1 || buckets[0].Count != tt.count {
+				t.Errorf("Expected %d events in the price buckets, got %+v (err %v)", tt.count, buckets, err)
+			}
+		})
+	}
+}
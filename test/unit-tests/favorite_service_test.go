@@ -0,0 +1,90 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+// MockFavoriteRepo for favorites tests
+type MockFavoriteRepo struct {
+	AddFunc    func(ctx context.Context, userID, eventID string) error
+	RemoveFunc func(ctx context.Context, userID, eventID string) error
+	ListFunc   func(ctx context.Context, userID string) ([]domain.Favorite, error)
+}
+
+func (m *MockFavoriteRepo) AddFavorite(ctx context.Context, userID, eventID string) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, userID, eventID)
+	}
+	return nil
+}
+
+func (m *MockFavoriteRepo) RemoveFavorite(ctx context.Context, userID, eventID string) error {
+	if m.RemoveFunc != nil {
+		return m.RemoveFunc(ctx, userID, eventID)
+	}
+	return nil
+}
+
+func (m *MockFavoriteRepo) ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, userID)
+	}
+	return []domain.Favorite{}, nil
+}
+
+func TestFavoriteService_Validation(t *testing.T) {
+	svc := service.NewFavoriteService(&MockFavoriteRepo{})
+
+	if err := svc.AddFavorite(context.Background(), "", "evt_1"); err == nil {
+		t.Error("Expected error for missing user id")
+	}
+	if err := svc.RemoveFavorite(context.Background(), "user_1", ""); err == nil {
+		t.Error("Expected error for missing event id")
+	}
+	if _, err := svc.ListFavorites(context.Background(), ""); err == nil {
+		t.Error("Expected error for missing user id")
+	}
+}
+
+func TestFavoriteHandler_ScopedToAuthenticatedUser(t *testing.T) {
+	var addedFor, addedEvent string
+	repo := &MockFavoriteRepo{
+		AddFunc: func(ctx context.Context, userID, eventID string) error {
+			addedFor, addedEvent = userID, eventID
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{
+		Events:    &MockEventService{},
+		Tracking:  &MockTrackingService{},
+		Favorites: service.NewFavoriteService(repo),
+	})
+
+	// 1. Anonymous callers are rejected
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/me/favorites", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 Unauthorized, got %d", w.Code)
+	}
+
+	// 2. The UID always comes from the verified token
+	req := httptest.NewRequest(http.MethodPost, "/users/me/favorites/evt_7", nil)
+	req = req.WithContext(context.WithValue(req.Context(), transport.UserContextKey, &auth.Token{UID: "user_9"}))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if addedFor != "user_9" || addedEvent != "evt_7" {
+		t.Errorf("Expected favorite user_9/evt_7, got %s/%s", addedFor, addedEvent)
+	}
+}
@@ -0,0 +1,133 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/flags"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeFlagSource struct {
+	flags map[string]bool
+	err   error
+	loads int
+}
+
+func (s *fakeFlagSource) LoadFlags(ctx context.Context) (map[string]bool, error) {
+	s.loads++
+	return s.flags, s.err
+}
+
+func TestFlags_Precedence(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeFlagSource{flags: map[string]bool{flags.Webhooks: true}}
+	set := flags.New(map[string]bool{flags.Webhooks: false, flags.GuestRead: false}, flags.WithSource(source, time.Hour))
+
+	if !set.Enabled(ctx, flags.PopularitySort) {
+		t.Error("expected a flag without overrides to keep its default")
+	}
+	if set.Enabled(ctx, flags.GuestRead) {
+		t.Error("expected the static override to switch guest_read off")
+	}
+	if !set.Enabled(ctx, flags.Webhooks) {
+		t.Error("expected the source to win over the static override")
+	}
+	if set.Enabled(ctx, "no_such_flag") {
+		t.Error("expected unknown flags to be off")
+	}
+	if source.loads != 1 {
+		t.Errorf("expected one load within the TTL, got %d", source.loads)
+	}
+}
+
+func TestFlags_FailedReloadKeepsOverrides(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeFlagSource{flags: map[string]bool{flags.GuestRead: false}}
+	set := flags.New(nil, flags.WithSource(source, 0))
+
+	if set.Enabled(ctx, flags.GuestRead) {
+		t.Fatal("expected the source to switch guest_read off")
+	}
+	source.flags, source.err = nil, errors.New("firestore unavailable")
+	if set.Enabled(ctx, flags.GuestRead) {
+		t.Error("expected the last overrides to survive a failed reload")
+	}
+	if source.loads != 2 {
+		t.Errorf("expected a reload once the TTL passed, got %d loads", source.loads)
+	}
+}
+
+func TestWithFeatureGates(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithFeatureGates(okHandler, flags.New(map[string]bool{flags.Webhooks: false, flags.GuestRead: false}), nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  bool
+		want   int
+	}{
+		{"webhooks off", http.MethodPost, "/webhooks/partner", false, http.StatusNotFound},
+		{"guest gates left to WithGuestGates", http.MethodGet, "/v1/events/123", false, http.StatusOK},
+		{"ungated route", http.MethodGet, "/healthz", false, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestWithGuestGates(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithGuestGates(okHandler, flags.New(map[string]bool{flags.GuestRead: false}), nil)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    bool // an Authorization header, which auth failed to verify unless signedIn
+		signedIn bool
+		want     int
+	}{
+		{"guest catalog read", http.MethodGet, "/v1/events/123", false, false, http.StatusUnauthorized},
+		{"bogus token", http.MethodGet, "/events/123", true, false, http.StatusUnauthorized},
+		{"signed-in catalog read", http.MethodGet, "/events/123", true, true, http.StatusOK},
+		{"guest write left to auth", http.MethodPost, "/events/", false, false, http.StatusOK},
+		{"webhooks left to WithFeatureGates", http.MethodPost, "/webhooks/partner", false, false, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token {
+				req.Header.Set("Authorization", "Bearer bogus")
+			}
+			if tt.signedIn {
+				req = req.WithContext(domain.WithPrincipal(req.Context(), domain.Principal{UID: "u1"}))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/graphql"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+func testSchema() *graphql.Schema {
+	item := &graphql.Object{Name: "Item", Fields: map[string]*graphql.Field{
+		"name": {Type: &graphql.NonNull{OfType: graphql.String}, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(map[string]interface{})["name"], nil
+		}},
+	}}
+	return &graphql.Schema{Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"echo": {
+			Type: graphql.String,
+			Args: map[string]*graphql.Argument{"msg": {Type: &graphql.NonNull{OfType: graphql.String}}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Args["msg"], nil
+			},
+		},
+		"double": {
+			Type: graphql.Int,
+			Args: map[string]*graphql.Argument{"n": {Type: graphql.Int}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Args["n"].(int) * 2, nil
+			},
+		},
+		"items": {
+			Type: &graphql.List{OfType: item},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": nil},
+				}, nil
+			},
+		},
+		"fail": {
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return nil, errors.New("boom")
+			},
+		},
+	}}}
+}
+
+func execJSON(t *testing.T, s *graphql.Schema, req graphql.Request) map[string]interface{} {
+	t.Helper()
+	body, err := json.Marshal(s.Execute(context.Background(), req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	_ = json.Unmarshal(body, &out)
+	return out
+}
+
+func TestGraphQL_AliasesFragmentsAndVariables(t *testing.T) {
+	out := execJSON(t, testSchema(), graphql.Request{
+		Query: `query Q($m: String!, $n: Int = 4) {
+			first: echo(msg: $m)
+			second: echo(msg: "two")
+			double(n: $n)
+			... on Query { hidden: echo(msg: "x") @skip(if: true) }
+			...More
+		}
+		fragment More on Query { again: double(n: 1) }`,
+		Variables: map[string]interface{}{"m": "one"},
+	})
+	data := out["data"].(map[string]interface{})
+	if data["first"] != "one" || data["second"] != "two" || data["double"] != float64(8) || data["again"] != float64(2) {
+		t.Errorf("unexpected data: %v", data)
+	}
+	if _, ok := data["hidden"]; ok {
+		t.Error("@skip(if: true) field should be omitted")
+	}
+	if out["errors"] != nil {
+		t.Errorf("unexpected errors: %v", out["errors"])
+	}
+}
+
+func TestGraphQL_ResolverErrorHasPath(t *testing.T) {
+	out := execJSON(t, testSchema(), graphql.Request{Query: `{ fail echo(msg: "ok") }`})
+	data := out["data"].(map[string]interface{})
+	if data["fail"] != nil || data["echo"] != "ok" {
+		t.Errorf("expected partial data, got %v", data)
+	}
+	errs := out["errors"].([]interface{})
+	first := errs[0].(map[string]interface{})
+	if first["message"] != "boom" || first["path"].([]interface{})[0] != "fail" {
+		t.Errorf("unexpected error: %v", first)
+	}
+}
+
+func TestGraphQL_NonNullPropagatesToNullableParent(t *testing.T) {
+	out := execJSON(t, testSchema(), graphql.Request{Query: `{ items { name } }`})
+	items := out["data"].(map[string]interface{})["items"].([]interface{})
+	if items[0].(map[string]interface{})["name"] != "a" || items[1] != nil {
+		t.Errorf("expected second item nulled, got %v", items)
+	}
+	path := out["errors"].([]interface{})[0].(map[string]interface{})["path"].([]interface{})
+	if len(path) != 3 || path[1] != float64(1) {
+		t.Errorf("unexpected path: %v", path)
+	}
+}
+
+func TestGraphQL_RejectsInvalidDocuments(t *testing.T) {
+	for name, query := range map[string]string{
+		"syntax":   `{ echo(msg: "x" }`,
+		"mutation": `mutation { echo(msg: "x") }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			out := execJSON(t, testSchema(), graphql.Request{Query: query})
+			if out["data"] != nil || out["errors"] == nil {
+				t.Errorf("expected request error, got %v", out)
+			}
+		})
+	}
+}
+
+func TestGraphQL_InvalidFieldsAreFieldErrors(t *testing.T) {
+	out := execJSON(t, testSchema(), graphql.Request{Query: `{ nope echo double(n: "x") }`})
+	data := out["data"].(map[string]interface{})
+	if data["nope"] != nil || data["echo"] != nil || data["double"] != nil {
+		t.Errorf("expected null fields, got %v", data)
+	}
+	if errs := out["errors"].([]interface{}); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+}
+
+func graphQLRouter(events service.EventService, organizers *MockOrganizerRepo) http.Handler {
+	return transport.NewRouter(transport.Services{
+		Events:     events,
+		Organizers: service.NewOrganizerService(organizers),
+		Tracking: &MockTrackingService{StatsFunc: func(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+			return []domain.TrackingStats{
+				{Counts: map[string]int64{"view": 1, "click": 1}, Total: 2},
+				{Counts: map[string]int64{"view": 1}, Total: 1},
+			}, nil
+		}},
+	})
+}
+
+func postGraphQL(t *testing.T, h http.Handler, query string, user *auth.Token) (int, map[string]interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(graphql.Request{Query: query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	if user != nil {
+		req = req.WithContext(context.WithValue(req.Context(), transport.UserContextKey, user))
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	var out map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", rr.Body.String(), err)
+	}
+	return rr.Code, out
+}
+
+func TestGraphQLHandler_EventWithNestedOrganizer(t *testing.T) {
+	events := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Jazz Night", City: "Berlin", Price: 12.5, OrganizerID: "org-1"}, nil
+		},
+	}
+	organizers := &MockOrganizerRepo{GetMultiFunc: func(ctx context.Context, ids []string) ([]domain.Organizer, error) {
+		return []domain.Organizer{{Id: ids[0], Name: "Blue Note"}}, nil
+	}}
+
+	code, out := postGraphQL(t, graphQLRouter(events, organizers), `{ event(id: "e1") { id name price organizer { name } } }`, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, out)
+	}
+	event := out["data"].(map[string]interface{})["event"].(map[string]interface{})
+	if event["name"] != "Jazz Night" || event["price"] != 12.5 {
+		t.Errorf("unexpected event: %v", event)
+	}
+	if event["organizer"].(map[string]interface{})["name"] != "Blue Note" {
+		t.Errorf("organizer not resolved: %v", event)
+	}
+}
+
+func TestGraphQLHandler_EventsPassesFiltersToService(t *testing.T) {
+	var got domain.SearchRequest
+	events := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			got = req
+			return []domain.Event{{Id: "e1", EventName: "A"}}, domain.Meta{NextPageToken: "next"}, nil
+		},
+	}
+	code, out := postGraphQL(t, graphQLRouter(events, &MockOrganizerRepo{}),
+		`{ events(city: "Paris", minPrice: 5, first: 10) { items { id } nextPageToken prevPageToken } }`, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, out)
+	}
+	if got.Filters.City != "Paris" || got.Filters.MinPrice == nil || *got.Filters.MinPrice != 5 || got.Sorting.PageSize != 10 {
+		t.Errorf("unexpected search request: %+v", got)
+	}
+	page := out["data"].(map[string]interface{})["events"].(map[string]interface{})
+	if page["nextPageToken"] != "next" || page["prevPageToken"] != nil || len(page["items"].([]interface{})) != 1 {
+		t.Errorf("unexpected page: %v", page)
+	}
+}
+
+func TestGraphQLHandler_DomainErrorsKeepTheirCode(t *testing.T) {
+	events := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	_, out := postGraphQL(t, graphQLRouter(events, &MockOrganizerRepo{}), `{ event(id: "missing") { id } }`, nil)
+	first := out["errors"].([]interface{})[0].(map[string]interface{})
+	if first["extensions"].(map[string]interface{})["code"] == nil {
+		t.Errorf("expected error code extension, got %v", first)
+	}
+}
+
+func TestGraphQLHandler_TrackingStatsRequiresAdmin(t *testing.T) {
+	h := graphQLRouter(&MockEventService{}, &MockOrganizerRepo{})
+	query := func(principal *domain.Principal) map[string]interface{} {
+		body, _ := json.Marshal(graphql.Request{Query: `{ trackingStats { total actions { action count } } }`})
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+		if principal != nil {
+			req = req.WithContext(domain.WithPrincipal(req.Context(), *principal))
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		var out map[string]interface{}
+		_ = json.Unmarshal(rr.Body.Bytes(), &out)
+		return out
+	}
+
+	for name, principal := range map[string]*domain.Principal{"anonymous": nil, "user": {UID: "u1"}} {
+		if out := query(principal); out["data"].(map[string]interface{})["trackingStats"] != nil || out["errors"] == nil {
+			t.Errorf("expected %s trackingStats to fail, got %v", name, out)
+		}
+	}
+
+	out := query(&domain.Principal{UID: "a1", Role: domain.RoleAdmin})
+	stats := out["data"].(map[string]interface{})["trackingStats"].(map[string]interface{})
+	actions := stats["actions"].([]interface{})
+	if stats["total"] != float64(3) || actions[0].(map[string]interface{})["action"] != "view" {
+		t.Errorf("unexpected stats: %v", stats)
+	}
+}
+
+func TestGraphQLHandler_BatchesOrganizerLookups(t *testing.T) {
+	events := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			return []domain.Event{{Id: "e1", OrganizerID: "o1"}, {Id: "e2", OrganizerID: "o2"}, {Id: "e3", OrganizerID: "o1"}}, domain.Meta{}, nil
+		},
+	}
+	var calls [][]string
+	organizers := &MockOrganizerRepo{GetMultiFunc: func(ctx context.Context, ids []string) ([]domain.Organizer, error) {
+		calls = append(calls, ids)
+		return []domain.Organizer{{Id: "o1", Name: "One"}}, nil
+	}}
+
+	code, out := postGraphQL(t, graphQLRouter(events, organizers), `{ events { items { organizer { name } } } }`, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", code, out)
+	}
+	if len(calls) != 1 || len(calls[0]) != 2 {
+		t.Errorf("expected one lookup of both organizers, got %v", calls)
+	}
+	items := out["data"].(map[string]interface{})["events"].(map[string]interface{})["items"].([]interface{})
+	if items[1].(map[string]interface{})["organizer"] != nil || items[2].(map[string]interface{})["organizer"] == nil {
+		t.Errorf("unexpected organizers: %v", items)
+	}
+}
+
+func TestGraphQLHandler_RejectsExpensiveQueries(t *testing.T) {
+	events := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			t.Error("an over-limit query must not reach the service")
+			return nil, domain.Meta{}, nil
+		},
+	}
+	h := graphQLRouter(events, &MockOrganizerRepo{})
+
+	for name, query := range map[string]string{
+		"nested pages": `{ events(first: 100) { items { organizer { events(first: 100) { items { organizer { name } } } } } } }`,
+		"aliases":      `{ ` + aliasedFields(21) + ` }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			code, out := postGraphQL(t, h, query, nil)
+			if code != http.StatusBadRequest || out["data"] != nil {
+				t.Errorf("expected 400 without data, got %d: %v", code, out)
+			}
+		})
+	}
+}
+
+func aliasedFields(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "e%d: eventStats(groupBy: \"city\") { count } ", i)
+	}
+	return b.String()
+}
+
+func TestGraphQLHandler_GetAndSchema(t *testing.T) {
+	h := graphQLRouter(&MockEventService{
+		FeaturedFunc: func(ctx context.Context) ([]domain.Event, error) { return nil, nil },
+	}, &MockOrganizerRepo{})
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{ featuredEvents { id } }`), nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"featuredEvents":[]`) {
+		t.Errorf("unexpected GET response %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{`), nil)
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a syntax error, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/graphql/schema.graphql", nil)
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if !strings.Contains(rr.Body.String(), "type Event {") || !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("unexpected schema response: %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,249 @@
+package unit_tests
+
+import (
+	eventsv1 "bibently.com/backend/api/events/v1"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeTokenVerifier accepts the tokens it knows
+type fakeTokenVerifier map[string]*auth.Token
+
+func (v fakeTokenVerifier) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	if t, ok := v[idToken]; ok {
+		return t, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+type fakeWatchService struct {
+	changes []domain.EventChange
+	filter  domain.WatchFilter
+}
+
+func (f *fakeWatchService) WatchEvents(ctx context.Context, filter domain.WatchFilter, fn func(domain.EventChange) error) error {
+	f.filter = filter
+	for _, ch := range f.changes {
+		if err := fn(ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newGRPCClient(t *testing.T, svc transport.Services) eventsv1.EventServiceClient {
+	t.Helper()
+	verifier := fakeTokenVerifier{
+		"organizer": {UID: "org-uid", Claims: map[string]interface{}{"role": domain.RoleOrganizer}},
+		"user":      {UID: "user-uid", Claims: map[string]interface{}{}},
+	}
+	lis := bufconn.Listen(1 << 20)
+	server := transport.NewGRPCServer(svc, verifier)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return eventsv1.NewEventServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPC_ListEventsMapsFiltersAndPage(t *testing.T) {
+	var got domain.SearchRequest
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			got = req
+			start := time.Date(2025, 5, 1, 20, 0, 0, 0, time.UTC)
+			return []domain.Event{{Id: "e1", EventName: "Jazz", StartTime: start}}, domain.Meta{NextPageToken: "next"}, nil
+		},
+	}})
+
+	minPrice := 10.0
+	resp, err := client.ListEvents(context.Background(), &eventsv1.ListEventsRequest{City: "Berlin", MinPrice: &minPrice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Filters.City != "Berlin" || got.Filters.MinPrice == nil || *got.Filters.MinPrice != 10 || got.Sorting.PageSize != 20 {
+		t.Errorf("unexpected search request: %+v", got)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Name != "Jazz" || resp.NextPageToken != "next" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+	if resp.Events[0].StartTime.AsTime().Hour() != 20 || resp.Events[0].EndTime != nil {
+		t.Errorf("unexpected times: %v", resp.Events[0])
+	}
+}
+
+func TestGRPC_ErrorsMapToStatusCodes(t *testing.T) {
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}})
+
+	_, err := client.GetEvent(context.Background(), &eventsv1.GetEventRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	_, err = client.ListEvents(context.Background(), &eventsv1.ListEventsRequest{PageSize: 500})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for page_size 500, got %v", err)
+	}
+}
+
+func TestGRPC_WritesRequireOrganizer(t *testing.T) {
+	var created *domain.Event
+	var principal domain.Principal
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{
+		CreateFunc: func(ctx context.Context, event *domain.Event) error {
+			principal, _ = domain.PrincipalFromContext(ctx)
+			event.Id = "new-id"
+			created = event
+			return nil
+		},
+	}})
+	req := &eventsv1.CreateEventRequest{Event: &eventsv1.EventInput{
+		Name:      "Jazz",
+		City:      "Berlin",
+		Type:      "concert",
+		StartTime: timestamppb.New(time.Date(2025, 5, 1, 20, 0, 0, 0, time.UTC)),
+	}}
+
+	if _, err := client.CreateEvent(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := client.CreateEvent(withToken("user"), req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for a plain user, got %v", err)
+	}
+
+	resp, err := client.CreateEvent(withToken("organizer"), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Id != "new-id" || created.EventName != "Jazz" || principal.UID != "org-uid" || principal.Role != domain.RoleOrganizer {
+		t.Errorf("unexpected create: resp=%v event=%+v principal=%+v", resp, created, principal)
+	}
+
+	req.Event.Type = "opera"
+	if _, err := client.CreateEvent(withToken("organizer"), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an unknown type, got %v", err)
+	}
+}
+
+func TestGRPC_UpdateEventWritesMaskedFieldsOnly(t *testing.T) {
+	var updates map[string]interface{}
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{
+		UpdateFunc: func(ctx context.Context, id string, u map[string]interface{}) error {
+			updates = u
+			return nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, Price: 25}, nil
+		},
+	}})
+
+	resp, err := client.UpdateEvent(withToken("organizer"), &eventsv1.UpdateEventRequest{
+		Id:         "e1",
+		Event:      &eventsv1.EventInput{Name: "ignored", Price: 25},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 || updates["price"] != 25.0 || resp.Price != 25 {
+		t.Errorf("unexpected updates %v / response %v", updates, resp)
+	}
+
+	_, err = client.UpdateEvent(withToken("organizer"), &eventsv1.UpdateEventRequest{Id: "e1", Event: &eventsv1.EventInput{}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an empty mask, got %v", err)
+	}
+}
+
+func TestGRPC_UpdateEventValidatesLinks(t *testing.T) {
+	var updates map[string]interface{}
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{
+		UpdateFunc: func(ctx context.Context, id string, u map[string]interface{}) error {
+			updates = u
+			return nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+	}})
+
+	_, err := client.UpdateEvent(withToken("organizer"), &eventsv1.UpdateEventRequest{
+		Id:         "e1",
+		Event:      &eventsv1.EventInput{Url: "https://example.com/jazz", ImageUrl: "https://cdn.example.com/jazz.jpg"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"url", "image_url"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updates["event_url"] != "https://example.com/jazz" || updates["image_url"] != "https://cdn.example.com/jazz.jpg" {
+		t.Errorf("unexpected updates %v", updates)
+	}
+
+	_, err = client.UpdateEvent(withToken("organizer"), &eventsv1.UpdateEventRequest{
+		Id:         "e1",
+		Event:      &eventsv1.EventInput{ImageUrl: "javascript:alert(1)"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"image_url"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a javascript: link, got %v", err)
+	}
+}
+
+func TestGRPC_WatchEventsStreamsChanges(t *testing.T) {
+	watch := &fakeWatchService{changes: []domain.EventChange{
+		{Kind: domain.EventAdded, Event: domain.Event{Id: "e1", EventName: "New"}},
+		{Kind: domain.EventRemoved, Event: domain.Event{Id: "e2"}},
+	}}
+	client := newGRPCClient(t, transport.Services{Events: &MockEventService{}, EventWatch: watch})
+
+	stream, err := client.WatchEvents(context.Background(), &eventsv1.WatchEventsRequest{City: "Berlin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []eventsv1.EventChange_Kind
+	for {
+		ch, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kinds = append(kinds, ch.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != eventsv1.EventChange_ADDED || kinds[1] != eventsv1.EventChange_REMOVED {
+		t.Errorf("unexpected changes: %v", kinds)
+	}
+	if watch.filter.City != "Berlin" {
+		t.Errorf("filter not passed: %+v", watch.filter)
+	}
+}
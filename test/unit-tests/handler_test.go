@@ -3,23 +3,41 @@ package unit_tests
 import (
 	"bibently.com/backend/internal/domain"
 	"bibently.com/backend/internal/transport"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
 )
 
 // MockService implements EventService for handler testing
 type MockEventService struct {
 	CreateFunc      func(ctx context.Context, event *domain.Event) error
-	BatchCreateFunc func(ctx context.Context, events []*domain.Event) error
+	BatchCreateFunc func(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error)
 	UpdateFunc      func(ctx context.Context, id string, updates map[string]interface{}) error
 	GetFunc         func(ctx context.Context, id string) (*domain.Event, error)
+	GetBySlugFunc   func(ctx context.Context, slug string) (*domain.Event, error)
+	GetManyFunc     func(ctx context.Context, ids []string) ([]domain.Event, error)
 	DeleteFunc      func(ctx context.Context, id string) error
-	ListFunc        func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, string, error)
+	ListFunc        func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error)
+	StreamFunc      func(ctx context.Context, req domain.SearchRequest, fn func(*domain.Event) error) error
+	StatsFunc       func(ctx context.Context, groupBy string) ([]domain.EventStats, error)
+	SummaryFunc     func(ctx context.Context, filters domain.FilterRequest) (domain.EventSummary, error)
+	BucketsFunc     func(ctx context.Context, city string, bounds []float64) ([]domain.PriceBucket, error)
+	FeatureFunc     func(ctx context.Context, id string, featured bool, until time.Time) error
+	FeaturedFunc    func(ctx context.Context) ([]domain.Event, error)
+	HistoryFunc     func(ctx context.Context, id string) ([]domain.EventRevision, error)
+	ArchiveFunc     func(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error)
 }
 
 func (m *MockEventService) CreateEvent(ctx context.Context, event *domain.Event) error {
@@ -40,29 +58,137 @@ func (m *MockEventService) GetEvent(ctx context.Context, id string) (*domain.Eve
 	}
 	return nil, nil
 }
+func (m *MockEventService) GetEventBySlug(ctx context.Context, slug string) (*domain.Event, error) {
+	if m.GetBySlugFunc != nil {
+		return m.GetBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
 func (m *MockEventService) DeleteEvent(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
 	}
 	return nil
 }
-func (m *MockEventService) ListEvents(ctx context.Context, req domain.SearchRequest) ([]domain.Event, string, error) {
+func (m *MockEventService) ListEvents(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx, req)
 	}
-	return nil, "", nil
+	return nil, domain.Meta{}, nil
+}
+func (m *MockEventService) StreamEvents(ctx context.Context, req domain.SearchRequest, fn func(*domain.Event) error) error {
+	if m.StreamFunc != nil {
+		return m.StreamFunc(ctx, req, fn)
+	}
+	return nil
 }
 
-func (m *MockEventService) BatchCreateEvents(ctx context.Context, events []*domain.Event) error {
+func (m *MockEventService) BatchCreateEvents(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error) {
 	if m.BatchCreateFunc != nil {
 		return m.BatchCreateFunc(ctx, events)
 	}
+	result := &domain.BatchCreateResult{}
+	for i, e := range events {
+		result.Created = append(result.Created, domain.BatchItemResult{Index: i, Id: e.Id})
+	}
+	return result, nil
+}
+
+func (m *MockEventService) GetEventStats(ctx context.Context, groupBy string) ([]domain.EventStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, groupBy)
+	}
+	return nil, nil
+}
+
+func (m *MockEventService) SummarizeEvents(ctx context.Context, filters domain.FilterRequest) (domain.EventSummary, error) {
+	if m.SummaryFunc != nil {
+		return m.SummaryFunc(ctx, filters)
+	}
+	return domain.EventSummary{}, nil
+}
+
+func (m *MockEventService) GetPriceBuckets(ctx context.Context, city string, bounds []float64) ([]domain.PriceBucket, error) {
+	if m.BucketsFunc != nil {
+		return m.BucketsFunc(ctx, city, bounds)
+	}
+	return nil, nil
+}
+
+func (m *MockEventService) SetFeatured(ctx context.Context, id string, featured bool, until time.Time) error {
+	if m.FeatureFunc != nil {
+		return m.FeatureFunc(ctx, id, featured, until)
+	}
 	return nil
 }
+func (m *MockEventService) GetEvents(ctx context.Context, ids []string) ([]domain.Event, error) {
+	if m.GetManyFunc != nil {
+		return m.GetManyFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockEventService) ListFeaturedEvents(ctx context.Context) ([]domain.Event, error) {
+	if m.FeaturedFunc != nil {
+		return m.FeaturedFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockEventService) GetEventHistory(ctx context.Context, id string) ([]domain.EventRevision, error) {
+	if m.HistoryFunc != nil {
+		return m.HistoryFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockEventService) ArchivePastEvents(ctx context.Context, olderThanDays int) (*domain.ArchiveResult, error) {
+	if m.ArchiveFunc != nil {
+		return m.ArchiveFunc(ctx, olderThanDays)
+	}
+	return &domain.ArchiveResult{}, nil
+}
+
+func (m *MockEventService) UpdatePopularity(ctx context.Context, scores map[string]float64) (*domain.PopularityResult, error) {
+	return &domain.PopularityResult{Scored: len(scores)}, nil
+}
 
 type MockTrackingService struct {
-	TrackFunc  func(ctx context.Context, event *domain.TrackingEvent) error
-	GetAllFunc func(ctx context.Context) ([]domain.TrackingEvent, error)
+	TrackFunc   func(ctx context.Context, event *domain.TrackingEvent) error
+	GetAllFunc  func(ctx context.Context) ([]domain.TrackingEvent, error)
+	PurgeFunc   func(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error)
+	StatsFunc   func(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error)
+	SessionFunc func(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error)
+	WatchFunc   func(ctx context.Context, fn func(domain.TrackingEvent) error) error
+	DeleteFunc  func(ctx context.Context, id string) error
+	// DeleteWhereFunc defaults to deleting nothing
+	DeleteWhereFunc func(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error)
+}
+
+func (m *MockTrackingService) DeleteTracking(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTrackingService) DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error) {
+	if m.DeleteWhereFunc != nil {
+		return m.DeleteWhereFunc(ctx, filter)
+	}
+	return &domain.PurgeResult{}, nil
+}
+
+func (m *MockTrackingService) WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+	if m.WatchFunc != nil {
+		return m.WatchFunc(ctx, fn)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (m *MockTrackingService) EventPopularity(ctx context.Context) (map[string]float64, error) {
+	return map[string]float64{}, nil
 }
 
 func (m *MockTrackingService) TrackEvent(ctx context.Context, event *domain.TrackingEvent) error {
@@ -77,21 +203,57 @@ func (m *MockTrackingService) GetAllTracking(ctx context.Context) ([]domain.Trac
 	}
 	return nil, nil
 }
+func (m *MockTrackingService) PurgeOldTracking(ctx context.Context, olderThanDays int) (*domain.PurgeResult, error) {
+	if m.PurgeFunc != nil {
+		return m.PurgeFunc(ctx, olderThanDays)
+	}
+	return &domain.PurgeResult{}, nil
+}
+func (m *MockTrackingService) GetSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+	if m.SessionFunc != nil {
+		return m.SessionFunc(ctx, sessionID)
+	}
+	return nil, nil
+}
+func (m *MockTrackingService) GetTrackingStats(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, groupBy, interval, from, to)
+	}
+	return nil, nil
+}
+
+type MockRSVPService struct {
+	RSVPFunc func(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error)
+	ListFunc func(ctx context.Context, eventID string) ([]domain.RSVP, error)
+}
+
+func (m *MockRSVPService) RSVP(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error) {
+	if m.RSVPFunc != nil {
+		return m.RSVPFunc(ctx, eventID, userID, email)
+	}
+	return &domain.RSVP{Id: userID, EventID: eventID, UserID: userID}, nil
+}
+func (m *MockRSVPService) ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, eventID)
+	}
+	return nil, nil
+}
 
 func TestHandler_ListEvents_QueryParams(t *testing.T) {
 	mockSvc := &MockEventService{
-		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, string, error) {
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
 			if req.Filters.City != "Warsaw" {
 				t.Errorf("Expected City 'Warsaw', got '%s'", req.Filters.City)
 			}
 			if req.Filters.MinPrice == nil || *req.Filters.MinPrice != 50.5 {
 				t.Errorf("Expected MinPrice 50.5, got %v", req.Filters.MinPrice)
 			}
-			return []domain.Event{}, "", nil
+			return []domain.Event{}, domain.Meta{}, nil
 		},
 	}
 
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
 	// Note: trailing slash required for collection root in standard mux if registered as /events/
 	req := httptest.NewRequest(http.MethodGet, "/events/?city=Warsaw&min_price=50.5", nil)
@@ -104,6 +266,52 @@ func TestHandler_ListEvents_QueryParams(t *testing.T) {
 	}
 }
 
+func TestHandler_ListEvents_RendersRepositoryProjection(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			return []domain.Event{{Id: "1", EventName: "Card", Capacity: 50}}, domain.Meta{Fields: []string{"id", "event_name"}}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"event_name":"Card"`) || strings.Contains(body, "apacity") {
+		t.Errorf("Expected only the projected fields, got %s", body)
+	}
+}
+
+func TestHandler_StatsSummary_UsesListFilters(t *testing.T) {
+	mockSvc := &MockEventService{
+		SummaryFunc: func(ctx context.Context, filters domain.FilterRequest) (domain.EventSummary, error) {
+			if filters.City != "Warsaw" || filters.MaxPrice == nil || *filters.MaxPrice != 100 {
+				t.Errorf("Expected city and max_price filters, got %+v", filters)
+			}
+			return domain.EventSummary{Count: 3, TotalPrice: 90, AvgPrice: 30}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/stats/summary?city=Warsaw&max_price=100", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"total_price":90`) {
+		t.Errorf("Expected the summary, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Filters are validated as for the list
+	req = httptest.NewRequest(http.MethodGet, "/events/stats/summary?min_price=cheap", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a malformed min_price, got %d", w.Code)
+	}
+}
+
 func TestTrackingHandler_Create(t *testing.T) {
 	mockTrack := &MockTrackingService{
 		TrackFunc: func(ctx context.Context, event *domain.TrackingEvent) error {
@@ -114,7 +322,7 @@ func TestTrackingHandler_Create(t *testing.T) {
 		},
 	}
 
-	router := transport.NewRouter(&MockEventService{}, mockTrack)
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack})
 
 	body := `{"action": "login", "payload": "user_123"}`
 	// Note: trailing slash
@@ -128,6 +336,43 @@ func TestTrackingHandler_Create(t *testing.T) {
 	}
 }
 
+func TestTrackingHandler_Create_HonorsDoNotTrack(t *testing.T) {
+	tests := []struct {
+		name     string
+		honor    bool
+		header   string
+		wantCode int
+	}{
+		{"DNT honored", true, "DNT", http.StatusNoContent},
+		{"GPC honored", true, "Sec-GPC", http.StatusNoContent},
+		{"Ignored when off", false, "DNT", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := false
+			mockTrack := &MockTrackingService{
+				TrackFunc: func(ctx context.Context, event *domain.TrackingEvent) error {
+					saved = true
+					return nil
+				},
+			}
+			router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack, HonorDoNotTrack: tt.honor})
+
+			req := httptest.NewRequest(http.MethodPost, "/tracking/", strings.NewReader(`{"action": "login"}`))
+			req.Header.Set(tt.header, "1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if saved != (tt.wantCode == http.StatusCreated) {
+				t.Errorf("Expected saved=%v", !saved)
+			}
+		})
+	}
+}
+
 // TestHandler_UpdateEvent_Success validates the happy path using the new DTO logic
 func TestHandler_UpdateEvent_Success(t *testing.T) {
 	mockSvc := &MockEventService{
@@ -142,7 +387,7 @@ func TestHandler_UpdateEvent_Success(t *testing.T) {
 			return nil
 		},
 	}
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
 	body := `{"price": 99.99}`
 	// URL uses Path Parameter now: /events/123
@@ -156,36 +401,73 @@ func TestHandler_UpdateEvent_Success(t *testing.T) {
 	}
 }
 
-// TestHandler_UpdateEvent_Security_MassAssignment verifies that injected fields are ignored
+// TestHandler_UpdateEvent_Security_MassAssignment verifies that injected fields are rejected
 func TestHandler_UpdateEvent_Security_MassAssignment(t *testing.T) {
 	mockSvc := &MockEventService{
 		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
-			// 1. Verify injected fields are NOT present
-			if _, exists := updates["is_admin"]; exists {
-				t.Error("Security Fail: 'is_admin' field was passed to service")
-			}
-			if _, exists := updates["created_at"]; exists {
-				t.Error("Security Fail: 'created_at' field was passed to service")
-			}
-
-			// 2. Verify valid fields ARE present
-			if val, ok := updates["event_name"]; !ok || val != "Hacked Name" {
-				t.Error("Expected 'event_name' to be updated")
-			}
+			t.Error("Service should NOT be called when unknown fields are present")
 			return nil
 		},
 	}
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
-	// Malicious Payload: includes valid field + ignored fields
+	// Malicious Payload: includes valid field + undeclared fields
 	body := `{"event_name": "Hacked Name", "is_admin": true, "created_at": "2020-01-01"}`
 	req := httptest.NewRequest(http.MethodPut, "/events/123", strings.NewReader(body))
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("Expected 200 OK, got %d", w.Code)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "is_admin") {
+		t.Errorf("Expected error to name the offending field, got %s", w.Body.String())
+	}
+}
+
+// TestHandler_CreateEvent_UnknownField verifies that a typo in a field name is reported instead of ignored
+func TestHandler_CreateEvent_UnknownField(t *testing.T) {
+	mockSvc := &MockEventService{
+		CreateFunc: func(ctx context.Context, event *domain.Event) error {
+			t.Error("Service should NOT be called when unknown fields are present")
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	body := `{"event_name": "Concert", "strat_time": "2025-01-01T10:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/events/", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "strat_time") {
+		t.Errorf("Expected error to name the offending field, got %s", w.Body.String())
+	}
+}
+
+// TestHandler_UpdateEvent_EndBeforeStart verifies the time range is checked when both ends are updated
+func TestHandler_UpdateEvent_EndBeforeStart(t *testing.T) {
+	mockSvc := &MockEventService{
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			t.Error("Service should NOT be called for an inverted time range")
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	body := `{"start_time": "2025-01-02T10:00:00Z", "end_time": "2025-01-01T10:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPut, "/events/123", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", w.Code)
 	}
 }
 
@@ -197,7 +479,7 @@ func TestHandler_UpdateEvent_TypePollution(t *testing.T) {
 			return nil
 		},
 	}
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
 	// "price" expects number, we send string
 	body := `{"price": "free"}`
@@ -219,7 +501,7 @@ func TestHandler_UpdateEvent_Validation(t *testing.T) {
 			return nil
 		},
 	}
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
 	// "price" must be >= 0
 	body := `{"price": -50.00}`
@@ -234,7 +516,7 @@ func TestHandler_UpdateEvent_Validation(t *testing.T) {
 }
 
 func TestEventHandler_Create_InvalidJSON(t *testing.T) {
-	router := transport.NewRouter(&MockEventService{}, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
 
 	// Send invalid JSON (missing closing brace)
 	body := `{"event_name": "Broken JSON"`
@@ -249,14 +531,13 @@ func TestEventHandler_Create_InvalidJSON(t *testing.T) {
 }
 
 func TestEventHandler_Get_NotFound(t *testing.T) {
-	// Mock service to return an error looking like "event not found"
+	// Mock service to return a typed not-found error
 	mockSvc := &MockEventService{
 		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
-			// Matches the error check in transport/handler.go: respondError
-			return nil, errors.New("event not found")
+			return nil, domain.ErrNotFound("event not found")
 		},
 	}
-	router := transport.NewRouter(mockSvc, &MockTrackingService{})
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
 
 	req := httptest.NewRequest(http.MethodGet, "/events/missing-id", nil)
 	w := httptest.NewRecorder()
@@ -266,6 +547,71 @@ func TestEventHandler_Get_NotFound(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected 404 Not Found, got %d", w.Code)
 	}
+
+	var resp domain.APIResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Code != domain.CodeNotFound {
+		t.Errorf("Expected code %q, got %q", domain.CodeNotFound, resp.Code)
+	}
+}
+
+func TestTrackingHandler_Delete(t *testing.T) {
+	var deleted string
+	mockTrack := &MockTrackingService{
+		DeleteFunc: func(ctx context.Context, id string) error {
+			if id == "missing" {
+				return domain.ErrNotFound("tracking event not found")
+			}
+			deleted = id
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tracking/track_1", nil))
+	if w.Code != http.StatusOK || deleted != "track_1" {
+		t.Errorf("Expected track_1 deleted with 200, got %d (%q)", w.Code, deleted)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tracking/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestTrackingHandler_DeleteWhere(t *testing.T) {
+	var got domain.TrackingDeleteFilter
+	mockTrack := &MockTrackingService{
+		DeleteWhereFunc: func(ctx context.Context, filter domain.TrackingDeleteFilter) (*domain.PurgeResult, error) {
+			got = filter
+			return &domain.PurgeResult{Purged: 4, Cutoff: filter.OlderThan}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tracking/?older_than=2026-05-01T00:00:00Z&action=test_click", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got.Action != "test_click" || !got.OlderThan.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected filter: %+v", got)
+	}
+
+	// Ages count back from now
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tracking/?older_than=24h", nil))
+	if want := time.Now().Add(-24 * time.Hour); w.Code != http.StatusOK || got.OlderThan.Sub(want).Abs() > time.Minute {
+		t.Errorf("Expected a cutoff near %v, got %v (%d)", want, got.OlderThan, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tracking/?older_than=yesterday", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unparsable older_than, got %d", w.Code)
+	}
 }
 
 func TestTrackingHandler_List(t *testing.T) {
@@ -279,7 +625,7 @@ func TestTrackingHandler_List(t *testing.T) {
 		},
 	}
 
-	router := transport.NewRouter(&MockEventService{}, mockTrackSvc)
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrackSvc})
 
 	req := httptest.NewRequest(http.MethodGet, "/tracking/", nil)
 	w := httptest.NewRecorder()
@@ -308,7 +654,597 @@ func TestTrackingHandler_List(t *testing.T) {
 	}
 
 	item := dataSlice[0].(map[string]interface{})
-	if item["Action"] != "signup" {
+	if item["action"] != "signup" {
 		t.Errorf("Expected action 'signup', got %v", item["action"])
 	}
 }
+
+func TestEventHandler_ImportCSV_PerRowReport(t *testing.T) {
+	mockSvc := &MockEventService{
+		BatchCreateFunc: func(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error) {
+			if len(events) != 2 {
+				t.Errorf("Expected 2 valid events to be saved, got %d", len(events))
+			}
+			result := &domain.BatchCreateResult{}
+			for i := range events {
+				result.Created = append(result.Created, domain.BatchItemResult{Index: i, Id: fmt.Sprintf("id_%d", i)})
+			}
+			return result, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	csvData := "event_name,city,type,price,start_time,end_time\n" +
+		"Jazz Night,Warsaw,concert,49.99,2025-07-20T20:00:00Z,2025-07-20T23:00:00Z\n" +
+		"Bad Type,Krakow,rave,10,2025-07-21T20:00:00Z,\n" +
+		"Free Meetup,Gdansk,meetup,,2025-07-22T18:00:00Z,\n" +
+		"Bad Price,Poznan,concert,cheap,2025-07-23T18:00:00Z,\n"
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "events.csv")
+	_, _ = part.Write([]byte(csvData))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/events/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data domain.ImportReport `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	report := resp.Data
+	if report.Total != 4 || report.Created != 2 || report.Failed != 2 {
+		t.Errorf("Expected total=4 created=2 failed=2, got total=%d created=%d failed=%d", report.Total, report.Created, report.Failed)
+	}
+	if !report.Rows[0].Success || report.Rows[0].EventID != "id_0" {
+		t.Errorf("Expected row 1 to succeed with id_0, got %+v", report.Rows[0])
+	}
+	if report.Rows[1].Success || report.Rows[1].Error == "" {
+		t.Errorf("Expected row 2 to fail with an error, got %+v", report.Rows[1])
+	}
+	if !report.Rows[2].Success || report.Rows[2].EventID != "id_1" {
+		t.Errorf("Expected row 3 to succeed with id_1, got %+v", report.Rows[2])
+	}
+	if report.Rows[3].Success {
+		t.Errorf("Expected row 4 to fail on price, got %+v", report.Rows[3])
+	}
+}
+
+func TestEventHandler_ImportCSV_MissingColumn(t *testing.T) {
+	mockSvc := &MockEventService{
+		BatchCreateFunc: func(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error) {
+			t.Error("Service should NOT be called when the header is invalid")
+			return nil, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "events.csv")
+	_, _ = part.Write([]byte("event_name,city\nJazz Night,Warsaw\n"))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/events/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", w.Code)
+	}
+}
+
+func TestEventHandler_GetBySlug(t *testing.T) {
+	mockSvc := &MockEventService{
+		GetBySlugFunc: func(ctx context.Context, slug string) (*domain.Event, error) {
+			if slug == "jazz-night-warsaw-2025" {
+				return &domain.Event{Id: "123", Slug: slug}, nil
+			}
+			return nil, domain.ErrNotFound("event not found")
+		},
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			t.Errorf("Slug route should not fall through to GET /{id}, got id %q", id)
+			return nil, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/slug/jazz-night-warsaw-2025", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 OK, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/events/slug/unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 Not Found, got %d", w.Code)
+	}
+}
+
+func TestRSVPHandler_UsesAuthenticatedUID(t *testing.T) {
+	mockRSVP := &MockRSVPService{
+		RSVPFunc: func(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error) {
+			if eventID != "evt_1" || userID != "user_42" {
+				t.Errorf("Expected evt_1/user_42, got %s/%s", eventID, userID)
+			}
+			if email != "fan@example.com" {
+				t.Errorf("Expected email from token claims, got '%s'", email)
+			}
+			return &domain.RSVP{Id: userID, EventID: eventID, UserID: userID}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, RSVPs: mockRSVP})
+
+	// 1. Without a verified token the handler refuses
+	req := httptest.NewRequest(http.MethodPost, "/events/evt_1/rsvp", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 Unauthorized without token, got %d", w.Code)
+	}
+
+	// 2. With a token injected by the auth middleware
+	token := &auth.Token{UID: "user_42", Claims: map[string]interface{}{"email": "fan@example.com"}}
+	req = httptest.NewRequest(http.MethodPost, "/events/evt_1/rsvp", nil)
+	req = req.WithContext(context.WithValue(req.Context(), transport.UserContextKey, token))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected 201 Created, got %d", w.Code)
+	}
+}
+
+func TestRSVPHandler_EventFullIsConflict(t *testing.T) {
+	mockRSVP := &MockRSVPService{
+		RSVPFunc: func(ctx context.Context, eventID, userID, email string) (*domain.RSVP, error) {
+			return nil, domain.ErrConflict("event is full")
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, RSVPs: mockRSVP})
+
+	req := httptest.NewRequest(http.MethodPost, "/events/evt_1/rsvp", nil)
+	req = req.WithContext(context.WithValue(req.Context(), transport.UserContextKey, &auth.Token{UID: "user_42"}))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 Conflict, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListEvents_OrganizerFilter(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			if req.Filters.OrganizerID != "org_1" {
+				t.Errorf("Expected OrganizerID 'org_1', got '%s'", req.Filters.OrganizerID)
+			}
+			return []domain.Event{}, domain.Meta{}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/?organizer_id=org_1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 OK, got %d", w.Code)
+	}
+}
+
+func TestHandler_SparseFieldsets(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			expected := []string{"id", "event_name", "price"}
+			if strings.Join(req.Fields, ",") != strings.Join(expected, ",") {
+				t.Errorf("Expected fields %v to reach the service, got %v", expected, req.Fields)
+			}
+			return []domain.Event{{Id: "1", EventName: "Jazz", Price: 10, City: "Warsaw"}}, domain.Meta{}, nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Jazz", City: "Warsaw"}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	// 1. List returns only the requested keys
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?fields=event_name,price", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	var listResp struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&listResp)
+	if len(listResp.Data) != 1 || len(listResp.Data[0]) != 3 {
+		t.Fatalf("Expected 1 item with 3 keys, got %v", listResp.Data)
+	}
+	if _, leaked := listResp.Data[0]["city"]; leaked {
+		t.Error("Field 'city' was not requested but is present")
+	}
+
+	// 2. Get supports the same parameter
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/42?fields=city", nil))
+	var getResp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&getResp)
+	if getResp.Data["city"] != "Warsaw" || getResp.Data["id"] != "42" || len(getResp.Data) != 2 {
+		t.Errorf("Expected {id, city}, got %v", getResp.Data)
+	}
+
+	// 3. Unknown fields are rejected
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?fields=password", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request for unknown field, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListEvents_PageTokensInMeta(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			return []domain.Event{{Id: "1"}}, domain.Meta{NextPageToken: "bmV4dA==", PrevPageToken: "cHJldg=="}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?page_token=bmV4dA==", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+
+	var resp domain.APIPaginationResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Meta == nil || resp.Meta.NextPageToken != "bmV4dA==" || resp.Meta.PrevPageToken != "cHJldg==" {
+		t.Errorf("Expected both page tokens in meta, got %+v", resp.Meta)
+	}
+}
+
+func TestEventHandler_Featured(t *testing.T) {
+	mockSvc := &MockEventService{
+		FeaturedFunc: func(ctx context.Context) ([]domain.Event, error) {
+			return []domain.Event{{Id: "f1", Featured: true}}, nil
+		},
+		FeatureFunc: func(ctx context.Context, id string, featured bool, until time.Time) error {
+			if id != "42" || !featured || until.IsZero() {
+				t.Errorf("Unexpected SetFeatured call: %s %v %v", id, featured, until)
+			}
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	// 1. The literal route must win over GET /events/{id}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/featured", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "f1") {
+		t.Errorf("Expected featured list, got %d %s", w.Code, w.Body.String())
+	}
+
+	// 2. featured_until is required when promoting
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/events/42/featured", strings.NewReader(`{"featured":true}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without featured_until, got %d", w.Code)
+	}
+
+	// 3. Valid toggle
+	w = httptest.NewRecorder()
+	body := `{"featured":true,"featured_until":"2099-01-01T00:00:00Z"}`
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/events/42/featured", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 OK, got %d", w.Code)
+	}
+}
+
+func TestEventHandler_Create_DuplicateIsConflict(t *testing.T) {
+	mockSvc := &MockEventService{
+		CreateFunc: func(ctx context.Context, event *domain.Event) error {
+			return &domain.DuplicateEventError{ExistingID: "existing-id"}
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	body := `{"event_name":"Jazz","city":"Warsaw","type":"concert","start_time":"2025-05-01T20:00:00Z"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/events/", strings.NewReader(body)))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 Conflict, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "existing-id") {
+		t.Errorf("Expected the existing Id in the response, got %s", w.Body.String())
+	}
+}
+
+func TestEventHandler_BatchCreate_PartialSuccess(t *testing.T) {
+	mockSvc := &MockEventService{
+		BatchCreateFunc: func(ctx context.Context, events []*domain.Event) (*domain.BatchCreateResult, error) {
+			if len(events) != 2 {
+				t.Errorf("Expected only the 2 valid items to reach the service, got %d", len(events))
+			}
+			// The second valid item fails in storage
+			return &domain.BatchCreateResult{
+				Created: []domain.BatchItemResult{{Index: 0, Id: "id_a"}},
+				Failed:  []domain.BatchItemResult{{Index: 1, Id: "id_b", Error: "deadline exceeded"}},
+			}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	body := `{"events": [
+		{"event_name": "A", "city": "Gdansk", "type": "concert", "start_time": "2025-05-01T20:00:00Z"},
+		{"event_name": "", "city": "Gdansk", "type": "concert", "start_time": "2025-05-01T20:00:00Z"},
+		{"event_name": "B", "city": "Gdansk", "type": "meetup", "start_time": "2025-05-02T20:00:00Z"}
+	]}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/events/batch", strings.NewReader(body)))
+
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected 207 Multi-Status, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data domain.BatchCreateResult `json:"data"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+
+	if len(resp.Data.Created) != 1 || resp.Data.Created[0].Index != 0 {
+		t.Errorf("Expected item 0 to be created, got %+v", resp.Data.Created)
+	}
+	// Indexes refer to positions in the request, not in the filtered slice
+	if len(resp.Data.Failed) != 2 || resp.Data.Failed[0].Index != 1 || resp.Data.Failed[1].Index != 2 {
+		t.Errorf("Expected items 1 and 2 to fail, got %+v", resp.Data.Failed)
+	}
+}
+
+type MockUserService struct {
+	GetProfileFunc     func(ctx context.Context, uid string) (map[string]interface{}, error)
+	DeleteUserDataFunc func(ctx context.Context, uid string) (*domain.DataDeletionReport, error)
+}
+
+func (m *MockUserService) DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+	if m.DeleteUserDataFunc != nil {
+		return m.DeleteUserDataFunc(ctx, uid)
+	}
+	return &domain.DataDeletionReport{UserID: uid}, nil
+}
+
+func (m *MockUserService) GetProfile(ctx context.Context, uid string) (map[string]interface{}, error) {
+	if m.GetProfileFunc != nil {
+		return m.GetProfileFunc(ctx, uid)
+	}
+	return nil, nil
+}
+
+func TestUserHandler_Me(t *testing.T) {
+	users := &MockUserService{
+		GetProfileFunc: func(ctx context.Context, uid string) (map[string]interface{}, error) {
+			return map[string]interface{}{"display_name": "Ala"}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, Users: users})
+
+	token := &auth.Token{UID: "user_42", Claims: map[string]interface{}{
+		"email": "ala@example.com", "email_verified": true, "iss": "https://securetoken.google.com/x", "role": "organizer",
+	}}
+	req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+	ctx := context.WithValue(req.Context(), transport.UserContextKey, token)
+	ctx = domain.WithPrincipal(ctx, domain.Principal{UID: "user_42", Role: domain.RoleOrganizer})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	var resp struct {
+		Data domain.CurrentUser `json:"data"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	me := resp.Data
+	if me.UID != "user_42" || me.Email != "ala@example.com" || !me.EmailVerified {
+		t.Errorf("Unexpected identity: %+v", me)
+	}
+	if len(me.Roles) != 2 || me.Roles[1] != domain.RoleOrganizer {
+		t.Errorf("Expected roles [user organizer], got %v", me.Roles)
+	}
+	if _, ok := me.Claims["iss"]; ok || me.Claims["role"] != "organizer" {
+		t.Errorf("Expected only custom claims, got %v", me.Claims)
+	}
+	if me.Profile["display_name"] != "Ala" {
+		t.Errorf("Expected profile to be included, got %v", me.Profile)
+	}
+}
+
+func TestUserHandler_Me_Unauthenticated(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, Users: &MockUserService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/me", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 Unauthorized, got %d", w.Code)
+	}
+}
+
+func TestUserHandler_DeleteData(t *testing.T) {
+	var gotUID string
+	users := &MockUserService{
+		DeleteUserDataFunc: func(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+			gotUID = uid
+			return &domain.DataDeletionReport{UserID: uid, TrackingEvents: 3, RSVPs: 1, Favorites: 2}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, Users: users})
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/me/data", nil)
+	ctx := context.WithValue(req.Context(), transport.UserContextKey, &auth.Token{UID: "user_42"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	var resp struct {
+		Data domain.DataDeletionReport `json:"data"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if gotUID != "user_42" || resp.Data.TrackingEvents != 3 || resp.Data.Favorites != 2 {
+		t.Errorf("Expected the caller's report, got %q %+v", gotUID, resp.Data)
+	}
+}
+
+func TestHandler_BatchGet(t *testing.T) {
+	var gotIDs []string
+	mockSvc := &MockEventService{
+		GetManyFunc: func(ctx context.Context, ids []string) ([]domain.Event, error) {
+			gotIDs = ids
+			return []domain.Event{{Id: "b"}, {Id: "a"}}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/events/batch-get?ids=b,%20a&ids=c", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !reflect.DeepEqual(gotIDs, []string{"b", "a", "c"}) {
+		t.Errorf("Unexpected ids passed to service: %v", gotIDs)
+	}
+	var resp struct {
+		Data []domain.Event `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data) != 2 || resp.Data[0].Id != "b" {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestTrackingHandler_Stats(t *testing.T) {
+	var gotInterval string
+	var gotFrom time.Time
+	mockTrackSvc := &MockTrackingService{
+		StatsFunc: func(ctx context.Context, groupBy, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+			gotInterval, gotFrom = interval, from
+			return []domain.TrackingStats{{Counts: map[string]int64{"view": 2}, Total: 2}}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrackSvc})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tracking/stats?group_by=action&interval=hour&from=2026-05-01T00:00:00Z", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if gotInterval != "hour" || !gotFrom.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected parameters %q %v", gotInterval, gotFrom)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tracking/stats?from=yesterday", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid from, got %d", w.Code)
+	}
+}
+
+func TestTrackingHandler_Session(t *testing.T) {
+	var gotID string
+	mockTrackSvc := &MockTrackingService{
+		SessionFunc: func(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+			gotID = sessionID
+			return []domain.TrackingEvent{{Action: "view", SessionID: sessionID}}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrackSvc})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tracking/sessions/s-42", nil))
+	if w.Code != http.StatusOK || gotID != "s-42" {
+		t.Errorf("Expected 200 for session s-42, got %d for %q", w.Code, gotID)
+	}
+}
+
+func TestAdminHandler_TrackingStream(t *testing.T) {
+	mockTrack := &MockTrackingService{
+		WatchFunc: func(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+			for _, id := range []string{"t1", "t2"} {
+				if err := fn(domain.TrackingEvent{Id: id, Action: "view"}); err != nil {
+					return err
+				}
+			}
+			return errors.New("listener closed")
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: mockTrack})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/tracking/stream", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %q", ct)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "id: t1\nevent: tracking\ndata: {") || !strings.Contains(body, "id: t2\n") {
+		t.Errorf("Expected both tracking events, got %q", body)
+	}
+	if !strings.HasSuffix(body, "event: error\ndata: {\"error\":\"stream interrupted\"}\n\n") {
+		t.Errorf("Expected the stream to end with an error event, got %q", body)
+	}
+}
+
+func TestAdminHandler_LogLevel(t *testing.T) {
+	level := new(slog.LevelVar)
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, LogLevel: level})
+
+	put := func(body string) int {
+		req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := put(`{"level":"Debug"}`); code != http.StatusOK || level.Level() != slog.LevelDebug {
+		t.Fatalf("Expected the level switched to debug, got %d and %v", code, level.Level())
+	}
+	if code := put(`{"level":"verbose"}`); code != http.StatusBadRequest || level.Level() != slog.LevelDebug {
+		t.Errorf("Expected an unknown level rejected and the level kept, got %d and %v", code, level.Level())
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+	if !strings.Contains(w.Body.String(), `"level":"debug"`) {
+		t.Errorf("Expected the current level, got %s", w.Body.String())
+	}
+
+	// Without a LevelVar the endpoints do not exist
+	w = httptest.NewRecorder()
+	transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}}).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without a level to control, got %d", w.Code)
+	}
+}
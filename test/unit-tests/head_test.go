@@ -0,0 +1,74 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func headTestRouter() http.Handler {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Jazz Night", UpdatedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+	return transport.WithHeadResponses(transport.WithCompression(router))
+}
+
+func TestHead_MirrorsGetHeadersWithoutBody(t *testing.T) {
+	handler := headTestRouter()
+
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/v1/events/e1", nil))
+	head := httptest.NewRecorder()
+	handler.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/v1/events/e1", nil))
+
+	if head.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", head.Code)
+	}
+	if head.Body.Len() != 0 {
+		t.Errorf("HEAD must not carry a body, got %q", head.Body.String())
+	}
+	if got, want := head.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+		t.Errorf("Content-Length = %q, want %q", got, want)
+	}
+	for _, name := range []string{"Content-Type", "ETag"} {
+		if head.Header().Get(name) == "" || head.Header().Get(name) != get.Header().Get(name) {
+			t.Errorf("%s = %q, GET sent %q", name, head.Header().Get(name), get.Header().Get(name))
+		}
+	}
+}
+
+func TestHead_ConditionalRequestGets304(t *testing.T) {
+	handler := headTestRouter()
+
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/v1/events/e1", nil))
+
+	req := httptest.NewRequest(http.MethodHead, "/v1/events/e1", nil)
+	req.Header.Set("If-None-Match", get.Header().Get("ETag"))
+	head := httptest.NewRecorder()
+	handler.ServeHTTP(head, req)
+
+	if head.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", head.Code)
+	}
+	if head.Header().Get("Content-Length") != "" {
+		t.Errorf("304 should not be given a length, got %q", head.Header().Get("Content-Length"))
+	}
+}
+
+func TestHead_UnknownPathStillReturns404(t *testing.T) {
+	rr := httptest.NewRecorder()
+	headTestRouter().ServeHTTP(rr, httptest.NewRequest(http.MethodHead, "/v1/nope", nil))
+
+	if rr.Code != http.StatusNotFound || rr.Body.Len() != 0 {
+		t.Errorf("expected an empty 404, got %d: %q", rr.Code, rr.Body.String())
+	}
+}
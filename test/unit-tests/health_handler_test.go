@@ -0,0 +1,102 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/buildinfo"
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 OK, got %d", w.Code)
+	}
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		probe      transport.HealthProbe
+		wantCode   int
+		wantStatus string
+	}{
+		{"Healthy", func(ctx context.Context) error { return nil }, http.StatusOK, domain.HealthOK},
+		{"Unhealthy", func(ctx context.Context) error { return errors.New("deadline exceeded") }, http.StatusServiceUnavailable, domain.HealthFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := transport.NewRouter(transport.Services{
+				Events:       &MockEventService{},
+				Tracking:     &MockTrackingService{},
+				HealthProbes: map[string]transport.HealthProbe{"firestore": tt.probe},
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			var report domain.HealthReport
+			if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if report.Status != tt.wantStatus || report.Checks["firestore"].Status != tt.wantStatus {
+				t.Errorf("Expected status %q, got %+v", tt.wantStatus, report)
+			}
+		})
+	}
+}
+
+// /warmup runs the readiness probes, with room for first connections
+func TestHealthHandler_Warmup(t *testing.T) {
+	var budget time.Duration
+	router := transport.NewRouter(transport.Services{
+		Events:   &MockEventService{},
+		Tracking: &MockTrackingService{},
+		HealthProbes: map[string]transport.HealthProbe{"firestore": func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			budget = time.Until(deadline)
+			return nil
+		}},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/warmup", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if budget <= 2*time.Second || budget > transport.WarmupTimeout {
+		t.Errorf("Expected the warm-up deadline, got %s", budget)
+	}
+}
+
+func TestHealthHandler_Version(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	var info buildinfo.Info
+	if err := json.NewDecoder(w.Body).Decode(&info); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if info.GoVersion == "" || info.GitSHA == "" || info.BuildTime == "" {
+		t.Errorf("Expected all build fields to be populated, got %+v", info)
+	}
+}
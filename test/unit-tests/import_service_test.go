@@ -0,0 +1,120 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockJobRepo keeps jobs in memory and applies chunks like the Firestore transaction does
+type MockJobRepo struct {
+	Jobs map[string]*domain.ImportJob
+}
+
+func (m *MockJobRepo) CreateJob(ctx context.Context, job *domain.ImportJob) error {
+	if m.Jobs == nil {
+		m.Jobs = map[string]*domain.ImportJob{}
+	}
+	copied := *job
+	m.Jobs[job.Id] = &copied
+	return nil
+}
+
+func (m *MockJobRepo) GetJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	if job, ok := m.Jobs[id]; ok {
+		return job, nil
+	}
+	return nil, domain.ErrNotFound("job not found")
+}
+
+func (m *MockJobRepo) UpdateJob(ctx context.Context, id string, updates map[string]interface{}) error {
+	if status, ok := updates["status"].(string); ok {
+		m.Jobs[id].Status = status
+	}
+	return nil
+}
+
+func (m *MockJobRepo) RecordChunk(ctx context.Context, id string, index, created int, failures []domain.ImportRowResult) error {
+	job, ok := m.Jobs[id]
+	if !ok {
+		return domain.ErrNotFound("job not found")
+	}
+	job.ApplyChunk(index, created, failures, time.Now())
+	return nil
+}
+
+// MockQueue captures enqueued payloads
+type MockQueue struct {
+	Chunks []domain.ImportChunk
+	FailAt int // 1-based call that fails; 0 never fails
+	calls  int
+}
+
+func (m *MockQueue) Enqueue(ctx context.Context, path string, payload interface{}) error {
+	m.calls++
+	if m.FailAt == m.calls {
+		return errors.New("queue unavailable")
+	}
+	m.Chunks = append(m.Chunks, payload.(domain.ImportChunk))
+	return nil
+}
+
+func importRows(n int) []domain.ImportChunkRow {
+	rows := make([]domain.ImportChunkRow, n)
+	for i := range rows {
+		rows[i] = domain.ImportChunkRow{Row: i + 1, Event: domain.Event{EventName: "Imported", City: "Warsaw"}}
+	}
+	return rows
+}
+
+func TestImport_ChunksAndCompletes(t *testing.T) {
+	jobs := &MockJobRepo{}
+	queue := &MockQueue{}
+	events := &MockEventService{}
+	svc := service.NewImportService(jobs, events, queue)
+
+	rejected := []domain.ImportRowResult{{Row: 1201, Error: "invalid type"}}
+	job, err := svc.StartImport(context.Background(), importRows(1200), rejected)
+	if err != nil {
+		t.Fatalf("StartImport failed: %v", err)
+	}
+
+	// 1. 1200 rows are split into 3 chunks of at most 500, each with pre-assigned Ids
+	if job.TotalChunks != 3 || len(queue.Chunks) != 3 || len(queue.Chunks[2].Rows) != 200 {
+		t.Fatalf("Expected 3 chunks (500/500/200), got %d enqueued", len(queue.Chunks))
+	}
+	if queue.Chunks[0].Rows[0].Event.Id == "" {
+		t.Error("Expected event Ids to be assigned before enqueueing")
+	}
+	if job.Failed != 1 || job.Status != domain.JobPending {
+		t.Errorf("Expected pending job with 1 rejected row, got %+v", job)
+	}
+
+	// 2. Processing every chunk (one twice, as Cloud Tasks may redeliver) completes the job once
+	for _, chunk := range append(queue.Chunks, queue.Chunks[0]) {
+		if err := svc.ProcessChunk(context.Background(), &chunk); err != nil {
+			t.Fatalf("ProcessChunk failed: %v", err)
+		}
+	}
+	stored, _ := svc.GetJob(context.Background(), job.Id)
+	if stored.Status != domain.JobCompleted || stored.Created != 1200 || stored.Failed != 1 {
+		t.Errorf("Expected completed job with 1200 created and 1 failed, got %+v", stored)
+	}
+}
+
+func TestImport_EnqueueFailureMarksJobFailed(t *testing.T) {
+	jobs := &MockJobRepo{}
+	svc := service.NewImportService(jobs, &MockEventService{}, &MockQueue{FailAt: 2})
+
+	if _, err := svc.StartImport(context.Background(), importRows(600), nil); err == nil {
+		t.Fatal("Expected an error when the queue fails")
+	}
+	for _, job := range jobs.Jobs {
+		if job.Status != domain.JobFailed {
+			t.Errorf("Expected job to be marked failed, got %s", job.Status)
+		}
+	}
+}
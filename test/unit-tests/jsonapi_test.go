@@ -0,0 +1,128 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestJSONAPI_ListHasResourcesAndPaginationLinks(t *testing.T) {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			return []domain.Event{{Id: "e1", EventName: "Jazz", City: "Berlin", OrganizerID: "org-1", DedupKey: "hash"}},
+				domain.Meta{NextPageToken: "bmV4dA==", PrevPageToken: "cHJldg=="}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/?city=Berlin&page_token=Y3Vy", nil)
+	req.Header.Set("Accept", "application/vnd.api+json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/vnd.api+json" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+
+	var doc struct {
+		Data []struct {
+			Type          string                 `json:"type"`
+			ID            string                 `json:"id"`
+			Attributes    map[string]interface{} `json:"attributes"`
+			Relationships map[string]struct {
+				Data *struct{ Type, ID string } `json:"data"`
+			} `json:"relationships"`
+		} `json:"data"`
+		Links   map[string]string `json:"links"`
+		JSONAPI map[string]string `json:"jsonapi"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Data) != 1 || doc.Data[0].Type != "events" || doc.Data[0].ID != "e1" {
+		t.Fatalf("unexpected data: %+v", doc.Data)
+	}
+	attrs := doc.Data[0].Attributes
+	if attrs["event_name"] != "Jazz" || attrs["city"] != "Berlin" {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+	for _, hidden := range []string{"id", "organizer_id", "dedup_key", "city_lc"} {
+		if _, ok := attrs[hidden]; ok {
+			t.Errorf("attribute %q should not be exposed", hidden)
+		}
+	}
+	if org := doc.Data[0].Relationships["organizer"].Data; org == nil || org.Type != "organizers" || org.ID != "org-1" {
+		t.Errorf("unexpected organizer relationship: %+v", org)
+	}
+
+	next, err := url.Parse(doc.Links["next"])
+	if err != nil || next.Path != "/events/" || next.Query().Get("page_token") != "bmV4dA==" || next.Query().Get("city") != "Berlin" {
+		t.Errorf("unexpected next link %q", doc.Links["next"])
+	}
+	first, _ := url.Parse(doc.Links["first"])
+	if first.Query().Has("page_token") || first.Query().Get("city") != "Berlin" {
+		t.Errorf("unexpected first link %q", doc.Links["first"])
+	}
+	if doc.Links["self"] != "/events/?city=Berlin&page_token=Y3Vy" || doc.Links["prev"] == "" {
+		t.Errorf("unexpected links: %v", doc.Links)
+	}
+	if doc.JSONAPI["version"] != "1.1" {
+		t.Errorf("missing jsonapi version: %v", doc.JSONAPI)
+	}
+}
+
+func TestJSONAPI_GetWithoutOrganizerHasNullRelationship(t *testing.T) {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, EventName: "Jazz", Price: 10}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/e1?fields=price", nil)
+	req.Header.Set("Accept", "application/vnd.api+json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var doc struct {
+		Data struct {
+			ID            string                            `json:"id"`
+			Attributes    map[string]interface{}            `json:"attributes"`
+			Relationships map[string]map[string]interface{} `json:"relationships"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Data.ID != "e1" || len(doc.Data.Attributes) != 1 || doc.Data.Attributes["price"] != 10.0 {
+		t.Errorf("expected only the price attribute, got %+v", doc.Data)
+	}
+	organizer, ok := doc.Data.Relationships["organizer"]
+	if !ok || organizer["data"] != nil {
+		t.Errorf("expected a null organizer relationship, got %v", doc.Data.Relationships)
+	}
+}
+
+func TestJSONAPI_PlainJSONIsUnchanged(t *testing.T) {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/e1", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Header().Get("Content-Type") != "application/json" || rr.Header().Get("Vary") == "" {
+		t.Errorf("unexpected headers: %v", rr.Header())
+	}
+}
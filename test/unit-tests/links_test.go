@@ -0,0 +1,103 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            string
+		stripTracking bool
+		want          string
+		wantErr       bool
+	}{
+		{"canonical", "https://example.com/events/1", false, "https://example.com/events/1", false},
+		{"case and default port", " HTTPS://Example.COM:443/Events/1 ", false, "https://example.com/Events/1", false},
+		{"other port kept", "http://example.com:8080/", false, "http://example.com:8080/", false},
+		{"tracking kept", "https://example.com/?utm_source=x&id=1", false, "https://example.com/?utm_source=x&id=1", false},
+		{"tracking stripped", "https://example.com/e?utm_source=x&id=1&FBCLID=y&gclid=z#tickets", true, "https://example.com/e?id=1#tickets", false},
+		{"only tracking", "https://example.com/e?utm_campaign=spring", true, "https://example.com/e", false},
+		{"javascript", "javascript:alert(document.cookie)", false, "", true},
+		{"data", "data:text/html;base64,PHNjcmlwdD4=", false, "", true},
+		{"relative", "/events/1", false, "", true},
+		{"scheme-relative", "//example.com/events/1", false, "", true},
+		{"ftp", "ftp://example.com/poster.jpg", false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NormalizeURL(tt.in, tt.stripTracking)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEventDTO_ValidatesLinks(t *testing.T) {
+	for link, valid := range map[string]bool{
+		"":                                   true,
+		"https://example.com/jazz-night":     true,
+		"http://example.com/poster.jpg?w=80": true,
+		"javascript:alert(1)":                false,
+		"JavaScript:alert(1)":                false,
+		"data:image/svg+xml;base64,PHN2Zz4=": false,
+		"example.com/jazz-night":             false,
+		"/jazz-night":                        false,
+	} {
+		dto := domain.EventDTO{EventName: "Jazz", City: "Berlin", Type: domain.TypeConcert, StartTime: "2030-07-20T22:00:00Z", EventURL: link, ImageUrl: link}
+		if err := domain.Validate.Struct(dto); (err == nil) != valid {
+			t.Errorf("%q: expected valid %v, got %v", link, valid, err)
+		}
+	}
+}
+
+func TestEventService_NormalizesLinks(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryEventRepository()
+	svc := service.NewEventService(repo, &test.MockRevisionRepository{}, service.WithTrackingParamsStripped())
+
+	event := &domain.Event{
+		Id: "jazz", EventName: "Jazz", City: "Berlin", StartTime: time.Date(2030, 7, 20, 20, 0, 0, 0, time.UTC),
+		EventURL: "HTTPS://Tickets.Example.com/jazz?utm_source=newsletter&seat=a1",
+		ImageUrl: "https://cdn.example.com:443/jazz.jpg",
+	}
+	if err := svc.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	stored, err := repo.GetByID(ctx, "jazz")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.EventURL != "https://tickets.example.com/jazz?seat=a1" || stored.ImageUrl != "https://cdn.example.com/jazz.jpg" {
+		t.Errorf("Expected normalized links, got %q and %q", stored.EventURL, stored.ImageUrl)
+	}
+
+	if err := svc.UpdateEvent(ctx, "jazz", map[string]interface{}{"event_url": "https://example.com/jazz?fbclid=1"}); err != nil {
+		t.Fatalf("UpdateEvent: %v", err)
+	}
+	if stored, _ := repo.GetByID(ctx, "jazz"); stored.EventURL != "https://example.com/jazz" {
+		t.Errorf("Expected the updated link normalized, got %q", stored.EventURL)
+	}
+
+	// Writers that skip the DTO cannot store script links either
+	var validation *domain.ValidationError
+	err = svc.UpdateEvent(ctx, "jazz", map[string]interface{}{"image_url": "javascript:alert(1)"})
+	if !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error for a javascript: link, got %v", err)
+	}
+	event = &domain.Event{EventName: "Rock", City: "Berlin", EventURL: "data:text/html,<script>alert(1)</script>"}
+	if err := svc.CreateEvent(ctx, event); !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error for a data: link, got %v", err)
+	}
+}
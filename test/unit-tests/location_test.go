@@ -0,0 +1,118 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng string
+		want     *latlng.LatLng
+		wantErr  bool
+	}{
+		{"decimal strings", "52.2297", " 21.0122 ", &latlng.LatLng{Latitude: 52.2297, Longitude: 21.0122}, false},
+		{"equator and meridian", "0", "0", &latlng.LatLng{}, false},
+		{"no location", "", "", nil, false},
+		{"missing longitude", "52.2297", "", nil, true},
+		{"latitude out of range", "91", "21", nil, true},
+		{"longitude out of range", "52", "-180.5", nil, true},
+		{"not a number", "52,2297", "21.0122", nil, true},
+		{"NaN", "NaN", "21.0122", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := domain.ParseGeoPoint(tt.lat, tt.lng)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			got := p.LatLng()
+			if (got == nil) != (tt.want == nil) || (got != nil && (got.Latitude != tt.want.Latitude || got.Longitude != tt.want.Longitude)) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEventDTO_ValidatesLocation(t *testing.T) {
+	coordinate := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name     string
+		location *domain.GeoPointDTO
+		valid    bool
+	}{
+		{"no location", nil, true},
+		{"in range", &domain.GeoPointDTO{Latitude: coordinate(-33.8688), Longitude: coordinate(151.2093)}, true},
+		{"zero", &domain.GeoPointDTO{Latitude: coordinate(0), Longitude: coordinate(0)}, true},
+		{"latitude out of range", &domain.GeoPointDTO{Latitude: coordinate(-90.1), Longitude: coordinate(0)}, false},
+		{"longitude out of range", &domain.GeoPointDTO{Latitude: coordinate(0), Longitude: coordinate(180.1)}, false},
+		{"missing longitude", &domain.GeoPointDTO{Latitude: coordinate(52)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := domain.EventDTO{EventName: "Jazz", City: "Sydney", Type: domain.TypeConcert, StartTime: "2030-07-20T22:00:00Z", Location: tt.location}
+			if err := domain.Validate.Struct(dto); (err == nil) != tt.valid {
+				t.Errorf("Expected valid %v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestEventResponse_Location(t *testing.T) {
+	location, err := domain.ParseGeoPoint("52.2297", "21.0122")
+	if err != nil {
+		t.Fatalf("ParseGeoPoint: %v", err)
+	}
+	dto := domain.EventDTO{EventName: "Jazz", City: "Warsaw", Type: domain.TypeConcert, StartTime: "2030-07-20T22:00:00Z", Location: location}
+	event, err := domain.EventDTOToModel(&dto)
+	if err != nil {
+		t.Fatalf("EventDTOToModel: %v", err)
+	}
+	if event.Location == nil || event.Location.Latitude != 52.2297 || event.Location.Longitude != 21.0122 {
+		t.Fatalf("Expected the location stored as a geopoint, got %v", event.Location)
+	}
+
+	resp := service.NewEventResponse(event, time.Now())
+	if resp.Location == nil || *resp.Location != (domain.GeoPoint{Latitude: 52.2297, Longitude: 21.0122}) {
+		t.Errorf("Expected the location in the response, got %v", resp.Location)
+	}
+	if resp := service.NewEventResponse(&domain.Event{}, time.Now()); resp.Location != nil {
+		t.Errorf("Expected no location for an event without one, got %v", resp.Location)
+	}
+}
+
+func TestHandler_UpdateEvent_Location(t *testing.T) {
+	var got interface{}
+	mockSvc := &MockEventService{
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			got = updates["location"]
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	for body, want := range map[string]int{
+		`{"location": {"latitude": 50.0647, "longitude": 19.945}}`: http.StatusOK,
+		`{"location": {"latitude": 95, "longitude": 19.945}}`:      http.StatusBadRequest,
+		`{"location": {"latitude": 50.0647}}`:                      http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/events/123", strings.NewReader(body)))
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d: %s", body, want, w.Code, w.Body.String())
+		}
+	}
+	if ll, ok := got.(*latlng.LatLng); !ok || ll.Latitude != 50.0647 || ll.Longitude != 19.945 {
+		t.Errorf("Expected the location written as a geopoint, got %v", got)
+	}
+}
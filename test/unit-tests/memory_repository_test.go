@@ -0,0 +1,369 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func seedMemoryEvents(t *testing.T, repo repository.EventRepository) {
+	t.Helper()
+	base := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		event := &domain.Event{
+			Id:        fmt.Sprintf("mem_%d", i),
+			EventName: fmt.Sprintf("Concert %d", i),
+			City:      []string{"Warsaw", "Berlin"}[i%2],
+			Price:     float64(10 * (5 - i)),
+			Type:      domain.DefaultEventTypes[0],
+			StartTime: base.AddDate(0, 0, i),
+			CreatedAt: base,
+		}
+		if err := repo.Save(context.Background(), event); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+}
+
+func eventIDs(events []domain.Event) []string {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
+
+func TestMemoryEventRepository_ListFiltersAndSorts(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	seedMemoryEvents(t, repo)
+
+	maxPrice := 30.0
+	events, _, err := repo.List(context.Background(), domain.SearchRequest{
+		Filters: domain.FilterRequest{City: "warSAW", MaxPrice: &maxPrice},
+		Sorting: domain.SortRequest{SortKey: "price", SortDirection: "asc"},
+	})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	// Warsaw holds mem_0 (50), mem_2 (30) and mem_4 (10)
+	if got := eventIDs(events); !reflect.DeepEqual(got, []string{"mem_4", "mem_2"}) {
+		t.Errorf("expected [mem_4 mem_2], got %v", got)
+	}
+}
+
+func TestMemoryEventRepository_SortsByPopularity(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	seedMemoryEvents(t, repo)
+	ctx := context.Background()
+
+	if _, _, err := repo.SetPopularity(ctx, map[string]float64{"mem_1": 2, "mem_3": 5}); err != nil {
+		t.Fatalf("SetPopularity failed: %v", err)
+	}
+	// A later run without mem_3 resets its score
+	scored, reset, err := repo.SetPopularity(ctx, map[string]float64{"mem_1": 2, "mem_4": 1, "missing": 9})
+	if err != nil {
+		t.Fatalf("SetPopularity failed: %v", err)
+	}
+	if scored != 2 || reset != 1 {
+		t.Errorf("expected 2 scored and 1 reset, got %d and %d", scored, reset)
+	}
+
+	events, _, err := repo.List(ctx, domain.SearchRequest{
+		Filters: domain.FilterRequest{City: "Berlin"},
+		Sorting: domain.SortRequest{SortKey: domain.SortPopularity, SortDirection: "desc"},
+	})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := eventIDs(events); !reflect.DeepEqual(got[:1], []string{"mem_1"}) || len(got) != 2 {
+		t.Errorf("expected mem_1 first of the two Berlin events, got %v", got)
+	}
+}
+
+func TestMemoryEventRepository_PaginatesBothWays(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	seedMemoryEvents(t, repo)
+	ctx := context.Background()
+
+	search := domain.SearchRequest{Sorting: domain.SortRequest{SortKey: "start_time", SortDirection: "desc", PageSize: 2}}
+	var pages [][]string
+	for {
+		events, meta, err := repo.List(ctx, search)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		pages = append(pages, eventIDs(events))
+		if meta.NextPageToken == "" {
+			break
+		}
+		search.Sorting.PageToken = meta.NextPageToken
+	}
+	want := [][]string{{"mem_4", "mem_3"}, {"mem_2", "mem_1"}, {"mem_0"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Fatalf("expected pages %v, got %v", want, pages)
+	}
+
+	// Back from the last page
+	_, meta, _ := repo.List(ctx, search)
+	search.Sorting.PageToken = meta.PrevPageToken
+	events, meta, err := repo.List(ctx, search)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := eventIDs(events); !reflect.DeepEqual(got, []string{"mem_2", "mem_1"}) || meta.NextPageToken == "" {
+		t.Errorf("expected [mem_2 mem_1] with a next token, got %v %+v", got, meta)
+	}
+
+	// Tokens only resume the query that issued them
+	search.Filters.City = "Berlin"
+	var validationErr *domain.ValidationError
+	if _, _, err := repo.List(ctx, search); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for a token of another query, got %v", err)
+	}
+}
+
+func TestMemoryEventRepository_UpdateInTransaction(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	seedMemoryEvents(t, repo)
+	ctx := context.Background()
+
+	err := repo.UpdateInTransaction(ctx, "mem_0", func(current *domain.Event) (map[string]interface{}, error) {
+		return map[string]interface{}{"event_name": "Opera", "capacity": current.Capacity + 5}, nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateInTransaction failed: %v", err)
+	}
+	event, _ := repo.GetByID(ctx, "mem_0")
+	if event.EventName != "Opera" || event.EventNameLC != "opera" || event.Capacity != 5 {
+		t.Errorf("expected the update with its search copy, got %+v", event)
+	}
+
+	var notFound *domain.NotFoundError
+	err = repo.UpdateInTransaction(ctx, "missing", func(*domain.Event) (map[string]interface{}, error) { return nil, nil })
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+// The memory repository stands in for Firestore behind the real service, without a mock
+func TestMemoryEventRepository_BacksEventService(t *testing.T) {
+	svc := service.NewEventService(repository.NewMemoryEventRepository(), &test.MockRevisionRepository{},
+		service.WithDuplicatePolicy(domain.DuplicateReject))
+	ctx := context.Background()
+
+	event := &domain.Event{EventName: "Jazz Night", City: "Krakow", StartTime: time.Now().Add(24 * time.Hour)}
+	if err := svc.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	duplicate := &domain.Event{EventName: "Jazz Night", City: "Krakow", StartTime: event.StartTime}
+	if err := svc.CreateEvent(ctx, duplicate); err == nil {
+		t.Error("expected the duplicate to be rejected")
+	}
+
+	events, _, err := svc.ListEvents(ctx, domain.SearchRequest{Filters: domain.FilterRequest{City: "krak"}})
+	if err != nil || len(events) != 1 || events[0].Id != event.Id {
+		t.Errorf("expected the created event, got %v %v", events, err)
+	}
+}
+
+func TestMemoryTrackingRepository_PurgesOldEvents(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	ctx := context.Background()
+	now := time.Now()
+	for _, age := range []time.Duration{time.Hour, 48 * time.Hour, 72 * time.Hour} {
+		if err := repo.SaveTracking(ctx, &domain.TrackingEvent{Action: "view", CreatedAt: now.Add(-age)}); err != nil {
+			t.Fatalf("SaveTracking failed: %v", err)
+		}
+	}
+
+	purged, err := repo.PurgeTracking(ctx, now.Add(-24*time.Hour), 10)
+	if err != nil || purged != 2 {
+		t.Fatalf("expected 2 purged, got %d %v", purged, err)
+	}
+	tracks, _ := repo.ListTracking(ctx)
+	if len(tracks) != 1 {
+		t.Errorf("expected 1 remaining, got %d", len(tracks))
+	}
+}
+
+func TestMemoryEventRepository_SummarizeMatchesList(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	seedMemoryEvents(t, repo)
+
+	summary, err := repo.Summarize(context.Background(), domain.FilterRequest{City: "Warsaw"}, false)
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	// mem_0 (50), mem_2 (30) and mem_4 (10)
+	want := domain.EventSummary{Count: 3, TotalPrice: 90, AvgPrice: 30}
+	if summary != want {
+		t.Errorf("expected %+v, got %+v", want, summary)
+	}
+}
+
+func TestMemoryEventRepository_ListProjection(t *testing.T) {
+	repo := repository.NewMemoryEventRepository(repository.WithListProjection(domain.EventCardFields))
+	ctx := context.Background()
+	if err := repo.Save(ctx, &domain.Event{Id: "card", EventName: "Card", City: "Lodz", Capacity: 50, Price: 12}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// 1. Without a fieldset only the card fields are read, and the page says so
+	events, meta, err := repo.List(ctx, domain.SearchRequest{})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("List failed: %v %v", events, err)
+	}
+	if events[0].Price != 12 || events[0].Capacity != 0 || !reflect.DeepEqual(meta.Fields, domain.EventCardFields) {
+		t.Errorf("expected a card projection, got %+v %v", events[0], meta.Fields)
+	}
+
+	// 2. A requested fieldset wins and is not echoed
+	events, meta, _ = repo.List(ctx, domain.SearchRequest{Fields: []string{"id", "capacity"}})
+	if events[0].Capacity != 50 || meta.Fields != nil {
+		t.Errorf("expected the requested fields, got %+v %v", events[0], meta.Fields)
+	}
+}
+
+func TestMemoryTrackingRepository_DeleteTracking(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for i, tr := range []domain.TrackingEvent{
+		{Action: "test_click", CreatedAt: now.Add(-48 * time.Hour)},
+		{Action: "test_click", CreatedAt: now},
+		{Action: "view", CreatedAt: now.Add(-48 * time.Hour)},
+		{Action: "view", CreatedAt: now},
+	} {
+		tr.Id = fmt.Sprintf("t-%d", i)
+		if err := repo.SaveTracking(ctx, &tr); err != nil {
+			t.Fatalf("SaveTracking failed: %v", err)
+		}
+	}
+
+	deleted, err := repo.DeleteTrackingWhere(ctx, domain.TrackingDeleteFilter{Action: "test_click", OlderThan: now.Add(-time.Hour)}, 10)
+	if err != nil || deleted != 1 {
+		t.Fatalf("expected the old test_click deleted, got %d (%v)", deleted, err)
+	}
+	if err := repo.DeleteTracking(ctx, "t-3"); err != nil {
+		t.Fatalf("DeleteTracking failed: %v", err)
+	}
+	var notFound *domain.NotFoundError
+	if err := repo.DeleteTracking(ctx, "t-3"); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError for a deleted event, got %v", err)
+	}
+
+	tracks, _ := repo.ListTracking(ctx)
+	ids := make([]string, 0, len(tracks))
+	for _, tr := range tracks {
+		ids = append(ids, tr.Id)
+	}
+	slices.Sort(ids)
+	if !reflect.DeepEqual(ids, []string{"t-1", "t-2"}) {
+		t.Errorf("expected t-1 and t-2 to remain, got %v", ids)
+	}
+}
+
+func TestMemoryTrackingRepository_Stats(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	ctx := context.Background()
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	for _, track := range []domain.TrackingEvent{
+		{Action: "view", CreatedAt: day.Add(time.Hour)},
+		{Action: "view", CreatedAt: day.Add(2 * time.Hour)},
+		{Action: "signup", CreatedAt: day.Add(3 * time.Hour)},
+		{Action: "view", CreatedAt: day.AddDate(0, 0, 1)},
+	} {
+		if err := repo.SaveTracking(ctx, &track); err != nil {
+			t.Fatalf("SaveTracking failed: %v", err)
+		}
+	}
+
+	stats, err := repo.TrackingStats(ctx, domain.TrackingIntervalDay, day, day.AddDate(0, 0, 1))
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("expected one bucket, got %+v %v", stats, err)
+	}
+	if want := map[string]int64{"view": 2, "signup": 1}; !reflect.DeepEqual(stats[0].Counts, want) || stats[0].Total != 3 {
+		t.Errorf("expected %v, got %+v", want, stats[0])
+	}
+}
+
+func TestMemoryTrackingRepository_ListSession(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	ctx := context.Background()
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, track := range []domain.TrackingEvent{
+		{Action: "checkout", SessionID: "s1", CreatedAt: start.Add(2 * time.Minute)},
+		{Action: "view", SessionID: "s1", CreatedAt: start},
+		{Action: "view", SessionID: "s2", CreatedAt: start},
+	} {
+		track.Id = fmt.Sprintf("t%d", i)
+		if err := repo.SaveTracking(ctx, &track); err != nil {
+			t.Fatalf("SaveTracking failed: %v", err)
+		}
+	}
+
+	tracks, err := repo.ListSession(ctx, "s1")
+	if err != nil || len(tracks) != 2 || tracks[0].Action != "view" || tracks[1].Action != "checkout" {
+		t.Errorf("expected s1 in order, got %+v %v", tracks, err)
+	}
+}
+
+func TestMemoryTrackingRepository_ClientEventIDStoredOnce(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	ctx := context.Background()
+	for _, payload := range []string{"first", "resend"} {
+		track := &domain.TrackingEvent{Id: "c_1", Action: "view", Payload: payload, ClientEventID: "evt-1"}
+		if err := repo.SaveTracking(ctx, track); err != nil {
+			t.Fatalf("SaveTracking failed: %v", err)
+		}
+	}
+	tracks, _ := repo.ListTracking(ctx)
+	if len(tracks) != 1 || tracks[0].Payload != "first" {
+		t.Errorf("expected the first send only, got %+v", tracks)
+	}
+}
+
+func TestMemoryTrackingRepository_WatchTracking(t *testing.T) {
+	repo := repository.NewMemoryTrackingRepository()
+	acme := domain.WithTenant(context.Background(), "acme")
+	ctx, cancel := context.WithCancel(acme)
+	defer cancel()
+
+	got := make(chan domain.TrackingEvent, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- repo.WatchTracking(ctx, func(tr domain.TrackingEvent) error {
+			got <- tr
+			cancel()
+			return nil
+		})
+	}()
+
+	// The watcher registers asynchronously; keep storing until it sees an event
+	deadline := time.After(2 * time.Second)
+	for i := 0; ; i++ {
+		_ = repo.SaveTracking(domain.WithTenant(context.Background(), "globex"), &domain.TrackingEvent{Id: fmt.Sprintf("other-%d", i), Action: "view"})
+		_ = repo.SaveTracking(acme, &domain.TrackingEvent{Id: fmt.Sprintf("t-%d", i), Action: "view"})
+		select {
+		case tr := <-got:
+			if tr.Id[:2] != "t-" {
+				t.Errorf("Expected an acme event, got %q", tr.Id)
+			}
+			if err := <-done; err != nil {
+				t.Errorf("Expected nil after cancellation, got %v", err)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Expected the watcher to receive an event")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
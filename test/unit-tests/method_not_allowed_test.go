@@ -0,0 +1,67 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouting_WrongMethodReturnsJSON405WithAllow(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPatch, "/v1/events/e1", nil))
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d: %s", rr.Code, rr.Body.String())
+	}
+	allow := rr.Header().Get("Allow")
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		if !strings.Contains(allow, method) {
+			t.Errorf("Allow %q is missing %s", allow, method)
+		}
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+	var resp domain.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if resp.Code != domain.CodeMethodNotAllowed {
+		t.Errorf("expected code %q, got %q", domain.CodeMethodNotAllowed, resp.Code)
+	}
+}
+
+func TestRouting_WrongMethodOnInfraRouteReturns405(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/healthz", nil))
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Header().Get("Allow"), http.MethodGet) {
+		t.Errorf("expected GET in Allow, got %q", rr.Header().Get("Allow"))
+	}
+}
+
+func TestRouting_UnknownPathReturnsJSON404(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/nope", nil))
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+	var resp domain.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil || resp.Code != domain.CodeNotFound {
+		t.Errorf("expected JSON not_found body, got %s", rr.Body.String())
+	}
+}
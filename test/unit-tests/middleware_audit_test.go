@@ -0,0 +1,88 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockAuditService struct {
+	Recorded []*domain.AuditEntry
+	ListFunc func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+func (m *MockAuditService) RecordAudit(ctx context.Context, entry *domain.AuditEntry) error {
+	m.Recorded = append(m.Recorded, entry)
+	return nil
+}
+
+func (m *MockAuditService) ListAuditLogs(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return []domain.AuditEntry{}, nil
+}
+
+func TestWithAuditLog_RecordsWrites(t *testing.T) {
+	audit := &MockAuditService{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Services report field changes through the request context
+		domain.RecordAuditChanges(r.Context(), "evt_1", map[string]domain.FieldChange{
+			"event_name": {Old: "Old", New: "New"},
+		})
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := transport.WithAuditLog(next, audit)
+
+	req := httptest.NewRequest(http.MethodPut, "/events/evt_1", nil)
+	req = req.WithContext(domain.WithActor(req.Context(), "admin_1"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(audit.Recorded) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(audit.Recorded))
+	}
+	entry := audit.Recorded[0]
+	if entry.Actor != "admin_1" || entry.Resource != "events" || entry.ResourceID != "evt_1" || entry.Status != http.StatusAccepted {
+		t.Errorf("Unexpected audit entry: %+v", entry)
+	}
+	if change := entry.Changes["evt_1"]["event_name"]; change.Old != "Old" || change.New != "New" {
+		t.Errorf("Expected old-vs-new values to be recorded, got %+v", entry.Changes)
+	}
+}
+
+func TestWithAuditLog_SkipsReads(t *testing.T) {
+	audit := &MockAuditService{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithAuditLog(next, audit)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if len(audit.Recorded) != 0 {
+		t.Errorf("Expected reads not to be audited, got %d entries", len(audit.Recorded))
+	}
+}
+
+func TestAdminHandler_ListAuditLogs_Filters(t *testing.T) {
+	audit := &MockAuditService{
+		ListFunc: func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+			if filter.Actor != "admin_1" || filter.Resource != "events" || filter.Limit != 10 {
+				t.Errorf("Unexpected filter: %+v", filter)
+			}
+			return []domain.AuditEntry{{Id: "a1"}}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, Audit: audit})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit-logs?actor=admin_1&resource=events&limit=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 OK, got %d", w.Code)
+	}
+}
@@ -0,0 +1,84 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCachedRouter(calls *int) http.Handler {
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			*calls++
+			return []domain.Event{{Id: "1"}}, domain.Meta{}, nil
+		},
+		CreateFunc: func(ctx context.Context, event *domain.Event) error { return nil },
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+	return transport.WithResponseCache(router, time.Minute, transport.IsPublicEventList)
+}
+
+func TestWithResponseCache_NormalizedQueryHits(t *testing.T) {
+	calls := 0
+	handler := newCachedRouter(&calls)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?city=Warsaw&type=concert", nil))
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("Expected first request to miss, got %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?type=concert&city=Warsaw&page_token=", nil))
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("Expected reordered query to hit, got %q", got)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the service to be called once, got %d", calls)
+	}
+	if w.Header().Get("ETag") == "" || !strings.Contains(w.Body.String(), `"id":"1"`) {
+		t.Errorf("Expected the cached body and headers to be replayed, got %q", w.Body.String())
+	}
+}
+
+func TestWithResponseCache_BypassedForAuthenticatedCallers(t *testing.T) {
+	calls := 0
+	handler := newCachedRouter(&calls)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls != 2 {
+		t.Errorf("Expected authenticated requests to skip the cache, got %d service calls", calls)
+	}
+}
+
+func TestWithResponseCache_InvalidatedOnWrite(t *testing.T) {
+	calls := 0
+	handler := newCachedRouter(&calls)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events/", nil))
+
+	body := `{"event_name":"Concert","city":"Warsaw","type":"concert","start_time":"2030-01-01T10:00:00Z"}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/events/", strings.NewReader(body)))
+	if w.Code >= http.StatusBadRequest {
+		t.Fatalf("Expected the create to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/", nil))
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("Expected a miss after a write, got %q", got)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the list to be reloaded, got %d service calls", calls)
+	}
+}
@@ -0,0 +1,145 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/transport"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compressionHandler(contentType, body string) http.Handler {
+	return transport.WithCompression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = io.WriteString(w, body)
+	}))
+}
+
+func TestWithCompression_Negotiation(t *testing.T) {
+	body := strings.Repeat(`{"event_name":"Concert"}`, 100)
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{"None", "", ""},
+		{"Gzip only", "gzip", "gzip"},
+		{"Brotli preferred on tie", "gzip, br", "br"},
+		{"Q-values respected", "br;q=0.5, gzip;q=0.9", "gzip"},
+		{"Refused encoding", "br;q=0, gzip", "gzip"},
+		{"Wildcard", "*", "br"},
+		{"Unsupported only", "deflate", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			compressionHandler("application/json", body).ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Expected Content-Encoding %q, got %q", tt.wantEncoding, got)
+			}
+		})
+	}
+}
+
+func TestWithCompression_GzipRoundTrip(t *testing.T) {
+	body := strings.Repeat(`{"event_name":"Concert"}`, 100)
+	req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	compressionHandler("application/json", body).ServeHTTP(w, req)
+
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Expected a gzip body: %v", err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Failed to decompress: %v", err)
+	}
+	if string(got) != body {
+		t.Error("Decompressed body does not match the original")
+	}
+}
+
+func TestWithCompression_SkipsIncompressibleTypes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/files/poster", nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	w := httptest.NewRecorder()
+	compressionHandler("image/png", strings.Repeat("x", 4096)).ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Expected image/png to be sent as-is, got Content-Encoding %q", got)
+	}
+	if w.Body.Len() != 4096 {
+		t.Errorf("Expected the raw body, got %d bytes", w.Body.Len())
+	}
+}
+
+func TestWithCompression_SmallResponsesBypass(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	compressionHandler("application/json", `{"data":"ok"}`).ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Expected a small body to be sent as-is, got Content-Encoding %q", got)
+	}
+	if w.Body.String() != `{"data":"ok"}` {
+		t.Errorf("Unexpected body %q", w.Body.String())
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding on every response, got %q", got)
+	}
+}
+
+// TestWithCompression_StripsContentLength verifies a handler-declared length does not outlive compression
+func TestWithCompression_StripsContentLength(t *testing.T) {
+	body := strings.Repeat("a", 8192)
+	handler := transport.WithCompression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Length", "8192")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, body)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Expected gzip, got %q", got)
+	}
+	if got := w.Header().Get("Content-Length"); got != "" {
+		t.Errorf("Expected Content-Length to be removed, got %q", got)
+	}
+	if w.Body.Len() >= len(body) {
+		t.Errorf("Expected a compressed body, got %d bytes", w.Body.Len())
+	}
+}
+
+func TestWithCompression_FlushSendsBufferedData(t *testing.T) {
+	handler := transport.WithCompression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: hello\n\n")
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Expected the writer to support flushing: %v", err)
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if !w.Flushed {
+		t.Error("Expected the flush to reach the underlying writer")
+	}
+}
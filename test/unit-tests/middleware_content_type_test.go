@@ -0,0 +1,83 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithContentTypes_DefaultRules(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithContentTypes(okHandler, nil)
+
+	tests := []struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{http.MethodPost, "/v1/events/", "application/json", `{}`, http.StatusOK},
+		{http.MethodPost, "/v1/events/", "application/json; charset=utf-8", `{}`, http.StatusOK},
+		{http.MethodPut, "/events/e1", "Application/JSON", `{}`, http.StatusOK},
+		{http.MethodPost, "/v1/events/", "text/plain", `{}`, http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/v1/events/", "", `{}`, http.StatusUnsupportedMediaType},
+		{http.MethodPut, "/v1/events/e1", "application/x-www-form-urlencoded", `a=b`, http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/v1/events/import", "multipart/form-data; boundary=x", `--x--`, http.StatusOK},
+		{http.MethodPost, "/v1/events/import", "application/json", `{}`, http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/internal/cron/daily", "application/octet-stream", `x`, http.StatusOK},
+		// Bodiless writes and reads are not checked
+		{http.MethodPost, "/v1/events/e1/rsvp", "", ``, http.StatusOK},
+		{http.MethodGet, "/v1/events/", "text/plain", ``, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.contentType, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestWithContentTypes_RejectionIsJSON(t *testing.T) {
+	handler := transport.WithContentTypes(http.NotFoundHandler(), nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/tracking/", strings.NewReader(`action=login`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var resp domain.APIResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if resp.Code != domain.CodeUnsupportedMedia || !strings.Contains(resp.Error, "application/json") {
+		t.Errorf("unexpected error body %+v", resp)
+	}
+}
+
+func TestWithContentTypes_CustomRulesExtendTheAllowlist(t *testing.T) {
+	rules := append([]transport.ContentTypeRule{
+		{Methods: []string{http.MethodPost}, Path: "/events/", MediaTypes: []string{"application/json", "application/x-ndjson"}},
+	}, transport.DefaultContentTypes...)
+	handler := transport.WithContentTypes(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), rules)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/events/", strings.NewReader("{}\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected the extra media type to be accepted, got %d", w.Code)
+	}
+}
@@ -0,0 +1,84 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/cache"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithDatabaseRouting(t *testing.T) {
+	builds := map[string]int{}
+	named := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(name)) })
+	}
+	handler := transport.WithDatabaseRouting(named("prod"), "prod", []string{"staging", "broken"},
+		transport.DatabaseFromHeader, func(databaseID string) (http.Handler, error) {
+			builds[databaseID]++
+			if databaseID == "broken" {
+				return nil, errors.New("client failed")
+			}
+			return named(databaseID), nil
+		})
+
+	tests := []struct {
+		name     string
+		database string
+		wantCode int
+		wantBody string
+	}{
+		{"No header serves the default", "", http.StatusOK, "prod"},
+		{"Default by name", "prod", http.StatusOK, "prod"},
+		{"Routed", "staging", http.StatusOK, "staging"},
+		{"Routed again", "staging", http.StatusOK, "staging"},
+		{"Unknown rejected", "stagign", http.StatusBadRequest, ""},
+		{"Failed build", "broken", http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+			if tt.database != "" {
+				req.Header.Set(transport.DatabaseHeader, tt.database)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected the %s handler, got %q", tt.wantBody, w.Body.String())
+			}
+			if w.Header().Get("Vary") != transport.DatabaseHeader {
+				t.Errorf("Expected Vary: %s, got %q", transport.DatabaseHeader, w.Header().Get("Vary"))
+			}
+		})
+	}
+	if builds["staging"] != 1 {
+		t.Errorf("Expected the staging handler to be built once, got %d", builds["staging"])
+	}
+}
+
+// Routed databases share one cache server under separate key prefixes
+func TestCacheWithPrefix(t *testing.T) {
+	store := cache.NewMemoryStore()
+	staging := cache.WithPrefix(store, "staging:")
+	ctx := context.Background()
+
+	if err := staging.Set(ctx, "event:1", []byte("v"), 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, "event:1"); ok {
+		t.Error("Expected the unprefixed key to stay empty")
+	}
+	if v, ok, _ := store.Get(ctx, "staging:event:1"); !ok || string(v) != "v" {
+		t.Errorf("Expected the prefixed key, got %q %v", v, ok)
+	}
+	_ = staging.Delete(ctx, "event:1")
+	if _, ok, _ := staging.Get(ctx, "event:1"); ok {
+		t.Error("Expected the prefixed key to be deleted")
+	}
+}
@@ -0,0 +1,60 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/transport"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithDebugLogging_RedactsBodies(t *testing.T) {
+	var received string
+	handler := transport.WithDebugLogging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"e1","organizer":{"email":"ops@example.com"}}}`))
+	}), []string{"/events/**"})
+
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "", nil)
+	send := func(path, body string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(logging.WithLogger(req.Context(), logger)))
+	}
+
+	send("/v1/events/", `{"event_name":"Gig","password":"hunter2"}`)
+	if received != `{"event_name":"Gig","password":"hunter2"}` {
+		t.Errorf("Expected the handler to get the whole body, got %q", received)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log entry, got %q: %v", buf.String(), err)
+	}
+	logged := buf.String()
+	if strings.Contains(logged, "hunter2") || strings.Contains(logged, "ops@example.com") {
+		t.Errorf("Expected secrets and personal data redacted, got %s", logged)
+	}
+	if !strings.Contains(logged, `"event_name":"Gig"`) || !strings.Contains(logged, `"id":"e1"`) {
+		t.Errorf("Expected the other fields logged, got %s", logged)
+	}
+
+	// Malformed payloads, the case the mode exists for, are logged as redacted text
+	buf.Reset()
+	send("/events/", `{"event_name":"Gig", "api_key": "abc123",`)
+	if logged := buf.String(); strings.Contains(logged, "abc123") || !strings.Contains(logged, "Gig") {
+		t.Errorf("Expected the malformed body logged with the key redacted, got %s", logged)
+	}
+
+	buf.Reset()
+	send("/tracking/", `{"action":"view"}`)
+	if buf.Len() != 0 {
+		t.Errorf("Expected routes not listed to be left alone, got %s", buf.String())
+	}
+}
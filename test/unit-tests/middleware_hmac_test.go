@@ -0,0 +1,86 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhook_HMACSignature(t *testing.T) {
+	const secret = "s3cret"
+	body := `{"event_name": "Partner Gig", "city": "Warsaw", "type": "concert", "price": 10, "start_time": "2030-01-01T20:00:00Z"}`
+
+	var actor string
+	mockSvc := &MockEventService{
+		CreateFunc: func(ctx context.Context, event *domain.Event) error {
+			actor = domain.ActorFromContext(ctx)
+			event.Id = "evt_1"
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}, WebhookSecret: []byte(secret)})
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-transport.MaxSignatureAge-time.Minute).Unix(), 10)
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		want      int
+	}{
+		{"Valid", now, sign(secret, now, body), http.StatusCreated},
+		{"WrongSecret", now, sign("other", now, body), http.StatusUnauthorized},
+		{"Missing", now, "", http.StatusUnauthorized},
+		{"Malformed", now, "sha256=not-hex", http.StatusUnauthorized},
+		{"MissingTimestamp", "", sign(secret, now, body), http.StatusUnauthorized},
+		{"Replayed", stale, sign(secret, stale, body), http.StatusUnauthorized},
+		{"TimestampNotSigned", now, sign(secret, stale, body), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/events", strings.NewReader(body))
+			if tt.timestamp != "" {
+				req.Header.Set(transport.SignatureTimestampHeader, tt.timestamp)
+			}
+			if tt.signature != "" {
+				req.Header.Set(transport.SignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("Expected %d, got %d. Body: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if actor != transport.PartnerActor {
+		t.Errorf("Expected webhook writes to run as %q, got %q", transport.PartnerActor, actor)
+	}
+}
+
+func TestWebhook_NotRoutedWithoutSecret(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/events", strings.NewReader(`{}`)))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when no secret is configured, got %d", w.Code)
+	}
+}
@@ -0,0 +1,112 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/metrics"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWithMetrics_LabelsByRoutePattern verifies Ids are collapsed into the route pattern, including under stripped prefixes
+func TestWithMetrics_LabelsByRoutePattern(t *testing.T) {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+	}
+	handler := transport.WithMetrics(transport.NewRouter(transport.Services{
+		Events:         mockSvc,
+		Tracking:       &MockTrackingService{},
+		MetricsEnabled: true,
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events/evt-123", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no-such-route", nil))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected text exposition format, got %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/events/{id}",status="200"}`,
+		`http_requests_total{method="GET",route="unmatched",status="404"}`,
+		`http_request_duration_seconds_bucket{method="GET",route="/events/{id}",le="+Inf"}`,
+		"# TYPE http_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics output to contain %s", want)
+		}
+	}
+	if strings.Contains(body, "evt-123") {
+		t.Error("Raw Ids must not appear in metric labels")
+	}
+}
+
+func TestMetricsEndpoint_DisabledByDefault(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when metrics are disabled, got %d", w.Code)
+	}
+}
+
+func TestPprofEndpoints_OnlyWhenEnabled(t *testing.T) {
+	services := transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}}
+
+	w := httptest.NewRecorder()
+	transport.NewRouter(services).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when pprof is disabled, got %d", w.Code)
+	}
+
+	services.PprofEnabled = true
+	router := transport.NewRouter(services)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap?debug=1", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "heap profile") {
+		t.Errorf("Expected the heap profile, got %d: %.100s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	if w.Header().Get("Deprecation") != "" {
+		t.Error("Expected /debug/pprof/ to stay outside API versioning")
+	}
+}
+
+func TestRegistry_WritePrometheus(t *testing.T) {
+	reg := &metrics.Registry{}
+	counter := reg.NewCounterVec("jobs_total", "Jobs processed.", "result")
+	counter.Inc("ok")
+	counter.Add(2, "ok")
+	hist := reg.NewHistogramVec("job_seconds", "Job latency.", []float64{0.1, 1}, "kind")
+	hist.Observe(0.5, "import")
+
+	var sb strings.Builder
+	reg.WritePrometheus(&sb)
+	out := sb.String()
+
+	for _, want := range []string{
+		"# HELP jobs_total Jobs processed.",
+		`jobs_total{result="ok"} 3`,
+		`job_seconds_bucket{kind="import",le="0.1"} 0`,
+		`job_seconds_bucket{kind="import",le="1"} 1`,
+		`job_seconds_count{kind="import"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
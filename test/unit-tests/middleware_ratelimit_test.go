@@ -0,0 +1,93 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/ratelimit"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	return false, 0, errors.New("backend down")
+}
+
+func TestWithRateLimit(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	// 1 token per minute with a burst of 2: the third request from one IP is throttled
+	handler := transport.WithRateLimit(okHandler, ratelimit.NewMemoryLimiter(1.0/60, 2), "api", nil)
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set("X-Forwarded-For", ip)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := send("203.0.113.1"); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	w := send("203.0.113.1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("Expected Retry-After between 1 and 60 seconds, got %q", w.Header().Get("Retry-After"))
+	}
+
+	// Buckets are per client
+	if w := send("203.0.113.2"); w.Code != http.StatusOK {
+		t.Errorf("Expected a different IP to have its own bucket, got %d", w.Code)
+	}
+}
+
+func TestWithRateLimit_OnlyMatchingRequests(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithRateLimit(okHandler, ratelimit.NewMemoryLimiter(1.0/60, 1), "tracking", transport.IsTrackingWrite)
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Non-tracking request should not be limited, got %d", w.Code)
+		}
+	}
+
+	codes := make([]int, 2)
+	for i := range codes {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tracking/", nil))
+		codes[i] = w.Code
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected [200 429] for tracking writes, got %v", codes)
+	}
+}
+
+func TestWithRateLimit_FailsOpen(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithRateLimit(okHandler, failingLimiter{}, "api", nil)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected requests to pass when the limiter fails, got %d", w.Code)
+	}
+}
@@ -0,0 +1,46 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithRecovery_Returns500(t *testing.T) {
+	for _, service := range []string{"", "bibently-backend"} {
+		handler := transport.WithRequestID(transport.WithRecovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}), service), nil)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("service %q: expected 500, got %d", service, w.Code)
+		}
+		var resp domain.APIResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.RequestID == "" {
+			t.Error("Expected the 500 payload to carry the request Id")
+		}
+	}
+}
+
+// TestWithRecovery_AbortHandlerPropagates verifies http.ErrAbortHandler is left to net/http
+func TestWithRecovery_AbortHandlerPropagates(t *testing.T) {
+	handler := transport.WithRecovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), "")
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be re-panicked, got %v", rec)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,93 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/logging"
+	"bibently.com/backend/internal/transport"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		inbound  string
+		wantEcho bool
+	}{
+		{"Generated when absent", "", false},
+		{"Inbound honored", "req-abc-123", true},
+		{"Inbound with spaces replaced", "bad id", false},
+		{"Oversized inbound replaced", strings.Repeat("x", 200), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := transport.WithRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = transport.RequestIDFromContext(r.Context())
+			}), nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+			if tt.inbound != "" {
+				req.Header.Set(transport.RequestIDHeader, tt.inbound)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			got := w.Header().Get(transport.RequestIDHeader)
+			if got == "" || got != seen {
+				t.Fatalf("Expected the response header to echo the context Id, got header %q and context %q", got, seen)
+			}
+			if tt.wantEcho != (got == tt.inbound) {
+				t.Errorf("Inbound %q: unexpected request Id %q", tt.inbound, got)
+			}
+		})
+	}
+}
+
+// TestWithRequestID_InErrorPayload verifies error bodies carry the Id so users can report it
+func TestWithRequestID_InErrorPayload(t *testing.T) {
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	handler := transport.WithRequestID(transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}}), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/missing", nil)
+	req.Header.Set(transport.RequestIDHeader, "req-404")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var resp domain.APIResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.RequestID != "req-404" {
+		t.Errorf("Expected request_id req-404 in the error payload, got %q", resp.RequestID)
+	}
+}
+
+// TestWithRequestID_LogsCarryTheId verifies handlers log through a child of the injected logger
+func TestWithRequestID_LogsCarryTheId(t *testing.T) {
+	var buf bytes.Buffer
+	handler := transport.WithRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).WarnContext(r.Context(), "something odd")
+	}), logging.New(&buf, "test-project", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+	req.Header.Set(transport.RequestIDHeader, "req-log")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log entry, got %q: %v", buf.String(), err)
+	}
+	if entry["request_id"] != "req-log" || entry["severity"] != "WARN" || entry["message"] != "something odd" {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+}
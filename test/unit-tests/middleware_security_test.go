@@ -71,3 +71,56 @@ func TestWithSecurityHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestWithCORS_Origins(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithCORS(okHandler, "https://bibently.com, https://*.bibently.com")
+
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{"https://bibently.com", "https://bibently.com"},
+		{"https://app.bibently.com", "https://app.bibently.com"},
+		{"https://staging.app.bibently.com", "https://staging.app.bibently.com"},
+		{"http://app.bibently.com", ""},
+		{"https://evilbibently.com", ""},
+		{"https://bibently.com.evil.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			req.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.want {
+				t.Errorf("Expected Access-Control-Allow-Origin %q, got %q", tt.want, got)
+			}
+			if w.Header().Get("Vary") != "Origin" {
+				t.Errorf("Expected Vary: Origin, got %q", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestWithCORS_WildcardAndPreflight(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Preflight should not reach the handler")
+	})
+	handler := transport.WithCORS(okHandler, "")
+
+	req := httptest.NewRequest(http.MethodOptions, "/events", nil)
+	req.Header.Set("Origin", "https://anywhere.example")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected '*' when no origins are configured, got %q", got)
+	}
+	if w.Header().Get("Access-Control-Max-Age") == "" {
+		t.Error("Expected Access-Control-Max-Age on preflight")
+	}
+}
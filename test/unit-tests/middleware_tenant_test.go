@@ -0,0 +1,119 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+func TestWithTenantPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantCode   int
+		wantPath   string
+		wantTenant string
+	}{
+		{"Root path untouched", "/v1/events", http.StatusOK, "/v1/events", ""},
+		{"Tenant stripped", "/tenants/acme/v1/events", http.StatusOK, "/v1/events", "acme"},
+		{"Invalid tenant", "/tenants/ac.me/v1/events", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, tenant string
+			handler := transport.WithTenantPath(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path, tenant = r.URL.Path, domain.TenantFromContext(r.Context())
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if path != tt.wantPath || tenant != tt.wantTenant {
+				t.Errorf("Expected %q in tenant %q, got %q in %q", tt.wantPath, tt.wantTenant, path, tenant)
+			}
+		})
+	}
+}
+
+func TestWithTenantIsolation(t *testing.T) {
+	tests := []struct {
+		name        string
+		pathTenant  string
+		tokenTenant string
+		role        string
+		guest       bool
+		wantCode    int
+		wantTenant  string
+	}{
+		{"Guest on a tenant path", "acme", "", "", true, http.StatusOK, "acme"},
+		{"Tenant user without a path", "", "acme", "", false, http.StatusOK, "acme"},
+		{"Tenant user on its path", "acme", "acme", "", false, http.StatusOK, "acme"},
+		{"Tenant user on another tenant", "globex", "acme", "", false, http.StatusForbidden, ""},
+		{"Tenant admin on another tenant", "globex", "acme", domain.RoleAdmin, false, http.StatusForbidden, ""},
+		{"Root user on a tenant path", "acme", "", "", false, http.StatusForbidden, ""},
+		{"Root admin on a tenant path", "acme", "", domain.RoleAdmin, false, http.StatusOK, "acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tenant string
+			handler := transport.WithTenantIsolation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenant = domain.TenantFromContext(r.Context())
+			}))
+
+			ctx := context.Background()
+			if tt.pathTenant != "" {
+				ctx = domain.WithTenant(ctx, tt.pathTenant)
+			}
+			if !tt.guest {
+				token := &auth.Token{UID: "user-1", Firebase: auth.FirebaseInfo{Tenant: tt.tokenTenant}}
+				ctx = context.WithValue(ctx, transport.UserContextKey, token)
+				ctx = domain.WithPrincipal(ctx, domain.Principal{UID: token.UID, Role: tt.role})
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/events", nil).WithContext(ctx))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if tenant != tt.wantTenant {
+				t.Errorf("Expected tenant %q, got %q", tt.wantTenant, tenant)
+			}
+		})
+	}
+}
+
+// Each tenant sees only its own events and tracking
+func TestMemoryRepositories_TenantIsolation(t *testing.T) {
+	events := repository.NewMemoryEventRepository()
+	tracking := repository.NewMemoryTrackingRepository()
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+
+	if err := events.Save(acme, &domain.Event{Id: "e1", EventName: "Acme"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := tracking.SaveTracking(acme, &domain.TrackingEvent{Action: "view"}); err != nil {
+		t.Fatalf("SaveTracking failed: %v", err)
+	}
+
+	if _, err := events.GetByID(acme, "e1"); err != nil {
+		t.Errorf("Expected the tenant's event, got %v", err)
+	}
+	for _, ctx := range []context.Context{globex, context.Background()} {
+		if _, err := events.GetByID(ctx, "e1"); err == nil {
+			t.Errorf("Expected the event to be invisible outside acme (tenant %q)", domain.TenantFromContext(ctx))
+		}
+		if tracks, _ := tracking.ListTracking(ctx); len(tracks) != 0 {
+			t.Errorf("Expected no tracking outside acme, got %d", len(tracks))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_ListEvents_NDJSONStream(t *testing.T) {
+	listCalled := false
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			listCalled = true
+			return nil, domain.Meta{}, nil
+		},
+		StreamFunc: func(ctx context.Context, req domain.SearchRequest, fn func(*domain.Event) error) error {
+			if req.Filters.City != "Warsaw" {
+				t.Errorf("Expected filters to be passed to the stream, got city %q", req.Filters.City)
+			}
+			for _, id := range []string{"1", "2", "3"} {
+				if err := fn(&domain.Event{Id: id, EventName: "Event " + id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/events/?city=Warsaw&fields=id,event_name", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if listCalled {
+		t.Error("Expected the paged list not to be used for NDJSON")
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Expected application/x-ndjson, got %q", got)
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Line is not a JSON object: %q", scanner.Text())
+		}
+		ids = append(ids, line["id"].(string))
+	}
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("Expected one projected event per line, got %v", ids)
+	}
+}
+
+func TestHandler_ListEvents_NDJSONErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		emitted    int
+		wantCode   int
+		wantErrEnd bool
+	}{
+		{"Before first line", 0, http.StatusInternalServerError, false},
+		{"Mid-stream", 2, http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &MockEventService{
+				StreamFunc: func(ctx context.Context, req domain.SearchRequest, fn func(*domain.Event) error) error {
+					for i := 0; i < tt.emitted; i++ {
+						_ = fn(&domain.Event{Id: "x"})
+					}
+					return errors.New("firestore unavailable")
+				},
+			}
+			router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+			req := httptest.NewRequest(http.MethodGet, "/events/", nil)
+			req.Header.Set("Accept", "application/x-ndjson")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+			if tt.wantErrEnd && !strings.Contains(lines[len(lines)-1], `"error":"stream interrupted"`) {
+				t.Errorf("Expected the stream to end with an error line, got %q", lines[len(lines)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/notify"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockSubscriptionRepo struct {
+	ListEmailFunc func(ctx context.Context, city string) ([]domain.Subscription, error)
+}
+
+func (m *MockSubscriptionRepo) GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error) {
+	return nil, domain.ErrNotFound("subscription not found")
+}
+
+func (m *MockSubscriptionRepo) SaveSubscription(ctx context.Context, sub *domain.Subscription) error {
+	return nil
+}
+
+func (m *MockSubscriptionRepo) DeleteSubscription(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (m *MockSubscriptionRepo) ListEmailSubscribers(ctx context.Context, city string) ([]domain.Subscription, error) {
+	if m.ListEmailFunc != nil {
+		return m.ListEmailFunc(ctx, city)
+	}
+	return nil, nil
+}
+
+type recordingMailer struct {
+	sent []notify.Email
+}
+
+func (m *recordingMailer) Send(ctx context.Context, email notify.Email) error {
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+type notifierFunc func(ctx context.Context, event *domain.Event) error
+
+func (f notifierFunc) EventPublished(ctx context.Context, event *domain.Event) error {
+	return f(ctx, event)
+}
+
+func TestEmailNotifier_MailsCitySubscribersInBatches(t *testing.T) {
+	var queriedCity string
+	subs := &MockSubscriptionRepo{
+		ListEmailFunc: func(ctx context.Context, city string) ([]domain.Subscription, error) {
+			queriedCity = city
+			list := make([]domain.Subscription, 1500)
+			for i := range list {
+				list[i] = domain.Subscription{UserID: fmt.Sprint(i), Email: fmt.Sprintf("user%d@example.com", i), EmailOptIn: true}
+			}
+			return list, nil
+		},
+	}
+	mailer := &recordingMailer{}
+	notifier := notify.NewEmailNotifier(subs, nil, mailer, "https://bibently.com/")
+
+	event := &domain.Event{EventName: "Jazz <Night>", City: "Krakow", Slug: "jazz-night", StartTime: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC), Timezone: "Europe/Warsaw"}
+	if err := notifier.EventPublished(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if queriedCity != "Krakow" {
+		t.Errorf("expected subscribers of Krakow, got %q", queriedCity)
+	}
+	if len(mailer.sent) != 2 || len(mailer.sent[0].To) != 1000 || len(mailer.sent[1].To) != 500 {
+		t.Fatalf("expected batches of 1000 and 500, got %d mails", len(mailer.sent))
+	}
+	mail := mailer.sent[0]
+	if mail.Subject != "New in Krakow: Jazz <Night>" {
+		t.Errorf("unexpected subject %q", mail.Subject)
+	}
+	if !strings.Contains(mail.Text, "https://bibently.com/events/jazz-night") || !strings.Contains(mail.Text, "20:00 CEST") {
+		t.Errorf("unexpected text body: %s", mail.Text)
+	}
+	if !strings.Contains(mail.HTML, "Jazz &lt;Night&gt;") {
+		t.Errorf("expected escaped HTML body, got: %s", mail.HTML)
+	}
+}
+
+func TestEmailNotifier_NoSubscribers(t *testing.T) {
+	mailer := &recordingMailer{}
+	notifier := notify.NewEmailNotifier(&MockSubscriptionRepo{}, nil, mailer, "https://bibently.com")
+
+	if err := notifier.EventPublished(context.Background(), &domain.Event{EventName: "Talk", City: "Gdansk"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mailer.sent) != 0 {
+		t.Errorf("expected no mail, got %d", len(mailer.sent))
+	}
+}
+
+func TestSendGridMailer_Payload(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" || r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	mailer := notify.NewSendGridMailer("key", "events@bibently.com", server.URL)
+	err := mailer.Send(context.Background(), notify.Email{To: []string{"a@example.com", "b@example.com"}, Subject: "Hi", Text: "t", HTML: "<p>h</p>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// One personalization per recipient keeps addresses private
+	if p, _ := body["personalizations"].([]interface{}); len(p) != 2 {
+		t.Errorf("expected 2 personalizations, got %v", body["personalizations"])
+	}
+}
+
+func TestMailgunMailer_BatchSendAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if r.URL.Path != "/v3/mg.example.com/messages" || user != "api" || pass != "key" {
+			t.Errorf("unexpected request %s (%s)", r.URL.Path, user)
+		}
+		if r.FormValue("to") != "a@example.com,b@example.com" || !strings.Contains(r.FormValue("recipient-variables"), "b@example.com") {
+			t.Errorf("expected a batch send, got to=%q vars=%q", r.FormValue("to"), r.FormValue("recipient-variables"))
+		}
+		if r.FormValue("subject") == "fail" {
+			http.Error(w, "domain not verified", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	mailer := notify.NewMailgunMailer("key", "mg.example.com", "events@bibently.com", server.URL)
+	email := notify.Email{To: []string{"a@example.com", "b@example.com"}, Subject: "Hi", Text: "t", HTML: "h"}
+	if err := mailer.Send(context.Background(), email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	email.Subject = "fail"
+	if err := mailer.Send(context.Background(), email); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected provider error with status, got %v", err)
+	}
+}
+
+type recordingAnnouncer struct {
+	scheduled []string
+	announced []string
+	fail      error
+}
+
+func (a *recordingAnnouncer) Schedule(ctx context.Context, eventID string) error {
+	a.scheduled = append(a.scheduled, eventID)
+	return a.fail
+}
+
+func (a *recordingAnnouncer) Announce(ctx context.Context, eventID string) error {
+	a.announced = append(a.announced, eventID)
+	return a.fail
+}
+
+// MockAnnouncementRepo keeps delivered batches in memory
+type MockAnnouncementRepo struct {
+	delivered map[string]map[string]bool
+}
+
+func (m *MockAnnouncementRepo) Delivered(ctx context.Context, eventID string) (map[string]bool, error) {
+	done := map[string]bool{}
+	for batch := range m.delivered[eventID] {
+		done[batch] = true
+	}
+	return done, nil
+}
+
+func (m *MockAnnouncementRepo) MarkDelivered(ctx context.Context, eventID, batch string) error {
+	if m.delivered == nil {
+		m.delivered = map[string]map[string]bool{}
+	}
+	if m.delivered[eventID] == nil {
+		m.delivered[eventID] = map[string]bool{}
+	}
+	m.delivered[eventID][batch] = true
+	return nil
+}
+
+// failingMailer fails the sends listed in failOn (0-based), then succeeds
+type failingMailer struct {
+	recordingMailer
+	calls  int
+	failOn map[int]bool
+}
+
+func (m *failingMailer) Send(ctx context.Context, email notify.Email) error {
+	call := m.calls
+	m.calls++
+	if m.failOn[call] {
+		return errors.New("provider down")
+	}
+	return m.recordingMailer.Send(ctx, email)
+}
+
+func TestEmailNotifier_RetrySkipsDeliveredBatches(t *testing.T) {
+	subs := &MockSubscriptionRepo{
+		ListEmailFunc: func(ctx context.Context, city string) ([]domain.Subscription, error) {
+			list := make([]domain.Subscription, 2500)
+			for i := range list {
+				list[i] = domain.Subscription{UserID: fmt.Sprint(i), Email: fmt.Sprintf("user%d@example.com", i), EmailOptIn: true}
+			}
+			return list, nil
+		},
+	}
+	deliveries := &MockAnnouncementRepo{}
+	// The second of three batches fails on the first attempt
+	mailer := &failingMailer{failOn: map[int]bool{1: true}}
+	notifier := notify.NewEmailNotifier(subs, deliveries, mailer, "https://bibently.com")
+	event := &domain.Event{Id: "evt-1", EventName: "Talk", City: "Gdansk"}
+
+	if err := notifier.EventPublished(context.Background(), event); err == nil {
+		t.Fatal("expected the failed batch to be reported")
+	}
+	if err := notifier.EventPublished(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if err := notifier.EventPublished(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error on a repeated announcement: %v", err)
+	}
+
+	// Attempt 1 delivers batch 0, attempt 2 delivers batches 1 and 2, attempt 3 sends nothing
+	if len(mailer.sent) != 3 {
+		t.Fatalf("expected every batch to be mailed exactly once, got %d mails", len(mailer.sent))
+	}
+	seen := map[string]bool{}
+	for _, mail := range mailer.sent {
+		for _, to := range mail.To {
+			if seen[to] {
+				t.Fatalf("%s was mailed twice", to)
+			}
+			seen[to] = true
+		}
+	}
+	if len(seen) != 2500 {
+		t.Errorf("expected 2500 recipients, got %d", len(seen))
+	}
+}
+
+func TestCreateEvent_SchedulesAnnouncement(t *testing.T) {
+	announcer := &recordingAnnouncer{fail: errors.New("queue down")}
+	svc := service.NewEventService(&test.MockRepository{}, &test.MockRevisionRepository{}, service.WithAnnouncer(announcer))
+
+	event := &domain.Event{EventName: "Go Meetup", City: "Warsaw"}
+	if err := svc.CreateEvent(context.Background(), event); err != nil {
+		t.Fatalf("a scheduling failure must not fail the create, got %v", err)
+	}
+	if len(announcer.scheduled) != 1 || announcer.scheduled[0] != event.Id {
+		t.Errorf("expected the created event to be scheduled, got %v", announcer.scheduled)
+	}
+	if len(announcer.announced) != 0 {
+		t.Error("subscribers must not be notified inside the create")
+	}
+}
+
+func TestAnnouncementService_Announce(t *testing.T) {
+	var notified *domain.Event
+	repo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			if id == "gone" {
+				return nil, domain.ErrNotFound("event not found")
+			}
+			return &domain.Event{Id: id, EventName: "Go Meetup", City: "Warsaw"}, nil
+		},
+	}
+	svc := service.NewAnnouncementService(repo, notifierFunc(func(ctx context.Context, event *domain.Event) error {
+		notified = event
+		return nil
+	}), nil)
+
+	if err := svc.Announce(context.Background(), "evt-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if notified == nil || notified.Id != "evt-1" {
+		t.Errorf("expected the stored event to be announced, got %+v", notified)
+	}
+
+	notified = nil
+	if err := svc.Announce(context.Background(), "gone"); err != nil || notified != nil {
+		t.Errorf("expected a deleted event to be skipped without a retry, got %v (%+v)", err, notified)
+	}
+}
@@ -0,0 +1,171 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/openapi"
+	"bibently.com/backend/internal/transport"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPI_ServedUnversionedAndPublic(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, APIDocs: transport.APIDocsPublic})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if w.Header().Get("Deprecation") != "" {
+		t.Errorf("Expected /openapi.json outside API versioning, got Deprecation %q", w.Header().Get("Deprecation"))
+	}
+	var doc openapi.Document
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("Expected OpenAPI 3.1.0, got %q", doc.OpenAPI)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "/v1" {
+		t.Errorf("Expected the /v1 server, got %+v", doc.Servers)
+	}
+}
+
+func TestOpenAPI_AdminOnlyDocs(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, APIDocs: transport.APIDocsAdmin})
+	handler := transport.WithAuthProtection(router, nil, transport.APIDocsPolicies(nil, transport.APIDocsAdmin), "")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected the document to be admin-only, got %d", w.Code)
+	}
+
+	// The page itself holds no API details and asks for a token
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "swagger-initializer.js") {
+		t.Fatalf("Expected the Swagger UI page, got %d", w.Code)
+	}
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "script-src 'self' https://unpkg.com/") || strings.Contains(csp, "'unsafe-eval'") {
+		t.Errorf("Expected a CSP allowing the pinned Swagger UI scripts, got %q", csp)
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/swagger-initializer.js", nil))
+	if !strings.Contains(w.Body.String(), "const adminOnly = true;") {
+		t.Errorf("Expected the initializer to ask for a token, got %q", w.Body.String())
+	}
+
+	// Other routes keep their policies
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-logs", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected the default policies behind the docs policy, got %d", w.Code)
+	}
+}
+
+func TestOpenAPI_DocsOff(t *testing.T) {
+	router := transport.NewRouter(transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}, APIDocs: transport.APIDocsOff})
+
+	for _, path := range []string{"/openapi.json", "/swagger/"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected %s not to be served, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestOpenAPI_Components(t *testing.T) {
+	doc := transport.OpenAPISpec()
+	schemas := doc.Components.Schemas
+
+	eventType := schemas["EventType"]
+	// Types are configured per deployment, so clients must not get them as an enum
+	if eventType == nil || eventType.Type != "string" || len(eventType.Enum) != 0 {
+		t.Fatalf("Expected EventType to be a string without an enum, got %+v", eventType)
+	}
+	if ref := schemas["EventDTO"].Properties["type"].Ref; ref != "#/components/schemas/EventType" {
+		t.Errorf("Expected EventDTO.type to reference EventType, got %q", ref)
+	}
+	if required := schemas["EventDTO"].Required; !slices.Contains(required, "event_name") || slices.Contains(required, "end_time") {
+		t.Errorf("Expected required fields from validate tags, got %v", required)
+	}
+
+	errorModel := schemas["Error"]
+	if errorModel == nil || !slices.Contains(errorModel.Properties["code"].Enum, any(domain.CodeDuplicateEvent)) {
+		t.Errorf("Expected an Error model listing the error codes, got %+v", errorModel)
+	}
+	if scheme := doc.Components.SecuritySchemes["bearerAuth"]; scheme == nil || scheme.Scheme != "bearer" {
+		t.Errorf("Expected a bearer security scheme, got %+v", scheme)
+	}
+}
+
+func TestOpenAPI_Operations(t *testing.T) {
+	doc := transport.OpenAPISpec()
+
+	list := doc.Paths["/events"].Get
+	page := list.Responses["200"].Content["application/json"].Schema
+	if page.Properties["data"].Type != "array" || page.Properties["meta"].Ref != "#/components/schemas/Meta" {
+		t.Errorf("Expected the pagination envelope, got %+v", page)
+	}
+	if _, ok := list.Responses["200"].Content["application/x-ndjson"]; !ok {
+		t.Error("Expected GET /events to document NDJSON")
+	}
+	if len(list.Security) != 2 || len(list.Security[0]) != 0 || list.RequiredRole != "" {
+		t.Errorf("Expected optional authentication on a public route, got %+v", list.Security)
+	}
+
+	create := doc.Paths["/events"].Post
+	if create.RequiredRole != domain.RoleOrganizer || create.Responses["401"] == nil || create.Responses["400"].Ref != "#/components/responses/BadRequest" {
+		t.Errorf("Expected POST /events to need an organizer, got role %q and responses %v", create.RequiredRole, create.Responses)
+	}
+	if role := doc.Paths["/admin/audit-logs"].Get.RequiredRole; role != domain.RoleAdmin {
+		t.Errorf("Expected the audit log to need an admin, got %q", role)
+	}
+	if servers := doc.Paths["/healthz"].Servers; len(servers) != 1 || servers[0].URL != "/" {
+		t.Errorf("Expected /healthz outside the versioned API, got %+v", servers)
+	}
+
+	ids := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for _, op := range []*openapi.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch} {
+			if op == nil {
+				continue
+			}
+			if ids[op.OperationID] {
+				t.Errorf("Duplicate operationId %q (%s)", op.OperationID, path)
+			}
+			ids[op.OperationID] = true
+		}
+	}
+}
+
+func TestOpenAPI_SchemasFollowEncodingJSON(t *testing.T) {
+	type item struct {
+		Name    string   `json:"name"`
+		Note    *string  `json:"note"`
+		Tags    []string `json:"tags,omitempty"`
+		Ignored string   `json:"-"`
+	}
+	schemas := openapi.NewSchemas()
+	schemas.For(item{})
+	schema := schemas.Components()["Unittestsitem"]
+	if schema == nil {
+		t.Fatalf("Expected a component for item, got %v", schemas.Components())
+	}
+	if _, ok := schema.Properties["Ignored"]; ok {
+		t.Error("Expected json:\"-\" fields to be left out")
+	}
+	if !slices.Equal(schema.Required, []string{"name", "note"}) {
+		t.Errorf("Expected fields without omitempty to be required, got %v", schema.Required)
+	}
+	if typ, _ := schema.Properties["note"].Type.([]string); !slices.Equal(typ, []string{"string", "null"}) {
+		t.Errorf("Expected a nullable pointer, got %v", schema.Properties["note"].Type)
+	}
+}
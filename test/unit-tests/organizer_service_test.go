@@ -0,0 +1,98 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockOrganizerRepo for organizer tests
+type MockOrganizerRepo struct {
+	ListFunc     func(ctx context.Context) ([]domain.Organizer, error)
+	GetByIDFunc  func(ctx context.Context, id string) (*domain.Organizer, error)
+	GetMultiFunc func(ctx context.Context, ids []string) ([]domain.Organizer, error)
+	SaveFunc     func(ctx context.Context, organizer *domain.Organizer) error
+	UpdateFunc   func(ctx context.Context, id string, updates map[string]interface{}) error
+	DeleteFunc   func(ctx context.Context, id string) error
+}
+
+func (m *MockOrganizerRepo) List(ctx context.Context) ([]domain.Organizer, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockOrganizerRepo) GetByID(ctx context.Context, id string) (*domain.Organizer, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockOrganizerRepo) GetMulti(ctx context.Context, ids []string) ([]domain.Organizer, error) {
+	if m.GetMultiFunc != nil {
+		return m.GetMultiFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockOrganizerRepo) Save(ctx context.Context, organizer *domain.Organizer) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, organizer)
+	}
+	return nil
+}
+
+func (m *MockOrganizerRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, updates)
+	}
+	return nil
+}
+
+func (m *MockOrganizerRepo) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func TestCreateOrganizer(t *testing.T) {
+	repo := &MockOrganizerRepo{
+		SaveFunc: func(ctx context.Context, o *domain.Organizer) error {
+			if o.Id == "" || o.CreatedAt.IsZero() {
+				return errors.New("id or created_at not set")
+			}
+			return nil
+		},
+	}
+	svc := service.NewOrganizerService(repo)
+
+	if err := svc.CreateOrganizer(context.Background(), &domain.Organizer{Name: "Blue Note"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := svc.CreateOrganizer(context.Background(), &domain.Organizer{}); err == nil {
+		t.Error("Expected validation error for missing name")
+	}
+}
+
+func TestUpdateOrganizer_NotFound(t *testing.T) {
+	repo := &MockOrganizerRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Organizer, error) {
+			return nil, domain.ErrNotFound("organizer not found")
+		},
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			t.Error("Update should NOT be called for a missing organizer")
+			return nil
+		},
+	}
+	svc := service.NewOrganizerService(repo)
+
+	err := svc.UpdateOrganizer(context.Background(), "missing", map[string]interface{}{"name": "X"})
+	if err == nil || err.Error() != "organizer not found" {
+		t.Errorf("Expected 'organizer not found', got %v", err)
+	}
+}
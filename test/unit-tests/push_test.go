@@ -0,0 +1,190 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/notify"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	"firebase.google.com/go/v4/messaging"
+)
+
+type MockPushSubscriptionRepo struct {
+	Saved       []*domain.PushSubscription
+	TargetsFunc func(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error)
+}
+
+func (m *MockPushSubscriptionRepo) SavePushSubscription(ctx context.Context, sub *domain.PushSubscription) error {
+	m.Saved = append(m.Saved, sub)
+	return nil
+}
+
+func (m *MockPushSubscriptionRepo) ListPushSubscriptions(ctx context.Context, userID string) ([]domain.PushSubscription, error) {
+	return nil, nil
+}
+
+func (m *MockPushSubscriptionRepo) DeletePushSubscription(ctx context.Context, userID, id string) error {
+	return nil
+}
+
+func (m *MockPushSubscriptionRepo) ListPushTargets(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error) {
+	if m.TargetsFunc != nil {
+		return m.TargetsFunc(ctx, city, eventType)
+	}
+	return nil, nil
+}
+
+func (m *MockPushSubscriptionRepo) DeletePushTokens(ctx context.Context, tokens []string) error {
+	return nil
+}
+
+type recordingPushSender struct {
+	messages []*messaging.MulticastMessage
+	fail     error
+}
+
+func (s *recordingPushSender) SendEachForMulticast(ctx context.Context, msg *messaging.MulticastMessage) (*messaging.BatchResponse, error) {
+	s.messages = append(s.messages, msg)
+	resp := &messaging.BatchResponse{}
+	for range msg.Tokens {
+		if s.fail != nil {
+			resp.FailureCount++
+			resp.Responses = append(resp.Responses, &messaging.SendResponse{Error: s.fail})
+		} else {
+			resp.SuccessCount++
+			resp.Responses = append(resp.Responses, &messaging.SendResponse{Success: true})
+		}
+	}
+	return resp, nil
+}
+
+func TestPushNotifier_OnePushPerDevice(t *testing.T) {
+	var gotCity string
+	var gotType domain.EventType
+	repo := &MockPushSubscriptionRepo{
+		TargetsFunc: func(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error) {
+			gotCity, gotType = city, eventType
+			// device-a follows both the city and the type
+			return []domain.PushSubscription{
+				{Token: "device-a", City: city},
+				{Token: "device-a", City: city, Type: eventType},
+				{Token: "device-b", City: city},
+			}, nil
+		},
+	}
+	sender := &recordingPushSender{}
+	notifier := notify.NewPushNotifier(repo, nil, sender)
+
+	event := &domain.Event{Id: "evt-1", EventName: "Jazz Night", City: "Krakow", Type: "concert", Slug: "jazz-night"}
+	if err := notifier.EventPublished(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotCity != "Krakow" || gotType != "concert" {
+		t.Errorf("expected targets for Krakow/concert, got %s/%s", gotCity, gotType)
+	}
+	if len(sender.messages) != 1 {
+		t.Fatalf("expected one multicast, got %d", len(sender.messages))
+	}
+	msg := sender.messages[0]
+	if len(msg.Tokens) != 2 {
+		t.Errorf("expected 2 distinct tokens, got %v", msg.Tokens)
+	}
+	if msg.Notification.Title != "New in Krakow" || msg.Data["event_id"] != "evt-1" || msg.Data["slug"] != "jazz-night" {
+		t.Errorf("unexpected message: %+v %+v", msg.Notification, msg.Data)
+	}
+}
+
+func TestPushNotifier_ReportsFailures(t *testing.T) {
+	repo := &MockPushSubscriptionRepo{
+		TargetsFunc: func(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error) {
+			return []domain.PushSubscription{{Token: "device-a", City: city}}, nil
+		},
+	}
+	notifier := notify.NewPushNotifier(repo, nil, &recordingPushSender{fail: errors.New("quota exceeded")})
+
+	err := notifier.EventPublished(context.Background(), &domain.Event{EventName: "Talk", City: "Gdansk"})
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("expected the push failure to be reported, got %v", err)
+	}
+}
+
+func TestPushNotifier_RetrySkipsDeliveredBatches(t *testing.T) {
+	repo := &MockPushSubscriptionRepo{
+		TargetsFunc: func(ctx context.Context, city string, eventType domain.EventType) ([]domain.PushSubscription, error) {
+			return []domain.PushSubscription{{Token: "device-a", City: city}}, nil
+		},
+	}
+	sender := &recordingPushSender{}
+	notifier := notify.NewPushNotifier(repo, &MockAnnouncementRepo{}, sender)
+	event := &domain.Event{Id: "evt-1", EventName: "Talk", City: "Gdansk"}
+
+	for range 2 {
+		if err := notifier.EventPublished(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(sender.messages) != 1 {
+		t.Errorf("expected a repeated announcement to push nothing, got %d multicasts", len(sender.messages))
+	}
+}
+
+func TestMultiNotifier_RunsAll(t *testing.T) {
+	calls := 0
+	n := notify.Multi(
+		notifierFunc(func(ctx context.Context, e *domain.Event) error { calls++; return errors.New("email down") }),
+		notifierFunc(func(ctx context.Context, e *domain.Event) error { calls++; return nil }),
+	)
+	if err := n.EventPublished(context.Background(), &domain.Event{}); err == nil {
+		t.Error("expected the first notifier's error")
+	}
+	if calls != 2 {
+		t.Errorf("expected both notifiers to run, got %d", calls)
+	}
+}
+
+func TestPushSubscriptionHandler_Subscribe(t *testing.T) {
+	repo := &MockPushSubscriptionRepo{}
+	router := transport.NewRouter(transport.Services{
+		Events:            &MockEventService{},
+		Tracking:          &MockTrackingService{},
+		PushSubscriptions: service.NewPushSubscriptionService(repo),
+	})
+
+	body := `{"token":"fcm-token","city":" Krakow ","type":"concert"}`
+	subscribe := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/me/subscriptions", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), transport.UserContextKey, &auth.Token{UID: "user_9"}))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := subscribe()
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "fcm-token") {
+		t.Error("the device token must not be echoed back")
+	}
+	subscribe()
+	if len(repo.Saved) != 2 || repo.Saved[0].Id != repo.Saved[1].Id {
+		t.Fatalf("expected a stable id for repeated subscriptions, got %+v", repo.Saved)
+	}
+	if sub := repo.Saved[0]; sub.UserID != "user_9" || sub.City != "Krakow" || sub.Type != "concert" {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+
+	// Unknown event types are rejected
+	body = `{"token":"fcm-token","city":"Krakow","type":"karaoke-marathon"}`
+	if w := subscribe(); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown type, got %d", w.Code)
+	}
+}
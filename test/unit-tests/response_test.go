@@ -0,0 +1,108 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponses_UseSnakeCase(t *testing.T) {
+	for name, v := range map[string]any{
+		"event":     service.NewEventResponse(&domain.Event{Id: "e1", EventName: "Jazz"}, time.Now()),
+		"organizer": domain.Organizer{Id: "o1"},
+		"tier":      domain.TicketTier{Id: "t1"},
+		"tracking":  domain.NewTrackingEventResponse(&domain.TrackingEvent{Id: "t1"}),
+		"meta":      domain.Meta{NextPageToken: "next"},
+	} {
+		data, _ := json.Marshal(v)
+		var fields map[string]any
+		_ = json.Unmarshal(data, &fields)
+		for key := range fields {
+			for _, c := range key {
+				if c >= 'A' && c <= 'Z' {
+					t.Errorf("%s: expected snake_case names, got %q", name, key)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestTrackingEventResponse_LeavesOutStorageFields(t *testing.T) {
+	track := domain.TrackingEvent{Id: "t1", Action: "view", CreatedAt: time.Now(), ExpireAt: time.Now().Add(time.Hour)}
+
+	data, _ := json.Marshal(domain.NewTrackingEventResponse(&track))
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+	if fields["action"] != "view" {
+		t.Errorf("Expected the action, got %v", fields)
+	}
+	if _, ok := fields["expire_at"]; ok {
+		t.Error("Expected expire_at to stay out of responses")
+	}
+
+	if responses := domain.NewTrackingEventResponses(nil); responses == nil || len(responses) != 0 {
+		t.Errorf("Expected an empty list, got %#v", responses)
+	}
+}
+
+func TestNewEventResponse(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := domain.Event{
+		Id:        "e1",
+		EventName: "Jazz",
+		StartTime: time.Date(2030, 6, 1, 18, 0, 0, 0, time.UTC),
+		Timezone:  "Europe/Warsaw",
+		DedupKey:  "hash",
+		CreatedBy: "uid-1",
+	}
+
+	resp := service.NewEventResponse(&event, now)
+	if resp.IsPast || !resp.IsFree {
+		t.Errorf("Expected an upcoming free event, got is_past %v, is_free %v", resp.IsPast, resp.IsFree)
+	}
+	if resp.StartTime.Location().String() != "Europe/Warsaw" || !resp.StartTime.Equal(event.StartTime) {
+		t.Errorf("Expected the start time in the event's timezone, got %v", resp.StartTime)
+	}
+	data, _ := json.Marshal(resp)
+	if !strings.Contains(string(data), `"start_time":"2030-06-01T20:00:00+02:00"`) {
+		t.Errorf("Expected a local start time, got %s", data)
+	}
+	for _, hidden := range []string{"dedup_key", "event_name_lc", "created_by", "end_time"} {
+		if strings.Contains(string(data), `"`+hidden+`"`) {
+			t.Errorf("Expected %s to stay out of the response, got %s", hidden, data)
+		}
+	}
+
+	// Without an end time the event is past once it has started; with one, once that has passed
+	if !service.NewEventResponse(&event, event.StartTime.Add(time.Minute)).IsPast {
+		t.Error("Expected a started event without an end time to be past")
+	}
+	event.EndTime = event.StartTime.Add(3 * time.Hour)
+	event.Price = 20
+	resp = service.NewEventResponse(&event, event.StartTime.Add(time.Hour))
+	if resp.IsPast || resp.IsFree {
+		t.Errorf("Expected a running paid event, got is_past %v, is_free %v", resp.IsPast, resp.IsFree)
+	}
+}
+
+func TestProjectEvent_OnlySelectableFields(t *testing.T) {
+	if _, err := domain.ParseEventFields("is_past"); err == nil {
+		t.Error("Expected derived flags not to be selectable, since lists project them in Firestore")
+	}
+	if _, err := domain.ParseEventFields("dedup_key"); err == nil {
+		t.Error("Expected storage-only fields not to be selectable")
+	}
+
+	resp := service.NewEventResponse(&domain.Event{Id: "e1", City: "Warsaw"}, time.Now())
+	projected := domain.ProjectEvent(&resp, []string{"id", "city", "end_time"})
+	if len(projected) != 2 || projected["city"] != "Warsaw" {
+		t.Errorf("Expected id and city without the unset end_time, got %v", projected)
+	}
+	if all := domain.ProjectEvent(&resp, nil); all["is_free"] != true {
+		t.Errorf("Expected every field without a fieldset, got %v", all)
+	}
+}
@@ -0,0 +1,101 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/transport"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Guests never present a token, so the auth client is not needed to exercise the policy table
+func TestWithAuthProtection_DefaultPolicies_Guest(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := transport.WithAuthProtection(okHandler, nil, nil, "")
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/events/", http.StatusOK},
+		{http.MethodGet, "/events/123/tiers", http.StatusOK},
+		{http.MethodGet, "/organizers/", http.StatusOK},
+		{http.MethodPost, "/internal/tasks/import-chunk", http.StatusOK},
+		{http.MethodPost, "/internal/cron/daily", http.StatusOK},
+		{http.MethodGet, "/tracking/", http.StatusUnauthorized},
+		{http.MethodPost, "/events/123/rsvp", http.StatusUnauthorized},
+		{http.MethodPost, "/events/", http.StatusForbidden},
+		{http.MethodPut, "/events/123", http.StatusForbidden},
+		{http.MethodGet, "/events/123/attendees", http.StatusForbidden},
+		{http.MethodGet, "/admin/audit-logs", http.StatusForbidden},
+		{http.MethodGet, "/debug/pprof/heap", http.StatusForbidden},
+		{http.MethodGet, "/dev/seed", http.StatusForbidden},
+		{http.MethodDelete, "/tracking/123", http.StatusForbidden},
+		{http.MethodPost, "/new-resource", http.StatusForbidden},
+		// Versioned paths share the policies of their unversioned aliases
+		{http.MethodGet, "/v1/events/", http.StatusOK},
+		{http.MethodGet, "/v1/tracking/", http.StatusUnauthorized},
+		{http.MethodPost, "/v1/events/", http.StatusForbidden},
+		// HEAD follows the GET policy of the same route
+		{http.MethodHead, "/v1/events/123", http.StatusOK},
+		{http.MethodHead, "/healthz", http.StatusOK},
+		{http.MethodHead, "/tracking/", http.StatusUnauthorized},
+		{http.MethodHead, "/admin/audit-logs", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestWithAuthProtection_CustomPolicies(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	policies := []transport.RoutePolicy{
+		{Methods: []string{http.MethodGet}, Path: "/venues/**", Public: true},
+	}
+	handler := transport.WithAuthProtection(okHandler, nil, policies, "")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues/1", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a public custom route, got %d", w.Code)
+	}
+
+	// Unmatched routes fall back to admin-only
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an unmatched route, got %d", w.Code)
+	}
+}
+
+func TestLoadRoutePolicies(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	_ = os.WriteFile(valid, []byte(`[{"methods": ["GET"], "path": "/venues/**", "public": true}, {"path": "/**", "role": "admin"}]`), 0o600)
+	policies, err := transport.LoadRoutePolicies(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(policies) != 2 || !policies[0].Public || policies[1].Role != "admin" {
+		t.Errorf("Unexpected policies: %+v", policies)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	_ = os.WriteFile(invalid, []byte(`[{"path": "/venues", "role": "superuser"}]`), 0o600)
+	if _, err := transport.LoadRoutePolicies(invalid); err == nil {
+		t.Error("Expected an error for an unknown role")
+	}
+}
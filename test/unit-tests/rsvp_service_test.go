@@ -0,0 +1,85 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"testing"
+	"time"
+)
+
+// MockRSVPRepo for RSVP tests
+type MockRSVPRepo struct {
+	CreateFunc func(ctx context.Context, rsvp *domain.RSVP, capacity int) error
+	ListFunc   func(ctx context.Context, eventID string) ([]domain.RSVP, error)
+}
+
+func (m *MockRSVPRepo) CreateRSVP(ctx context.Context, rsvp *domain.RSVP, capacity int) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, rsvp, capacity)
+	}
+	return nil
+}
+
+func (m *MockRSVPRepo) ListAttendees(ctx context.Context, eventID string) ([]domain.RSVP, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, eventID)
+	}
+	return nil, nil
+}
+
+func TestRSVP_Success(t *testing.T) {
+	eventRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, Capacity: 10, AttendeeCount: 3, StartTime: time.Now().Add(24 * time.Hour)}, nil
+		},
+	}
+	rsvpRepo := &MockRSVPRepo{
+		CreateFunc: func(ctx context.Context, rsvp *domain.RSVP, capacity int) error {
+			if rsvp.Id != "user_1" || rsvp.UserID != "user_1" || rsvp.EventID != "evt_1" {
+				t.Errorf("Unexpected RSVP identity: %+v", rsvp)
+			}
+			if capacity != 10 {
+				t.Errorf("Expected capacity 10 to be passed to repository, got %d", capacity)
+			}
+			return nil
+		},
+	}
+
+	svc := service.NewRSVPService(rsvpRepo, eventRepo)
+	if _, err := svc.RSVP(context.Background(), "evt_1", "user_1", "a@b.com"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRSVP_CapacityReached(t *testing.T) {
+	eventRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id, Capacity: 2, AttendeeCount: 2, StartTime: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	rsvpRepo := &MockRSVPRepo{
+		CreateFunc: func(ctx context.Context, rsvp *domain.RSVP, capacity int) error {
+			t.Error("Repository should NOT be called for a full event")
+			return nil
+		},
+	}
+
+	svc := service.NewRSVPService(rsvpRepo, eventRepo)
+	_, err := svc.RSVP(context.Background(), "evt_1", "user_1", "")
+	if err == nil || err.Error() != "event is full" {
+		t.Errorf("Expected 'event is full', got %v", err)
+	}
+}
+
+func TestRSVP_Validation(t *testing.T) {
+	svc := service.NewRSVPService(&MockRSVPRepo{}, &test.MockRepository{})
+
+	if _, err := svc.RSVP(context.Background(), "evt_1", "", ""); err == nil {
+		t.Error("Expected error for missing user id")
+	}
+	if _, err := svc.RSVP(context.Background(), "", "user_1", ""); err == nil {
+		t.Error("Expected error for missing event id")
+	}
+}
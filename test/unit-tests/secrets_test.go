@@ -0,0 +1,36 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/secrets"
+	"context"
+	"testing"
+)
+
+func TestResolver_PlainValuesPassThrough(t *testing.T) {
+	r := secrets.NewResolver()
+
+	for _, val := range []string{"", "plain-value", "https://example.com"} {
+		got, err := r.Resolve(context.Background(), val)
+		if err != nil || got != val {
+			t.Errorf("Resolve(%q) = %q, %v; want the value unchanged", val, got, err)
+		}
+	}
+}
+
+func TestResolver_InvalidReference(t *testing.T) {
+	r := secrets.NewResolver()
+
+	if _, err := r.Resolve(context.Background(), "sm://my-secret"); err == nil {
+		t.Error("Expected an error for a reference without project and secret")
+	}
+}
+
+func TestResolver_Getenv(t *testing.T) {
+	t.Setenv("TEST_PLAIN_SETTING", "value")
+	r := secrets.NewResolver()
+
+	got, err := r.Getenv(context.Background(), "TEST_PLAIN_SETTING")
+	if err != nil || got != "value" {
+		t.Errorf("Getenv = %q, %v; want \"value\"", got, err)
+	}
+}
@@ -0,0 +1,116 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/seed"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/transport"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate_IsDeterministicAndWithinTheRequest(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	req, err := domain.SeedRequest{Events: 40, Cities: []string{"Warsaw", "Lodz"}, Seed: 3}.WithDefaults(now)
+	if err != nil {
+		t.Fatalf("WithDefaults failed: %v", err)
+	}
+
+	events, tracking := seed.Generate(req, now)
+	again, trackingAgain := seed.Generate(req, now)
+	if !reflect.DeepEqual(events, again) || !reflect.DeepEqual(tracking, trackingAgain) {
+		t.Fatal("Expected the same seed to generate the same data")
+	}
+	if len(events) != 40 || len(tracking) == 0 || len(tracking) > 40*domain.DefaultSeedTracking {
+		t.Fatalf("Expected 40 events with tracking, got %d and %d", len(events), len(tracking))
+	}
+	ids := make(map[string]bool)
+	for _, e := range events {
+		ids[e.Id] = true
+		if e.StartTime.Before(req.From) || !e.StartTime.Before(req.To) || !e.EndTime.After(e.StartTime) {
+			t.Errorf("Event %s runs %s to %s, outside [%s, %s)", e.Id, e.StartTime, e.EndTime, req.From, req.To)
+		}
+		if !slices.Contains(req.Cities, e.City) || !e.Type.IsValid() || e.EventName == "" {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	}
+	for _, tr := range tracking {
+		if !ids[tr.EventID] || tr.ClientEventID == "" || tr.CreatedAt.After(now) || tr.CreatedAt.Before(now.AddDate(0, 0, -seed.TrackingDays)) {
+			t.Errorf("Unexpected tracking %+v", tr)
+		}
+	}
+
+	if other, _ := seed.Generate(domain.SeedRequest{Events: 1, Cities: req.Cities, From: req.From, To: req.To, Seed: 4}, now); other[0].Id == events[0].Id {
+		t.Error("Expected other seeds to generate other Ids")
+	}
+}
+
+func TestSeedService_StoresAndReseedsInPlace(t *testing.T) {
+	ctx := context.Background()
+	eventRepo := repository.NewMemoryEventRepository()
+	trackingRepo := repository.NewMemoryTrackingRepository()
+	svc := service.NewSeedService(
+		service.NewEventService(eventRepo, &test.MockRevisionRepository{}),
+		service.NewTrackingService(trackingRepo),
+	)
+
+	req := domain.SeedRequest{Events: 12, TrackingPerEvent: 8, Seed: 7}
+	result, err := svc.Seed(ctx, req)
+	if err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	if result.Events != 12 || result.Tracking == 0 || result.Failed != 0 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	// Running the same seed again overwrites instead of adding
+	if _, err := svc.Seed(ctx, req); err != nil {
+		t.Fatalf("Second seed failed: %v", err)
+	}
+	events, _, _ := eventRepo.List(ctx, domain.SearchRequest{Sorting: domain.SortRequest{PageSize: 100}})
+	tracking, _ := trackingRepo.ListTracking(ctx)
+	if len(events) != 12 || len(tracking) != result.Tracking {
+		t.Errorf("Expected 12 events and %d tracking after reseeding, got %d and %d", result.Tracking, len(events), len(tracking))
+	}
+
+	_, err = svc.Seed(ctx, domain.SeedRequest{Events: domain.MaxSeedEvents + 1})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error above the limit, got %v", err)
+	}
+}
+
+func TestDevSeedEndpoint(t *testing.T) {
+	services := transport.Services{Events: &MockEventService{}, Tracking: &MockTrackingService{}}
+
+	w := httptest.NewRecorder()
+	transport.NewRouter(services).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/dev/seed", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without a seed service, got %d", w.Code)
+	}
+
+	events := service.NewEventService(repository.NewMemoryEventRepository(), &test.MockRevisionRepository{})
+	services.Seeds = service.NewSeedService(events, service.NewTrackingService(repository.NewMemoryTrackingRepository()))
+	router := transport.NewRouter(services)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/dev/seed", strings.NewReader(`{"events":3,"cities":["Lodz"]}`)))
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"events":3`) {
+		t.Errorf("Expected 3 seeded events, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/dev/seed", strings.NewReader(`{"event":3}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown field, got %d", w.Code)
+	}
+}
@@ -0,0 +1,115 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflightEventRepository_SharesConcurrentLists(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mock := &test.MockRepository{
+		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			calls.Add(1)
+			<-release
+			return []domain.Event{{Id: "1", EventName: "Shared"}}, domain.Meta{}, nil
+		},
+	}
+	repo := repository.NewSingleflightEventRepository(mock)
+
+	// Page size 0 and 20 are the same query
+	searches := []domain.SearchRequest{
+		{Filters: domain.FilterRequest{City: "Warsaw"}},
+		{Filters: domain.FilterRequest{City: "Warsaw"}, Sorting: domain.SortRequest{PageSize: 20}},
+	}
+	var wg sync.WaitGroup
+	results := make([][]domain.Event, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, _, err := repo.List(context.Background(), searches[i%2])
+			if err != nil {
+				t.Errorf("List failed: %v", err)
+			}
+			results[i] = events
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // let every caller join the flight
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected one query, got %d", n)
+	}
+	// Each caller owns its copy
+	results[0][0].EventName = "Changed"
+	if results[1][0].EventName != "Shared" {
+		t.Error("expected callers not to share the events slice")
+	}
+}
+
+func TestSingleflightEventRepository_DistinctQueriesRunSeparately(t *testing.T) {
+	var calls atomic.Int32
+	mock := &test.MockRepository{
+		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			calls.Add(1)
+			return nil, domain.Meta{}, nil
+		},
+	}
+	repo := repository.NewSingleflightEventRepository(mock)
+
+	// Differently cased filters seal different page tokens, so they are not merged
+	for _, city := range []string{"Warsaw", "warsaw", "Berlin"} {
+		_, _, _ = repo.List(context.Background(), domain.SearchRequest{Filters: domain.FilterRequest{City: city}})
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected three queries, got %d", n)
+	}
+}
+
+func TestSingleflightEventRepository_WaiterCancellation(t *testing.T) {
+	release := make(chan struct{})
+	mock := &test.MockRepository{
+		ListFunc: func(ctx context.Context, search domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			<-release
+			if ctx.Err() != nil {
+				return nil, domain.Meta{}, ctx.Err()
+			}
+			return []domain.Event{{Id: "1"}}, domain.Meta{}, nil
+		},
+	}
+	repo := repository.NewSingleflightEventRepository(mock)
+
+	// The caller that started the query goes away; a second caller still gets the page
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := repo.List(leaderCtx, domain.SearchRequest{})
+		leaderErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	followerDone := make(chan []domain.Event, 1)
+	go func() {
+		events, _, _ := repo.List(context.Background(), domain.SearchRequest{})
+		followerDone <- events
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to stop waiting, got %v", err)
+	}
+	close(release)
+	if events := <-followerDone; len(events) != 1 {
+		t.Errorf("expected the follower to get the page, got %v", events)
+	}
+}
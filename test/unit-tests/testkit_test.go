@@ -0,0 +1,63 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/repository"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/internal/testkit"
+	"bibently.com/backend/internal/transport"
+	"bibently.com/backend/test"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEventBuilder_BuildsValidEvents(t *testing.T) {
+	builder := testkit.NewEventBuilder().InCity("Gdansk").Free().StartingIn(2 * time.Hour).Lasting(3 * time.Hour)
+	event := builder.Build()
+
+	if event.City != "Gdansk" || event.CityLC != "gdansk" || event.Price != 0 || !event.Type.IsValid() {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if until := time.Until(event.StartTime); until < time.Hour || until > 2*time.Hour {
+		t.Errorf("Expected the event to start in 2 hours, got %s", until)
+	}
+	if event.EndTime.Sub(event.StartTime) != 3*time.Hour {
+		t.Errorf("Expected a 3 hour event, got %s to %s", event.StartTime, event.EndTime)
+	}
+	if other := testkit.NewEventBuilder().Build(); other.Id == event.Id {
+		t.Errorf("Expected builders to number their Ids, got %s twice", event.Id)
+	}
+	if err := domain.Validate.Struct(builder.BuildDTO()); err != nil {
+		t.Errorf("Expected a valid create request, got %v", err)
+	}
+
+	past := testkit.NewEventBuilder().StartingIn(-48 * time.Hour).Build()
+	if !past.EndTime.Before(time.Now()) {
+		t.Errorf("Expected a past event, got %s to %s", past.StartTime, past.EndTime)
+	}
+
+	tracking := testkit.NewTrackingBuilder().Action("click").ForEvent(event.Id).Ago(time.Hour).Build()
+	if tracking.Action != "click" || tracking.EventID != event.Id || time.Since(tracking.CreatedAt) < time.Hour {
+		t.Errorf("Unexpected tracking %+v", tracking)
+	}
+}
+
+func TestHTTPHelpers_AgainstTheRouter(t *testing.T) {
+	router := transport.NewRouter(transport.Services{
+		Events:   service.NewEventService(repository.NewMemoryEventRepository(), &test.MockRevisionRepository{}),
+		Tracking: service.NewTrackingService(repository.NewMemoryTrackingRepository()),
+	})
+
+	dto := testkit.NewEventBuilder().Named("Builder Concert").BuildDTO()
+	var id string
+	testkit.DecodeData(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodPost, "/events/", dto)), http.StatusCreated, &id)
+
+	var event domain.Event
+	testkit.DecodeData(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodGet, "/events/"+id, nil)), http.StatusOK, &event)
+	if event.EventName != "Builder Concert" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	testkit.AssertError(t, testkit.Serve(router, testkit.NewRequest(t, http.MethodPost, "/events/", `{"city":"Warsaw"}`)), http.StatusBadRequest, domain.CodeValidation)
+}
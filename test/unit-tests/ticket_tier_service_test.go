@@ -0,0 +1,124 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"bibently.com/backend/test"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockTicketTierRepo for ticket tier tests
+type MockTicketTierRepo struct {
+	ListFunc func(ctx context.Context, eventID string) ([]domain.TicketTier, error)
+	GetFunc  func(ctx context.Context, eventID, tierID string) (*domain.TicketTier, error)
+	SaveFunc func(ctx context.Context, tier *domain.TicketTier) error
+}
+
+func (m *MockTicketTierRepo) ListTiers(ctx context.Context, eventID string) ([]domain.TicketTier, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, eventID)
+	}
+	return nil, nil
+}
+
+func (m *MockTicketTierRepo) GetTier(ctx context.Context, eventID, tierID string) (*domain.TicketTier, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, eventID, tierID)
+	}
+	return nil, nil
+}
+
+func (m *MockTicketTierRepo) SaveTier(ctx context.Context, tier *domain.TicketTier) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, tier)
+	}
+	return nil
+}
+
+func TestCreateTier(t *testing.T) {
+	var flagged bool
+	eventRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return &domain.Event{Id: id}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, updates map[string]interface{}) error {
+			flagged = updates["has_tickets"] == true
+			return nil
+		},
+	}
+	tierRepo := &MockTicketTierRepo{
+		SaveFunc: func(ctx context.Context, tier *domain.TicketTier) error {
+			if tier.Id == "" || tier.EventID != "evt_1" || tier.CreatedAt.IsZero() {
+				return errors.New("tier identity not populated")
+			}
+			return nil
+		},
+	}
+
+	svc := service.NewTicketTierService(tierRepo, eventRepo)
+	tier := &domain.TicketTier{Name: "VIP", Price: 300, Quantity: 50}
+	if err := svc.CreateTier(context.Background(), "evt_1", tier); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !flagged {
+		t.Error("Expected event to be flagged with has_tickets=true")
+	}
+}
+
+func TestCreateTier_EventNotFound(t *testing.T) {
+	eventRepo := &test.MockRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			return nil, domain.ErrNotFound("event not found")
+		},
+	}
+	tierRepo := &MockTicketTierRepo{
+		SaveFunc: func(ctx context.Context, tier *domain.TicketTier) error {
+			t.Error("Tier should NOT be saved for a missing event")
+			return nil
+		},
+	}
+
+	svc := service.NewTicketTierService(tierRepo, eventRepo)
+	err := svc.CreateTier(context.Background(), "missing", &domain.TicketTier{Name: "VIP", Quantity: 1})
+	if err == nil || err.Error() != "event not found" {
+		t.Errorf("Expected 'event not found', got %v", err)
+	}
+}
+
+func TestUpdateTier_PreservesIdentity(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tierRepo := &MockTicketTierRepo{
+		GetFunc: func(ctx context.Context, eventID, tierID string) (*domain.TicketTier, error) {
+			return &domain.TicketTier{Id: tierID, EventID: eventID, Name: "Old", CreatedAt: createdAt}, nil
+		},
+		SaveFunc: func(ctx context.Context, tier *domain.TicketTier) error {
+			if tier.Id != "tier_1" || tier.EventID != "evt_1" || !tier.CreatedAt.Equal(createdAt) {
+				t.Errorf("Expected identity to be preserved, got %+v", tier)
+			}
+			if tier.Name != "New" {
+				t.Errorf("Expected name 'New', got '%s'", tier.Name)
+			}
+			return nil
+		},
+	}
+
+	svc := service.NewTicketTierService(tierRepo, &test.MockRepository{})
+	if err := svc.UpdateTier(context.Background(), "evt_1", "tier_1", &domain.TicketTier{Name: "New", Quantity: 10}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestTicketTierDTOToModel_InvertedSalesWindow(t *testing.T) {
+	dto := &domain.TicketTierDTO{
+		Name:       "Early Bird",
+		Quantity:   10,
+		SalesStart: "2024-06-10T00:00:00Z",
+		SalesEnd:   "2024-06-01T00:00:00Z",
+	}
+	if _, err := domain.TicketTierDTOToModel(dto); err == nil {
+		t.Error("Expected error for sales_end before sales_start")
+	}
+}
@@ -0,0 +1,125 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/tracing"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestParseCloudTraceContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantOK      bool
+		wantTrace   string
+		wantSampled bool
+	}{
+		{"Full", "105445aa7843bc8bf206b12000100000/1;o=1", true, "105445aa7843bc8bf206b12000100000", true},
+		{"Not sampled", "105445aa7843bc8bf206b12000100000/1;o=0", true, "105445aa7843bc8bf206b12000100000", false},
+		{"Trace only", "105445aa7843bc8bf206b12000100000", true, "105445aa7843bc8bf206b12000100000", false},
+		{"Invalid trace id", "not-a-trace/1;o=1", false, "", false},
+		{"Empty", "", false, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := tracing.ParseCloudTraceContext(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if sc.TraceID().String() != tt.wantTrace {
+				t.Errorf("Expected trace %s, got %s", tt.wantTrace, sc.TraceID())
+			}
+			if sc.IsSampled() != tt.wantSampled {
+				t.Errorf("Expected sampled=%v, got %v", tt.wantSampled, sc.IsSampled())
+			}
+		})
+	}
+}
+
+// TestWithTracing_ServerSpan verifies the request span continues the inbound trace, is named after the route
+// and is the active span in handler contexts
+func TestWithTracing_ServerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	var handlerSpan trace.SpanContext
+	mockSvc := &MockEventService{
+		GetFunc: func(ctx context.Context, id string) (*domain.Event, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return &domain.Event{Id: id}, nil
+		},
+	}
+	handler := transport.WithTracing(transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}}), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/evt-1", nil)
+	req.Header.Set(tracing.CloudTraceHeader, "105445aa7843bc8bf206b12000100000/1;o=1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 server span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /events/{id}" {
+		t.Errorf("Expected span named after the route, got %q", span.Name())
+	}
+	if span.SpanContext().TraceID().String() != "105445aa7843bc8bf206b12000100000" {
+		t.Errorf("Expected the inbound trace to be continued, got %s", span.SpanContext().TraceID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("Expected the handler context to carry the server span")
+	}
+}
+
+func TestWithTracing_FlushesSampledRequests(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	var flushes, endedAtFlush int
+	flush := func(ctx context.Context) error {
+		flushes++
+		endedAtFlush = len(recorder.Ended())
+		return nil
+	}
+	handler := transport.WithTracing(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), flush)
+
+	tests := []struct {
+		name        string
+		header      string
+		wantFlushes int
+	}{
+		{"Sampled", "105445aa7843bc8bf206b12000100000/1;o=1", 1},
+		{"Not sampled", "105445aa7843bc8bf206b12000100000/1;o=0", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flushes, endedAtFlush = 0, 0
+			req := httptest.NewRequest(http.MethodGet, "/events/evt-1", nil)
+			req.Header.Set(tracing.CloudTraceHeader, tt.header)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if flushes != tt.wantFlushes {
+				t.Errorf("Expected %d flushes, got %d", tt.wantFlushes, flushes)
+			}
+			if flushes > 0 && endedAtFlush == 0 {
+				t.Error("Expected the server span to be ended before the flush")
+			}
+		})
+	}
+}
@@ -11,8 +11,63 @@ import (
 
 // MockTrackingRepo for tracking tests
 type MockTrackingRepo struct {
-	SaveFunc func(ctx context.Context, t *domain.TrackingEvent) error
-	ListFunc func(ctx context.Context) ([]domain.TrackingEvent, error)
+	SaveFunc    func(ctx context.Context, t *domain.TrackingEvent) error
+	ListFunc    func(ctx context.Context) ([]domain.TrackingEvent, error)
+	PurgeFunc   func(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	StatsFunc   func(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error)
+	SessionFunc func(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error)
+	Streamed    []domain.TrackingEvent
+	// DeleteWhereFunc defaults to deleting nothing
+	DeleteWhereFunc func(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error)
+}
+
+func (m *MockTrackingRepo) DeleteTracking(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *MockTrackingRepo) DeleteTrackingWhere(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error) {
+	if m.DeleteWhereFunc != nil {
+		return m.DeleteWhereFunc(ctx, filter, limit)
+	}
+	return 0, nil
+}
+
+func (m *MockTrackingRepo) StreamTracking(ctx context.Context, since time.Time, fn func(domain.TrackingEvent) error) error {
+	for _, t := range m.Streamed {
+		if t.CreatedAt.Before(since) {
+			continue
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockTrackingRepo) WatchTracking(ctx context.Context, fn func(domain.TrackingEvent) error) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (m *MockTrackingRepo) ListSession(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+	if m.SessionFunc != nil {
+		return m.SessionFunc(ctx, sessionID)
+	}
+	return nil, nil
+}
+
+func (m *MockTrackingRepo) TrackingStats(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, interval, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockTrackingRepo) PurgeTracking(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	if m.PurgeFunc != nil {
+		return m.PurgeFunc(ctx, cutoff, limit)
+	}
+	return 0, nil
 }
 
 func (m *MockTrackingRepo) SaveTracking(ctx context.Context, t *domain.TrackingEvent) error {
@@ -88,3 +143,294 @@ func TestTrackEvent_Success(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestPurgeOldTracking_DefaultRetention(t *testing.T) {
+	var gotCutoff time.Time
+	mockRepo := &MockTrackingRepo{
+		PurgeFunc: func(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+			gotCutoff = cutoff
+			return 12, nil
+		},
+	}
+	svc := service.NewTrackingService(mockRepo)
+
+	result, err := svc.PurgeOldTracking(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Purged != 12 || result.Remaining {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := time.Now().UTC().AddDate(0, 0, -domain.DefaultTrackingRetentionDays)
+	if d := want.Sub(gotCutoff); d < 0 || d > time.Minute {
+		t.Errorf("expected cutoff near %v, got %v", want, gotCutoff)
+	}
+
+	if _, err := svc.PurgeOldTracking(context.Background(), -1); err == nil {
+		t.Error("expected validation error for negative retention")
+	}
+}
+
+func TestEventPopularity_WeighsRecentClicks(t *testing.T) {
+	now := time.Now().UTC()
+	mockRepo := &MockTrackingRepo{Streamed: []domain.TrackingEvent{
+		{Action: "view", EventID: "evt_a", CreatedAt: now},
+		{Action: "view", EventID: "evt_a", CreatedAt: now},
+		{Action: "click", EventID: "evt_b", CreatedAt: now},
+		{Action: "click", EventID: "evt_c", CreatedAt: now.Add(-2 * domain.PopularityHalfLife)},
+		{Action: "scroll", EventID: "evt_d", CreatedAt: now},
+		{Action: "click", CreatedAt: now},
+		{Action: "click", EventID: "evt_e", CreatedAt: now.AddDate(0, 0, -domain.PopularityWindowDays-1)},
+	}}
+	svc := service.NewTrackingService(mockRepo)
+
+	scores, err := svc.EventPopularity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scores) != 3 {
+		t.Fatalf("expected scores for evt_a, evt_b and evt_c only, got %v", scores)
+	}
+	// One fresh click outweighs two fresh views; two half-lives cut a click to 1/4
+	if !(scores["evt_b"] > scores["evt_a"] && scores["evt_a"] > scores["evt_c"]) {
+		t.Errorf("unexpected ranking: %v", scores)
+	}
+	if c := scores["evt_c"]; c < 0.74 || c > 0.76 {
+		t.Errorf("expected a decayed click near 3/4, got %v", c)
+	}
+}
+
+func TestDeleteTrackingWhere(t *testing.T) {
+	mockRepo := &MockTrackingRepo{
+		DeleteWhereFunc: func(ctx context.Context, filter domain.TrackingDeleteFilter, limit int) (int, error) {
+			return limit, nil
+		},
+	}
+	svc := service.NewTrackingService(mockRepo)
+
+	result, err := svc.DeleteTrackingWhere(context.Background(), domain.TrackingDeleteFilter{Action: "test_click"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Purged == 0 || !result.Remaining {
+		t.Errorf("expected a capped run to report remaining events, got %+v", result)
+	}
+
+	// An empty filter would wipe all tracking
+	var validationErr *domain.ValidationError
+	if _, err := svc.DeleteTrackingWhere(context.Background(), domain.TrackingDeleteFilter{}); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for an empty filter, got %v", err)
+	}
+}
+
+func TestGetTrackingStats_FillsBuckets(t *testing.T) {
+	to := time.Date(2026, 5, 3, 15, 30, 0, 0, time.UTC)
+	var gotFrom, gotTo time.Time
+	mockRepo := &MockTrackingRepo{
+		StatsFunc: func(ctx context.Context, interval string, from, to time.Time) ([]domain.TrackingStats, error) {
+			gotFrom, gotTo = from, to
+			return []domain.TrackingStats{{Start: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), Counts: map[string]int64{"view": 3}, Total: 3}}, nil
+		},
+	}
+	svc := service.NewTrackingService(mockRepo)
+
+	stats, err := svc.GetTrackingStats(context.Background(), "", "", time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC), to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The window widens to whole days, including the day of to
+	if !gotFrom.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) || !gotTo.Equal(time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected window [%v, %v)", gotFrom, gotTo)
+	}
+	if len(stats) != 3 || stats[0].Total != 0 || stats[1].Counts["view"] != 3 || stats[2].Counts == nil {
+		t.Errorf("expected 3 daily buckets with the counts on the second, got %+v", stats)
+	}
+}
+
+func TestGetTrackingStats_Validation(t *testing.T) {
+	svc := service.NewTrackingService(&MockTrackingRepo{})
+	ctx := context.Background()
+	now := time.Now()
+
+	cases := map[string]func() error{
+		"unknown group_by": func() error {
+			_, err := svc.GetTrackingStats(ctx, "user_name", "day", time.Time{}, time.Time{})
+			return err
+		},
+		"unknown interval": func() error {
+			_, err := svc.GetTrackingStats(ctx, "action", "minute", time.Time{}, time.Time{})
+			return err
+		},
+		"from after to": func() error {
+			_, err := svc.GetTrackingStats(ctx, "action", "day", now.AddDate(0, 0, 2), now)
+			return err
+		},
+		"too many buckets": func() error {
+			_, err := svc.GetTrackingStats(ctx, "action", "hour", now.AddDate(0, 0, -8), now)
+			return err
+		},
+	}
+	for name, call := range cases {
+		var validation *domain.ValidationError
+		if err := call(); !errors.As(err, &validation) {
+			t.Errorf("%s: expected ValidationError, got %v", name, err)
+		}
+	}
+}
+
+func TestTrackEvent_Expiry(t *testing.T) {
+	var saved *domain.TrackingEvent
+	mockRepo := &MockTrackingRepo{
+		SaveFunc: func(ctx context.Context, tr *domain.TrackingEvent) error {
+			saved = tr
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	// Without the option nothing expires
+	_ = service.NewTrackingService(mockRepo).TrackEvent(ctx, &domain.TrackingEvent{Action: "click"})
+	if !saved.ExpireAt.IsZero() {
+		t.Errorf("expected no expire_at, got %v", saved.ExpireAt)
+	}
+
+	svc := service.NewTrackingService(mockRepo, service.WithTrackingExpiry(30))
+	if err := svc.TrackEvent(ctx, &domain.TrackingEvent{Action: "click"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := saved.ExpireAt.Sub(saved.CreatedAt); got != 30*24*time.Hour {
+		t.Errorf("expected expire_at 30 days after created_at, got %v", got)
+	}
+}
+
+func TestTrackEvent_BindsUserFromContext(t *testing.T) {
+	var saved *domain.TrackingEvent
+	svc := service.NewTrackingService(&MockTrackingRepo{
+		SaveFunc: func(ctx context.Context, tr *domain.TrackingEvent) error {
+			saved = tr
+			return nil
+		},
+	})
+
+	ctx := domain.WithActor(context.Background(), "user-1")
+	if err := svc.TrackEvent(ctx, &domain.TrackingEvent{Action: "click", SessionID: "s1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.UserID != "user-1" || saved.SessionID != "s1" {
+		t.Errorf("expected user-1 in session s1, got %+v", saved)
+	}
+
+	// Anonymous callers cannot claim a user
+	_ = svc.TrackEvent(context.Background(), &domain.TrackingEvent{Action: "click", UserID: "someone"})
+	if saved.UserID != "" {
+		t.Errorf("expected an anonymous event, got user %q", saved.UserID)
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	svc := service.NewTrackingService(&MockTrackingRepo{
+		SessionFunc: func(ctx context.Context, sessionID string) ([]domain.TrackingEvent, error) {
+			return []domain.TrackingEvent{{SessionID: sessionID}}, nil
+		},
+	})
+	if tracks, err := svc.GetSession(context.Background(), "s1"); err != nil || len(tracks) != 1 {
+		t.Errorf("expected the session's events, got %v %v", tracks, err)
+	}
+	var validation *domain.ValidationError
+	if _, err := svc.GetSession(context.Background(), ""); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestTrackEvent_ClientEventIDIsStable(t *testing.T) {
+	var ids []string
+	svc := service.NewTrackingService(&MockTrackingRepo{
+		SaveFunc: func(ctx context.Context, tr *domain.TrackingEvent) error {
+			ids = append(ids, tr.Id)
+			return nil
+		},
+	})
+	alice := domain.WithActor(context.Background(), "alice")
+	bob := domain.WithActor(context.Background(), "bob")
+
+	for _, ctx := range []context.Context{alice, alice, bob} {
+		if err := svc.TrackEvent(ctx, &domain.TrackingEvent{Action: "click", ClientEventID: "evt-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if ids[0] != ids[1] {
+		t.Errorf("expected a resend to reuse the document Id, got %v", ids)
+	}
+	if ids[0] == ids[2] {
+		t.Errorf("expected another user's Id to differ, got %v", ids)
+	}
+}
+
+func TestTrackEvent_Privacy(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    domain.TrackingPrivacy
+		wantRaw bool
+		wantUA  bool
+	}{
+		{"Off keeps the user agent", domain.TrackingPrivacyOff, true, true},
+		{"Hash replaces it", domain.TrackingPrivacyHash, false, true},
+		{"Drop removes it", domain.TrackingPrivacyDrop, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved []string
+			svc := service.NewTrackingService(&MockTrackingRepo{
+				SaveFunc: func(ctx context.Context, tr *domain.TrackingEvent) error {
+					saved = append(saved, tr.UserAgent)
+					return nil
+				},
+			}, service.WithTrackingPrivacy(tt.mode, []byte("key")))
+
+			for range 2 {
+				if err := svc.TrackEvent(context.Background(), &domain.TrackingEvent{Action: "view", UserAgent: "Mozilla/5.0"}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if (saved[0] == "Mozilla/5.0") != tt.wantRaw || (saved[0] != "") != tt.wantUA {
+				t.Errorf("unexpected user agent %q", saved[0])
+			}
+			if saved[0] != saved[1] {
+				t.Errorf("expected a stable value, got %v", saved)
+			}
+		})
+	}
+}
+
+type MockTrackingExporter struct {
+	Exported []domain.TrackingEvent
+	Err      error
+}
+
+func (m *MockTrackingExporter) ExportTracking(ctx context.Context, events []domain.TrackingEvent) error {
+	m.Exported = append(m.Exported, events...)
+	return m.Err
+}
+
+func TestTrackEvent_Export(t *testing.T) {
+	exporter := &MockTrackingExporter{Err: errors.New("bigquery unavailable")}
+	saveErr := error(nil)
+	svc := service.NewTrackingService(&MockTrackingRepo{
+		SaveFunc: func(ctx context.Context, tr *domain.TrackingEvent) error { return saveErr },
+	}, service.WithTrackingExporter(exporter))
+
+	// A failed export does not fail the request
+	if err := svc.TrackEvent(context.Background(), &domain.TrackingEvent{Action: "view"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exporter.Exported) != 1 || exporter.Exported[0].Id == "" {
+		t.Fatalf("expected the stored event to be exported, got %+v", exporter.Exported)
+	}
+
+	// Events that were not stored are not exported
+	saveErr = errors.New("firestore unavailable")
+	_ = svc.TrackEvent(context.Background(), &domain.TrackingEvent{Action: "view"})
+	if len(exporter.Exported) != 1 {
+		t.Errorf("expected no export after a failed save, got %d", len(exporter.Exported))
+	}
+}
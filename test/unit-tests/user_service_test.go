@@ -0,0 +1,76 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/service"
+	"context"
+	"errors"
+	"testing"
+)
+
+type MockUserRepo struct {
+	DeleteFunc func(ctx context.Context, uid string) (*domain.DataDeletionReport, error)
+}
+
+func (m *MockUserRepo) GetProfile(ctx context.Context, uid string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepo) DeleteUserData(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, uid)
+	}
+	return &domain.DataDeletionReport{UserID: uid}, nil
+}
+
+func TestDeleteUserData_Authorization(t *testing.T) {
+	tests := []struct {
+		name          string
+		principal     domain.Principal
+		uid           string
+		wantForbidden bool
+	}{
+		{"Self", domain.Principal{UID: "alice"}, "alice", false},
+		{"Another user", domain.Principal{UID: "bob"}, "alice", true},
+		{"Organizer for another user", domain.Principal{UID: "bob", Role: domain.RoleOrganizer}, "alice", true},
+		{"Admin for another user", domain.Principal{UID: "root", Role: domain.RoleAdmin}, "alice", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			svc := service.NewUserService(&MockUserRepo{
+				DeleteFunc: func(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+					deleted = true
+					return &domain.DataDeletionReport{UserID: uid}, nil
+				},
+			})
+
+			_, err := svc.DeleteUserData(domain.WithPrincipal(context.Background(), tt.principal), tt.uid)
+			var forbidden *domain.ForbiddenError
+			if errors.As(err, &forbidden) != tt.wantForbidden || deleted == tt.wantForbidden {
+				t.Errorf("Expected forbidden=%v, got err %v (deleted %v)", tt.wantForbidden, err, deleted)
+			}
+		})
+	}
+}
+
+func TestDeleteUserData_ForbiddenWithoutPrincipal(t *testing.T) {
+	svc := service.NewUserService(&MockUserRepo{
+		DeleteFunc: func(ctx context.Context, uid string) (*domain.DataDeletionReport, error) {
+			t.Error("data deleted without a principal")
+			return nil, nil
+		},
+	})
+	var forbidden *domain.ForbiddenError
+	if _, err := svc.DeleteUserData(context.Background(), "alice"); !errors.As(err, &forbidden) {
+		t.Errorf("Expected ForbiddenError, got %v", err)
+	}
+}
+
+func TestDeleteUserData_Validation(t *testing.T) {
+	svc := service.NewUserService(&MockUserRepo{})
+	var validationErr *domain.ValidationError
+	if _, err := svc.DeleteUserData(context.Background(), ""); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package unit_tests
+
+import (
+	"bibently.com/backend/internal/domain"
+	"bibently.com/backend/internal/transport"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResolveWhen(t *testing.T) {
+	warsaw, _ := time.LoadLocation("Europe/Warsaw")
+	// Wednesday 2025-07-16 23:30 UTC is already Thursday 01:30 in Warsaw
+	now := time.Date(2025, 7, 16, 23, 30, 0, 0, time.UTC)
+
+	t.Run("Today_UsesCallerTimezone", func(t *testing.T) {
+		start, end := domain.ResolveWhen(domain.WhenToday, now, warsaw)
+		wantStart := time.Date(2025, 7, 17, 0, 0, 0, 0, warsaw)
+		if start == nil || !start.Equal(wantStart) {
+			t.Errorf("Expected start %v, got %v", wantStart, start)
+		}
+		if end == nil || end.Sub(*start) != 24*time.Hour-time.Nanosecond {
+			t.Errorf("Expected end one day after start, got %v", end)
+		}
+	})
+
+	t.Run("ThisWeekend_FromWeekday", func(t *testing.T) {
+		start, end := domain.ResolveWhen(domain.WhenThisWeekend, now, warsaw)
+		if start.Weekday() != time.Saturday || start.Day() != 19 {
+			t.Errorf("Expected Saturday 19th, got %v", start)
+		}
+		if end.Weekday() != time.Sunday || end.Day() != 20 {
+			t.Errorf("Expected Sunday 20th, got %v", end)
+		}
+	})
+
+	t.Run("ThisWeekend_OnSunday", func(t *testing.T) {
+		sunday := time.Date(2025, 7, 20, 12, 0, 0, 0, time.UTC)
+		start, _ := domain.ResolveWhen(domain.WhenThisWeekend, sunday, time.UTC)
+		if start.Day() != 19 {
+			t.Errorf("Expected the current weekend to start Saturday 19th, got %v", start)
+		}
+	})
+
+	t.Run("UpcomingAndPast_AreOpenEnded", func(t *testing.T) {
+		if start, end := domain.ResolveWhen(domain.WhenUpcoming, now, time.UTC); start == nil || end != nil {
+			t.Errorf("Expected only a start bound for upcoming, got %v - %v", start, end)
+		}
+		if start, end := domain.ResolveWhen(domain.WhenPast, now, time.UTC); start != nil || end == nil {
+			t.Errorf("Expected only an end bound for past, got %v - %v", start, end)
+		}
+	})
+}
+
+func TestHandler_ListEvents_When(t *testing.T) {
+	var got domain.FilterRequest
+	mockSvc := &MockEventService{
+		ListFunc: func(ctx context.Context, req domain.SearchRequest) ([]domain.Event, domain.Meta, error) {
+			got = req.Filters
+			return []domain.Event{}, domain.Meta{}, nil
+		},
+	}
+	router := transport.NewRouter(transport.Services{Events: mockSvc, Tracking: &MockTrackingService{}})
+
+	// 1. Valid window is translated into both bounds
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?when=today&tz=Europe/Warsaw", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", w.Code)
+	}
+	if got.StartDate == nil || got.EndDate == nil {
+		t.Error("Expected StartDate and EndDate to be set for when=today")
+	}
+
+	// 2. Invalid combinations are rejected
+	for _, query := range []string{
+		"when=tomorrow",
+		"when=today&tz=Mars/Olympus",
+		"when=upcoming&start_date=2025-01-01T00:00:00Z",
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events/?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %d", query, w.Code)
+		}
+	}
+}